@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -13,15 +14,24 @@ import (
 	"github.com/device-management-toolkit/console/config"
 	"github.com/device-management-toolkit/console/internal/app"
 	"github.com/device-management-toolkit/console/internal/controller/openapi"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/usecase"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
 	"github.com/device-management-toolkit/console/pkg/logger"
 )
 
+// backupFilePermission keeps backups, which hold every secret, readable by their owner only.
+const backupFilePermission = 0o600
+
 // Function pointers for better testability.
 var (
 	initializeConfigFunc = config.NewConfig
 	initializeAppFunc    = app.Init
 	runAppFunc           = app.Run
+	rotateKeyFunc        = app.RotateEncryptionKey
+	backupFunc           = app.Backup
+	restoreFunc          = app.Restore
+	migratePostgresFunc  = app.MigrateToPostgres
 	// NewGeneratorFunc allows tests to inject a fake OpenAPI generator.
 	NewGeneratorFunc = func(u usecase.Usecases, l logger.Interface) interface {
 		GenerateSpec() ([]byte, error)
@@ -44,9 +54,43 @@ func main() {
 
 	handleEncryptionKey(cfg)
 
+	if args := flag.Args(); len(args) > 0 {
+		switch args[0] {
+		case "rotate-key":
+			if err := handleRotateKey(cfg, args[1:]); err != nil {
+				log.Fatalf("Key rotation error: %s", err)
+			}
+
+			return
+		case "backup":
+			if err := handleBackup(cfg, args[1:]); err != nil {
+				log.Fatalf("Backup error: %s", err)
+			}
+
+			return
+		case "restore":
+			if err := handleRestore(cfg, args[1:]); err != nil {
+				log.Fatalf("Restore error: %s", err)
+			}
+
+			return
+		case "migrate-postgres":
+			if err := handleMigratePostgres(args[1:]); err != nil {
+				log.Fatalf("Migration error: %s", err)
+			}
+
+			return
+		}
+	}
+
 	if os.Getenv("GIN_MODE") != "debug" {
 		go func() {
-			browserError := openBrowser("http://localhost:"+cfg.Port, runtime.GOOS)
+			scheme := "http://"
+			if cfg.HTTP.TLS.Enabled {
+				scheme = "https://"
+			}
+
+			browserError := openBrowser(scheme+"localhost:"+cfg.Port, runtime.GOOS)
 			if browserError != nil {
 				panic(browserError)
 			}
@@ -84,6 +128,131 @@ func handleOpenAPIGeneration() error {
 	return nil
 }
 
+// handleRotateKey runs the rotate-key subcommand, which encrypts the stored secrets again with a new key.
+func handleRotateKey(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("rotate-key", flag.ContinueOnError)
+	newKey := flags.String("new-key", "", "new encryption key of 16, 24 or 32 characters, generated when empty")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := rotateKeyFunc(cfg, *newKey)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Encryption key rotated, %d secrets encrypted again", result.Secrets)
+
+	switch {
+	case result.KeyringUpdated:
+		log.Print("The new key is stored in the keyring")
+	case result.Key != "":
+		log.Printf("Set APP_ENCRYPTION_KEY to the new key before the next start: %s", result.Key)
+	default:
+		log.Print("Set APP_ENCRYPTION_KEY to the new key before the next start")
+	}
+
+	return nil
+}
+
+// handleBackup runs the backup subcommand, which writes a backup of the console encrypted with a passphrase.
+func handleBackup(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := flags.String("out", "console_backup.json", "file the backup is written to")
+	passphrase := flags.String("passphrase", os.Getenv("BACKUP_PASSPHRASE"), "passphrase the backup is encrypted with, BACKUP_PASSPHRASE by default")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	archive, err := backupFunc(cfg, *passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, archive, backupFilePermission); err != nil {
+		return err
+	}
+
+	log.Printf("Backup written to %s", *out)
+
+	return nil
+}
+
+// handleRestore runs the restore subcommand, which prints what restoring a backup changes and restores it
+// with -apply.
+func handleRestore(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	in := flags.String("in", "console_backup.json", "file the backup is read from")
+	passphrase := flags.String("passphrase", os.Getenv("BACKUP_PASSPHRASE"), "passphrase of the backup, BACKUP_PASSPHRASE by default")
+	mode := flags.String("mode", dto.RestoreMerge, "merge keeps the stored rows the backup does not have, replace deletes them")
+	apply := flags.Bool("apply", false, "restore the backup instead of only printing the changes")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	archive, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+
+	result, err := restoreFunc(cfg, archive, *passphrase, *mode, !*apply)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range result.Tables {
+		log.Printf("%s: %d added, %d updated, %d deleted, %d unchanged", t.Table, len(t.Added), len(t.Updated), len(t.Deleted), t.Unchanged)
+
+		for _, key := range t.Added {
+			log.Printf("  + %s", key)
+		}
+
+		for _, key := range t.Updated {
+			log.Printf("  ~ %s", key)
+		}
+
+		for _, key := range t.Deleted {
+			log.Printf("  - %s", key)
+		}
+	}
+
+	if result.DryRun {
+		log.Print("Nothing was changed, run again with -apply to restore the backup")
+	} else {
+		log.Printf("Backup restored in %s mode", result.Mode)
+	}
+
+	return nil
+}
+
+// handleMigratePostgres runs the migrate-postgres subcommand, which copies the embedded database to Postgres.
+func handleMigratePostgres(args []string) error {
+	flags := flag.NewFlagSet("migrate-postgres", flag.ContinueOnError)
+	target := flags.String("target", "", "postgres:// or postgresql:// URL of the database to copy to, it must not hold any data")
+	source := flags.String("source", "", "SQLite database to copy from, the embedded database by default")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	copied, err := migratePostgresFunc(*target, *source)
+
+	for _, t := range copied {
+		log.Printf("%s: %d rows copied and verified, checksum %s", t.Name, t.Rows, t.Checksum)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	log.Print("Set DB_URL to the target to run the console on Postgres")
+
+	return nil
+}
+
 func handleEncryptionKey(cfg *config.Config) {
 	toolkitCrypto := security.Crypto{}
 
@@ -91,11 +260,11 @@ func handleEncryptionKey(cfg *config.Config) {
 		return
 	}
 
-	secureStorage := security.NewKeyRingStorage("device-management-toolkit")
+	secureStorage := security.NewKeyRingStorage(encryption.KeyringService)
 
 	var err error
 
-	cfg.EncryptionKey, err = secureStorage.GetKeyValue("default-security-key")
+	cfg.EncryptionKey, err = secureStorage.GetKeyValue(encryption.KeyringKey)
 	if err == nil {
 		return
 	}
@@ -129,7 +298,7 @@ func handleKeyNotFound(cfg *config.Config, toolkitCrypto security.Crypto, secure
 
 	cfg.EncryptionKey = toolkitCrypto.GenerateKey()
 
-	err = secureStorage.SetKeyValue("default-security-key", cfg.EncryptionKey)
+	err = secureStorage.SetKeyValue(encryption.KeyringKey, cfg.EncryptionKey)
 	if err != nil {
 		log.Fatal(err)
 	}
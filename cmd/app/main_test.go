@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/usecase"
+	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
 )
 
@@ -135,3 +137,98 @@ func TestHandleOpenAPIGeneration_GenerateFails(t *testing.T) {
 
 	mockGen.AssertExpectations(t)
 }
+
+//nolint:paralleltest // modifies package-level rotateKeyFunc
+func TestHandleRotateKey(t *testing.T) {
+	cfg := &config.Config{App: config.App{EncryptionKey: "0123456789abcdef"}}
+
+	var gotKey string
+
+	rotateKeyFunc = func(_ *config.Config, newKey string) (*dto.KeyRotation, error) {
+		gotKey = newKey
+
+		return &dto.KeyRotation{Secrets: 2, KeyringUpdated: true}, nil
+	}
+
+	err := handleRotateKey(cfg, []string{"-new-key", "fedcba9876543210"})
+	assert.NoError(t, err)
+	assert.Equal(t, "fedcba9876543210", gotKey)
+
+	rotateKeyFunc = func(_ *config.Config, _ string) (*dto.KeyRotation, error) {
+		return nil, assert.AnError
+	}
+
+	err = handleRotateKey(cfg, nil)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	err = handleRotateKey(cfg, []string{"-unknown"})
+	assert.Error(t, err)
+}
+
+//nolint:paralleltest // modifies package-level backupFunc and restoreFunc
+func TestHandleBackupAndRestore(t *testing.T) {
+	cfg := &config.Config{}
+	file := t.TempDir() + "/backup.json"
+
+	backupFunc = func(_ *config.Config, passphrase string) ([]byte, error) {
+		assert.Equal(t, "correct horse battery staple", passphrase)
+
+		return []byte("archive"), nil
+	}
+
+	err := handleBackup(cfg, []string{"-out", file, "-passphrase", "correct horse battery staple"})
+	assert.NoError(t, err)
+
+	var gotArchive []byte
+
+	var gotDryRun bool
+
+	restoreFunc = func(_ *config.Config, archive []byte, _, mode string, dryRun bool) (*dto.Restore, error) {
+		gotArchive = archive
+		gotDryRun = dryRun
+
+		return &dto.Restore{Mode: mode, DryRun: dryRun, Tables: []dto.RestoreTableChange{{Table: "profiles", Added: []string{"p1"}}}}, nil
+	}
+
+	err = handleRestore(cfg, []string{"-in", file, "-passphrase", "correct horse battery staple"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("archive"), gotArchive)
+	assert.True(t, gotDryRun)
+
+	err = handleRestore(cfg, []string{"-in", file, "-apply"})
+	assert.NoError(t, err)
+	assert.False(t, gotDryRun)
+
+	err = handleRestore(cfg, []string{"-in", t.TempDir() + "/missing.json"})
+	assert.Error(t, err)
+
+	backupFunc = func(_ *config.Config, _ string) ([]byte, error) {
+		return nil, assert.AnError
+	}
+
+	err = handleBackup(cfg, []string{"-out", file})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+//nolint:paralleltest // modifies package-level migratePostgresFunc
+func TestHandleMigratePostgres(t *testing.T) {
+	var gotTarget, gotSource string
+
+	migratePostgresFunc = func(target, source string) ([]db.CopiedTable, error) {
+		gotTarget, gotSource = target, source
+
+		return []db.CopiedTable{{Name: "profiles", Rows: 2, Checksum: "abc"}}, nil
+	}
+
+	err := handleMigratePostgres([]string{"-target", "postgres://localhost/console", "-source", "console.db"})
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres://localhost/console", gotTarget)
+	assert.Equal(t, "console.db", gotSource)
+
+	migratePostgresFunc = func(_, _ string) ([]db.CopiedTable, error) {
+		return nil, assert.AnError
+	}
+
+	err = handleMigratePostgres(nil)
+	assert.ErrorIs(t, err, assert.AnError)
+}
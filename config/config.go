@@ -16,12 +16,16 @@ var ConsoleConfig *Config
 type (
 	// Config -.
 	Config struct {
-		App  `yaml:"app"`
-		HTTP `yaml:"http"`
-		Log  `yaml:"logger"`
-		DB   `yaml:"postgres"`
-		EA   `yaml:"ea"`
-		Auth `yaml:"auth"`
+		App         `yaml:"app"`
+		HTTP        `yaml:"http"`
+		Log         `yaml:"logger"`
+		DB          `yaml:"postgres"`
+		EA          `yaml:"ea"`
+		Auth        `yaml:"auth"`
+		Redirection `yaml:"redirection"`
+		Secrets     `yaml:"secrets"`
+		CertMonitor `yaml:"certMonitor"`
+		Trash       `yaml:"trash"`
 	}
 
 	// App -.
@@ -40,6 +44,20 @@ type (
 		AllowedOrigins []string `env-required:"true" yaml:"allowed_origins" env:"HTTP_ALLOWED_ORIGINS"`
 		AllowedHeaders []string `env-required:"true" yaml:"allowed_headers" env:"HTTP_ALLOWED_HEADERS"`
 		WSCompression  bool     `yaml:"ws_compression" env:"WS_COMPRESSION"`
+		RequireIfMatch bool     `yaml:"require_if_match" env:"HTTP_REQUIRE_IF_MATCH"`
+		TLS            TLS      `yaml:"tls"`
+	}
+
+	// TLS serves the console over HTTPS. The files are loaded again when they change, client certificates are
+	// required when ClientCAFile is set unless ClientAuth is "optional".
+	TLS struct {
+		Enabled        bool          `yaml:"enabled" env:"HTTP_TLS_ENABLED"`
+		CertFile       string        `yaml:"cert_file" env:"HTTP_TLS_CERT_FILE"`
+		KeyFile        string        `yaml:"key_file" env:"HTTP_TLS_KEY_FILE"`
+		MinVersion     string        `yaml:"min_version" env:"HTTP_TLS_MIN_VERSION"`
+		ClientCAFile   string        `yaml:"client_ca_file" env:"HTTP_TLS_CLIENT_CA_FILE"`
+		ClientAuth     string        `yaml:"client_auth" env:"HTTP_TLS_CLIENT_AUTH"`
+		ReloadInterval time.Duration `yaml:"reload_interval" env:"HTTP_TLS_RELOAD_INTERVAL"`
 	}
 
 	// Log -.
@@ -68,11 +86,74 @@ type (
 		JWTKey                   string        `env-required:"true" yaml:"jwtKey" env:"AUTH_JWT_KEY"`
 		JWTExpiration            time.Duration `yaml:"jwtExpiration" env:"AUTH_JWT_EXPIRATION"`
 		RedirectionJWTExpiration time.Duration `yaml:"redirectionJWTExpiration" env:"AUTH_REDIRECTION_JWT_EXPIRATION"`
+		RefreshTokenExpiration   time.Duration `yaml:"refreshTokenExpiration" env:"AUTH_REFRESH_TOKEN_EXPIRATION"`
+		LoginMaxAttempts         int           `yaml:"loginMaxAttempts" env:"AUTH_LOGIN_MAX_ATTEMPTS"`
+		LoginLockoutDuration     time.Duration `yaml:"loginLockoutDuration" env:"AUTH_LOGIN_LOCKOUT_DURATION"`
 		ClientID                 string        `yaml:"clientId" env:"AUTH_CLIENT_ID"`
 		Issuer                   string        `yaml:"issuer" env:"AUTH_ISSUER"`
+		RoleMappings             []RoleMapping `yaml:"roleMappings"`
+		TenantClaim              string        `yaml:"tenantClaim" env:"AUTH_TENANT_CLAIM"`
 		UI                       UIAuthConfig  `yaml:"ui"`
 	}
 
+	// RoleMapping grants Role to identity provider users whose Claim holds Value, either as the claim itself or as
+	// an entry of a list claim. Claim may be a dotted path into nested claims, e.g. realm_access.roles. EmailDomain
+	// matches the domain of the verified email claim instead.
+	RoleMapping struct {
+		Claim       string `yaml:"claim"`
+		Value       string `yaml:"value"`
+		EmailDomain string `yaml:"emailDomain"`
+		Role        string `yaml:"role"`
+	}
+
+	// Redirection -.
+	Redirection struct {
+		HeartbeatInterval  time.Duration                             `yaml:"heartbeatInterval" env:"REDIRECTION_HEARTBEAT_INTERVAL"`
+		DeviceTimeout      time.Duration                             `yaml:"deviceTimeout" env:"REDIRECTION_DEVICE_TIMEOUT"`
+		WarningPeriod      time.Duration                             `yaml:"warningPeriod" env:"REDIRECTION_WARNING_PERIOD"`
+		MaxSessions        int                                       `yaml:"maxSessions" env:"REDIRECTION_MAX_SESSIONS"`
+		MaxSessionsPerUser int                                       `yaml:"maxSessionsPerUser" env:"REDIRECTION_MAX_SESSIONS_PER_USER"`
+		Modes              map[string]RedirectionTimeouts            `yaml:"modes"`
+		TagOverrides       map[string]map[string]RedirectionTimeouts `yaml:"tagOverrides"`
+	}
+
+	// RedirectionTimeouts -. Zero disables the timeout.
+	RedirectionTimeouts struct {
+		IdleTimeout    time.Duration `yaml:"idleTimeout"`
+		SessionTimeout time.Duration `yaml:"sessionTimeout"`
+	}
+
+	// Secrets selects where credentials are kept. The "database" provider stores them in encrypted columns, the
+	// "vault" provider stores them in a Vault KV version 2 mount and the columns only hold references to them.
+	Secrets struct {
+		Provider string `yaml:"provider" env:"SECRETS_PROVIDER"`
+		Vault    Vault  `yaml:"vault"`
+	}
+
+	// Vault -.
+	Vault struct {
+		Address   string        `yaml:"address" env:"SECRETS_VAULT_ADDRESS"`
+		Token     string        `yaml:"token" env:"SECRETS_VAULT_TOKEN"`
+		Mount     string        `yaml:"mount" env:"SECRETS_VAULT_MOUNT"`
+		Path      string        `yaml:"path" env:"SECRETS_VAULT_PATH"`
+		Namespace string        `yaml:"namespace" env:"SECRETS_VAULT_NAMESPACE"`
+		Timeout   time.Duration `yaml:"timeout" env:"SECRETS_VAULT_TIMEOUT"`
+	}
+
+	// CertMonitor checks provisioning, MPS root and device certificates every Interval and warns once a
+	// certificate expires within one of the WarningDays. An Interval of 0 disables the periodic check.
+	CertMonitor struct {
+		Interval     time.Duration `yaml:"interval" env:"CERT_MONITOR_INTERVAL"`
+		WarningDays  []int         `yaml:"warningDays" env:"CERT_MONITOR_WARNING_DAYS"`
+		CheckDevices bool          `yaml:"checkDevices" env:"CERT_MONITOR_CHECK_DEVICES"`
+	}
+
+	// Trash keeps deleted devices and profiles for Retention before they are purged for good. A Retention of 0
+	// keeps them until they are purged through the API.
+	Trash struct {
+		Retention time.Duration `yaml:"retention" env:"TRASH_RETENTION"`
+	}
+
 	// UIAuthConfig -.
 	UIAuthConfig struct {
 		ClientID                          string `yaml:"clientId"`
@@ -102,6 +183,16 @@ func NewConfig() (*Config, error) {
 			AllowedOrigins: []string{"*"},
 			AllowedHeaders: []string{"*"},
 			WSCompression:  true,
+			RequireIfMatch: false,
+			TLS: TLS{
+				Enabled:        false,
+				CertFile:       "",
+				KeyFile:        "",
+				MinVersion:     "1.2",
+				ClientCAFile:   "",
+				ClientAuth:     "require",
+				ReloadInterval: 30 * time.Second,
+			},
 		},
 		Log: Log{
 			Level: "info",
@@ -121,9 +212,14 @@ func NewConfig() (*Config, error) {
 			JWTKey:                   "your_secret_jwt_key",
 			JWTExpiration:            24 * time.Hour,
 			RedirectionJWTExpiration: 5 * time.Minute,
+			RefreshTokenExpiration:   7 * 24 * time.Hour,
+			LoginMaxAttempts:         5,
+			LoginLockoutDuration:     15 * time.Minute,
 			// OAUTH CONFIG, if provided will not use basic auth
-			ClientID: "",
-			Issuer:   "",
+			ClientID:     "",
+			Issuer:       "",
+			RoleMappings: []RoleMapping{},
+			TenantClaim:  "",
 			UI: UIAuthConfig{
 				ClientID:                          "",
 				Issuer:                            "",
@@ -134,6 +230,38 @@ func NewConfig() (*Config, error) {
 				StrictDiscoveryDocumentValidation: true,
 			},
 		},
+		Redirection: Redirection{
+			HeartbeatInterval:  30 * time.Second,
+			DeviceTimeout:      30 * time.Second,
+			WarningPeriod:      time.Minute,
+			MaxSessions:        0,
+			MaxSessionsPerUser: 0,
+			Modes: map[string]RedirectionTimeouts{
+				"kvm":  {IdleTimeout: 0, SessionTimeout: 0},
+				"sol":  {IdleTimeout: 0, SessionTimeout: 0},
+				"ider": {IdleTimeout: 0, SessionTimeout: 0},
+			},
+			TagOverrides: map[string]map[string]RedirectionTimeouts{},
+		},
+		CertMonitor: CertMonitor{
+			Interval:     24 * time.Hour,
+			WarningDays:  []int{60, 30, 7},
+			CheckDevices: false,
+		},
+		Trash: Trash{
+			Retention: 30 * 24 * time.Hour,
+		},
+		Secrets: Secrets{
+			Provider: "database",
+			Vault: Vault{
+				Address:   "http://localhost:8200",
+				Token:     "",
+				Mount:     "secret",
+				Path:      "console",
+				Namespace: "",
+				Timeout:   10 * time.Second,
+			},
+		},
 	}
 
 	// Define a command line flag for the config path
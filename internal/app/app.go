@@ -2,6 +2,7 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
@@ -40,6 +41,13 @@ func Run(cfg *config.Config) {
 	// Use case
 	usecases := usecase.NewUseCases(database, log)
 
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+	defer stopMonitor()
+
+	go usecases.CertMonitor.Run(monitorCtx)
+	go usecases.Trash.Run(monitorCtx)
+	go usecases.Encryption.Run(monitorCtx)
+
 	if os.Getenv("GIN_MODE") != "debug" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -50,6 +58,7 @@ func Run(cfg *config.Config) {
 	defaultConfig := cors.DefaultConfig()
 	defaultConfig.AllowOrigins = cfg.AllowedOrigins
 	defaultConfig.AllowHeaders = cfg.AllowedHeaders
+	defaultConfig.ExposeHeaders = []string{"ETag"}
 
 	handler.Use(cors.New(defaultConfig))
 	consolehttp.NewRouter(handler, log, *usecases, cfg)
@@ -72,8 +81,24 @@ func Run(cfg *config.Config) {
 		EnableCompression: cfg.WSCompression,
 	}
 
-	wsv1.RegisterRoutes(handler, log, usecases.Devices, upgrader)
-	httpServer := httpserver.New(handler, httpserver.Port(cfg.Host, cfg.Port))
+	wsv1.RegisterRoutes(handler, log, usecases.Devices, usecases.Activity, upgrader)
+	serverOptions := []httpserver.Option{httpserver.Port(cfg.Host, cfg.Port)}
+	if cfg.HTTP.TLS.Enabled {
+		serverOptions = append(serverOptions, httpserver.TLS(httpserver.TLSConfig{
+			CertFile:             cfg.HTTP.TLS.CertFile,
+			KeyFile:              cfg.HTTP.TLS.KeyFile,
+			MinVersion:           cfg.HTTP.TLS.MinVersion,
+			AllowInsecureCiphers: cfg.AllowInsecureCiphers,
+			ClientCAFile:         cfg.HTTP.TLS.ClientCAFile,
+			ClientAuth:           cfg.HTTP.TLS.ClientAuth,
+			ReloadInterval:       cfg.HTTP.TLS.ReloadInterval,
+			ReloadError: func(err error) {
+				log.Error(fmt.Errorf("app - Run - httpServer - TLS files not reloaded, the previous ones stay in use: %w", err))
+			},
+		}))
+	}
+
+	httpServer := httpserver.New(handler, serverOptions...)
 
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
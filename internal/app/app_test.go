@@ -2,8 +2,10 @@ package app_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/device-management-toolkit/console/config"
@@ -63,3 +65,18 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrateToPostgresURL(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "console.db")
+
+	_, err := app.MigrateToPostgres("mysql://localhost/console", missing)
+	require.ErrorContains(t, err, "postgresql://")
+
+	// both schemes Postgres accepts get past the check to the missing source
+	for _, url := range []string{"postgres://localhost/console", "postgresql://localhost/console"} {
+		_, err = app.MigrateToPostgres(url, missing)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// Backup returns a backup of the console encrypted with passphrase without starting the server.
+func Backup(cfg *config.Config, passphrase string) ([]byte, error) {
+	var archive []byte
+
+	err := withUseCases(cfg, func(u *usecase.Usecases) (err error) {
+		archive, err = u.Backup.Backup(context.Background(), passphrase)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("app - Backup: %w", err)
+	}
+
+	return archive, nil
+}
+
+// Restore restores a backup, or only compares it with the database when dryRun is set, without starting the
+// server. The console must not be running against the same database meanwhile.
+func Restore(cfg *config.Config, archive []byte, passphrase, mode string, dryRun bool) (*dto.Restore, error) {
+	var result *dto.Restore
+
+	err := withUseCases(cfg, func(u *usecase.Usecases) (err error) {
+		result, err = u.Backup.Restore(context.Background(), archive, passphrase, mode, dryRun)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("app - Restore: %w", err)
+	}
+
+	return result, nil
+}
+
+func withUseCases(cfg *config.Config, f func(*usecase.Usecases) error) error {
+	log := logger.New(cfg.Level)
+
+	database, err := db.New(cfg.DB.URL, sql.Open, db.MaxPoolSize(cfg.PoolMax), db.EnableForeignKeys(true))
+	if err != nil {
+		return fmt.Errorf("db.New: %w", err)
+	}
+	defer database.Close()
+
+	return f(usecase.NewUseCases(database, log))
+}
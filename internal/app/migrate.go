@@ -9,7 +9,6 @@ import (
 	"log"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -21,6 +20,7 @@ import (
 	_ "modernc.org/sqlite" // sqlite3 driver
 
 	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/pkg/db"
 )
 
 const (
@@ -49,7 +49,7 @@ func Init(cfg *config.Config) error {
 		log.Fatal(err)
 	}
 
-	if strings.HasPrefix(databaseURL, "postgres://") {
+	if db.IsPostgres(databaseURL) {
 		err := setupHostedDB(migrationsSource, databaseURL)
 		if err != nil {
 			return err
@@ -81,7 +81,11 @@ func setupLocalDB(migrationsSource source.Driver) error {
 
 	log.Printf("DB path : %s\n", filepath.Join(consoleDir, "console.db"))
 
-	db, err := sql.Open("sqlite", filepath.Join(consoleDir, "console.db"))
+	return migrateLocalDB(migrationsSource, filepath.Join(consoleDir, "console.db"))
+}
+
+func migrateLocalDB(migrationsSource source.Driver, path string) error {
+	db, err := sql.Open("sqlite", path)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+var errNotPostgres = errors.New("the target must be a postgres:// or postgresql:// URL")
+
+// MigrateToPostgres moves the data of an embedded console to Postgres. Both databases are migrated to the
+// current schema, then every table of the SQLite database at sourcePath, the embedded database when it is
+// empty, is copied to the Postgres database at targetURL, which must not hold any data yet, and verified. The
+// console must not be running against the SQLite database meanwhile. The target keeps using the encryption key
+// of the source.
+func MigrateToPostgres(targetURL, sourcePath string) ([]db.CopiedTable, error) {
+	if !db.IsPostgres(targetURL) {
+		return nil, errNotPostgres
+	}
+
+	if sourcePath == "" {
+		dirname, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("app - MigrateToPostgres - os.UserConfigDir: %w", err)
+		}
+
+		sourcePath = filepath.Join(dirname, "device-management-toolkit", "console.db")
+	}
+
+	if _, err := os.Stat(sourcePath); err != nil {
+		return nil, fmt.Errorf("app - MigrateToPostgres - os.Stat: %w", err)
+	}
+
+	// every migration run closes its source
+	sourceMigrations, err := iofs.New(content, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("app - MigrateToPostgres - iofs.New: %w", err)
+	}
+
+	if err := migrateLocalDB(sourceMigrations, sourcePath); err != nil {
+		return nil, fmt.Errorf("app - MigrateToPostgres - migrateLocalDB: %w", err)
+	}
+
+	targetMigrations, err := iofs.New(content, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("app - MigrateToPostgres - iofs.New: %w", err)
+	}
+
+	if err := setupHostedDB(targetMigrations, targetURL); err != nil {
+		return nil, fmt.Errorf("app - MigrateToPostgres - setupHostedDB: %w", err)
+	}
+
+	source, err := sql.Open("sqlite", sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("app - MigrateToPostgres - sql.Open: %w", err)
+	}
+	defer source.Close()
+
+	target, err := db.New(targetURL, sql.Open)
+	if err != nil {
+		return nil, fmt.Errorf("app - MigrateToPostgres - db.New: %w", err)
+	}
+	defer target.Close()
+
+	copied, err := db.CopySQLite(context.Background(), source, target)
+	if err != nil {
+		return copied, fmt.Errorf("app - MigrateToPostgres - db.CopySQLite: %w", err)
+	}
+
+	return copied, nil
+}
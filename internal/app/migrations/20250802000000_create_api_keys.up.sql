@@ -0,0 +1,17 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE TABLE IF NOT EXISTS api_keys(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  owner TEXT NOT NULL,
+  scopes TEXT NOT NULL,
+  key_hash TEXT NOT NULL,
+  expires_at TEXT,
+  last_used_at TEXT,
+  created_at TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id)
+);
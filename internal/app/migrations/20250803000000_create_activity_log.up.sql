@@ -0,0 +1,21 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE TABLE IF NOT EXISTS activity_log(
+  id TEXT NOT NULL,
+  timestamp TEXT NOT NULL,
+  actor TEXT NOT NULL,
+  api_key_id TEXT NOT NULL,
+  source_ip TEXT NOT NULL,
+  action TEXT NOT NULL,
+  target TEXT NOT NULL,
+  parameters TEXT NOT NULL,
+  status INTEGER NOT NULL,
+  result TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS activity_log_timestamp ON activity_log (timestamp);
@@ -0,0 +1,27 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE TABLE IF NOT EXISTS refresh_tokens(
+  id TEXT NOT NULL,
+  family_id TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  role TEXT NOT NULL,
+  token_hash TEXT NOT NULL,
+  expires_at TEXT NOT NULL,
+  used BOOLEAN NOT NULL DEFAULT FALSE,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_subject ON refresh_tokens(subject);
+
+CREATE TABLE IF NOT EXISTS token_revocations(
+  token_id TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  revoked_at TEXT NOT NULL,
+  expires_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS token_revocations_token_id ON token_revocations(token_id);
+CREATE INDEX IF NOT EXISTS token_revocations_subject ON token_revocations(subject);
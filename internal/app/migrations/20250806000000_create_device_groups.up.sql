@@ -0,0 +1,29 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE TABLE IF NOT EXISTS device_groups(
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  rule TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (name, tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS device_group_members(
+  group_name TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  FOREIGN KEY (group_name, tenant_id) REFERENCES device_groups(name, tenant_id) ON DELETE CASCADE,
+  PRIMARY KEY (group_name, guid, tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS device_group_subgroups(
+  group_name TEXT NOT NULL,
+  subgroup_name TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  FOREIGN KEY (group_name, tenant_id) REFERENCES device_groups(name, tenant_id) ON DELETE CASCADE,
+  FOREIGN KEY (subgroup_name, tenant_id) REFERENCES device_groups(name, tenant_id),
+  PRIMARY KEY (group_name, subgroup_name, tenant_id)
+);
@@ -0,0 +1,15 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE TABLE IF NOT EXISTS device_attributes(
+  name TEXT NOT NULL,
+  type TEXT NOT NULL,
+  enum_values TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (name, tenant_id)
+);
+
+ALTER TABLE devices ADD COLUMN attributes TEXT NOT NULL DEFAULT '';
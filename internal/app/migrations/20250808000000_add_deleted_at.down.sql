@@ -0,0 +1,11 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+DELETE FROM devices WHERE deleted_at <> '';
+DELETE FROM profiles_wirelessconfigs WHERE EXISTS (SELECT 1 FROM profiles p WHERE p.profile_name = profiles_wirelessconfigs.profile_name AND p.tenant_id = profiles_wirelessconfigs.tenant_id AND p.deleted_at <> '');
+DELETE FROM profiles WHERE deleted_at <> '';
+
+ALTER TABLE devices DROP COLUMN deleted_at;
+ALTER TABLE profiles DROP COLUMN deleted_at;
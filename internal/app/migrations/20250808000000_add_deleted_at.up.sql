@@ -0,0 +1,7 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+ALTER TABLE devices ADD COLUMN deleted_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN deleted_at TEXT NOT NULL DEFAULT '';
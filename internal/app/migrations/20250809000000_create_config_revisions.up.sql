@@ -0,0 +1,26 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE TABLE IF NOT EXISTS config_revisions(
+  kind TEXT NOT NULL,
+  name TEXT NOT NULL,
+  revision INTEGER NOT NULL,
+  author TEXT NOT NULL DEFAULT '',
+  created_at TEXT NOT NULL,
+  content TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (kind, name, revision, tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS config_revision_secrets(
+  kind TEXT NOT NULL,
+  name TEXT NOT NULL,
+  revision INTEGER NOT NULL,
+  field TEXT NOT NULL,
+  value TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  FOREIGN KEY (kind, name, revision, tenant_id) REFERENCES config_revisions(kind, name, revision, tenant_id),
+  PRIMARY KEY (kind, name, revision, field, tenant_id)
+);
@@ -0,0 +1,9 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+ALTER TABLE profiles DROP COLUMN template_overrides;
+ALTER TABLE profiles DROP COLUMN template_name;
+
+DROP TABLE IF EXISTS profile_templates;
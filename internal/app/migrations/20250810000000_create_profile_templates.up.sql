@@ -0,0 +1,14 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE TABLE IF NOT EXISTS profile_templates(
+  name TEXT NOT NULL,
+  settings TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (name, tenant_id)
+);
+
+ALTER TABLE profiles ADD COLUMN template_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN template_overrides TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+DROP INDEX IF EXISTS devices_tenantid_guid;
+DROP INDEX IF EXISTS activity_log_tenant_id_timestamp;
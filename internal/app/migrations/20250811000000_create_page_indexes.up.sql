@@ -0,0 +1,7 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+CREATE INDEX IF NOT EXISTS devices_tenantid_guid ON devices (tenantid, guid);
+CREATE INDEX IF NOT EXISTS activity_log_tenant_id_timestamp ON activity_log (tenant_id, timestamp DESC, id);
@@ -0,0 +1,10 @@
+/*********************************************************************
+* Copyright (c) Intel Corporation 2023
+* SPDX-License-Identifier: Apache-2.0
+**********************************************************************/
+
+ALTER TABLE profiles DROP COLUMN version;
+ALTER TABLE domains DROP COLUMN version;
+ALTER TABLE wirelessconfigs DROP COLUMN version;
+ALTER TABLE ieee8021xconfigs DROP COLUMN version;
+ALTER TABLE ciraconfigs DROP COLUMN version;
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// RotateEncryptionKey encrypts the stored secrets again with newKey, or a generated key when it is empty, without
+// starting the server. The console must not be running against the same database meanwhile.
+func RotateEncryptionKey(cfg *config.Config, newKey string) (*dto.KeyRotation, error) {
+	log := logger.New(cfg.Level)
+
+	database, err := db.New(cfg.DB.URL, sql.Open, db.MaxPoolSize(cfg.PoolMax), db.EnableForeignKeys(true))
+	if err != nil {
+		return nil, fmt.Errorf("app - RotateEncryptionKey - db.New: %w", err)
+	}
+	defer database.Close()
+
+	usecases := usecase.NewUseCases(database, log)
+
+	return usecases.Encryption.RotateKey(context.Background(), newKey)
+}
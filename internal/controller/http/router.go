@@ -44,8 +44,9 @@ func NewRouter(handler *gin.Engine, l logger.Interface, t usecase.Usecases, cfg
 	fuegoAdapter.AddToGinRouter(handler)
 
 	// Public routes
-	login := v1.NewLoginRoute(cfg)
+	login := v1.NewLoginRoute(cfg, t.Users, t.APIKeys, t.Auth)
 	handler.POST("/api/v1/authorize", login.Login)
+	handler.POST("/api/v1/authorize/refresh", login.Refresh)
 	// Static files
 	// Serve static assets (js, css, images, etc.)
 	// Create subdirectory view of the embedded file system
@@ -99,28 +100,50 @@ func NewRouter(handler *gin.Engine, l logger.Interface, t usecase.Usecases, cfg
 	// Protected routes using JWT middleware
 	var protected *gin.RouterGroup
 	if cfg.Disabled {
-		protected = handler.Group("/api")
+		protected = handler.Group("/api", v1.AllowAll())
 	} else {
 		protected = handler.Group("/api", login.JWTAuthMiddleware())
 	}
 
+	protected.Use(v1.RecordActivity(t.Activity, l))
+
+	// every authenticated caller may log out, whatever its permissions
+	protected.POST("/v1/logout", login.Logout)
+
 	// Routers
-	h2 := protected.Group("/v1")
+	h2 := protected.Group("/v1", v1.RequirePermissions(v1.DevicePolicy))
 	{
-		v1.NewDeviceRoutes(h2, t.Devices, l)
+		v1.NewDeviceRoutes(h2, t.Devices, t.DeviceGroups, t.Exporter, l)
 		v1.NewAmtRoutes(h2, t.Devices, t.AMTExplorer, t.Exporter, l)
 	}
 
-	h := protected.Group("/v1/admin")
+	h := protected.Group("/v1/admin", v1.RequirePermissions(v1.AdminPolicy))
 	{
-		v1.NewDomainRoutes(h, t.Domains, l)
-		v1.NewCIRAConfigRoutes(h, t.CIRAConfigs, l)
-		v1.NewProfileRoutes(h, t.Profiles, l)
-		v1.NewWirelessConfigRoutes(h, t.WirelessProfiles, l)
-		v1.NewIEEE8021xConfigRoutes(h, t.IEEE8021xProfiles, l)
+		// provisioning configs carry ETags, changing them can be required to name the one read
+		configs := h
+		if cfg.RequireIfMatch {
+			configs = h.Group("", v1.RequireIfMatch())
+		}
+
+		v1.NewDomainRoutes(configs, t.Domains, l)
+		v1.NewCIRAConfigRoutes(configs, t.CIRAConfigs, l)
+		v1.NewProfileRoutes(configs, t.Profiles, l)
+		v1.NewWirelessConfigRoutes(configs, t.WirelessProfiles, l)
+		v1.NewIEEE8021xConfigRoutes(configs, t.IEEE8021xProfiles, l)
+		v1.NewUserRoutes(h, t.Users, t.Tenants, t.Auth, t.APIKeys, l)
+		v1.NewAPIKeyRoutes(h, t.APIKeys, l)
+		v1.NewActivityRoutes(h, t.Activity, t.Exporter, l)
+		v1.NewTenantRoutes(h, t.Tenants, l)
+		v1.NewEncryptionRoutes(h, t.Encryption, l)
+		v1.NewCertificateRoutes(h, t.CertMonitor, l)
+		v1.NewBackupRoutes(h, t.Backup, l)
+		v1.NewDeviceGroupRoutes(h, t.DeviceGroups, l)
+		v1.NewDeviceAttributeRoutes(h, t.DeviceAttributes, l)
+		v1.NewRevisionRoutes(h, t.Revisions, l)
+		v1.NewProfileTemplateRoutes(h, t.ProfileTemplates, l)
 	}
 
-	h3 := protected.Group("/v2")
+	h3 := protected.Group("/v2", v1.RequirePermissions(v1.DevicePolicy))
 	{
 		v2.NewAmtRoutes(h3, t.Devices, l)
 	}
@@ -140,6 +163,9 @@ func injectConfigToMainJS(l logger.Interface, cfg *config.Config) string {
 	}
 
 	protocol := "http://"
+	if cfg.HTTP.TLS.Enabled {
+		protocol = "https://"
+	}
 
 	requireHTTPSReplacement := ",requireHttps:!1"
 	if cfg.UI.RequireHTTPS {
@@ -0,0 +1,314 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/activity"
+	"github.com/device-management-toolkit/console/internal/usecase/export"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationActivity = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ActivityAPI")}
+
+// exportPageSize is how many entries or devices are read at a time when exporting them.
+const exportPageSize = 500
+
+// auditedReads are GET routes that act on a device or hand out secrets, they are recorded like mutations.
+var auditedReads = []string{"/amt/userConsentCode/", "/authorize/redirection/", "/admin/profiles/export/"}
+
+// targetFields identify the object a request without path parameters acts on.
+var targetFields = []string{"guid", "profileName", "ciraConfigName", "username", "name", "domainSuffix"}
+
+// RecordActivity adds every mutating request to the console activity audit trail once it has been handled.
+func RecordActivity(a activity.Feature, l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isAudited(c) {
+			c.Next()
+
+			return
+		}
+
+		var body []byte
+
+		if c.Request.Body != nil {
+			body, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+
+		entry := &dto.ActivityEntry{
+			Actor:      c.GetString(userContextKey),
+			APIKeyID:   c.GetString(apiKeyContextKey),
+			SourceIP:   c.ClientIP(),
+			Action:     c.Request.Method + " " + c.FullPath(),
+			Target:     activityTarget(c, body),
+			Parameters: activity.Parameters(body, c.Request.URL.Query()),
+			Status:     status,
+			Result:     activityResult(status),
+			TenantID:   callerTenant(c),
+		}
+
+		// the entry is written even if the client has gone away
+		if err := a.Record(context.WithoutCancel(c.Request.Context()), entry); err != nil {
+			l.Error(err, "http - v1 - recordActivity")
+		}
+	}
+}
+
+func isAudited(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		for _, route := range auditedReads {
+			if strings.Contains(c.FullPath(), route) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return true
+	}
+}
+
+func activityTarget(c *gin.Context, body []byte) string {
+	if len(c.Params) > 0 {
+		parts := make([]string, len(c.Params))
+		for i, param := range c.Params {
+			parts[i] = param.Key + "=" + param.Value
+		}
+
+		return strings.Join(parts, ",")
+	}
+
+	var fields map[string]any
+	if json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+
+	for _, field := range targetFields {
+		if value, ok := fields[field].(string); ok && value != "" {
+			return field + "=" + value
+		}
+	}
+
+	return ""
+}
+
+func activityResult(status int) string {
+	if status >= http.StatusBadRequest {
+		return activity.ResultFailure
+	}
+
+	return activity.ResultSuccess
+}
+
+type activityRoutes struct {
+	t activity.Feature
+	e export.Exporter
+	l logger.Interface
+}
+
+func NewActivityRoutes(handler *gin.RouterGroup, t activity.Feature, e export.Exporter, l logger.Interface) {
+	r := &activityRoutes{t, e, l}
+
+	h := handler.Group("/activity")
+	{
+		h.GET("", r.get)
+		h.GET("export", r.export)
+	}
+}
+
+type ActivityCountResponse struct {
+	Count       int                 `json:"totalCount"`
+	Data        []dto.ActivityEntry `json:"data"`
+	Approximate bool                `json:"approximate,omitempty"`
+}
+
+// ActivityPageResponse is a page of the audit trail read with $skiptoken. SkipToken reads the next page and is
+// left out on the last one, Count is only there with $count.
+type ActivityPageResponse struct {
+	Data        []dto.ActivityEntry `json:"data"`
+	SkipToken   string              `json:"skipToken,omitempty"`
+	Count       *int                `json:"totalCount,omitempty"`
+	Approximate bool                `json:"approximate,omitempty"`
+}
+
+// @Summary     Show Console Activity
+// @Description Query the console activity audit trail, newest first
+// @ID          activity
+// @Tags  	    activity
+// @Accept      json
+// @Produce     json
+// @Param       actor  query string false "user who acted"
+// @Param       action query string false "part of the method and route"
+// @Param       target query string false "part of the target"
+// @Param       result query string false "success or failure"
+// @Param       from   query string false "RFC 3339 start time"
+// @Param       to     query string false "RFC 3339 end time"
+// @Param       $skiptoken  query string false "skipToken of the previous page, empty for the first one"
+// @Param       approximate query bool   false "estimate $count"
+// @Success     200 {object} ActivityCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/activity [get]
+func (r *activityRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		ErrorResponse(c, ErrValidationActivity.Wrap("get", "ShouldBindQuery", err))
+
+		return
+	}
+
+	var filter dto.ActivityFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		ErrorResponse(c, ErrValidationActivity.Wrap("get", "ShouldBindQuery", err))
+
+		return
+	}
+
+	paged, err := odata.Paged()
+	if err != nil {
+		ErrorResponse(c, ErrValidationActivity.Wrap("get", "odata.Paged", err))
+
+		return
+	}
+
+	if paged {
+		r.getPage(c, odata, filter)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), filter, odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.count(c, odata, filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, ActivityCountResponse{Count: count, Data: items, Approximate: odata.Approximate})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// getPage writes the page of entries following $skiptoken with the token of the next one.
+func (r *activityRoutes) getPage(c *gin.Context, o OData, filter dto.ActivityFilter) {
+	items, next, err := r.t.GetPage(c.Request.Context(), filter, o.Top, *o.SkipToken, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getPage")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	page := ActivityPageResponse{Data: items, SkipToken: next}
+
+	if o.Count {
+		count, err := r.count(c, o, filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		page.Count = &count
+		page.Approximate = o.Approximate
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// count counts the matching entries, or estimates how many there are when approximate is set.
+func (r *activityRoutes) count(c *gin.Context, o OData, filter dto.ActivityFilter) (int, error) {
+	if o.Approximate {
+		return r.t.EstimateCount(c.Request.Context(), filter, callerTenant(c))
+	}
+
+	return r.t.GetCount(c.Request.Context(), filter, callerTenant(c))
+}
+
+// @Summary     Export Console Activity
+// @Description Export the matching console activity as CSV or JSON
+// @ID          exportActivity
+// @Tags  	    activity
+// @Produce     text/csv
+// @Param       format query string false "csv (default) or json"
+// @Success     200 {file} file
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/activity/export [get]
+func (r *activityRoutes) export(c *gin.Context) {
+	var filter dto.ActivityFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		ErrorResponse(c, ErrValidationActivity.Wrap("export", "ShouldBindQuery", err))
+
+		return
+	}
+
+	var (
+		entries   []dto.ActivityEntry
+		skipToken string
+	)
+
+	for {
+		page, next, err := r.t.GetPage(c.Request.Context(), filter, exportPageSize, skipToken, callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - exportActivity")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		entries = append(entries, page...)
+
+		if next == "" {
+			break
+		}
+
+		skipToken = next
+	}
+
+	if c.Query("format") == "json" {
+		c.Header("Content-Disposition", "attachment; filename=console_activity.json")
+		c.JSON(http.StatusOK, entries)
+
+		return
+	}
+
+	csvReader, err := r.e.ExportActivityCSV(entries)
+	if err != nil {
+		r.l.Error(err, "http - v1 - exportActivity")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=console_activity.csv")
+	c.Header("Content-Type", "text/csv")
+
+	if _, err = io.Copy(c.Writer, csvReader); err != nil {
+		r.l.Error(err, "http - v1 - exportActivity")
+	}
+}
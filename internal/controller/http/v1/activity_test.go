@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/activity"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func TestRecordActivity(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*mocks.MockActivityFeature, *gin.Engine) {
+		t.Helper()
+
+		recorder := mocks.NewMockActivityFeature(gomock.NewController(t))
+
+		engine := gin.New()
+		handler := engine.Group("/api/v1", func(c *gin.Context) {
+			c.Set(userContextKey, "jdoe")
+		}, RecordActivity(recorder, logger.New("error")))
+
+		handler.POST("/admin/profiles", func(c *gin.Context) {
+			// the handler still sees the body
+			body, _ := io.ReadAll(c.Request.Body)
+			require.Contains(t, string(body), "P@ssw0rd")
+
+			c.Status(http.StatusCreated)
+		})
+		handler.POST("/amt/power/action/:guid", func(c *gin.Context) { c.Status(http.StatusForbidden) })
+		handler.GET("/devices/:guid", func(c *gin.Context) { c.Status(http.StatusOK) })
+		handler.GET("/amt/userConsentCode/:guid", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		return recorder, engine
+	}
+
+	serve := func(engine *gin.Engine, method, target, body string) {
+		req, _ := http.NewRequestWithContext(context.Background(), method, target, strings.NewReader(body))
+
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	t.Run("mutation with secrets redacted", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, engine := setup(t)
+
+		recorder.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, entry *dto.ActivityEntry) error {
+			require.Equal(t, "jdoe", entry.Actor)
+			require.Equal(t, "POST /api/v1/admin/profiles", entry.Action)
+			require.Equal(t, "profileName=p1", entry.Target)
+			require.JSONEq(t, `{"body":{"profileName":"p1","amtPassword":"[REDACTED]"}}`, entry.Parameters)
+			require.Equal(t, http.StatusCreated, entry.Status)
+			require.Equal(t, activity.ResultSuccess, entry.Result)
+
+			return nil
+		})
+
+		serve(engine, http.MethodPost, "/api/v1/admin/profiles", `{"profileName":"p1","amtPassword":"P@ssw0rd"}`)
+	})
+
+	t.Run("failed power action", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, engine := setup(t)
+
+		recorder.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, entry *dto.ActivityEntry) error {
+			require.Equal(t, "guid=123", entry.Target)
+			require.Equal(t, activity.ResultFailure, entry.Result)
+
+			return nil
+		})
+
+		serve(engine, http.MethodPost, "/api/v1/amt/power/action/123", `{"action":2}`)
+	})
+
+	t.Run("reads are not recorded", func(t *testing.T) {
+		t.Parallel()
+
+		_, engine := setup(t)
+
+		serve(engine, http.MethodGet, "/api/v1/devices/123", "")
+	})
+
+	t.Run("consent code is recorded", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, engine := setup(t)
+
+		recorder.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil)
+
+		serve(engine, http.MethodGet, "/api/v1/amt/userConsentCode/123", "")
+	})
+}
+
+func TestActivityRoutes(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*mocks.MockActivityFeature, *mocks.MockExporter, *gin.Engine) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		feature := mocks.NewMockActivityFeature(ctrl)
+		exporter := mocks.NewMockExporter(ctrl)
+
+		engine := gin.New()
+		NewActivityRoutes(engine.Group("/api/v1/admin"), feature, exporter, logger.New("error"))
+
+		return feature, exporter, engine
+	}
+
+	get := func(engine *gin.Engine, target string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, target, http.NoBody)
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	entries := []dto.ActivityEntry{{ID: "a", Actor: "jdoe", Result: activity.ResultFailure}}
+
+	t.Run("query with count", func(t *testing.T) {
+		t.Parallel()
+
+		feature, _, engine := setup(t)
+
+		filter := dto.ActivityFilter{Actor: "jdoe", Result: activity.ResultFailure}
+		feature.EXPECT().Get(gomock.Any(), filter, 25, 0, "").Return(entries, nil)
+		feature.EXPECT().GetCount(gomock.Any(), filter, "").Return(1, nil)
+
+		w := get(engine, "/api/v1/admin/activity?actor=jdoe&result=failure&$top=25&$count=true")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(ActivityCountResponse{Count: 1, Data: entries})
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("page with an approximate count", func(t *testing.T) {
+		t.Parallel()
+
+		feature, _, engine := setup(t)
+
+		feature.EXPECT().GetPage(gomock.Any(), dto.ActivityFilter{}, 1, "", "").Return(entries, "next", nil)
+		feature.EXPECT().EstimateCount(gomock.Any(), dto.ActivityFilter{}, "").Return(1200, nil)
+
+		w := get(engine, "/api/v1/admin/activity?$top=1&$skiptoken=&$count=true&approximate=true")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		count := 1200
+		expected, _ := json.Marshal(ActivityPageResponse{Data: entries, SkipToken: "next", Count: &count, Approximate: true})
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("$skip with $skiptoken", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, engine := setup(t)
+
+		w := get(engine, "/api/v1/admin/activity?$skip=25&$skiptoken=next")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("export csv", func(t *testing.T) {
+		t.Parallel()
+
+		feature, exporter, engine := setup(t)
+
+		feature.EXPECT().GetPage(gomock.Any(), dto.ActivityFilter{}, exportPageSize, "", "").Return(entries, "next", nil)
+		feature.EXPECT().GetPage(gomock.Any(), dto.ActivityFilter{}, exportPageSize, "next", "").Return(entries, "", nil)
+		exporter.EXPECT().ExportActivityCSV(append(entries, entries...)).Return(strings.NewReader("Time,Actor\n"), nil)
+
+		w := get(engine, "/api/v1/admin/activity/export")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		require.Equal(t, "Time,Actor\n", w.Body.String())
+	})
+
+	t.Run("export json", func(t *testing.T) {
+		t.Parallel()
+
+		feature, _, engine := setup(t)
+
+		feature.EXPECT().GetPage(gomock.Any(), dto.ActivityFilter{}, exportPageSize, "", "").Return(entries, "", nil)
+
+		w := get(engine, "/api/v1/admin/activity/export?format=json")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(entries)
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+}
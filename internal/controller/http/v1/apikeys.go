@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/apikeys"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationAPIKeys = dto.NotValidError{Console: consoleerrors.CreateConsoleError("APIKeysAPI")}
+
+type apiKeyRoutes struct {
+	t apikeys.Feature
+	l logger.Interface
+}
+
+func NewAPIKeyRoutes(handler *gin.RouterGroup, t apikeys.Feature, l logger.Interface) {
+	r := &apiKeyRoutes{t, l}
+
+	h := handler.Group("/apikeys")
+	{
+		h.GET("", r.get)
+		h.POST("", r.insert)
+		h.DELETE(":id", r.delete)
+	}
+}
+
+type APIKeyCountResponse struct {
+	Count int          `json:"totalCount"`
+	Data  []dto.APIKey `json:"data"`
+}
+
+// @Summary     Show API Keys
+// @Description Show all API keys, the keys themselves are never returned
+// @ID          apikeys
+// @Tags  	    apikeys
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} APIKeyCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/apikeys [get]
+func (r *apiKeyRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationAPIKeys.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, APIKeyCountResponse{Count: count, Data: items})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Add API Key
+// @Description Create an API key owned by the caller. The key is only returned in this response.
+// @ID          insertAPIKey
+// @Tags  	    apikeys
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.APIKey
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/apikeys [post]
+func (r *apiKeyRoutes) insert(c *gin.Context) {
+	var key dto.APIKey
+	if err := c.ShouldBindJSON(&key); err != nil {
+		validationErr := ErrValidationAPIKeys.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	// a key can never do more than the user who created it
+	for _, scope := range key.Scopes {
+		if !hasPermission(c, scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, response{"scope " + scope + " exceeds the caller's permissions"})
+
+			return
+		}
+	}
+
+	key.Owner = c.GetString(userContextKey)
+	key.TenantID = callerTenant(c)
+
+	newKey, err := r.t.Insert(c.Request.Context(), &key)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newKey)
+}
+
+// @Summary     Revoke API Key
+// @Description Revoke an API key
+// @ID          deleteAPIKey
+// @Tags  	    apikeys
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/apikeys/:id [delete]
+func (r *apiKeyRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("id"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func apiKeysTest(t *testing.T, role string) (*mocks.MockAPIKeysFeature, *gin.Engine) {
+	t.Helper()
+
+	apiKey := mocks.NewMockAPIKeysFeature(gomock.NewController(t))
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+		c.Set(userContextKey, "jdoe")
+		c.Set(permissionsContextKey, users.Permissions(role))
+	})
+
+	NewAPIKeyRoutes(handler, apiKey, logger.New("error"))
+
+	return apiKey, engine
+}
+
+func TestAPIKeyRoutes(t *testing.T) {
+	t.Parallel()
+
+	post := func(engine *gin.Engine, key dto.APIKey) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(key)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/admin/apikeys", bytes.NewBuffer(data))
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("insert owned by the caller", func(t *testing.T) {
+		t.Parallel()
+
+		apiKey, engine := apiKeysTest(t, users.RoleOperator)
+
+		created := &dto.APIKey{ID: "id", Name: "ci", Owner: "jdoe", Scopes: []string{users.PermissionPowerWrite}, Key: "cak_secret"}
+		apiKey.EXPECT().Insert(gomock.Any(), &dto.APIKey{Name: "ci", Owner: "jdoe", Scopes: []string{users.PermissionPowerWrite}}).Return(created, nil)
+
+		w := post(engine, dto.APIKey{Name: "ci", Owner: "someone-else", Scopes: []string{users.PermissionPowerWrite}})
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		expected, _ := json.Marshal(created)
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("scopes beyond the caller's permissions", func(t *testing.T) {
+		t.Parallel()
+
+		_, engine := apiKeysTest(t, users.RoleViewer)
+
+		w := post(engine, dto.APIKey{Name: "ci", Scopes: []string{users.PermissionPowerWrite}})
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		t.Parallel()
+
+		apiKey, engine := apiKeysTest(t, users.RoleAdmin)
+
+		apiKey.EXPECT().Delete(gomock.Any(), "id", "").Return(nil)
+
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/admin/apikeys/id", http.NoBody)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
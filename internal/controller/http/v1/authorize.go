@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+)
+
+const (
+	// permissionsContextKey holds the permissions of the authenticated caller on the gin context.
+	permissionsContextKey = "permissions"
+	// tenantContextKey holds the tenant of the authenticated caller on the gin context.
+	tenantContextKey = "tenant"
+)
+
+// RouteOverride requires Permission for every method on routes whose path contains Route.
+type RouteOverride struct {
+	Route      string
+	Permission string
+}
+
+// RoutePolicy maps the requests of a route group to the permission they need. GET and HEAD need Read,
+// every other method needs Write. The first matching override wins, it is used for GET routes that act
+// on a device and for routes that need a more specific permission.
+type RoutePolicy struct {
+	Read      string
+	Write     string
+	Overrides []RouteOverride
+}
+
+var (
+	// DevicePolicy guards the device routes of the v1 and v2 APIs.
+	DevicePolicy = RoutePolicy{
+		Read:  users.PermissionDevicesRead,
+		Write: users.PermissionDevicesWrite,
+		Overrides: []RouteOverride{
+			{Route: "/authorize/redirection/", Permission: users.PermissionDevicesRedirect},
+			{Route: "/amt/kvm/", Permission: users.PermissionDevicesRedirect},
+			{Route: "/amt/redirection/", Permission: users.PermissionDevicesRedirect},
+			{Route: "/amt/power/action/", Permission: users.PermissionPowerWrite},
+			{Route: "/amt/power/bootOptions/", Permission: users.PermissionPowerWrite},
+			{Route: "/amt/power/bootoptions/", Permission: users.PermissionPowerWrite},
+			// these GET routes start or cancel consent and run WS-MAN calls on the device
+			{Route: "/amt/userConsentCode/", Permission: users.PermissionDevicesWrite},
+			{Route: "/amt/explorer/", Permission: users.PermissionDevicesWrite},
+		},
+	}
+
+	// AdminPolicy guards the provisioning configuration and user management routes.
+	AdminPolicy = RoutePolicy{
+		Read:  users.PermissionAdminRead,
+		Write: users.PermissionAdminWrite,
+		Overrides: []RouteOverride{
+			{Route: "/admin/users", Permission: users.PermissionUsersManage},
+			{Route: "/admin/apikeys", Permission: users.PermissionUsersManage},
+			{Route: "/admin/activity", Permission: users.PermissionAuditRead},
+			{Route: "/admin/tenants", Permission: users.PermissionTenantsManage},
+			{Route: "/admin/encryption", Permission: users.PermissionEncryptionManage},
+			{Route: "/admin/backup", Permission: users.PermissionBackupManage},
+			// exported profiles contain secrets
+			{Route: "/admin/profiles/export/", Permission: users.PermissionAdminWrite},
+		},
+	}
+)
+
+func (p RoutePolicy) permissionFor(c *gin.Context) string {
+	for _, override := range p.Overrides {
+		if strings.Contains(c.FullPath(), override.Route) {
+			return override.Permission
+		}
+	}
+
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		return p.Read
+	}
+
+	return p.Write
+}
+
+// RequirePermissions rejects requests whose caller lacks the permission the policy asks for.
+func RequirePermissions(policy RoutePolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasPermission(c, policy.permissionFor(c)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, response{"insufficient permissions"})
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AllowAll grants every permission to the caller, it stands in for the JWT middleware when auth is disabled.
+func AllowAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(permissionsContextKey, users.Permissions(users.RoleAdmin))
+		c.Next()
+	}
+}
+
+func hasPermission(c *gin.Context, permission string) bool {
+	granted, _ := c.Get(permissionsContextKey)
+	permissions, _ := granted.([]string)
+
+	return slices.Contains(permissions, permission)
+}
+
+// setTenant scopes the rest of the request to the tenant of the caller. Device operations that only take a GUID
+// read the tenant from the request context.
+func setTenant(c *gin.Context, tenantID string) {
+	c.Set(tenantContextKey, tenantID)
+	c.Request = c.Request.WithContext(devices.WithTenant(c.Request.Context(), tenantID))
+}
+
+// setUser records the authenticated caller for the rest of the request, configuration revisions saved by the
+// request name the caller as their author.
+func setUser(c *gin.Context, user string) {
+	c.Set(userContextKey, user)
+	c.Request = c.Request.WithContext(revisions.WithAuthor(c.Request.Context(), user))
+}
+
+// callerTenant returns the tenant of the caller, callers without a tenant belong to the default tenant.
+func callerTenant(c *gin.Context) string {
+	return c.GetString(tenantContextKey)
+}
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/backup"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// maxArchiveSize bounds the size of an uploaded backup.
+const maxArchiveSize = 256 << 20
+
+var (
+	ErrValidationBackup = dto.NotValidError{Console: consoleerrors.CreateConsoleError("BackupAPI")}
+	errArchiveTooLarge  = errors.New("the backup is too large")
+)
+
+type backupRoutes struct {
+	b backup.Feature
+	l logger.Interface
+}
+
+func NewBackupRoutes(handler *gin.RouterGroup, b backup.Feature, l logger.Interface) {
+	r := &backupRoutes{b, l}
+
+	h := handler.Group("/backup")
+	{
+		h.POST("", r.backup)
+		h.POST("restore", r.restore)
+	}
+}
+
+// @Summary     Back up the Console
+// @Description Download the devices, device attributes and groups, profiles, domains, CIRA, wireless and 802.1X configs of every tenant encrypted with a passphrase
+// @ID          backup
+// @Tags  	    backup
+// @Accept      json
+// @Produce     octet-stream
+// @Success     200 {file} file
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/backup [post]
+func (r *backupRoutes) backup(c *gin.Context) {
+	// a backup holds the secrets of every tenant
+	if callerTenant(c) != tenants.DefaultTenant {
+		c.AbortWithStatusJSON(http.StatusForbidden, response{"backups can only be made from the default tenant"})
+
+		return
+	}
+
+	var req dto.BackupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErr := ErrValidationBackup.Wrap("backup", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	archive, err := r.b.Backup(c.Request.Context(), req.Passphrase)
+	if err != nil {
+		r.l.Error(err, "http - v1 - backup")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=console_backup.json")
+	c.Data(http.StatusOK, "application/octet-stream", archive)
+}
+
+// @Summary     Restore a Backup
+// @Description Compare a backup with the stored devices and configuration and restore it in merge or replace mode, only the changes are reported unless dryRun is false
+// @ID          restoreBackup
+// @Tags  	    backup
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       archive formData file true "Backup"
+// @Param       passphrase formData string true "Passphrase of the backup"
+// @Param       mode formData string false "merge or replace" default(merge)
+// @Param       dryRun formData bool false "Only report the changes" default(true)
+// @Success     200 {object} dto.Restore
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/backup/restore [post]
+func (r *backupRoutes) restore(c *gin.Context) {
+	if callerTenant(c) != tenants.DefaultTenant {
+		c.AbortWithStatusJSON(http.StatusForbidden, response{"backups can only be restored from the default tenant"})
+
+		return
+	}
+
+	var req dto.RestoreRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErr := ErrValidationBackup.Wrap("restore", "ShouldBind", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	header, err := c.FormFile("archive")
+	if err != nil {
+		ErrorResponse(c, ErrValidationBackup.Wrap("restore", "FormFile", err))
+
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ErrorResponse(c, ErrValidationBackup.Wrap("restore", "header.Open", err))
+
+		return
+	}
+	defer file.Close()
+
+	archive, err := io.ReadAll(io.LimitReader(file, maxArchiveSize+1))
+	if err != nil {
+		ErrorResponse(c, ErrValidationBackup.Wrap("restore", "io.ReadAll", err))
+
+		return
+	}
+
+	if len(archive) > maxArchiveSize {
+		ErrorResponse(c, ErrValidationBackup.Wrap("restore", "io.ReadAll", errArchiveTooLarge))
+
+		return
+	}
+
+	result, err := r.b.Restore(c.Request.Context(), archive, req.Passphrase, req.Mode, req.DryRun)
+	if err != nil {
+		r.l.Error(err, "http - v1 - restore")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
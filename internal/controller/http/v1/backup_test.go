@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func backupTest(t *testing.T, tenant string) (*mocks.MockBackupFeature, *gin.Engine) {
+	t.Helper()
+
+	backupFeature := mocks.NewMockBackupFeature(gomock.NewController(t))
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+		setTenant(c, tenant)
+	})
+
+	NewBackupRoutes(handler, backupFeature, logger.New("error"))
+
+	return backupFeature, engine
+}
+
+// restoreForm returns a multipart form with the archive and the given fields.
+func restoreForm(t *testing.T, archive string, fields map[string]string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+
+	if archive != "" {
+		part, err := form.CreateFormFile("archive", "console_backup.json")
+		require.NoError(t, err)
+
+		_, err = part.Write([]byte(archive))
+		require.NoError(t, err)
+	}
+
+	for name, value := range fields {
+		require.NoError(t, form.WriteField(name, value))
+	}
+
+	require.NoError(t, form.Close())
+
+	return body, form.FormDataContentType()
+}
+
+func TestBackupRoutes(t *testing.T) {
+	t.Parallel()
+
+	changes := &dto.Restore{Mode: dto.RestoreMerge, DryRun: true, Tables: []dto.RestoreTableChange{
+		{Table: "profiles", Added: []string{"p3"}, Updated: []string{}, Deleted: []string{}, Unchanged: 1},
+	}}
+
+	tests := []struct {
+		name         string
+		tenant       string
+		url          string
+		body         func(t *testing.T) (*bytes.Buffer, string)
+		mock         func(*mocks.MockBackupFeature)
+		expectedCode int
+		response     string
+	}{
+		{
+			name: "backup",
+			url:  "/api/v1/admin/backup",
+			body: func(_ *testing.T) (*bytes.Buffer, string) {
+				data, _ := json.Marshal(dto.BackupRequest{Passphrase: "correct horse battery staple"})
+
+				return bytes.NewBuffer(data), "application/json"
+			},
+			mock: func(b *mocks.MockBackupFeature) {
+				b.EXPECT().Backup(gomock.Any(), "correct horse battery staple").Return([]byte(`{"format":"console-backup"}`), nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     `{"format":"console-backup"}`,
+		},
+		{
+			name:   "backup by a caller of a tenant",
+			tenant: "bu-retail",
+			url:    "/api/v1/admin/backup",
+			body: func(_ *testing.T) (*bytes.Buffer, string) {
+				return bytes.NewBufferString(`{"passphrase":"correct horse battery staple"}`), "application/json"
+			},
+			mock:         func(_ *mocks.MockBackupFeature) {},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "restore is a dry run by default",
+			url:  "/api/v1/admin/backup/restore",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return restoreForm(t, "archive", map[string]string{"passphrase": "correct horse battery staple"})
+			},
+			mock: func(b *mocks.MockBackupFeature) {
+				b.EXPECT().Restore(gomock.Any(), []byte("archive"), "correct horse battery staple", dto.RestoreMerge, true).Return(changes, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     `{"mode":"merge","dryRun":true,"tables":[{"table":"profiles","added":["p3"],"updated":[],"deleted":[],"unchanged":1}]}`,
+		},
+		{
+			name: "restore replacing everything",
+			url:  "/api/v1/admin/backup/restore",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return restoreForm(t, "archive", map[string]string{"passphrase": "correct horse battery staple", "mode": "replace", "dryRun": "false"})
+			},
+			mock: func(b *mocks.MockBackupFeature) {
+				b.EXPECT().Restore(gomock.Any(), []byte("archive"), "correct horse battery staple", dto.RestoreReplace, false).
+					Return(&dto.Restore{Mode: dto.RestoreReplace, Tables: []dto.RestoreTableChange{}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     `{"mode":"replace","dryRun":false,"tables":[]}`,
+		},
+		{
+			name: "restore without an archive",
+			url:  "/api/v1/admin/backup/restore",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return restoreForm(t, "", map[string]string{"passphrase": "correct horse battery staple"})
+			},
+			mock:         func(_ *mocks.MockBackupFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.tenant == "" {
+				tc.tenant = tenants.DefaultTenant
+			}
+
+			backupFeature, engine := backupTest(t, tc.tenant)
+			tc.mock(backupFeature)
+
+			body, contentType := tc.body(t)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, tc.url, body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", contentType)
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != "" {
+				require.Equal(t, tc.response, w.Body.String())
+			}
+		})
+	}
+}
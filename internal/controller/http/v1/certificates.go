@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/certmonitor"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationCertificates = dto.NotValidError{Console: consoleerrors.CreateConsoleError("CertificatesAPI")}
+
+type certificateRoutes struct {
+	m certmonitor.Feature
+	l logger.Interface
+}
+
+type expiringQuery struct {
+	Days int `form:"days" binding:"gte=0"`
+}
+
+func NewCertificateRoutes(handler *gin.RouterGroup, m certmonitor.Feature, l logger.Interface) {
+	r := &certificateRoutes{m, l}
+
+	h := handler.Group("/certificates")
+	{
+		h.GET("expiring", r.getExpiring)
+		h.POST("check", r.check)
+	}
+}
+
+// @Summary     Show Expiring Certificates
+// @Description Show the provisioning, MPS root and device certificates that expire within the given days, the largest warning threshold by default, or that no longer match their pinned hash, as of the last check
+// @ID          expiringCertificates
+// @Tags  	    certificates
+// @Accept      json
+// @Produce     json
+// @Param       days query int false "days until expiration"
+// @Success     200 {object} []dto.MonitoredCertificate
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/certificates/expiring [get]
+func (r *certificateRoutes) getExpiring(c *gin.Context) {
+	var query expiringQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		validationErr := ErrValidationCertificates.Wrap("getExpiring", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.m.GetExpiring(c.Request.Context(), query.Days, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getExpiring")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary     Check Certificates
+// @Description Check every monitored certificate now instead of waiting for the next scheduled check
+// @ID          checkCertificates
+// @Tags  	    certificates
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/certificates/check [post]
+func (r *certificateRoutes) check(c *gin.Context) {
+	if err := r.m.Check(c.Request.Context()); err != nil {
+		r.l.Error(err, "http - v1 - check")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func certificatesTest(t *testing.T) (*mocks.MockCertMonitorFeature, *gin.Engine) {
+	t.Helper()
+
+	monitor := mocks.NewMockCertMonitorFeature(gomock.NewController(t))
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+		setTenant(c, "bu-retail")
+	})
+
+	NewCertificateRoutes(handler, monitor, logger.New("error"))
+
+	return monitor, engine
+}
+
+func TestCertificateRoutes(t *testing.T) {
+	t.Parallel()
+
+	expiring := []dto.MonitoredCertificate{{Source: dto.CertificateSourceDomain, Name: "example.com", TenantID: "bu-retail", DaysRemaining: 20, WarningDays: 30}}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(*mocks.MockCertMonitorFeature)
+		expectedCode int
+		response     interface{}
+	}{
+		{
+			name:   "expiring with the default threshold",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/certificates/expiring",
+			mock: func(m *mocks.MockCertMonitorFeature) {
+				m.EXPECT().GetExpiring(gomock.Any(), 0, "bu-retail").Return(expiring, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     expiring,
+		},
+		{
+			name:   "expiring within days",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/certificates/expiring?days=30",
+			mock: func(m *mocks.MockCertMonitorFeature) {
+				m.EXPECT().GetExpiring(gomock.Any(), 30, "bu-retail").Return(expiring, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     expiring,
+		},
+		{
+			name:         "invalid days",
+			method:       http.MethodGet,
+			url:          "/api/v1/admin/certificates/expiring?days=soon",
+			mock:         func(_ *mocks.MockCertMonitorFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "check",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/certificates/check",
+			mock: func(m *mocks.MockCertMonitorFeature) {
+				m.EXPECT().Check(gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "check fails",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/certificates/check",
+			mock: func(m *mocks.MockCertMonitorFeature) {
+				m.EXPECT().Check(gomock.Any()).Return(errors.New("database down"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			monitor, engine := certificatesTest(t)
+			tc.mock(monitor)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, tc.url, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				expected, _ := json.Marshal(tc.response)
+				require.JSONEq(t, string(expected), w.Body.String())
+			}
+		})
+	}
+}
@@ -7,9 +7,12 @@ import (
 
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/usecase/ciraconfigs"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/logger"
 )
 
+var ErrValidationCIRAConfig = dto.NotValidError{Console: consoleerrors.CreateConsoleError("CIRAConfigsAPI")}
+
 type ciraConfigRoutes struct {
 	cira ciraconfigs.Feature
 	l    logger.Interface
@@ -37,7 +40,15 @@ func (r *ciraConfigRoutes) get(c *gin.Context) {
 		return
 	}
 
-	configs, err := r.cira.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	query, err := odata.Query()
+	if err != nil {
+		validationErr := ErrValidationCIRAConfig.Wrap("get", "odata.Query", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	configs, err := r.cira.Get(c.Request.Context(), query, odata.Top, odata.Skip, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - getCount")
 		ErrorResponse(c, err)
@@ -46,7 +57,7 @@ func (r *ciraConfigRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.cira.GetCount(c.Request.Context(), "")
+		count, err := r.cira.GetCount(c.Request.Context(), query, callerTenant(c))
 		if err != nil {
 			r.l.Error(err, "http - CIRA configs - v1 - getCount")
 			ErrorResponse(c, err)
@@ -59,16 +70,16 @@ func (r *ciraConfigRoutes) get(c *gin.Context) {
 			Data:  configs,
 		}
 
-		c.JSON(http.StatusOK, countResponse)
+		odata.respond(c, countResponse)
 	} else {
-		c.JSON(http.StatusOK, configs)
+		odata.respond(c, configs)
 	}
 }
 
 func (r *ciraConfigRoutes) getByName(c *gin.Context) {
 	configName := c.Param("ciraConfigName")
 
-	foundConfig, err := r.cira.GetByName(c.Request.Context(), configName, "")
+	foundConfig, err := r.cira.GetByName(c.Request.Context(), configName, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - getByName")
 		ErrorResponse(c, err)
@@ -76,6 +87,7 @@ func (r *ciraConfigRoutes) getByName(c *gin.Context) {
 		return
 	}
 
+	setETag(c, foundConfig.Version)
 	c.JSON(http.StatusOK, foundConfig)
 }
 
@@ -88,6 +100,8 @@ func (r *ciraConfigRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = callerTenant(c)
+
 	newCiraConfig, err := r.cira.Insert(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - insert")
@@ -108,6 +122,15 @@ func (r *ciraConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = callerTenant(c)
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	config.Version = version
+
 	updatedConfig, err := r.cira.Update(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - update")
@@ -116,13 +139,19 @@ func (r *ciraConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	setETag(c, updatedConfig.Version)
 	c.JSON(http.StatusOK, updatedConfig)
 }
 
 func (r *ciraConfigRoutes) delete(c *gin.Context) {
 	configName := c.Param("ciraConfigName")
 
-	err := r.cira.Delete(c.Request.Context(), configName, "")
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	err := r.cira.Delete(c.Request.Context(), configName, version, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - delete")
 		ErrorResponse(c, err)
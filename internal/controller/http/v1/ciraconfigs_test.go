@@ -16,6 +16,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/ciraconfigs"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 func ciraconfigsTest(t *testing.T) (*mocks.MockCIRAConfigsFeature, *gin.Engine) {
@@ -46,8 +47,8 @@ type ciraconfigTest struct {
 }
 
 var (
-	requestCIRAConfig  = dto.CIRAConfig{ConfigName: "ciraconfig", MPSAddress: "https://example.com", MPSPort: 4433, Username: "username", Password: "password", CommonName: "example.com", ServerAddressFormat: 201, AuthMethod: 2, MPSRootCertificate: "-----BEGIN CERTIFICATE-----\n...", ProxyDetails: "http://example.com", TenantID: "abc123", RegeneratePassword: true, Version: "1.0.0"}
-	responseCIRAConfig = dto.CIRAConfig{ConfigName: "ciraconfig", MPSAddress: "https://example.com", MPSPort: 4433, Username: "username", Password: "password", CommonName: "example.com", ServerAddressFormat: 201, AuthMethod: 2, MPSRootCertificate: "-----BEGIN CERTIFICATE-----\n...", ProxyDetails: "http://example.com", TenantID: "abc123", RegeneratePassword: true, Version: "1.0.0"}
+	requestCIRAConfig  = dto.CIRAConfig{ConfigName: "ciraconfig", MPSAddress: "https://example.com", MPSPort: 4433, Username: "username", Password: "password", CommonName: "example.com", ServerAddressFormat: 201, AuthMethod: 2, MPSRootCertificate: "-----BEGIN CERTIFICATE-----\n...", ProxyDetails: "http://example.com", RegeneratePassword: true, Version: "1.0.0"}
+	responseCIRAConfig = dto.CIRAConfig{ConfigName: "ciraconfig", MPSAddress: "https://example.com", MPSPort: 4433, Username: "username", Password: "password", CommonName: "example.com", ServerAddressFormat: 201, AuthMethod: 2, MPSRootCertificate: "-----BEGIN CERTIFICATE-----\n...", ProxyDetails: "http://example.com", RegeneratePassword: true, Version: "1.0.0"}
 )
 
 func TestCIRAConfigRoutes(t *testing.T) {
@@ -59,7 +60,7 @@ func TestCIRAConfigRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/ciraconfigs",
 			mock: func(ciraconfig *mocks.MockCIRAConfigsFeature) {
-				ciraconfig.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.CIRAConfig{{
+				ciraconfig.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return([]dto.CIRAConfig{{
 					ConfigName: "config",
 				}}, nil)
 			},
@@ -71,10 +72,10 @@ func TestCIRAConfigRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/ciraconfigs?$top=10&$skip=1&$count=true",
 			mock: func(ciraconfig *mocks.MockCIRAConfigsFeature) {
-				ciraconfig.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.CIRAConfig{{
+				ciraconfig.EXPECT().Get(context.Background(), odata.Query{}, 10, 1, "").Return([]dto.CIRAConfig{{
 					ConfigName: "config",
 				}}, nil)
-				ciraconfig.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+				ciraconfig.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(1, nil)
 			},
 			response:     dto.CIRAConfigCountResponse{Count: 1, Data: []dto.CIRAConfig{{ConfigName: "config"}}},
 			expectedCode: http.StatusOK,
@@ -84,7 +85,7 @@ func TestCIRAConfigRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/ciraconfigs",
 			mock: func(ciraconfig *mocks.MockCIRAConfigsFeature) {
-				ciraconfig.EXPECT().Get(context.Background(), 25, 0, "").Return(nil, ciraconfigs.ErrDatabase)
+				ciraconfig.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return(nil, ciraconfigs.ErrDatabase)
 			},
 			response:     ciraconfigs.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -127,7 +128,6 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
@@ -153,7 +153,6 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
@@ -174,14 +173,13 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
 				ciraconfig.EXPECT().Insert(context.Background(), ciraconfig400Test).Return(nil, ciraconfigs.ErrDatabase)
 			},
 			response:     ciraconfigs.ErrDatabase,
-			requestBody:  dto.CIRAConfig{ConfigName: "ciraconfig", ServerAddressFormat: 201, AuthMethod: 2, MPSRootCertificate: "-----BEGIN CERTIFICATE-----\n...", ProxyDetails: "http://example.com", TenantID: "abc123", RegeneratePassword: true, Version: "1.0.0"},
+			requestBody:  dto.CIRAConfig{ConfigName: "ciraconfig", ServerAddressFormat: 201, AuthMethod: 2, MPSRootCertificate: "-----BEGIN CERTIFICATE-----\n...", ProxyDetails: "http://example.com", RegeneratePassword: true, Version: "1.0.0"},
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -189,7 +187,7 @@ func TestCIRAConfigRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/ciraconfigs/profile",
 			mock: func(ciraconfig *mocks.MockCIRAConfigsFeature) {
-				ciraconfig.EXPECT().Delete(context.Background(), "profile", "").Return(nil)
+				ciraconfig.EXPECT().Delete(context.Background(), "profile", "", "").Return(nil)
 			},
 			response:     nil,
 			expectedCode: http.StatusNoContent,
@@ -199,7 +197,7 @@ func TestCIRAConfigRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/ciraconfigs/profile",
 			mock: func(ciraconfig *mocks.MockCIRAConfigsFeature) {
-				ciraconfig.EXPECT().Delete(context.Background(), "profile", "").Return(ciraconfigs.ErrDatabase)
+				ciraconfig.EXPECT().Delete(context.Background(), "profile", "", "").Return(ciraconfigs.ErrDatabase)
 			},
 			response:     ciraconfigs.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -220,11 +218,9 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
-					Version:             "1.0.0",
 				}
-				ciraconfig.EXPECT().Update(context.Background(), ciraconfigTest).Return(ciraconfigTest, nil)
+				ciraconfig.EXPECT().Update(context.Background(), ciraconfigTest).Return(&responseCIRAConfig, nil)
 			},
 			response:     responseCIRAConfig,
 			requestBody:  requestCIRAConfig,
@@ -246,9 +242,7 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
-					Version:             "1.0.0",
 				}
 				ciraconfig.EXPECT().Update(context.Background(), ciraconfigTest).Return(nil, ciraconfigs.ErrDatabase)
 			},
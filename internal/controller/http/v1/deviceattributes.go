@@ -0,0 +1,178 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/deviceattributes"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationDeviceAttributes = dto.NotValidError{Console: consoleerrors.CreateConsoleError("DeviceAttributesAPI")}
+
+type deviceAttributeRoutes struct {
+	a deviceattributes.Feature
+	l logger.Interface
+}
+
+func NewDeviceAttributeRoutes(handler *gin.RouterGroup, a deviceattributes.Feature, l logger.Interface) {
+	r := &deviceAttributeRoutes{a, l}
+
+	h := handler.Group("/attributes")
+	{
+		h.GET("", r.get)
+		h.GET(":name", r.getByName)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":name", r.delete)
+	}
+}
+
+// @Summary     Show Device Attributes
+// @Description Show the custom attributes defined for devices
+// @ID          deviceAttributes
+// @Tags  	    attributes
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceAttributeCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/attributes [get]
+func (r *deviceAttributeRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationDeviceAttributes.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.a.Get(c.Request.Context(), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.a.GetCount(c.Request.Context(), callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.DeviceAttributeCountResponse{Count: count, Data: items})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Device Attribute
+// @Description Show a custom device attribute by name
+// @ID          deviceAttribute
+// @Tags  	    attributes
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceAttribute
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/attributes/:name [get]
+func (r *deviceAttributeRoutes) getByName(c *gin.Context) {
+	item, err := r.a.GetByName(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByName")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Add Device Attribute
+// @Description Define a custom attribute of type string, number, date or enum for devices
+// @ID          insertDeviceAttribute
+// @Tags  	    attributes
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.DeviceAttribute
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/attributes [post]
+func (r *deviceAttributeRoutes) insert(c *gin.Context) {
+	var attribute dto.DeviceAttribute
+	if err := c.ShouldBindJSON(&attribute); err != nil {
+		validationErr := ErrValidationDeviceAttributes.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	attribute.TenantID = callerTenant(c)
+
+	newAttribute, err := r.a.Insert(c.Request.Context(), &attribute)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newAttribute)
+}
+
+// @Summary     Edit Device Attribute
+// @Description Change the type, enum values or description of a custom device attribute. The type cannot change while devices
+// @Description have a value for the attribute, nor can enum values be removed while devices have them.
+// @ID          updateDeviceAttribute
+// @Tags  	    attributes
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceAttribute
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/attributes [patch]
+func (r *deviceAttributeRoutes) update(c *gin.Context) {
+	var attribute dto.DeviceAttribute
+	if err := c.ShouldBindJSON(&attribute); err != nil {
+		validationErr := ErrValidationDeviceAttributes.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	attribute.TenantID = callerTenant(c)
+
+	updatedAttribute, err := r.a.Update(c.Request.Context(), &attribute)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedAttribute)
+}
+
+// @Summary     Remove Device Attribute
+// @Description Remove a custom device attribute and the values devices have for it
+// @ID          deleteDeviceAttribute
+// @Tags  	    attributes
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/attributes/:name [delete]
+func (r *deviceAttributeRoutes) delete(c *gin.Context) {
+	err := r.a.Delete(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
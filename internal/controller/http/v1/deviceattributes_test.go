@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/deviceattributes"
+	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+func TestDeviceAttributeRoutes(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*mocks.MockDeviceAttributesFeature, *gin.Engine) {
+		t.Helper()
+
+		attributes := mocks.NewMockDeviceAttributesFeature(gomock.NewController(t))
+
+		engine := gin.New()
+		handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+			setTenant(c, "t1")
+		})
+
+		NewDeviceAttributeRoutes(handler, attributes, logger.New("error"))
+
+		return attributes, engine
+	}
+
+	serve := func(engine *gin.Engine, method, url string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+
+		req, _ := http.NewRequestWithContext(context.Background(), method, url, bytes.NewBuffer(data))
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("insert in the tenant of the caller", func(t *testing.T) {
+		t.Parallel()
+
+		attributes, engine := setup(t)
+
+		attribute := &dto.DeviceAttribute{Name: "warrantyEnd", Type: dto.AttributeDate, TenantID: "t1"}
+		attributes.EXPECT().Insert(gomock.Any(), attribute).Return(attribute, nil)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/attributes", dto.DeviceAttribute{Name: "warrantyEnd", Type: dto.AttributeDate, TenantID: "t2"})
+		require.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("list with count", func(t *testing.T) {
+		t.Parallel()
+
+		attributes, engine := setup(t)
+
+		items := []dto.DeviceAttribute{{Name: "owner", Type: dto.AttributeString, TenantID: "t1"}}
+
+		attributes.EXPECT().Get(gomock.Any(), 25, 0, "t1").Return(items, nil)
+		attributes.EXPECT().GetCount(gomock.Any(), "t1").Return(1, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/attributes?$count=true", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(dto.DeviceAttributeCountResponse{Count: 1, Data: items})
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		t.Parallel()
+
+		attributes, engine := setup(t)
+
+		attributes.EXPECT().Delete(gomock.Any(), "unknown", "t1").Return(deviceattributes.ErrNotFound)
+
+		w := serve(engine, http.MethodDelete, "/api/v1/admin/attributes/unknown", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestDevicesExport(t *testing.T) {
+	t.Parallel()
+
+	ctl := gomock.NewController(t)
+	device := mocks.NewMockDeviceManagementFeature(ctl)
+	exporter := mocks.NewMockExporter(ctl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1", func(c *gin.Context) {
+		setTenant(c, "t1")
+	})
+
+	NewDeviceRoutes(handler, device, nil, exporter, logger.New("error"))
+
+	q := odata.Query{Filter: odata.Comparison{Property: "owner", Operator: odata.Equal, Value: "ops"}}
+	items := []dto.Device{{GUID: "guid1", Attributes: map[string]interface{}{"owner": "ops"}}}
+
+	device.EXPECT().GetPage(gomock.Any(), q, exportPageSize, "", "t1").Return(items, "", nil).Times(2)
+	exporter.EXPECT().ExportDevicesCSV(items).Return(strings.NewReader("GUID,owner\nguid1,ops\n"), nil)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/devices/export?$filter=owner%20eq%20'ops'", http.NoBody)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	require.Equal(t, "GUID,owner\nguid1,ops\n", w.Body.String())
+
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/devices/export?format=json&$filter=owner%20eq%20'ops'", http.NoBody)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "attachment; filename=devices.json", w.Header().Get("Content-Disposition"))
+}
@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/devicegroups"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationDeviceGroups = dto.NotValidError{Console: consoleerrors.CreateConsoleError("DeviceGroupsAPI")}
+
+type deviceGroupRoutes struct {
+	g devicegroups.Feature
+	l logger.Interface
+}
+
+func NewDeviceGroupRoutes(handler *gin.RouterGroup, g devicegroups.Feature, l logger.Interface) {
+	r := &deviceGroupRoutes{g, l}
+
+	h := handler.Group("/groups")
+	{
+		h.GET("", r.get)
+		h.GET("evaluate", r.evaluate)
+		h.GET(":name", r.getByName)
+		h.GET(":name/members", r.getMembers)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":name", r.delete)
+	}
+}
+
+// @Summary     Show Device Groups
+// @Description Show all device groups
+// @ID          deviceGroups
+// @Tags  	    groups
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceGroupCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/groups [get]
+func (r *deviceGroupRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationDeviceGroups.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.g.Get(c.Request.Context(), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.g.GetCount(c.Request.Context(), callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.DeviceGroupCountResponse{Count: count, Data: items})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Device Group
+// @Description Show device group by name
+// @ID          deviceGroup
+// @Tags  	    groups
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceGroup
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/groups/:name [get]
+func (r *deviceGroupRoutes) getByName(c *gin.Context) {
+	item, err := r.g.GetByName(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByName")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Show Device Group Members
+// @Description Show the devices of a group, its subgroups included, narrowed down by $filter
+// @ID          deviceGroupMembers
+// @Tags  	    groups
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/groups/:name/members [get]
+func (r *deviceGroupRoutes) getMembers(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationDeviceGroups.Wrap("getMembers", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	query, err := odata.Query()
+	if err != nil {
+		validationErr := ErrValidationDeviceGroups.Wrap("getMembers", "odata.Query", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.g.GetMembers(c.Request.Context(), c.Param("name"), query, odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getMembers")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.g.GetMemberCount(c.Request.Context(), c.Param("name"), query, callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getMemberCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		odata.respond(c, dto.DeviceCountResponse{Count: count, Data: items})
+	} else {
+		odata.respond(c, items)
+	}
+}
+
+// @Summary     Evaluate a Device Group Rule
+// @Description Count the devices a rule matches and show a page of them without saving a group
+// @ID          evaluateDeviceGroupRule
+// @Tags  	    groups
+// @Accept      json
+// @Produce     json
+// @Param       rule query string true "Rule" example(currentMode eq 'ACM' and fwVersion startswith '16.')
+// @Success     200 {object} dto.DeviceCountResponse
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/groups/evaluate [get]
+func (r *deviceGroupRoutes) evaluate(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationDeviceGroups.Wrap("evaluate", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	result, err := r.g.Evaluate(c.Request.Context(), c.Query("rule"), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - evaluate")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary     Add Device Group
+// @Description Add a static group listing its devices or a dynamic group with a rule
+// @ID          insertDeviceGroup
+// @Tags  	    groups
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.DeviceGroup
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/groups [post]
+func (r *deviceGroupRoutes) insert(c *gin.Context) {
+	var group dto.DeviceGroup
+	if err := c.ShouldBindJSON(&group); err != nil {
+		validationErr := ErrValidationDeviceGroups.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	group.TenantID = callerTenant(c)
+
+	newGroup, err := r.g.Insert(c.Request.Context(), &group)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newGroup)
+}
+
+// @Summary     Edit Device Group
+// @Description Change the description, rule, devices or subgroups of a group
+// @ID          updateDeviceGroup
+// @Tags  	    groups
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceGroup
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/groups [patch]
+func (r *deviceGroupRoutes) update(c *gin.Context) {
+	var group dto.DeviceGroup
+	if err := c.ShouldBindJSON(&group); err != nil {
+		validationErr := ErrValidationDeviceGroups.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	group.TenantID = callerTenant(c)
+
+	updatedGroup, err := r.g.Update(c.Request.Context(), &group)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedGroup)
+}
+
+// @Summary     Remove Device Group
+// @Description Remove a group that is not a subgroup of another group
+// @ID          deleteDeviceGroup
+// @Tags  	    groups
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/groups/:name [delete]
+func (r *deviceGroupRoutes) delete(c *gin.Context) {
+	err := r.g.Delete(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/devicegroups"
+	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+func deviceGroupsTest(t *testing.T) (*mocks.MockDeviceGroupsFeature, *mocks.MockDeviceManagementFeature, *gin.Engine) {
+	t.Helper()
+
+	ctl := gomock.NewController(t)
+	groups := mocks.NewMockDeviceGroupsFeature(ctl)
+	device := mocks.NewMockDeviceManagementFeature(ctl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1", func(c *gin.Context) {
+		setTenant(c, "t1")
+	})
+
+	NewDeviceRoutes(handler, device, groups, nil, logger.New("error"))
+	NewDeviceGroupRoutes(handler.Group("/admin"), groups, logger.New("error"))
+
+	return groups, device, engine
+}
+
+func TestDeviceGroupRoutes(t *testing.T) {
+	t.Parallel()
+
+	serve := func(engine *gin.Engine, method, url string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+
+		req, _ := http.NewRequestWithContext(context.Background(), method, url, bytes.NewBuffer(data))
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("insert in the tenant of the caller", func(t *testing.T) {
+		t.Parallel()
+
+		groups, _, engine := deviceGroupsTest(t)
+
+		group := &dto.DeviceGroup{Name: "site-x", Rule: "tags eq 'site-x'", TenantID: "t1"}
+		groups.EXPECT().Insert(gomock.Any(), group).Return(group, nil)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/groups", dto.DeviceGroup{Name: "site-x", Rule: "tags eq 'site-x'"})
+		require.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("members with count", func(t *testing.T) {
+		t.Parallel()
+
+		groups, _, engine := deviceGroupsTest(t)
+
+		q := odata.Query{Filter: odata.Comparison{Property: "hostname", Operator: odata.Equal, Value: "lab"}}
+		members := []dto.Device{{GUID: "guid1"}}
+
+		groups.EXPECT().GetMembers(gomock.Any(), "site-x", q, 25, 0, "t1").Return(members, nil)
+		groups.EXPECT().GetMemberCount(gomock.Any(), "site-x", q, "t1").Return(1, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/groups/site-x/members?$count=true&$filter=hostname%20eq%20'lab'", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(dto.DeviceCountResponse{Count: 1, Data: members})
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("evaluate", func(t *testing.T) {
+		t.Parallel()
+
+		groups, _, engine := deviceGroupsTest(t)
+
+		groups.EXPECT().Evaluate(gomock.Any(), "currentMode eq 'ACM'", 25, 0, "t1").Return(dto.DeviceCountResponse{Count: 0, Data: []dto.Device{}}, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/groups/evaluate?rule=currentMode%20eq%20'ACM'", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		t.Parallel()
+
+		groups, _, engine := deviceGroupsTest(t)
+
+		groups.EXPECT().GetByName(gomock.Any(), "unknown", "t1").Return(nil, devicegroups.ErrNotFound)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/groups/unknown", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("group as device selector", func(t *testing.T) {
+		t.Parallel()
+
+		groups, device, engine := deviceGroupsTest(t)
+
+		selected := odata.Query{Filter: odata.Comparison{Property: "guid", Operator: odata.In, Value: []interface{}{"guid1"}}}
+
+		groups.EXPECT().Members(gomock.Any(), "site-x", odata.Query{}, "t1").Return(selected, nil)
+		device.EXPECT().Get(gomock.Any(), selected, 25, 0, "t1").Return([]dto.Device{{GUID: "guid1"}}, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/devices?group=site-x", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -61,5 +61,11 @@ func NewAmtRoutes(handler *gin.RouterGroup, d devices.Feature, amt amtexplorer.F
 		// KVM display settings
 		h.GET("kvm/displays/:guid", r.getKVMDisplays)
 		h.PUT("kvm/displays/:guid", r.setKVMDisplays)
+		h.GET("kvm/screenshot/:guid", r.getKVMScreenshot)
+
+		// Shared redirection sessions
+		h.GET("redirection/sessions/:guid", r.getRedirectionSessions)
+		h.POST("redirection/sessions/:guid/:mode/viewers/:id/promote", r.promoteRedirectionViewer)
+		h.DELETE("redirection/sessions/:guid/:mode/viewers/:id", r.revokeRedirectionViewer)
 	}
 }
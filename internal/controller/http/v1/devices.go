@@ -1,28 +1,37 @@
 package v1
 
 import (
+	"errors"
+	"io"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 
-	"github.com/device-management-toolkit/console/config"
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/devicegroups"
 	"github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/internal/usecase/export"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type deviceRoutes struct {
 	t devices.Feature
+	g devicegroups.Feature
+	e export.Exporter
 	l logger.Interface
 }
 
-var ErrValidationDevices = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ProfileAPI")}
+var (
+	ErrValidationDevices = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ProfileAPI")}
 
-func NewDeviceRoutes(handler *gin.RouterGroup, t devices.Feature, l logger.Interface) {
-	r := &deviceRoutes{t, l}
+	errSkipTokenFilter = errors.New("$skiptoken cannot be combined with hostname, friendlyName or tags, use $filter")
+)
+
+func NewDeviceRoutes(handler *gin.RouterGroup, t devices.Feature, g devicegroups.Feature, e export.Exporter, l logger.Interface) {
+	r := &deviceRoutes{t, g, e, l}
 
 	handler.GET("authorize/redirection/:id", r.LoginRedirection)
 
@@ -30,6 +39,10 @@ func NewDeviceRoutes(handler *gin.RouterGroup, t devices.Feature, l logger.Inter
 	{
 		h.GET("", r.get)
 		h.GET("stats", r.getStats)
+		h.GET("export", r.export)
+		h.GET("trash", r.getDeleted)
+		h.POST("trash/:guid/restore", r.restore)
+		h.DELETE("trash/:guid", r.purge)
 		h.GET("redirectstatus/:guid", r.redirectStatus)
 		h.GET("cert/:guid", r.getDeviceCertificate)
 		h.POST("cert/:guid", r.pinDeviceCertificate)
@@ -52,7 +65,7 @@ func NewDeviceRoutes(handler *gin.RouterGroup, t devices.Feature, l logger.Inter
 // @Failure     500 {object} response
 // @Router      /api/v1/devices [get]
 func (dr *deviceRoutes) getStats(c *gin.Context) {
-	count, err := dr.t.GetCount(c.Request.Context(), "")
+	count, err := dr.t.GetCount(c.Request.Context(), odata.Query{}, callerTenant(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - getCount")
 		ErrorResponse(c, err)
@@ -68,35 +81,27 @@ func (dr *deviceRoutes) getStats(c *gin.Context) {
 }
 
 // @Summary     route for redirection auth
-// @Description gets token for use with redirection
+// @Description gets a single-use token bound to the device and the requested redirection modes (comma separated, all when omitted)
 // @ID          loginRedirection
 // @Tags  	    devices
 // @Accept      json
 // @Produce     json
+// @Param       mode query string false "redirection modes, e.g. kvm,sol"
 // @Success     200 {object} DeviceCountResponse
 // @Failure     500 {object} response
 // @Router      /api/v1/authorize/redirection [get]
 func (dr *deviceRoutes) LoginRedirection(c *gin.Context) {
 	deviceID := c.Param("id")
 
-	_, err := dr.t.GetByID(c.Request.Context(), deviceID, "", false)
-	if err != nil {
-		dr.l.Error(err, "http - devices - v1 - LoginRedirection")
-		ErrorResponse(c, err)
-
-		return
+	var modes []string
+	if mode := c.Query("mode"); mode != "" {
+		modes = strings.Split(mode, ",")
 	}
-	// Create JWT token
-	expirationTime := time.Now().Add(config.ConsoleConfig.JWTExpiration)
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expirationTime),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	tokenString, err := token.SignedString([]byte(config.ConsoleConfig.JWTKey))
+	tokenString, err := dr.t.IssueRedirectionToken(c.Request.Context(), deviceID, c.GetString(userContextKey), modes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create token"})
+		dr.l.Error(err, "http - devices - v1 - LoginRedirection")
+		ErrorResponse(c, err)
 
 		return
 	}
@@ -110,6 +115,8 @@ func (dr *deviceRoutes) LoginRedirection(c *gin.Context) {
 // @Tags  	    devices
 // @Accept      json
 // @Produce     json
+// @Param       $skiptoken  query string false "skipToken of the previous page, empty for the first one"
+// @Param       approximate query bool   false "estimate $count"
 // @Success     200 {object} DeviceCountResponse
 // @Failure     500 {object} response
 // @Router      /api/v1/devices/:id [get]
@@ -121,26 +128,49 @@ func (dr *deviceRoutes) get(c *gin.Context) {
 		return
 	}
 
+	query, err := dr.query(c, odata)
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
 	tags := c.Query("tags")
 	hostname := c.Query("hostname")
 	friendlyName := c.Query("friendlyName")
 
-	var items []dto.Device
+	paged, err := odata.Paged()
+	if err == nil && paged && (tags != "" || hostname != "" || friendlyName != "") {
+		err = errSkipTokenFilter
+	}
 
-	var err error
+	if err != nil {
+		ErrorResponse(c, ErrValidationDevices.Wrap("get", "odata.Paged", err))
+
+		return
+	}
+
+	if paged {
+		dr.getPage(c, odata, query)
+
+		return
+	}
+
+	var items []dto.Device
 
 	switch {
 	case hostname != "":
-		items, err = dr.getByColumnOrTags(c, "HostName", hostname, odata.Top, odata.Skip, "")
+		items, err = dr.getByColumnOrTags(c, "HostName", hostname, odata.Top, odata.Skip, callerTenant(c))
 
 	case friendlyName != "":
-		items, err = dr.getByColumnOrTags(c, "FriendlyName", friendlyName, odata.Top, odata.Skip, "")
+		items, err = dr.getByColumnOrTags(c, "FriendlyName", friendlyName, odata.Top, odata.Skip, callerTenant(c))
 
 	case tags != "":
-		items, err = dr.getByColumnOrTags(c, "Tags", tags, odata.Top, odata.Skip, "")
+		items, err = dr.getByColumnOrTags(c, "Tags", tags, odata.Top, odata.Skip, callerTenant(c))
 
 	default:
-		items, err = dr.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+		items, err = dr.t.Get(c.Request.Context(), query, odata.Top, odata.Skip, callerTenant(c))
 	}
 
 	if err != nil {
@@ -151,7 +181,7 @@ func (dr *deviceRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := dr.t.GetCount(c.Request.Context(), "")
+		count, err := dr.count(c, odata, query)
 		if err != nil {
 			dr.l.Error(err, "http - devices - v1 - get")
 			ErrorResponse(c, err)
@@ -160,13 +190,137 @@ func (dr *deviceRoutes) get(c *gin.Context) {
 		}
 
 		countResponse := dto.DeviceCountResponse{
-			Count: count,
-			Data:  items,
+			Count:       count,
+			Data:        items,
+			Approximate: odata.Approximate,
 		}
 
-		c.JSON(http.StatusOK, countResponse)
+		odata.respond(c, countResponse)
 	} else {
+		odata.respond(c, items)
+	}
+}
+
+// getPage writes the page of devices following $skiptoken with the token of the next one.
+func (dr *deviceRoutes) getPage(c *gin.Context, o OData, query odata.Query) {
+	items, next, err := dr.t.GetPage(c.Request.Context(), query, o.Top, *o.SkipToken, callerTenant(c))
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - getPage")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	page := dto.DevicePageResponse{Data: items, SkipToken: next}
+
+	if o.Count {
+		count, err := dr.count(c, o, query)
+		if err != nil {
+			dr.l.Error(err, "http - devices - v1 - getPage")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		page.Count = &count
+		page.Approximate = o.Approximate
+	}
+
+	o.respond(c, page)
+}
+
+// count counts the devices of the query, or estimates how many there are when approximate is set.
+func (dr *deviceRoutes) count(c *gin.Context, o OData, query odata.Query) (int, error) {
+	if o.Approximate {
+		return dr.t.EstimateCount(c.Request.Context(), query, callerTenant(c))
+	}
+
+	return dr.t.GetCount(c.Request.Context(), query, callerTenant(c))
+}
+
+// query parses $filter and $orderby and narrows them down to the members of the group in the query, if any.
+func (dr *deviceRoutes) query(c *gin.Context, o OData) (odata.Query, error) {
+	query, err := o.Query()
+	if err != nil {
+		return odata.Query{}, ErrValidationDevices.Wrap("query", "odata.Query", err)
+	}
+
+	// a group targets its members, which $filter narrows down
+	if group := c.Query("group"); group != "" {
+		return dr.g.Members(c.Request.Context(), group, query, callerTenant(c))
+	}
+
+	return query, nil
+}
+
+// @Summary     Export Devices
+// @Description Export the devices matching $filter and group, with their custom attributes, as CSV or JSON
+// @ID          exportDevices
+// @Tags  	    devices
+// @Produce     text/csv
+// @Param       format query string false "csv (default) or json"
+// @Success     200 {file} file
+// @Failure     500 {object} response
+// @Router      /api/v1/devices/export [get]
+func (dr *deviceRoutes) export(c *gin.Context) {
+	var o OData
+	if err := c.ShouldBindQuery(&o); err != nil {
+		ErrorResponse(c, ErrValidationDevices.Wrap("export", "ShouldBindQuery", err))
+
+		return
+	}
+
+	query, err := dr.query(c, o)
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - export")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	var (
+		items     []dto.Device
+		skipToken string
+	)
+
+	for {
+		page, next, err := dr.t.GetPage(c.Request.Context(), query, exportPageSize, skipToken, callerTenant(c))
+		if err != nil {
+			dr.l.Error(err, "http - devices - v1 - export")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		items = append(items, page...)
+
+		if next == "" {
+			break
+		}
+
+		skipToken = next
+	}
+
+	if c.Query("format") == "json" {
+		c.Header("Content-Disposition", "attachment; filename=devices.json")
 		c.JSON(http.StatusOK, items)
+
+		return
+	}
+
+	csvReader, err := dr.e.ExportDevicesCSV(items)
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - export")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=devices.csv")
+	c.Header("Content-Type", "text/csv")
+
+	if _, err = io.Copy(c.Writer, csvReader); err != nil {
+		dr.l.Error(err, "http - devices - v1 - export")
 	}
 }
 
@@ -179,7 +333,7 @@ func (dr *deviceRoutes) getByColumnOrTags(c *gin.Context, column, value string,
 	if column == "Tags" {
 		items, err = dr.t.GetByTags(ctx, value, c.Query("method"), limit, skip, tenantID)
 	} else {
-		items, err = dr.t.GetByColumn(ctx, column, value, "")
+		items, err = dr.t.GetByColumn(ctx, column, value, tenantID)
 	}
 
 	if err != nil {
@@ -208,7 +362,7 @@ func (dr *deviceRoutes) getByID(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "", false)
+	item, err := dr.t.GetByID(c.Request.Context(), guid, callerTenant(c), false)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - get")
 		ErrorResponse(c, err)
@@ -237,6 +391,9 @@ func (dr *deviceRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	// devices always belong to the tenant of the caller, whatever the body says
+	device.TenantID = callerTenant(c)
+
 	newDevice, err := dr.t.Insert(c.Request.Context(), &device)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - insert")
@@ -265,6 +422,8 @@ func (dr *deviceRoutes) update(c *gin.Context) {
 		return
 	}
 
+	device.TenantID = callerTenant(c)
+
 	updatedDevice, err := dr.t.Update(c.Request.Context(), &device)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - update")
@@ -288,7 +447,7 @@ func (dr *deviceRoutes) update(c *gin.Context) {
 func (dr *deviceRoutes) delete(c *gin.Context) {
 	guid := c.Param("guid")
 
-	err := dr.t.Delete(c.Request.Context(), guid, "")
+	err := dr.t.Delete(c.Request.Context(), guid, callerTenant(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - delete")
 		ErrorResponse(c, err)
@@ -299,6 +458,76 @@ func (dr *deviceRoutes) delete(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// @Summary     Show Deleted Devices
+// @Description Show the devices in the trash, which are purged once the retention is over
+// @ID          getDeletedDevices
+// @Tags  	    devices
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.Device
+// @Failure     500 {object} response
+// @Router      /api/v1/devices/trash [get]
+func (dr *deviceRoutes) getDeleted(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		ErrorResponse(c, ErrValidationDevices.Wrap("getDeleted", "ShouldBindQuery", err))
+
+		return
+	}
+
+	items, err := dr.t.GetDeleted(c.Request.Context(), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - getDeleted")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary     Restore Device
+// @Description Take a device out of the trash
+// @ID          restoreDevice
+// @Tags  	    devices
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/devices/trash/:guid/restore [post]
+func (dr *deviceRoutes) restore(c *gin.Context) {
+	err := dr.t.Restore(c.Request.Context(), c.Param("guid"), callerTenant(c))
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - restore")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Purge Device
+// @Description Remove a device in the trash for good, with its credentials and pinned certificate
+// @ID          purgeDevice
+// @Tags  	    devices
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/devices/trash/:guid [delete]
+func (dr *deviceRoutes) purge(c *gin.Context) {
+	err := dr.t.Purge(c.Request.Context(), c.Param("guid"), callerTenant(c))
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - purge")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
 func (dr *deviceRoutes) redirectStatus(c *gin.Context) {
 	_ = c.Param("guid")
 	result := map[string]bool{
@@ -318,7 +547,7 @@ func (dr *deviceRoutes) redirectStatus(c *gin.Context) {
 // @Failure     500 {object} response
 // @Router      /api/v1/devices/tags [get]
 func (dr *deviceRoutes) getTags(c *gin.Context) {
-	tags, err := dr.t.GetDistinctTags(c.Request.Context(), "")
+	tags, err := dr.t.GetDistinctTags(c.Request.Context(), callerTenant(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - tags")
 		ErrorResponse(c, err)
@@ -348,7 +577,7 @@ func (dr *deviceRoutes) getDeviceCertificate(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "", false)
+	item, err := dr.t.GetByID(c.Request.Context(), guid, callerTenant(c), false)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - cert")
 		ErrorResponse(c, err)
@@ -388,7 +617,7 @@ func (dr *deviceRoutes) pinDeviceCertificate(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "", true)
+	item, err := dr.t.GetByID(c.Request.Context(), guid, callerTenant(c), true)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - deleteDeviceCertificate - getById")
 		ErrorResponse(c, err)
@@ -428,7 +657,7 @@ func (dr *deviceRoutes) deleteDeviceCertificate(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "", true)
+	item, err := dr.t.GetByID(c.Request.Context(), guid, callerTenant(c), true)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - deleteDeviceCertificate - getById")
 		ErrorResponse(c, err)
@@ -17,6 +17,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/devices"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 func devicesTest(t *testing.T) (*mocks.MockDeviceManagementFeature, *gin.Engine) {
@@ -31,7 +32,7 @@ func devicesTest(t *testing.T) (*mocks.MockDeviceManagementFeature, *gin.Engine)
 	engine := gin.New()
 	handler := engine.Group("/api/v1")
 
-	NewDeviceRoutes(handler, device, log)
+	NewDeviceRoutes(handler, device, nil, nil, log)
 
 	return device, engine
 }
@@ -48,20 +49,32 @@ type deviceTest struct {
 
 var (
 	timeNow        = time.Now().UTC()
-	requestDevice  = dto.Device{ConnectionStatus: true, MPSInstance: "mpsInstance", Hostname: "hostname", GUID: "guid", MPSUsername: "mpsusername", Tags: []string{"tag1", "tag2"}, TenantID: "tenantId", FriendlyName: "friendlyName", DNSSuffix: "dnsSuffix", Username: "admin", Password: "password", UseTLS: true, AllowSelfSigned: true, LastConnected: &timeNow, LastSeen: &timeNow, LastDisconnected: &timeNow}
-	responseDevice = dto.Device{ConnectionStatus: true, MPSInstance: "mpsInstance", Hostname: "hostname", GUID: "guid", MPSUsername: "mpsusername", Tags: []string{"tag1", "tag2"}, TenantID: "tenantId", FriendlyName: "friendlyName", DNSSuffix: "dnsSuffix", Username: "admin", Password: "password", UseTLS: true, AllowSelfSigned: true, LastConnected: &timeNow, LastSeen: &timeNow, LastDisconnected: &timeNow}
+	requestDevice  = dto.Device{ConnectionStatus: true, MPSInstance: "mpsInstance", Hostname: "hostname", GUID: "guid", MPSUsername: "mpsusername", Tags: []string{"tag1", "tag2"}, FriendlyName: "friendlyName", DNSSuffix: "dnsSuffix", Username: "admin", Password: "password", UseTLS: true, AllowSelfSigned: true, LastConnected: &timeNow, LastSeen: &timeNow, LastDisconnected: &timeNow}
+	responseDevice = dto.Device{ConnectionStatus: true, MPSInstance: "mpsInstance", Hostname: "hostname", GUID: "guid", MPSUsername: "mpsusername", Tags: []string{"tag1", "tag2"}, FriendlyName: "friendlyName", DNSSuffix: "dnsSuffix", Username: "admin", Password: "password", UseTLS: true, AllowSelfSigned: true, LastConnected: &timeNow, LastSeen: &timeNow, LastDisconnected: &timeNow}
 )
 
 func TestDevicesRoutes(t *testing.T) {
 	t.Parallel()
 
+	estimatedCount := 50000
+
 	tests := []deviceTest{
+		{
+			name:   "authorize redirection for kvm",
+			method: http.MethodGet,
+			url:    "/api/v1/authorize/redirection/guid?mode=kvm,sol",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().IssueRedirectionToken(context.Background(), "guid", "", []string{"kvm", "sol"}).Return("token", nil)
+			},
+			response:     map[string]string{"token": "token"},
+			expectedCode: http.StatusOK,
+		},
 		{
 			name:   "get all devices",
 			method: http.MethodGet,
 			url:    "/api/v1/devices",
 			mock: func(device *mocks.MockDeviceManagementFeature) {
-				device.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.Device{{
+				device.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return([]dto.Device{{
 					GUID: "guid", MPSUsername: "mpsusername", Username: "admin", Password: "password", ConnectionStatus: true, Hostname: "hostname",
 				}}, nil)
 			},
@@ -73,14 +86,73 @@ func TestDevicesRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/devices?$top=10&$skip=1&$count=true",
 			mock: func(device *mocks.MockDeviceManagementFeature) {
-				device.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.Device{{
+				device.EXPECT().Get(context.Background(), odata.Query{}, 10, 1, "").Return([]dto.Device{{
 					GUID: "guid", MPSUsername: "mpsusername", Username: "admin", Password: "password", ConnectionStatus: true, Hostname: "hostname",
 				}}, nil)
-				device.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+				device.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(1, nil)
 			},
 			response:     dto.DeviceCountResponse{Count: 1, Data: []dto.Device{{GUID: "guid", MPSUsername: "mpsusername", Username: "admin", Password: "password", ConnectionStatus: true, Hostname: "hostname"}}},
 			expectedCode: http.StatusOK,
 		},
+		{
+			name:   "get all devices - filtered, ordered and selected",
+			method: http.MethodGet,
+			url:    "/api/v1/devices?$filter=hostname%20startswith%20'lab'&$orderby=hostname&$select=guid,hostname&$count=true",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				query := odata.Query{
+					Filter:  odata.Comparison{Property: "hostname", Operator: odata.StartsWith, Value: "lab"},
+					OrderBy: []odata.Order{{Property: "hostname"}},
+				}
+				device.EXPECT().Get(context.Background(), query, 25, 0, "").Return([]dto.Device{{
+					GUID: "guid", MPSUsername: "mpsusername", Username: "admin", Password: "password", ConnectionStatus: true, Hostname: "lab-1",
+				}}, nil)
+				device.EXPECT().GetCount(context.Background(), query, "").Return(1, nil)
+			},
+			response:     map[string]interface{}{"totalCount": 1, "data": []map[string]string{{"guid": "guid", "hostname": "lab-1"}}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "get all devices - invalid filter",
+			method:       http.MethodGet,
+			url:          "/api/v1/devices?$filter=hostname%20eq",
+			mock:         func(_ *mocks.MockDeviceManagementFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "get all devices - first page",
+			method: http.MethodGet,
+			url:    "/api/v1/devices?$skiptoken=&$top=2&$select=guid",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().GetPage(context.Background(), odata.Query{}, 2, "", "").Return([]dto.Device{{GUID: "guid", Hostname: "hostname"}}, "next", nil)
+			},
+			response:     map[string]interface{}{"data": []map[string]string{{"guid": "guid"}}, "skipToken": "next"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get all devices - last page with an approximate count",
+			method: http.MethodGet,
+			url:    "/api/v1/devices?$skiptoken=next&$count=true&approximate=true",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().GetPage(context.Background(), odata.Query{}, 25, "next", "").Return([]dto.Device{{GUID: "guid"}}, "", nil)
+				device.EXPECT().EstimateCount(context.Background(), odata.Query{}, "").Return(50000, nil)
+			},
+			response:     dto.DevicePageResponse{Data: []dto.Device{{GUID: "guid"}}, Count: &estimatedCount, Approximate: true},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "get all devices - $skip with $skiptoken",
+			method:       http.MethodGet,
+			url:          "/api/v1/devices?$skip=5&$skiptoken=next",
+			mock:         func(_ *mocks.MockDeviceManagementFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "get all devices - hostname with $skiptoken",
+			method:       http.MethodGet,
+			url:          "/api/v1/devices?hostname=lab&$skiptoken=",
+			mock:         func(_ *mocks.MockDeviceManagementFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
 		{
 			name:   "get device by id",
 			method: http.MethodGet,
@@ -108,7 +180,7 @@ func TestDevicesRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/devices",
 			mock: func(device *mocks.MockDeviceManagementFeature) {
-				device.EXPECT().Get(context.Background(), 25, 0, "").Return(nil, devices.ErrDatabase)
+				device.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return(nil, devices.ErrDatabase)
 			},
 			response:     devices.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -125,7 +197,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -154,7 +225,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -191,6 +261,36 @@ func TestDevicesRoutes(t *testing.T) {
 			response:     devices.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			name:   "get deleted devices",
+			method: http.MethodGet,
+			url:    "/api/v1/devices/trash",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().GetDeleted(context.Background(), 25, 0, "").Return([]dto.Device{{GUID: "guid", DeletedAt: "2025-08-08T00:00:00Z"}}, nil)
+			},
+			response:     []dto.Device{{GUID: "guid", DeletedAt: "2025-08-08T00:00:00Z"}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "restore device",
+			method: http.MethodPost,
+			url:    "/api/v1/devices/trash/guid/restore",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().Restore(context.Background(), "guid", "").Return(nil)
+			},
+			response:     nil,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "purge device - not in the trash",
+			method: http.MethodDelete,
+			url:    "/api/v1/devices/trash/guid",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().Purge(context.Background(), "guid", "").Return(devices.ErrNotFound)
+			},
+			response:     devices.ErrNotFound,
+			expectedCode: http.StatusNotFound,
+		},
 		{
 			name:   "update device",
 			method: http.MethodPatch,
@@ -203,7 +303,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -232,7 +331,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -274,7 +372,7 @@ func TestDevicesRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/devices/stats",
 			mock: func(device *mocks.MockDeviceManagementFeature) {
-				device.EXPECT().GetCount(context.Background(), "").Return(5, nil)
+				device.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(5, nil)
 			},
 			response:     dto.DeviceStatResponse{TotalCount: 5},
 			expectedCode: http.StatusOK,
@@ -319,3 +417,48 @@ func TestDevicesRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestDevicesRoutesTenant(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	device := mocks.NewMockDeviceManagementFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1", func(c *gin.Context) { setTenant(c, "bu-retail") })
+
+	NewDeviceRoutes(handler, device, nil, nil, logger.New("error"))
+
+	retail := dto.Device{GUID: "retail-guid", Hostname: "pos-1", TenantID: "bu-retail"}
+
+	device.EXPECT().Get(gomock.Any(), odata.Query{}, 100, 0, "bu-retail").Return([]dto.Device{retail}, nil)
+	device.EXPECT().GetByID(gomock.Any(), "energy-guid", "bu-retail", false).Return(nil, devices.ErrNotFound)
+	device.EXPECT().Insert(gomock.Any(), &dto.Device{GUID: "new-guid", Hostname: "pos-2", TenantID: "bu-retail"}).
+		DoAndReturn(func(_ context.Context, d *dto.Device) (*dto.Device, error) { return d, nil })
+
+	serve := func(method, url string, body []byte) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(context.Background(), method, url, bytes.NewBuffer(body))
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	w := serve(http.MethodGet, "/api/v1/devices?$top=100", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var listed []dto.Device
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.Equal(t, []dto.Device{retail}, listed)
+
+	w = serve(http.MethodGet, "/api/v1/devices/energy-guid", nil)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// the tenant in the body is ignored
+	body, _ := json.Marshal(dto.Device{GUID: "new-guid", Hostname: "pos-2", TenantID: "bu-energy"})
+	w = serve(http.MethodPost, "/api/v1/devices", body)
+	require.Equal(t, http.StatusCreated, w.Code)
+}
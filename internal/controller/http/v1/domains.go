@@ -54,7 +54,15 @@ func (r *domainRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	query, err := odata.Query()
+	if err != nil {
+		validationErr := ErrValidationDomains.Wrap("get", "odata.Query", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), query, odata.Top, odata.Skip, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - getCount")
 		ErrorResponse(c, err)
@@ -63,7 +71,7 @@ func (r *domainRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), query, callerTenant(c))
 		if err != nil {
 			r.l.Error(err, "http - v1 - getCount")
 			ErrorResponse(c, err)
@@ -74,9 +82,9 @@ func (r *domainRoutes) get(c *gin.Context) {
 			Data:  items,
 		}
 
-		c.JSON(http.StatusOK, countResponse)
+		odata.respond(c, countResponse)
 	} else {
-		c.JSON(http.StatusOK, items)
+		odata.respond(c, items)
 	}
 }
 
@@ -92,7 +100,7 @@ func (r *domainRoutes) get(c *gin.Context) {
 func (r *domainRoutes) getByName(c *gin.Context) {
 	name := c.Param("name")
 
-	item, err := r.t.GetByName(c.Request.Context(), name, "")
+	item, err := r.t.GetByName(c.Request.Context(), name, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - getByName")
 		ErrorResponse(c, err)
@@ -100,6 +108,7 @@ func (r *domainRoutes) getByName(c *gin.Context) {
 		return
 	}
 
+	setETag(c, item.Version)
 	c.JSON(http.StatusOK, item)
 }
 
@@ -121,6 +130,8 @@ func (r *domainRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	domain.TenantID = callerTenant(c)
+
 	newDomain, err := r.t.Insert(c.Request.Context(), &domain)
 	if err != nil {
 		r.l.Error(err, "http - v1 - insert")
@@ -150,6 +161,15 @@ func (r *domainRoutes) update(c *gin.Context) {
 		return
 	}
 
+	domain.TenantID = callerTenant(c)
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	domain.Version = version
+
 	updatedDomain, err := r.t.Update(c.Request.Context(), &domain)
 	if err != nil {
 		r.l.Error(err, "http - v1 - update")
@@ -158,6 +178,7 @@ func (r *domainRoutes) update(c *gin.Context) {
 		return
 	}
 
+	setETag(c, updatedDomain.Version)
 	c.JSON(http.StatusOK, updatedDomain)
 }
 
@@ -173,7 +194,12 @@ func (r *domainRoutes) update(c *gin.Context) {
 func (r *domainRoutes) delete(c *gin.Context) {
 	name := c.Param("name")
 
-	err := r.t.Delete(c.Request.Context(), name, "")
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	err := r.t.Delete(c.Request.Context(), name, version, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - delete")
 		ErrorResponse(c, err)
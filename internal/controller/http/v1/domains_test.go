@@ -16,6 +16,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/domains"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 //nolint:gochecknoinits // required to avoid issues when running tests in parallel
@@ -52,8 +53,8 @@ type test struct {
 }
 
 var (
-	requestDomain  = dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
-	responseDomain = dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+	requestDomain  = dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+	responseDomain = dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
 )
 
 func TestDomainRoutes(t *testing.T) {
@@ -65,7 +66,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domain.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.Domain{{
+				domain.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return([]dto.Domain{{
 					ProfileName: "profile",
 				}}, nil)
 			},
@@ -77,10 +78,10 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/domains?$top=10&$skip=1&$count=true",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domain.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.Domain{{
+				domain.EXPECT().Get(context.Background(), odata.Query{}, 10, 1, "").Return([]dto.Domain{{
 					ProfileName: "profile",
 				}}, nil)
-				domain.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+				domain.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(1, nil)
 			},
 			response:     DomainCountResponse{Count: 1, Data: []dto.Domain{{ProfileName: "profile"}}},
 			expectedCode: http.StatusOK,
@@ -90,7 +91,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domain.EXPECT().Get(context.Background(), 25, 0, "").Return(nil, domains.ErrDatabase)
+				domain.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return(nil, domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -122,7 +123,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
 				domain.EXPECT().Insert(context.Background(), domainTest).Return(domainTest, nil)
 			},
 			response:     responseDomain,
@@ -134,7 +135,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
 				domain.EXPECT().Insert(context.Background(), domainTest).Return(nil, domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
@@ -146,11 +147,11 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domain400Test := &dto.Domain{ProfileName: "p1", TenantID: "t1", DomainSuffix: "domain1.com", ProvisioningCert: "cert1", ProvisioningCertStorageFormat: "string1"}
+				domain400Test := &dto.Domain{ProfileName: "p1", DomainSuffix: "domain1.com", ProvisioningCert: "cert1", ProvisioningCertStorageFormat: "string1"}
 				domain.EXPECT().Insert(context.Background(), domain400Test).Return(nil, domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
-			requestBody:  dto.Domain{ProfileName: "p1", TenantID: "t1", DomainSuffix: "domain1.com", ProvisioningCert: "cert1", ProvisioningCertStorageFormat: "string1"},
+			requestBody:  dto.Domain{ProfileName: "p1", DomainSuffix: "domain1.com", ProvisioningCert: "cert1", ProvisioningCertStorageFormat: "string1"},
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -158,7 +159,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/domains/profile",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domain.EXPECT().Delete(context.Background(), "profile", "").Return(nil)
+				domain.EXPECT().Delete(context.Background(), "profile", "", "").Return(nil)
 			},
 			response:     nil,
 			expectedCode: http.StatusNoContent,
@@ -168,7 +169,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/domains/profile",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domain.EXPECT().Delete(context.Background(), "profile", "").Return(domains.ErrDatabase)
+				domain.EXPECT().Delete(context.Background(), "profile", "", "").Return(domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -178,7 +179,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
 				domain.EXPECT().Update(context.Background(), domainTest).Return(domainTest, nil)
 			},
 			response:     responseDomain,
@@ -190,7 +191,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
 				domain.EXPECT().Update(context.Background(), domainTest).Return(nil, domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
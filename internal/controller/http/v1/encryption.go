@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationEncryption = dto.NotValidError{Console: consoleerrors.CreateConsoleError("EncryptionAPI")}
+
+type encryptionRoutes struct {
+	e encryption.Feature
+	l logger.Interface
+}
+
+func NewEncryptionRoutes(handler *gin.RouterGroup, e encryption.Feature, l logger.Interface) {
+	r := &encryptionRoutes{e, l}
+
+	h := handler.Group("/encryption")
+	{
+		h.POST("rotate", r.rotate)
+	}
+}
+
+// @Summary     Rotate Encryption Key
+// @Description Encrypt every stored secret again with a new key, a key is generated and returned when none is given
+// @ID          rotateEncryptionKey
+// @Tags  	    encryption
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.KeyRotation
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/encryption/rotate [post]
+func (r *encryptionRoutes) rotate(c *gin.Context) {
+	// the key protects the secrets of every tenant
+	if callerTenant(c) != tenants.DefaultTenant {
+		c.AbortWithStatusJSON(http.StatusForbidden, response{"the encryption key can only be rotated from the default tenant"})
+
+		return
+	}
+
+	var req dto.KeyRotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErr := ErrValidationEncryption.Wrap("rotate", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	result, err := r.e.RotateKey(c.Request.Context(), req.NewKey)
+	if err != nil {
+		r.l.Error(err, "http - v1 - rotate")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
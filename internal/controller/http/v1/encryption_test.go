@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func encryptionTest(t *testing.T, tenant string) (*mocks.MockEncryptionFeature, *gin.Engine) {
+	t.Helper()
+
+	encryptionFeature := mocks.NewMockEncryptionFeature(gomock.NewController(t))
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+		setTenant(c, tenant)
+	})
+
+	NewEncryptionRoutes(handler, encryptionFeature, logger.New("error"))
+
+	return encryptionFeature, engine
+}
+
+func TestEncryptionRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		tenant       string
+		request      dto.KeyRotationRequest
+		mock         func(*mocks.MockEncryptionFeature)
+		expectedCode int
+		response     interface{}
+	}{
+		{
+			name:    "rotate to a given key",
+			request: dto.KeyRotationRequest{NewKey: "0123456789abcdef"},
+			mock: func(e *mocks.MockEncryptionFeature) {
+				e.EXPECT().RotateKey(gomock.Any(), "0123456789abcdef").Return(&dto.KeyRotation{Secrets: 3, KeyringUpdated: true}, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     dto.KeyRotation{Secrets: 3, KeyringUpdated: true},
+		},
+		{
+			name: "rotate to a generated key",
+			mock: func(e *mocks.MockEncryptionFeature) {
+				e.EXPECT().RotateKey(gomock.Any(), "").Return(&dto.KeyRotation{Secrets: 3, Key: "generatedkey0123"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     dto.KeyRotation{Secrets: 3, Key: "generatedkey0123"},
+		},
+		{
+			name:    "invalid key",
+			request: dto.KeyRotationRequest{NewKey: "short"},
+			mock: func(e *mocks.MockEncryptionFeature) {
+				e.EXPECT().RotateKey(gomock.Any(), "short").Return(nil, encryption.ErrValidation.Wrap("RotateKey", "len(newKey)", errors.New("invalid")))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "caller of a tenant",
+			tenant:       "bu-retail",
+			request:      dto.KeyRotationRequest{NewKey: "0123456789abcdef"},
+			mock:         func(_ *mocks.MockEncryptionFeature) {},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.tenant == "" {
+				tc.tenant = tenants.DefaultTenant
+			}
+
+			encryptionFeature, engine := encryptionTest(t, tc.tenant)
+			tc.mock(encryptionFeature)
+
+			data, _ := json.Marshal(tc.request)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/admin/encryption/rotate", bytes.NewBuffer(data))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				expected, _ := json.Marshal(tc.response)
+				require.JSONEq(t, string(expected), w.Body.String())
+			}
+		})
+	}
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/usecase/devices"
 	"github.com/device-management-toolkit/console/internal/usecase/domains"
+	"github.com/device-management-toolkit/console/internal/usecase/profiletemplates"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type response struct {
@@ -26,10 +28,14 @@ func ErrorResponse(c *gin.Context, err error) {
 		notValidErr     dto.NotValidError
 		dbErr           sqldb.DatabaseError
 		NotUniqueErr    sqldb.NotUniqueError
+		versionErr      sqldb.VersionConflictError
 		amtErr          devices.AMTError
 		notSupportedErr devices.NotSupportedError
+		consentErr      devices.ConsentRequiredError
+		forbiddenErr    devices.ForbiddenError
 		certExpErr      domains.CertExpirationError
 		certPasswordErr domains.CertPasswordError
+		partialErr      profiletemplates.PartiallyAppliedError
 		netErr          net.Error
 	)
 
@@ -44,16 +50,24 @@ func ErrorResponse(c *gin.Context, err error) {
 		notFoundErrorHandle(c, nfErr)
 	case errors.As(err, &NotUniqueErr):
 		notUniqueErrorHandle(c, NotUniqueErr)
+	case errors.As(err, &versionErr):
+		versionConflictErrorHandle(c)
 	case errors.As(err, &dbErr):
 		dbErrorHandle(c, dbErr)
 	case errors.As(err, &amtErr):
 		amtErrorHandle(c, amtErr)
 	case errors.As(err, &notSupportedErr):
 		c.AbortWithStatusJSON(http.StatusNotImplemented, response{notSupportedErr.Console.FriendlyMessage()})
+	case errors.As(err, &consentErr):
+		c.AbortWithStatusJSON(http.StatusForbidden, response{consentErr.Console.FriendlyMessage()})
+	case errors.As(err, &forbiddenErr):
+		c.AbortWithStatusJSON(http.StatusForbidden, response{forbiddenErr.Console.FriendlyMessage()})
 	case errors.As(err, &certExpErr):
 		c.AbortWithStatusJSON(http.StatusBadRequest, response{certExpErr.Console.FriendlyMessage()})
 	case errors.As(err, &certPasswordErr):
 		c.AbortWithStatusJSON(http.StatusBadRequest, response{certPasswordErr.Console.FriendlyMessage()})
+	case errors.As(err, &partialErr):
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response{partialErr.Console.FriendlyMessage()})
 	default:
 		c.AbortWithStatusJSON(http.StatusInternalServerError, response{"general error"})
 	}
@@ -85,18 +99,35 @@ func dbErrorHandle(c *gin.Context, err sqldb.DatabaseError) {
 
 	var foreignKeyViolationErr sqldb.ForeignKeyViolationError
 
+	var versionErr sqldb.VersionConflictError
+
 	if errors.As(err.Console.OriginalError, &notUniqueErr) {
 		notUniqueErrorHandle(c, notUniqueErr)
 
 		return
 	}
 
+	if errors.As(err.Console.OriginalError, &versionErr) {
+		versionConflictErrorHandle(c)
+
+		return
+	}
+
 	if errors.As(err.Console.OriginalError, &foreignKeyViolationErr) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, response{foreignKeyViolationErr.Console.FriendlyMessage()})
 
 		return
 	}
 
+	// filters and orderings that do not fit the properties of the list
+	var queryErr odata.Error
+
+	if errors.As(err.Console.OriginalError, &queryErr) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, response{"Invalid input: " + queryErr.Error()})
+
+		return
+	}
+
 	c.AbortWithStatusJSON(http.StatusBadRequest, response{err.Console.FriendlyMessage()})
 }
 
@@ -108,6 +139,10 @@ func amtErrorHandle(c *gin.Context, err devices.AMTError) {
 	}
 }
 
+func versionConflictErrorHandle(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusPreconditionFailed, response{"the resource was changed since it was read, read it again and retry"})
+}
+
 func notUniqueErrorHandle(c *gin.Context, err sqldb.NotUniqueError) {
 	c.AbortWithStatusJSON(http.StatusBadRequest, response{err.Console.FriendlyMessage()})
 }
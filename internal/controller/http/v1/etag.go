@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// entityTag returns the strong entity tag of a resource at version, the number the database bumps on every
+// change of it.
+func entityTag(version string) string {
+	if version == "" {
+		return ""
+	}
+
+	return `"` + version + `"`
+}
+
+// setETag sets the ETag header of the response to the entity tag of a resource at version.
+func setETag(c *gin.Context, version string) {
+	if tag := entityTag(version); tag != "" {
+		c.Header("ETag", tag)
+	}
+}
+
+// ifMatch returns the version the If-Match header of the request makes an update or delete conditional on.
+// The database only writes the resource while it is still at that version and the request fails with 412
+// otherwise. The version is blank when there is no header or it is "*". It responds with 400 and reports false
+// when the header holds more than one entity tag.
+func ifMatch(c *gin.Context) (string, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return "", true
+	}
+
+	if strings.Contains(header, ",") {
+		c.AbortWithStatusJSON(http.StatusBadRequest, response{"If-Match header must hold the single ETag of the resource as read"})
+
+		return "", false
+	}
+
+	return strings.Trim(header, `"`), true
+}
+
+// RequireIfMatch rejects PUT, PATCH and DELETE requests without an If-Match header with 428, so that two
+// admins editing the same resource cannot silently overwrite each other. "*" can be sent to skip the check.
+func RequireIfMatch() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			if strings.TrimSpace(c.GetHeader("If-Match")) == "" {
+				c.AbortWithStatusJSON(http.StatusPreconditionRequired, response{"If-Match header required, send the ETag of the resource as read"})
+
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/profiles"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func TestProfileETags(t *testing.T) {
+	t.Parallel()
+
+	current := profileTest
+	current.Version = "3"
+
+	updated := profileTest
+	updated.Version = "4"
+
+	conflict := profiles.ErrDatabase.Wrap("Update", "uc.repo.Update", sqldb.ErrProfileVersionConflict.Wrap("newprofile"))
+
+	atVersion := func(version string) gomock.Matcher {
+		return gomock.Cond(func(p *dto.Profile) bool { return p.Version == version })
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		ifMatch      string
+		requireMatch bool
+		mock         func(m *mocks.MockProfilesFeature)
+		expectedCode int
+		expectedETag string
+	}{
+		{
+			name:   "get returns the version as ETag",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/profiles/newprofile",
+			mock: func(m *mocks.MockProfilesFeature) {
+				m.EXPECT().GetByName(context.Background(), "newprofile", "").Return(&current, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `"3"`,
+		},
+		{
+			name:    "update at the current version",
+			method:  http.MethodPatch,
+			url:     "/api/v1/admin/profiles",
+			ifMatch: `"3"`,
+			mock: func(m *mocks.MockProfilesFeature) {
+				m.EXPECT().Update(context.Background(), atVersion("3")).Return(&updated, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `"4"`,
+		},
+		{
+			name:   "update without If-Match ignores the version of the body",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/profiles",
+			mock: func(m *mocks.MockProfilesFeature) {
+				m.EXPECT().Update(context.Background(), atVersion("")).Return(&updated, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `"4"`,
+		},
+		{
+			name:    "update at a stale version",
+			method:  http.MethodPatch,
+			url:     "/api/v1/admin/profiles",
+			ifMatch: `"2"`,
+			mock: func(m *mocks.MockProfilesFeature) {
+				m.EXPECT().Update(context.Background(), atVersion("2")).Return(nil, conflict)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "delete at a stale version",
+			method:  http.MethodDelete,
+			url:     "/api/v1/admin/profiles/newprofile",
+			ifMatch: `"2"`,
+			mock: func(m *mocks.MockProfilesFeature) {
+				m.EXPECT().Delete(context.Background(), "newprofile", "2", "").Return(conflict)
+			},
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "delete with more than one ETag",
+			method:       http.MethodDelete,
+			url:          "/api/v1/admin/profiles/newprofile",
+			ifMatch:      `"2", "3"`,
+			mock:         func(_ *mocks.MockProfilesFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "delete with any ETag",
+			method:       http.MethodDelete,
+			url:          "/api/v1/admin/profiles/newprofile",
+			ifMatch:      "*",
+			requireMatch: true,
+			mock: func(m *mocks.MockProfilesFeature) {
+				m.EXPECT().Delete(context.Background(), "newprofile", "", "").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "delete without If-Match when it is required",
+			method:       http.MethodDelete,
+			url:          "/api/v1/admin/profiles/newprofile",
+			requireMatch: true,
+			mock:         func(_ *mocks.MockProfilesFeature) {},
+			expectedCode: http.StatusPreconditionRequired,
+		},
+		{
+			name:         "get without If-Match when it is required",
+			method:       http.MethodGet,
+			url:          "/api/v1/admin/profiles/newprofile",
+			requireMatch: true,
+			mock: func(m *mocks.MockProfilesFeature) {
+				m.EXPECT().GetByName(context.Background(), "newprofile", "").Return(&current, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedETag: `"3"`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtl := gomock.NewController(t)
+			mockProfiles := mocks.NewMockProfilesFeature(mockCtl)
+			tc.mock(mockProfiles)
+
+			engine := gin.New()
+			handler := engine.Group("/api/v1/admin")
+
+			if tc.requireMatch {
+				handler = handler.Group("", RequireIfMatch())
+			}
+
+			NewProfileRoutes(handler, mockProfiles, logger.New("error"))
+
+			var body io.Reader = http.NoBody
+
+			if tc.method == http.MethodPatch {
+				reqBody, err := json.Marshal(current)
+				require.NoError(t, err)
+
+				body = bytes.NewBuffer(reqBody)
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, tc.url, body)
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+			require.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+		})
+	}
+}
@@ -51,7 +51,15 @@ func (r *ieee8021xConfigRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	query, err := odata.Query()
+	if err != nil {
+		validationErr := ErrValidation8021xConfig.Wrap("get", "odata.Query", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), query, odata.Top, odata.Skip, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - getCount")
 		ErrorResponse(c, err)
@@ -60,7 +68,7 @@ func (r *ieee8021xConfigRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), query, callerTenant(c))
 		if err != nil {
 			r.l.Error(err, "http - IEEE8021x configs - v1 - getCount")
 			ErrorResponse(c, err)
@@ -71,16 +79,16 @@ func (r *ieee8021xConfigRoutes) get(c *gin.Context) {
 			Data:  items,
 		}
 
-		c.JSON(http.StatusOK, countResponse)
+		odata.respond(c, countResponse)
 	} else {
-		c.JSON(http.StatusOK, items)
+		odata.respond(c, items)
 	}
 }
 
 func (r *ieee8021xConfigRoutes) getByName(c *gin.Context) {
 	configName := c.Param("profileName")
 
-	config, err := r.t.GetByName(c.Request.Context(), configName, "")
+	config, err := r.t.GetByName(c.Request.Context(), configName, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - getByName")
 		ErrorResponse(c, err)
@@ -88,6 +96,7 @@ func (r *ieee8021xConfigRoutes) getByName(c *gin.Context) {
 		return
 	}
 
+	setETag(c, config.Version)
 	c.JSON(http.StatusOK, config)
 }
 
@@ -100,6 +109,8 @@ func (r *ieee8021xConfigRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = callerTenant(c)
+
 	newConfig, err := r.t.Insert(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - insert")
@@ -120,6 +131,15 @@ func (r *ieee8021xConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = callerTenant(c)
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	config.Version = version
+
 	updatedConfig, err := r.t.Update(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - update")
@@ -128,13 +148,19 @@ func (r *ieee8021xConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	setETag(c, updatedConfig.Version)
 	c.JSON(http.StatusOK, updatedConfig)
 }
 
 func (r *ieee8021xConfigRoutes) delete(c *gin.Context) {
 	configName := c.Param("profileName")
 
-	err := r.t.Delete(c.Request.Context(), configName, "")
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	err := r.t.Delete(c.Request.Context(), configName, version, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - delete")
 		ErrorResponse(c, err)
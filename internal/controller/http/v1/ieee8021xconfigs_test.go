@@ -16,6 +16,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/ieee8021xconfigs"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 func ieee8021xconfigsTest(t *testing.T) (*mocks.MockIEEE8021xConfigsFeature, *gin.Engine) {
@@ -51,7 +52,6 @@ var ieee8021xconfigTest = dto.IEEE8021xConfig{
 	ProfileName:            "newprofile",
 	AuthenticationProtocol: 2,
 	PXETimeout:             &pxeTime,
-	TenantID:               "tenant1",
 	Version:                "1.0",
 	WiredInterface:         false,
 }
@@ -65,7 +65,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.IEEE8021xConfig{{
+				ieeeConfig.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return([]dto.IEEE8021xConfig{{
 					ProfileName: "profile",
 				}}, nil)
 			},
@@ -77,10 +77,10 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/ieee8021xconfigs?$top=10&$skip=1&$count=true",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.IEEE8021xConfig{{
+				ieeeConfig.EXPECT().Get(context.Background(), odata.Query{}, 10, 1, "").Return([]dto.IEEE8021xConfig{{
 					ProfileName: "profile",
 				}}, nil)
-				ieeeConfig.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+				ieeeConfig.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(1, nil)
 			},
 			response:     dto.IEEE8021xConfigCountResponse{Count: 1, Data: []dto.IEEE8021xConfig{{ProfileName: "profile"}}},
 			expectedCode: http.StatusOK,
@@ -90,7 +90,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Get(context.Background(), 25, 0, "").Return(nil, ieee8021xconfigs.ErrDatabase)
+				ieeeConfig.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return(nil, ieee8021xconfigs.ErrDatabase)
 			},
 			response:     ieee8021xconfigs.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -144,7 +144,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/ieee8021xconfigs/profile",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Delete(context.Background(), "profile", "").Return(nil)
+				ieeeConfig.EXPECT().Delete(context.Background(), "profile", "", "").Return(nil)
 			},
 			response:     nil,
 			expectedCode: http.StatusNoContent,
@@ -154,7 +154,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/ieee8021xconfigs/profile",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Delete(context.Background(), "profile", "").Return(ieee8021xconfigs.ErrDatabase)
+				ieeeConfig.EXPECT().Delete(context.Background(), "profile", "", "").Return(ieee8021xconfigs.ErrDatabase)
 			},
 			response:     ieee8021xconfigs.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -164,7 +164,11 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Update(context.Background(), &ieee8021xconfigTest).Return(&ieee8021xconfigTest, nil)
+				// the version comes from If-Match, not the body
+				expected := ieee8021xconfigTest
+				expected.Version = ""
+
+				ieeeConfig.EXPECT().Update(context.Background(), &expected).Return(&ieee8021xconfigTest, nil)
 			},
 			response:     ieee8021xconfigTest,
 			requestBody:  ieee8021xconfigTest,
@@ -175,7 +179,11 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Update(context.Background(), &ieee8021xconfigTest).Return(nil, ieee8021xconfigs.ErrDatabase)
+				// the version comes from If-Match, not the body
+				expected := ieee8021xconfigTest
+				expected.Version = ""
+
+				ieeeConfig.EXPECT().Update(context.Background(), &expected).Return(nil, ieee8021xconfigs.ErrDatabase)
 			},
 			response:     ieee8021xconfigs.ErrDatabase,
 			requestBody:  ieee8021xconfigTest,
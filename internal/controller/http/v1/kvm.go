@@ -44,3 +44,18 @@ func (r *deviceManagementRoutes) setKVMDisplays(c *gin.Context) {
 
 	c.JSON(http.StatusOK, settings)
 }
+
+// getKVMScreenshot captures the current KVM framebuffer of the default display as a PNG
+func (r *deviceManagementRoutes) getKVMScreenshot(c *gin.Context) {
+	guid := c.Param("guid")
+
+	screenshot, err := r.d.GetKVMScreenshot(c.Request.Context(), guid)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getKVMScreenshot")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", screenshot)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
 	"github.com/device-management-toolkit/console/pkg/logger"
 )
 
@@ -65,4 +66,50 @@ func TestKVMDisplaysEndpoints(t *testing.T) {
 		engine.ServeHTTP(rr, req)
 		require.Equal(t, http.StatusOK, rr.Code)
 	})
+
+	t.Run("GET screenshot", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtl := gomock.NewController(t)
+		defer mockCtl.Finish()
+
+		log := logger.New("error")
+		deviceManagement := mocks.NewMockDeviceManagementFeature(mockCtl)
+		amtExplorerMock := mocks.NewMockAMTExplorerFeature(mockCtl)
+		exporterMock := mocks.NewMockExporter(mockCtl)
+		engine := gin.New()
+		handler := engine.Group("/api/v1")
+		NewAmtRoutes(handler, deviceManagement, amtExplorerMock, exporterMock, log)
+
+		deviceManagement.EXPECT().GetKVMScreenshot(context.Background(), "guid3").Return([]byte("png"), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/amt/kvm/screenshot/guid3", http.NoBody)
+		rr := httptest.NewRecorder()
+		engine.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+		require.Equal(t, "png", rr.Body.String())
+	})
+
+	t.Run("GET screenshot consent required", func(t *testing.T) {
+		t.Parallel()
+
+		mockCtl := gomock.NewController(t)
+		defer mockCtl.Finish()
+
+		log := logger.New("error")
+		deviceManagement := mocks.NewMockDeviceManagementFeature(mockCtl)
+		amtExplorerMock := mocks.NewMockAMTExplorerFeature(mockCtl)
+		exporterMock := mocks.NewMockExporter(mockCtl)
+		engine := gin.New()
+		handler := engine.Group("/api/v1")
+		NewAmtRoutes(handler, deviceManagement, amtExplorerMock, exporterMock, log)
+
+		deviceManagement.EXPECT().GetKVMScreenshot(context.Background(), "guid4").Return(nil, devices.ErrConsentRequired)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/amt/kvm/screenshot/guid4", http.NoBody)
+		rr := httptest.NewRecorder()
+		engine.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
 }
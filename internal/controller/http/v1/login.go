@@ -2,7 +2,11 @@ package v1
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,20 +16,51 @@ import (
 
 	"github.com/device-management-toolkit/console/config"
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/apikeys"
+	"github.com/device-management-toolkit/console/internal/usecase/auth"
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 )
 
 var ErrLogin = consoleerrors.CreateConsoleError("LoginHandler")
 
+const (
+	// userContextKey holds the subject of the authenticated caller on the gin context.
+	userContextKey = "user"
+	// roleContextKey holds the role of the authenticated caller on the gin context.
+	roleContextKey = "role"
+	// apiKeyContextKey holds the id of the API key the caller authenticated with.
+	apiKeyContextKey = "apiKey"
+	// accessClaimsContextKey holds the claims of the access token when the caller presented one issued by the console.
+	accessClaimsContextKey = "accessClaims"
+)
+
+// AccessClaims are carried by the tokens issued on login.
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	Role        string   `json:"role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	TenantID    string   `json:"tenantId,omitempty"`
+	// Scope is only set on redirection tokens, which never authorize the API.
+	Scope string `json:"scope,omitempty"`
+}
+
 type LoginRoute struct {
 	Config   *config.Config
 	Verifier *oidc.IDTokenVerifier
+	Users    users.Feature
+	APIKeys  apikeys.Feature
+	Auth     auth.Feature
 }
 
-// NewVersionRoute creates a new version route
-func NewLoginRoute(configData *config.Config) *LoginRoute {
+// NewLoginRoute creates a new login route
+func NewLoginRoute(configData *config.Config, u users.Feature, k apikeys.Feature, a auth.Feature) *LoginRoute {
 	lr := &LoginRoute{
-		Config: configData,
+		Config:  configData,
+		Users:   u,
+		APIKeys: k,
+		Auth:    a,
 	}
 
 	if config.ConsoleConfig.ClientID != "" {
@@ -56,16 +91,150 @@ func (lr LoginRoute) Login(c *gin.Context) {
 }
 
 func (lr LoginRoute) handleBasicAuth(creds dto.Credentials, c *gin.Context) {
-	if creds.Username != lr.Config.AdminUsername || creds.Password != lr.Config.AdminPassword {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+	if wait := lr.Auth.LoginAllowed(creds.Username); wait > 0 {
+		tooManyAttempts(c, wait)
+
+		return
+	}
+
+	user, err := lr.Users.Authenticate(c.Request.Context(), creds.Username, creds.Password)
+	if err != nil {
+		if !errors.Is(err, users.ErrInvalidCredentials) {
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		if !lr.isConfigAdmin(creds) {
+			if wait := lr.Auth.LoginFailed(creds.Username); wait > 0 {
+				c.Header("Retry-After", retryAfterSeconds(wait))
+			}
+
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+
+			return
+		}
+
+		user = &dto.User{Username: creds.Username, Role: users.RoleAdmin}
+	}
+
+	lr.Auth.LoginSucceeded(creds.Username)
+
+	session := &dto.LoginSession{Subject: user.Username, Role: user.Role, TenantID: user.TenantID}
+
+	refreshToken, err := lr.Auth.IssueRefreshToken(c.Request.Context(), session)
+	if err != nil {
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	lr.respondWithTokens(c, session, refreshToken)
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token. The role is looked up
+// again, so role changes take effect and removed users can no longer refresh.
+func (lr LoginRoute) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+
+		return
+	}
+
+	session, refreshToken, err := lr.Auth.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+
+			return
+		}
+
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	user, err := lr.Users.GetByUsername(c.Request.Context(), session.Subject, session.TenantID)
+
+	switch {
+	case err == nil:
+		session.Role = user.Role
+	case errors.Is(err, users.ErrNotFound) && lr.isConfigAdminSession(session):
+		session.Role = users.RoleAdmin
+	case errors.Is(err, users.ErrNotFound):
+		if err := lr.Auth.RevokeRefreshToken(c.Request.Context(), refreshToken); err != nil {
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+
+		return
+	default:
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	lr.respondWithTokens(c, session, refreshToken)
+}
+
+// Logout revokes the access token of the caller and, when given, its refresh token.
+func (lr LoginRoute) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+
+		return
+	}
+
+	value, ok := c.Get(accessClaimsContextKey)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only tokens issued by the console can be logged out"})
+
+		return
+	}
+
+	claims, _ := value.(*AccessClaims)
+
+	if err := lr.Auth.RevokeToken(c.Request.Context(), claims.ID, claims.Subject, claims.ExpiresAt.Time); err != nil {
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if req.RefreshToken != "" {
+		if err := lr.Auth.RevokeRefreshToken(c.Request.Context(), req.RefreshToken); err != nil && !errors.Is(err, auth.ErrInvalidRefreshToken) {
+			ErrorResponse(c, err)
+
+			return
+		}
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (lr LoginRoute) respondWithTokens(c *gin.Context, session *dto.LoginSession, refreshToken string) {
+	tokenID, err := auth.NewTokenID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create token"})
 
 		return
 	}
 
-	// Create JWT token
-	expirationTime := time.Now().Add(config.ConsoleConfig.JWTExpiration)
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expirationTime),
+	now := time.Now()
+	claims := AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   session.Subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(config.ConsoleConfig.JWTExpiration)),
+		},
+		Role:        session.Role,
+		Permissions: users.Permissions(session.Role),
+		TenantID:    session.TenantID,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -77,7 +246,29 @@ func (lr LoginRoute) handleBasicAuth(creds dto.Credentials, c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": tokenString})
+	c.JSON(http.StatusOK, dto.Tokens{Token: tokenString, RefreshToken: refreshToken})
+}
+
+func tooManyAttempts(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", retryAfterSeconds(wait))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many failed login attempts"})
+}
+
+func retryAfterSeconds(wait time.Duration) string {
+	return strconv.Itoa(int((wait + time.Second - 1) / time.Second))
+}
+
+// isConfigAdmin reports whether the credentials match the admin account from the configuration. It keeps
+// working next to local users so a console can always be recovered, an empty password disables it.
+func (lr LoginRoute) isConfigAdmin(creds dto.Credentials) bool {
+	return lr.Config.AdminPassword != "" &&
+		subtle.ConstantTimeCompare([]byte(creds.Username), []byte(lr.Config.AdminUsername)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(creds.Password), []byte(lr.Config.AdminPassword)) == 1
+}
+
+// isConfigAdminSession reports whether a session was started by the admin account from the configuration.
+func (lr LoginRoute) isConfigAdminSession(session *dto.LoginSession) bool {
+	return lr.Config.AdminPassword != "" && session.TenantID == "" && session.Subject == lr.Config.AdminUsername
 }
 
 // JWT Middleware
@@ -93,28 +284,130 @@ func (lr LoginRoute) JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// API keys are accepted in both auth modes
+		if apikeys.IsKey(tokenString) {
+			lr.authenticateAPIKey(c, tokenString)
+
+			return
+		}
+
 		// if clientID is set, use the oidc verifier
 		if config.ConsoleConfig.ClientID != "" {
-			_, err := lr.Verifier.Verify(c.Request.Context(), tokenString)
+			idToken, err := lr.Verifier.Verify(c.Request.Context(), tokenString)
 			if err != nil {
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid access token"})
 				c.Abort()
+
+				return
+			}
+
+			var claims map[string]any
+			if err := idToken.Claims(&claims); err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid access token"})
+				c.Abort()
+
+				return
 			}
+
+			role, ok := users.MapClaims(lr.Config.RoleMappings, claims)
+			if !ok {
+				c.JSON(http.StatusForbidden, gin.H{"error": "no console role is mapped to this user"})
+				c.Abort()
+
+				return
+			}
+
+			tenant, ok := users.ClaimTenant(lr.Config.TenantClaim, claims)
+			if !ok {
+				c.JSON(http.StatusForbidden, gin.H{"error": "no tenant is mapped to this user"})
+				c.Abort()
+
+				return
+			}
+
+			tokenID, _ := claims["jti"].(string)
+			if !lr.checkRevocation(c, tokenID, idToken.Subject, idToken.IssuedAt) {
+				return
+			}
+
+			setUser(c, idToken.Subject)
+			c.Set(roleContextKey, role)
+			c.Set(permissionsContextKey, users.Permissions(role))
+			setTenant(c, tenant)
 		} else {
-			claims := &jwt.MapClaims{}
+			claims := &AccessClaims{}
 
 			token, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
 				return []byte(lr.Config.JWTKey), nil
 			})
 
-			if err != nil || !token.Valid {
+			// redirection tokens only open a websocket, they never authorize the API; tokens without an id
+			// predate revocation and are no longer accepted
+			if err != nil || !token.Valid || claims.Scope == devices.RedirectionTokenScope || claims.ID == "" {
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid access token"})
 				c.Abort()
 
 				return
 			}
+
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+
+			if !lr.checkRevocation(c, claims.ID, claims.Subject, issuedAt) {
+				return
+			}
+
+			c.Set(accessClaimsContextKey, claims)
+
+			setUser(c, claims.Subject)
+			c.Set(roleContextKey, claims.Role)
+			c.Set(permissionsContextKey, claims.Permissions)
+			setTenant(c, claims.TenantID)
 		}
 
 		c.Next()
 	}
 }
+
+// checkRevocation aborts the request when the token has been revoked by a logout or a forced logout.
+func (lr LoginRoute) checkRevocation(c *gin.Context, tokenID, subject string, issuedAt time.Time) bool {
+	revoked, err := lr.Auth.IsRevoked(c.Request.Context(), tokenID, subject, issuedAt)
+	if err != nil {
+		ErrorResponse(c, err)
+
+		return false
+	}
+
+	if revoked {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "access token has been revoked"})
+		c.Abort()
+
+		return false
+	}
+
+	return true
+}
+
+func (lr LoginRoute) authenticateAPIKey(c *gin.Context, key string) {
+	apiKey, err := lr.APIKeys.Authenticate(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, apikeys.ErrInvalidKey) || errors.Is(err, apikeys.ErrExpiredKey) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid api key"})
+			c.Abort()
+
+			return
+		}
+
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	setUser(c, apiKey.Owner)
+	c.Set(apiKeyContextKey, apiKey.ID)
+	c.Set(permissionsContextKey, apiKey.Scopes)
+	setTenant(c, apiKey.TenantID)
+	c.Next()
+}
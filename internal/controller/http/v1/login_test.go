@@ -0,0 +1,445 @@
+package v1
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/apikeys"
+	"github.com/device-management-toolkit/console/internal/usecase/auth"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func loginTest(t *testing.T, verifier *oidc.IDTokenVerifier) (*mocks.MockUsersFeature, *mocks.MockAPIKeysFeature, *gin.Engine) {
+	t.Helper()
+
+	authFeature := mocks.NewMockAuthFeature(gomock.NewController(t))
+	authFeature.EXPECT().LoginAllowed(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+	authFeature.EXPECT().LoginFailed(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+	authFeature.EXPECT().LoginSucceeded(gomock.Any()).AnyTimes()
+	authFeature.EXPECT().IssueRefreshToken(gomock.Any(), gomock.Any()).Return("refresh", nil).AnyTimes()
+	authFeature.EXPECT().IsRevoked(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	return authLoginTest(t, verifier, authFeature)
+}
+
+// authLoginTest serves the login routes with the given login hardening in place.
+func authLoginTest(t *testing.T, verifier *oidc.IDTokenVerifier, authFeature auth.Feature) (*mocks.MockUsersFeature, *mocks.MockAPIKeysFeature, *gin.Engine) {
+	t.Helper()
+
+	previous := config.ConsoleConfig
+	t.Cleanup(func() { config.ConsoleConfig = previous })
+
+	config.ConsoleConfig = &config.Config{}
+	config.ConsoleConfig.JWTKey = "login-test-key"
+	config.ConsoleConfig.JWTExpiration = time.Hour
+	config.ConsoleConfig.AdminUsername = "standalone"
+	config.ConsoleConfig.AdminPassword = "G@ppm0ym"
+
+	mockCtl := gomock.NewController(t)
+	userFeature := mocks.NewMockUsersFeature(mockCtl)
+	apiKeyFeature := mocks.NewMockAPIKeysFeature(mockCtl)
+	login := NewLoginRoute(config.ConsoleConfig, userFeature, apiKeyFeature, authFeature)
+	login.Verifier = verifier
+
+	engine := gin.New()
+	engine.POST("/api/v1/authorize", login.Login)
+	engine.POST("/api/v1/authorize/refresh", login.Refresh)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	api := engine.Group("/api/v1", login.JWTAuthMiddleware())
+	api.GET("/tenant", func(c *gin.Context) { c.String(http.StatusOK, callerTenant(c)) })
+	api.POST("/logout", login.Logout)
+
+	amt := api.Group("", RequirePermissions(DevicePolicy))
+	amt.GET("/amt/hardwareInfo/:guid", ok)
+	amt.POST("/amt/power/action/:guid", ok)
+	amt.GET("/amt/kvm/screenshot/:guid", ok)
+	amt.GET("/authorize/redirection/:id", ok)
+
+	admin := api.Group("/admin", RequirePermissions(AdminPolicy))
+	admin.GET("/domains", ok)
+	admin.POST("/domains", ok)
+
+	return userFeature, apiKeyFeature, engine
+}
+
+func login(t *testing.T, engine *gin.Engine, username, password string) (int, string) {
+	t.Helper()
+
+	body, err := json.Marshal(dto.Credentials{Username: username, Password: password})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, "/api/v1/authorize", bytes.NewBuffer(body))
+	engine.ServeHTTP(w, req)
+
+	var token struct {
+		Token string `json:"token"`
+	}
+
+	_ = json.Unmarshal(w.Body.Bytes(), &token)
+
+	return w.Code, token.Token
+}
+
+func TestLoginPermissions(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	userFeature, _, engine := loginTest(t, nil)
+
+	userFeature.EXPECT().Authenticate(gomock.Any(), "jdoe", "viewer-pass").Return(&dto.User{Username: "jdoe", Role: users.RoleViewer}, nil)
+	userFeature.EXPECT().Authenticate(gomock.Any(), "jdoe", "wrong").Return(nil, users.ErrInvalidCredentials)
+	userFeature.EXPECT().Authenticate(gomock.Any(), "standalone", "G@ppm0ym").Return(nil, users.ErrInvalidCredentials)
+
+	code, _ := login(t, engine, "jdoe", "wrong")
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	code, viewerToken := login(t, engine, "jdoe", "viewer-pass")
+	require.Equal(t, http.StatusOK, code)
+
+	code, adminToken := login(t, engine, "standalone", "G@ppm0ym")
+	require.Equal(t, http.StatusOK, code)
+
+	tests := []struct {
+		name         string
+		token        string
+		method       string
+		url          string
+		expectedCode int
+	}{
+		{"viewer reads hardware info", viewerToken, http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusOK},
+		{"viewer cannot run power actions", viewerToken, http.MethodPost, "/api/v1/amt/power/action/guid", http.StatusForbidden},
+		{"viewer cannot take screenshots", viewerToken, http.MethodGet, "/api/v1/amt/kvm/screenshot/guid", http.StatusForbidden},
+		{"viewer cannot read admin", viewerToken, http.MethodGet, "/api/v1/admin/domains", http.StatusForbidden},
+		{"config admin runs power actions", adminToken, http.MethodPost, "/api/v1/amt/power/action/guid", http.StatusOK},
+		{"config admin reads admin", adminToken, http.MethodGet, "/api/v1/admin/domains", http.StatusOK},
+		{"missing token", "", http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(t.Context(), tc.method, tc.url, http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestOIDCRoleMapping(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	const issuer = "https://idp.example.com"
+
+	verifier := oidc.NewVerifier(issuer, &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{&key.PublicKey}}, &oidc.Config{ClientID: "console"})
+
+	_, _, engine := loginTest(t, verifier)
+	config.ConsoleConfig.ClientID = "console"
+	config.ConsoleConfig.RoleMappings = []config.RoleMapping{
+		{Claim: "groups", Value: "console-operators", Role: users.RoleOperator},
+		{EmailDomain: "example.com", Role: users.RoleViewer},
+	}
+
+	idToken := func(claims jwt.MapClaims) string {
+		claims["iss"] = issuer
+		claims["aud"] = "console"
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	operator := idToken(jwt.MapClaims{"sub": "op", "groups": []string{"staff", "console-operators"}})
+	viewer := idToken(jwt.MapClaims{"sub": "jdoe", "email": "jdoe@example.com", "email_verified": true})
+	unverified := idToken(jwt.MapClaims{"sub": "eve", "email": "eve@example.com", "email_verified": false})
+	unclaimed := idToken(jwt.MapClaims{"sub": "mallory", "email": "mallory@example.com"})
+	unknown := idToken(jwt.MapClaims{"sub": "guest", "groups": []string{"staff"}})
+
+	tests := []struct {
+		name         string
+		token        string
+		method       string
+		url          string
+		expectedCode int
+	}{
+		{"operator group runs power actions", operator, http.MethodPost, "/api/v1/amt/power/action/guid", http.StatusOK},
+		{"operator group opens redirection", operator, http.MethodGet, "/api/v1/authorize/redirection/guid", http.StatusOK},
+		{"operator group cannot change admin", operator, http.MethodPost, "/api/v1/admin/domains", http.StatusForbidden},
+		{"email domain reads hardware info", viewer, http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusOK},
+		{"email domain cannot run power actions", viewer, http.MethodPost, "/api/v1/amt/power/action/guid", http.StatusForbidden},
+		{"email domain cannot open redirection", viewer, http.MethodGet, "/api/v1/authorize/redirection/guid", http.StatusForbidden},
+		{"unverified email is rejected", unverified, http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusForbidden},
+		{"email without verification is rejected", unclaimed, http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusForbidden},
+		{"unmapped user is rejected", unknown, http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusForbidden},
+		{"malformed token is rejected", "not-an-id-token", http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(t.Context(), tc.method, tc.url, http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestAPIKeyAuthentication(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	_, apiKeyFeature, engine := loginTest(t, nil)
+
+	const (
+		labKey     = "cak_0123456789abcdef_secret"
+		revokedKey = "cak_fedcba9876543210_secret"
+	)
+
+	apiKeyFeature.EXPECT().Authenticate(gomock.Any(), labKey).Return(&dto.APIKey{
+		ID: "0123456789abcdef", Owner: "ci", Scopes: []string{users.PermissionDevicesRead, users.PermissionPowerWrite},
+	}, nil).AnyTimes()
+	apiKeyFeature.EXPECT().Authenticate(gomock.Any(), revokedKey).Return(nil, apikeys.ErrInvalidKey)
+
+	tests := []struct {
+		name         string
+		token        string
+		method       string
+		url          string
+		expectedCode int
+	}{
+		{"key reads hardware info", labKey, http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusOK},
+		{"key runs power actions", labKey, http.MethodPost, "/api/v1/amt/power/action/guid", http.StatusOK},
+		{"key outside its scopes", labKey, http.MethodGet, "/api/v1/amt/kvm/screenshot/guid", http.StatusForbidden},
+		{"key cannot read admin", labKey, http.MethodGet, "/api/v1/admin/domains", http.StatusForbidden},
+		{"revoked key", revokedKey, http.MethodGet, "/api/v1/amt/hardwareInfo/guid", http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(t.Context(), tc.method, tc.url, http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestLoginTenant(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	const issuer = "https://idp.example.com"
+
+	verifier := oidc.NewVerifier(issuer, &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{&key.PublicKey}}, &oidc.Config{ClientID: "console"})
+
+	userFeature, apiKeyFeature, engine := loginTest(t, verifier)
+
+	userFeature.EXPECT().Authenticate(gomock.Any(), "jdoe", "viewer-pass").
+		Return(&dto.User{Username: "jdoe", Role: users.RoleViewer, TenantID: "bu-retail"}, nil)
+	userFeature.EXPECT().Authenticate(gomock.Any(), "standalone", "G@ppm0ym").Return(nil, users.ErrInvalidCredentials)
+
+	_, userToken := login(t, engine, "jdoe", "viewer-pass")
+	_, adminToken := login(t, engine, "standalone", "G@ppm0ym")
+
+	const labKey = "cak_0123456789abcdef_secret"
+
+	apiKeyFeature.EXPECT().Authenticate(gomock.Any(), labKey).Return(&dto.APIKey{
+		ID: "0123456789abcdef", Owner: "ci", Scopes: []string{users.PermissionDevicesRead}, TenantID: "bu-energy",
+	}, nil)
+
+	idToken := func(claims jwt.MapClaims) string {
+		claims["iss"] = issuer
+		claims["aud"] = "console"
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		claims["groups"] = []string{"console-viewers"}
+
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	oidcUser := idToken(jwt.MapClaims{"sub": "op", "org": map[string]any{"tenant": "bu-logistics"}})
+	noTenant := idToken(jwt.MapClaims{"sub": "guest"})
+
+	tests := []struct {
+		name         string
+		token        string
+		oidc         bool
+		expectedCode int
+		tenant       string
+	}{
+		{"local user", userToken, false, http.StatusOK, "bu-retail"},
+		{"config admin belongs to the default tenant", adminToken, false, http.StatusOK, ""},
+		{"api key", labKey, false, http.StatusOK, "bu-energy"},
+		{"identity provider claim", oidcUser, true, http.StatusOK, "bu-logistics"},
+		{"identity provider user without tenant is rejected", noTenant, true, http.StatusForbidden, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config.ConsoleConfig.ClientID = ""
+			if tc.oidc {
+				config.ConsoleConfig.ClientID = "console"
+				config.ConsoleConfig.RoleMappings = []config.RoleMapping{{Claim: "groups", Value: "console-viewers", Role: users.RoleViewer}}
+				config.ConsoleConfig.TenantClaim = "org.tenant"
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/api/v1/tenant", http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK {
+				require.Equal(t, tc.tenant, w.Body.String())
+			}
+		})
+	}
+}
+
+func postJSON(t *testing.T, engine *gin.Engine, url, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, url, bytes.NewBuffer(data))
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	engine.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestLoginThrottling(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	userFeature, _, engine := authLoginTest(t, nil, auth.New(mocks.NewMockAuthRepository(gomock.NewController(t)), logger.New("error")))
+
+	userFeature.EXPECT().Authenticate(gomock.Any(), "jdoe", "wrong").Return(nil, users.ErrInvalidCredentials).Times(2)
+
+	// the first failure is not held back
+	w := postJSON(t, engine, "/api/v1/authorize", "", dto.Credentials{Username: "jdoe", Password: "wrong"})
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Empty(t, w.Header().Get("Retry-After"))
+
+	w = postJSON(t, engine, "/api/v1/authorize", "", dto.Credentials{Username: "jdoe", Password: "wrong"})
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	// the password is not even checked while the attempt is held back
+	w = postJSON(t, engine, "/api/v1/authorize", "", dto.Credentials{Username: "JDoe", Password: "viewer-pass"})
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+}
+
+func TestRefreshAndLogout(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	authFeature := mocks.NewMockAuthFeature(gomock.NewController(t))
+	userFeature, _, engine := authLoginTest(t, nil, authFeature)
+
+	viewer := &dto.LoginSession{Subject: "jdoe", Role: users.RoleViewer}
+
+	authFeature.EXPECT().LoginAllowed("jdoe").Return(time.Duration(0))
+	authFeature.EXPECT().LoginSucceeded("jdoe")
+	userFeature.EXPECT().Authenticate(gomock.Any(), "jdoe", "viewer-pass").Return(&dto.User{Username: "jdoe", Role: users.RoleViewer}, nil)
+	authFeature.EXPECT().IssueRefreshToken(gomock.Any(), viewer).Return("refresh-1", nil)
+
+	w := postJSON(t, engine, "/api/v1/authorize", "", dto.Credentials{Username: "jdoe", Password: "viewer-pass"})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var tokens dto.Tokens
+
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	require.Equal(t, "refresh-1", tokens.RefreshToken)
+
+	claims := &AccessClaims{}
+	_, err := jwt.ParseWithClaims(tokens.Token, claims, func(_ *jwt.Token) (interface{}, error) { return []byte("login-test-key"), nil })
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.ID)
+	require.NotNil(t, claims.IssuedAt)
+
+	// the role is looked up again on refresh
+	authFeature.EXPECT().Refresh(gomock.Any(), "refresh-1").Return(&dto.LoginSession{Subject: "jdoe", Role: users.RoleViewer}, "refresh-2", nil)
+	userFeature.EXPECT().GetByUsername(gomock.Any(), "jdoe", "").Return(&dto.User{Username: "jdoe", Role: users.RoleOperator}, nil)
+
+	w = postJSON(t, engine, "/api/v1/authorize/refresh", "", dto.RefreshRequest{RefreshToken: "refresh-1"})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	require.Equal(t, "refresh-2", tokens.RefreshToken)
+
+	refreshed := &AccessClaims{}
+	_, err = jwt.ParseWithClaims(tokens.Token, refreshed, func(_ *jwt.Token) (interface{}, error) { return []byte("login-test-key"), nil })
+	require.NoError(t, err)
+	require.Equal(t, users.RoleOperator, refreshed.Role)
+	require.NotEqual(t, claims.ID, refreshed.ID)
+
+	authFeature.EXPECT().Refresh(gomock.Any(), "refresh-1").Return(nil, "", auth.ErrInvalidRefreshToken)
+
+	w = postJSON(t, engine, "/api/v1/authorize/refresh", "", dto.RefreshRequest{RefreshToken: "refresh-1"})
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// removed users cannot refresh
+	authFeature.EXPECT().Refresh(gomock.Any(), "refresh-stale").Return(&dto.LoginSession{Subject: "gone", Role: users.RoleViewer}, "refresh-3", nil)
+	userFeature.EXPECT().GetByUsername(gomock.Any(), "gone", "").Return(nil, users.ErrNotFound)
+	authFeature.EXPECT().RevokeRefreshToken(gomock.Any(), "refresh-3").Return(nil)
+
+	w = postJSON(t, engine, "/api/v1/authorize/refresh", "", dto.RefreshRequest{RefreshToken: "refresh-stale"})
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// logout revokes the access token and the refresh token family
+	authFeature.EXPECT().IsRevoked(gomock.Any(), refreshed.ID, "jdoe", refreshed.IssuedAt.Time).Return(false, nil)
+	authFeature.EXPECT().RevokeToken(gomock.Any(), refreshed.ID, "jdoe", refreshed.ExpiresAt.Time).Return(nil)
+	authFeature.EXPECT().RevokeRefreshToken(gomock.Any(), "refresh-2").Return(nil)
+
+	w = postJSON(t, engine, "/api/v1/logout", tokens.Token, dto.LogoutRequest{RefreshToken: "refresh-2"})
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	authFeature.EXPECT().IsRevoked(gomock.Any(), refreshed.ID, "jdoe", refreshed.IssuedAt.Time).Return(true, nil)
+
+	w = postJSON(t, engine, "/api/v1/logout", tokens.Token, dto.LogoutRequest{})
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestTokenWithoutID(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	_, _, engine := loginTest(t, nil)
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "jdoe", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		Role:             users.RoleAdmin,
+		Permissions:      users.Permissions(users.RoleAdmin),
+	}).SignedString([]byte("login-test-key"))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "/api/v1/admin/domains", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,7 +1,112 @@
 package v1
 
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+var errSkipToken = errors.New("$skip and $skiptoken cannot be combined")
+
+// OData holds the query options of a list. Lists that support it are read in pages following $skiptoken when
+// it is present, the first page with an empty one, and $count then takes an estimate when approximate is set.
 type OData struct {
-	Top   int  `form:"$top,default=25"`
-	Skip  int  `form:"$skip"`
-	Count bool `form:"$count"`
+	Top         int     `form:"$top,default=25"`
+	Skip        int     `form:"$skip"`
+	SkipToken   *string `form:"$skiptoken"`
+	Count       bool    `form:"$count"`
+	Approximate bool    `form:"approximate"`
+	Filter      string  `form:"$filter"`
+	OrderBy     string  `form:"$orderby"`
+	Select      string  `form:"$select"`
+}
+
+// Paged reports whether the list is read in pages following $skiptoken rather than skipping $skip items.
+func (o OData) Paged() (bool, error) {
+	if o.SkipToken == nil {
+		return false, nil
+	}
+
+	if o.Skip > 0 {
+		return false, errSkipToken
+	}
+
+	return true, nil
+}
+
+// Query parses $filter and $orderby.
+func (o OData) Query() (odata.Query, error) {
+	return odata.Parse(o.Filter, o.OrderBy)
+}
+
+// respond writes a list, or the response wrapping it in data, keeping only the $select properties of every item.
+func (o OData) respond(c *gin.Context, body interface{}) {
+	if strings.TrimSpace(o.Select) == "" {
+		c.JSON(http.StatusOK, body)
+
+		return
+	}
+
+	properties := make(map[string]bool)
+
+	for _, p := range strings.Split(o.Select, ",") {
+		properties[strings.TrimSpace(p)] = true
+	}
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if strings.HasPrefix(string(raw), "{") {
+		var wrapped map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		if wrapped["data"], err = selectProperties(wrapped["data"], properties); err != nil {
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, wrapped)
+
+		return
+	}
+
+	items, err := selectProperties(raw, properties)
+	if err != nil {
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func selectProperties(raw json.RawMessage, properties map[string]bool) (json.RawMessage, error) {
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		for name := range item {
+			if !properties[name] {
+				delete(item, name)
+			}
+		}
+	}
+
+	return json.Marshal(items)
 }
@@ -38,6 +38,10 @@ func NewProfileRoutes(handler *gin.RouterGroup, t profiles.Feature, l logger.Int
 		h.PATCH("", r.update)
 		h.DELETE(":name", r.delete)
 		h.GET("export/:name", r.export)
+		h.POST(":name/clone", r.clone)
+		h.GET("trash", r.getDeleted)
+		h.POST("trash/:name/restore", r.restore)
+		h.DELETE("trash/:name", r.purge)
 	}
 }
 
@@ -59,7 +63,15 @@ func (r *profileRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	query, err := odata.Query()
+	if err != nil {
+		validationErr := ErrValidationProfile.Wrap("get", "odata.Query", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), query, odata.Top, odata.Skip, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - get")
 		ErrorResponse(c, err)
@@ -68,7 +80,7 @@ func (r *profileRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), query, callerTenant(c))
 		if err != nil {
 			r.l.Error(err, "http - v1 - getCount")
 			ErrorResponse(c, err)
@@ -79,9 +91,9 @@ func (r *profileRoutes) get(c *gin.Context) {
 			Data:  items,
 		}
 
-		c.JSON(http.StatusOK, countResponse)
+		odata.respond(c, countResponse)
 	} else {
-		c.JSON(http.StatusOK, items)
+		odata.respond(c, items)
 	}
 }
 
@@ -98,7 +110,7 @@ func (r *profileRoutes) get(c *gin.Context) {
 func (r *profileRoutes) getByName(c *gin.Context) {
 	name := c.Param("name")
 
-	item, err := r.t.GetByName(c.Request.Context(), name, "")
+	item, err := r.t.GetByName(c.Request.Context(), name, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - getByName")
 		ErrorResponse(c, err)
@@ -106,6 +118,7 @@ func (r *profileRoutes) getByName(c *gin.Context) {
 		return
 	}
 
+	setETag(c, item.Version)
 	c.JSON(http.StatusOK, item)
 }
 
@@ -123,7 +136,7 @@ func (r *profileRoutes) export(c *gin.Context) {
 	name := c.Param("name")
 	domainName := c.Query("domainName")
 
-	item, key, err := r.t.Export(c.Request.Context(), name, domainName, "")
+	item, key, err := r.t.Export(c.Request.Context(), name, domainName, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - export")
 		ErrorResponse(c, err)
@@ -160,6 +173,8 @@ func (r *profileRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	profile.TenantID = callerTenant(c)
+
 	newProfile, err := r.t.Insert(c.Request.Context(), &profile)
 	if err != nil {
 		r.l.Error(err, "http - v1 - insert")
@@ -190,6 +205,15 @@ func (r *profileRoutes) update(c *gin.Context) {
 		return
 	}
 
+	profile.TenantID = callerTenant(c)
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	profile.Version = version
+
 	updatedProfile, err := r.t.Update(c.Request.Context(), &profile)
 	if err != nil {
 		r.l.Error(err, "http - v1 - update")
@@ -198,6 +222,7 @@ func (r *profileRoutes) update(c *gin.Context) {
 		return
 	}
 
+	setETag(c, updatedProfile.Version)
 	c.JSON(http.StatusOK, updatedProfile)
 }
 
@@ -214,7 +239,12 @@ func (r *profileRoutes) update(c *gin.Context) {
 func (r *profileRoutes) delete(c *gin.Context) {
 	name := c.Param("name")
 
-	err := r.t.Delete(c.Request.Context(), name, "")
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	err := r.t.Delete(c.Request.Context(), name, version, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - delete")
 		ErrorResponse(c, err)
@@ -224,3 +254,103 @@ func (r *profileRoutes) delete(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// @Summary     Show Deleted Profiles
+// @Description Show the profiles in the trash, which are purged once the retention is over
+// @ID          getDeletedProfiles
+// @Tags  	    profiles
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.Profile
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/profiles/trash [get]
+func (r *profileRoutes) getDeleted(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationProfile.Wrap("getDeleted", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.GetDeleted(c.Request.Context(), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getDeleted")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary     Restore Profile
+// @Description Take a profile out of the trash along with its wireless configurations
+// @ID          restoreProfile
+// @Tags  	    profiles
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/profiles/trash/:name/restore [post]
+func (r *profileRoutes) restore(c *gin.Context) {
+	err := r.t.Restore(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - restore")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Purge Profile
+// @Description Remove a profile in the trash for good
+// @ID          purgeProfile
+// @Tags  	    profiles
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/profiles/trash/:name [delete]
+func (r *profileRoutes) purge(c *gin.Context) {
+	err := r.t.Purge(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - purge")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Clone Profile
+// @Description Create a profile from a copy of another one, the fields in the body, at least a new profileName, replace those of the copy
+// @ID          cloneProfile
+// @Tags  	    profiles
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.Profile
+// @Failure     400 {object} response
+// @Router      /api/v1/admin/profiles/:name/clone [post]
+func (r *profileRoutes) clone(c *gin.Context) {
+	overrides, err := c.GetRawData()
+	if err != nil {
+		validationErr := ErrValidationProfile.Wrap("clone", "GetRawData", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	newProfile, err := r.t.Clone(c.Request.Context(), c.Param("name"), overrides, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - clone")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newProfile)
+}
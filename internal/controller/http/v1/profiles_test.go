@@ -16,6 +16,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/profiles"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 func profilesTest(t *testing.T) (*mocks.MockProfilesFeature, *gin.Engine) {
@@ -82,7 +83,7 @@ func TestProfileRoutes(t *testing.T) { //nolint:gocognit // this is a test funct
 			method: http.MethodGet,
 			url:    "/api/v1/admin/profiles",
 			mock: func(profile *mocks.MockProfilesFeature) {
-				profile.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.Profile{{
+				profile.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return([]dto.Profile{{
 					ProfileName: "profile",
 				}}, nil)
 			},
@@ -94,10 +95,10 @@ func TestProfileRoutes(t *testing.T) { //nolint:gocognit // this is a test funct
 			method: http.MethodGet,
 			url:    "/api/v1/admin/profiles?$top=10&$skip=1&$count=true",
 			mock: func(domain *mocks.MockProfilesFeature) {
-				domain.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.Profile{{
+				domain.EXPECT().Get(context.Background(), odata.Query{}, 10, 1, "").Return([]dto.Profile{{
 					ProfileName: "profile",
 				}}, nil)
-				domain.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+				domain.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(1, nil)
 			},
 			response:     dto.ProfileCountResponse{Count: 1, Data: []dto.Profile{{ProfileName: "profile"}}},
 			expectedCode: http.StatusOK,
@@ -107,7 +108,7 @@ func TestProfileRoutes(t *testing.T) { //nolint:gocognit // this is a test funct
 			method: http.MethodGet,
 			url:    "/api/v1/admin/profiles",
 			mock: func(domain *mocks.MockProfilesFeature) {
-				domain.EXPECT().Get(context.Background(), 25, 0, "").Return(nil, profiles.ErrDatabase)
+				domain.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return(nil, profiles.ErrDatabase)
 			},
 			response:     profiles.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -161,7 +162,7 @@ func TestProfileRoutes(t *testing.T) { //nolint:gocognit // this is a test funct
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/profiles/profile",
 			mock: func(profile *mocks.MockProfilesFeature) {
-				profile.EXPECT().Delete(context.Background(), "profile", "").Return(nil)
+				profile.EXPECT().Delete(context.Background(), "profile", "", "").Return(nil)
 			},
 			response:     nil,
 			expectedCode: http.StatusNoContent,
@@ -171,17 +172,51 @@ func TestProfileRoutes(t *testing.T) { //nolint:gocognit // this is a test funct
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/profiles/profile",
 			mock: func(profile *mocks.MockProfilesFeature) {
-				profile.EXPECT().Delete(context.Background(), "profile", "").Return(profiles.ErrDatabase)
+				profile.EXPECT().Delete(context.Background(), "profile", "", "").Return(profiles.ErrDatabase)
 			},
 			response:     profiles.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			name:   "get deleted profiles",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/profiles/trash",
+			mock: func(profile *mocks.MockProfilesFeature) {
+				profile.EXPECT().GetDeleted(context.Background(), 25, 0, "").Return([]dto.Profile{{ProfileName: "profile", DeletedAt: "2025-08-08T00:00:00Z"}}, nil)
+			},
+			response:     []dto.Profile{{ProfileName: "profile", DeletedAt: "2025-08-08T00:00:00Z"}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "restore profile",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/profiles/trash/profile/restore",
+			mock: func(profile *mocks.MockProfilesFeature) {
+				profile.EXPECT().Restore(context.Background(), "profile", "").Return(nil)
+			},
+			response:     nil,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "purge profile",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/profiles/trash/profile",
+			mock: func(profile *mocks.MockProfilesFeature) {
+				profile.EXPECT().Purge(context.Background(), "profile", "").Return(nil)
+			},
+			response:     nil,
+			expectedCode: http.StatusNoContent,
+		},
 		{
 			name:   "update profile",
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/profiles",
 			mock: func(profile *mocks.MockProfilesFeature) {
-				profile.EXPECT().Update(context.Background(), &profileTest).Return(&profileTest, nil)
+				// the version comes from If-Match, not the body
+				expected := profileTest
+				expected.Version = ""
+
+				profile.EXPECT().Update(context.Background(), &expected).Return(&profileTest, nil)
 			},
 			response:     profileTest,
 			requestBody:  profileTest,
@@ -192,7 +227,11 @@ func TestProfileRoutes(t *testing.T) { //nolint:gocognit // this is a test funct
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/profiles",
 			mock: func(profile *mocks.MockProfilesFeature) {
-				profile.EXPECT().Update(context.Background(), &profileTest).Return(nil, profiles.ErrDatabase)
+				// the version comes from If-Match, not the body
+				expected := profileTest
+				expected.Version = ""
+
+				profile.EXPECT().Update(context.Background(), &expected).Return(nil, profiles.ErrDatabase)
 			},
 			response:     profiles.ErrDatabase,
 			requestBody:  profileTest,
@@ -258,6 +297,27 @@ func TestProfileRoutes(t *testing.T) { //nolint:gocognit // this is a test funct
 			},
 			expectedCode: http.StatusOK,
 		},
+		{
+			name:   "clone profile",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/profiles/profile/clone",
+			mock: func(profile *mocks.MockProfilesFeature) {
+				profile.EXPECT().Clone(context.Background(), "profile", gomock.Any(), "").Return(&profileTest, nil)
+			},
+			response:     profileTest,
+			requestBody:  profileTest,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "clone profile - not valid",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/profiles/profile/clone",
+			mock: func(profile *mocks.MockProfilesFeature) {
+				profile.EXPECT().Clone(context.Background(), "profile", gomock.Any(), "").Return(nil, profiles.ErrNotValid)
+			},
+			requestBody:  profileTest,
+			expectedCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range tests {
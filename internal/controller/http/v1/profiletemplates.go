@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/profiletemplates"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationProfileTemplates = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ProfileTemplatesAPI")}
+
+type profileTemplateRoutes struct {
+	t profiletemplates.Feature
+	l logger.Interface
+}
+
+func NewProfileTemplateRoutes(handler *gin.RouterGroup, t profiletemplates.Feature, l logger.Interface) {
+	r := &profileTemplateRoutes{t, l}
+
+	h := handler.Group("/profiletemplates")
+	{
+		h.GET("", r.get)
+		h.GET(":name", r.getByName)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":name", r.delete)
+		h.POST("preview", r.preview)
+	}
+}
+
+// @Summary     Show Profile Templates
+// @Description Show the templates profiles can be derived from
+// @ID          profileTemplates
+// @Tags  	    profiletemplates
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ProfileTemplateCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/profiletemplates [get]
+func (r *profileTemplateRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationProfileTemplates.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ProfileTemplateCountResponse{Count: count, Data: items})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Profile Template
+// @Description Show a profile template by name
+// @ID          profileTemplate
+// @Tags  	    profiletemplates
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ProfileTemplate
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/profiletemplates/:name [get]
+func (r *profileTemplateRoutes) getByName(c *gin.Context) {
+	item, err := r.t.GetByName(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByName")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Add Profile Template
+// @Description Add a template holding the settings shared by the profiles derived from it
+// @ID          insertProfileTemplate
+// @Tags  	    profiletemplates
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.ProfileTemplate
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/profiletemplates [post]
+func (r *profileTemplateRoutes) insert(c *gin.Context) {
+	var template dto.ProfileTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		validationErr := ErrValidationProfileTemplates.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	template.TenantID = callerTenant(c)
+
+	newTemplate, err := r.t.Insert(c.Request.Context(), &template)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newTemplate)
+}
+
+// @Summary     Edit Profile Template
+// @Description Edit a profile template, the profiles derived from it take the settings they do not override
+// @ID          updateProfileTemplate
+// @Tags  	    profiletemplates
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ProfileTemplate
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/profiletemplates [patch]
+func (r *profileTemplateRoutes) update(c *gin.Context) {
+	var template dto.ProfileTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		validationErr := ErrValidationProfileTemplates.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	template.TenantID = callerTenant(c)
+
+	updatedTemplate, err := r.t.Update(c.Request.Context(), &template)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedTemplate)
+}
+
+// @Summary     Remove Profile Template
+// @Description Remove a profile template no profile is derived from
+// @ID          deleteProfileTemplate
+// @Tags  	    profiletemplates
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     400 {object} response
+// @Router      /api/v1/admin/profiletemplates/:name [delete]
+func (r *profileTemplateRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("name"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Preview Profile Template Edit
+// @Description Show the settings an edit of a template would change in the profiles derived from it, without saving it
+// @ID          previewProfileTemplate
+// @Tags  	    profiletemplates
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.ProfileTemplateImpact
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/profiletemplates/preview [post]
+func (r *profileTemplateRoutes) preview(c *gin.Context) {
+	var template dto.ProfileTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		validationErr := ErrValidationProfileTemplates.Wrap("preview", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	template.TenantID = callerTenant(c)
+
+	impacts, err := r.t.Preview(c.Request.Context(), &template)
+	if err != nil {
+		r.l.Error(err, "http - v1 - preview")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, impacts)
+}
@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/profiletemplates"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func TestProfileTemplateRoutes(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*mocks.MockProfileTemplatesFeature, *gin.Engine) {
+		t.Helper()
+
+		templatesMock := mocks.NewMockProfileTemplatesFeature(gomock.NewController(t))
+
+		engine := gin.New()
+		handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+			setTenant(c, "t1")
+		})
+
+		NewProfileTemplateRoutes(handler, templatesMock, logger.New("error"))
+
+		return templatesMock, engine
+	}
+
+	serve := func(engine *gin.Engine, method, url string, body io.Reader) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), method, url, body)
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	tlsMode := 2
+	template := dto.ProfileTemplate{TemplateName: "site", ProfileTemplateSettings: dto.ProfileTemplateSettings{TLSMode: &tlsMode}, TenantID: "t1"}
+
+	t.Run("list with count", func(t *testing.T) {
+		t.Parallel()
+
+		templatesMock, engine := setup(t)
+
+		items := []dto.ProfileTemplate{template}
+
+		templatesMock.EXPECT().Get(gomock.Any(), 25, 0, "t1").Return(items, nil)
+		templatesMock.EXPECT().GetCount(gomock.Any(), "t1").Return(1, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/profiletemplates?$count=true", http.NoBody)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(dto.ProfileTemplateCountResponse{Count: 1, Data: items})
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		t.Parallel()
+
+		templatesMock, engine := setup(t)
+
+		templatesMock.EXPECT().Insert(gomock.Any(), &template).Return(&template, nil)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/profiletemplates", strings.NewReader(`{"templateName":"site","tlsMode":2}`))
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.JSONEq(t, `{"templateName":"site","tlsMode":2,"tenantId":"t1"}`, w.Body.String())
+	})
+
+	t.Run("delete a template in use", func(t *testing.T) {
+		t.Parallel()
+
+		templatesMock, engine := setup(t)
+
+		templatesMock.EXPECT().Delete(gomock.Any(), "site", "t1").Return(profiletemplates.ErrValidation)
+
+		w := serve(engine, http.MethodDelete, "/api/v1/admin/profiletemplates/site", http.NoBody)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("update applied to some derived profiles only", func(t *testing.T) {
+		t.Parallel()
+
+		templatesMock, engine := setup(t)
+
+		partial := profiletemplates.PartiallyAppliedError{Console: profiletemplates.ErrProfileTemplatesUseCase}.Wrap("Update", "uc.profiles.ApplyTemplate", []string{"p1"}, nil)
+		templatesMock.EXPECT().Update(gomock.Any(), &template).Return(nil, partial)
+
+		w := serve(engine, http.MethodPatch, "/api/v1/admin/profiletemplates", strings.NewReader(`{"templateName":"site","tlsMode":2}`))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), "[p1]")
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		t.Parallel()
+
+		templatesMock, engine := setup(t)
+
+		impacts := []dto.ProfileTemplateImpact{{ProfileName: "p1", Changes: []string{"tlsMode"}, Overrides: []string{"tags"}}}
+		templatesMock.EXPECT().Preview(gomock.Any(), &template).Return(impacts, nil)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/profiletemplates/preview", strings.NewReader(`{"templateName":"site","tlsMode":2}`))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(impacts)
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("preview of a missing template", func(t *testing.T) {
+		t.Parallel()
+
+		templatesMock, engine := setup(t)
+
+		templatesMock.EXPECT().Preview(gomock.Any(), gomock.Any()).Return(nil, profiletemplates.ErrNotFound)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/profiletemplates/preview", strings.NewReader(`{"templateName":"missing"}`))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// viewerTokenHeader carries the secret the primary viewer supplied when opening its redirection websocket.
+const viewerTokenHeader = "X-Viewer-Token"
+
+// getRedirectionSessions lists the active redirection sessions of the device and their viewers
+func (r *deviceManagementRoutes) getRedirectionSessions(c *gin.Context) {
+	guid := c.Param("guid")
+
+	sessions, err := r.d.GetRedirectionSessions(c.Request.Context(), guid)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRedirectionSessions")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// promoteRedirectionViewer hands control of the session to an observer
+func (r *deviceManagementRoutes) promoteRedirectionViewer(c *gin.Context) {
+	err := r.d.PromoteRedirectionViewer(c.Request.Context(), c.Param("guid"), c.Param("mode"), c.Param("id"), c.GetHeader(viewerTokenHeader))
+	if err != nil {
+		r.l.Error(err, "http - v1 - promoteRedirectionViewer")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// revokeRedirectionViewer disconnects a viewer from the session
+func (r *deviceManagementRoutes) revokeRedirectionViewer(c *gin.Context) {
+	err := r.d.RevokeRedirectionViewer(c.Request.Context(), c.Param("guid"), c.Param("mode"), c.Param("id"), c.GetHeader(viewerTokenHeader))
+	if err != nil {
+		r.l.Error(err, "http - v1 - revokeRedirectionViewer")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
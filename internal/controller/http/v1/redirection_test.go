@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func redirectionTest(t *testing.T) (*mocks.MockDeviceManagementFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	deviceManagement := mocks.NewMockDeviceManagementFeature(mockCtl)
+	amtExplorerMock := mocks.NewMockAMTExplorerFeature(mockCtl)
+	exporterMock := mocks.NewMockExporter(mockCtl)
+	engine := gin.New()
+	handler := engine.Group("/api/v1")
+	NewAmtRoutes(handler, deviceManagement, amtExplorerMock, exporterMock, log)
+
+	return deviceManagement, engine
+}
+
+func TestRedirectionSessionRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(m *mocks.MockDeviceManagementFeature)
+		expectedCode int
+	}{
+		{
+			name:   "list sessions",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/redirection/sessions/guid",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetRedirectionSessions(context.Background(), "guid").
+					Return([]dto.RedirectionSession{{GUID: "guid", Mode: "kvm"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "promote viewer",
+			method: http.MethodPost,
+			url:    "/api/v1/amt/redirection/sessions/guid/kvm/viewers/viewer1/promote",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().PromoteRedirectionViewer(context.Background(), "guid", "kvm", "viewer1", "secret").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "revoke viewer forbidden",
+			method: http.MethodDelete,
+			url:    "/api/v1/amt/redirection/sessions/guid/kvm/viewers/viewer1",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().RevokeRedirectionViewer(context.Background(), "guid", "kvm", "viewer1", "secret").Return(devices.ErrViewerForbidden)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deviceManagement, engine := redirectionTest(t)
+			tc.mock(deviceManagement)
+
+			req := httptest.NewRequest(tc.method, tc.url, http.NoBody)
+			req.Header.Set("X-Viewer-Token", "secret")
+
+			rr := httptest.NewRecorder()
+			engine.ServeHTTP(rr, req)
+			require.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
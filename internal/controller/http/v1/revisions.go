@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationRevisions = dto.NotValidError{Console: consoleerrors.CreateConsoleError("RevisionsAPI")}
+
+type revisionRoutes struct {
+	v revisions.Feature
+	l logger.Interface
+}
+
+// revisionDiffQuery names the two revisions a diff compares.
+type revisionDiffQuery struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+func NewRevisionRoutes(handler *gin.RouterGroup, v revisions.Feature, l logger.Interface) {
+	r := &revisionRoutes{v, l}
+
+	h := handler.Group("/revisions")
+	{
+		h.GET(":kind/:name", r.get)
+		h.GET(":kind/:name/diff", r.diff)
+		h.GET(":kind/:name/:revision", r.getByRevision)
+		h.POST(":kind/:name/:revision/rollback", r.rollback)
+	}
+}
+
+// @Summary     Show Configuration Revisions
+// @Description Show the revisions of a profile, wireless config, CIRA config, 802.1X config or domain, newest first
+// @ID          revisions
+// @Tags  	    revisions
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ConfigRevisionCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/revisions/:kind/:name [get]
+func (r *revisionRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationRevisions.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.v.Get(c.Request.Context(), c.Param("kind"), c.Param("name"), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.v.GetCount(c.Request.Context(), c.Param("kind"), c.Param("name"), callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ConfigRevisionCountResponse{Count: count, Data: items})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Configuration Revision
+// @Description Show the full content of a revision, the values of its secrets are not shown
+// @ID          revision
+// @Tags  	    revisions
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ConfigRevision
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/revisions/:kind/:name/:revision [get]
+func (r *revisionRoutes) getByRevision(c *gin.Context) {
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		ErrorResponse(c, ErrValidationRevisions.Wrap("getByRevision", "strconv.Atoi", err))
+
+		return
+	}
+
+	item, err := r.v.GetByRevision(c.Request.Context(), c.Param("kind"), c.Param("name"), revision, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByRevision")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Compare Configuration Revisions
+// @Description Show the fields that differ between two revisions, changed secrets are listed without their values
+// @ID          revisionDiff
+// @Tags  	    revisions
+// @Accept      json
+// @Produce     json
+// @Param       from query int true "revision to compare from"
+// @Param       to query int true "revision to compare to"
+// @Success     200 {object} dto.RevisionDiff
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/revisions/:kind/:name/diff [get]
+func (r *revisionRoutes) diff(c *gin.Context) {
+	var query revisionDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		validationErr := ErrValidationRevisions.Wrap("diff", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	diff, err := r.v.Diff(c.Request.Context(), c.Param("kind"), c.Param("name"), query.From, query.To, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - diff")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// @Summary     Roll Back Configuration
+// @Description Save the content of a revision as the current configuration, the rollback is recorded as a new revision
+// @ID          rollbackRevision
+// @Tags  	    revisions
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ConfigRevision
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/revisions/:kind/:name/:revision/rollback [post]
+func (r *revisionRoutes) rollback(c *gin.Context) {
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		ErrorResponse(c, ErrValidationRevisions.Wrap("rollback", "strconv.Atoi", err))
+
+		return
+	}
+
+	item, err := r.v.Rollback(c.Request.Context(), c.Param("kind"), c.Param("name"), revision, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - rollback")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func TestRevisionRoutes(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*mocks.MockRevisionsFeature, *gin.Engine) {
+		t.Helper()
+
+		revisionsMock := mocks.NewMockRevisionsFeature(gomock.NewController(t))
+
+		engine := gin.New()
+		handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+			setTenant(c, "t1")
+		})
+
+		NewRevisionRoutes(handler, revisionsMock, logger.New("error"))
+
+		return revisionsMock, engine
+	}
+
+	serve := func(engine *gin.Engine, method, url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequestWithContext(context.Background(), method, url, http.NoBody)
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("list with count", func(t *testing.T) {
+		t.Parallel()
+
+		revisionsMock, engine := setup(t)
+
+		items := []dto.ConfigRevision{{Kind: "profiles", Name: "p1", Revision: 2, TenantID: "t1"}}
+
+		revisionsMock.EXPECT().Get(gomock.Any(), "profiles", "p1", 25, 0, "t1").Return(items, nil)
+		revisionsMock.EXPECT().GetCount(gomock.Any(), "profiles", "p1", "t1").Return(1, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/revisions/profiles/p1?$count=true")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(dto.ConfigRevisionCountResponse{Count: 1, Data: items})
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+
+		revisionsMock, engine := setup(t)
+
+		diff := &dto.RevisionDiff{Kind: "ciraconfigs", Name: "c1", From: 1, To: 2, Changes: []dto.RevisionChange{{Field: "password", Secret: true}}}
+		revisionsMock.EXPECT().Diff(gomock.Any(), "ciraconfigs", "c1", 1, 2, "t1").Return(diff, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/revisions/ciraconfigs/c1/diff?from=1&to=2")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(diff)
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("diff with an invalid revision", func(t *testing.T) {
+		t.Parallel()
+
+		_, engine := setup(t)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/revisions/ciraconfigs/c1/diff?from=first&to=2")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get revision", func(t *testing.T) {
+		t.Parallel()
+
+		revisionsMock, engine := setup(t)
+
+		revisionsMock.EXPECT().GetByRevision(gomock.Any(), "domains", "d1", 4, "t1").Return(nil, revisions.ErrNotFound)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/revisions/domains/d1/4")
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		t.Parallel()
+
+		revisionsMock, engine := setup(t)
+
+		rev := &dto.ConfigRevision{Kind: "wirelessconfigs", Name: "w1", Revision: 5, TenantID: "t1"}
+		revisionsMock.EXPECT().Rollback(gomock.Any(), "wirelessconfigs", "w1", 2, "t1").Return(rev, nil)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/revisions/wirelessconfigs/w1/2/rollback")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(rev)
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("rollback to an invalid revision", func(t *testing.T) {
+		t.Parallel()
+
+		_, engine := setup(t)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/revisions/wirelessconfigs/w1/latest/rollback")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationTenants = dto.NotValidError{Console: consoleerrors.CreateConsoleError("TenantsAPI")}
+
+type tenantRoutes struct {
+	t tenants.Feature
+	l logger.Interface
+}
+
+func NewTenantRoutes(handler *gin.RouterGroup, t tenants.Feature, l logger.Interface) {
+	r := &tenantRoutes{t, l}
+
+	h := handler.Group("/tenants", requireDefaultTenant)
+	{
+		h.GET("", r.get)
+		h.GET(":id", r.getByID)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":id", r.delete)
+	}
+}
+
+// requireDefaultTenant keeps callers that belong to a tenant from seeing or changing the other tenants.
+func requireDefaultTenant(c *gin.Context) {
+	if callerTenant(c) != tenants.DefaultTenant {
+		c.AbortWithStatusJSON(http.StatusForbidden, response{"tenants can only be managed from the default tenant"})
+
+		return
+	}
+
+	c.Next()
+}
+
+type TenantCountResponse struct {
+	Count int          `json:"totalCount"`
+	Data  []dto.Tenant `json:"data"`
+}
+
+// @Summary     Show Tenants
+// @Description Show all tenants
+// @ID          tenants
+// @Tags  	    tenants
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} TenantCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/tenants [get]
+func (r *tenantRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationTenants.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context())
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, TenantCountResponse{Count: count, Data: items})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Tenant
+// @Description Show tenant by id
+// @ID          tenant
+// @Tags  	    tenants
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.Tenant
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/tenants/:id [get]
+func (r *tenantRoutes) getByID(c *gin.Context) {
+	item, err := r.t.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByID")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Add Tenant
+// @Description Register a tenant, its users are then added under /api/v1/admin/users with its tenantId
+// @ID          insertTenant
+// @Tags  	    tenants
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.Tenant
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/tenants [post]
+func (r *tenantRoutes) insert(c *gin.Context) {
+	var tenant dto.Tenant
+	if err := c.ShouldBindJSON(&tenant); err != nil {
+		validationErr := ErrValidationTenants.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	newTenant, err := r.t.Insert(c.Request.Context(), &tenant)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newTenant)
+}
+
+// @Summary     Edit Tenant
+// @Description Change the name or description of a tenant
+// @ID          updateTenant
+// @Tags  	    tenants
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.Tenant
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/tenants [patch]
+func (r *tenantRoutes) update(c *gin.Context) {
+	var tenant dto.Tenant
+	if err := c.ShouldBindJSON(&tenant); err != nil {
+		validationErr := ErrValidationTenants.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	updatedTenant, err := r.t.Update(c.Request.Context(), &tenant)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedTenant)
+}
+
+// @Summary     Remove Tenant
+// @Description Remove a tenant registration, the data of the tenant is kept
+// @ID          deleteTenant
+// @Tags  	    tenants
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/tenants/:id [delete]
+func (r *tenantRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func tenantsTest(t *testing.T, tenant string) (*mocks.MockTenantsFeature, *gin.Engine) {
+	t.Helper()
+
+	tenantsFeature := mocks.NewMockTenantsFeature(gomock.NewController(t))
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) {
+		setTenant(c, tenant)
+	})
+
+	NewTenantRoutes(handler, tenantsFeature, logger.New("error"))
+
+	return tenantsFeature, engine
+}
+
+func TestTenantRoutes(t *testing.T) {
+	t.Parallel()
+
+	serve := func(engine *gin.Engine, method, url string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+
+		req, _ := http.NewRequestWithContext(context.Background(), method, url, bytes.NewBuffer(data))
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("list from the default tenant", func(t *testing.T) {
+		t.Parallel()
+
+		tenantsFeature, engine := tenantsTest(t, tenants.DefaultTenant)
+
+		list := []dto.Tenant{{ID: "bu-retail", Name: "Retail"}}
+		tenantsFeature.EXPECT().Get(gomock.Any(), 25, 0).Return(list, nil)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/tenants", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		expected, _ := json.Marshal(list)
+		require.JSONEq(t, string(expected), w.Body.String())
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		t.Parallel()
+
+		tenantsFeature, engine := tenantsTest(t, tenants.DefaultTenant)
+
+		tenant := &dto.Tenant{ID: "bu-retail", Name: "Retail"}
+		tenantsFeature.EXPECT().Insert(gomock.Any(), tenant).Return(tenant, nil)
+
+		w := serve(engine, http.MethodPost, "/api/v1/admin/tenants", tenant)
+		require.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("unknown tenant", func(t *testing.T) {
+		t.Parallel()
+
+		tenantsFeature, engine := tenantsTest(t, tenants.DefaultTenant)
+
+		tenantsFeature.EXPECT().GetByID(gomock.Any(), "unknown").Return(nil, tenants.ErrNotFound)
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/tenants/unknown", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("caller of another tenant", func(t *testing.T) {
+		t.Parallel()
+
+		_, engine := tenantsTest(t, "bu-retail")
+
+		w := serve(engine, http.MethodGet, "/api/v1/admin/tenants", nil)
+		require.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serve(engine, http.MethodDelete, "/api/v1/admin/tenants/bu-energy", nil)
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,285 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/apikeys"
+	"github.com/device-management-toolkit/console/internal/usecase/auth"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+var ErrValidationUsers = dto.NotValidError{Console: consoleerrors.CreateConsoleError("UsersAPI")}
+
+type userRoutes struct {
+	t       users.Feature
+	tenants tenants.Feature
+	auth    auth.Feature
+	keys    apikeys.Feature
+	l       logger.Interface
+}
+
+func NewUserRoutes(handler *gin.RouterGroup, t users.Feature, tn tenants.Feature, a auth.Feature, k apikeys.Feature, l logger.Interface) {
+	r := &userRoutes{t, tn, a, k, l}
+
+	h := handler.Group("/users")
+	{
+		h.GET("", r.get)
+		h.GET(":username", r.getByUsername)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":username", r.delete)
+		h.POST(":username/logout", r.logout)
+	}
+}
+
+type UserCountResponse struct {
+	Count int        `json:"totalCount"`
+	Data  []dto.User `json:"data"`
+}
+
+// @Summary     Show Users
+// @Description Show all console users
+// @ID          users
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} UserCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users [get]
+func (r *userRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationUsers.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - get")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), callerTenant(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, UserCountResponse{Count: count, Data: items})
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show User
+// @Description Show console user by username
+// @ID          user
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.User
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users/:username [get]
+func (r *userRoutes) getByUsername(c *gin.Context) {
+	item, err := r.t.GetByUsername(c.Request.Context(), c.Param("username"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByUsername")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Add User
+// @Description Add a console user. Callers of the default tenant may add the user to another tenant with tenantId.
+// @ID          insertUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.User
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users [post]
+func (r *userRoutes) insert(c *gin.Context) {
+	var user dto.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		validationErr := ErrValidationUsers.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	if err := r.resolveTenant(c, &user); err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	newUser, err := r.t.Insert(c.Request.Context(), &user)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newUser)
+}
+
+// resolveTenant picks the tenant of a new user. Callers of the default tenant may add users to any registered
+// tenant, everybody else only to their own.
+func (r *userRoutes) resolveTenant(c *gin.Context, user *dto.User) error {
+	if tenant := callerTenant(c); tenant != tenants.DefaultTenant || user.TenantID == tenants.DefaultTenant {
+		user.TenantID = tenant
+
+		return nil
+	}
+
+	_, err := r.tenants.GetByID(c.Request.Context(), user.TenantID)
+	if errors.Is(err, tenants.ErrNotFound) {
+		return ErrValidationUsers.Wrap("resolveTenant", "tenants.GetByID", errors.New("unknown tenant "+user.TenantID))
+	}
+
+	return err
+}
+
+// @Summary     Edit User
+// @Description Change the role or password of a console user. Either change logs the user out, changing the role also
+// @Description revokes the API keys of the user.
+// @ID          updateUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.User
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users [patch]
+func (r *userRoutes) update(c *gin.Context) {
+	var user dto.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		validationErr := ErrValidationUsers.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	user.TenantID = callerTenant(c)
+
+	existing, err := r.t.GetByUsername(c.Request.Context(), user.Username, user.TenantID)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	updatedUser, err := r.t.Update(c.Request.Context(), &user)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	// the tokens of the user carry its previous role and the scopes of its API keys were granted by it, a new
+	// password ends the sessions opened with the old one
+	switch {
+	case updatedUser.Role != existing.Role:
+		err = r.revoke(c, user.Username)
+	case user.Password != "":
+		err = r.auth.RevokeSubject(c.Request.Context(), user.Username)
+	}
+
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedUser)
+}
+
+// @Summary     Remove User
+// @Description Remove a console user, the sessions and API keys of the user end with it
+// @ID          deleteUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users/:username [delete]
+func (r *userRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("username"), callerTenant(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if err := r.revoke(c, c.Param("username")); err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Log User Out
+// @Description Force a logout of the user everywhere: its access and refresh tokens and its API keys are revoked. Callers of the
+// @Description default tenant can also log out the configured admin and identity provider subjects.
+// @ID          logoutUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users/:username/logout [post]
+func (r *userRoutes) logout(c *gin.Context) {
+	username := c.Param("username")
+
+	if _, err := r.t.GetByUsername(c.Request.Context(), username, callerTenant(c)); err != nil {
+		if !errors.Is(err, users.ErrNotFound) || callerTenant(c) != tenants.DefaultTenant {
+			r.l.Error(err, "http - v1 - logout")
+			ErrorResponse(c, err)
+
+			return
+		}
+	}
+
+	if err := r.revoke(c, username); err != nil {
+		r.l.Error(err, "http - v1 - logout")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// revoke ends every session of username: its tokens and its API keys.
+func (r *userRoutes) revoke(c *gin.Context, username string) error {
+	if err := r.auth.RevokeSubject(c.Request.Context(), username); err != nil {
+		return err
+	}
+
+	return r.keys.DeleteByOwner(c.Request.Context(), username)
+}
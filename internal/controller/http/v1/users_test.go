@@ -0,0 +1,355 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/apikeys"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func usersTest(t *testing.T) (*mocks.MockUsersFeature, *mocks.MockAuthFeature, *mocks.MockAPIKeysFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	keys := mocks.NewMockAPIKeysFeature(mockCtl)
+
+	user, _, authFeature, engine := tenantUsersTest(t, "", keys)
+
+	return user, authFeature, keys, engine
+}
+
+// tenantUsersTest serves the user routes to a caller of tenant.
+func tenantUsersTest(t *testing.T, tenant string, keys apikeys.Feature) (*mocks.MockUsersFeature, *mocks.MockTenantsFeature, *mocks.MockAuthFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	user := mocks.NewMockUsersFeature(mockCtl)
+	tenantFeature := mocks.NewMockTenantsFeature(mockCtl)
+	authFeature := mocks.NewMockAuthFeature(mockCtl)
+
+	if keys == nil {
+		keys = mocks.NewMockAPIKeysFeature(mockCtl)
+	}
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin")
+
+	if tenant != "" {
+		handler.Use(func(c *gin.Context) { setTenant(c, tenant) })
+	}
+
+	NewUserRoutes(handler, user, tenantFeature, authFeature, keys, log)
+
+	return user, tenantFeature, authFeature, engine
+}
+
+func TestUserRoutes(t *testing.T) {
+	t.Parallel()
+
+	viewer := dto.User{Username: "jdoe", Role: users.RoleViewer}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(user *mocks.MockUsersFeature, authFeature *mocks.MockAuthFeature, keys *mocks.MockAPIKeysFeature)
+		response     interface{}
+		requestBody  *dto.User
+		expectedCode int
+	}{
+		{
+			name:   "get all users - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/users?$top=10&$skip=1&$count=true",
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockAuthFeature, _ *mocks.MockAPIKeysFeature) {
+				user.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.User{viewer}, nil)
+				user.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+			},
+			response:     UserCountResponse{Count: 1, Data: []dto.User{viewer}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get user by username - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/users/jdoe",
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockAuthFeature, _ *mocks.MockAPIKeysFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "jdoe", "").Return(nil, users.ErrNotFound)
+			},
+			response:     response{"Error not found"},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "insert user",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockAuthFeature, _ *mocks.MockAPIKeysFeature) {
+				user.EXPECT().Insert(context.Background(), &dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleViewer}).Return(&viewer, nil)
+			},
+			requestBody:  &dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleViewer},
+			response:     viewer,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "update user",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockAuthFeature, _ *mocks.MockAPIKeysFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "jdoe", "").Return(&viewer, nil)
+				user.EXPECT().Update(context.Background(), &viewer).Return(&viewer, nil)
+			},
+			requestBody:  &viewer,
+			response:     viewer,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "update user role logs the user out and revokes its API keys",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature, authFeature *mocks.MockAuthFeature, keys *mocks.MockAPIKeysFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "jdoe", "").Return(&dto.User{Username: "jdoe", Role: users.RoleAdmin}, nil)
+				user.EXPECT().Update(context.Background(), &viewer).Return(&viewer, nil)
+				authFeature.EXPECT().RevokeSubject(context.Background(), "jdoe").Return(nil)
+				keys.EXPECT().DeleteByOwner(context.Background(), "jdoe").Return(nil)
+			},
+			requestBody:  &viewer,
+			response:     viewer,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "update user password logs the user out",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature, authFeature *mocks.MockAuthFeature, _ *mocks.MockAPIKeysFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "jdoe", "").Return(&viewer, nil)
+				user.EXPECT().Update(context.Background(), &dto.User{Username: "jdoe", Password: "new-viewer-pass", Role: users.RoleViewer}).Return(&viewer, nil)
+				authFeature.EXPECT().RevokeSubject(context.Background(), "jdoe").Return(nil)
+			},
+			requestBody:  &dto.User{Username: "jdoe", Password: "new-viewer-pass", Role: users.RoleViewer},
+			response:     viewer,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "delete user",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/users/jdoe",
+			mock: func(user *mocks.MockUsersFeature, authFeature *mocks.MockAuthFeature, keys *mocks.MockAPIKeysFeature) {
+				user.EXPECT().Delete(context.Background(), "jdoe", "").Return(nil)
+				authFeature.EXPECT().RevokeSubject(context.Background(), "jdoe").Return(nil)
+				keys.EXPECT().DeleteByOwner(context.Background(), "jdoe").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "log user out",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/users/jdoe/logout",
+			mock: func(user *mocks.MockUsersFeature, authFeature *mocks.MockAuthFeature, keys *mocks.MockAPIKeysFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "jdoe", "").Return(&viewer, nil)
+				authFeature.EXPECT().RevokeSubject(context.Background(), "jdoe").Return(nil)
+				keys.EXPECT().DeleteByOwner(context.Background(), "jdoe").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "log out a subject that is not a local user",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/users/standalone/logout",
+			mock: func(user *mocks.MockUsersFeature, authFeature *mocks.MockAuthFeature, keys *mocks.MockAPIKeysFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "standalone", "").Return(nil, users.ErrNotFound)
+				authFeature.EXPECT().RevokeSubject(context.Background(), "standalone").Return(nil)
+				keys.EXPECT().DeleteByOwner(context.Background(), "standalone").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			userFeature, authFeature, keys, engine := usersTest(t)
+
+			tc.mock(userFeature, authFeature, keys)
+
+			var req *http.Request
+
+			if tc.requestBody != nil {
+				data, _ := json.Marshal(tc.requestBody)
+				req, _ = http.NewRequestWithContext(context.Background(), tc.method, tc.url, bytes.NewBuffer(data))
+			} else {
+				req, _ = http.NewRequestWithContext(context.Background(), tc.method, tc.url, http.NoBody)
+			}
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				expected, _ := json.Marshal(tc.response)
+				require.JSONEq(t, string(expected), w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserRoutesTenant(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		tenant       string
+		requestBody  dto.User
+		mock         func(user *mocks.MockUsersFeature, tenantFeature *mocks.MockTenantsFeature)
+		expectedCode int
+	}{
+		{
+			name:        "default tenant adds a user to a registered tenant",
+			requestBody: dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleAdmin, TenantID: "bu-retail"},
+			mock: func(user *mocks.MockUsersFeature, tenantFeature *mocks.MockTenantsFeature) {
+				tenantFeature.EXPECT().GetByID(gomock.Any(), "bu-retail").Return(&dto.Tenant{ID: "bu-retail", Name: "Retail"}, nil)
+				user.EXPECT().Insert(gomock.Any(), &dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleAdmin, TenantID: "bu-retail"}).
+					Return(&dto.User{Username: "jdoe", Role: users.RoleAdmin, TenantID: "bu-retail"}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "default tenant cannot add a user to an unknown tenant",
+			requestBody: dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleAdmin, TenantID: "bu-unknown"},
+			mock: func(_ *mocks.MockUsersFeature, tenantFeature *mocks.MockTenantsFeature) {
+				tenantFeature.EXPECT().GetByID(gomock.Any(), "bu-unknown").Return(nil, tenants.ErrNotFound)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "tenant users only add users to their own tenant",
+			tenant:      "bu-retail",
+			requestBody: dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleViewer, TenantID: "bu-energy"},
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockTenantsFeature) {
+				user.EXPECT().Insert(gomock.Any(), &dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleViewer, TenantID: "bu-retail"}).
+					Return(&dto.User{Username: "jdoe", Role: users.RoleViewer, TenantID: "bu-retail"}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "tenant users only update users of their own tenant",
+			tenant:      "bu-retail",
+			requestBody: dto.User{Username: "jdoe", Role: users.RoleAdmin, TenantID: "bu-energy"},
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockTenantsFeature) {
+				user.EXPECT().GetByUsername(gomock.Any(), "jdoe", "bu-retail").Return(nil, users.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			userFeature, tenantFeature, _, engine := tenantUsersTest(t, tc.tenant, nil)
+
+			tc.mock(userFeature, tenantFeature)
+
+			method := http.MethodPost
+			if tc.requestBody.Password == "" {
+				method = http.MethodPatch
+			}
+
+			data, _ := json.Marshal(tc.requestBody)
+			req, _ := http.NewRequestWithContext(context.Background(), method, "/api/v1/admin/users", bytes.NewBuffer(data))
+
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestUserLogoutTenant(t *testing.T) {
+	t.Parallel()
+
+	userFeature, _, _, engine := tenantUsersTest(t, "bu-retail", nil)
+
+	// a user of another tenant, or a subject that is no local user, is not found for tenant callers
+	userFeature.EXPECT().GetByUsername(gomock.Any(), "jdoe", "bu-retail").Return(nil, users.ErrNotFound)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/admin/users/jdoe/logout", http.NoBody)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUserDeleteRevokesAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE api_keys (
+			id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			owner TEXT NOT NULL,
+			scopes TEXT NOT NULL,
+			key_hash TEXT NOT NULL,
+			expires_at TEXT,
+			last_used_at TEXT,
+			created_at TEXT NOT NULL,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (id)
+		);`)
+	require.NoError(t, err)
+
+	log := logger.New("error")
+	keys := apikeys.New(sqldb.NewAPIKeyRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, log), log)
+
+	// the key was created by the user within its own tenant, not the one of the admin removing the user
+	created, err := keys.Insert(context.Background(), &dto.APIKey{Name: "ci", Owner: "jdoe", Scopes: []string{users.PermissionDevicesRead}, TenantID: "bu-retail"})
+	require.NoError(t, err)
+
+	_, err = keys.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err)
+
+	userFeature, _, authFeature, engine := tenantUsersTest(t, "", keys)
+
+	userFeature.EXPECT().Delete(gomock.Any(), "jdoe", "").Return(nil)
+	authFeature.EXPECT().RevokeSubject(gomock.Any(), "jdoe").Return(nil)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/admin/users/jdoe", http.NoBody)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	_, err = keys.Authenticate(context.Background(), created.Key)
+	require.ErrorIs(t, err, apikeys.ErrInvalidKey)
+}
@@ -51,7 +51,15 @@ func (r *WirelessConfigRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	query, err := odata.Query()
+	if err != nil {
+		validationErr := ErrValidationWifiConfig.Wrap("get", "odata.Query", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), query, odata.Top, odata.Skip, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - getCount")
 		ErrorResponse(c, err)
@@ -60,7 +68,7 @@ func (r *WirelessConfigRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), query, callerTenant(c))
 		if err != nil {
 			r.l.Error(err, "http - wireless configs - v1 - getCount")
 			ErrorResponse(c, err)
@@ -71,16 +79,16 @@ func (r *WirelessConfigRoutes) get(c *gin.Context) {
 			Data:  items,
 		}
 
-		c.JSON(http.StatusOK, countResponse)
+		odata.respond(c, countResponse)
 	} else {
-		c.JSON(http.StatusOK, items)
+		odata.respond(c, items)
 	}
 }
 
 func (r *WirelessConfigRoutes) getByName(c *gin.Context) {
 	profileName := c.Param("profileName")
 
-	config, err := r.t.GetByName(c.Request.Context(), profileName, "")
+	config, err := r.t.GetByName(c.Request.Context(), profileName, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - getByName")
 		ErrorResponse(c, err)
@@ -88,6 +96,7 @@ func (r *WirelessConfigRoutes) getByName(c *gin.Context) {
 		return
 	}
 
+	setETag(c, config.Version)
 	c.JSON(http.StatusOK, config)
 }
 
@@ -100,6 +109,8 @@ func (r *WirelessConfigRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = callerTenant(c)
+
 	insertedConfig, err := r.t.Insert(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - insert")
@@ -121,6 +132,15 @@ func (r *WirelessConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = callerTenant(c)
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	config.Version = version
+
 	updatedWirelessConfig, err := r.t.Update(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - update")
@@ -129,13 +149,19 @@ func (r *WirelessConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	setETag(c, updatedWirelessConfig.Version)
 	c.JSON(http.StatusOK, updatedWirelessConfig)
 }
 
 func (r *WirelessConfigRoutes) delete(c *gin.Context) {
 	configName := c.Param("profileName")
 
-	err := r.t.Delete(c.Request.Context(), configName, "")
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	err := r.t.Delete(c.Request.Context(), configName, version, callerTenant(c))
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - delete")
 		ErrorResponse(c, err)
@@ -16,6 +16,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/wificonfigs"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 func wifiTest(t *testing.T) (*mocks.MockWiFiConfigsFeature, *gin.Engine) {
@@ -46,8 +47,8 @@ type wifiConfigTest struct {
 }
 
 var (
-	requestWiFiConfig  = dto.WirelessConfig{AuthenticationMethod: 4, EncryptionMethod: 3, SSID: "exampleSSID", PSKValue: 12345, PSKPassphrase: "examplepassphrase", ProfileName: "newprofile", LinkPolicy: []int{1, 2, 3}, TenantID: "tenant1", Version: "1.0"}
-	responseWiFiConfig = dto.WirelessConfig{AuthenticationMethod: 4, EncryptionMethod: 3, SSID: "exampleSSID", PSKValue: 12345, PSKPassphrase: "examplepassphrase", ProfileName: "newprofile", LinkPolicy: []int{1, 2, 3}, TenantID: "tenant1", Version: "1.0"}
+	requestWiFiConfig  = dto.WirelessConfig{AuthenticationMethod: 4, EncryptionMethod: 3, SSID: "exampleSSID", PSKValue: 12345, PSKPassphrase: "examplepassphrase", ProfileName: "newprofile", LinkPolicy: []int{1, 2, 3}, Version: "1.0"}
+	responseWiFiConfig = dto.WirelessConfig{AuthenticationMethod: 4, EncryptionMethod: 3, SSID: "exampleSSID", PSKValue: 12345, PSKPassphrase: "examplepassphrase", ProfileName: "newprofile", LinkPolicy: []int{1, 2, 3}, Version: "1.0"}
 )
 
 func TestWiFiConfigRoutes(t *testing.T) {
@@ -59,7 +60,7 @@ func TestWiFiConfigRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/wirelessconfigs",
 			mock: func(wificonfig *mocks.MockWiFiConfigsFeature) {
-				wificonfig.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.WirelessConfig{{
+				wificonfig.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return([]dto.WirelessConfig{{
 					ProfileName: "profile",
 				}}, nil)
 			},
@@ -71,10 +72,10 @@ func TestWiFiConfigRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/wirelessconfigs?$top=10&$skip=1&$count=true",
 			mock: func(wificonfig *mocks.MockWiFiConfigsFeature) {
-				wificonfig.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.WirelessConfig{{
+				wificonfig.EXPECT().Get(context.Background(), odata.Query{}, 10, 1, "").Return([]dto.WirelessConfig{{
 					ProfileName: "profile",
 				}}, nil)
-				wificonfig.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+				wificonfig.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(1, nil)
 			},
 			response:     dto.WirelessConfigCountResponse{Count: 1, Data: []dto.WirelessConfig{{ProfileName: "profile"}}},
 			expectedCode: http.StatusOK,
@@ -84,7 +85,7 @@ func TestWiFiConfigRoutes(t *testing.T) {
 			method: http.MethodGet,
 			url:    "/api/v1/admin/wirelessconfigs",
 			mock: func(wificonfig *mocks.MockWiFiConfigsFeature) {
-				wificonfig.EXPECT().Get(context.Background(), 25, 0, "").Return(nil, wificonfigs.ErrDatabase)
+				wificonfig.EXPECT().Get(context.Background(), odata.Query{}, 25, 0, "").Return(nil, wificonfigs.ErrDatabase)
 			},
 			response:     wificonfigs.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -124,7 +125,6 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
 				wificonfig.EXPECT().Insert(context.Background(), wificonfigTest).Return(wificonfigTest, nil)
@@ -146,7 +146,6 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
 				wificonfig.EXPECT().Insert(context.Background(), wificonfigTest).Return(nil, wificonfigs.ErrDatabase)
@@ -168,13 +167,12 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
 				wificonfig.EXPECT().Insert(context.Background(), wificonfigTest).Return(nil, wificonfigs.ErrDatabase)
 			},
 			response:     wificonfigs.ErrDatabase,
-			requestBody:  dto.WirelessConfig{AuthenticationMethod: 4, EncryptionMethod: 3, SSID: "exampleSSID", PSKValue: 12345, PSKPassphrase: "examplepassphrase", ProfileName: "newprofile", LinkPolicy: []int{1, 2, 3}, TenantID: "tenant1", Version: "1.0"},
+			requestBody:  dto.WirelessConfig{AuthenticationMethod: 4, EncryptionMethod: 3, SSID: "exampleSSID", PSKValue: 12345, PSKPassphrase: "examplepassphrase", ProfileName: "newprofile", LinkPolicy: []int{1, 2, 3}, Version: "1.0"},
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -182,7 +180,7 @@ func TestWiFiConfigRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/wirelessconfigs/profile",
 			mock: func(wificonfig *mocks.MockWiFiConfigsFeature) {
-				wificonfig.EXPECT().Delete(context.Background(), "profile", "").Return(nil)
+				wificonfig.EXPECT().Delete(context.Background(), "profile", "", "").Return(nil)
 			},
 			response:     nil,
 			expectedCode: http.StatusNoContent,
@@ -192,7 +190,7 @@ func TestWiFiConfigRoutes(t *testing.T) {
 			method: http.MethodDelete,
 			url:    "/api/v1/admin/wirelessconfigs/profile",
 			mock: func(wificonfig *mocks.MockWiFiConfigsFeature) {
-				wificonfig.EXPECT().Delete(context.Background(), "profile", "").Return(wificonfigs.ErrDatabase)
+				wificonfig.EXPECT().Delete(context.Background(), "profile", "", "").Return(wificonfigs.ErrDatabase)
 			},
 			response:     wificonfigs.ErrDatabase,
 			expectedCode: http.StatusBadRequest,
@@ -210,10 +208,8 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
-					Version:              "1.0",
 				}
-				wificonfig.EXPECT().Update(context.Background(), wificonfigTest).Return(wificonfigTest, nil)
+				wificonfig.EXPECT().Update(context.Background(), wificonfigTest).Return(&responseWiFiConfig, nil)
 			},
 			response:     responseWiFiConfig,
 			requestBody:  requestWiFiConfig,
@@ -232,8 +228,6 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
-					Version:              "1.0",
 				}
 				wificonfig.EXPECT().Update(context.Background(), wificonfigTest).Return(nil, wificonfigs.ErrDatabase)
 			},
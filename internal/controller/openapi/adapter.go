@@ -31,6 +31,20 @@ func NewFuegoAdapter(usecases usecase.Usecases, log logger.Interface) *FuegoAdap
 	}
 }
 
+// optionETag documents the ETag returned with a resource read by name.
+func optionETag() func(*fuego.BaseRoute) {
+	return fuego.OptionResponseHeader("ETag", "Entity tag of the resource, sent in If-Match to change it")
+}
+
+// optionIfMatch documents the If-Match precondition of updates and deletes.
+func optionIfMatch() func(*fuego.BaseRoute) {
+	return fuego.GroupOptions(
+		fuego.OptionHeader("If-Match", "ETag of the resource as read, or * to skip the check"),
+		fuego.OptionAddError(http.StatusPreconditionFailed, "The resource was changed since it was read"),
+		fuego.OptionAddError(http.StatusPreconditionRequired, "If-Match header required when http.require_if_match is set"),
+	)
+}
+
 // Registers API routes with Fuego for automatic OpenAPI generation.
 func (f *FuegoAdapter) RegisterRoutes() {
 	// Profiles
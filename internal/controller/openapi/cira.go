@@ -21,6 +21,7 @@ func (f *FuegoAdapter) RegisterCIRAConfigRoutes() {
 		fuego.OptionSummary("Get CIRA Configuration by Name"),
 		fuego.OptionDescription("Retrieve a specific CIRA configuration by profile name"),
 		fuego.OptionPath("name", "Profile name"),
+		optionETag(),
 	)
 
 	fuego.Post(f.server, "/api/v1/admin/ciraconfigs", f.createCIRAConfig,
@@ -33,6 +34,7 @@ func (f *FuegoAdapter) RegisterCIRAConfigRoutes() {
 		fuego.OptionTags("CIRA"),
 		fuego.OptionSummary("Update CIRA Configuration"),
 		fuego.OptionDescription("Update an existing CIRA configuration"),
+		optionIfMatch(),
 	)
 
 	fuego.Delete(f.server, "/api/v1/admin/ciraconfigs/{name}", f.deleteCIRAConfig,
@@ -40,6 +42,7 @@ func (f *FuegoAdapter) RegisterCIRAConfigRoutes() {
 		fuego.OptionSummary("Delete CIRA Configuration"),
 		fuego.OptionDescription("Delete a CIRA configuration by profile name"),
 		fuego.OptionPath("name", "Profile name"),
+		optionIfMatch(),
 	)
 }
 
@@ -15,9 +15,12 @@ func (f *FuegoAdapter) RegisterDeviceRoutes() {
 		fuego.OptionDescription("Retrieve all devices with optional pagination and filtering"),
 		fuego.OptionQueryInt("$top", "Number of records to return"),
 		fuego.OptionQueryInt("$skip", "Number of records to skip"),
+		fuego.OptionQuery("$skiptoken", "Read the page following the skipToken of the previous one, empty for the first page, instead of skipping $skip records"),
 		fuego.OptionQueryBool("$count", "Include total count"),
+		fuego.OptionQueryBool("approximate", "Estimate the total count rather than counting the devices"),
 		fuego.OptionQuery("tags", "Comma-separated list of tags to filter devices"),
 		fuego.OptionQuery("method", "Method to filter tags (any/all)"),
+		fuego.OptionQuery("group", "Name of a device group whose members to list"),
 	)
 
 	fuego.Get(f.server, "/api/v1/admin/devices/stats", f.getDeviceStats,
@@ -26,6 +29,15 @@ func (f *FuegoAdapter) RegisterDeviceRoutes() {
 		fuego.OptionDescription("Retrieve statistics for devices"),
 	)
 
+	fuego.Get(f.server, "/api/v1/admin/devices/export", f.exportDevices,
+		fuego.OptionTags("Devices"),
+		fuego.OptionSummary("Export Devices"),
+		fuego.OptionDescription("Export the devices matching $filter and group with their custom attributes, as CSV unless format is json"),
+		fuego.OptionQuery("$filter", "Filter over the device properties, inventory and custom attributes"),
+		fuego.OptionQuery("group", "Name of a device group whose members to export"),
+		fuego.OptionQuery("format", "csv (default) or json"),
+	)
+
 	fuego.Get(f.server, "/api/v1/admin/devices/cert/{id}", f.getDeviceCertificate,
 		fuego.OptionTags("Devices"),
 		fuego.OptionSummary("Get Device Certificate"),
@@ -68,7 +80,27 @@ func (f *FuegoAdapter) RegisterDeviceRoutes() {
 	fuego.Delete(f.server, "/api/v1/admin/devices/{id}", f.deleteDevice,
 		fuego.OptionTags("Devices"),
 		fuego.OptionSummary("Delete Device"),
-		fuego.OptionDescription("Delete a device by ID"),
+		fuego.OptionDescription("Move a device to the trash, from where it can be restored until the retention is over"),
+		fuego.OptionPath("id", "Device ID"),
+	)
+
+	fuego.Get(f.server, "/api/v1/admin/devices/trash", f.getDeletedDevices,
+		fuego.OptionTags("Devices"),
+		fuego.OptionSummary("Get Deleted Devices"),
+		fuego.OptionDescription("Retrieve the devices in the trash, the most recently deleted first"),
+	)
+
+	fuego.Post(f.server, "/api/v1/admin/devices/trash/{id}/restore", f.restoreDevice,
+		fuego.OptionTags("Devices"),
+		fuego.OptionSummary("Restore Device"),
+		fuego.OptionDescription("Take a device out of the trash"),
+		fuego.OptionPath("id", "Device ID"),
+	)
+
+	fuego.Delete(f.server, "/api/v1/admin/devices/trash/{id}", f.purgeDevice,
+		fuego.OptionTags("Devices"),
+		fuego.OptionSummary("Purge Device"),
+		fuego.OptionDescription("Remove a device in the trash for good, with its credentials and pinned certificate"),
 		fuego.OptionPath("id", "Device ID"),
 	)
 }
@@ -107,6 +139,10 @@ func (f *FuegoAdapter) getDeviceStats(_ fuego.ContextNoBody) (dto.DeviceStatResp
 	}, nil
 }
 
+func (f *FuegoAdapter) exportDevices(_ fuego.ContextNoBody) ([]dto.Device, error) {
+	return []dto.Device{}, nil
+}
+
 func (f *FuegoAdapter) getDeviceCertificate(_ fuego.ContextNoBody) (dto.Certificate, error) {
 	return dto.Certificate{
 		GUID:       "example-guid-1",
@@ -158,3 +194,15 @@ func (f *FuegoAdapter) updateDevice(c fuego.ContextWithBody[dto.Device]) (dto.De
 func (f *FuegoAdapter) deleteDevice(_ fuego.ContextNoBody) (any, error) {
 	return nil, nil
 }
+
+func (f *FuegoAdapter) getDeletedDevices(_ fuego.ContextNoBody) ([]dto.Device, error) {
+	return []dto.Device{{GUID: "example-guid-1", DeletedAt: "2025-08-08T00:00:00Z"}}, nil
+}
+
+func (f *FuegoAdapter) restoreDevice(_ fuego.ContextNoBody) (any, error) {
+	return nil, nil
+}
+
+func (f *FuegoAdapter) purgeDevice(_ fuego.ContextNoBody) (any, error) {
+	return nil, nil
+}
@@ -27,12 +27,14 @@ func (f *FuegoAdapter) RegisterIEEE8021xConfigRoutes() {
 		fuego.OptionSummary("Get IEEE 802.1x Configuration by Name"),
 		fuego.OptionDescription("Retrieve a specific IEEE 802.1x configuration by name"),
 		fuego.OptionPath("name", "Configuration name"),
+		optionETag(),
 	)
 
 	fuego.Patch(f.server, "/api/v1/admin/ieee8021xconfigs", f.updateIEEE8021xConfig,
 		fuego.OptionTags("IEEE 802.1x"),
 		fuego.OptionSummary("Update IEEE 802.1x Configuration"),
 		fuego.OptionDescription("Update an existing IEEE 802.1x configuration"),
+		optionIfMatch(),
 	)
 
 	fuego.Delete(f.server, "/api/v1/admin/ieee8021xconfigs/{name}", f.deleteIEEE8021xConfig,
@@ -40,6 +42,7 @@ func (f *FuegoAdapter) RegisterIEEE8021xConfigRoutes() {
 		fuego.OptionSummary("Delete IEEE 802.1x Configuration"),
 		fuego.OptionDescription("Delete an IEEE 802.1x configuration by name"),
 		fuego.OptionPath("name", "Configuration name"),
+		optionIfMatch(),
 	)
 }
 
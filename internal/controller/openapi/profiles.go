@@ -21,6 +21,7 @@ func (f *FuegoAdapter) RegisterProfileRoutes() {
 		fuego.OptionSummary("Get Profile by Name"),
 		fuego.OptionDescription("Retrieve a specific profile by name"),
 		fuego.OptionPath("name", "Profile name"),
+		optionETag(),
 	)
 
 	fuego.Post(f.server, "/api/v1/admin/profiles", f.createProfile,
@@ -33,13 +34,15 @@ func (f *FuegoAdapter) RegisterProfileRoutes() {
 		fuego.OptionTags("Profiles"),
 		fuego.OptionSummary("Update Profile"),
 		fuego.OptionDescription("Update an existing profile"),
+		optionIfMatch(),
 	)
 
 	fuego.Delete(f.server, "/api/v1/admin/profiles/{name}", f.deleteProfile,
 		fuego.OptionTags("Profiles"),
 		fuego.OptionSummary("Delete Profile"),
-		fuego.OptionDescription("Delete a profile by name"),
+		fuego.OptionDescription("Move a profile to the trash, from where it can be restored until the retention is over"),
 		fuego.OptionPath("name", "Profile name"),
+		optionIfMatch(),
 	)
 
 	fuego.Get(f.server, "/api/v1/admin/profiles/export/{name}", f.exportProfile,
@@ -49,6 +52,33 @@ func (f *FuegoAdapter) RegisterProfileRoutes() {
 		fuego.OptionPath("name", "Profile name"),
 		fuego.OptionQuery("domainName", "Domain name for export"),
 	)
+
+	fuego.Post(f.server, "/api/v1/admin/profiles/{name}/clone", f.cloneProfile,
+		fuego.OptionTags("Profiles"),
+		fuego.OptionSummary("Clone Profile"),
+		fuego.OptionDescription("Create a profile from a copy of another one, the fields in the body, at least a new profileName, replace those of the copy"),
+		fuego.OptionPath("name", "Name of the profile to copy"),
+	)
+
+	fuego.Get(f.server, "/api/v1/admin/profiles/trash", f.getDeletedProfiles,
+		fuego.OptionTags("Profiles"),
+		fuego.OptionSummary("Get Deleted Profiles"),
+		fuego.OptionDescription("Retrieve the profiles in the trash, the most recently deleted first"),
+	)
+
+	fuego.Post(f.server, "/api/v1/admin/profiles/trash/{name}/restore", f.restoreProfile,
+		fuego.OptionTags("Profiles"),
+		fuego.OptionSummary("Restore Profile"),
+		fuego.OptionDescription("Take a profile out of the trash along with its wireless configurations"),
+		fuego.OptionPath("name", "Profile name"),
+	)
+
+	fuego.Delete(f.server, "/api/v1/admin/profiles/trash/{name}", f.purgeProfile,
+		fuego.OptionTags("Profiles"),
+		fuego.OptionSummary("Purge Profile"),
+		fuego.OptionDescription("Remove a profile in the trash for good"),
+		fuego.OptionPath("name", "Profile name"),
+	)
 }
 
 func (f *FuegoAdapter) getProfiles(_ fuego.ContextNoBody) (dto.ProfileCountResponse, error) {
@@ -118,6 +148,27 @@ func (f *FuegoAdapter) deleteProfile(_ fuego.ContextNoBody) (any, error) {
 	return nil, nil
 }
 
+func (f *FuegoAdapter) cloneProfile(c fuego.ContextWithBody[dto.Profile]) (dto.Profile, error) {
+	body, err := c.Body()
+	if err != nil {
+		return dto.Profile{}, err
+	}
+
+	return body, nil
+}
+
+func (f *FuegoAdapter) getDeletedProfiles(_ fuego.ContextNoBody) ([]dto.Profile, error) {
+	return []dto.Profile{{ProfileName: "example-profile", DeletedAt: "2025-08-08T00:00:00Z"}}, nil
+}
+
+func (f *FuegoAdapter) restoreProfile(_ fuego.ContextNoBody) (any, error) {
+	return nil, nil
+}
+
+func (f *FuegoAdapter) purgeProfile(_ fuego.ContextNoBody) (any, error) {
+	return nil, nil
+}
+
 func (f *FuegoAdapter) exportProfile(_ fuego.ContextNoBody) (dto.ProfileExportResponse, error) {
 	return dto.ProfileExportResponse{
 		Filename: "example-profile.yaml",
@@ -21,6 +21,7 @@ func (f *FuegoAdapter) RegisterWirelessConfigRoutes() {
 		fuego.OptionSummary("Get Wireless Configuration by Name"),
 		fuego.OptionDescription("Retrieve a specific wireless configuration by profile name"),
 		fuego.OptionPath("name", "Profile name"),
+		optionETag(),
 	)
 
 	fuego.Post(f.server, "/api/v1/admin/wirelessconfigs", f.createWirelessConfig,
@@ -33,6 +34,7 @@ func (f *FuegoAdapter) RegisterWirelessConfigRoutes() {
 		fuego.OptionTags("Wireless"),
 		fuego.OptionSummary("Update Wireless Configuration"),
 		fuego.OptionDescription("Update an existing wireless configuration"),
+		optionIfMatch(),
 	)
 
 	fuego.Delete(f.server, "/api/v1/admin/wirelessconfigs/{name}", f.deleteWirelessConfig,
@@ -40,6 +42,7 @@ func (f *FuegoAdapter) RegisterWirelessConfigRoutes() {
 		fuego.OptionSummary("Delete Wireless Configuration"),
 		fuego.OptionDescription("Delete a wireless configuration by profile name"),
 		fuego.OptionPath("name", "Profile name"),
+		optionIfMatch(),
 	)
 }
 
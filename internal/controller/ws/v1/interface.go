@@ -11,6 +11,8 @@ import (
 
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	dtov2 "github.com/device-management-toolkit/console/internal/entity/dto/v2"
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // Upgrader defines the interface for upgrading an HTTP connection to a WebSocket connection.
@@ -22,13 +24,15 @@ type Upgrader interface {
 // Redirect defines the interface for handling redirects.
 
 type Redirect interface {
-	Redirect(c *gin.Context, conn *websocket.Conn, host, mode string) error
+	Redirect(c *gin.Context, conn *websocket.Conn, host, mode, viewerToken string) error
 }
 
 type Feature interface {
 	// Repository/Database Calls
-	GetCount(context.Context, string) (int, error)
-	Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error)
+	GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+	Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error)
+	GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]dto.Device, string, error)
+	EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
 	GetByID(ctx context.Context, guid, tenantID string, includeSecrets bool) (*dto.Device, error)
 	GetDistinctTags(ctx context.Context, tenantID string) ([]string, error)
 	GetByTags(ctx context.Context, tags, method string, limit, offset int, tenantID string) ([]dto.Device, error)
@@ -36,6 +40,10 @@ type Feature interface {
 	Update(ctx context.Context, d *dto.Device) (*dto.Device, error)
 	Insert(ctx context.Context, d *dto.Device) (*dto.Device, error)
 	GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]dto.Device, error)
+	GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error)
+	Restore(ctx context.Context, guid, tenantID string) error
+	Purge(ctx context.Context, guid, tenantID string) error
+	PurgeDeleted(ctx context.Context, before string) (int, error)
 	// Management Calls
 	GetVersion(ctx context.Context, guid string) (dto.Version, dtov2.Version, error)
 	GetFeatures(ctx context.Context, guid string) (dto.Features, dtov2.Features, error)
@@ -54,7 +62,7 @@ type Feature interface {
 	SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error)
 	GetAuditLog(ctx context.Context, startIndex int, guid string) (dto.AuditLog, error)
 	GetEventLog(ctx context.Context, startIndex, maxReadRecords int, guid string) (dto.EventLogs, error)
-	Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error
+	Redirect(ctx context.Context, conn devices.WebSocketConn, guid, mode, viewerToken string) error
 	GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error)
 	GetCertificates(c context.Context, guid string) (dto.SecuritySettings, error)
 	GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error)
@@ -65,4 +73,10 @@ type Feature interface {
 	// KVM Screen Settings
 	GetKVMScreenSettings(c context.Context, guid string) (dto.KVMScreenSettings, error)
 	SetKVMScreenSettings(c context.Context, guid string, req dto.KVMScreenSettingsRequest) (dto.KVMScreenSettings, error)
+	GetKVMScreenshot(c context.Context, guid string) ([]byte, error)
+	GetRedirectionSessions(c context.Context, guid string) ([]dto.RedirectionSession, error)
+	PromoteRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error
+	RevokeRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error
+	IssueRedirectionToken(c context.Context, guid, user string, modes []string) (string, error)
+	ConsumeRedirectionToken(c context.Context, tokenString, guid, mode string) (devices.RedirectionClaims, error)
 }
@@ -2,26 +2,31 @@ package v1
 
 import (
 	"compress/flate"
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/websocket"
 
 	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/activity"
 	"github.com/device-management-toolkit/console/internal/usecase/devices"
 	"github.com/device-management-toolkit/console/pkg/logger"
 )
 
 type RedirectRoutes struct {
 	d devices.Feature
+	a activity.Feature
 	l logger.Interface
 	u Upgrader
 }
 
-func RegisterRoutes(r *gin.Engine, l logger.Interface, t devices.Feature, u Upgrader) {
+func RegisterRoutes(r *gin.Engine, l logger.Interface, t devices.Feature, a activity.Feature, u Upgrader) {
 	rr := &RedirectRoutes{
 		t,
+		a,
 		l,
 		u,
 	}
@@ -31,25 +36,36 @@ func RegisterRoutes(r *gin.Engine, l logger.Interface, t devices.Feature, u Upgr
 func (r *RedirectRoutes) websocketHandler(c *gin.Context) {
 	tokenString := c.GetHeader("Sec-Websocket-Protocol")
 
+	var user, tenant string
+
 	// validate jwt token in the Sec-Websocket-protocol header
 	if !config.ConsoleConfig.Disabled {
 		if tokenString == "" {
 			http.Error(c.Writer, "request does not contain an access token", http.StatusUnauthorized)
+			r.record(c, "", "", http.StatusUnauthorized)
 
 			return
 		}
 
-		claims := &jwt.MapClaims{}
+		// the token must have been minted for this device and mode, and is burnt on use
+		claims, err := r.d.ConsumeRedirectionToken(c.Request.Context(), tokenString, c.Query("host"), c.Query("mode"))
+		if errors.Is(err, devices.ErrRedirectionTokenScope) {
+			http.Error(c.Writer, "access token is not valid for this device or mode", http.StatusForbidden)
+			r.record(c, "", "", http.StatusForbidden)
 
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
-			return []byte(config.ConsoleConfig.JWTKey), nil
-		})
+			return
+		}
 
-		if err != nil || !token.Valid {
+		if err != nil {
 			http.Error(c.Writer, "invalid access token", http.StatusUnauthorized)
+			r.record(c, "", "", http.StatusUnauthorized)
 
 			return
 		}
+
+		user = claims.Subject
+		// the token carries the tenant of the device it was issued for
+		tenant = claims.TenantID
 	}
 
 	upgrader, ok := r.u.(*websocket.Upgrader)
@@ -62,6 +78,7 @@ func (r *RedirectRoutes) websocketHandler(c *gin.Context) {
 	conn, err := r.u.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		http.Error(c.Writer, "Could not open websocket connection", http.StatusInternalServerError)
+		r.record(c, user, tenant, http.StatusInternalServerError)
 
 		return
 	}
@@ -76,10 +93,37 @@ func (r *RedirectRoutes) websocketHandler(c *gin.Context) {
 	}
 
 	r.l.Info("Websocket connection opened")
+	r.record(c, user, tenant, http.StatusSwitchingProtocols)
+
+	ctx := devices.WithTenant(devices.WithRedirectionUser(c, user), tenant)
 
-	err = r.d.Redirect(c, conn, c.Query("host"), c.Query("mode"))
+	err = r.d.Redirect(ctx, conn, c.Query("host"), c.Query("mode"), c.Query("viewer"))
 	if err != nil {
 		r.l.Error(err, "http - devices - v1 - redirect")
 		errorResponse(c, http.StatusInternalServerError, "redirect failed")
+		r.record(c, user, tenant, http.StatusInternalServerError)
+	}
+}
+
+// record adds the redirection session to the console activity audit trail.
+func (r *RedirectRoutes) record(c *gin.Context, user, tenant string, status int) {
+	result := activity.ResultSuccess
+	if status >= http.StatusBadRequest {
+		result = activity.ResultFailure
+	}
+
+	entry := &dto.ActivityEntry{
+		Actor:      user,
+		SourceIP:   c.ClientIP(),
+		Action:     c.Request.Method + " " + c.FullPath(),
+		Target:     "guid=" + c.Query("host"),
+		Parameters: activity.Parameters(nil, c.Request.URL.Query()),
+		Status:     status,
+		Result:     result,
+		TenantID:   tenant,
+	}
+
+	if err := r.a.Record(context.WithoutCancel(c.Request.Context()), entry); err != nil {
+		r.l.Error(err, "http - devices - v1 - recordActivity")
 	}
 }
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -12,7 +13,10 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/activity"
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
 )
 
 var (
@@ -30,30 +34,35 @@ func TestWebSocketHandler(t *testing.T) { //nolint:paralleltest // logging libra
 	mockFeature := mocks.NewMockFeature(ctrl)
 	mockUpgrader := mocks.NewMockUpgrader(ctrl)
 	mockLogger := mocks.NewMockLogger(ctrl)
+	mockActivity := mocks.NewMockActivityFeature(ctrl)
 
 	tests := []struct {
 		name           string
 		upgraderError  error
 		redirectError  error
 		expectedStatus int
+		recorded       []int
 	}{
 		{
 			name:           "Success case",
 			upgraderError:  nil,
 			redirectError:  nil,
 			expectedStatus: http.StatusOK,
+			recorded:       []int{http.StatusSwitchingProtocols},
 		},
 		{
 			name:           "Upgrade error",
 			upgraderError:  ErrUpgrade,
 			redirectError:  nil,
 			expectedStatus: http.StatusInternalServerError,
+			recorded:       []int{http.StatusInternalServerError},
 		},
 		{
 			name:           "Redirect error",
 			upgraderError:  nil,
 			redirectError:  ErrRedirect,
 			expectedStatus: http.StatusInternalServerError,
+			recorded:       []int{http.StatusSwitchingProtocols, http.StatusInternalServerError},
 		},
 	}
 
@@ -78,12 +87,23 @@ func TestWebSocketHandler(t *testing.T) { //nolint:paralleltest // logging libra
 				}
 
 				mockFeature.EXPECT().
-					Redirect(gomock.Any(), gomock.Any(), "someHost", "someMode").
+					Redirect(gomock.Any(), gomock.Any(), "someHost", "someMode", "").
 					Return(tc.redirectError)
 			}
 
+			var recorded []int
+
+			mockActivity.EXPECT().
+				Record(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, entry *dto.ActivityEntry) error {
+					recorded = append(recorded, entry.Status)
+
+					return nil
+				}).
+				Times(len(tc.recorded))
+
 			r := gin.Default()
-			RegisterRoutes(r, mockLogger, mockFeature, mockUpgrader)
+			RegisterRoutes(r, mockLogger, mockFeature, mockActivity, mockUpgrader)
 
 			req := httptest.NewRequest(http.MethodGet, "/relay/webrelay.ashx?host=someHost&mode=someMode", http.NoBody)
 			w := httptest.NewRecorder()
@@ -91,6 +111,69 @@ func TestWebSocketHandler(t *testing.T) { //nolint:paralleltest // logging libra
 			r.ServeHTTP(w, req)
 
 			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, tc.recorded, recorded)
+		})
+	}
+}
+
+func TestWebSocketHandlerRedirectionToken(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	_, _ = config.NewConfig()
+
+	config.ConsoleConfig.Disabled = false
+
+	t.Cleanup(func() { config.ConsoleConfig.Disabled = true })
+
+	tests := []struct {
+		name           string
+		consumeError   error
+		expectedStatus int
+	}{
+		{
+			name:           "token minted for another device or mode",
+			consumeError:   devices.ErrRedirectionTokenScope,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "token already used",
+			consumeError:   devices.ErrRedirectionTokenUsed,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests { //nolint:paralleltest // modifies the global console config
+		t.Run(tc.name, func(t *testing.T) {
+			mockFeature := mocks.NewMockFeature(ctrl)
+			mockFeature.EXPECT().
+				ConsumeRedirectionToken(gomock.Any(), "token", "someHost", "kvm").
+				Return(devices.RedirectionClaims{}, tc.consumeError)
+
+			var entry *dto.ActivityEntry
+
+			mockActivity := mocks.NewMockActivityFeature(ctrl)
+			mockActivity.EXPECT().
+				Record(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, e *dto.ActivityEntry) error {
+					entry = e
+
+					return nil
+				})
+
+			r := gin.New()
+			RegisterRoutes(r, mocks.NewMockLogger(ctrl), mockFeature, mockActivity, mocks.NewMockUpgrader(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/relay/webrelay.ashx?host=someHost&mode=kvm", http.NoBody)
+			req.Header.Set("Sec-Websocket-Protocol", "token")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, tc.expectedStatus, entry.Status)
+			assert.Equal(t, activity.ResultFailure, entry.Result)
+			assert.Equal(t, "guid=someHost", entry.Target)
 		})
 	}
 }
@@ -0,0 +1,26 @@
+package entity
+
+type ActivityEntry struct {
+	ID         string
+	Timestamp  string
+	Actor      string
+	APIKeyID   string
+	SourceIP   string
+	Action     string
+	Target     string
+	Parameters string
+	Status     int
+	Result     string
+	TenantID   string
+}
+
+// ActivityFilter narrows activity queries, empty fields match everything.
+type ActivityFilter struct {
+	Actor    string
+	Action   string
+	Target   string
+	Result   string
+	From     string
+	To       string
+	TenantID string
+}
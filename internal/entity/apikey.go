@@ -0,0 +1,13 @@
+package entity
+
+type APIKey struct {
+	ID         string
+	Name       string
+	Owner      string
+	Scopes     string
+	KeyHash    string
+	ExpiresAt  string
+	LastUsedAt string
+	CreatedAt  string
+	TenantID   string
+}
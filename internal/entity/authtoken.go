@@ -0,0 +1,20 @@
+package entity
+
+type RefreshToken struct {
+	ID        string
+	FamilyID  string
+	Subject   string
+	Role      string
+	TokenHash string
+	ExpiresAt string
+	Used      bool
+	TenantID  string
+}
+
+// TokenRevocation revokes the token TokenID, or every token of Subject issued up to RevokedAt when TokenID is empty.
+type TokenRevocation struct {
+	TokenID   string
+	Subject   string
+	RevokedAt string
+	ExpiresAt string
+}
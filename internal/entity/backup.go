@@ -0,0 +1,14 @@
+package entity
+
+// BackupTable holds the rows of a table that is part of a backup, every row maps a column to its value.
+type BackupTable struct {
+	Name string
+	// Keys are the columns identifying a row
+	Keys []string
+	// Secrets are the columns encrypted with the console encryption key
+	Secrets []string
+	// Owner are the columns of the row owning a row, when a backup is merged the rows of every owner it holds
+	// replace the stored ones
+	Owner []string
+	Rows  []map[string]interface{}
+}
@@ -0,0 +1,13 @@
+package entity
+
+type ConfigRevision struct {
+	Kind      string
+	Name      string
+	Revision  int
+	Author    string
+	CreatedAt string
+	Content   string
+	// Secrets holds the encrypted secrets of the revision by field
+	Secrets  map[string]string
+	TenantID string
+}
@@ -21,6 +21,8 @@ type Device struct {
 	UseTLS           bool
 	AllowSelfSigned  bool
 	CertHash         *string
+	Attributes       string
+	DeletedAt        string
 }
 
 type Explorer struct {
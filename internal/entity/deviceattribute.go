@@ -0,0 +1,9 @@
+package entity
+
+type DeviceAttribute struct {
+	Name        string
+	Type        string
+	EnumValues  []string
+	Description string
+	TenantID    string
+}
@@ -0,0 +1,12 @@
+package entity
+
+type DeviceGroup struct {
+	Name        string
+	Description string
+	Rule        string
+	TenantID    string
+
+	// rows of device_group_members and device_group_subgroups
+	Devices   []string
+	Subgroups []string
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type ActivityEntry struct {
+	ID        string    `json:"id" example:"9b2f6c0e1d3a4b5c"`
+	Timestamp time.Time `json:"timestamp" example:"2025-01-01T00:00:00Z"`
+	Actor     string    `json:"actor" example:"jdoe"`
+	APIKeyID  string    `json:"apiKeyId,omitempty" example:"3f2a9c1e7b4d5a60"`
+	SourceIP  string    `json:"sourceIp" example:"10.0.0.5"`
+	// Action is the method and route of the request, e.g. POST /api/v1/amt/power/action/:guid
+	Action string `json:"action" example:"POST /api/v1/amt/power/action/:guid"`
+	Target string `json:"target" example:"guid=123e4567-e89b-12d3-a456-426614174000"`
+	// Parameters holds the request body and query as JSON with secrets redacted
+	Parameters string `json:"parameters" example:"{\"body\":{\"action\":10}}"`
+	Status     int    `json:"status" example:"200"`
+	Result     string `json:"result" example:"success"`
+	TenantID   string `json:"tenantId" example:"abc123"`
+}
+
+type ActivityFilter struct {
+	Actor  string    `form:"actor"`
+	Action string    `form:"action"`
+	Target string    `form:"target"`
+	Result string    `form:"result" binding:"omitempty,oneof=success failure"`
+	From   time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type APIKey struct {
+	ID         string     `json:"id" example:"3f2a9c1e7b4d5a60"`
+	Name       string     `json:"name" binding:"required,max=64" example:"ci-lab"`
+	Owner      string     `json:"owner" example:"jdoe"`
+	Scopes     []string   `json:"scopes" binding:"required,min=1" example:"devices:read,power:write"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" example:"2026-01-01T00:00:00Z"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" example:"2025-06-01T12:00:00Z"`
+	CreatedAt  time.Time  `json:"createdAt" example:"2025-01-01T00:00:00Z"`
+	TenantID   string     `json:"tenantId" example:"abc123"`
+	// Key is only returned once, when the key is created
+	Key string `json:"key,omitempty" example:"cak_3f2a9c1e7b4d5a60_..."`
+}
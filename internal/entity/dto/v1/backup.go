@@ -0,0 +1,37 @@
+package dto
+
+const (
+	// RestoreMerge keeps what is stored and overwrites it with the rows of the backup with the same key.
+	RestoreMerge = "merge"
+	// RestoreReplace deletes everything that is backed up before restoring the backup.
+	RestoreReplace = "replace"
+)
+
+type BackupRequest struct {
+	// Passphrase encrypts the backup, it is needed to restore it
+	Passphrase string `json:"passphrase" binding:"required" example:"correct horse battery staple"`
+}
+
+// RestoreRequest holds the form fields of a restore next to the archive file.
+type RestoreRequest struct {
+	Passphrase string `form:"passphrase" binding:"required"`
+	Mode       string `form:"mode,default=merge" binding:"oneof=merge replace" example:"merge"`
+	// DryRun only reports the changes, it has to be set to false explicitly to restore the backup
+	DryRun bool `form:"dryRun,default=true" example:"true"`
+}
+
+// Restore lists what restoring a backup changes, or changed unless DryRun is set.
+type Restore struct {
+	Mode   string               `json:"mode" example:"merge"`
+	DryRun bool                 `json:"dryRun" example:"true"`
+	Tables []RestoreTableChange `json:"tables"`
+}
+
+// RestoreTableChange lists the rows of a table that are added, updated and deleted by their keys.
+type RestoreTableChange struct {
+	Table     string   `json:"table" example:"profiles"`
+	Added     []string `json:"added"`
+	Updated   []string `json:"updated"`
+	Deleted   []string `json:"deleted"`
+	Unchanged int      `json:"unchanged" example:"3"`
+}
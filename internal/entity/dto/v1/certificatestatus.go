@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+const (
+	CertificateSourceDomain     = "domain"
+	CertificateSourceCIRAConfig = "ciraConfig"
+	CertificateSourceDevice     = "device"
+)
+
+// MonitoredCertificate is the state of a certificate as of the last check of the certificate monitor. Name is
+// the domain profile or CIRA config name, or the device GUID.
+type MonitoredCertificate struct {
+	Source            string    `json:"source" example:"domain"`
+	Name              string    `json:"name" example:"example.com"`
+	TenantID          string    `json:"tenantId" example:"abc123"`
+	CommonName        string    `json:"commonName,omitempty" example:"MPS Root"`
+	SHA256Fingerprint string    `json:"sha256Fingerprint,omitempty"`
+	NotAfter          time.Time `json:"notAfter" example:"2026-01-01T00:00:00Z"`
+	DaysRemaining     int       `json:"daysRemaining" example:"29"`
+	// WarningDays is the smallest configured warning threshold the certificate is within, 0 when none.
+	WarningDays int  `json:"warningDays,omitempty" example:"30"`
+	Expired     bool `json:"expired"`
+	// PinMismatch is set when a device presents a certificate that does not match its pinned hash.
+	PinMismatch bool      `json:"pinMismatch,omitempty"`
+	CheckedAt   time.Time `json:"checkedAt" example:"2025-12-02T00:00:00Z"`
+}
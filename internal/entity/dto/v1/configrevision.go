@@ -0,0 +1,46 @@
+package dto
+
+// The kinds of configurations revisions are kept for, named after their routes.
+const (
+	RevisionKindProfile   = "profiles"
+	RevisionKindWireless  = "wirelessconfigs"
+	RevisionKindCIRA      = "ciraconfigs"
+	RevisionKindIEEE8021x = "ieee8021xconfigs"
+	RevisionKindDomain    = "domains"
+)
+
+// ConfigRevision is the content of a configuration as it was saved by an insert, update or rollback. Secrets
+// are kept encrypted with the revision and left out of the content.
+type ConfigRevision struct {
+	Kind      string                 `json:"kind" example:"profiles"`
+	Name      string                 `json:"name" example:"My Profile"`
+	Revision  int                    `json:"revision" example:"3"`
+	Author    string                 `json:"author" example:"admin"`
+	CreatedAt string                 `json:"createdAt" example:"2025-08-09T00:00:00Z"`
+	Content   map[string]interface{} `json:"content"`
+	// Secrets lists the fields of the content whose values are kept encrypted
+	Secrets  []string `json:"secrets,omitempty" example:"amtPassword,mebxPassword"`
+	TenantID string   `json:"tenantId" example:"abc123"`
+}
+
+type ConfigRevisionCountResponse struct {
+	Count int              `json:"totalCount"`
+	Data  []ConfigRevision `json:"data"`
+}
+
+// RevisionChange is a field that differs between two revisions. Nested fields are named by their path, such
+// as ciraConfigObject.mpsPort. The values of secrets are not shown.
+type RevisionChange struct {
+	Field  string      `json:"field" example:"tlsMode"`
+	From   interface{} `json:"from"`
+	To     interface{} `json:"to"`
+	Secret bool        `json:"secret,omitempty"`
+}
+
+type RevisionDiff struct {
+	Kind    string           `json:"kind" example:"profiles"`
+	Name    string           `json:"name" example:"My Profile"`
+	From    int              `json:"from" example:"2"`
+	To      int              `json:"to" example:"3"`
+	Changes []RevisionChange `json:"changes"`
+}
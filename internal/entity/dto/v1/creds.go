@@ -4,3 +4,25 @@ type Credentials struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+// LoginSession is the identity a refresh token renews access tokens for.
+type LoginSession struct {
+	Subject  string `json:"subject" example:"jdoe"`
+	Role     string `json:"role" example:"operator"`
+	TenantID string `json:"tenantId" example:"abc123"`
+}
+
+// Tokens are returned on login and on refresh.
+type Tokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type LogoutRequest struct {
+	// RefreshToken is revoked along with the access token when given
+	RefreshToken string `json:"refreshToken"`
+}
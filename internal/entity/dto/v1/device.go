@@ -5,8 +5,18 @@ import (
 )
 
 type DeviceCountResponse struct {
-	Count int      `json:"totalCount"`
-	Data  []Device `json:"data"`
+	Count       int      `json:"totalCount"`
+	Data        []Device `json:"data"`
+	Approximate bool     `json:"approximate,omitempty"`
+}
+
+// DevicePageResponse is a page of devices read with $skiptoken. SkipToken reads the next page and is left out
+// on the last one, Count is only there with $count.
+type DevicePageResponse struct {
+	Data        []Device `json:"data"`
+	SkipToken   string   `json:"skipToken,omitempty"`
+	Count       *int     `json:"totalCount,omitempty"`
+	Approximate bool     `json:"approximate,omitempty"`
 }
 type DeviceStatResponse struct {
 	TotalCount        int `json:"totalCount"`
@@ -32,6 +42,10 @@ type Device struct {
 	UseTLS           bool        `json:"useTLS"`
 	AllowSelfSigned  bool        `json:"allowSelfSigned"`
 	CertHash         string      `json:"certHash"`
+	// Attributes holds the values of the custom attributes defined for the tenant, by name
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// DeletedAt is when a device in the trash was deleted
+	DeletedAt string `json:"deletedAt,omitempty"`
 }
 
 type DeviceInfo struct {
@@ -0,0 +1,25 @@
+package dto
+
+// The types of custom device attributes. Dates are kept as RFC 3339 timestamps in UTC.
+const (
+	AttributeString = "string"
+	AttributeNumber = "number"
+	AttributeDate   = "date"
+	AttributeEnum   = "enum"
+)
+
+// DeviceAttribute defines a custom attribute the devices of a tenant can have, such as an asset tag or the
+// end of the warranty. The values are set in the attributes of a device and can be filtered by name.
+type DeviceAttribute struct {
+	Name string `json:"name" binding:"required,max=64" example:"warrantyEnd"`
+	Type string `json:"type" binding:"required,oneof=string number date enum" example:"date"`
+	// EnumValues lists the values an enum attribute allows
+	EnumValues  []string `json:"enumValues,omitempty" example:"datacenter,branch,home"`
+	Description string   `json:"description" binding:"max=512" example:"End of the hardware warranty"`
+	TenantID    string   `json:"tenantId" example:"abc123"`
+}
+
+type DeviceAttributeCountResponse struct {
+	Count int               `json:"totalCount"`
+	Data  []DeviceAttribute `json:"data"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// DeviceGroup is a static group when it lists its devices and a dynamic group when it has a rule, a $filter
+// over the device properties and the inventory the devices report. The members of its subgroups belong to it
+// as well.
+type DeviceGroup struct {
+	Name        string `json:"name" binding:"required,max=64,excludesall=/?#%" example:"acm-amt16-site-x"`
+	Description string `json:"description" binding:"max=512" example:"ACM devices on AMT 16 at site X"`
+	// Rule selects the members of a dynamic group
+	Rule string `json:"rule" example:"currentMode eq 'ACM' and fwVersion startswith '16.' and tags eq 'site-x'"`
+	// Devices lists the GUIDs of the members of a static group
+	Devices   []string `json:"devices" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Subgroups []string `json:"subgroups" example:"site-x-kiosks"`
+	TenantID  string   `json:"tenantId" example:"abc123"`
+}
+
+type DeviceGroupCountResponse struct {
+	Count int           `json:"totalCount"`
+	Data  []DeviceGroup `json:"data"`
+}
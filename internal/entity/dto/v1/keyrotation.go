@@ -0,0 +1,15 @@
+package dto
+
+type KeyRotationRequest struct {
+	// NewKey must be 16, 24 or 32 characters long, it is generated by the console when empty
+	NewKey string `json:"newKey" example:"0123456789abcdef0123456789abcdef"`
+}
+
+type KeyRotation struct {
+	// Secrets is the number of secrets that were encrypted again
+	Secrets int `json:"secrets" example:"42"`
+	// KeyringUpdated is false when the key comes from APP_ENCRYPTION_KEY, which then has to be set to the new key
+	KeyringUpdated bool `json:"keyringUpdated" example:"true"`
+	// Key is only returned when the console generated it
+	Key string `json:"key,omitempty" example:"0123456789abcdef0123456789abcdef"`
+}
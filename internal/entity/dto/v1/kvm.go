@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // KVMScreenDisplay represents one display's status and geometry.
 type KVMScreenDisplay struct {
 	DisplayIndex int    `json:"displayIndex"`
@@ -23,3 +25,17 @@ type KVMScreenSettings struct {
 type KVMScreenSettingsRequest struct {
 	DisplayIndex int `json:"displayIndex,omitempty"`
 }
+
+// RedirectionViewer describes one browser attached to a redirection session.
+type RedirectionViewer struct {
+	ID          string    `json:"id"`
+	Role        string    `json:"role"` // primary or observer
+	ConnectedAt time.Time `json:"connectedAt"`
+}
+
+// RedirectionSession is an active redirection session shared by one or more viewers.
+type RedirectionSession struct {
+	GUID    string              `json:"guid"`
+	Mode    string              `json:"mode"`
+	Viewers []RedirectionViewer `json:"viewers"`
+}
@@ -34,6 +34,10 @@ type Profile struct {
 	IEEE8021xProfile           *IEEE8021xConfig     `json:"ieee8021xProfile,omitempty"`
 	Version                    string               `json:"version,omitempty" example:"1.0.0"`
 	UEFIWiFiSyncEnabled        bool                 `json:"uefiWifiSyncEnabled" example:"true"`
+	DeletedAt                  string               `json:"deletedAt,omitempty" example:"2025-08-08T00:00:00Z"`
+	TemplateName               string               `json:"templateName,omitempty" example:"Site Defaults"`
+	// TemplateOverrides names the template settings the profile sets itself instead of inheriting them
+	TemplateOverrides []string `json:"templateOverrides,omitempty" example:"tags,wifiConfigs"`
 }
 
 var ValidateCIRAOrTLS validator.Func = func(fl validator.FieldLevel) bool {
@@ -0,0 +1,52 @@
+package dto
+
+// ProfileTemplateSettings are the profile settings a template can hold. Settings left out are not inherited, the
+// JSON names are those of the profile fields they set.
+type ProfileTemplateSettings struct {
+	Activation           *string              `json:"activation,omitempty" binding:"omitempty,oneof=ccmactivate acmactivate" example:"acmactivate"`
+	CIRAConfigName       *string              `json:"ciraConfigName,omitempty" example:"My CIRA Config"`
+	TLSMode              *int                 `json:"tlsMode,omitempty" binding:"omitempty,min=0,max=4" example:"1"`
+	TLSSigningAuthority  *string              `json:"tlsSigningAuthority,omitempty" binding:"omitempty,oneof=SelfSigned MicrosoftCA" example:"SelfSigned"`
+	UserConsent          *string              `json:"userConsent,omitempty" example:"All"`
+	IDEREnabled          *bool                `json:"iderEnabled,omitempty" example:"true"`
+	KVMEnabled           *bool                `json:"kvmEnabled,omitempty" example:"true"`
+	SOLEnabled           *bool                `json:"solEnabled,omitempty" example:"true"`
+	IEEE8021xProfileName *string              `json:"ieee8021xProfileName,omitempty" example:"My Profile"`
+	DHCPEnabled          *bool                `json:"dhcpEnabled,omitempty" example:"true"`
+	IPSyncEnabled        *bool                `json:"ipSyncEnabled,omitempty" example:"true"`
+	LocalWiFiSyncEnabled *bool                `json:"localWifiSyncEnabled,omitempty" example:"true"`
+	UEFIWiFiSyncEnabled  *bool                `json:"uefiWifiSyncEnabled,omitempty" example:"true"`
+	Tags                 []string             `json:"tags,omitempty"`
+	WiFiConfigs          []ProfileWiFiConfigs `json:"wifiConfigs,omitempty" binding:"omitempty,dive"`
+}
+
+// ProfileTemplateFields are the JSON names of the settings a template can hold, the only names a profile can
+// override.
+var ProfileTemplateFields = []string{
+	"activation", "ciraConfigName", "tlsMode", "tlsSigningAuthority", "userConsent", "iderEnabled", "kvmEnabled",
+	"solEnabled", "ieee8021xProfileName", "dhcpEnabled", "ipSyncEnabled", "localWifiSyncEnabled",
+	"uefiWifiSyncEnabled", "tags", "wifiConfigs",
+}
+
+// ProfileTemplate holds the settings shared by the profiles derived from it. Passwords are not part of a
+// template, every profile keeps its own.
+type ProfileTemplate struct {
+	TemplateName string `json:"templateName" binding:"required" example:"Site Defaults"`
+	ProfileTemplateSettings
+	TenantID string `json:"tenantId" example:"abc123"`
+}
+
+type ProfileTemplateCountResponse struct {
+	Count int               `json:"totalCount"`
+	Data  []ProfileTemplate `json:"data"`
+}
+
+// ProfileTemplateImpact shows how a template edit changes a derived profile. Changes lists the settings the
+// profile inherits with a new value, the settings it overrides are not changed. Invalid tells why the profile
+// would no longer be valid, an edit that leaves any derived profile invalid cannot be saved.
+type ProfileTemplateImpact struct {
+	ProfileName string   `json:"profileName" example:"site-42"`
+	Changes     []string `json:"changes" example:"tlsMode,kvmEnabled"`
+	Overrides   []string `json:"overrides,omitempty" example:"tags"`
+	Invalid     string   `json:"invalid,omitempty" example:"Key: 'Profile.TLSMode' Error:Field validation for 'TLSMode' failed on the 'ciraortls' tag"`
+}
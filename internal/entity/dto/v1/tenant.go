@@ -0,0 +1,8 @@
+package dto
+
+type Tenant struct {
+	// ID is the value of the tenant claim of the identity provider and the tenantId of the tenant's users
+	ID          string `json:"id" binding:"required,max=64,excludesall=/?#%" example:"bu-retail"`
+	Name        string `json:"name" binding:"required,max=128" example:"Retail"`
+	Description string `json:"description" binding:"max=512" example:"Devices of the retail business unit"`
+}
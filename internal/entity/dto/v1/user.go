@@ -0,0 +1,9 @@
+package dto
+
+type User struct {
+	Username string `json:"username" binding:"required,max=64" example:"jdoe"`
+	// Password is only accepted on input, it is never returned
+	Password string `json:"password,omitempty" binding:"omitempty,min=8,max=72" example:"my_password"`
+	Role     string `json:"role" binding:"required,oneof=admin operator viewer auditor" example:"viewer"`
+	TenantID string `json:"tenantId" example:"abc123"`
+}
@@ -15,4 +15,13 @@ type (
 	UserConsentCode struct {
 		ConsentCode string `json:"consentCode" binding:"required" example:"123456"`
 	}
+
+	// RedirectionControlMessage is exchanged as a websocket text frame to negotiate and supervise a redirection session.
+	RedirectionControlMessage struct {
+		Type         string `json:"type" example:"consent"`
+		State        string `json:"state,omitempty" example:"required"`
+		Code         string `json:"code,omitempty" example:"123456"`
+		AttemptsLeft int    `json:"attemptsLeft,omitempty"`
+		SecondsLeft  int    `json:"secondsLeft,omitempty"`
+	}
 )
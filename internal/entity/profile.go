@@ -23,6 +23,10 @@ type Profile struct {
 	SOLEnabled                 bool
 	IEEE8021xProfileName       *string
 	UEFIWiFiSyncEnabled        bool
+	DeletedAt                  string
+	TemplateName               string
+	// TemplateOverrides lists the comma separated template settings the profile sets itself
+	TemplateOverrides string
 
 	// columns to populate from join query
 	Version                string
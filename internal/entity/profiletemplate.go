@@ -0,0 +1,8 @@
+package entity
+
+type ProfileTemplate struct {
+	TemplateName string
+	// Settings holds the settings of the template as JSON, named like the fields of a profile
+	Settings string
+	TenantID string
+}
@@ -0,0 +1,7 @@
+package entity
+
+type Tenant struct {
+	ID          string
+	Name        string
+	Description string
+}
@@ -0,0 +1,8 @@
+package entity
+
+type User struct {
+	Username     string
+	PasswordHash string
+	Role         string
+	TenantID     string
+}
@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/activity/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/activity/interfaces.go -package mocks -mock_names Repository=MockActivityRepository,Feature=MockActivityFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockActivityRepository is a mock of Repository interface.
+type MockActivityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityRepositoryMockRecorder is the mock recorder for MockActivityRepository.
+type MockActivityRepositoryMockRecorder struct {
+	mock *MockActivityRepository
+}
+
+// NewMockActivityRepository creates a new mock instance.
+func NewMockActivityRepository(ctrl *gomock.Controller) *MockActivityRepository {
+	mock := &MockActivityRepository{ctrl: ctrl}
+	mock.recorder = &MockActivityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityRepository) EXPECT() *MockActivityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// EstimateCount mocks base method.
+func (m *MockActivityRepository) EstimateCount(ctx context.Context, filter entity.ActivityFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCount indicates an expected call of EstimateCount.
+func (mr *MockActivityRepositoryMockRecorder) EstimateCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCount", reflect.TypeOf((*MockActivityRepository)(nil).EstimateCount), ctx, filter)
+}
+
+// Get mocks base method.
+func (m *MockActivityRepository) Get(ctx context.Context, filter entity.ActivityFilter, top, skip int) ([]entity.ActivityEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]entity.ActivityEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockActivityRepositoryMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockActivityRepository)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockActivityRepository) GetCount(ctx context.Context, filter entity.ActivityFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockActivityRepositoryMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockActivityRepository)(nil).GetCount), ctx, filter)
+}
+
+// GetPage mocks base method.
+func (m *MockActivityRepository) GetPage(ctx context.Context, filter entity.ActivityFilter, top int, skipToken string) ([]entity.ActivityEntry, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, filter, top, skipToken)
+	ret0, _ := ret[0].([]entity.ActivityEntry)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockActivityRepositoryMockRecorder) GetPage(ctx, filter, top, skipToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockActivityRepository)(nil).GetPage), ctx, filter, top, skipToken)
+}
+
+// Insert mocks base method.
+func (m *MockActivityRepository) Insert(ctx context.Context, e *entity.ActivityEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockActivityRepositoryMockRecorder) Insert(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockActivityRepository)(nil).Insert), ctx, e)
+}
+
+// MockActivityFeature is a mock of Feature interface.
+type MockActivityFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityFeatureMockRecorder is the mock recorder for MockActivityFeature.
+type MockActivityFeatureMockRecorder struct {
+	mock *MockActivityFeature
+}
+
+// NewMockActivityFeature creates a new mock instance.
+func NewMockActivityFeature(ctrl *gomock.Controller) *MockActivityFeature {
+	mock := &MockActivityFeature{ctrl: ctrl}
+	mock.recorder = &MockActivityFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityFeature) EXPECT() *MockActivityFeatureMockRecorder {
+	return m.recorder
+}
+
+// EstimateCount mocks base method.
+func (m *MockActivityFeature) EstimateCount(ctx context.Context, filter dto.ActivityFilter, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCount", ctx, filter, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCount indicates an expected call of EstimateCount.
+func (mr *MockActivityFeatureMockRecorder) EstimateCount(ctx, filter, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCount", reflect.TypeOf((*MockActivityFeature)(nil).EstimateCount), ctx, filter, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockActivityFeature) Get(ctx context.Context, filter dto.ActivityFilter, top, skip int, tenantID string) ([]dto.ActivityEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.ActivityEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockActivityFeatureMockRecorder) Get(ctx, filter, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockActivityFeature)(nil).Get), ctx, filter, top, skip, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockActivityFeature) GetCount(ctx context.Context, filter dto.ActivityFilter, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockActivityFeatureMockRecorder) GetCount(ctx, filter, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockActivityFeature)(nil).GetCount), ctx, filter, tenantID)
+}
+
+// GetPage mocks base method.
+func (m *MockActivityFeature) GetPage(ctx context.Context, filter dto.ActivityFilter, top int, skipToken, tenantID string) ([]dto.ActivityEntry, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, filter, top, skipToken, tenantID)
+	ret0, _ := ret[0].([]dto.ActivityEntry)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockActivityFeatureMockRecorder) GetPage(ctx, filter, top, skipToken, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockActivityFeature)(nil).GetPage), ctx, filter, top, skipToken, tenantID)
+}
+
+// Record mocks base method.
+func (m *MockActivityFeature) Record(ctx context.Context, e *dto.ActivityEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockActivityFeatureMockRecorder) Record(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockActivityFeature)(nil).Record), ctx, e)
+}
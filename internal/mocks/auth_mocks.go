@@ -0,0 +1,311 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/auth/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/auth/interfaces.go -package mocks -mock_names Repository=MockAuthRepository,Feature=MockAuthFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthRepository is a mock of Repository interface.
+type MockAuthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthRepositoryMockRecorder is the mock recorder for MockAuthRepository.
+type MockAuthRepositoryMockRecorder struct {
+	mock *MockAuthRepository
+}
+
+// NewMockAuthRepository creates a new mock instance.
+func NewMockAuthRepository(ctrl *gomock.Controller) *MockAuthRepository {
+	mock := &MockAuthRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthRepository) EXPECT() *MockAuthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpired mocks base method.
+func (m *MockAuthRepository) DeleteExpired(ctx context.Context, now string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockAuthRepositoryMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockAuthRepository)(nil).DeleteExpired), ctx, now)
+}
+
+// DeleteRefreshTokenFamily mocks base method.
+func (m *MockAuthRepository) DeleteRefreshTokenFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRefreshTokenFamily indicates an expected call of DeleteRefreshTokenFamily.
+func (mr *MockAuthRepositoryMockRecorder) DeleteRefreshTokenFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRefreshTokenFamily", reflect.TypeOf((*MockAuthRepository)(nil).DeleteRefreshTokenFamily), ctx, familyID)
+}
+
+// DeleteRefreshTokens mocks base method.
+func (m *MockAuthRepository) DeleteRefreshTokens(ctx context.Context, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRefreshTokens", ctx, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRefreshTokens indicates an expected call of DeleteRefreshTokens.
+func (mr *MockAuthRepositoryMockRecorder) DeleteRefreshTokens(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRefreshTokens", reflect.TypeOf((*MockAuthRepository)(nil).DeleteRefreshTokens), ctx, subject)
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockAuthRepository) GetRefreshToken(ctx context.Context, id string) (*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", ctx, id)
+	ret0, _ := ret[0].(*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) GetRefreshToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).GetRefreshToken), ctx, id)
+}
+
+// InsertRefreshToken mocks base method.
+func (m *MockAuthRepository) InsertRefreshToken(ctx context.Context, t *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRefreshToken", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRefreshToken indicates an expected call of InsertRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) InsertRefreshToken(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).InsertRefreshToken), ctx, t)
+}
+
+// InsertRevocation mocks base method.
+func (m *MockAuthRepository) InsertRevocation(ctx context.Context, r *entity.TokenRevocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRevocation", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRevocation indicates an expected call of InsertRevocation.
+func (mr *MockAuthRepositoryMockRecorder) InsertRevocation(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRevocation", reflect.TypeOf((*MockAuthRepository)(nil).InsertRevocation), ctx, r)
+}
+
+// IsRevoked mocks base method.
+func (m *MockAuthRepository) IsRevoked(ctx context.Context, tokenID, subject, issuedAt, now string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID, subject, issuedAt, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockAuthRepositoryMockRecorder) IsRevoked(ctx, tokenID, subject, issuedAt, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockAuthRepository)(nil).IsRevoked), ctx, tokenID, subject, issuedAt, now)
+}
+
+// MarkRefreshTokenUsed mocks base method.
+func (m *MockAuthRepository) MarkRefreshTokenUsed(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRefreshTokenUsed", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRefreshTokenUsed indicates an expected call of MarkRefreshTokenUsed.
+func (mr *MockAuthRepositoryMockRecorder) MarkRefreshTokenUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRefreshTokenUsed", reflect.TypeOf((*MockAuthRepository)(nil).MarkRefreshTokenUsed), ctx, id)
+}
+
+// MockAuthFeature is a mock of Feature interface.
+type MockAuthFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthFeatureMockRecorder is the mock recorder for MockAuthFeature.
+type MockAuthFeatureMockRecorder struct {
+	mock *MockAuthFeature
+}
+
+// NewMockAuthFeature creates a new mock instance.
+func NewMockAuthFeature(ctrl *gomock.Controller) *MockAuthFeature {
+	mock := &MockAuthFeature{ctrl: ctrl}
+	mock.recorder = &MockAuthFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthFeature) EXPECT() *MockAuthFeatureMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockAuthFeature) IsRevoked(ctx context.Context, tokenID, subject string, issuedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID, subject, issuedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockAuthFeatureMockRecorder) IsRevoked(ctx, tokenID, subject, issuedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockAuthFeature)(nil).IsRevoked), ctx, tokenID, subject, issuedAt)
+}
+
+// IssueRefreshToken mocks base method.
+func (m *MockAuthFeature) IssueRefreshToken(ctx context.Context, s *dto.LoginSession) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueRefreshToken", ctx, s)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueRefreshToken indicates an expected call of IssueRefreshToken.
+func (mr *MockAuthFeatureMockRecorder) IssueRefreshToken(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueRefreshToken", reflect.TypeOf((*MockAuthFeature)(nil).IssueRefreshToken), ctx, s)
+}
+
+// LoginAllowed mocks base method.
+func (m *MockAuthFeature) LoginAllowed(username string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginAllowed", username)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// LoginAllowed indicates an expected call of LoginAllowed.
+func (mr *MockAuthFeatureMockRecorder) LoginAllowed(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginAllowed", reflect.TypeOf((*MockAuthFeature)(nil).LoginAllowed), username)
+}
+
+// LoginFailed mocks base method.
+func (m *MockAuthFeature) LoginFailed(username string) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginFailed", username)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// LoginFailed indicates an expected call of LoginFailed.
+func (mr *MockAuthFeatureMockRecorder) LoginFailed(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginFailed", reflect.TypeOf((*MockAuthFeature)(nil).LoginFailed), username)
+}
+
+// LoginSucceeded mocks base method.
+func (m *MockAuthFeature) LoginSucceeded(username string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LoginSucceeded", username)
+}
+
+// LoginSucceeded indicates an expected call of LoginSucceeded.
+func (mr *MockAuthFeatureMockRecorder) LoginSucceeded(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginSucceeded", reflect.TypeOf((*MockAuthFeature)(nil).LoginSucceeded), username)
+}
+
+// Refresh mocks base method.
+func (m *MockAuthFeature) Refresh(ctx context.Context, refreshToken string) (*dto.LoginSession, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*dto.LoginSession)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthFeatureMockRecorder) Refresh(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthFeature)(nil).Refresh), ctx, refreshToken)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockAuthFeature) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockAuthFeatureMockRecorder) RevokeRefreshToken(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockAuthFeature)(nil).RevokeRefreshToken), ctx, refreshToken)
+}
+
+// RevokeSubject mocks base method.
+func (m *MockAuthFeature) RevokeSubject(ctx context.Context, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSubject", ctx, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSubject indicates an expected call of RevokeSubject.
+func (mr *MockAuthFeatureMockRecorder) RevokeSubject(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSubject", reflect.TypeOf((*MockAuthFeature)(nil).RevokeSubject), ctx, subject)
+}
+
+// RevokeToken mocks base method.
+func (m *MockAuthFeature) RevokeToken(ctx context.Context, tokenID, subject string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, tokenID, subject, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockAuthFeatureMockRecorder) RevokeToken(ctx, tokenID, subject, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockAuthFeature)(nil).RevokeToken), ctx, tokenID, subject, expiresAt)
+}
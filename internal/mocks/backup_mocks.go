@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/backup/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/backup/interfaces.go -package mocks -mock_names Repository=MockBackupRepository,Feature=MockBackupFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackupRepository is a mock of Repository interface.
+type MockBackupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupRepositoryMockRecorder is the mock recorder for MockBackupRepository.
+type MockBackupRepositoryMockRecorder struct {
+	mock *MockBackupRepository
+}
+
+// NewMockBackupRepository creates a new mock instance.
+func NewMockBackupRepository(ctrl *gomock.Controller) *MockBackupRepository {
+	mock := &MockBackupRepository{ctrl: ctrl}
+	mock.recorder = &MockBackupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupRepository) EXPECT() *MockBackupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Dump mocks base method.
+func (m *MockBackupRepository) Dump(ctx context.Context) ([]entity.BackupTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dump", ctx)
+	ret0, _ := ret[0].([]entity.BackupTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dump indicates an expected call of Dump.
+func (mr *MockBackupRepositoryMockRecorder) Dump(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dump", reflect.TypeOf((*MockBackupRepository)(nil).Dump), ctx)
+}
+
+// Restore mocks base method.
+func (m *MockBackupRepository) Restore(ctx context.Context, tables []entity.BackupTable, replace bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, tables, replace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBackupRepositoryMockRecorder) Restore(ctx, tables, replace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBackupRepository)(nil).Restore), ctx, tables, replace)
+}
+
+// MockBackupFeature is a mock of Feature interface.
+type MockBackupFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupFeatureMockRecorder is the mock recorder for MockBackupFeature.
+type MockBackupFeatureMockRecorder struct {
+	mock *MockBackupFeature
+}
+
+// NewMockBackupFeature creates a new mock instance.
+func NewMockBackupFeature(ctrl *gomock.Controller) *MockBackupFeature {
+	mock := &MockBackupFeature{ctrl: ctrl}
+	mock.recorder = &MockBackupFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupFeature) EXPECT() *MockBackupFeatureMockRecorder {
+	return m.recorder
+}
+
+// Backup mocks base method.
+func (m *MockBackupFeature) Backup(ctx context.Context, passphrase string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", ctx, passphrase)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockBackupFeatureMockRecorder) Backup(ctx, passphrase any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockBackupFeature)(nil).Backup), ctx, passphrase)
+}
+
+// Restore mocks base method.
+func (m *MockBackupFeature) Restore(ctx context.Context, archive []byte, passphrase, mode string, dryRun bool) (*dto.Restore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, archive, passphrase, mode, dryRun)
+	ret0, _ := ret[0].(*dto.Restore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBackupFeatureMockRecorder) Restore(ctx, archive, passphrase, mode, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBackupFeature)(nil).Restore), ctx, archive, passphrase, mode, dryRun)
+}
@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/certmonitor/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/certmonitor/interfaces.go -package mocks -mock_names Repository=MockCertMonitorRepository,Feature=MockCertMonitorFeature,DeviceCertificates=MockDeviceCertificates
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCertMonitorRepository is a mock of Repository interface.
+type MockCertMonitorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertMonitorRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCertMonitorRepositoryMockRecorder is the mock recorder for MockCertMonitorRepository.
+type MockCertMonitorRepositoryMockRecorder struct {
+	mock *MockCertMonitorRepository
+}
+
+// NewMockCertMonitorRepository creates a new mock instance.
+func NewMockCertMonitorRepository(ctrl *gomock.Controller) *MockCertMonitorRepository {
+	mock := &MockCertMonitorRepository{ctrl: ctrl}
+	mock.recorder = &MockCertMonitorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertMonitorRepository) EXPECT() *MockCertMonitorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCIRARootCertificates mocks base method.
+func (m *MockCertMonitorRepository) GetCIRARootCertificates(ctx context.Context) ([]entity.CIRAConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCIRARootCertificates", ctx)
+	ret0, _ := ret[0].([]entity.CIRAConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCIRARootCertificates indicates an expected call of GetCIRARootCertificates.
+func (mr *MockCertMonitorRepositoryMockRecorder) GetCIRARootCertificates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCIRARootCertificates", reflect.TypeOf((*MockCertMonitorRepository)(nil).GetCIRARootCertificates), ctx)
+}
+
+// GetDomainExpirations mocks base method.
+func (m *MockCertMonitorRepository) GetDomainExpirations(ctx context.Context) ([]entity.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomainExpirations", ctx)
+	ret0, _ := ret[0].([]entity.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomainExpirations indicates an expected call of GetDomainExpirations.
+func (mr *MockCertMonitorRepositoryMockRecorder) GetDomainExpirations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainExpirations", reflect.TypeOf((*MockCertMonitorRepository)(nil).GetDomainExpirations), ctx)
+}
+
+// GetTLSDevices mocks base method.
+func (m *MockCertMonitorRepository) GetTLSDevices(ctx context.Context) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSDevices", ctx)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTLSDevices indicates an expected call of GetTLSDevices.
+func (mr *MockCertMonitorRepositoryMockRecorder) GetTLSDevices(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSDevices", reflect.TypeOf((*MockCertMonitorRepository)(nil).GetTLSDevices), ctx)
+}
+
+// MockDeviceCertificates is a mock of DeviceCertificates interface.
+type MockDeviceCertificates struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceCertificatesMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceCertificatesMockRecorder is the mock recorder for MockDeviceCertificates.
+type MockDeviceCertificatesMockRecorder struct {
+	mock *MockDeviceCertificates
+}
+
+// NewMockDeviceCertificates creates a new mock instance.
+func NewMockDeviceCertificates(ctrl *gomock.Controller) *MockDeviceCertificates {
+	mock := &MockDeviceCertificates{ctrl: ctrl}
+	mock.recorder = &MockDeviceCertificatesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceCertificates) EXPECT() *MockDeviceCertificatesMockRecorder {
+	return m.recorder
+}
+
+// GetDeviceCertificate mocks base method.
+func (m *MockDeviceCertificates) GetDeviceCertificate(ctx context.Context, guid string) (dto.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceCertificate", ctx, guid)
+	ret0, _ := ret[0].(dto.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceCertificate indicates an expected call of GetDeviceCertificate.
+func (mr *MockDeviceCertificatesMockRecorder) GetDeviceCertificate(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceCertificate", reflect.TypeOf((*MockDeviceCertificates)(nil).GetDeviceCertificate), ctx, guid)
+}
+
+// MockCertMonitorFeature is a mock of Feature interface.
+type MockCertMonitorFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertMonitorFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockCertMonitorFeatureMockRecorder is the mock recorder for MockCertMonitorFeature.
+type MockCertMonitorFeatureMockRecorder struct {
+	mock *MockCertMonitorFeature
+}
+
+// NewMockCertMonitorFeature creates a new mock instance.
+func NewMockCertMonitorFeature(ctrl *gomock.Controller) *MockCertMonitorFeature {
+	mock := &MockCertMonitorFeature{ctrl: ctrl}
+	mock.recorder = &MockCertMonitorFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertMonitorFeature) EXPECT() *MockCertMonitorFeatureMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockCertMonitorFeature) Check(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockCertMonitorFeatureMockRecorder) Check(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockCertMonitorFeature)(nil).Check), ctx)
+}
+
+// GetExpiring mocks base method.
+func (m *MockCertMonitorFeature) GetExpiring(ctx context.Context, days int, tenantID string) ([]dto.MonitoredCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiring", ctx, days, tenantID)
+	ret0, _ := ret[0].([]dto.MonitoredCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiring indicates an expected call of GetExpiring.
+func (mr *MockCertMonitorFeatureMockRecorder) GetExpiring(ctx, days, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiring", reflect.TypeOf((*MockCertMonitorFeature)(nil).GetExpiring), ctx, days, tenantID)
+}
+
+// Run mocks base method.
+func (m *MockCertMonitorFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockCertMonitorFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCertMonitorFeature)(nil).Run), ctx)
+}
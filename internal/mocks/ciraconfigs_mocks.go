@@ -15,6 +15,7 @@ import (
 
 	entity "github.com/device-management-toolkit/console/internal/entity"
 	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,33 +44,33 @@ func (m *MockCIRAConfigsRepository) EXPECT() *MockCIRAConfigsRepositoryMockRecor
 }
 
 // Delete mocks base method.
-func (m *MockCIRAConfigsRepository) Delete(ctx context.Context, profileName, tenantID string) (bool, error) {
+func (m *MockCIRAConfigsRepository) Delete(ctx context.Context, profileName, version, tenantID string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCIRAConfigsRepositoryMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockCIRAConfigsRepositoryMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCIRAConfigsRepository)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCIRAConfigsRepository)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockCIRAConfigsRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.CIRAConfig, error) {
+func (m *MockCIRAConfigsRepository) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.CIRAConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]entity.CIRAConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockCIRAConfigsRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockCIRAConfigsRepositoryMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCIRAConfigsRepository)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCIRAConfigsRepository)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -88,18 +89,18 @@ func (mr *MockCIRAConfigsRepositoryMockRecorder) GetByName(ctx, configName, tena
 }
 
 // GetCount mocks base method.
-func (m *MockCIRAConfigsRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockCIRAConfigsRepository) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockCIRAConfigsRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockCIRAConfigsRepositoryMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCIRAConfigsRepository)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCIRAConfigsRepository)(nil).GetCount), ctx, q, tenantID)
 }
 
 // Insert mocks base method.
@@ -157,32 +158,32 @@ func (m *MockCIRAConfigsFeature) EXPECT() *MockCIRAConfigsFeatureMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockCIRAConfigsFeature) Delete(ctx context.Context, profileName, tenantID string) error {
+func (m *MockCIRAConfigsFeature) Delete(ctx context.Context, profileName, version, tenantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCIRAConfigsFeatureMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockCIRAConfigsFeatureMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCIRAConfigsFeature)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCIRAConfigsFeature)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockCIRAConfigsFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.CIRAConfig, error) {
+func (m *MockCIRAConfigsFeature) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.CIRAConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]dto.CIRAConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockCIRAConfigsFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockCIRAConfigsFeatureMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCIRAConfigsFeature)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCIRAConfigsFeature)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -201,18 +202,18 @@ func (mr *MockCIRAConfigsFeatureMockRecorder) GetByName(ctx, configName, tenantI
 }
 
 // GetCount mocks base method.
-func (m *MockCIRAConfigsFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockCIRAConfigsFeature) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockCIRAConfigsFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockCIRAConfigsFeatureMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCIRAConfigsFeature)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCIRAConfigsFeature)(nil).GetCount), ctx, q, tenantID)
 }
 
 // Insert mocks base method.
@@ -0,0 +1,260 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/deviceattributes/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/deviceattributes/interfaces.go -package mocks -mock_names Repository=MockDeviceAttributesRepository,Feature=MockDeviceAttributesFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeviceAttributesRepository is a mock of Repository interface.
+type MockDeviceAttributesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceAttributesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceAttributesRepositoryMockRecorder is the mock recorder for MockDeviceAttributesRepository.
+type MockDeviceAttributesRepositoryMockRecorder struct {
+	mock *MockDeviceAttributesRepository
+}
+
+// NewMockDeviceAttributesRepository creates a new mock instance.
+func NewMockDeviceAttributesRepository(ctrl *gomock.Controller) *MockDeviceAttributesRepository {
+	mock := &MockDeviceAttributesRepository{ctrl: ctrl}
+	mock.recorder = &MockDeviceAttributesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceAttributesRepository) EXPECT() *MockDeviceAttributesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountValues mocks base method.
+func (m *MockDeviceAttributesRepository) CountValues(ctx context.Context, name, tenantID string, values []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountValues", ctx, name, tenantID, values)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountValues indicates an expected call of CountValues.
+func (mr *MockDeviceAttributesRepositoryMockRecorder) CountValues(ctx, name, tenantID, values any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountValues", reflect.TypeOf((*MockDeviceAttributesRepository)(nil).CountValues), ctx, name, tenantID, values)
+}
+
+// Delete mocks base method.
+func (m *MockDeviceAttributesRepository) Delete(ctx context.Context, name, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeviceAttributesRepositoryMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeviceAttributesRepository)(nil).Delete), ctx, name, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockDeviceAttributesRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.DeviceAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.DeviceAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeviceAttributesRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceAttributesRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByName mocks base method.
+func (m *MockDeviceAttributesRepository) GetByName(ctx context.Context, name, tenantID string) (*entity.DeviceAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*entity.DeviceAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockDeviceAttributesRepositoryMockRecorder) GetByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockDeviceAttributesRepository)(nil).GetByName), ctx, name, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockDeviceAttributesRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockDeviceAttributesRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceAttributesRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockDeviceAttributesRepository) Insert(ctx context.Context, a *entity.DeviceAttribute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDeviceAttributesRepositoryMockRecorder) Insert(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeviceAttributesRepository)(nil).Insert), ctx, a)
+}
+
+// Update mocks base method.
+func (m *MockDeviceAttributesRepository) Update(ctx context.Context, a *entity.DeviceAttribute) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, a)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeviceAttributesRepositoryMockRecorder) Update(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeviceAttributesRepository)(nil).Update), ctx, a)
+}
+
+// MockDeviceAttributesFeature is a mock of Feature interface.
+type MockDeviceAttributesFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceAttributesFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceAttributesFeatureMockRecorder is the mock recorder for MockDeviceAttributesFeature.
+type MockDeviceAttributesFeatureMockRecorder struct {
+	mock *MockDeviceAttributesFeature
+}
+
+// NewMockDeviceAttributesFeature creates a new mock instance.
+func NewMockDeviceAttributesFeature(ctrl *gomock.Controller) *MockDeviceAttributesFeature {
+	mock := &MockDeviceAttributesFeature{ctrl: ctrl}
+	mock.recorder = &MockDeviceAttributesFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceAttributesFeature) EXPECT() *MockDeviceAttributesFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDeviceAttributesFeature) Delete(ctx context.Context, name, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeviceAttributesFeatureMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeviceAttributesFeature)(nil).Delete), ctx, name, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockDeviceAttributesFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.DeviceAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.DeviceAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeviceAttributesFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceAttributesFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByName mocks base method.
+func (m *MockDeviceAttributesFeature) GetByName(ctx context.Context, name, tenantID string) (*dto.DeviceAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*dto.DeviceAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockDeviceAttributesFeatureMockRecorder) GetByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockDeviceAttributesFeature)(nil).GetByName), ctx, name, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockDeviceAttributesFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockDeviceAttributesFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceAttributesFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockDeviceAttributesFeature) Insert(ctx context.Context, a *dto.DeviceAttribute) (*dto.DeviceAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, a)
+	ret0, _ := ret[0].(*dto.DeviceAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDeviceAttributesFeatureMockRecorder) Insert(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeviceAttributesFeature)(nil).Insert), ctx, a)
+}
+
+// Update mocks base method.
+func (m *MockDeviceAttributesFeature) Update(ctx context.Context, a *dto.DeviceAttribute) (*dto.DeviceAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, a)
+	ret0, _ := ret[0].(*dto.DeviceAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeviceAttributesFeatureMockRecorder) Update(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeviceAttributesFeature)(nil).Update), ctx, a)
+}
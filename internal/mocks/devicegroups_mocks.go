@@ -0,0 +1,375 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/devicegroups/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/devicegroups/interfaces.go -package mocks -mock_names Repository=MockDeviceGroupsRepository,Feature=MockDeviceGroupsFeature,Devices=MockDeviceGroupsDevices
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeviceGroupsRepository is a mock of Repository interface.
+type MockDeviceGroupsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceGroupsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceGroupsRepositoryMockRecorder is the mock recorder for MockDeviceGroupsRepository.
+type MockDeviceGroupsRepositoryMockRecorder struct {
+	mock *MockDeviceGroupsRepository
+}
+
+// NewMockDeviceGroupsRepository creates a new mock instance.
+func NewMockDeviceGroupsRepository(ctrl *gomock.Controller) *MockDeviceGroupsRepository {
+	mock := &MockDeviceGroupsRepository{ctrl: ctrl}
+	mock.recorder = &MockDeviceGroupsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceGroupsRepository) EXPECT() *MockDeviceGroupsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDeviceGroupsRepository) Delete(ctx context.Context, name, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeviceGroupsRepositoryMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeviceGroupsRepository)(nil).Delete), ctx, name, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockDeviceGroupsRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.DeviceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.DeviceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeviceGroupsRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceGroupsRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByName mocks base method.
+func (m *MockDeviceGroupsRepository) GetByName(ctx context.Context, name, tenantID string) (*entity.DeviceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*entity.DeviceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockDeviceGroupsRepositoryMockRecorder) GetByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockDeviceGroupsRepository)(nil).GetByName), ctx, name, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockDeviceGroupsRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockDeviceGroupsRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceGroupsRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// GetParents mocks base method.
+func (m *MockDeviceGroupsRepository) GetParents(ctx context.Context, name, tenantID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParents", ctx, name, tenantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParents indicates an expected call of GetParents.
+func (mr *MockDeviceGroupsRepositoryMockRecorder) GetParents(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParents", reflect.TypeOf((*MockDeviceGroupsRepository)(nil).GetParents), ctx, name, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockDeviceGroupsRepository) Insert(ctx context.Context, g *entity.DeviceGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDeviceGroupsRepositoryMockRecorder) Insert(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeviceGroupsRepository)(nil).Insert), ctx, g)
+}
+
+// Update mocks base method.
+func (m *MockDeviceGroupsRepository) Update(ctx context.Context, g *entity.DeviceGroup) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, g)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeviceGroupsRepositoryMockRecorder) Update(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeviceGroupsRepository)(nil).Update), ctx, g)
+}
+
+// MockDeviceGroupsDevices is a mock of Devices interface.
+type MockDeviceGroupsDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceGroupsDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceGroupsDevicesMockRecorder is the mock recorder for MockDeviceGroupsDevices.
+type MockDeviceGroupsDevicesMockRecorder struct {
+	mock *MockDeviceGroupsDevices
+}
+
+// NewMockDeviceGroupsDevices creates a new mock instance.
+func NewMockDeviceGroupsDevices(ctrl *gomock.Controller) *MockDeviceGroupsDevices {
+	mock := &MockDeviceGroupsDevices{ctrl: ctrl}
+	mock.recorder = &MockDeviceGroupsDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceGroupsDevices) EXPECT() *MockDeviceGroupsDevicesMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDeviceGroupsDevices) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeviceGroupsDevicesMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceGroupsDevices)(nil).Get), ctx, q, top, skip, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockDeviceGroupsDevices) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockDeviceGroupsDevicesMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceGroupsDevices)(nil).GetCount), ctx, q, tenantID)
+}
+
+// MockDeviceGroupsFeature is a mock of Feature interface.
+type MockDeviceGroupsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceGroupsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceGroupsFeatureMockRecorder is the mock recorder for MockDeviceGroupsFeature.
+type MockDeviceGroupsFeatureMockRecorder struct {
+	mock *MockDeviceGroupsFeature
+}
+
+// NewMockDeviceGroupsFeature creates a new mock instance.
+func NewMockDeviceGroupsFeature(ctrl *gomock.Controller) *MockDeviceGroupsFeature {
+	mock := &MockDeviceGroupsFeature{ctrl: ctrl}
+	mock.recorder = &MockDeviceGroupsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceGroupsFeature) EXPECT() *MockDeviceGroupsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDeviceGroupsFeature) Delete(ctx context.Context, name, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeviceGroupsFeatureMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).Delete), ctx, name, tenantID)
+}
+
+// Evaluate mocks base method.
+func (m *MockDeviceGroupsFeature) Evaluate(ctx context.Context, rule string, top, skip int, tenantID string) (dto.DeviceCountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, rule, top, skip, tenantID)
+	ret0, _ := ret[0].(dto.DeviceCountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockDeviceGroupsFeatureMockRecorder) Evaluate(ctx, rule, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).Evaluate), ctx, rule, top, skip, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockDeviceGroupsFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.DeviceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.DeviceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeviceGroupsFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByName mocks base method.
+func (m *MockDeviceGroupsFeature) GetByName(ctx context.Context, name, tenantID string) (*dto.DeviceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*dto.DeviceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockDeviceGroupsFeatureMockRecorder) GetByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).GetByName), ctx, name, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockDeviceGroupsFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockDeviceGroupsFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// GetMemberCount mocks base method.
+func (m *MockDeviceGroupsFeature) GetMemberCount(ctx context.Context, name string, q odata.Query, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberCount", ctx, name, q, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberCount indicates an expected call of GetMemberCount.
+func (mr *MockDeviceGroupsFeatureMockRecorder) GetMemberCount(ctx, name, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberCount", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).GetMemberCount), ctx, name, q, tenantID)
+}
+
+// GetMembers mocks base method.
+func (m *MockDeviceGroupsFeature) GetMembers(ctx context.Context, name string, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, name, q, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockDeviceGroupsFeatureMockRecorder) GetMembers(ctx, name, q, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).GetMembers), ctx, name, q, top, skip, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockDeviceGroupsFeature) Insert(ctx context.Context, g *dto.DeviceGroup) (*dto.DeviceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, g)
+	ret0, _ := ret[0].(*dto.DeviceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDeviceGroupsFeatureMockRecorder) Insert(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).Insert), ctx, g)
+}
+
+// Members mocks base method.
+func (m *MockDeviceGroupsFeature) Members(ctx context.Context, name string, q odata.Query, tenantID string) (odata.Query, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Members", ctx, name, q, tenantID)
+	ret0, _ := ret[0].(odata.Query)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Members indicates an expected call of Members.
+func (mr *MockDeviceGroupsFeatureMockRecorder) Members(ctx, name, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).Members), ctx, name, q, tenantID)
+}
+
+// Update mocks base method.
+func (m *MockDeviceGroupsFeature) Update(ctx context.Context, g *dto.DeviceGroup) (*dto.DeviceGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, g)
+	ret0, _ := ret[0].(*dto.DeviceGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDeviceGroupsFeatureMockRecorder) Update(ctx, g any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeviceGroupsFeature)(nil).Update), ctx, g)
+}
@@ -18,9 +18,9 @@ import (
 	v2 "github.com/device-management-toolkit/console/internal/entity/dto/v2"
 	devices "github.com/device-management-toolkit/console/internal/usecase/devices"
 	wsman "github.com/device-management-toolkit/console/internal/usecase/devices/wsman"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
 	wsman0 "github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman"
 	power "github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
-	websocket "github.com/gorilla/websocket"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -288,19 +288,49 @@ func (mr *MockDeviceManagementRepositoryMockRecorder) Delete(ctx, guid, tenantID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Delete), ctx, guid, tenantID)
 }
 
+// EstimateCount mocks base method.
+func (m *MockDeviceManagementRepository) EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCount", ctx, q, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCount indicates an expected call of EstimateCount.
+func (mr *MockDeviceManagementRepositoryMockRecorder) EstimateCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCount", reflect.TypeOf((*MockDeviceManagementRepository)(nil).EstimateCount), ctx, q, tenantID)
+}
+
 // Get mocks base method.
-func (m *MockDeviceManagementRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Device, error) {
+func (m *MockDeviceManagementRepository) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Device, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]entity.Device)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockDeviceManagementRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockDeviceManagementRepositoryMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Get), ctx, q, top, skip, tenantID)
+}
+
+// GetAttributes mocks base method.
+func (m *MockDeviceManagementRepository) GetAttributes(ctx context.Context, tenantID string) ([]entity.DeviceAttribute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributes", ctx, tenantID)
+	ret0, _ := ret[0].([]entity.DeviceAttribute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributes indicates an expected call of GetAttributes.
+func (mr *MockDeviceManagementRepositoryMockRecorder) GetAttributes(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributes", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetAttributes), ctx, tenantID)
 }
 
 // GetByColumn mocks base method.
@@ -349,18 +379,33 @@ func (mr *MockDeviceManagementRepositoryMockRecorder) GetByTags(ctx, tags, metho
 }
 
 // GetCount mocks base method.
-func (m *MockDeviceManagementRepository) GetCount(arg0 context.Context, arg1 string) (int, error) {
+func (m *MockDeviceManagementRepository) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockDeviceManagementRepositoryMockRecorder) GetCount(arg0, arg1 any) *gomock.Call {
+func (mr *MockDeviceManagementRepositoryMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetCount), ctx, q, tenantID)
+}
+
+// GetDeleted mocks base method.
+func (m *MockDeviceManagementRepository) GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockDeviceManagementRepositoryMockRecorder) GetDeleted(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetDeleted), ctx, top, skip, tenantID)
 }
 
 // GetDistinctTags mocks base method.
@@ -378,6 +423,22 @@ func (mr *MockDeviceManagementRepositoryMockRecorder) GetDistinctTags(ctx, tenan
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistinctTags", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetDistinctTags), ctx, tenantID)
 }
 
+// GetPage mocks base method.
+func (m *MockDeviceManagementRepository) GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]entity.Device, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, q, top, skipToken, tenantID)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockDeviceManagementRepositoryMockRecorder) GetPage(ctx, q, top, skipToken, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetPage), ctx, q, top, skipToken, tenantID)
+}
+
 // Insert mocks base method.
 func (m *MockDeviceManagementRepository) Insert(ctx context.Context, d *entity.Device) (string, error) {
 	m.ctrl.T.Helper()
@@ -393,6 +454,51 @@ func (mr *MockDeviceManagementRepositoryMockRecorder) Insert(ctx, d any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Insert), ctx, d)
 }
 
+// Purge mocks base method.
+func (m *MockDeviceManagementRepository) Purge(ctx context.Context, guid, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, guid, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockDeviceManagementRepositoryMockRecorder) Purge(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Purge), ctx, guid, tenantID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockDeviceManagementRepository) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockDeviceManagementRepositoryMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockDeviceManagementRepository)(nil).PurgeDeleted), ctx, before)
+}
+
+// Restore mocks base method.
+func (m *MockDeviceManagementRepository) Restore(ctx context.Context, guid, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, guid, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockDeviceManagementRepositoryMockRecorder) Restore(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Restore), ctx, guid, tenantID)
+}
+
 // Update mocks base method.
 func (m *MockDeviceManagementRepository) Update(ctx context.Context, d *entity.Device) (bool, error) {
 	m.ctrl.T.Helper()
@@ -462,6 +568,21 @@ func (mr *MockDeviceManagementFeatureMockRecorder) CancelUserConsent(ctx, guid a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserConsent", reflect.TypeOf((*MockDeviceManagementFeature)(nil).CancelUserConsent), ctx, guid)
 }
 
+// ConsumeRedirectionToken mocks base method.
+func (m *MockDeviceManagementFeature) ConsumeRedirectionToken(c context.Context, tokenString, guid, mode string) (devices.RedirectionClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRedirectionToken", c, tokenString, guid, mode)
+	ret0, _ := ret[0].(devices.RedirectionClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeRedirectionToken indicates an expected call of ConsumeRedirectionToken.
+func (mr *MockDeviceManagementFeatureMockRecorder) ConsumeRedirectionToken(c, tokenString, guid, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRedirectionToken", reflect.TypeOf((*MockDeviceManagementFeature)(nil).ConsumeRedirectionToken), c, tokenString, guid, mode)
+}
+
 // CreateAlarmOccurrences mocks base method.
 func (m *MockDeviceManagementFeature) CreateAlarmOccurrences(ctx context.Context, guid string, alarm dto.AlarmClockOccurrenceInput) (dto.AddAlarmOutput, error) {
 	m.ctrl.T.Helper()
@@ -505,19 +626,34 @@ func (mr *MockDeviceManagementFeatureMockRecorder) DeleteAlarmOccurrences(ctx, g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmOccurrences", reflect.TypeOf((*MockDeviceManagementFeature)(nil).DeleteAlarmOccurrences), ctx, guid, instanceID)
 }
 
+// EstimateCount mocks base method.
+func (m *MockDeviceManagementFeature) EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCount", ctx, q, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCount indicates an expected call of EstimateCount.
+func (mr *MockDeviceManagementFeatureMockRecorder) EstimateCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCount", reflect.TypeOf((*MockDeviceManagementFeature)(nil).EstimateCount), ctx, q, tenantID)
+}
+
 // Get mocks base method.
-func (m *MockDeviceManagementFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error) {
+func (m *MockDeviceManagementFeature) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]dto.Device)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockDeviceManagementFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockDeviceManagementFeatureMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetAlarmOccurrences mocks base method.
@@ -626,18 +762,33 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetCertificates(c, guid any)
 }
 
 // GetCount mocks base method.
-func (m *MockDeviceManagementFeature) GetCount(arg0 context.Context, arg1 string) (int, error) {
+func (m *MockDeviceManagementFeature) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockDeviceManagementFeatureMockRecorder) GetCount(arg0, arg1 any) *gomock.Call {
+func (mr *MockDeviceManagementFeatureMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetCount), ctx, q, tenantID)
+}
+
+// GetDeleted mocks base method.
+func (m *MockDeviceManagementFeature) GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetDeleted(ctx, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetDeleted), ctx, top, skip, tenantID)
 }
 
 // GetDeviceCertificate mocks base method.
@@ -761,6 +912,21 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetKVMScreenSettings(c, guid
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKVMScreenSettings", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetKVMScreenSettings), c, guid)
 }
 
+// GetKVMScreenshot mocks base method.
+func (m *MockDeviceManagementFeature) GetKVMScreenshot(c context.Context, guid string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKVMScreenshot", c, guid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKVMScreenshot indicates an expected call of GetKVMScreenshot.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetKVMScreenshot(c, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKVMScreenshot", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetKVMScreenshot), c, guid)
+}
+
 // GetNetworkSettings mocks base method.
 func (m *MockDeviceManagementFeature) GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error) {
 	m.ctrl.T.Helper()
@@ -776,6 +942,22 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetNetworkSettings(c, guid an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkSettings", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetNetworkSettings), c, guid)
 }
 
+// GetPage mocks base method.
+func (m *MockDeviceManagementFeature) GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]dto.Device, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, q, top, skipToken, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetPage(ctx, q, top, skipToken, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetPage), ctx, q, top, skipToken, tenantID)
+}
+
 // GetPowerCapabilities mocks base method.
 func (m *MockDeviceManagementFeature) GetPowerCapabilities(ctx context.Context, guid string) (dto.PowerCapabilities, error) {
 	m.ctrl.T.Helper()
@@ -806,6 +988,21 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetPowerState(ctx, guid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetPowerState), ctx, guid)
 }
 
+// GetRedirectionSessions mocks base method.
+func (m *MockDeviceManagementFeature) GetRedirectionSessions(c context.Context, guid string) ([]dto.RedirectionSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectionSessions", c, guid)
+	ret0, _ := ret[0].([]dto.RedirectionSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedirectionSessions indicates an expected call of GetRedirectionSessions.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetRedirectionSessions(c, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectionSessions", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetRedirectionSessions), c, guid)
+}
+
 // GetTLSSettingData mocks base method.
 func (m *MockDeviceManagementFeature) GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error) {
 	m.ctrl.T.Helper()
@@ -867,18 +1064,104 @@ func (mr *MockDeviceManagementFeatureMockRecorder) Insert(ctx, d any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Insert), ctx, d)
 }
 
+// IssueRedirectionToken mocks base method.
+func (m *MockDeviceManagementFeature) IssueRedirectionToken(c context.Context, guid, user string, modes []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueRedirectionToken", c, guid, user, modes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueRedirectionToken indicates an expected call of IssueRedirectionToken.
+func (mr *MockDeviceManagementFeatureMockRecorder) IssueRedirectionToken(c, guid, user, modes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueRedirectionToken", reflect.TypeOf((*MockDeviceManagementFeature)(nil).IssueRedirectionToken), c, guid, user, modes)
+}
+
+// PromoteRedirectionViewer mocks base method.
+func (m *MockDeviceManagementFeature) PromoteRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteRedirectionViewer", c, guid, mode, viewerID, viewerToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PromoteRedirectionViewer indicates an expected call of PromoteRedirectionViewer.
+func (mr *MockDeviceManagementFeatureMockRecorder) PromoteRedirectionViewer(c, guid, mode, viewerID, viewerToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteRedirectionViewer", reflect.TypeOf((*MockDeviceManagementFeature)(nil).PromoteRedirectionViewer), c, guid, mode, viewerID, viewerToken)
+}
+
+// Purge mocks base method.
+func (m *MockDeviceManagementFeature) Purge(ctx context.Context, guid, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, guid, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockDeviceManagementFeatureMockRecorder) Purge(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Purge), ctx, guid, tenantID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockDeviceManagementFeature) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockDeviceManagementFeatureMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockDeviceManagementFeature)(nil).PurgeDeleted), ctx, before)
+}
+
 // Redirect mocks base method.
-func (m *MockDeviceManagementFeature) Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error {
+func (m *MockDeviceManagementFeature) Redirect(ctx context.Context, conn devices.WebSocketConn, guid, mode, viewerToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Redirect", ctx, conn, guid, mode)
+	ret := m.ctrl.Call(m, "Redirect", ctx, conn, guid, mode, viewerToken)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Redirect indicates an expected call of Redirect.
-func (mr *MockDeviceManagementFeatureMockRecorder) Redirect(ctx, conn, guid, mode any) *gomock.Call {
+func (mr *MockDeviceManagementFeatureMockRecorder) Redirect(ctx, conn, guid, mode, viewerToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Redirect), ctx, conn, guid, mode, viewerToken)
+}
+
+// Restore mocks base method.
+func (m *MockDeviceManagementFeature) Restore(ctx context.Context, guid, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, guid, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockDeviceManagementFeatureMockRecorder) Restore(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Restore), ctx, guid, tenantID)
+}
+
+// RevokeRedirectionViewer mocks base method.
+func (m *MockDeviceManagementFeature) RevokeRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRedirectionViewer", c, guid, mode, viewerID, viewerToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRedirectionViewer indicates an expected call of RevokeRedirectionViewer.
+func (mr *MockDeviceManagementFeatureMockRecorder) RevokeRedirectionViewer(c, guid, mode, viewerID, viewerToken any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Redirect), ctx, conn, guid, mode)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRedirectionViewer", reflect.TypeOf((*MockDeviceManagementFeature)(nil).RevokeRedirectionViewer), c, guid, mode, viewerID, viewerToken)
 }
 
 // SendConsentCode mocks base method.
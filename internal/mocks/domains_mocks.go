@@ -15,6 +15,7 @@ import (
 
 	entity "github.com/device-management-toolkit/console/internal/entity"
 	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,33 +44,33 @@ func (m *MockDomainsRepository) EXPECT() *MockDomainsRepositoryMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockDomainsRepository) Delete(ctx context.Context, name, tenantID string) (bool, error) {
+func (m *MockDomainsRepository) Delete(ctx context.Context, name, version, tenantID string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, name, version, tenantID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockDomainsRepositoryMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+func (mr *MockDomainsRepositoryMockRecorder) Delete(ctx, name, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDomainsRepository)(nil).Delete), ctx, name, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDomainsRepository)(nil).Delete), ctx, name, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockDomainsRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Domain, error) {
+func (m *MockDomainsRepository) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Domain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]entity.Domain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockDomainsRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockDomainsRepositoryMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDomainsRepository)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDomainsRepository)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -88,18 +89,18 @@ func (mr *MockDomainsRepositoryMockRecorder) GetByName(ctx, name, tenantID any)
 }
 
 // GetCount mocks base method.
-func (m *MockDomainsRepository) GetCount(arg0 context.Context, arg1 string) (int, error) {
+func (m *MockDomainsRepository) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockDomainsRepositoryMockRecorder) GetCount(arg0, arg1 any) *gomock.Call {
+func (mr *MockDomainsRepositoryMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDomainsRepository)(nil).GetCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDomainsRepository)(nil).GetCount), ctx, q, tenantID)
 }
 
 // GetDomainByDomainSuffix mocks base method.
@@ -172,32 +173,32 @@ func (m *MockDomainsFeature) EXPECT() *MockDomainsFeatureMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockDomainsFeature) Delete(ctx context.Context, name, tenantID string) error {
+func (m *MockDomainsFeature) Delete(ctx context.Context, name, version, tenantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, name, version, tenantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockDomainsFeatureMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+func (mr *MockDomainsFeatureMockRecorder) Delete(ctx, name, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDomainsFeature)(nil).Delete), ctx, name, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDomainsFeature)(nil).Delete), ctx, name, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockDomainsFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Domain, error) {
+func (m *MockDomainsFeature) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Domain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]dto.Domain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockDomainsFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockDomainsFeatureMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDomainsFeature)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDomainsFeature)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -216,18 +217,18 @@ func (mr *MockDomainsFeatureMockRecorder) GetByName(ctx, name, tenantID any) *go
 }
 
 // GetCount mocks base method.
-func (m *MockDomainsFeature) GetCount(arg0 context.Context, arg1 string) (int, error) {
+func (m *MockDomainsFeature) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockDomainsFeatureMockRecorder) GetCount(arg0, arg1 any) *gomock.Call {
+func (mr *MockDomainsFeatureMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDomainsFeature)(nil).GetCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockDomainsFeature)(nil).GetCount), ctx, q, tenantID)
 }
 
 // GetDomainByDomainSuffix mocks base method.
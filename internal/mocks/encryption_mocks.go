@@ -0,0 +1,220 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/encryption/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/encryption/interfaces.go -package mocks -mock_names Repository=MockEncryptionRepository,Feature=MockEncryptionFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEncryptionRepository is a mock of Repository interface.
+type MockEncryptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptionRepositoryMockRecorder is the mock recorder for MockEncryptionRepository.
+type MockEncryptionRepositoryMockRecorder struct {
+	mock *MockEncryptionRepository
+}
+
+// NewMockEncryptionRepository creates a new mock instance.
+func NewMockEncryptionRepository(ctrl *gomock.Controller) *MockEncryptionRepository {
+	mock := &MockEncryptionRepository{ctrl: ctrl}
+	mock.recorder = &MockEncryptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptionRepository) EXPECT() *MockEncryptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSecrets mocks base method.
+func (m *MockEncryptionRepository) GetSecrets(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecrets", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecrets indicates an expected call of GetSecrets.
+func (mr *MockEncryptionRepositoryMockRecorder) GetSecrets(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockEncryptionRepository)(nil).GetSecrets), ctx)
+}
+
+// RotateSecrets mocks base method.
+func (m *MockEncryptionRepository) RotateSecrets(ctx context.Context, reencrypt func(string) (string, error), verify func(string, string) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSecrets", ctx, reencrypt, verify)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSecrets indicates an expected call of RotateSecrets.
+func (mr *MockEncryptionRepositoryMockRecorder) RotateSecrets(ctx, reencrypt, verify any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSecrets", reflect.TypeOf((*MockEncryptionRepository)(nil).RotateSecrets), ctx, reencrypt, verify)
+}
+
+// MockSecretStore is a mock of SecretStore interface.
+type MockSecretStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSecretStoreMockRecorder is the mock recorder for MockSecretStore.
+type MockSecretStoreMockRecorder struct {
+	mock *MockSecretStore
+}
+
+// NewMockSecretStore creates a new mock instance.
+func NewMockSecretStore(ctrl *gomock.Controller) *MockSecretStore {
+	mock := &MockSecretStore{ctrl: ctrl}
+	mock.recorder = &MockSecretStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretStore) EXPECT() *MockSecretStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSecretStore) Delete(ctx context.Context, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSecretStoreMockRecorder) Delete(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSecretStore)(nil).Delete), ctx, path)
+}
+
+// List mocks base method.
+func (m *MockSecretStore) List(ctx context.Context, path string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, path)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSecretStoreMockRecorder) List(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSecretStore)(nil).List), ctx, path)
+}
+
+// Read mocks base method.
+func (m *MockSecretStore) Read(ctx context.Context, path string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, path)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockSecretStoreMockRecorder) Read(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockSecretStore)(nil).Read), ctx, path)
+}
+
+// Write mocks base method.
+func (m *MockSecretStore) Write(ctx context.Context, path string, data map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", ctx, path, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockSecretStoreMockRecorder) Write(ctx, path, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockSecretStore)(nil).Write), ctx, path, data)
+}
+
+// MockEncryptionFeature is a mock of Feature interface.
+type MockEncryptionFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptionFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptionFeatureMockRecorder is the mock recorder for MockEncryptionFeature.
+type MockEncryptionFeatureMockRecorder struct {
+	mock *MockEncryptionFeature
+}
+
+// NewMockEncryptionFeature creates a new mock instance.
+func NewMockEncryptionFeature(ctrl *gomock.Controller) *MockEncryptionFeature {
+	mock := &MockEncryptionFeature{ctrl: ctrl}
+	mock.recorder = &MockEncryptionFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptionFeature) EXPECT() *MockEncryptionFeatureMockRecorder {
+	return m.recorder
+}
+
+// PruneSecrets mocks base method.
+func (m *MockEncryptionFeature) PruneSecrets(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneSecrets", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneSecrets indicates an expected call of PruneSecrets.
+func (mr *MockEncryptionFeatureMockRecorder) PruneSecrets(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneSecrets", reflect.TypeOf((*MockEncryptionFeature)(nil).PruneSecrets), ctx)
+}
+
+// RotateKey mocks base method.
+func (m *MockEncryptionFeature) RotateKey(ctx context.Context, newKey string) (*dto.KeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKey", ctx, newKey)
+	ret0, _ := ret[0].(*dto.KeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKey indicates an expected call of RotateKey.
+func (mr *MockEncryptionFeatureMockRecorder) RotateKey(ctx, newKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKey", reflect.TypeOf((*MockEncryptionFeature)(nil).RotateKey), ctx, newKey)
+}
+
+// Run mocks base method.
+func (m *MockEncryptionFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockEncryptionFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockEncryptionFeature)(nil).Run), ctx)
+}
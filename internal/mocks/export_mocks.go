@@ -42,6 +42,21 @@ func (m *MockExporter) EXPECT() *MockExporterMockRecorder {
 	return m.recorder
 }
 
+// ExportActivityCSV mocks base method.
+func (m *MockExporter) ExportActivityCSV(entries []dto.ActivityEntry) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportActivityCSV", entries)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportActivityCSV indicates an expected call of ExportActivityCSV.
+func (mr *MockExporterMockRecorder) ExportActivityCSV(entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportActivityCSV", reflect.TypeOf((*MockExporter)(nil).ExportActivityCSV), entries)
+}
+
 // ExportAuditLogsCSV mocks base method.
 func (m *MockExporter) ExportAuditLogsCSV(logs []auditlog.AuditLogRecord) (io.Reader, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +72,21 @@ func (mr *MockExporterMockRecorder) ExportAuditLogsCSV(logs any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAuditLogsCSV", reflect.TypeOf((*MockExporter)(nil).ExportAuditLogsCSV), logs)
 }
 
+// ExportDevicesCSV mocks base method.
+func (m *MockExporter) ExportDevicesCSV(devices []dto.Device) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDevicesCSV", devices)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportDevicesCSV indicates an expected call of ExportDevicesCSV.
+func (mr *MockExporterMockRecorder) ExportDevicesCSV(devices any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDevicesCSV", reflect.TypeOf((*MockExporter)(nil).ExportDevicesCSV), devices)
+}
+
 // ExportEventLogsCSV mocks base method.
 func (m *MockExporter) ExportEventLogsCSV(logs []dto.EventLog) (io.Reader, error) {
 	m.ctrl.T.Helper()
@@ -15,6 +15,7 @@ import (
 
 	entity "github.com/device-management-toolkit/console/internal/entity"
 	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -58,33 +59,33 @@ func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) CheckProfileExists(ctx, pr
 }
 
 // Delete mocks base method.
-func (m *MockIEEE8021xConfigsRepository) Delete(ctx context.Context, profileName, tenantID string) (bool, error) {
+func (m *MockIEEE8021xConfigsRepository) Delete(ctx context.Context, profileName, version, tenantID string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIEEE8021xConfigsRepository)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIEEE8021xConfigsRepository)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockIEEE8021xConfigsRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.IEEE8021xConfig, error) {
+func (m *MockIEEE8021xConfigsRepository) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.IEEE8021xConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]entity.IEEE8021xConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIEEE8021xConfigsRepository)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIEEE8021xConfigsRepository)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -103,18 +104,18 @@ func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) GetByName(ctx, profileName
 }
 
 // GetCount mocks base method.
-func (m *MockIEEE8021xConfigsRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockIEEE8021xConfigsRepository) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockIEEE8021xConfigsRepositoryMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockIEEE8021xConfigsRepository)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockIEEE8021xConfigsRepository)(nil).GetCount), ctx, q, tenantID)
 }
 
 // Insert mocks base method.
@@ -187,32 +188,32 @@ func (mr *MockIEEE8021xConfigsFeatureMockRecorder) CheckProfileExists(ctx, profi
 }
 
 // Delete mocks base method.
-func (m *MockIEEE8021xConfigsFeature) Delete(ctx context.Context, profileName, tenantID string) error {
+func (m *MockIEEE8021xConfigsFeature) Delete(ctx context.Context, profileName, version, tenantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIEEE8021xConfigsFeatureMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockIEEE8021xConfigsFeatureMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIEEE8021xConfigsFeature)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIEEE8021xConfigsFeature)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockIEEE8021xConfigsFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.IEEE8021xConfig, error) {
+func (m *MockIEEE8021xConfigsFeature) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.IEEE8021xConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]dto.IEEE8021xConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockIEEE8021xConfigsFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockIEEE8021xConfigsFeatureMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIEEE8021xConfigsFeature)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIEEE8021xConfigsFeature)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -231,18 +232,18 @@ func (mr *MockIEEE8021xConfigsFeatureMockRecorder) GetByName(ctx, profileName, t
 }
 
 // GetCount mocks base method.
-func (m *MockIEEE8021xConfigsFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockIEEE8021xConfigsFeature) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockIEEE8021xConfigsFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockIEEE8021xConfigsFeatureMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockIEEE8021xConfigsFeature)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockIEEE8021xConfigsFeature)(nil).GetCount), ctx, q, tenantID)
 }
 
 // Insert mocks base method.
@@ -15,6 +15,7 @@ import (
 
 	entity "github.com/device-management-toolkit/console/internal/entity"
 	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,33 +44,33 @@ func (m *MockProfilesRepository) EXPECT() *MockProfilesRepositoryMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockProfilesRepository) Delete(ctx context.Context, profileName, tenantID string) (bool, error) {
+func (m *MockProfilesRepository) Delete(ctx context.Context, profileName, version, tenantID string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockProfilesRepositoryMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockProfilesRepositoryMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfilesRepository)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfilesRepository)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockProfilesRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Profile, error) {
+func (m *MockProfilesRepository) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Profile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]entity.Profile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockProfilesRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockProfilesRepositoryMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProfilesRepository)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProfilesRepository)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -87,19 +88,49 @@ func (mr *MockProfilesRepositoryMockRecorder) GetByName(ctx, profileName, tenant
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProfilesRepository)(nil).GetByName), ctx, profileName, tenantID)
 }
 
+// GetByTemplate mocks base method.
+func (m *MockProfilesRepository) GetByTemplate(ctx context.Context, templateName, tenantID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTemplate", ctx, templateName, tenantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTemplate indicates an expected call of GetByTemplate.
+func (mr *MockProfilesRepositoryMockRecorder) GetByTemplate(ctx, templateName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTemplate", reflect.TypeOf((*MockProfilesRepository)(nil).GetByTemplate), ctx, templateName, tenantID)
+}
+
 // GetCount mocks base method.
-func (m *MockProfilesRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockProfilesRepository) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockProfilesRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockProfilesRepositoryMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockProfilesRepository)(nil).GetCount), ctx, q, tenantID)
+}
+
+// GetDeleted mocks base method.
+func (m *MockProfilesRepository) GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockProfilesRepositoryMockRecorder) GetDeleted(ctx, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockProfilesRepository)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockProfilesRepository)(nil).GetDeleted), ctx, top, skip, tenantID)
 }
 
 // Insert mocks base method.
@@ -117,6 +148,51 @@ func (mr *MockProfilesRepositoryMockRecorder) Insert(ctx, p any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockProfilesRepository)(nil).Insert), ctx, p)
 }
 
+// Purge mocks base method.
+func (m *MockProfilesRepository) Purge(ctx context.Context, profileName, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, profileName, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockProfilesRepositoryMockRecorder) Purge(ctx, profileName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockProfilesRepository)(nil).Purge), ctx, profileName, tenantID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockProfilesRepository) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockProfilesRepositoryMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockProfilesRepository)(nil).PurgeDeleted), ctx, before)
+}
+
+// Restore mocks base method.
+func (m *MockProfilesRepository) Restore(ctx context.Context, profileName, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, profileName, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProfilesRepositoryMockRecorder) Restore(ctx, profileName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProfilesRepository)(nil).Restore), ctx, profileName, tenantID)
+}
+
 // Update mocks base method.
 func (m *MockProfilesRepository) Update(ctx context.Context, p *entity.Profile) (bool, error) {
 	m.ctrl.T.Helper()
@@ -156,18 +232,33 @@ func (m *MockProfilesFeature) EXPECT() *MockProfilesFeatureMockRecorder {
 	return m.recorder
 }
 
+// Clone mocks base method.
+func (m *MockProfilesFeature) Clone(ctx context.Context, profileName string, overrides []byte, tenantID string) (*dto.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, profileName, overrides, tenantID)
+	ret0, _ := ret[0].(*dto.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockProfilesFeatureMockRecorder) Clone(ctx, profileName, overrides, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockProfilesFeature)(nil).Clone), ctx, profileName, overrides, tenantID)
+}
+
 // Delete mocks base method.
-func (m *MockProfilesFeature) Delete(ctx context.Context, profileName, tenantID string) error {
+func (m *MockProfilesFeature) Delete(ctx context.Context, profileName, version, tenantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockProfilesFeatureMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockProfilesFeatureMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfilesFeature)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfilesFeature)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Export mocks base method.
@@ -187,18 +278,18 @@ func (mr *MockProfilesFeatureMockRecorder) Export(ctx, profileName, domainName,
 }
 
 // Get mocks base method.
-func (m *MockProfilesFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Profile, error) {
+func (m *MockProfilesFeature) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Profile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]dto.Profile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockProfilesFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockProfilesFeatureMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProfilesFeature)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProfilesFeature)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -217,18 +308,33 @@ func (mr *MockProfilesFeatureMockRecorder) GetByName(ctx, profileName, tenantID
 }
 
 // GetCount mocks base method.
-func (m *MockProfilesFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockProfilesFeature) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockProfilesFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockProfilesFeatureMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockProfilesFeature)(nil).GetCount), ctx, q, tenantID)
+}
+
+// GetDeleted mocks base method.
+func (m *MockProfilesFeature) GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]dto.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockProfilesFeatureMockRecorder) GetDeleted(ctx, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockProfilesFeature)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockProfilesFeature)(nil).GetDeleted), ctx, top, skip, tenantID)
 }
 
 // Insert mocks base method.
@@ -246,6 +352,49 @@ func (mr *MockProfilesFeatureMockRecorder) Insert(ctx, p any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockProfilesFeature)(nil).Insert), ctx, p)
 }
 
+// Purge mocks base method.
+func (m *MockProfilesFeature) Purge(ctx context.Context, profileName, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, profileName, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockProfilesFeatureMockRecorder) Purge(ctx, profileName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockProfilesFeature)(nil).Purge), ctx, profileName, tenantID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockProfilesFeature) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockProfilesFeatureMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockProfilesFeature)(nil).PurgeDeleted), ctx, before)
+}
+
+// Restore mocks base method.
+func (m *MockProfilesFeature) Restore(ctx context.Context, profileName, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, profileName, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockProfilesFeatureMockRecorder) Restore(ctx, profileName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProfilesFeature)(nil).Restore), ctx, profileName, tenantID)
+}
+
 // Update mocks base method.
 func (m *MockProfilesFeature) Update(ctx context.Context, p *dto.Profile) (*dto.Profile, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,329 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/profiletemplates/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/profiletemplates/interfaces.go -package mocks -mock_names Repository=MockProfileTemplatesRepository,Feature=MockProfileTemplatesFeature,Profiles=MockProfileTemplatesProfiles
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfileTemplatesRepository is a mock of Repository interface.
+type MockProfileTemplatesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileTemplatesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileTemplatesRepositoryMockRecorder is the mock recorder for MockProfileTemplatesRepository.
+type MockProfileTemplatesRepositoryMockRecorder struct {
+	mock *MockProfileTemplatesRepository
+}
+
+// NewMockProfileTemplatesRepository creates a new mock instance.
+func NewMockProfileTemplatesRepository(ctrl *gomock.Controller) *MockProfileTemplatesRepository {
+	mock := &MockProfileTemplatesRepository{ctrl: ctrl}
+	mock.recorder = &MockProfileTemplatesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileTemplatesRepository) EXPECT() *MockProfileTemplatesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProfileTemplatesRepository) Delete(ctx context.Context, templateName, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, templateName, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProfileTemplatesRepositoryMockRecorder) Delete(ctx, templateName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfileTemplatesRepository)(nil).Delete), ctx, templateName, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockProfileTemplatesRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.ProfileTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.ProfileTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProfileTemplatesRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProfileTemplatesRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByName mocks base method.
+func (m *MockProfileTemplatesRepository) GetByName(ctx context.Context, templateName, tenantID string) (*entity.ProfileTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, templateName, tenantID)
+	ret0, _ := ret[0].(*entity.ProfileTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockProfileTemplatesRepositoryMockRecorder) GetByName(ctx, templateName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProfileTemplatesRepository)(nil).GetByName), ctx, templateName, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockProfileTemplatesRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockProfileTemplatesRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockProfileTemplatesRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockProfileTemplatesRepository) Insert(ctx context.Context, t *entity.ProfileTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockProfileTemplatesRepositoryMockRecorder) Insert(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockProfileTemplatesRepository)(nil).Insert), ctx, t)
+}
+
+// Update mocks base method.
+func (m *MockProfileTemplatesRepository) Update(ctx context.Context, t *entity.ProfileTemplate) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, t)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProfileTemplatesRepositoryMockRecorder) Update(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProfileTemplatesRepository)(nil).Update), ctx, t)
+}
+
+// MockProfileTemplatesProfiles is a mock of Profiles interface.
+type MockProfileTemplatesProfiles struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileTemplatesProfilesMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileTemplatesProfilesMockRecorder is the mock recorder for MockProfileTemplatesProfiles.
+type MockProfileTemplatesProfilesMockRecorder struct {
+	mock *MockProfileTemplatesProfiles
+}
+
+// NewMockProfileTemplatesProfiles creates a new mock instance.
+func NewMockProfileTemplatesProfiles(ctrl *gomock.Controller) *MockProfileTemplatesProfiles {
+	mock := &MockProfileTemplatesProfiles{ctrl: ctrl}
+	mock.recorder = &MockProfileTemplatesProfilesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileTemplatesProfiles) EXPECT() *MockProfileTemplatesProfilesMockRecorder {
+	return m.recorder
+}
+
+// ApplyTemplate mocks base method.
+func (m *MockProfileTemplatesProfiles) ApplyTemplate(ctx context.Context, templateName, tenantID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTemplate", ctx, templateName, tenantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyTemplate indicates an expected call of ApplyTemplate.
+func (mr *MockProfileTemplatesProfilesMockRecorder) ApplyTemplate(ctx, templateName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTemplate", reflect.TypeOf((*MockProfileTemplatesProfiles)(nil).ApplyTemplate), ctx, templateName, tenantID)
+}
+
+// GetByTemplate mocks base method.
+func (m *MockProfileTemplatesProfiles) GetByTemplate(ctx context.Context, templateName, tenantID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTemplate", ctx, templateName, tenantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTemplate indicates an expected call of GetByTemplate.
+func (mr *MockProfileTemplatesProfilesMockRecorder) GetByTemplate(ctx, templateName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTemplate", reflect.TypeOf((*MockProfileTemplatesProfiles)(nil).GetByTemplate), ctx, templateName, tenantID)
+}
+
+// PreviewTemplate mocks base method.
+func (m *MockProfileTemplatesProfiles) PreviewTemplate(ctx context.Context, t *dto.ProfileTemplate) ([]dto.ProfileTemplateImpact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewTemplate", ctx, t)
+	ret0, _ := ret[0].([]dto.ProfileTemplateImpact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewTemplate indicates an expected call of PreviewTemplate.
+func (mr *MockProfileTemplatesProfilesMockRecorder) PreviewTemplate(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTemplate", reflect.TypeOf((*MockProfileTemplatesProfiles)(nil).PreviewTemplate), ctx, t)
+}
+
+// MockProfileTemplatesFeature is a mock of Feature interface.
+type MockProfileTemplatesFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileTemplatesFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockProfileTemplatesFeatureMockRecorder is the mock recorder for MockProfileTemplatesFeature.
+type MockProfileTemplatesFeatureMockRecorder struct {
+	mock *MockProfileTemplatesFeature
+}
+
+// NewMockProfileTemplatesFeature creates a new mock instance.
+func NewMockProfileTemplatesFeature(ctrl *gomock.Controller) *MockProfileTemplatesFeature {
+	mock := &MockProfileTemplatesFeature{ctrl: ctrl}
+	mock.recorder = &MockProfileTemplatesFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileTemplatesFeature) EXPECT() *MockProfileTemplatesFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProfileTemplatesFeature) Delete(ctx context.Context, templateName, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, templateName, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProfileTemplatesFeatureMockRecorder) Delete(ctx, templateName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfileTemplatesFeature)(nil).Delete), ctx, templateName, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockProfileTemplatesFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.ProfileTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.ProfileTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProfileTemplatesFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProfileTemplatesFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByName mocks base method.
+func (m *MockProfileTemplatesFeature) GetByName(ctx context.Context, templateName, tenantID string) (*dto.ProfileTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, templateName, tenantID)
+	ret0, _ := ret[0].(*dto.ProfileTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockProfileTemplatesFeatureMockRecorder) GetByName(ctx, templateName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProfileTemplatesFeature)(nil).GetByName), ctx, templateName, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockProfileTemplatesFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockProfileTemplatesFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockProfileTemplatesFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockProfileTemplatesFeature) Insert(ctx context.Context, t *dto.ProfileTemplate) (*dto.ProfileTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, t)
+	ret0, _ := ret[0].(*dto.ProfileTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockProfileTemplatesFeatureMockRecorder) Insert(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockProfileTemplatesFeature)(nil).Insert), ctx, t)
+}
+
+// Preview mocks base method.
+func (m *MockProfileTemplatesFeature) Preview(ctx context.Context, t *dto.ProfileTemplate) ([]dto.ProfileTemplateImpact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", ctx, t)
+	ret0, _ := ret[0].([]dto.ProfileTemplateImpact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockProfileTemplatesFeatureMockRecorder) Preview(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockProfileTemplatesFeature)(nil).Preview), ctx, t)
+}
+
+// Update mocks base method.
+func (m *MockProfileTemplatesFeature) Update(ctx context.Context, t *dto.ProfileTemplate) (*dto.ProfileTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, t)
+	ret0, _ := ret[0].(*dto.ProfileTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProfileTemplatesFeatureMockRecorder) Update(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProfileTemplatesFeature)(nil).Update), ctx, t)
+}
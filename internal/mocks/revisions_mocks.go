@@ -0,0 +1,334 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/revisions/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/revisions/interfaces.go -package mocks -mock_names Repository=MockRevisionsRepository,Feature=MockRevisionsFeature,Recorder=MockRevisionsRecorder,Applier=MockRevisionsApplier
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRevisionsRepository is a mock of Repository interface.
+type MockRevisionsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRevisionsRepositoryMockRecorder is the mock recorder for MockRevisionsRepository.
+type MockRevisionsRepositoryMockRecorder struct {
+	mock *MockRevisionsRepository
+}
+
+// NewMockRevisionsRepository creates a new mock instance.
+func NewMockRevisionsRepository(ctrl *gomock.Controller) *MockRevisionsRepository {
+	mock := &MockRevisionsRepository{ctrl: ctrl}
+	mock.recorder = &MockRevisionsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionsRepository) EXPECT() *MockRevisionsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRevisionsRepository) Get(ctx context.Context, kind, name string, top, skip int, tenantID string) ([]entity.ConfigRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, kind, name, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.ConfigRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRevisionsRepositoryMockRecorder) Get(ctx, kind, name, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRevisionsRepository)(nil).Get), ctx, kind, name, top, skip, tenantID)
+}
+
+// GetByRevision mocks base method.
+func (m *MockRevisionsRepository) GetByRevision(ctx context.Context, kind, name string, revision int, tenantID string) (*entity.ConfigRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRevision", ctx, kind, name, revision, tenantID)
+	ret0, _ := ret[0].(*entity.ConfigRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRevision indicates an expected call of GetByRevision.
+func (mr *MockRevisionsRepositoryMockRecorder) GetByRevision(ctx, kind, name, revision, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRevision", reflect.TypeOf((*MockRevisionsRepository)(nil).GetByRevision), ctx, kind, name, revision, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockRevisionsRepository) GetCount(ctx context.Context, kind, name, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, kind, name, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockRevisionsRepositoryMockRecorder) GetCount(ctx, kind, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRevisionsRepository)(nil).GetCount), ctx, kind, name, tenantID)
+}
+
+// InTx mocks base method.
+func (m *MockRevisionsRepository) InTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockRevisionsRepositoryMockRecorder) InTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockRevisionsRepository)(nil).InTx), ctx, fn)
+}
+
+// Insert mocks base method.
+func (m *MockRevisionsRepository) Insert(ctx context.Context, rev *entity.ConfigRevision) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, rev)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRevisionsRepositoryMockRecorder) Insert(ctx, rev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRevisionsRepository)(nil).Insert), ctx, rev)
+}
+
+// MockRevisionsRecorder is a mock of Recorder interface.
+type MockRevisionsRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionsRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockRevisionsRecorderMockRecorder is the mock recorder for MockRevisionsRecorder.
+type MockRevisionsRecorderMockRecorder struct {
+	mock *MockRevisionsRecorder
+}
+
+// NewMockRevisionsRecorder creates a new mock instance.
+func NewMockRevisionsRecorder(ctrl *gomock.Controller) *MockRevisionsRecorder {
+	mock := &MockRevisionsRecorder{ctrl: ctrl}
+	mock.recorder = &MockRevisionsRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionsRecorder) EXPECT() *MockRevisionsRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockRevisionsRecorder) Record(ctx context.Context, kind, name, tenantID string, content any, secrets map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, kind, name, tenantID, content, secrets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRevisionsRecorderMockRecorder) Record(ctx, kind, name, tenantID, content, secrets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRevisionsRecorder)(nil).Record), ctx, kind, name, tenantID, content, secrets)
+}
+
+// Save mocks base method.
+func (m *MockRevisionsRecorder) Save(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRevisionsRecorderMockRecorder) Save(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRevisionsRecorder)(nil).Save), ctx, fn)
+}
+
+// MockRevisionsApplier is a mock of Applier interface.
+type MockRevisionsApplier struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionsApplierMockRecorder
+	isgomock struct{}
+}
+
+// MockRevisionsApplierMockRecorder is the mock recorder for MockRevisionsApplier.
+type MockRevisionsApplierMockRecorder struct {
+	mock *MockRevisionsApplier
+}
+
+// NewMockRevisionsApplier creates a new mock instance.
+func NewMockRevisionsApplier(ctrl *gomock.Controller) *MockRevisionsApplier {
+	mock := &MockRevisionsApplier{ctrl: ctrl}
+	mock.recorder = &MockRevisionsApplierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionsApplier) EXPECT() *MockRevisionsApplierMockRecorder {
+	return m.recorder
+}
+
+// ApplyRevision mocks base method.
+func (m *MockRevisionsApplier) ApplyRevision(ctx context.Context, content []byte, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyRevision", ctx, content, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyRevision indicates an expected call of ApplyRevision.
+func (mr *MockRevisionsApplierMockRecorder) ApplyRevision(ctx, content, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRevision", reflect.TypeOf((*MockRevisionsApplier)(nil).ApplyRevision), ctx, content, tenantID)
+}
+
+// MockRevisionsFeature is a mock of Feature interface.
+type MockRevisionsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockRevisionsFeatureMockRecorder is the mock recorder for MockRevisionsFeature.
+type MockRevisionsFeatureMockRecorder struct {
+	mock *MockRevisionsFeature
+}
+
+// NewMockRevisionsFeature creates a new mock instance.
+func NewMockRevisionsFeature(ctrl *gomock.Controller) *MockRevisionsFeature {
+	mock := &MockRevisionsFeature{ctrl: ctrl}
+	mock.recorder = &MockRevisionsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionsFeature) EXPECT() *MockRevisionsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockRevisionsFeature) Diff(ctx context.Context, kind, name string, from, to int, tenantID string) (*dto.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, kind, name, from, to, tenantID)
+	ret0, _ := ret[0].(*dto.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockRevisionsFeatureMockRecorder) Diff(ctx, kind, name, from, to, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockRevisionsFeature)(nil).Diff), ctx, kind, name, from, to, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockRevisionsFeature) Get(ctx context.Context, kind, name string, top, skip int, tenantID string) ([]dto.ConfigRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, kind, name, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.ConfigRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRevisionsFeatureMockRecorder) Get(ctx, kind, name, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRevisionsFeature)(nil).Get), ctx, kind, name, top, skip, tenantID)
+}
+
+// GetByRevision mocks base method.
+func (m *MockRevisionsFeature) GetByRevision(ctx context.Context, kind, name string, revision int, tenantID string) (*dto.ConfigRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRevision", ctx, kind, name, revision, tenantID)
+	ret0, _ := ret[0].(*dto.ConfigRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRevision indicates an expected call of GetByRevision.
+func (mr *MockRevisionsFeatureMockRecorder) GetByRevision(ctx, kind, name, revision, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRevision", reflect.TypeOf((*MockRevisionsFeature)(nil).GetByRevision), ctx, kind, name, revision, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockRevisionsFeature) GetCount(ctx context.Context, kind, name, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, kind, name, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockRevisionsFeatureMockRecorder) GetCount(ctx, kind, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRevisionsFeature)(nil).GetCount), ctx, kind, name, tenantID)
+}
+
+// Record mocks base method.
+func (m *MockRevisionsFeature) Record(ctx context.Context, kind, name, tenantID string, content any, secrets map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, kind, name, tenantID, content, secrets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRevisionsFeatureMockRecorder) Record(ctx, kind, name, tenantID, content, secrets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRevisionsFeature)(nil).Record), ctx, kind, name, tenantID, content, secrets)
+}
+
+// Rollback mocks base method.
+func (m *MockRevisionsFeature) Rollback(ctx context.Context, kind, name string, revision int, tenantID string) (*dto.ConfigRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx, kind, name, revision, tenantID)
+	ret0, _ := ret[0].(*dto.ConfigRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockRevisionsFeatureMockRecorder) Rollback(ctx, kind, name, revision, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockRevisionsFeature)(nil).Rollback), ctx, kind, name, revision, tenantID)
+}
+
+// Save mocks base method.
+func (m *MockRevisionsFeature) Save(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRevisionsFeatureMockRecorder) Save(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRevisionsFeature)(nil).Save), ctx, fn)
+}
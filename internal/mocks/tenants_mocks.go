@@ -0,0 +1,245 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/tenants/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/tenants/interfaces.go -package mocks -mock_names Repository=MockTenantsRepository,Feature=MockTenantsFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/device-management-toolkit/console/internal/entity"
+	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTenantsRepository is a mock of Repository interface.
+type MockTenantsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantsRepositoryMockRecorder is the mock recorder for MockTenantsRepository.
+type MockTenantsRepositoryMockRecorder struct {
+	mock *MockTenantsRepository
+}
+
+// NewMockTenantsRepository creates a new mock instance.
+func NewMockTenantsRepository(ctrl *gomock.Controller) *MockTenantsRepository {
+	mock := &MockTenantsRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantsRepository) EXPECT() *MockTenantsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTenantsRepository) Delete(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTenantsRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTenantsRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockTenantsRepository) Get(ctx context.Context, top, skip int) ([]entity.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTenantsRepositoryMockRecorder) Get(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTenantsRepository)(nil).Get), ctx, top, skip)
+}
+
+// GetByID mocks base method.
+func (m *MockTenantsRepository) GetByID(ctx context.Context, id string) (*entity.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTenantsRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTenantsRepository)(nil).GetByID), ctx, id)
+}
+
+// GetCount mocks base method.
+func (m *MockTenantsRepository) GetCount(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockTenantsRepositoryMockRecorder) GetCount(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockTenantsRepository)(nil).GetCount), ctx)
+}
+
+// Insert mocks base method.
+func (m *MockTenantsRepository) Insert(ctx context.Context, t *entity.Tenant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTenantsRepositoryMockRecorder) Insert(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTenantsRepository)(nil).Insert), ctx, t)
+}
+
+// Update mocks base method.
+func (m *MockTenantsRepository) Update(ctx context.Context, t *entity.Tenant) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, t)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTenantsRepositoryMockRecorder) Update(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTenantsRepository)(nil).Update), ctx, t)
+}
+
+// MockTenantsFeature is a mock of Feature interface.
+type MockTenantsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantsFeatureMockRecorder is the mock recorder for MockTenantsFeature.
+type MockTenantsFeatureMockRecorder struct {
+	mock *MockTenantsFeature
+}
+
+// NewMockTenantsFeature creates a new mock instance.
+func NewMockTenantsFeature(ctrl *gomock.Controller) *MockTenantsFeature {
+	mock := &MockTenantsFeature{ctrl: ctrl}
+	mock.recorder = &MockTenantsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantsFeature) EXPECT() *MockTenantsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTenantsFeature) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTenantsFeatureMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTenantsFeature)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockTenantsFeature) Get(ctx context.Context, top, skip int) ([]dto.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip)
+	ret0, _ := ret[0].([]dto.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTenantsFeatureMockRecorder) Get(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTenantsFeature)(nil).Get), ctx, top, skip)
+}
+
+// GetByID mocks base method.
+func (m *MockTenantsFeature) GetByID(ctx context.Context, id string) (*dto.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*dto.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTenantsFeatureMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTenantsFeature)(nil).GetByID), ctx, id)
+}
+
+// GetCount mocks base method.
+func (m *MockTenantsFeature) GetCount(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockTenantsFeatureMockRecorder) GetCount(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockTenantsFeature)(nil).GetCount), ctx)
+}
+
+// Insert mocks base method.
+func (m *MockTenantsFeature) Insert(ctx context.Context, t *dto.Tenant) (*dto.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, t)
+	ret0, _ := ret[0].(*dto.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTenantsFeatureMockRecorder) Insert(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTenantsFeature)(nil).Insert), ctx, t)
+}
+
+// Update mocks base method.
+func (m *MockTenantsFeature) Update(ctx context.Context, t *dto.Tenant) (*dto.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, t)
+	ret0, _ := ret[0].(*dto.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTenantsFeatureMockRecorder) Update(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTenantsFeature)(nil).Update), ctx, t)
+}
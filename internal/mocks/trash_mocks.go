@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/trash/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/trash/interfaces.go -package mocks -mock_names Purger=MockTrashPurger,Feature=MockTrashFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashPurger is a mock of Purger interface.
+type MockTrashPurger struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashPurgerMockRecorder
+	isgomock struct{}
+}
+
+// MockTrashPurgerMockRecorder is the mock recorder for MockTrashPurger.
+type MockTrashPurgerMockRecorder struct {
+	mock *MockTrashPurger
+}
+
+// NewMockTrashPurger creates a new mock instance.
+func NewMockTrashPurger(ctrl *gomock.Controller) *MockTrashPurger {
+	mock := &MockTrashPurger{ctrl: ctrl}
+	mock.recorder = &MockTrashPurgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashPurger) EXPECT() *MockTrashPurgerMockRecorder {
+	return m.recorder
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockTrashPurger) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockTrashPurgerMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockTrashPurger)(nil).PurgeDeleted), ctx, before)
+}
+
+// MockTrashFeature is a mock of Feature interface.
+type MockTrashFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockTrashFeatureMockRecorder is the mock recorder for MockTrashFeature.
+type MockTrashFeatureMockRecorder struct {
+	mock *MockTrashFeature
+}
+
+// NewMockTrashFeature creates a new mock instance.
+func NewMockTrashFeature(ctrl *gomock.Controller) *MockTrashFeature {
+	mock := &MockTrashFeature{ctrl: ctrl}
+	mock.recorder = &MockTrashFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashFeature) EXPECT() *MockTrashFeatureMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *MockTrashFeature) Purge(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashFeatureMockRecorder) Purge(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrashFeature)(nil).Purge), ctx)
+}
+
+// Run mocks base method.
+func (m *MockTrashFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockTrashFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockTrashFeature)(nil).Run), ctx)
+}
@@ -15,6 +15,7 @@ import (
 
 	entity "github.com/device-management-toolkit/console/internal/entity"
 	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -58,33 +59,33 @@ func (mr *MockWiFiConfigsRepositoryMockRecorder) CheckProfileExists(ctx, profile
 }
 
 // Delete mocks base method.
-func (m *MockWiFiConfigsRepository) Delete(ctx context.Context, profileName, tenantID string) (bool, error) {
+func (m *MockWiFiConfigsRepository) Delete(ctx context.Context, profileName, version, tenantID string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWiFiConfigsRepositoryMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockWiFiConfigsRepositoryMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWiFiConfigsRepository)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWiFiConfigsRepository)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockWiFiConfigsRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.WirelessConfig, error) {
+func (m *MockWiFiConfigsRepository) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.WirelessConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]entity.WirelessConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockWiFiConfigsRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockWiFiConfigsRepositoryMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWiFiConfigsRepository)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWiFiConfigsRepository)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -103,18 +104,18 @@ func (mr *MockWiFiConfigsRepositoryMockRecorder) GetByName(ctx, guid, tenantID a
 }
 
 // GetCount mocks base method.
-func (m *MockWiFiConfigsRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockWiFiConfigsRepository) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockWiFiConfigsRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockWiFiConfigsRepositoryMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockWiFiConfigsRepository)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockWiFiConfigsRepository)(nil).GetCount), ctx, q, tenantID)
 }
 
 // Insert mocks base method.
@@ -187,32 +188,32 @@ func (mr *MockWiFiConfigsFeatureMockRecorder) CheckProfileExists(ctx, profileNam
 }
 
 // Delete mocks base method.
-func (m *MockWiFiConfigsFeature) Delete(ctx context.Context, profileName, tenantID string) error {
+func (m *MockWiFiConfigsFeature) Delete(ctx context.Context, profileName, version, tenantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, profileName, tenantID)
+	ret := m.ctrl.Call(m, "Delete", ctx, profileName, version, tenantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockWiFiConfigsFeatureMockRecorder) Delete(ctx, profileName, tenantID any) *gomock.Call {
+func (mr *MockWiFiConfigsFeatureMockRecorder) Delete(ctx, profileName, version, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWiFiConfigsFeature)(nil).Delete), ctx, profileName, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWiFiConfigsFeature)(nil).Delete), ctx, profileName, version, tenantID)
 }
 
 // Get mocks base method.
-func (m *MockWiFiConfigsFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.WirelessConfig, error) {
+func (m *MockWiFiConfigsFeature) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.WirelessConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]dto.WirelessConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockWiFiConfigsFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockWiFiConfigsFeatureMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWiFiConfigsFeature)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWiFiConfigsFeature)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetByName mocks base method.
@@ -231,18 +232,18 @@ func (mr *MockWiFiConfigsFeatureMockRecorder) GetByName(ctx, guid, tenantID any)
 }
 
 // GetCount mocks base method.
-func (m *MockWiFiConfigsFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+func (m *MockWiFiConfigsFeature) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockWiFiConfigsFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+func (mr *MockWiFiConfigsFeatureMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockWiFiConfigsFeature)(nil).GetCount), ctx, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockWiFiConfigsFeature)(nil).GetCount), ctx, q, tenantID)
 }
 
 // Insert mocks base method.
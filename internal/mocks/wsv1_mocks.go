@@ -16,6 +16,8 @@ import (
 
 	dto "github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	v2 "github.com/device-management-toolkit/console/internal/entity/dto/v2"
+	devices "github.com/device-management-toolkit/console/internal/usecase/devices"
+	odata "github.com/device-management-toolkit/console/pkg/odata"
 	power "github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
 	gin "github.com/gin-gonic/gin"
 	websocket "github.com/gorilla/websocket"
@@ -86,17 +88,17 @@ func (m *MockRedirect) EXPECT() *MockRedirectMockRecorder {
 }
 
 // Redirect mocks base method.
-func (m *MockRedirect) Redirect(c *gin.Context, conn *websocket.Conn, host, mode string) error {
+func (m *MockRedirect) Redirect(c *gin.Context, conn *websocket.Conn, host, mode, viewerToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Redirect", c, conn, host, mode)
+	ret := m.ctrl.Call(m, "Redirect", c, conn, host, mode, viewerToken)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Redirect indicates an expected call of Redirect.
-func (mr *MockRedirectMockRecorder) Redirect(c, conn, host, mode any) *gomock.Call {
+func (mr *MockRedirectMockRecorder) Redirect(c, conn, host, mode, viewerToken any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockRedirect)(nil).Redirect), c, conn, host, mode)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockRedirect)(nil).Redirect), c, conn, host, mode, viewerToken)
 }
 
 // MockFeature is a mock of Feature interface.
@@ -153,6 +155,21 @@ func (mr *MockFeatureMockRecorder) CancelUserConsent(ctx, guid any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserConsent", reflect.TypeOf((*MockFeature)(nil).CancelUserConsent), ctx, guid)
 }
 
+// ConsumeRedirectionToken mocks base method.
+func (m *MockFeature) ConsumeRedirectionToken(c context.Context, tokenString, guid, mode string) (devices.RedirectionClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRedirectionToken", c, tokenString, guid, mode)
+	ret0, _ := ret[0].(devices.RedirectionClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeRedirectionToken indicates an expected call of ConsumeRedirectionToken.
+func (mr *MockFeatureMockRecorder) ConsumeRedirectionToken(c, tokenString, guid, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRedirectionToken", reflect.TypeOf((*MockFeature)(nil).ConsumeRedirectionToken), c, tokenString, guid, mode)
+}
+
 // CreateAlarmOccurrences mocks base method.
 func (m *MockFeature) CreateAlarmOccurrences(ctx context.Context, guid string, alarm dto.AlarmClockOccurrenceInput) (dto.AddAlarmOutput, error) {
 	m.ctrl.T.Helper()
@@ -196,19 +213,34 @@ func (mr *MockFeatureMockRecorder) DeleteAlarmOccurrences(ctx, guid, instanceID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmOccurrences", reflect.TypeOf((*MockFeature)(nil).DeleteAlarmOccurrences), ctx, guid, instanceID)
 }
 
+// EstimateCount mocks base method.
+func (m *MockFeature) EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCount", ctx, q, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCount indicates an expected call of EstimateCount.
+func (mr *MockFeatureMockRecorder) EstimateCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCount", reflect.TypeOf((*MockFeature)(nil).EstimateCount), ctx, q, tenantID)
+}
+
 // Get mocks base method.
-func (m *MockFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error) {
+func (m *MockFeature) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret := m.ctrl.Call(m, "Get", ctx, q, top, skip, tenantID)
 	ret0, _ := ret[0].([]dto.Device)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+func (mr *MockFeatureMockRecorder) Get(ctx, q, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFeature)(nil).Get), ctx, top, skip, tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFeature)(nil).Get), ctx, q, top, skip, tenantID)
 }
 
 // GetAlarmOccurrences mocks base method.
@@ -317,18 +349,33 @@ func (mr *MockFeatureMockRecorder) GetCertificates(c, guid any) *gomock.Call {
 }
 
 // GetCount mocks base method.
-func (m *MockFeature) GetCount(arg0 context.Context, arg1 string) (int, error) {
+func (m *MockFeature) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCount", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetCount", ctx, q, tenantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCount indicates an expected call of GetCount.
-func (mr *MockFeatureMockRecorder) GetCount(arg0, arg1 any) *gomock.Call {
+func (mr *MockFeatureMockRecorder) GetCount(ctx, q, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockFeature)(nil).GetCount), ctx, q, tenantID)
+}
+
+// GetDeleted mocks base method.
+func (m *MockFeature) GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockFeatureMockRecorder) GetDeleted(ctx, top, skip, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockFeature)(nil).GetCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockFeature)(nil).GetDeleted), ctx, top, skip, tenantID)
 }
 
 // GetDeviceCertificate mocks base method.
@@ -452,6 +499,21 @@ func (mr *MockFeatureMockRecorder) GetKVMScreenSettings(c, guid any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKVMScreenSettings", reflect.TypeOf((*MockFeature)(nil).GetKVMScreenSettings), c, guid)
 }
 
+// GetKVMScreenshot mocks base method.
+func (m *MockFeature) GetKVMScreenshot(c context.Context, guid string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKVMScreenshot", c, guid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKVMScreenshot indicates an expected call of GetKVMScreenshot.
+func (mr *MockFeatureMockRecorder) GetKVMScreenshot(c, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKVMScreenshot", reflect.TypeOf((*MockFeature)(nil).GetKVMScreenshot), c, guid)
+}
+
 // GetNetworkSettings mocks base method.
 func (m *MockFeature) GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error) {
 	m.ctrl.T.Helper()
@@ -467,6 +529,22 @@ func (mr *MockFeatureMockRecorder) GetNetworkSettings(c, guid any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkSettings", reflect.TypeOf((*MockFeature)(nil).GetNetworkSettings), c, guid)
 }
 
+// GetPage mocks base method.
+func (m *MockFeature) GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]dto.Device, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, q, top, skipToken, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockFeatureMockRecorder) GetPage(ctx, q, top, skipToken, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockFeature)(nil).GetPage), ctx, q, top, skipToken, tenantID)
+}
+
 // GetPowerCapabilities mocks base method.
 func (m *MockFeature) GetPowerCapabilities(ctx context.Context, guid string) (dto.PowerCapabilities, error) {
 	m.ctrl.T.Helper()
@@ -497,6 +575,21 @@ func (mr *MockFeatureMockRecorder) GetPowerState(ctx, guid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockFeature)(nil).GetPowerState), ctx, guid)
 }
 
+// GetRedirectionSessions mocks base method.
+func (m *MockFeature) GetRedirectionSessions(c context.Context, guid string) ([]dto.RedirectionSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectionSessions", c, guid)
+	ret0, _ := ret[0].([]dto.RedirectionSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedirectionSessions indicates an expected call of GetRedirectionSessions.
+func (mr *MockFeatureMockRecorder) GetRedirectionSessions(c, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectionSessions", reflect.TypeOf((*MockFeature)(nil).GetRedirectionSessions), c, guid)
+}
+
 // GetTLSSettingData mocks base method.
 func (m *MockFeature) GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error) {
 	m.ctrl.T.Helper()
@@ -558,18 +651,104 @@ func (mr *MockFeatureMockRecorder) Insert(ctx, d any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFeature)(nil).Insert), ctx, d)
 }
 
+// IssueRedirectionToken mocks base method.
+func (m *MockFeature) IssueRedirectionToken(c context.Context, guid, user string, modes []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueRedirectionToken", c, guid, user, modes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueRedirectionToken indicates an expected call of IssueRedirectionToken.
+func (mr *MockFeatureMockRecorder) IssueRedirectionToken(c, guid, user, modes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueRedirectionToken", reflect.TypeOf((*MockFeature)(nil).IssueRedirectionToken), c, guid, user, modes)
+}
+
+// PromoteRedirectionViewer mocks base method.
+func (m *MockFeature) PromoteRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteRedirectionViewer", c, guid, mode, viewerID, viewerToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PromoteRedirectionViewer indicates an expected call of PromoteRedirectionViewer.
+func (mr *MockFeatureMockRecorder) PromoteRedirectionViewer(c, guid, mode, viewerID, viewerToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteRedirectionViewer", reflect.TypeOf((*MockFeature)(nil).PromoteRedirectionViewer), c, guid, mode, viewerID, viewerToken)
+}
+
+// Purge mocks base method.
+func (m *MockFeature) Purge(ctx context.Context, guid, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, guid, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockFeatureMockRecorder) Purge(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockFeature)(nil).Purge), ctx, guid, tenantID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockFeature) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockFeatureMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockFeature)(nil).PurgeDeleted), ctx, before)
+}
+
 // Redirect mocks base method.
-func (m *MockFeature) Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error {
+func (m *MockFeature) Redirect(ctx context.Context, conn devices.WebSocketConn, guid, mode, viewerToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Redirect", ctx, conn, guid, mode)
+	ret := m.ctrl.Call(m, "Redirect", ctx, conn, guid, mode, viewerToken)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Redirect indicates an expected call of Redirect.
-func (mr *MockFeatureMockRecorder) Redirect(ctx, conn, guid, mode any) *gomock.Call {
+func (mr *MockFeatureMockRecorder) Redirect(ctx, conn, guid, mode, viewerToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockFeature)(nil).Redirect), ctx, conn, guid, mode, viewerToken)
+}
+
+// Restore mocks base method.
+func (m *MockFeature) Restore(ctx context.Context, guid, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, guid, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockFeatureMockRecorder) Restore(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockFeature)(nil).Restore), ctx, guid, tenantID)
+}
+
+// RevokeRedirectionViewer mocks base method.
+func (m *MockFeature) RevokeRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRedirectionViewer", c, guid, mode, viewerID, viewerToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRedirectionViewer indicates an expected call of RevokeRedirectionViewer.
+func (mr *MockFeatureMockRecorder) RevokeRedirectionViewer(c, guid, mode, viewerID, viewerToken any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockFeature)(nil).Redirect), ctx, conn, guid, mode)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRedirectionViewer", reflect.TypeOf((*MockFeature)(nil).RevokeRedirectionViewer), c, guid, mode, viewerID, viewerToken)
 }
 
 // SendConsentCode mocks base method.
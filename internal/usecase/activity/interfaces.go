@@ -0,0 +1,25 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, filter entity.ActivityFilter) (int, error)
+		Get(ctx context.Context, filter entity.ActivityFilter, top, skip int) ([]entity.ActivityEntry, error)
+		GetPage(ctx context.Context, filter entity.ActivityFilter, top int, skipToken string) ([]entity.ActivityEntry, string, error)
+		EstimateCount(ctx context.Context, filter entity.ActivityFilter) (int, error)
+		Insert(ctx context.Context, e *entity.ActivityEntry) error
+	}
+	Feature interface {
+		Record(ctx context.Context, e *dto.ActivityEntry) error
+		GetCount(ctx context.Context, filter dto.ActivityFilter, tenantID string) (int, error)
+		Get(ctx context.Context, filter dto.ActivityFilter, top, skip int, tenantID string) ([]dto.ActivityEntry, error)
+		GetPage(ctx context.Context, filter dto.ActivityFilter, top int, skipToken, tenantID string) ([]dto.ActivityEntry, string, error)
+		EstimateCount(ctx context.Context, filter dto.ActivityFilter, tenantID string) (int, error)
+	}
+)
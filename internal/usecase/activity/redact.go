@@ -0,0 +1,79 @@
+package activity
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the value of secret parameters.
+const Redacted = "[REDACTED]"
+
+// maxParametersLength caps what is kept of a request, certificate uploads can be large.
+const maxParametersLength = 16 * 1024
+
+// secretMarkers are matched case-insensitively against parameter names. Certificates are included because
+// provisioning certificates carry their private key.
+var secretMarkers = []string{"password", "passphrase", "secret", "token", "psk", "key", "cert"}
+
+// Parameters renders the body and query of a request as JSON with secrets redacted. Bodies that are not
+// JSON are left out.
+func Parameters(body []byte, query url.Values) string {
+	params := map[string]any{}
+
+	var decoded any
+	if len(body) > 0 && json.Unmarshal(body, &decoded) == nil {
+		params["body"] = redact(decoded)
+	}
+
+	if len(query) > 0 {
+		q := map[string]any{}
+		for name, values := range query {
+			q[name] = strings.Join(values, ",")
+		}
+
+		params["query"] = redact(q)
+	}
+
+	if len(params) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil || len(data) > maxParametersLength {
+		return `{"truncated":true}`
+	}
+
+	return string(data)
+}
+
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, nested := range v {
+			if isSecret(name) {
+				v[name] = Redacted
+			} else {
+				v[name] = redact(nested)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+
+	return value
+}
+
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, marker := range secretMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+
+	return false
+}
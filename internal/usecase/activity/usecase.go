@@ -0,0 +1,165 @@
+package activity
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+
+	// timestampFormat has a fixed width so stored timestamps sort and compare as strings.
+	timestampFormat = "2006-01-02T15:04:05.000Z"
+
+	idLength = 16
+)
+
+// UseCase -.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+}
+
+// New -.
+func New(r Repository, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  log,
+	}
+}
+
+var (
+	ErrActivityUseCase = consoleerrors.CreateConsoleError("ActivityUseCase")
+	ErrDatabase        = sqldb.DatabaseError{Console: ErrActivityUseCase}
+)
+
+// Record stores an entry of the audit trail. The id and, when missing, the timestamp are filled in.
+func (uc *UseCase) Record(ctx context.Context, d *dto.ActivityEntry) error {
+	id := make([]byte, idLength)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+
+	if d.Timestamp.IsZero() {
+		d.Timestamp = time.Now()
+	}
+
+	d.ID = hex.EncodeToString(id)
+
+	e := &entity.ActivityEntry{
+		ID:         d.ID,
+		Timestamp:  formatTimestamp(d.Timestamp),
+		Actor:      d.Actor,
+		APIKeyID:   d.APIKeyID,
+		SourceIP:   d.SourceIP,
+		Action:     d.Action,
+		Target:     d.Target,
+		Parameters: d.Parameters,
+		Status:     d.Status,
+		Result:     d.Result,
+		TenantID:   d.TenantID,
+	}
+
+	if err := uc.repo.Insert(ctx, e); err != nil {
+		return ErrDatabase.Wrap("Record", "uc.repo.Insert", err)
+	}
+
+	return nil
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, filter dto.ActivityFilter, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, toEntityFilter(filter, tenantID))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the matching entries, newest first.
+func (uc *UseCase) Get(ctx context.Context, filter dto.ActivityFilter, top, skip int, tenantID string) ([]dto.ActivityEntry, error) {
+	data, err := uc.repo.Get(ctx, toEntityFilter(filter, tenantID), top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	return toDTOs(data), nil
+}
+
+// GetPage returns a page of the matching entries, newest first, and the token of the next one, which is empty on
+// the last page.
+func (uc *UseCase) GetPage(ctx context.Context, filter dto.ActivityFilter, top int, skipToken, tenantID string) ([]dto.ActivityEntry, string, error) {
+	data, next, err := uc.repo.GetPage(ctx, toEntityFilter(filter, tenantID), top, skipToken)
+	if err != nil {
+		return nil, "", ErrDatabase.Wrap("GetPage", "uc.repo.GetPage", err)
+	}
+
+	return toDTOs(data), next, nil
+}
+
+// EstimateCount returns about how many entries match, cheaper than GetCount on a long audit trail.
+func (uc *UseCase) EstimateCount(ctx context.Context, filter dto.ActivityFilter, tenantID string) (int, error) {
+	count, err := uc.repo.EstimateCount(ctx, toEntityFilter(filter, tenantID))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("EstimateCount", "uc.repo.EstimateCount", err)
+	}
+
+	return count, nil
+}
+
+func toDTOs(data []entity.ActivityEntry) []dto.ActivityEntry {
+	d1 := make([]dto.ActivityEntry, len(data))
+
+	for i := range data {
+		timestamp, _ := time.Parse(timestampFormat, data[i].Timestamp)
+
+		d1[i] = dto.ActivityEntry{
+			ID:         data[i].ID,
+			Timestamp:  timestamp,
+			Actor:      data[i].Actor,
+			APIKeyID:   data[i].APIKeyID,
+			SourceIP:   data[i].SourceIP,
+			Action:     data[i].Action,
+			Target:     data[i].Target,
+			Parameters: data[i].Parameters,
+			Status:     data[i].Status,
+			Result:     data[i].Result,
+			TenantID:   data[i].TenantID,
+		}
+	}
+
+	return d1
+}
+
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampFormat)
+}
+
+func toEntityFilter(filter dto.ActivityFilter, tenantID string) entity.ActivityFilter {
+	f := entity.ActivityFilter{
+		Actor:    filter.Actor,
+		Action:   filter.Action,
+		Target:   filter.Target,
+		Result:   filter.Result,
+		TenantID: tenantID,
+	}
+
+	if !filter.From.IsZero() {
+		f.From = formatTimestamp(filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		f.To = formatTimestamp(filter.To)
+	}
+
+	return f
+}
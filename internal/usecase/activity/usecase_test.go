@@ -0,0 +1,135 @@
+package activity_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/activity"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func activityTest(t *testing.T) (*activity.UseCase, *mocks.MockActivityRepository) {
+	t.Helper()
+
+	repo := mocks.NewMockActivityRepository(gomock.NewController(t))
+
+	return activity.New(repo, logger.New("error")), repo
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := activityTest(t)
+
+	var stored *entity.ActivityEntry
+
+	repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.ActivityEntry) error {
+		stored = e
+
+		return nil
+	})
+
+	entry := &dto.ActivityEntry{
+		Timestamp: time.Date(2025, 3, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600)),
+		Actor:     "jdoe",
+		Action:    "POST /api/v1/amt/power/action/:guid",
+		Target:    "guid=123",
+		Status:    200,
+		Result:    activity.ResultSuccess,
+	}
+
+	require.NoError(t, useCase.Record(context.Background(), entry))
+	require.Len(t, entry.ID, 32)
+	require.Equal(t, entry.ID, stored.ID)
+	require.Equal(t, "2025-03-01T09:00:00.000Z", stored.Timestamp)
+	require.Equal(t, "guid=123", stored.Target)
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := activityTest(t)
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().
+		Get(context.Background(), entity.ActivityFilter{Actor: "jdoe", From: "2025-03-01T00:00:00.000Z", TenantID: "t1"}, 10, 0).
+		Return([]entity.ActivityEntry{{ID: "a", Timestamp: "2025-03-01T09:00:00.000Z", Actor: "jdoe", Status: 403, Result: activity.ResultFailure}}, nil)
+
+	entries, err := useCase.Get(context.Background(), dto.ActivityFilter{Actor: "jdoe", From: from}, 10, 0, "t1")
+	require.NoError(t, err)
+	require.Equal(t, []dto.ActivityEntry{{
+		ID:        "a",
+		Timestamp: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+		Actor:     "jdoe",
+		Status:    403,
+		Result:    activity.ResultFailure,
+	}}, entries)
+}
+
+func TestGetPage(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := activityTest(t)
+
+	repo.EXPECT().
+		GetPage(context.Background(), entity.ActivityFilter{Result: activity.ResultFailure, TenantID: "t1"}, 1, "token").
+		Return([]entity.ActivityEntry{{ID: "a", Timestamp: "2025-03-01T09:00:00.000Z", Result: activity.ResultFailure}}, "next", nil)
+
+	entries, next, err := useCase.GetPage(context.Background(), dto.ActivityFilter{Result: activity.ResultFailure}, 1, "token", "t1")
+	require.NoError(t, err)
+	require.Equal(t, []dto.ActivityEntry{{ID: "a", Timestamp: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), Result: activity.ResultFailure}}, entries)
+	require.Equal(t, "next", next)
+
+	repo.EXPECT().EstimateCount(context.Background(), entity.ActivityFilter{TenantID: "t1"}).Return(0, activity.ErrDatabase)
+
+	_, err = useCase.EstimateCount(context.Background(), dto.ActivityFilter{}, "t1")
+	require.ErrorAs(t, err, &activity.ErrDatabase)
+}
+
+func TestParameters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		body     string
+		query    url.Values
+		expected string
+	}{
+		{
+			name:     "nothing to record",
+			expected: "",
+		},
+		{
+			name:     "nested secrets are redacted",
+			body:     `{"profileName":"p1","amtPassword":"P@ssw0rd","wifiConfigs":[{"pskPassphrase":"x","priority":1}]}`,
+			expected: `{"body":{"amtPassword":"[REDACTED]","profileName":"p1","wifiConfigs":[{"priority":1,"pskPassphrase":"[REDACTED]"}]}}`,
+		},
+		{
+			name:     "query is recorded",
+			query:    url.Values{"mode": {"kvm"}, "token": {"abc"}},
+			expected: `{"query":{"mode":"kvm","token":"[REDACTED]"}}`,
+		},
+		{
+			name:     "bodies that are not json are left out",
+			body:     "not json",
+			expected: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, activity.Parameters([]byte(tc.body), tc.query))
+		})
+	}
+}
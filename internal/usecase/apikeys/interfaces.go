@@ -0,0 +1,28 @@
+package apikeys
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.APIKey, error)
+		GetByID(ctx context.Context, id string) (*entity.APIKey, error)
+		Delete(ctx context.Context, id, tenantID string) (bool, error)
+		DeleteByOwner(ctx context.Context, owner string) (int, error)
+		Insert(ctx context.Context, k *entity.APIKey) error
+		UpdateLastUsed(ctx context.Context, id, lastUsedAt string) error
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.APIKey, error)
+		Delete(ctx context.Context, id, tenantID string) error
+		DeleteByOwner(ctx context.Context, owner string) error
+		Insert(ctx context.Context, k *dto.APIKey) (*dto.APIKey, error)
+		Authenticate(ctx context.Context, key string) (*dto.APIKey, error)
+	}
+)
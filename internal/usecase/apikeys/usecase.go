@@ -0,0 +1,242 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+const (
+	// KeyPrefix marks API keys so they can be told apart from JWTs in the Authorization header.
+	KeyPrefix = "cak_"
+
+	idLength     = 8
+	secretLength = 32
+
+	// lastUsedResolution limits how often using a key writes to the database.
+	lastUsedResolution = time.Minute
+)
+
+// UseCase -.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+}
+
+// New -.
+func New(r Repository, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  log,
+	}
+}
+
+var (
+	ErrAPIKeysUseCase = consoleerrors.CreateConsoleError("APIKeysUseCase")
+	ErrDatabase       = sqldb.DatabaseError{Console: ErrAPIKeysUseCase}
+	ErrNotFound       = sqldb.NotFoundError{Console: ErrAPIKeysUseCase}
+	ErrValidation     = dto.NotValidError{Console: ErrAPIKeysUseCase}
+	ErrInvalidKey     = errors.New("invalid api key")
+	ErrExpiredKey     = errors.New("api key has expired")
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.APIKey, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.APIKey, len(data))
+
+	for i := range data {
+		d1[i] = *entityToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+// Delete revokes a key, requests using it are rejected from then on.
+func (uc *UseCase) Delete(ctx context.Context, id, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, id, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByOwner revokes every key of owner, whose keys must not outlive the user or its sessions. Usernames
+// are unique across tenants, so the keys are revoked whichever tenant they were created in.
+func (uc *UseCase) DeleteByOwner(ctx context.Context, owner string) error {
+	if _, err := uc.repo.DeleteByOwner(ctx, owner); err != nil {
+		return ErrDatabase.Wrap("DeleteByOwner", "uc.repo.DeleteByOwner", err)
+	}
+
+	return nil
+}
+
+// Insert creates a key for k.Owner. The key itself is returned once and only its hash is stored.
+func (uc *UseCase) Insert(ctx context.Context, k *dto.APIKey) (*dto.APIKey, error) {
+	for _, scope := range k.Scopes {
+		if !users.IsPermission(scope) {
+			return nil, ErrValidation.Wrap("Insert", "users.IsPermission", errors.New("unknown scope "+scope))
+		}
+	}
+
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		return nil, ErrValidation.Wrap("Insert", "ExpiresAt", errors.New("expiry must be in the future"))
+	}
+
+	id, err := randomBytes(idLength)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomBytes(secretLength)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entity.APIKey{
+		ID:        hex.EncodeToString(id),
+		Name:      k.Name,
+		Owner:     k.Owner,
+		Scopes:    strings.Join(k.Scopes, ","),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		TenantID:  k.TenantID,
+	}
+
+	if k.ExpiresAt != nil {
+		e.ExpiresAt = k.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	secretString := base64.RawURLEncoding.EncodeToString(secret)
+	e.KeyHash = hashSecret(secretString)
+
+	if err := uc.repo.Insert(ctx, e); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	created := entityToDTO(e)
+	created.Key = KeyPrefix + e.ID + "_" + secretString
+
+	return created, nil
+}
+
+// Authenticate resolves the key presented by a client and records that it was used.
+func (uc *UseCase) Authenticate(ctx context.Context, key string) (*dto.APIKey, error) {
+	id, secret, ok := parseKey(key)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+
+	e, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Authenticate", "uc.repo.GetByID", err)
+	}
+
+	if e == nil || subtle.ConstantTimeCompare([]byte(e.KeyHash), []byte(hashSecret(secret))) != 1 {
+		return nil, ErrInvalidKey
+	}
+
+	k := entityToDTO(e)
+	now := time.Now()
+
+	if k.ExpiresAt != nil && now.After(*k.ExpiresAt) {
+		return nil, ErrExpiredKey
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= lastUsedResolution {
+		if err := uc.repo.UpdateLastUsed(ctx, id, now.UTC().Format(time.RFC3339)); err != nil {
+			uc.log.Warn("apikeys - Authenticate - failed to record last use: " + err.Error())
+		}
+	}
+
+	return k, nil
+}
+
+// IsKey reports whether the bearer token looks like an API key rather than a JWT.
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, KeyPrefix)
+}
+
+func parseKey(key string) (id, secret string, ok bool) {
+	rest, ok := strings.CutPrefix(key, KeyPrefix)
+	if !ok || len(rest) <= idLength*2+1 || rest[idLength*2] != '_' {
+		return "", "", false
+	}
+
+	return rest[:idLength*2], rest[idLength*2+1:], true
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func parseTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func entityToDTO(e *entity.APIKey) *dto.APIKey {
+	k := &dto.APIKey{
+		ID:         e.ID,
+		Name:       e.Name,
+		Owner:      e.Owner,
+		Scopes:     strings.Split(e.Scopes, ","),
+		ExpiresAt:  parseTime(e.ExpiresAt),
+		LastUsedAt: parseTime(e.LastUsedAt),
+		TenantID:   e.TenantID,
+	}
+
+	if created := parseTime(e.CreatedAt); created != nil {
+		k.CreatedAt = *created
+	}
+
+	return k
+}
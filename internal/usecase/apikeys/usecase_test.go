@@ -0,0 +1,140 @@
+package apikeys_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/apikeys"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func apiKeysTest(t *testing.T) (*apikeys.UseCase, *mocks.MockAPIKeysRepository) {
+	t.Helper()
+
+	repo := mocks.NewMockAPIKeysRepository(gomock.NewController(t))
+
+	return apikeys.New(repo, logger.New("error")), repo
+}
+
+// createKey inserts a key and returns it along with the stored row.
+func createKey(t *testing.T, useCase *apikeys.UseCase, repo *mocks.MockAPIKeysRepository, expiresAt *time.Time) (*dto.APIKey, *entity.APIKey) {
+	t.Helper()
+
+	stored := &entity.APIKey{}
+
+	repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, k *entity.APIKey) error {
+		*stored = *k
+
+		return nil
+	})
+
+	created, err := useCase.Insert(context.Background(), &dto.APIKey{Name: "ci-lab", Owner: "jdoe", Scopes: []string{"devices:read", "power:write"}, ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	return created, stored
+}
+
+func TestInsertAndAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := apiKeysTest(t)
+
+	created, stored := createKey(t, useCase, repo, nil)
+	require.True(t, apikeys.IsKey(created.Key))
+	require.NotContains(t, stored.KeyHash, created.Key)
+	require.Equal(t, "devices:read,power:write", stored.Scopes)
+
+	repo.EXPECT().GetByID(context.Background(), created.ID).Return(stored, nil).Times(2)
+	repo.EXPECT().UpdateLastUsed(context.Background(), created.ID, gomock.Any()).Return(nil)
+
+	key, err := useCase.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err)
+	require.Equal(t, "jdoe", key.Owner)
+	require.Equal(t, []string{"devices:read", "power:write"}, key.Scopes)
+
+	_, err = useCase.Authenticate(context.Background(), created.Key+"x")
+	require.ErrorIs(t, err, apikeys.ErrInvalidKey)
+
+	_, err = useCase.Authenticate(context.Background(), "not-a-key")
+	require.ErrorIs(t, err, apikeys.ErrInvalidKey)
+}
+
+func TestAuthenticateRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := apiKeysTest(t)
+
+	created, stored := createKey(t, useCase, repo, nil)
+	stored.LastUsedAt = time.Now().UTC().Format(time.RFC3339)
+
+	// used a moment ago, last use is not written again
+	repo.EXPECT().GetByID(context.Background(), created.ID).Return(stored, nil)
+
+	_, err := useCase.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err)
+}
+
+func TestAuthenticateExpired(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := apiKeysTest(t)
+
+	expiresAt := time.Now().Add(time.Hour)
+	created, stored := createKey(t, useCase, repo, &expiresAt)
+	stored.ExpiresAt = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	repo.EXPECT().GetByID(context.Background(), created.ID).Return(stored, nil)
+
+	_, err := useCase.Authenticate(context.Background(), created.Key)
+	require.ErrorIs(t, err, apikeys.ErrExpiredKey)
+}
+
+func TestInsertValidation(t *testing.T) {
+	t.Parallel()
+
+	useCase, _ := apiKeysTest(t)
+	past := time.Now().Add(-time.Hour)
+
+	for _, key := range []dto.APIKey{
+		{Name: "bad scope", Scopes: []string{"devices:delete"}},
+		{Name: "expired", Scopes: []string{"devices:read"}, ExpiresAt: &past},
+	} {
+		_, err := useCase.Insert(context.Background(), &key)
+
+		var validationErr dto.NotValidError
+		require.ErrorAs(t, err, &validationErr)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := apiKeysTest(t)
+
+	repo.EXPECT().Delete(context.Background(), "id", "").Return(false, nil)
+
+	require.ErrorIs(t, useCase.Delete(context.Background(), "id", ""), apikeys.ErrNotFound)
+}
+
+func TestDeleteByOwner(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := apiKeysTest(t)
+
+	// a user without keys is no error
+	repo.EXPECT().DeleteByOwner(context.Background(), "jdoe").Return(0, nil)
+
+	require.NoError(t, useCase.DeleteByOwner(context.Background(), "jdoe"))
+
+	repo.EXPECT().DeleteByOwner(context.Background(), "jdoe").Return(0, errors.New("db down"))
+
+	require.ErrorAs(t, useCase.DeleteByOwner(context.Background(), "jdoe"), &apikeys.ErrDatabase)
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLoginMaxAttempts     = 5
+	defaultLoginLockoutDuration = 15 * time.Minute
+
+	// firstLoginDelay is the wait after the second failed attempt, it doubles with every further failure.
+	firstLoginDelay = time.Second
+)
+
+// failedLogins tracks the failed attempts of one username.
+type failedLogins struct {
+	count      int
+	last       time.Time
+	retryAfter time.Time
+}
+
+// attemptTracker slows down and then locks out repeated failed logins of a username. Attempts are tracked in
+// memory, per console instance, and forgotten once the lockout duration passed without a failure.
+type attemptTracker struct {
+	mu       sync.Mutex
+	failures map[string]*failedLogins
+	now      func() time.Time
+}
+
+func newAttemptTracker() *attemptTracker {
+	return &attemptTracker{
+		failures: make(map[string]*failedLogins),
+		now:      time.Now,
+	}
+}
+
+// allowed returns how long the username has to wait before it may try again, zero when it may try now.
+func (t *attemptTracker) allowed(username string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, ok := t.failures[strings.ToLower(username)]
+	if !ok {
+		return 0
+	}
+
+	if wait := f.retryAfter.Sub(t.now()); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
+
+// failed records a failed attempt and returns how long the username has to wait before the next one.
+func (t *attemptTracker) failed(username string, maxAttempts int, lockout time.Duration) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	for name, f := range t.failures {
+		if now.Sub(f.last) > lockout && now.After(f.retryAfter) {
+			delete(t.failures, name)
+		}
+	}
+
+	key := strings.ToLower(username)
+
+	f, ok := t.failures[key]
+	if !ok {
+		f = &failedLogins{}
+		t.failures[key] = f
+	}
+
+	// a lockout that has passed starts the count again
+	if f.count >= maxAttempts {
+		f.count = 0
+	}
+
+	f.count++
+	f.last = now
+
+	var wait time.Duration
+
+	switch {
+	case f.count >= maxAttempts:
+		wait = lockout
+	case f.count > 1:
+		wait = min(firstLoginDelay<<(f.count-2), lockout)
+	}
+
+	f.retryAfter = now.Add(wait)
+
+	return wait
+}
+
+func (t *attemptTracker) succeeded(username string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failures, strings.ToLower(username))
+}
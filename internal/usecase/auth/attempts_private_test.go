@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttemptTracker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newAttemptTracker()
+	tracker.now = func() time.Time { return now }
+
+	const lockout = 15 * time.Minute
+
+	// the delay doubles with every failure until the lockout
+	require.Equal(t, time.Duration(0), tracker.failed("jdoe", 4, lockout))
+	require.Equal(t, time.Duration(0), tracker.allowed("jdoe"))
+	require.Equal(t, time.Second, tracker.failed("jdoe", 4, lockout))
+	require.Equal(t, time.Second, tracker.allowed("JDOE"))
+
+	now = now.Add(time.Second)
+	require.Equal(t, 2*time.Second, tracker.failed("jdoe", 4, lockout))
+
+	now = now.Add(2 * time.Second)
+	require.Equal(t, lockout, tracker.failed("jdoe", 4, lockout))
+	require.Equal(t, time.Duration(0), tracker.allowed("other"))
+
+	now = now.Add(lockout - time.Minute)
+	require.Equal(t, time.Minute, tracker.allowed("jdoe"))
+
+	// after the lockout the count starts again
+	now = now.Add(time.Minute)
+	require.Equal(t, time.Duration(0), tracker.allowed("jdoe"))
+	require.Equal(t, time.Duration(0), tracker.failed("jdoe", 4, lockout))
+
+	// a successful login forgets the failures
+	require.Equal(t, time.Second, tracker.failed("jdoe", 4, lockout))
+	tracker.succeeded("jdoe")
+	require.Equal(t, time.Duration(0), tracker.allowed("jdoe"))
+	require.Equal(t, time.Duration(0), tracker.failed("jdoe", 4, lockout))
+}
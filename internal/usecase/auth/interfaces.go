@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetRefreshToken(ctx context.Context, id string) (*entity.RefreshToken, error)
+		InsertRefreshToken(ctx context.Context, t *entity.RefreshToken) error
+		MarkRefreshTokenUsed(ctx context.Context, id string) (bool, error)
+		DeleteRefreshTokenFamily(ctx context.Context, familyID string) error
+		DeleteRefreshTokens(ctx context.Context, subject string) error
+		InsertRevocation(ctx context.Context, r *entity.TokenRevocation) error
+		IsRevoked(ctx context.Context, tokenID, subject, issuedAt, now string) (bool, error)
+		DeleteExpired(ctx context.Context, now string) error
+	}
+	Feature interface {
+		LoginAllowed(username string) time.Duration
+		LoginFailed(username string) time.Duration
+		LoginSucceeded(username string)
+		IssueRefreshToken(ctx context.Context, s *dto.LoginSession) (string, error)
+		Refresh(ctx context.Context, refreshToken string) (*dto.LoginSession, string, error)
+		RevokeRefreshToken(ctx context.Context, refreshToken string) error
+		RevokeToken(ctx context.Context, tokenID, subject string, expiresAt time.Time) error
+		RevokeSubject(ctx context.Context, subject string) error
+		IsRevoked(ctx context.Context, tokenID, subject string, issuedAt time.Time) (bool, error)
+	}
+)
@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+const (
+	defaultRefreshTokenExpiration = 7 * 24 * time.Hour
+
+	idLength     = 16
+	secretLength = 32
+)
+
+// UseCase guards logins against guessing and keeps track of refresh tokens and revoked access tokens.
+type UseCase struct {
+	repo     Repository
+	log      logger.Interface
+	attempts *attemptTracker
+}
+
+// New -.
+func New(r Repository, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:     r,
+		log:      log,
+		attempts: newAttemptTracker(),
+	}
+}
+
+var (
+	ErrAuthUseCase         = consoleerrors.CreateConsoleError("AuthUseCase")
+	ErrDatabase            = sqldb.DatabaseError{Console: ErrAuthUseCase}
+	ErrValidation          = dto.NotValidError{Console: ErrAuthUseCase}
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
+// LoginAllowed returns how long logins of username are held back after failed attempts, zero when it may log in.
+func (uc *UseCase) LoginAllowed(username string) time.Duration {
+	return uc.attempts.allowed(username)
+}
+
+// LoginFailed records a failed login of username and returns how long its next attempt is held back.
+func (uc *UseCase) LoginFailed(username string) time.Duration {
+	maxAttempts := config.ConsoleConfig.LoginMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultLoginMaxAttempts
+	}
+
+	lockout := config.ConsoleConfig.LoginLockoutDuration
+	if lockout <= 0 {
+		lockout = defaultLoginLockoutDuration
+	}
+
+	wait := uc.attempts.failed(username, maxAttempts, lockout)
+	if wait >= lockout {
+		uc.log.Warn(fmt.Sprintf("logins of %q are locked out for %s after %d failed attempts", username, wait, maxAttempts))
+	}
+
+	return wait
+}
+
+// LoginSucceeded forgets the failed attempts of username.
+func (uc *UseCase) LoginSucceeded(username string) {
+	uc.attempts.succeeded(username)
+}
+
+// IssueRefreshToken starts a new refresh token family for the session. The token itself is returned and only
+// its hash is stored.
+func (uc *UseCase) IssueRefreshToken(ctx context.Context, s *dto.LoginSession) (string, error) {
+	familyID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.repo.DeleteExpired(ctx, formatTime(time.Now())); err != nil {
+		uc.log.Warn("auth - IssueRefreshToken - failed to remove expired tokens: " + err.Error())
+	}
+
+	return uc.issueRefreshToken(ctx, familyID, s)
+}
+
+// Refresh exchanges a refresh token for a new one of the same family. Each token can be used once, presenting a
+// used token again means it was stolen, so the whole family is revoked.
+func (uc *UseCase) Refresh(ctx context.Context, refreshToken string) (*dto.LoginSession, string, error) {
+	t, err := uc.lookupRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	first := !t.Used
+	if first {
+		first, err = uc.repo.MarkRefreshTokenUsed(ctx, t.ID)
+		if err != nil {
+			return nil, "", ErrDatabase.Wrap("Refresh", "uc.repo.MarkRefreshTokenUsed", err)
+		}
+	}
+
+	if !first {
+		uc.log.Warn(fmt.Sprintf("refresh token of %q was used twice, revoking its family", t.Subject))
+
+		if err := uc.repo.DeleteRefreshTokenFamily(ctx, t.FamilyID); err != nil {
+			return nil, "", ErrDatabase.Wrap("Refresh", "uc.repo.DeleteRefreshTokenFamily", err)
+		}
+
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	if expiresAt, err := time.Parse(time.RFC3339, t.ExpiresAt); err != nil || time.Now().After(expiresAt) {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	s := &dto.LoginSession{Subject: t.Subject, Role: t.Role, TenantID: t.TenantID}
+
+	next, err := uc.issueRefreshToken(ctx, t.FamilyID, s)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s, next, nil
+}
+
+// RevokeRefreshToken revokes the family of a refresh token, it is used on logout.
+func (uc *UseCase) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	t, err := uc.lookupRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteRefreshTokenFamily(ctx, t.FamilyID); err != nil {
+		return ErrDatabase.Wrap("RevokeRefreshToken", "uc.repo.DeleteRefreshTokenFamily", err)
+	}
+
+	return nil
+}
+
+// RevokeToken rejects the access token tokenID until it expires.
+func (uc *UseCase) RevokeToken(ctx context.Context, tokenID, subject string, expiresAt time.Time) error {
+	// an empty id would revoke every token of the subject
+	if tokenID == "" {
+		return ErrValidation.Wrap("RevokeToken", "tokenID", errors.New("the token has no id"))
+	}
+
+	return uc.revoke(ctx, &entity.TokenRevocation{
+		TokenID:   tokenID,
+		Subject:   subject,
+		RevokedAt: formatTime(time.Now()),
+		ExpiresAt: formatTime(expiresAt),
+	})
+}
+
+// RevokeSubject logs subject out everywhere: its refresh tokens are removed and every access token issued to it
+// up to now, including the current second, is rejected.
+func (uc *UseCase) RevokeSubject(ctx context.Context, subject string) error {
+	if err := uc.repo.DeleteRefreshTokens(ctx, subject); err != nil {
+		return ErrDatabase.Wrap("RevokeSubject", "uc.repo.DeleteRefreshTokens", err)
+	}
+
+	now := time.Now()
+
+	return uc.revoke(ctx, &entity.TokenRevocation{
+		Subject:   subject,
+		RevokedAt: formatTime(now),
+		// access tokens issued before now are expired by then
+		ExpiresAt: formatTime(now.Add(config.ConsoleConfig.JWTExpiration).Add(time.Second)),
+	})
+}
+
+// IsRevoked reports whether the access token tokenID issued to subject at issuedAt has been revoked.
+func (uc *UseCase) IsRevoked(ctx context.Context, tokenID, subject string, issuedAt time.Time) (bool, error) {
+	revoked, err := uc.repo.IsRevoked(ctx, tokenID, subject, formatTime(issuedAt), formatTime(time.Now()))
+	if err != nil {
+		return false, ErrDatabase.Wrap("IsRevoked", "uc.repo.IsRevoked", err)
+	}
+
+	return revoked, nil
+}
+
+func (uc *UseCase) revoke(ctx context.Context, r *entity.TokenRevocation) error {
+	if err := uc.repo.DeleteExpired(ctx, r.RevokedAt); err != nil {
+		uc.log.Warn("auth - revoke - failed to remove expired tokens: " + err.Error())
+	}
+
+	if err := uc.repo.InsertRevocation(ctx, r); err != nil {
+		return ErrDatabase.Wrap("revoke", "uc.repo.InsertRevocation", err)
+	}
+
+	return nil
+}
+
+func (uc *UseCase) issueRefreshToken(ctx context.Context, familyID string, s *dto.LoginSession) (string, error) {
+	id, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	expiration := config.ConsoleConfig.RefreshTokenExpiration
+	if expiration <= 0 {
+		expiration = defaultRefreshTokenExpiration
+	}
+
+	secretString := base64.RawURLEncoding.EncodeToString(secret)
+
+	t := &entity.RefreshToken{
+		ID:        id,
+		FamilyID:  familyID,
+		Subject:   s.Subject,
+		Role:      s.Role,
+		TokenHash: hashSecret(secretString),
+		ExpiresAt: formatTime(time.Now().Add(expiration)),
+		TenantID:  s.TenantID,
+	}
+
+	if err := uc.repo.InsertRefreshToken(ctx, t); err != nil {
+		return "", ErrDatabase.Wrap("issueRefreshToken", "uc.repo.InsertRefreshToken", err)
+	}
+
+	return id + "." + secretString, nil
+}
+
+func (uc *UseCase) lookupRefreshToken(ctx context.Context, refreshToken string) (*entity.RefreshToken, error) {
+	id, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || len(id) != idLength*2 {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	t, err := uc.repo.GetRefreshToken(ctx, id)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("lookupRefreshToken", "uc.repo.GetRefreshToken", err)
+	}
+
+	if t == nil || subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(hashSecret(secret))) != 1 {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return t, nil
+}
+
+// NewTokenID returns a random id for the jti claim of access tokens and for refresh tokens.
+func NewTokenID() (string, error) {
+	b := make([]byte, idLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
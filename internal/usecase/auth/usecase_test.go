@@ -0,0 +1,113 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/auth"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func authTest(t *testing.T) (*auth.UseCase, *mocks.MockAuthRepository) {
+	t.Helper()
+
+	config.ConsoleConfig = &config.Config{}
+	config.ConsoleConfig.JWTExpiration = time.Hour
+
+	repo := mocks.NewMockAuthRepository(gomock.NewController(t))
+
+	return auth.New(repo, logger.New("error")), repo
+}
+
+func TestRefreshRotation(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	useCase, repo := authTest(t)
+
+	stored := map[string]*entity.RefreshToken{}
+
+	repo.EXPECT().DeleteExpired(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, rt *entity.RefreshToken) error {
+		stored[rt.ID] = rt
+
+		return nil
+	}).Times(2)
+	repo.EXPECT().GetRefreshToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*entity.RefreshToken, error) {
+		return stored[id], nil
+	}).AnyTimes()
+	repo.EXPECT().MarkRefreshTokenUsed(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (bool, error) {
+		stored[id].Used = true
+
+		return true, nil
+	})
+
+	session := &dto.LoginSession{Subject: "jdoe", Role: "viewer", TenantID: "bu-retail"}
+
+	first, err := useCase.IssueRefreshToken(context.Background(), session)
+	require.NoError(t, err)
+
+	refreshed, second, err := useCase.Refresh(context.Background(), first)
+	require.NoError(t, err)
+	require.Equal(t, session, refreshed)
+	require.NotEqual(t, first, second)
+
+	// the second use of a token revokes the family, the token it was rotated into included
+	familyID := ""
+	for _, rt := range stored {
+		familyID = rt.FamilyID
+	}
+
+	repo.EXPECT().DeleteRefreshTokenFamily(gomock.Any(), familyID).Return(nil)
+
+	_, _, err = useCase.Refresh(context.Background(), first)
+	require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+
+	_, _, err = useCase.Refresh(context.Background(), first[:32]+".forged")
+	require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+
+	_, _, err = useCase.Refresh(context.Background(), "not-a-token")
+	require.ErrorIs(t, err, auth.ErrInvalidRefreshToken)
+}
+
+func TestRevocation(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	useCase, repo := authTest(t)
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	issuedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().DeleteExpired(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	repo.EXPECT().InsertRevocation(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *entity.TokenRevocation) error {
+		require.Equal(t, "jti", r.TokenID)
+		require.Equal(t, "2030-01-01T00:00:00Z", r.ExpiresAt)
+
+		return nil
+	})
+
+	require.NoError(t, useCase.RevokeToken(context.Background(), "jti", "jdoe", expiresAt))
+
+	// revoking by an empty id would log the subject out everywhere
+	require.Error(t, useCase.RevokeToken(context.Background(), "", "jdoe", expiresAt))
+
+	repo.EXPECT().DeleteRefreshTokens(gomock.Any(), "jdoe").Return(nil)
+	repo.EXPECT().InsertRevocation(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *entity.TokenRevocation) error {
+		require.Empty(t, r.TokenID)
+		require.Equal(t, "jdoe", r.Subject)
+		require.Greater(t, r.ExpiresAt, r.RevokedAt)
+
+		return nil
+	})
+
+	require.NoError(t, useCase.RevokeSubject(context.Background(), "jdoe"))
+
+	repo.EXPECT().IsRevoked(gomock.Any(), "jti", "jdoe", "2025-01-01T00:00:00Z", gomock.Any()).Return(true, nil)
+
+	revoked, err := useCase.IsRevoked(context.Background(), "jti", "jdoe", issuedAt)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
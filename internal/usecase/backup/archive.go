@@ -0,0 +1,175 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	archiveFormat  = "console-backup"
+	archiveVersion = 1
+
+	kdfScrypt = "scrypt"
+	// scrypt parameters of new archives, archives with more expensive ones are refused
+	scryptN    = 1 << 15
+	scryptR    = 8
+	scryptP    = 1
+	maxScryptN = 1 << 20
+	maxScryptR = 16
+	maxScryptP = 4
+
+	saltLength = 16
+	keyLength  = 32
+)
+
+var (
+	errNotABackup       = errors.New("the file is not a console backup")
+	errArchiveVersion   = errors.New("the backup was made by a newer version of the console")
+	errArchiveKDF       = errors.New("the backup uses an unsupported key derivation")
+	errWrongPassphrase  = errors.New("the passphrase is wrong or the backup is damaged")
+	errArchiveMalformed = errors.New("the contents of the backup are malformed")
+)
+
+// archive is the file a backup is written to. Only the header is readable, the contents are encrypted with
+// AES-256-GCM using a key derived from the passphrase, so the backup does not depend on the encryption key of
+// the console it was made on.
+type archive struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	KDF       kdf       `json:"kdf"`
+	Nonce     []byte    `json:"nonce"`
+	Data      []byte    `json:"data"`
+}
+
+type kdf struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// contents holds the rows of every backed up table with their secrets in plain text.
+type contents struct {
+	Tables map[string][]map[string]interface{} `json:"tables"`
+}
+
+// additionalData binds the header to the encrypted contents.
+func additionalData(a *archive) []byte {
+	return []byte(a.Format + "/" + strconv.Itoa(a.Version))
+}
+
+func aead(passphrase string, k kdf) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), k.Salt, k.N, k.R, k.P, keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(c contents, passphrase string, createdAt time.Time) ([]byte, error) {
+	plainText, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &archive{
+		Format:    archiveFormat,
+		Version:   archiveVersion,
+		CreatedAt: createdAt.UTC(),
+		KDF:       kdf{Name: kdfScrypt, Salt: make([]byte, saltLength), N: scryptN, R: scryptR, P: scryptP},
+	}
+
+	if _, err := rand.Read(a.KDF.Salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := aead(passphrase, a.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(a.Nonce); err != nil {
+		return nil, err
+	}
+
+	a.Data = gcm.Seal(nil, a.Nonce, plainText, additionalData(a))
+
+	return json.MarshalIndent(a, "", "  ")
+}
+
+func open(raw []byte, passphrase string) (contents, error) {
+	var a archive
+	if err := json.Unmarshal(raw, &a); err != nil || a.Format != archiveFormat {
+		return contents{}, errNotABackup
+	}
+
+	if a.Version > archiveVersion {
+		return contents{}, fmt.Errorf("%w: version %d", errArchiveVersion, a.Version)
+	}
+
+	k := a.KDF
+	if k.Name != kdfScrypt || k.N > maxScryptN || k.R > maxScryptR || k.P > maxScryptP {
+		return contents{}, errArchiveKDF
+	}
+
+	gcm, err := aead(passphrase, k)
+	if err != nil {
+		return contents{}, errArchiveKDF
+	}
+
+	if len(a.Nonce) != gcm.NonceSize() {
+		return contents{}, errWrongPassphrase
+	}
+
+	plainText, err := gcm.Open(nil, a.Nonce, a.Data, additionalData(&a))
+	if err != nil {
+		return contents{}, errWrongPassphrase
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(plainText))
+	decoder.UseNumber()
+
+	var c contents
+	if err := decoder.Decode(&c); err != nil {
+		return contents{}, errArchiveMalformed
+	}
+
+	for _, rows := range c.Tables {
+		for _, row := range rows {
+			for column, value := range row {
+				n, ok := value.(json.Number)
+				if !ok {
+					continue
+				}
+
+				if i, err := n.Int64(); err == nil {
+					row[column] = i
+				} else if f, err := n.Float64(); err == nil {
+					row[column] = f
+				} else {
+					return contents{}, errArchiveMalformed
+				}
+			}
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,19 @@
+package backup
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		Dump(ctx context.Context) ([]entity.BackupTable, error)
+		Restore(ctx context.Context, tables []entity.BackupTable, replace bool) error
+	}
+	Feature interface {
+		Backup(ctx context.Context, passphrase string) ([]byte, error)
+		Restore(ctx context.Context, archive []byte, passphrase, mode string, dryRun bool) (*dto.Restore, error)
+	}
+)
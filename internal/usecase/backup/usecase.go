@@ -0,0 +1,252 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// MinPassphraseLength is the length a backup passphrase needs at least.
+const MinPassphraseLength = 12
+
+// UseCase backs up the devices and the provisioning configuration of every tenant and restores them. The
+// secrets are decrypted for the backup and encrypted again with the key of the console a backup is restored on.
+type UseCase struct {
+	repo             Repository
+	log              logger.Interface
+	safeRequirements security.Cryptor
+	now              func() time.Time
+}
+
+// New -.
+func New(r Repository, log logger.Interface, safeRequirements security.Cryptor) *UseCase {
+	return &UseCase{
+		repo:             r,
+		log:              log,
+		safeRequirements: safeRequirements,
+		now:              time.Now,
+	}
+}
+
+var (
+	ErrBackupUseCase = consoleerrors.CreateConsoleError("BackupUseCase")
+	ErrDatabase      = sqldb.DatabaseError{Console: ErrBackupUseCase}
+	ErrValidation    = dto.NotValidError{Console: ErrBackupUseCase}
+	errUnknownTable  = errors.New("the backup holds an unknown table")
+)
+
+// Backup returns the archive of a backup encrypted with passphrase.
+func (uc *UseCase) Backup(ctx context.Context, passphrase string) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, ErrValidation.Wrap("Backup", "len(passphrase)", fmt.Errorf("the passphrase must be at least %d characters long", MinPassphraseLength))
+	}
+
+	tables, err := uc.repo.Dump(ctx)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Backup", "uc.repo.Dump", err)
+	}
+
+	c := contents{Tables: make(map[string][]map[string]interface{}, len(tables))}
+
+	for _, table := range tables {
+		if err := uc.crypt(table, uc.safeRequirements.Decrypt); err != nil {
+			return nil, ErrBackupUseCase.Wrap("Backup", "uc.safeRequirements.Decrypt", err)
+		}
+
+		c.Tables[table.Name] = table.Rows
+	}
+
+	archive, err := seal(c, passphrase, uc.now())
+	if err != nil {
+		return nil, ErrBackupUseCase.Wrap("Backup", "seal", err)
+	}
+
+	return archive, nil
+}
+
+// Restore compares a backup with what is stored and restores it unless dryRun is set. In merge mode the rows
+// of the backup are added or overwrite the stored ones with the same key, in replace mode everything that is
+// backed up is deleted first.
+func (uc *UseCase) Restore(ctx context.Context, archive []byte, passphrase, mode string, dryRun bool) (*dto.Restore, error) {
+	if mode != dto.RestoreMerge && mode != dto.RestoreReplace {
+		return nil, ErrValidation.Wrap("Restore", "mode", fmt.Errorf("unknown restore mode %q", mode))
+	}
+
+	c, err := open(archive, passphrase)
+	if err != nil {
+		return nil, ErrValidation.Wrap("Restore", "open", err)
+	}
+
+	current, err := uc.repo.Dump(ctx)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Restore", "uc.repo.Dump", err)
+	}
+
+	known := make(map[string]bool, len(current))
+	for _, table := range current {
+		known[table.Name] = true
+	}
+
+	for name := range c.Tables {
+		if !known[name] {
+			return nil, ErrValidation.Wrap("Restore", "open", fmt.Errorf("%w: %s", errUnknownTable, name))
+		}
+	}
+
+	result := &dto.Restore{Mode: mode, DryRun: dryRun, Tables: make([]dto.RestoreTableChange, 0, len(current))}
+	restored := make([]entity.BackupTable, 0, len(current))
+
+	for _, table := range current {
+		if err := uc.crypt(table, uc.safeRequirements.Decrypt); err != nil {
+			return nil, ErrBackupUseCase.Wrap("Restore", "uc.safeRequirements.Decrypt", err)
+		}
+
+		backedUp := table
+		backedUp.Rows = c.Tables[table.Name]
+
+		result.Tables = append(result.Tables, changes(table, backedUp, mode == dto.RestoreReplace))
+		restored = append(restored, backedUp)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for _, table := range restored {
+		if err := uc.crypt(table, uc.safeRequirements.Encrypt); err != nil {
+			return nil, ErrBackupUseCase.Wrap("Restore", "uc.safeRequirements.Encrypt", err)
+		}
+	}
+
+	if err := uc.repo.Restore(ctx, restored, mode == dto.RestoreReplace); err != nil {
+		return nil, ErrDatabase.Wrap("Restore", "uc.repo.Restore", err)
+	}
+
+	uc.log.Info("backup restored in " + mode + " mode")
+
+	return result, nil
+}
+
+// crypt replaces every non-empty secret of table with f(secret).
+func (uc *UseCase) crypt(table entity.BackupTable, f func(string) (string, error)) error {
+	for _, row := range table.Rows {
+		for _, column := range table.Secrets {
+			secret, ok := row[column].(string)
+			if !ok || secret == "" {
+				continue
+			}
+
+			value, err := f(secret)
+			if err != nil {
+				return fmt.Errorf("%s.%s of %s: %w", table.Name, column, displayKey(table.Keys, row), err)
+			}
+
+			row[column] = value
+		}
+	}
+
+	return nil
+}
+
+// changes compares the stored rows of a table with the ones of a backup. Stored rows are deleted when
+// everything is replaced or when the backup holds other rows of their owner.
+func changes(stored, backedUp entity.BackupTable, replace bool) dto.RestoreTableChange {
+	change := dto.RestoreTableChange{
+		Table:   stored.Name,
+		Added:   make([]string, 0),
+		Updated: make([]string, 0),
+		Deleted: make([]string, 0),
+	}
+
+	storedRows := make(map[string]map[string]interface{}, len(stored.Rows))
+	for _, row := range stored.Rows {
+		storedRows[rowKey(stored.Keys, row)] = row
+	}
+
+	backedUpRows := make(map[string]bool, len(backedUp.Rows))
+	owners := make(map[string]bool)
+
+	for _, row := range backedUp.Rows {
+		key := rowKey(stored.Keys, row)
+		backedUpRows[key] = true
+
+		if len(stored.Owner) > 0 {
+			owners[rowKey(stored.Owner, row)] = true
+		}
+
+		existing, ok := storedRows[key]
+
+		switch {
+		case !ok:
+			change.Added = append(change.Added, displayKey(stored.Keys, row))
+		case sameRow(existing, row):
+			change.Unchanged++
+		default:
+			change.Updated = append(change.Updated, displayKey(stored.Keys, row))
+		}
+	}
+
+	for _, row := range stored.Rows {
+		if backedUpRows[rowKey(stored.Keys, row)] {
+			continue
+		}
+
+		if replace || (len(stored.Owner) > 0 && owners[rowKey(stored.Owner, row)]) {
+			change.Deleted = append(change.Deleted, displayKey(stored.Keys, row))
+		}
+	}
+
+	return change
+}
+
+// sameRow reports whether the columns of a backed up row the database has hold the stored values.
+func sameRow(stored, backedUp map[string]interface{}) bool {
+	for column, value := range backedUp {
+		current, ok := stored[column]
+		if !ok {
+			continue
+		}
+
+		a, errA := json.Marshal(current)
+		b, errB := json.Marshal(value)
+
+		if errA != nil || errB != nil || string(a) != string(b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func rowKey(columns []string, row map[string]interface{}) string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = fmt.Sprint(row[column])
+	}
+
+	return strings.Join(values, "\x00")
+}
+
+// displayKey joins the key values of a row with slashes, leaving out the empty ones such as the default tenant.
+func displayKey(columns []string, row map[string]interface{}) string {
+	values := make([]string, 0, len(columns))
+
+	for _, column := range columns {
+		if s := fmt.Sprint(row[column]); s != "" && row[column] != nil {
+			values = append(values, s)
+		}
+	}
+
+	return strings.Join(values, "/")
+}
@@ -0,0 +1,118 @@
+package backup_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/backup"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+const passphrase = "correct horse battery staple"
+
+// tables returns the stored tables of a console, the secrets are encrypted with crypto.
+func tables(t *testing.T, crypto *encryption.Crypto, profiles map[string]string, devices ...string) []entity.BackupTable {
+	t.Helper()
+
+	profileTable := entity.BackupTable{
+		Name:    "profiles",
+		Keys:    []string{"profile_name", "tenant_id"},
+		Secrets: []string{"amt_password"},
+		Rows:    make([]map[string]interface{}, 0),
+	}
+
+	for _, name := range []string{"p1", "p2", "p3"} {
+		password, ok := profiles[name]
+		if !ok {
+			continue
+		}
+
+		encrypted, err := crypto.Encrypt(password)
+		require.NoError(t, err)
+
+		profileTable.Rows = append(profileTable.Rows, map[string]interface{}{
+			"profile_name": name, "amt_password": encrypted, "kvm_enabled": true, "tls_mode": int64(1), "tenant_id": "",
+		})
+	}
+
+	deviceTable := entity.BackupTable{Name: "devices", Keys: []string{"guid"}, Secrets: []string{"password"}, Rows: make([]map[string]interface{}, 0)}
+	for _, guid := range devices {
+		deviceTable.Rows = append(deviceTable.Rows, map[string]interface{}{"guid": guid, "password": nil, "tenantid": ""})
+	}
+
+	return []entity.BackupTable{profileTable, deviceTable}
+}
+
+func backupTest(t *testing.T, key string) (*backup.UseCase, *encryption.Crypto, *mocks.MockBackupRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockBackupRepository(mockCtl)
+	crypto := encryption.NewCrypto(key)
+
+	return backup.New(repo, logger.New("error"), crypto), crypto, repo
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	t.Parallel()
+
+	source, sourceCrypto, sourceRepo := backupTest(t, "0123456789abcdef")
+	sourceRepo.EXPECT().Dump(context.Background()).Return(tables(t, sourceCrypto, map[string]string{"p1": "secret1", "p3": "secret3"}, "d1"), nil)
+
+	_, err := source.Backup(context.Background(), "short")
+	require.Error(t, err)
+
+	archive, err := source.Backup(context.Background(), passphrase)
+	require.NoError(t, err)
+	require.NotContains(t, string(archive), "secret1")
+
+	// the target console uses another encryption key
+	target, targetCrypto, targetRepo := backupTest(t, "fedcba9876543210")
+	stored := func() []entity.BackupTable {
+		return tables(t, targetCrypto, map[string]string{"p1": "secret1", "p2": "secret2"})
+	}
+
+	_, err = target.Restore(context.Background(), archive, "wrong passphrase", dto.RestoreMerge, true)
+	require.Error(t, err)
+
+	_, err = target.Restore(context.Background(), archive, passphrase, "append", true)
+	require.Error(t, err)
+
+	targetRepo.EXPECT().Dump(context.Background()).Return(stored(), nil)
+
+	result, err := target.Restore(context.Background(), archive, passphrase, dto.RestoreMerge, true)
+	require.NoError(t, err)
+	require.Equal(t, &dto.Restore{
+		Mode:   dto.RestoreMerge,
+		DryRun: true,
+		Tables: []dto.RestoreTableChange{
+			{Table: "profiles", Added: []string{"p3"}, Updated: []string{}, Deleted: []string{}, Unchanged: 1},
+			{Table: "devices", Added: []string{"d1"}, Updated: []string{}, Deleted: []string{}},
+		},
+	}, result)
+
+	targetRepo.EXPECT().Dump(context.Background()).Return(stored(), nil)
+	targetRepo.EXPECT().Restore(context.Background(), gomock.Any(), true).DoAndReturn(
+		func(_ context.Context, restored []entity.BackupTable, _ bool) error {
+			require.Len(t, restored[0].Rows, 2)
+
+			password, err := targetCrypto.Decrypt(restored[0].Rows[1]["amt_password"].(string))
+			require.NoError(t, err)
+			require.Equal(t, "secret3", password)
+			require.Equal(t, int64(1), restored[0].Rows[1]["tls_mode"])
+
+			return nil
+		})
+
+	result, err = target.Restore(context.Background(), archive, passphrase, dto.RestoreReplace, false)
+	require.NoError(t, err)
+	require.False(t, result.DryRun)
+	require.Equal(t, []string{"p2"}, result.Tables[0].Deleted)
+}
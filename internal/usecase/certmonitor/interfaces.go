@@ -0,0 +1,25 @@
+package certmonitor
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetDomainExpirations(ctx context.Context) ([]entity.Domain, error)
+		GetCIRARootCertificates(ctx context.Context) ([]entity.CIRAConfig, error)
+		GetTLSDevices(ctx context.Context) ([]entity.Device, error)
+	}
+	// DeviceCertificates reads the certificate a device presents, devices.Feature implements it.
+	DeviceCertificates interface {
+		GetDeviceCertificate(ctx context.Context, guid string) (dto.Certificate, error)
+	}
+	Feature interface {
+		Run(ctx context.Context)
+		Check(ctx context.Context) error
+		GetExpiring(ctx context.Context, days int, tenantID string) ([]dto.MonitoredCertificate, error)
+	}
+)
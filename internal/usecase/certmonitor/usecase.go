@@ -0,0 +1,332 @@
+package certmonitor
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+const day = 24 * time.Hour
+
+var defaultWarningDays = []int{60, 30, 7}
+
+// UseCase keeps the state of every monitored certificate as of the last check in memory and logs a warning
+// whenever a certificate reaches another warning threshold, expires or stops matching its pin.
+type UseCase struct {
+	repo    Repository
+	devices DeviceCertificates
+	log     logger.Interface
+	now     func() time.Time
+
+	// checking serializes checks started by the timer and through the API
+	checking sync.Mutex
+
+	mu           sync.RWMutex
+	certificates []dto.MonitoredCertificate
+	warned       map[string]string
+}
+
+// New -.
+func New(r Repository, d DeviceCertificates, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:    r,
+		devices: d,
+		log:     log,
+		now:     time.Now,
+		warned:  make(map[string]string),
+	}
+}
+
+var (
+	ErrCertMonitorUseCase = consoleerrors.CreateConsoleError("CertMonitorUseCase")
+	ErrDatabase           = sqldb.DatabaseError{Console: ErrCertMonitorUseCase}
+	errNoCertificate      = errors.New("no certificate found")
+)
+
+// Run checks the certificates right away and then every configured interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	interval := config.ConsoleConfig.CertMonitor.Interval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.Check(ctx); err != nil {
+			uc.log.Error("certmonitor - Run - " + err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reads every monitored certificate again. A device that cannot be reached keeps the state of its last
+// successful check.
+func (uc *UseCase) Check(ctx context.Context) error {
+	uc.checking.Lock()
+	defer uc.checking.Unlock()
+
+	now := uc.now()
+	certificates := make([]dto.MonitoredCertificate, 0)
+
+	domains, err := uc.repo.GetDomainExpirations(ctx)
+	if err != nil {
+		return ErrDatabase.Wrap("Check", "uc.repo.GetDomainExpirations", err)
+	}
+
+	for i := range domains {
+		notAfter, err := time.Parse(time.RFC3339, domains[i].ExpirationDate)
+		if err != nil {
+			continue
+		}
+
+		certificates = append(certificates, dto.MonitoredCertificate{
+			Source:   dto.CertificateSourceDomain,
+			Name:     domains[i].ProfileName,
+			TenantID: domains[i].TenantID,
+			NotAfter: notAfter,
+		})
+	}
+
+	configs, err := uc.repo.GetCIRARootCertificates(ctx)
+	if err != nil {
+		return ErrDatabase.Wrap("Check", "uc.repo.GetCIRARootCertificates", err)
+	}
+
+	for i := range configs {
+		cert, err := parseCertificate(configs[i].MPSRootCertificate)
+		if err != nil {
+			uc.log.Warn(fmt.Sprintf("certmonitor - the MPS root certificate of CIRA config %q cannot be read: %v", configs[i].ConfigName, err))
+
+			continue
+		}
+
+		sum := sha256.Sum256(cert.Raw)
+
+		certificates = append(certificates, dto.MonitoredCertificate{
+			Source:            dto.CertificateSourceCIRAConfig,
+			Name:              configs[i].ConfigName,
+			TenantID:          configs[i].TenantID,
+			CommonName:        cert.Subject.CommonName,
+			SHA256Fingerprint: hex.EncodeToString(sum[:]),
+			NotAfter:          cert.NotAfter,
+		})
+	}
+
+	if config.ConsoleConfig.CertMonitor.CheckDevices {
+		deviceCertificates, err := uc.checkDevices(ctx)
+		if err != nil {
+			return err
+		}
+
+		certificates = append(certificates, deviceCertificates...)
+	}
+
+	for i := range certificates {
+		if certificates[i].CheckedAt.IsZero() {
+			certificates[i].CheckedAt = now
+		}
+
+		uc.evaluate(&certificates[i], now)
+	}
+
+	uc.warn(certificates)
+
+	uc.mu.Lock()
+	uc.certificates = certificates
+	uc.mu.Unlock()
+
+	return nil
+}
+
+func (uc *UseCase) checkDevices(ctx context.Context) ([]dto.MonitoredCertificate, error) {
+	items, err := uc.repo.GetTLSDevices(ctx)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("checkDevices", "uc.repo.GetTLSDevices", err)
+	}
+
+	previous := make(map[string]dto.MonitoredCertificate)
+
+	uc.mu.RLock()
+
+	for _, c := range uc.certificates {
+		if c.Source == dto.CertificateSourceDevice {
+			previous[c.TenantID+"/"+c.Name] = c
+		}
+	}
+
+	uc.mu.RUnlock()
+
+	certificates := make([]dto.MonitoredCertificate, 0, len(items))
+
+	for i := range items {
+		cert, err := uc.devices.GetDeviceCertificate(devices.WithTenant(ctx, items[i].TenantID), items[i].GUID)
+		if err != nil {
+			uc.log.Debug(fmt.Sprintf("certmonitor - the certificate of device %s cannot be read: %v", items[i].GUID, err))
+
+			if last, ok := previous[items[i].TenantID+"/"+items[i].GUID]; ok {
+				certificates = append(certificates, last)
+			}
+
+			continue
+		}
+
+		pinned := items[i].CertHash != nil && *items[i].CertHash != ""
+
+		certificates = append(certificates, dto.MonitoredCertificate{
+			Source:            dto.CertificateSourceDevice,
+			Name:              items[i].GUID,
+			TenantID:          items[i].TenantID,
+			CommonName:        cert.CommonName,
+			SHA256Fingerprint: cert.SHA256Fingerprint,
+			NotAfter:          cert.NotAfter,
+			PinMismatch:       pinned && !strings.EqualFold(*items[i].CertHash, cert.SHA256Fingerprint),
+		})
+	}
+
+	return certificates, nil
+}
+
+// evaluate fills in the remaining days and the warning threshold the certificate is within.
+func (uc *UseCase) evaluate(c *dto.MonitoredCertificate, now time.Time) {
+	remaining := c.NotAfter.Sub(now)
+
+	c.Expired = remaining <= 0
+	// whole days, negative once a certificate is expired for more than a day
+	c.DaysRemaining = int(remaining / day)
+	c.WarningDays = 0
+
+	for _, days := range warningDays() {
+		if c.DaysRemaining < days {
+			c.WarningDays = days
+
+			break
+		}
+	}
+}
+
+// warn logs the certificates whose state changed since their last warning.
+func (uc *UseCase) warn(certificates []dto.MonitoredCertificate) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	seen := make(map[string]bool, len(certificates))
+
+	for i := range certificates {
+		c := &certificates[i]
+		key := c.Source + "/" + c.TenantID + "/" + c.Name
+		seen[key] = true
+
+		var states []string
+
+		switch {
+		case c.Expired:
+			states = append(states, "expired")
+		case c.WarningDays > 0:
+			states = append(states, fmt.Sprintf("within %d days", c.WarningDays))
+		}
+
+		if c.PinMismatch {
+			states = append(states, "pin mismatch "+c.SHA256Fingerprint)
+		}
+
+		state := strings.Join(states, ", ")
+		if state == uc.warned[key] {
+			continue
+		}
+
+		uc.warned[key] = state
+
+		switch {
+		case c.PinMismatch:
+			uc.log.Warn(fmt.Sprintf("certmonitor - device %s presents certificate %s, it does not match its pinned hash", c.Name, c.SHA256Fingerprint))
+		case c.Expired:
+			uc.log.Error(fmt.Sprintf("certmonitor - the %s certificate of %q expired on %s", c.Source, c.Name, c.NotAfter.Format(time.RFC3339)))
+		case c.WarningDays > 0:
+			uc.log.Warn(fmt.Sprintf("certmonitor - the %s certificate of %q expires in %d days, on %s", c.Source, c.Name, c.DaysRemaining, c.NotAfter.Format(time.RFC3339)))
+		}
+	}
+
+	for key := range uc.warned {
+		if !seen[key] {
+			delete(uc.warned, key)
+		}
+	}
+}
+
+// GetExpiring returns the certificates of the tenant that expire within days, the largest warning threshold by
+// default, or that do not match their pin, soonest expiring first.
+func (uc *UseCase) GetExpiring(_ context.Context, days int, tenantID string) ([]dto.MonitoredCertificate, error) {
+	if days <= 0 {
+		days = slices.Max(warningDays())
+	}
+
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	expiring := make([]dto.MonitoredCertificate, 0)
+
+	for _, c := range uc.certificates {
+		if c.TenantID == tenantID && (c.DaysRemaining < days || c.PinMismatch) {
+			expiring = append(expiring, c)
+		}
+	}
+
+	slices.SortStableFunc(expiring, func(a, b dto.MonitoredCertificate) int {
+		return a.NotAfter.Compare(b.NotAfter)
+	})
+
+	return expiring, nil
+}
+
+// warningDays returns the configured thresholds in ascending order.
+func warningDays() []int {
+	days := slices.Clone(config.ConsoleConfig.CertMonitor.WarningDays)
+	days = slices.DeleteFunc(days, func(d int) bool { return d <= 0 })
+
+	if len(days) == 0 {
+		days = slices.Clone(defaultWarningDays)
+	}
+
+	slices.Sort(days)
+
+	return days
+}
+
+// parseCertificate reads a PEM or base64 encoded DER certificate.
+func parseCertificate(s string) (*x509.Certificate, error) {
+	s = strings.TrimSpace(s)
+
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		return x509.ParseCertificate(block.Bytes)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil || len(der) == 0 {
+		return nil, errNoCertificate
+	}
+
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,142 @@
+package certmonitor_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/certmonitor"
+)
+
+const day = 24 * time.Hour
+
+var errUnreachable = errors.New("device unreachable")
+
+func rootCertificate(t *testing.T, notAfter time.Time) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "MPS Root"},
+		NotBefore:    time.Now().Add(-day),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func certMonitorTest(t *testing.T) (*certmonitor.UseCase, *mocks.MockCertMonitorRepository, *mocks.MockDeviceCertificates, *mocks.MockLogger) {
+	t.Helper()
+
+	config.ConsoleConfig = &config.Config{}
+	config.ConsoleConfig.CertMonitor = config.CertMonitor{WarningDays: []int{7, 60, 30}, CheckDevices: true}
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockCertMonitorRepository(ctrl)
+	deviceCerts := mocks.NewMockDeviceCertificates(ctrl)
+	log := mocks.NewMockLogger(ctrl)
+
+	return certmonitor.New(repo, deviceCerts, log), repo, deviceCerts, log
+}
+
+func TestCheck(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	useCase, repo, deviceCerts, log := certMonitorTest(t)
+	now := time.Now()
+	pinned := "aabbcc"
+	matching := "ddeeff"
+
+	repo.EXPECT().GetDomainExpirations(gomock.Any()).Return([]entity.Domain{
+		{ProfileName: "soon", ExpirationDate: now.Add(20*day + time.Hour).Format(time.RFC3339)},
+		{ProfileName: "later", ExpirationDate: now.Add(200 * day).Format(time.RFC3339)},
+		{ProfileName: "expired", ExpirationDate: now.Add(-day).Format(time.RFC3339), TenantID: "bu-retail"},
+		{ProfileName: "no date", ExpirationDate: ""},
+	}, nil).Times(2)
+	repo.EXPECT().GetCIRARootCertificates(gomock.Any()).Return([]entity.CIRAConfig{
+		{ConfigName: "cira", MPSRootCertificate: rootCertificate(t, now.Add(5*day+time.Hour))},
+		{ConfigName: "broken", MPSRootCertificate: "not a certificate"},
+	}, nil).Times(2)
+	repo.EXPECT().GetTLSDevices(gomock.Any()).Return([]entity.Device{
+		{GUID: "pinned", CertHash: &pinned},
+		{GUID: "matching", CertHash: &matching},
+	}, nil).Times(2)
+
+	deviceCerts.EXPECT().GetDeviceCertificate(gomock.Any(), "pinned").Return(dto.Certificate{SHA256Fingerprint: "112233", NotAfter: now.Add(365 * day)}, nil)
+	deviceCerts.EXPECT().GetDeviceCertificate(gomock.Any(), "matching").Return(dto.Certificate{SHA256Fingerprint: "DDEEFF", NotAfter: now.Add(365 * day)}, nil)
+
+	// soon, cira, the pin mismatch and the unreadable MPS root certificate, twice for the latter
+	log.EXPECT().Warn(gomock.Any()).Times(5)
+	log.EXPECT().Error(gomock.Any()).Times(1)
+
+	require.NoError(t, useCase.Check(context.Background()))
+
+	expiring, err := useCase.GetExpiring(context.Background(), 0, "")
+	require.NoError(t, err)
+	require.Len(t, expiring, 3)
+
+	require.Equal(t, "cira", expiring[0].Name)
+	require.Equal(t, dto.CertificateSourceCIRAConfig, expiring[0].Source)
+	require.Equal(t, "MPS Root", expiring[0].CommonName)
+	require.Equal(t, 5, expiring[0].DaysRemaining)
+	require.Equal(t, 7, expiring[0].WarningDays)
+
+	require.Equal(t, "soon", expiring[1].Name)
+	require.Equal(t, 20, expiring[1].DaysRemaining)
+	require.Equal(t, 30, expiring[1].WarningDays)
+
+	require.Equal(t, "pinned", expiring[2].Name)
+	require.True(t, expiring[2].PinMismatch)
+
+	expiring, err = useCase.GetExpiring(context.Background(), 10, "")
+	require.NoError(t, err)
+	require.Len(t, expiring, 2)
+
+	expiring, err = useCase.GetExpiring(context.Background(), 0, "bu-retail")
+	require.NoError(t, err)
+	require.Len(t, expiring, 1)
+	require.True(t, expiring[0].Expired)
+	require.Equal(t, -1, expiring[0].DaysRemaining)
+
+	// nothing changed, so no warning is repeated; unreachable devices keep their last state
+	deviceCerts.EXPECT().GetDeviceCertificate(gomock.Any(), gomock.Any()).Return(dto.Certificate{}, errUnreachable).Times(2)
+	log.EXPECT().Debug(gomock.Any()).Times(2)
+
+	require.NoError(t, useCase.Check(context.Background()))
+
+	expiring, err = useCase.GetExpiring(context.Background(), 0, "")
+	require.NoError(t, err)
+	require.Len(t, expiring, 3)
+}
+
+func TestCheckDatabaseError(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	useCase, repo, _, _ := certMonitorTest(t)
+
+	repo.EXPECT().GetDomainExpirations(gomock.Any()).Return(nil, errors.New("database down"))
+
+	err := useCase.Check(context.Background())
+	require.Error(t, err)
+
+	expiring, err := useCase.GetExpiring(context.Background(), 0, "")
+	require.NoError(t, err)
+	require.Empty(t, expiring)
+}
@@ -0,0 +1,36 @@
+package ciraconfigs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// recordRevision stores the saved CIRA config as a revision, the password is kept as it was encrypted for the config.
+func (uc *UseCase) recordRevision(ctx context.Context, d2 *dto.CIRAConfig, d1 *entity.CIRAConfig) error {
+	secrets := map[string]string{"password": d1.Password}
+
+	if err := uc.revisions.Record(ctx, dto.RevisionKindCIRA, d2.ConfigName, d2.TenantID, d2, secrets); err != nil {
+		return ErrDatabase.Wrap("recordRevision", "uc.revisions.Record", err)
+	}
+
+	return nil
+}
+
+// ApplyRevision updates the CIRA config to the content of one of its revisions.
+func (uc *UseCase) ApplyRevision(ctx context.Context, content []byte, tenantID string) error {
+	d := &dto.CIRAConfig{}
+	if err := json.Unmarshal(content, d); err != nil {
+		return err
+	}
+
+	d.TenantID = tenantID
+	// a revision is applied over whatever version the config is at now
+	d.Version = ""
+
+	_, err := uc.Update(ctx, d)
+
+	return err
+}
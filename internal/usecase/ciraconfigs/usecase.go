@@ -7,9 +7,11 @@ import (
 
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // UseCase -.
@@ -17,6 +19,7 @@ type UseCase struct {
 	repo             Repository
 	log              logger.Interface
 	safeRequirements security.Cryptor
+	revisions        revisions.Recorder
 }
 
 var (
@@ -26,17 +29,18 @@ var (
 )
 
 // New -.
-func New(r Repository, log logger.Interface, safeRequirements security.Cryptor) *UseCase {
+func New(r Repository, log logger.Interface, safeRequirements security.Cryptor, rev revisions.Recorder) *UseCase {
 	return &UseCase{
 		repo:             r,
 		log:              log,
 		safeRequirements: safeRequirements,
+		revisions:        rev,
 	}
 }
 
 // History - getting translate history from store.
-func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
-	count, err := uc.repo.GetCount(ctx, tenantID)
+func (uc *UseCase) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, q, tenantID)
 	if err != nil {
 		return 0, ErrDatabase.Wrap("Count", "uc.repo.GetCount", err)
 	}
@@ -44,8 +48,8 @@ func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
 	return count, nil
 }
 
-func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.CIRAConfig, error) {
-	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+func (uc *UseCase) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.CIRAConfig, error) {
+	data, err := uc.repo.Get(ctx, q, top, skip, tenantID)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
 	}
@@ -75,8 +79,8 @@ func (uc *UseCase) GetByName(ctx context.Context, configName, tenantID string) (
 	return d2, nil
 }
 
-func (uc *UseCase) Delete(ctx context.Context, configName, tenantID string) error {
-	isSuccessful, err := uc.repo.Delete(ctx, configName, tenantID)
+func (uc *UseCase) Delete(ctx context.Context, configName, version, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, configName, version, tenantID)
 	if err != nil {
 		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
 	}
@@ -91,40 +95,60 @@ func (uc *UseCase) Delete(ctx context.Context, configName, tenantID string) erro
 func (uc *UseCase) Update(ctx context.Context, d *dto.CIRAConfig) (*dto.CIRAConfig, error) {
 	d1 := uc.dtoToEntity(d)
 
-	updated, err := uc.repo.Update(ctx, d1)
-	if err != nil {
-		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
-	}
+	var d2 *dto.CIRAConfig
 
-	if !updated {
-		return nil, ErrNotFound
-	}
+	// the CIRA config is saved with its revision, a revision that cannot be recorded undoes the update
+	err := uc.revisions.Save(ctx, func(ctx context.Context) error {
+		updated, err := uc.repo.Update(ctx, d1)
+		if err != nil {
+			return ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+		}
+
+		if !updated {
+			return ErrNotFound
+		}
+
+		updatedCiraConfig, err := uc.repo.GetByName(ctx, d.ConfigName, d.TenantID)
+		if err != nil {
+			return err
+		}
+
+		d2 = uc.entityToDTO(updatedCiraConfig)
 
-	updatedCiraConfig, err := uc.repo.GetByName(ctx, d.ConfigName, d.TenantID)
+		return uc.recordRevision(ctx, d2, d1)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	d2 := uc.entityToDTO(updatedCiraConfig)
-
 	return d2, nil
 }
 
 func (uc *UseCase) Insert(ctx context.Context, d *dto.CIRAConfig) (*dto.CIRAConfig, error) {
 	d1 := uc.dtoToEntity(d)
 
-	_, err := uc.repo.Insert(ctx, d1)
-	if err != nil {
-		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
-	}
+	var d2 *dto.CIRAConfig
+
+	// the CIRA config is saved with its revision, a revision that cannot be recorded undoes the insert
+	err := uc.revisions.Save(ctx, func(ctx context.Context) error {
+		_, err := uc.repo.Insert(ctx, d1)
+		if err != nil {
+			return ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+		}
 
-	newConfig, err := uc.repo.GetByName(ctx, d.ConfigName, d.TenantID)
+		newConfig, err := uc.repo.GetByName(ctx, d.ConfigName, d.TenantID)
+		if err != nil {
+			return err
+		}
+
+		d2 = uc.entityToDTO(newConfig)
+
+		return uc.recordRevision(ctx, d2, d1)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	d2 := uc.entityToDTO(newConfig)
-
 	return d2, nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/ciraconfigs"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type test struct {
@@ -35,7 +36,12 @@ func ciraconfigsTest(t *testing.T) (*ciraconfigs.UseCase, *mocks.MockCIRAConfigs
 	repo := mocks.NewMockCIRAConfigsRepository(mockCtl)
 	crypto := mocks.MockCrypto{}
 	log := logger.New("error")
-	useCase := ciraconfigs.New(repo, log, crypto)
+	revisionsMock := mocks.NewMockRevisionsRecorder(mockCtl)
+	revisionsMock.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	revisionsMock.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	useCase := ciraconfigs.New(repo, log, crypto, revisionsMock)
 
 	return useCase, repo
 }
@@ -47,7 +53,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "empty result",
 			mock: func(repo *mocks.MockCIRAConfigsRepository) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, nil)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, nil)
 			},
 			res: 0,
 			err: nil,
@@ -55,7 +61,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "result with error",
 			mock: func(repo *mocks.MockCIRAConfigsRepository) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, ciraconfigs.ErrDatabase.Wrap("", "", nil))
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, ciraconfigs.ErrDatabase.Wrap("", "", nil))
 			},
 			res: 0,
 			err: ciraconfigs.ErrDatabase.Wrap("", "", nil),
@@ -72,7 +78,7 @@ func TestGetCount(t *testing.T) {
 
 			tc.mock(repo)
 
-			res, err := useCase.GetCount(context.Background(), "")
+			res, err := useCase.GetCount(context.Background(), odata.Query{}, "")
 
 			require.Equal(t, tc.res, res)
 			require.IsType(t, tc.err, err)
@@ -113,7 +119,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockCIRAConfigsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 0, "tenant-id-456").
 					Return(testCIRAConfigs, nil)
 			},
 			res: testCIRAConfigDTOs,
@@ -126,7 +132,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockCIRAConfigsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 5, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 5, 0, "tenant-id-456").
 					Return(nil, ciraconfigs.ErrDatabase)
 			},
 			res: []dto.CIRAConfig(nil),
@@ -139,7 +145,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockCIRAConfigsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 20, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 20, "tenant-id-456").
 					Return([]entity.CIRAConfig{}, nil)
 			},
 			res: []dto.CIRAConfig{},
@@ -156,7 +162,7 @@ func TestGet(t *testing.T) {
 
 			tc.mock(repo)
 
-			results, err := useCase.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			results, err := useCase.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			require.Equal(t, tc.res, results)
 
@@ -249,7 +255,7 @@ func TestDelete(t *testing.T) {
 			tenantID:   "tenant-id-456",
 			mock: func(repo *mocks.MockCIRAConfigsRepository) {
 				repo.EXPECT().
-					Delete(context.Background(), "example-ciraconfig", "tenant-id-456").
+					Delete(context.Background(), "example-ciraconfig", "", "tenant-id-456").
 					Return(true, nil)
 			},
 			err: nil,
@@ -260,7 +266,7 @@ func TestDelete(t *testing.T) {
 			tenantID:   "tenant-id-456",
 			mock: func(repo *mocks.MockCIRAConfigsRepository) {
 				repo.EXPECT().
-					Delete(context.Background(), "nonexistent-ciraconfig", "tenant-id-456").
+					Delete(context.Background(), "nonexistent-ciraconfig", "", "tenant-id-456").
 					Return(false, nil)
 			},
 			err: ciraconfigs.ErrNotFound,
@@ -276,7 +282,7 @@ func TestDelete(t *testing.T) {
 
 			tc.mock(repo)
 
-			err := useCase.Delete(context.Background(), tc.configName, tc.tenantID)
+			err := useCase.Delete(context.Background(), tc.configName, "", tc.tenantID)
 
 			if tc.err != nil {
 				require.Error(t, err)
@@ -0,0 +1,28 @@
+package deviceattributes
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.DeviceAttribute, error)
+		GetByName(ctx context.Context, name, tenantID string) (*entity.DeviceAttribute, error)
+		Delete(ctx context.Context, name, tenantID string) (bool, error)
+		Update(ctx context.Context, a *entity.DeviceAttribute) (bool, error)
+		Insert(ctx context.Context, a *entity.DeviceAttribute) error
+		CountValues(ctx context.Context, name, tenantID string, values []string) (int, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.DeviceAttribute, error)
+		GetByName(ctx context.Context, name, tenantID string) (*dto.DeviceAttribute, error)
+		Delete(ctx context.Context, name, tenantID string) error
+		Update(ctx context.Context, a *dto.DeviceAttribute) (*dto.DeviceAttribute, error)
+		Insert(ctx context.Context, a *dto.DeviceAttribute) (*dto.DeviceAttribute, error)
+	}
+)
@@ -0,0 +1,236 @@
+package deviceattributes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// UseCase manages the custom attributes the devices of a tenant can have. The devices check their values
+// against them.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+}
+
+// New -.
+func New(r Repository, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  log,
+	}
+}
+
+var (
+	ErrDeviceAttributesUseCase = consoleerrors.CreateConsoleError("DeviceAttributesUseCase")
+	ErrDatabase                = sqldb.DatabaseError{Console: ErrDeviceAttributesUseCase}
+	ErrNotFound                = sqldb.NotFoundError{Console: ErrDeviceAttributesUseCase}
+	ErrValidation              = dto.NotValidError{Console: ErrDeviceAttributesUseCase}
+
+	errName          = errors.New("names start with a letter followed by letters, digits and underscores")
+	errBuiltIn       = errors.New("devices already have a property named")
+	errType          = errors.New("unknown attribute type")
+	errEnumValues    = errors.New("enum attributes need the values they allow")
+	errNotEnumValues = errors.New("only enum attributes have values")
+	errTypeInUse     = errors.New("the type cannot change while devices have a value for the attribute")
+	errEnumInUse     = errors.New("values cannot be removed while devices have them")
+)
+
+// attributeName is what a $filter can refer to, which also keeps the names safe in a JSON path.
+var attributeName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.DeviceAttribute, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.DeviceAttribute, len(data))
+
+	for i := range data {
+		d1[i] = *entityToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByName(ctx context.Context, name, tenantID string) (*dto.DeviceAttribute, error) {
+	data, err := uc.repo.GetByName(ctx, name, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByName", "uc.repo.GetByName", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return entityToDTO(data), nil
+}
+
+// Delete removes an attribute along with the values the devices have for it.
+func (uc *UseCase) Delete(ctx context.Context, name, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, name, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Update changes the type, values or description of an attribute. The values the devices already have must stay
+// valid: the type only changes while no device has a value for the attribute, and enum values are only removed
+// while no device has them.
+func (uc *UseCase) Update(ctx context.Context, d *dto.DeviceAttribute) (*dto.DeviceAttribute, error) {
+	if err := validate(d); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.GetByName(ctx, d.Name, d.TenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.GetByName", err)
+	}
+
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+
+	if err := uc.checkValues(ctx, existing, d); err != nil {
+		return nil, err
+	}
+
+	a := dtoToEntity(d)
+
+	updated, err := uc.repo.Update(ctx, a)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return uc.GetByName(ctx, a.Name, a.TenantID)
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.DeviceAttribute) (*dto.DeviceAttribute, error) {
+	if err := validate(d); err != nil {
+		return nil, err
+	}
+
+	a := dtoToEntity(d)
+
+	if err := uc.repo.Insert(ctx, a); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return uc.GetByName(ctx, a.Name, a.TenantID)
+}
+
+// checkValues makes sure the values devices have for an attribute remain valid with the change to it. Lists and
+// rules filtering on the attribute would fail on the values that do not.
+func (uc *UseCase) checkValues(ctx context.Context, existing *entity.DeviceAttribute, d *dto.DeviceAttribute) error {
+	if existing.Type != d.Type {
+		count, err := uc.repo.CountValues(ctx, d.Name, d.TenantID, nil)
+		if err != nil {
+			return ErrDatabase.Wrap("checkValues", "uc.repo.CountValues", err)
+		}
+
+		if count > 0 {
+			return ErrValidation.Wrap("checkValues", "uc.repo.CountValues", fmt.Errorf("%w, %d devices have one", errTypeInUse, count))
+		}
+
+		return nil
+	}
+
+	removed := make([]string, 0)
+
+	for _, value := range existing.EnumValues {
+		if !slices.Contains(d.EnumValues, value) {
+			removed = append(removed, value)
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	count, err := uc.repo.CountValues(ctx, d.Name, d.TenantID, removed)
+	if err != nil {
+		return ErrDatabase.Wrap("checkValues", "uc.repo.CountValues", err)
+	}
+
+	if count > 0 {
+		return ErrValidation.Wrap("checkValues", "uc.repo.CountValues", fmt.Errorf("%w, %d devices have one of %s", errEnumInUse, count, strings.Join(removed, ", ")))
+	}
+
+	return nil
+}
+
+// validate checks that an attribute can be filtered by without shadowing a device property and that only enum
+// attributes list values.
+func validate(d *dto.DeviceAttribute) error {
+	if !attributeName.MatchString(d.Name) {
+		return ErrValidation.Wrap("validate", "d.Name", errName)
+	}
+
+	if sqldb.IsDeviceProperty(d.Name) {
+		return ErrValidation.Wrap("validate", "d.Name", fmt.Errorf("%w %s", errBuiltIn, d.Name))
+	}
+
+	switch d.Type {
+	case dto.AttributeEnum:
+		if len(d.EnumValues) == 0 {
+			return ErrValidation.Wrap("validate", "d.EnumValues", errEnumValues)
+		}
+	case dto.AttributeString, dto.AttributeNumber, dto.AttributeDate:
+		if len(d.EnumValues) > 0 {
+			return ErrValidation.Wrap("validate", "d.EnumValues", errNotEnumValues)
+		}
+	default:
+		return ErrValidation.Wrap("validate", "d.Type", fmt.Errorf("%w: %s", errType, d.Type))
+	}
+
+	return nil
+}
+
+func dtoToEntity(d *dto.DeviceAttribute) *entity.DeviceAttribute {
+	return &entity.DeviceAttribute{
+		Name:        d.Name,
+		Type:        d.Type,
+		EnumValues:  d.EnumValues,
+		Description: d.Description,
+		TenantID:    d.TenantID,
+	}
+}
+
+func entityToDTO(a *entity.DeviceAttribute) *dto.DeviceAttribute {
+	return &dto.DeviceAttribute{
+		Name:        a.Name,
+		Type:        a.Type,
+		EnumValues:  a.EnumValues,
+		Description: a.Description,
+		TenantID:    a.TenantID,
+	}
+}
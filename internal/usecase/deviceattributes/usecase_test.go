@@ -0,0 +1,116 @@
+package deviceattributes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/deviceattributes"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func deviceAttributesTest(t *testing.T) (*deviceattributes.UseCase, *mocks.MockDeviceAttributesRepository) {
+	t.Helper()
+
+	ctl := gomock.NewController(t)
+	repo := mocks.NewMockDeviceAttributesRepository(ctl)
+
+	return deviceattributes.New(repo, logger.New("error")), repo
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := deviceAttributesTest(t)
+	ctx := context.Background()
+
+	location := &entity.DeviceAttribute{Name: "location", Type: dto.AttributeEnum, EnumValues: []string{"datacenter", "branch"}, TenantID: "t1"}
+
+	repo.EXPECT().Insert(ctx, location).Return(nil)
+	repo.EXPECT().GetByName(ctx, "location", "t1").Return(location, nil)
+
+	inserted, err := useCase.Insert(ctx, &dto.DeviceAttribute{Name: "location", Type: dto.AttributeEnum, EnumValues: []string{"datacenter", "branch"}, TenantID: "t1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"datacenter", "branch"}, inserted.EnumValues)
+}
+
+func TestInsertValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]dto.DeviceAttribute{
+		"name with a dash":    {Name: "asset-tag", Type: dto.AttributeString},
+		"device property":     {Name: "Hostname", Type: dto.AttributeString},
+		"inventory property":  {Name: "fwVersion", Type: dto.AttributeString},
+		"unknown type":        {Name: "owner", Type: "text"},
+		"enum without values": {Name: "location", Type: dto.AttributeEnum},
+		"values of a number":  {Name: "rack", Type: dto.AttributeNumber, EnumValues: []string{"1"}},
+	}
+
+	for name, attribute := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, _ := deviceAttributesTest(t)
+
+			_, err := useCase.Insert(context.Background(), &attribute)
+
+			var notValid dto.NotValidError
+			require.ErrorAs(t, err, &notValid)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := deviceAttributesTest(t)
+	ctx := context.Background()
+
+	location := &entity.DeviceAttribute{Name: "location", Type: dto.AttributeEnum, EnumValues: []string{"datacenter", "branch"}, TenantID: "t1"}
+	changed := &entity.DeviceAttribute{Name: "location", Type: dto.AttributeEnum, EnumValues: []string{"datacenter", "home"}, TenantID: "t1"}
+
+	repo.EXPECT().GetByName(ctx, "location", "t1").Return(location, nil).Times(3)
+
+	// a device is still at the branch
+	repo.EXPECT().CountValues(ctx, "location", "t1", []string{"branch"}).Return(1, nil)
+
+	_, err := useCase.Update(ctx, &dto.DeviceAttribute{Name: "location", Type: dto.AttributeEnum, EnumValues: []string{"datacenter", "home"}, TenantID: "t1"})
+	require.ErrorAs(t, err, &dto.NotValidError{})
+
+	// devices have a value the number could not be compared with
+	repo.EXPECT().CountValues(ctx, "location", "t1", nil).Return(2, nil)
+
+	_, err = useCase.Update(ctx, &dto.DeviceAttribute{Name: "location", Type: dto.AttributeNumber, TenantID: "t1"})
+	require.ErrorAs(t, err, &dto.NotValidError{})
+
+	repo.EXPECT().CountValues(ctx, "location", "t1", []string{"branch"}).Return(0, nil)
+	repo.EXPECT().Update(ctx, changed).Return(true, nil)
+	repo.EXPECT().GetByName(ctx, "location", "t1").Return(changed, nil)
+
+	updated, err := useCase.Update(ctx, &dto.DeviceAttribute{Name: "location", Type: dto.AttributeEnum, EnumValues: []string{"datacenter", "home"}, TenantID: "t1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"datacenter", "home"}, updated.EnumValues)
+
+	repo.EXPECT().GetByName(ctx, "unknown", "t1").Return(nil, nil)
+
+	_, err = useCase.Update(ctx, &dto.DeviceAttribute{Name: "unknown", Type: dto.AttributeString, TenantID: "t1"})
+	require.ErrorIs(t, err, deviceattributes.ErrNotFound)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := deviceAttributesTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().Delete(ctx, "owner", "t1").Return(true, nil)
+	repo.EXPECT().Delete(ctx, "unknown", "t1").Return(false, nil)
+
+	require.NoError(t, useCase.Delete(ctx, "owner", "t1"))
+	require.ErrorIs(t, useCase.Delete(ctx, "unknown", "t1"), deviceattributes.ErrNotFound)
+}
@@ -0,0 +1,38 @@
+package devicegroups
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.DeviceGroup, error)
+		GetByName(ctx context.Context, name, tenantID string) (*entity.DeviceGroup, error)
+		GetParents(ctx context.Context, name, tenantID string) ([]string, error)
+		Delete(ctx context.Context, name, tenantID string) (bool, error)
+		Update(ctx context.Context, g *entity.DeviceGroup) (bool, error)
+		Insert(ctx context.Context, g *entity.DeviceGroup) error
+	}
+	// Devices lists the devices a filter selects, devices.Feature implements it.
+	Devices interface {
+		GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+		Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.DeviceGroup, error)
+		GetByName(ctx context.Context, name, tenantID string) (*dto.DeviceGroup, error)
+		Delete(ctx context.Context, name, tenantID string) error
+		Update(ctx context.Context, g *dto.DeviceGroup) (*dto.DeviceGroup, error)
+		Insert(ctx context.Context, g *dto.DeviceGroup) (*dto.DeviceGroup, error)
+		Members(ctx context.Context, name string, q odata.Query, tenantID string) (odata.Query, error)
+		GetMemberCount(ctx context.Context, name string, q odata.Query, tenantID string) (int, error)
+		GetMembers(ctx context.Context, name string, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error)
+		Evaluate(ctx context.Context, rule string, top, skip int, tenantID string) (dto.DeviceCountResponse, error)
+	}
+)
@@ -0,0 +1,318 @@
+package devicegroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+// UseCase manages device groups and resolves them into the filter that selects their members, so a group
+// can target devices wherever a list of devices can be filtered.
+type UseCase struct {
+	repo    Repository
+	devices Devices
+	log     logger.Interface
+}
+
+// New -.
+func New(r Repository, d Devices, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:    r,
+		devices: d,
+		log:     log,
+	}
+}
+
+var (
+	ErrDeviceGroupsUseCase = consoleerrors.CreateConsoleError("DeviceGroupsUseCase")
+	ErrDatabase            = sqldb.DatabaseError{Console: ErrDeviceGroupsUseCase}
+	ErrNotFound            = sqldb.NotFoundError{Console: ErrDeviceGroupsUseCase}
+	ErrValidation          = dto.NotValidError{Console: ErrDeviceGroupsUseCase}
+
+	errRuleAndDevices  = errors.New("a group has either a rule or devices")
+	errUnknownSubgroup = errors.New("unknown subgroup")
+	errSubgroupCycle   = errors.New("the group would contain itself")
+	errSubgroupInUse   = errors.New("the group is a subgroup of other groups")
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.DeviceGroup, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.DeviceGroup, len(data))
+
+	for i := range data {
+		d1[i] = *entityToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByName(ctx context.Context, name, tenantID string) (*dto.DeviceGroup, error) {
+	data, err := uc.repo.GetByName(ctx, name, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByName", "uc.repo.GetByName", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return entityToDTO(data), nil
+}
+
+// Delete removes a group. Groups that are a subgroup of another group have to be removed from it first.
+func (uc *UseCase) Delete(ctx context.Context, name, tenantID string) error {
+	parents, err := uc.repo.GetParents(ctx, name, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.GetParents", err)
+	}
+
+	if len(parents) > 0 {
+		return ErrValidation.Wrap("Delete", "uc.repo.GetParents", fmt.Errorf("%w: %s", errSubgroupInUse, strings.Join(parents, ", ")))
+	}
+
+	isSuccessful, err := uc.repo.Delete(ctx, name, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (uc *UseCase) Update(ctx context.Context, d *dto.DeviceGroup) (*dto.DeviceGroup, error) {
+	if err := uc.validate(ctx, d); err != nil {
+		return nil, err
+	}
+
+	g := dtoToEntity(d)
+
+	updated, err := uc.repo.Update(ctx, g)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return uc.GetByName(ctx, g.Name, g.TenantID)
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.DeviceGroup) (*dto.DeviceGroup, error) {
+	if err := uc.validate(ctx, d); err != nil {
+		return nil, err
+	}
+
+	g := dtoToEntity(d)
+
+	if err := uc.repo.Insert(ctx, g); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return uc.GetByName(ctx, g.Name, g.TenantID)
+}
+
+// Members narrows the filter of q down to the members of a group: its devices or the devices its rule
+// matches, and the members of its subgroups.
+func (uc *UseCase) Members(ctx context.Context, name string, q odata.Query, tenantID string) (odata.Query, error) {
+	selector, err := uc.selector(ctx, name, tenantID, make(map[string]bool))
+	if err != nil {
+		return odata.Query{}, err
+	}
+
+	if q.Filter != nil {
+		selector = odata.And{Left: selector, Right: q.Filter}
+	}
+
+	return odata.Query{Filter: selector, OrderBy: q.OrderBy}, nil
+}
+
+func (uc *UseCase) selector(ctx context.Context, name, tenantID string, path map[string]bool) (odata.Node, error) {
+	if path[name] {
+		return nil, ErrValidation.Wrap("selector", name, errSubgroupCycle)
+	}
+
+	path[name] = true
+	defer delete(path, name)
+
+	g, err := uc.repo.GetByName(ctx, name, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("selector", "uc.repo.GetByName", err)
+	}
+
+	if g == nil {
+		return nil, ErrNotFound
+	}
+
+	var n odata.Node
+
+	if g.Rule != "" {
+		q, err := odata.Parse(g.Rule, "")
+		if err != nil {
+			return nil, ErrValidation.Wrap("selector", "odata.Parse "+name, err)
+		}
+
+		n = q.Filter
+	} else {
+		guids := make([]interface{}, len(g.Devices))
+		for i, guid := range g.Devices {
+			guids[i] = guid
+		}
+
+		n = odata.Comparison{Property: "guid", Operator: odata.In, Value: guids}
+	}
+
+	for _, subgroup := range g.Subgroups {
+		s, err := uc.selector(ctx, subgroup, tenantID, path)
+		if err != nil {
+			return nil, err
+		}
+
+		n = odata.Or{Left: n, Right: s}
+	}
+
+	return n, nil
+}
+
+func (uc *UseCase) GetMemberCount(ctx context.Context, name string, q odata.Query, tenantID string) (int, error) {
+	members, err := uc.Members(ctx, name, q, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	return uc.devices.GetCount(ctx, members, tenantID)
+}
+
+// GetMembers lists the members of a group that also match the filter of q.
+func (uc *UseCase) GetMembers(ctx context.Context, name string, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error) {
+	members, err := uc.Members(ctx, name, q, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.devices.Get(ctx, members, top, skip, tenantID)
+}
+
+// Evaluate counts the devices a rule matches and lists a page of them, without saving a group.
+func (uc *UseCase) Evaluate(ctx context.Context, rule string, top, skip int, tenantID string) (dto.DeviceCountResponse, error) {
+	q, err := odata.Parse(rule, "")
+	if err != nil {
+		return dto.DeviceCountResponse{}, ErrValidation.Wrap("Evaluate", "odata.Parse", err)
+	}
+
+	count, err := uc.devices.GetCount(ctx, q, tenantID)
+	if err != nil {
+		return dto.DeviceCountResponse{}, err
+	}
+
+	items, err := uc.devices.Get(ctx, q, top, skip, tenantID)
+	if err != nil {
+		return dto.DeviceCountResponse{}, err
+	}
+
+	return dto.DeviceCountResponse{Count: count, Data: items}, nil
+}
+
+// validate checks that a group is static or dynamic, that its rule fits the device properties and that its
+// subgroups exist without the group ending up inside itself.
+func (uc *UseCase) validate(ctx context.Context, d *dto.DeviceGroup) error {
+	if d.Rule != "" && len(d.Devices) > 0 {
+		return ErrValidation.Wrap("validate", "d.Rule", errRuleAndDevices)
+	}
+
+	if d.Rule != "" {
+		q, err := odata.Parse(d.Rule, "")
+		if err != nil {
+			return ErrValidation.Wrap("validate", "odata.Parse", err)
+		}
+
+		// the devices reject properties they do not have
+		if _, err := uc.devices.GetCount(ctx, q, d.TenantID); err != nil {
+			return err
+		}
+	}
+
+	for _, subgroup := range d.Subgroups {
+		if err := uc.checkSubgroup(ctx, d.Name, subgroup, d.TenantID, make(map[string]bool)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSubgroup reports an error when subgroup does not exist or contains the group.
+func (uc *UseCase) checkSubgroup(ctx context.Context, group, subgroup, tenantID string, seen map[string]bool) error {
+	if subgroup == group {
+		return ErrValidation.Wrap("checkSubgroup", subgroup, errSubgroupCycle)
+	}
+
+	if seen[subgroup] {
+		return nil
+	}
+
+	seen[subgroup] = true
+
+	g, err := uc.repo.GetByName(ctx, subgroup, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("checkSubgroup", "uc.repo.GetByName", err)
+	}
+
+	if g == nil {
+		return ErrValidation.Wrap("checkSubgroup", subgroup, fmt.Errorf("%w: %s", errUnknownSubgroup, subgroup))
+	}
+
+	for _, s := range g.Subgroups {
+		if err := uc.checkSubgroup(ctx, group, s, tenantID, seen); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func dtoToEntity(d *dto.DeviceGroup) *entity.DeviceGroup {
+	return &entity.DeviceGroup{
+		Name:        d.Name,
+		Description: d.Description,
+		Rule:        d.Rule,
+		TenantID:    d.TenantID,
+		Devices:     d.Devices,
+		Subgroups:   d.Subgroups,
+	}
+}
+
+func entityToDTO(g *entity.DeviceGroup) *dto.DeviceGroup {
+	return &dto.DeviceGroup{
+		Name:        g.Name,
+		Description: g.Description,
+		Rule:        g.Rule,
+		TenantID:    g.TenantID,
+		Devices:     g.Devices,
+		Subgroups:   g.Subgroups,
+	}
+}
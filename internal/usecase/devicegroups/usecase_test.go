@@ -0,0 +1,169 @@
+package devicegroups_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/devicegroups"
+	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+func deviceGroupsTest(t *testing.T) (*devicegroups.UseCase, *mocks.MockDeviceGroupsRepository, *mocks.MockDeviceGroupsDevices) {
+	t.Helper()
+
+	ctl := gomock.NewController(t)
+	repo := mocks.NewMockDeviceGroupsRepository(ctl)
+	devices := mocks.NewMockDeviceGroupsDevices(ctl)
+
+	return devicegroups.New(repo, devices, logger.New("error")), repo, devices
+}
+
+func TestGetMembers(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, devices := deviceGroupsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetByName(ctx, "site-x", "t1").Return(&entity.DeviceGroup{
+		Name: "site-x", Rule: "currentMode eq 'ACM'", Subgroups: []string{"kiosks"}, TenantID: "t1",
+	}, nil).Times(2)
+	repo.EXPECT().GetByName(ctx, "kiosks", "t1").Return(&entity.DeviceGroup{
+		Name: "kiosks", Devices: []string{"guid1", "guid2"}, TenantID: "t1",
+	}, nil).Times(2)
+
+	narrow, err := odata.Parse("hostname startswith 'lab'", "hostname")
+	require.NoError(t, err)
+
+	expected := odata.Query{
+		Filter: odata.And{
+			Left: odata.Or{
+				Left:  odata.Comparison{Property: "currentMode", Operator: odata.Equal, Value: "ACM"},
+				Right: odata.Comparison{Property: "guid", Operator: odata.In, Value: []interface{}{"guid1", "guid2"}},
+			},
+			Right: narrow.Filter,
+		},
+		OrderBy: narrow.OrderBy,
+	}
+
+	devices.EXPECT().Get(ctx, expected, 10, 0, "t1").Return([]dto.Device{{GUID: "guid1"}}, nil)
+	devices.EXPECT().GetCount(ctx, expected, "t1").Return(1, nil)
+
+	members, err := useCase.GetMembers(ctx, "site-x", narrow, 10, 0, "t1")
+	require.NoError(t, err)
+	require.Equal(t, []dto.Device{{GUID: "guid1"}}, members)
+
+	count, err := useCase.GetMemberCount(ctx, "site-x", narrow, "t1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	repo.EXPECT().GetByName(ctx, "unknown", "t1").Return(nil, nil)
+
+	_, err = useCase.Members(ctx, "unknown", odata.Query{}, "t1")
+	require.ErrorIs(t, err, devicegroups.ErrNotFound)
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	useCase, _, devices := deviceGroupsTest(t)
+	ctx := context.Background()
+
+	rule := odata.Query{Filter: odata.Comparison{Property: "fwVersion", Operator: odata.StartsWith, Value: "16."}}
+
+	devices.EXPECT().GetCount(ctx, rule, "").Return(3, nil)
+	devices.EXPECT().Get(ctx, rule, 1, 0, "").Return([]dto.Device{{GUID: "guid1"}}, nil)
+
+	result, err := useCase.Evaluate(ctx, "fwVersion startswith '16.'", 1, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, dto.DeviceCountResponse{Count: 3, Data: []dto.Device{{GUID: "guid1"}}}, result)
+
+	_, err = useCase.Evaluate(ctx, "fwVersion startswith", 1, 0, "")
+
+	var notValid dto.NotValidError
+	require.ErrorAs(t, err, &notValid)
+}
+
+func TestInsertValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("rule and devices", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, _, _ := deviceGroupsTest(t)
+
+		_, err := useCase.Insert(ctx, &dto.DeviceGroup{Name: "g", Rule: "tags eq 'a'", Devices: []string{"guid1"}})
+
+		var notValid dto.NotValidError
+		require.ErrorAs(t, err, &notValid)
+	})
+
+	t.Run("unknown subgroup", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, repo, _ := deviceGroupsTest(t)
+
+		repo.EXPECT().GetByName(ctx, "missing", "").Return(nil, nil)
+
+		_, err := useCase.Insert(ctx, &dto.DeviceGroup{Name: "g", Subgroups: []string{"missing"}})
+
+		var notValid dto.NotValidError
+		require.ErrorAs(t, err, &notValid)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, repo, _ := deviceGroupsTest(t)
+
+		repo.EXPECT().GetByName(ctx, "b", "").Return(&entity.DeviceGroup{Name: "b", Subgroups: []string{"a"}}, nil)
+
+		_, err := useCase.Update(ctx, &dto.DeviceGroup{Name: "a", Subgroups: []string{"b"}})
+
+		var notValid dto.NotValidError
+		require.ErrorAs(t, err, &notValid)
+	})
+
+	t.Run("dynamic group", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, repo, devices := deviceGroupsTest(t)
+
+		group := &entity.DeviceGroup{Name: "g", Rule: "tags eq 'a'", Subgroups: []string{"s"}}
+
+		devices.EXPECT().GetCount(ctx, gomock.Any(), "").Return(0, nil)
+		repo.EXPECT().GetByName(ctx, "s", "").Return(&entity.DeviceGroup{Name: "s"}, nil)
+		repo.EXPECT().Insert(ctx, group).Return(nil)
+		repo.EXPECT().GetByName(ctx, "g", "").Return(group, nil)
+
+		inserted, err := useCase.Insert(ctx, &dto.DeviceGroup{Name: "g", Rule: "tags eq 'a'", Subgroups: []string{"s"}})
+		require.NoError(t, err)
+		require.Equal(t, "tags eq 'a'", inserted.Rule)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := deviceGroupsTest(t)
+	ctx := context.Background()
+
+	repo.EXPECT().GetParents(ctx, "kiosks", "").Return([]string{"site-x"}, nil)
+	repo.EXPECT().GetParents(ctx, "site-x", "").Return([]string{}, nil)
+	repo.EXPECT().Delete(ctx, "site-x", "").Return(true, nil)
+
+	err := useCase.Delete(ctx, "kiosks", "")
+
+	var notValid dto.NotValidError
+	require.ErrorAs(t, err, &notValid)
+
+	require.NoError(t, useCase.Delete(ctx, "site-x", ""))
+}
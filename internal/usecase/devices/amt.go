@@ -61,3 +61,33 @@ func (e ValidationError) Wrap(call, function, message string) error {
 
 	return e
 }
+
+type ConsentRequiredError struct {
+	Console consoleerrors.InternalError
+}
+
+func (e ConsentRequiredError) Error() string {
+	return e.Console.Error()
+}
+
+func (e ConsentRequiredError) Wrap(call, function, message string) error {
+	_ = e.Console.Wrap(call, function, nil)
+	e.Console.Message = message
+
+	return e
+}
+
+type ForbiddenError struct {
+	Console consoleerrors.InternalError
+}
+
+func (e ForbiddenError) Error() string {
+	return e.Console.Error()
+}
+
+func (e ForbiddenError) Wrap(call, function, message string) error {
+	_ = e.Console.Wrap(call, function, nil)
+	e.Console.Message = message
+
+	return e
+}
@@ -0,0 +1,105 @@
+package devices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+)
+
+var (
+	ErrAttributes = dto.NotValidError{Console: consoleerrors.CreateConsoleError("DevicesUseCase")}
+
+	errUnknownAttribute = errors.New("unknown attribute")
+	errNotNumber        = errors.New("not a number")
+	errNotDate          = errors.New("not a date")
+	errNotString        = errors.New("not a string")
+	errNotEnumValue     = errors.New("not one of the allowed values")
+)
+
+// encodeAttributes checks the custom attributes of a device against the attributes defined for its tenant and
+// returns them as JSON, numbers as numbers and dates in RFC 3339 in UTC. A null value leaves an attribute out.
+// Devices without attributes keep the ones they have, so nothing is returned for them.
+func (uc *UseCase) encodeAttributes(ctx context.Context, d *dto.Device) (string, error) {
+	if d.Attributes == nil {
+		return "", nil
+	}
+
+	defined, err := uc.repo.GetAttributes(ctx, d.TenantID)
+	if err != nil {
+		return "", ErrDatabase.Wrap("encodeAttributes", "uc.repo.GetAttributes", err)
+	}
+
+	definitions := make(map[string]entity.DeviceAttribute, len(defined))
+	for _, a := range defined {
+		definitions[a.Name] = a
+	}
+
+	values := make(map[string]interface{}, len(d.Attributes))
+
+	for name, value := range d.Attributes {
+		a, ok := definitions[name]
+		if !ok {
+			return "", ErrAttributes.Wrap("encodeAttributes", name, fmt.Errorf("%w: %s", errUnknownAttribute, name))
+		}
+
+		if value == nil {
+			continue
+		}
+
+		v, err := attributeValue(a, value)
+		if err != nil {
+			return "", ErrAttributes.Wrap("encodeAttributes", name, fmt.Errorf("%s: %w", name, err))
+		}
+
+		values[name] = v
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", ErrDatabase.Wrap("encodeAttributes", "json.Marshal", err)
+	}
+
+	return string(data), nil
+}
+
+// attributeValue checks that value fits the type of a and returns it the way it is kept.
+func attributeValue(a entity.DeviceAttribute, value interface{}) (interface{}, error) {
+	switch a.Type {
+	case dto.AttributeNumber:
+		if n, ok := value.(float64); ok {
+			return n, nil
+		}
+
+		return nil, errNotNumber
+	case dto.AttributeDate:
+		s, _ := value.(string)
+
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, s); err != nil {
+				return nil, errNotDate
+			}
+		}
+
+		return t.UTC().Format(time.RFC3339), nil
+	case dto.AttributeEnum:
+		if s, ok := value.(string); ok && slices.Contains(a.EnumValues, s) {
+			return s, nil
+		}
+
+		return nil, errNotEnumValue
+	default:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+
+		return nil, errNotString
+	}
+}
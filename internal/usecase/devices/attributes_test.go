@@ -0,0 +1,77 @@
+package devices_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+var attributeDefinitions = []entity.DeviceAttribute{
+	{Name: "assetTag", Type: dto.AttributeString},
+	{Name: "rack", Type: dto.AttributeNumber},
+	{Name: "warrantyEnd", Type: dto.AttributeDate},
+	{Name: "location", Type: dto.AttributeEnum, EnumValues: []string{"datacenter", "branch"}},
+}
+
+func TestInsertAttributes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	useCase, repo, _ := devicesTest(t)
+
+	stored := `{"assetTag":"A-1001","location":"branch","rack":12,"warrantyEnd":"2027-03-31T00:00:00Z"}`
+
+	repo.EXPECT().GetAttributes(ctx, "t1").Return(attributeDefinitions, nil)
+	repo.EXPECT().Insert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, d *entity.Device) (string, error) {
+		require.JSONEq(t, stored, d.Attributes)
+
+		return "", nil
+	})
+	repo.EXPECT().GetByID(ctx, "guid1", "t1").Return(&entity.Device{GUID: "guid1", TenantID: "t1", Attributes: stored}, nil)
+
+	inserted, err := useCase.Insert(ctx, &dto.Device{GUID: "guid1", TenantID: "t1", Attributes: map[string]interface{}{
+		"assetTag":    "A-1001",
+		"rack":        float64(12),
+		"warrantyEnd": "2027-03-31",
+		"location":    "branch",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"assetTag": "A-1001", "location": "branch", "rack": float64(12), "warrantyEnd": "2027-03-31T00:00:00Z",
+	}, inserted.Attributes)
+}
+
+func TestInsertAttributesInvalid(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := map[string]interface{}{
+		"owner":       "ops",
+		"rack":        "12",
+		"warrantyEnd": "31/03/2027",
+		"location":    "home",
+		"assetTag":    float64(1001),
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo, _ := devicesTest(t)
+
+			repo.EXPECT().GetAttributes(ctx, "").Return(attributeDefinitions, nil)
+
+			_, err := useCase.Insert(ctx, &dto.Device{GUID: "guid1", Attributes: map[string]interface{}{name: value}})
+
+			var notValid dto.NotValidError
+			require.ErrorAs(t, err, &notValid)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -31,6 +32,9 @@ const (
 	TypeWired    string = "Wired"
 )
 
+// ErrDeviceCertificate is returned when the device presented no certificate that could be read.
+var ErrDeviceCertificate = errors.New("the device presented no readable certificate")
+
 func processConcreteDependencies(certificateHandle string, profileAssociation *dto.ProfileAssociation, dependencyItems []concrete.ConcreteDependency, securitySettings dto.SecuritySettings) {
 	for i := range dependencyItems {
 		di := dependencyItems[i]
@@ -153,7 +157,7 @@ func processCertificates(contextItems []credential.CredentialContext, response w
 }
 
 func (uc *UseCase) GetCertificates(c context.Context, guid string) (dto.SecuritySettings, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.SecuritySettings{}, err
 	}
@@ -247,7 +251,7 @@ func KeysToDTO(r *publicprivate.RefinedPullResponse) dto.KeyPullResponse {
 }
 
 func (uc *UseCase) GetDeviceCertificate(c context.Context, guid string) (dto.Certificate, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.Certificate{}, err
 	}
@@ -279,6 +283,10 @@ func (uc *UseCase) GetDeviceCertificate(c context.Context, guid string) (dto.Cer
 		certDTOs = append(certDTOs, certDTO)
 	}
 
+	if len(certDTOs) == 0 {
+		return dto.Certificate{}, ErrDeviceCertificate
+	}
+
 	return certDTOs[0], nil
 }
 
@@ -317,7 +325,7 @@ func populateCertificateDTO(cert *x509.Certificate) dto.Certificate {
 func (uc *UseCase) AddCertificate(c context.Context, guid string, certInfo dto.CertInfo) (handle string, err error) {
 	var certData []byte
 
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return "", err
 	}
@@ -8,7 +8,7 @@ import (
 )
 
 func (uc *UseCase) CancelUserConsent(c context.Context, guid string) (dto.UserConsentMessage, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.UserConsentMessage{}, err
 	}
@@ -28,7 +28,7 @@ func (uc *UseCase) CancelUserConsent(c context.Context, guid string) (dto.UserCo
 }
 
 func (uc *UseCase) GetUserConsentCode(c context.Context, guid string) (dto.GetUserConsentMessage, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.GetUserConsentMessage{}, err
 	}
@@ -55,7 +55,7 @@ func (uc *UseCase) GetUserConsentCode(c context.Context, guid string) (dto.GetUs
 }
 
 func (uc *UseCase) SendConsentCode(c context.Context, userConsent dto.UserConsentCode, guid string) (dto.UserConsentMessage, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.UserConsentMessage{}, err
 	}
@@ -0,0 +1,205 @@
+package devices
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/ips/optin"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/devices/wsman"
+)
+
+const (
+	// ConsentTimeout bounds how long the browser has to enter the code shown on the device screen.
+	ConsentTimeout = 5 * time.Minute
+
+	consentCodeAttempts = 3
+
+	// consentCodeIncorrect is what SendOptInCode returns when the code does not match the one on the device
+	// screen. It is the only result that uses up an attempt.
+	consentCodeIncorrect = 2066
+
+	// Control messages sent as websocket text frames before the redirection session starts.
+	ControlTypeConsent       = "consent"
+	ControlTypeConsentCode   = "consentCode"
+	ControlTypeConsentCancel = "consentCancel"
+
+	ConsentStateRequired    = "required"
+	ConsentStateInvalidCode = "invalidCode"
+	ConsentStateGranted     = "granted"
+	ConsentStateCancelled   = "cancelled"
+	ConsentStateTimeout     = "timeout"
+)
+
+var consentCodePattern = regexp.MustCompile(`^\d{6}$`)
+
+// negotiateKVMConsent makes sure the user at the device has agreed to the KVM session. When consent is
+// required the code request is started on the device and the browser is prompted for the code over the
+// websocket. Giving up, timing out, running out of attempts or the device refusing the code for any reason but
+// a wrong code cancels the request on the device.
+func (uc *UseCase) negotiateKVMConsent(c context.Context, conn WebSocketConn, item *entity.Device) error {
+	device := uc.device.SetupWsmanClient(*item, false, true)
+
+	optInResponse, err := device.GetIPSOptInService()
+	if err != nil {
+		return err
+	}
+
+	required := optInResponse.Body.GetAndPutResponse.OptInRequired
+	state := optin.OptInState(optInResponse.Body.GetAndPutResponse.OptInState)
+
+	if kvmConsentSatisfied(required, int(state)) {
+		return nil
+	}
+
+	// a request that is already pending keeps its code, starting a new one would replace it
+	if state != optin.Requested && state != optin.Displayed {
+		started, err := device.GetUserConsentCode()
+		if err != nil {
+			return err
+		}
+
+		if started.ReturnValue != 0 {
+			return ErrConsentRequired.Wrap("negotiateKVMConsent", "device.GetUserConsentCode", "device refused to start the user consent request")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, ConsentTimeout)
+	defer cancel()
+
+	if err := writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeConsent, State: ConsentStateRequired}); err != nil {
+		uc.cancelConsent(device)
+
+		return err
+	}
+
+	for attemptsLeft := consentCodeAttempts; attemptsLeft > 0; {
+		reply, err := awaitControl(ctx, conn)
+		if err != nil {
+			uc.cancelConsent(device)
+
+			if ctx.Err() != nil {
+				_ = writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeConsent, State: ConsentStateTimeout})
+				// unblocks the pending read
+				_ = conn.Close()
+
+				return ErrConsentRequired.Wrap("negotiateKVMConsent", "awaitControl", "timed out waiting for the user consent code")
+			}
+
+			return err
+		}
+
+		switch reply.Type {
+		case ControlTypeConsentCancel:
+			uc.cancelConsent(device)
+
+			_ = writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeConsent, State: ConsentStateCancelled})
+
+			return ErrConsentRequired.Wrap("negotiateKVMConsent", "awaitControl", "user consent was cancelled")
+		case ControlTypeConsentCode:
+			// a malformed code never reaches the device and costs no attempt
+			if consentCodePattern.MatchString(reply.Code) {
+				code, _ := strconv.Atoi(reply.Code)
+
+				response, err := device.SendConsentCode(code)
+				if err != nil {
+					uc.cancelConsent(device)
+
+					return err
+				}
+
+				switch response.ReturnValue {
+				case int(optin.ReturnValueSuccess):
+					return writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeConsent, State: ConsentStateGranted})
+				case consentCodeIncorrect:
+					attemptsLeft--
+				default:
+					uc.cancelConsent(device)
+
+					_ = writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeConsent, State: ConsentStateCancelled})
+
+					return ErrConsentRequired.Wrap("negotiateKVMConsent", "device.SendConsentCode", "device refused the user consent code with return value "+strconv.Itoa(response.ReturnValue))
+				}
+			}
+
+			if attemptsLeft > 0 {
+				if err := writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeConsent, State: ConsentStateInvalidCode, AttemptsLeft: attemptsLeft}); err != nil {
+					uc.cancelConsent(device)
+
+					return err
+				}
+			}
+		default:
+		}
+	}
+
+	uc.cancelConsent(device)
+
+	_ = writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeConsent, State: ConsentStateCancelled})
+
+	return ErrConsentRequired.Wrap("negotiateKVMConsent", "device.SendConsentCode", "user consent code was rejected")
+}
+
+func (uc *UseCase) cancelConsent(device wsman.Management) {
+	if _, err := device.CancelUserConsentRequest(); err != nil {
+		uc.log.Warn("devices - negotiateKVMConsent - failed to cancel user consent request: " + err.Error())
+	}
+}
+
+func writeControl(conn WebSocketConn, msg dto.RedirectionControlMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// awaitControl waits for the next control message from the browser. Binary frames sent before the session
+// starts are dropped.
+func awaitControl(ctx context.Context, conn WebSocketConn) (dto.RedirectionControlMessage, error) {
+	type result struct {
+		msg dto.RedirectionControlMessage
+		err error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				done <- result{err: err}
+
+				return
+			}
+
+			if messageType != websocket.TextMessage {
+				continue
+			}
+
+			var msg dto.RedirectionControlMessage
+			if json.Unmarshal(data, &msg) != nil {
+				continue
+			}
+
+			done <- result{msg: msg}
+
+			return
+		}
+	}()
+
+	select {
+	case r := <-done:
+		return r.msg, r.err
+	case <-ctx.Done():
+		return dto.RedirectionControlMessage{}, ctx.Err()
+	}
+}
@@ -0,0 +1,165 @@
+package devices_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/ips/optin"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	devices "github.com/device-management-toolkit/console/internal/usecase/devices"
+)
+
+func controlFrame(msg dto.RedirectionControlMessage) []byte {
+	data, _ := json.Marshal(msg)
+
+	return data
+}
+
+func expectControl(conn *mocks.MockWebSocketConn, msg dto.RedirectionControlMessage) *gomock.Call {
+	return conn.EXPECT().WriteMessage(websocket.TextMessage, controlFrame(msg)).Return(nil)
+}
+
+func replyControl(conn *mocks.MockWebSocketConn, msg dto.RedirectionControlMessage) *gomock.Call {
+	return conn.EXPECT().ReadMessage().Return(websocket.TextMessage, controlFrame(msg), nil)
+}
+
+func TestRedirectKVMConsent(t *testing.T) {
+	t.Parallel()
+
+	device := &entity.Device{GUID: "guid", Username: "admin"}
+	required := dto.RedirectionControlMessage{Type: devices.ControlTypeConsent, State: devices.ConsentStateRequired}
+	code := func(c string) dto.RedirectionControlMessage {
+		return dto.RedirectionControlMessage{Type: devices.ControlTypeConsentCode, Code: c}
+	}
+
+	t.Run("code accepted starts the session", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, redirection, repo := initScreenshotTest(t)
+		conn := mocks.NewMockWebSocketConn(gomock.NewController(t))
+
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredKVM), int(optin.NotStarted)), nil)
+		management.EXPECT().GetUserConsentCode().Return(optin.StartOptIn_OUTPUT{}, nil)
+
+		gomock.InOrder(
+			expectControl(conn, required),
+			// binary frames before the session starts are ignored
+			conn.EXPECT().ReadMessage().Return(websocket.BinaryMessage, []byte{0x10}, nil),
+			// a malformed code is not sent to the device and costs no attempt
+			replyControl(conn, code("12")),
+			expectControl(conn, dto.RedirectionControlMessage{Type: devices.ControlTypeConsent, State: devices.ConsentStateInvalidCode, AttemptsLeft: 3}),
+			replyControl(conn, code("123456")),
+			management.EXPECT().SendConsentCode(123456).Return(dto.UserConsentMessage{}, nil),
+			expectControl(conn, dto.RedirectionControlMessage{Type: devices.ControlTypeConsent, State: devices.ConsentStateGranted}),
+			redirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{}),
+			redirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(ErrConnectionFailed),
+		)
+
+		err := useCase.Redirect(context.Background(), conn, device.GUID, "kvm", "")
+		require.ErrorIs(t, err, ErrConnectionFailed)
+	})
+
+	t.Run("cancel from the browser cancels the request on the device", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, _, repo := initScreenshotTest(t)
+		conn := mocks.NewMockWebSocketConn(gomock.NewController(t))
+
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredAll), int(optin.Displayed)), nil)
+
+		gomock.InOrder(
+			expectControl(conn, required),
+			replyControl(conn, dto.RedirectionControlMessage{Type: devices.ControlTypeConsentCancel}),
+			management.EXPECT().CancelUserConsentRequest().Return(dto.UserConsentMessage{}, nil),
+			expectControl(conn, dto.RedirectionControlMessage{Type: devices.ControlTypeConsent, State: devices.ConsentStateCancelled}),
+		)
+
+		err := useCase.Redirect(context.Background(), conn, device.GUID, "kvm", "")
+
+		var consentErr devices.ConsentRequiredError
+		require.ErrorAs(t, err, &consentErr)
+	})
+
+	t.Run("rejected codes exhaust the attempts", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, _, repo := initScreenshotTest(t)
+		conn := mocks.NewMockWebSocketConn(gomock.NewController(t))
+
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredKVM), int(optin.NotStarted)), nil)
+		management.EXPECT().GetUserConsentCode().Return(optin.StartOptIn_OUTPUT{}, nil)
+		management.EXPECT().SendConsentCode(111111).Return(dto.UserConsentMessage{ReturnValue: 2066}, nil).Times(3)
+		conn.EXPECT().WriteMessage(websocket.TextMessage, gomock.Any()).Return(nil).Times(3)
+		replyControl(conn, code("111111")).Times(3)
+
+		gomock.InOrder(
+			management.EXPECT().CancelUserConsentRequest().Return(dto.UserConsentMessage{}, nil),
+			expectControl(conn, dto.RedirectionControlMessage{Type: devices.ControlTypeConsent, State: devices.ConsentStateCancelled}),
+		)
+
+		err := useCase.Redirect(context.Background(), conn, device.GUID, "kvm", "")
+
+		var consentErr devices.ConsentRequiredError
+		require.ErrorAs(t, err, &consentErr)
+	})
+
+	t.Run("failure to send the code is an error, not a rejected code", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, _, repo := initScreenshotTest(t)
+		conn := mocks.NewMockWebSocketConn(gomock.NewController(t))
+
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredKVM), int(optin.Displayed)), nil)
+
+		gomock.InOrder(
+			expectControl(conn, required),
+			replyControl(conn, code("123456")),
+			management.EXPECT().SendConsentCode(123456).Return(dto.UserConsentMessage{}, ErrConnectionFailed),
+			management.EXPECT().CancelUserConsentRequest().Return(dto.UserConsentMessage{}, nil),
+		)
+
+		err := useCase.Redirect(context.Background(), conn, device.GUID, "kvm", "")
+		require.ErrorIs(t, err, ErrConnectionFailed)
+	})
+
+	t.Run("code refused for another reason ends the negotiation", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, _, repo := initScreenshotTest(t)
+		conn := mocks.NewMockWebSocketConn(gomock.NewController(t))
+
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredKVM), int(optin.Displayed)), nil)
+
+		gomock.InOrder(
+			expectControl(conn, required),
+			replyControl(conn, code("123456")),
+			management.EXPECT().SendConsentCode(123456).Return(dto.UserConsentMessage{ReturnValue: int(optin.ReturnValueBlocked)}, nil),
+			management.EXPECT().CancelUserConsentRequest().Return(dto.UserConsentMessage{}, nil),
+			expectControl(conn, dto.RedirectionControlMessage{Type: devices.ControlTypeConsent, State: devices.ConsentStateCancelled}),
+		)
+
+		err := useCase.Redirect(context.Background(), conn, device.GUID, "kvm", "")
+
+		var consentErr devices.ConsentRequiredError
+		require.ErrorAs(t, err, &consentErr)
+	})
+}
@@ -41,7 +41,7 @@ type OCRData struct {
 }
 
 func (uc *UseCase) GetFeatures(c context.Context, guid string) (settingsResults dto.Features, settingsResultsV2 dtov2.Features, err error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.Features{}, dtov2.Features{}, err
 	}
@@ -180,7 +180,7 @@ func getOneClickRecoverySettings(settingsResultsV2 *dtov2.Features, device wsman
 }
 
 func (uc *UseCase) SetFeatures(c context.Context, guid string, features dto.Features) (settingsResults dto.Features, settingsResultsV2 dtov2.Features, err error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return settingsResults, settingsResultsV2, err
 	}
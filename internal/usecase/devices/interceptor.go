@@ -27,7 +27,7 @@ const (
 	ContentLengthPadding       = 8
 	RedirectSessionLengthBytes = 13
 	RedirectionSessionReply    = 4
-	ConnectionTimeout          = 5 * time.Minute
+	ConnectionTimeout          = 5 * time.Minute  // Reuse a session without traffic this long when its mode has no idle timeout
 	InactivityTimeout          = 30 * time.Second // Close connection if no data for 30 seconds
 	HeartbeatInterval          = 30 * time.Second // Check connection health every 30 seconds
 )
@@ -45,10 +45,23 @@ type DeviceConnection struct {
 	lastDataRecv  time.Time // Track last data received from device
 	mu            sync.RWMutex
 	healthTicker  *time.Ticker
+	// viewers holds every browser attached to the session, the primary is the only one whose input reaches the device
+	viewers []*redirectionViewer
+	primary *redirectionViewer
+	// handshake replays the session setup to observers that join after the primary
+	handshake     []byte
+	handshakeDone bool
+	frameRequest  []byte
+	// user owns the session for the per-user cap, policy bounds its lifetime
+	user      string
+	policy    redirectionPolicy
+	startedAt time.Time
+	lastInput time.Time
+	warned    string
 }
 
-func (uc *UseCase) Redirect(c context.Context, conn *websocket.Conn, guid, mode string) error {
-	device, err := uc.repo.GetByID(c, guid, "")
+func (uc *UseCase) Redirect(c context.Context, conn WebSocketConn, guid, mode, viewerToken string) error {
+	device, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return err
 	}
@@ -59,18 +72,20 @@ func (uc *UseCase) Redirect(c context.Context, conn *websocket.Conn, guid, mode
 
 	key := device.GUID + "-" + mode
 
-	deviceConnection, err := uc.getOrCreateConnection(c, conn, key, device)
+	deviceConnection, created, err := uc.getOrCreateConnection(c, conn, key, device, viewerToken)
 	if err != nil {
 		return err
 	}
 
+	if !created {
+		// the session is already running, the browser has been attached as an observer
+		return nil
+	}
+
 	err = uc.redirection.RedirectConnect(c, deviceConnection)
 	if err != nil {
 		deviceConnection.cancel()
-
-		uc.redirMutex.Lock()
-		delete(uc.redirConnections, key)
-		uc.redirMutex.Unlock()
+		uc.removeConnection(key, deviceConnection)
 
 		return err
 	}
@@ -81,7 +96,7 @@ func (uc *UseCase) Redirect(c context.Context, conn *websocket.Conn, guid, mode
 	return nil
 }
 
-func (uc *UseCase) getOrCreateConnection(c context.Context, conn *websocket.Conn, key string, device *entity.Device) (*DeviceConnection, error) {
+func (uc *UseCase) getOrCreateConnection(c context.Context, conn WebSocketConn, key string, device *entity.Device, viewerToken string) (*DeviceConnection, bool, error) {
 	uc.redirMutex.RLock()
 	existingConn, ok := uc.redirConnections[key]
 	uc.redirMutex.RUnlock()
@@ -89,32 +104,50 @@ func (uc *UseCase) getOrCreateConnection(c context.Context, conn *websocket.Conn
 	if ok {
 		// Check if existing connection is still valid
 		existingConn.mu.RLock()
-		isExpired := time.Since(existingConn.lastActivity) > ConnectionTimeout
+		isExpired := time.Since(existingConn.lastActivity) > existingConn.policy.reuseTimeout()
 		existingConn.mu.RUnlock()
 
-		if isExpired {
-			// Clean up expired connection
-			existingConn.cancel()
-			uc.redirection.RedirectClose(c, existingConn)
+		if !isExpired {
+			err := uc.attachObserver(existingConn, conn, viewerToken)
 
-			uc.redirMutex.Lock()
-			delete(uc.redirConnections, key)
-			uc.redirMutex.Unlock()
-		} else {
-			existingConn.Conn = conn // Update websocket connection
+			return existingConn, false, err
+		}
+
+		// Clean up expired connection
+		existingConn.cancel()
+		uc.redirection.RedirectClose(c, existingConn)
+		uc.removeConnection(key, existingConn)
+	}
 
-			return existingConn, nil
+	if err := uc.checkSessionLimit(conn, redirectionUser(c)); err != nil {
+		return nil, false, err
+	}
+
+	// observers join a session that already has consent, only a new KVM session asks for it
+	if key[len(device.GUID)+1:] == redirectionModeKVM {
+		if err := uc.negotiateKVMConsent(c, conn, device); err != nil {
+			return nil, false, err
 		}
 	}
 
-	return uc.createNewConnection(c, conn, key, device)
+	deviceConnection, err := uc.createNewConnection(c, conn, key, device, viewerToken)
+
+	return deviceConnection, true, err
 }
 
-func (uc *UseCase) createNewConnection(c context.Context, conn *websocket.Conn, key string, device *entity.Device) (*DeviceConnection, error) {
+func (uc *UseCase) createNewConnection(c context.Context, conn WebSocketConn, key string, device *entity.Device, viewerToken string) (*DeviceConnection, error) {
 	wsmanConnection := uc.redirection.SetupWsmanClient(*device, true, true)
 
 	device.Password, _ = uc.safeRequirements.Decrypt(device.Password)
 
+	primary, err := newRedirectionViewer(conn, viewerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := key[len(device.GUID)+1:]
+	policy := resolveRedirectionPolicy(device, mode)
+
 	ctx, cancel := context.WithCancel(c)
 	now := time.Now()
 	deviceConnection := &DeviceConnection{
@@ -122,7 +155,7 @@ func (uc *UseCase) createNewConnection(c context.Context, conn *websocket.Conn,
 		wsmanMessages: wsmanConnection,
 		Device:        *device,
 		Direct:        false,
-		Mode:          key[len(device.GUID)+1:], // Extract mode from key
+		Mode:          mode,
 		Challenge: client.AuthChallenge{
 			Username: device.Username,
 			Password: device.Password,
@@ -131,16 +164,41 @@ func (uc *UseCase) createNewConnection(c context.Context, conn *websocket.Conn,
 		cancel:       cancel,
 		lastActivity: now,
 		lastDataRecv: now,
-		healthTicker: time.NewTicker(HeartbeatInterval),
+		healthTicker: time.NewTicker(policy.heartbeatInterval),
+		viewers:      []*redirectionViewer{primary},
+		primary:      primary,
+		user:         redirectionUser(c),
+		policy:       policy,
+		startedAt:    now,
+		lastInput:    now,
 	}
 
 	uc.redirMutex.Lock()
+
+	// checked again while holding the lock, sessions may have been opened during consent
+	if uc.sessionLimitReached(deviceConnection.user) {
+		uc.redirMutex.Unlock()
+		deviceConnection.healthTicker.Stop()
+		cancel()
+
+		return nil, sessionLimitExceeded(conn)
+	}
+
 	uc.redirConnections[key] = deviceConnection
 	uc.redirMutex.Unlock()
 
 	return deviceConnection, nil
 }
 
+// removeConnection drops the session from the registry unless it has already been replaced by a newer one.
+func (uc *UseCase) removeConnection(key string, deviceConnection *DeviceConnection) {
+	uc.redirMutex.Lock()
+	if uc.redirConnections[key] == deviceConnection {
+		delete(uc.redirConnections, key)
+	}
+	uc.redirMutex.Unlock()
+}
+
 func (uc *UseCase) updateConnectionActivity(deviceConnection *DeviceConnection) {
 	deviceConnection.mu.Lock()
 	deviceConnection.lastActivity = time.Now()
@@ -172,6 +230,12 @@ func (uc *UseCase) startConnectionGoroutines(c context.Context, deviceConnection
 		uc.MonitorConnectionHealth(deviceConnection, key)
 	}()
 
+	// Closing the browser sockets unblocks their readers once the session ends
+	go func() {
+		<-deviceConnection.ctx.Done()
+		deviceConnection.closeViewers()
+	}()
+
 	// Start cleanup goroutine
 	go func() {
 		wg.Wait()
@@ -182,16 +246,11 @@ func (uc *UseCase) startConnectionGoroutines(c context.Context, deviceConnection
 
 		deviceConnection.cancel()
 		uc.redirection.RedirectClose(c, deviceConnection)
-
-		uc.redirMutex.Lock()
-		delete(uc.redirConnections, key)
-		uc.redirMutex.Unlock()
+		uc.removeConnection(key, deviceConnection)
 	}()
 }
 
 func (uc *UseCase) ListenToDevice(deviceConnection *DeviceConnection) {
-	conn := deviceConnection.Conn
-
 	defer func() {
 		// Clean up on exit
 		deviceConnection.cancel()
@@ -232,6 +291,8 @@ func (uc *UseCase) ListenToDevice(deviceConnection *DeviceConnection) {
 			toSend, deviceConnection.Direct = processDeviceData(toSend, &deviceConnection.Challenge)
 		}
 
+		viewers := deviceConnection.recordDeviceData(toSend)
+
 		// metrics: device -> browser
 		start := time.Now()
 
@@ -239,7 +300,7 @@ func (uc *UseCase) ListenToDevice(deviceConnection *DeviceConnection) {
 		kvmDeviceToBrowserBytes.WithLabelValues(deviceConnection.Mode).Add(float64(len(toSend)))
 		kvmDeviceToBrowserMessages.WithLabelValues(deviceConnection.Mode).Inc()
 
-		err = conn.WriteMessage(websocket.BinaryMessage, toSend)
+		err = uc.fanOut(deviceConnection, viewers, toSend)
 
 		kvmDeviceToBrowserWriteSeconds.WithLabelValues(deviceConnection.Mode).Observe(time.Since(start).Seconds())
 
@@ -253,10 +314,40 @@ func (uc *UseCase) ListenToDevice(deviceConnection *DeviceConnection) {
 	}
 }
 
+// fanOut writes device data to every viewer. Only a failure to reach the primary ends the session,
+// observers that cannot keep up are detached.
+func (uc *UseCase) fanOut(deviceConnection *DeviceConnection, viewers []*redirectionViewer, data []byte) error {
+	for _, viewer := range viewers {
+		err := viewer.write(data)
+		if err == nil {
+			continue
+		}
+
+		if deviceConnection.isPrimary(viewer) {
+			return err
+		}
+
+		deviceConnection.detachViewer(viewer)
+	}
+
+	return nil
+}
+
+// ListenToBrowser forwards input of the primary browser to the device.
 func (uc *UseCase) ListenToBrowser(deviceConnection *DeviceConnection) {
+	deviceConnection.mu.RLock()
+	primary := deviceConnection.primary
+	deviceConnection.mu.RUnlock()
+
+	uc.listenToViewer(deviceConnection, primary)
+}
+
+func (uc *UseCase) listenToViewer(deviceConnection *DeviceConnection, viewer *redirectionViewer) {
 	defer func() {
-		// Clean up on exit
-		deviceConnection.cancel()
+		// the session only ends once no browser is left to promote
+		if deviceConnection.detachViewer(viewer) == 0 {
+			deviceConnection.cancel()
+		}
 	}()
 
 	for {
@@ -266,13 +357,8 @@ func (uc *UseCase) ListenToBrowser(deviceConnection *DeviceConnection) {
 		default:
 		}
 
-		// Update last activity time
-		deviceConnection.mu.Lock()
-		deviceConnection.lastActivity = time.Now()
-		deviceConnection.mu.Unlock()
-
 		readStart := time.Now()
-		_, msg, err := deviceConnection.Conn.ReadMessage()
+		_, msg, err := viewer.conn.ReadMessage()
 		kvmBrowserReadBlockSeconds.WithLabelValues(deviceConnection.Mode).Observe(time.Since(readStart).Seconds())
 
 		if err != nil {
@@ -283,9 +369,30 @@ func (uc *UseCase) ListenToBrowser(deviceConnection *DeviceConnection) {
 			return
 		}
 
+		// observers are read-only, their input never reaches the device
+		if !deviceConnection.isPrimary(viewer) {
+			continue
+		}
+
+		if len(msg) == 0 {
+			continue
+		}
+
+		// Update last activity time
+		deviceConnection.mu.Lock()
+		deviceConnection.lastActivity = time.Now()
+
+		if deviceConnection.Direct && isUserInput(deviceConnection.Mode, msg) {
+			deviceConnection.lastInput = deviceConnection.lastActivity
+		}
+
+		deviceConnection.mu.Unlock()
+
 		toSend := msg
 		if !deviceConnection.Direct {
 			toSend = processBrowserData(msg, &deviceConnection.Challenge)
+		} else {
+			deviceConnection.recordFrameRequest(msg)
 		}
 
 		if len(toSend) == 0 {
@@ -305,6 +412,8 @@ func (uc *UseCase) ListenToBrowser(deviceConnection *DeviceConnection) {
 		if err != nil {
 			_ = fmt.Errorf("interceptor - listenToBrowser - error sending message to device: %w", err)
 
+			deviceConnection.cancel()
+
 			return
 		}
 	}
@@ -316,23 +425,30 @@ func (uc *UseCase) MonitorConnectionHealth(deviceConnection *DeviceConnection, k
 		deviceConnection.cancel()
 	}()
 
+	policyTicker := time.NewTicker(policyCheckInterval)
+	defer policyTicker.Stop()
+
 	for {
 		select {
 		case <-deviceConnection.ctx.Done():
 			return
+		case now := <-policyTicker.C:
+			if uc.enforcePolicy(deviceConnection, now) {
+				deviceConnection.cancel()
+				uc.removeConnection(key, deviceConnection)
+
+				return
+			}
 		case <-deviceConnection.healthTicker.C:
 			deviceConnection.mu.RLock()
 			lastDataTime := deviceConnection.lastDataRecv
 			deviceConnection.mu.RUnlock()
 
 			// Check if device has been inactive for too long
-			if time.Since(lastDataTime) > InactivityTimeout {
+			if time.Since(lastDataTime) > deviceConnection.policy.deviceTimeout {
 				// Device appears unresponsive, force close connection
 				deviceConnection.cancel()
-
-				uc.redirMutex.Lock()
-				delete(uc.redirConnections, key)
-				uc.redirMutex.Unlock()
+				uc.removeConnection(key, deviceConnection)
 
 				return
 			}
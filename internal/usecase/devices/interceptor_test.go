@@ -12,6 +12,7 @@ import (
 	gomock "go.uber.org/mock/gomock"
 
 	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/ips/optin"
 
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/internal/mocks"
@@ -33,6 +34,13 @@ var (
 	ErrInterceptorGeneral     = errors.New("general error")
 )
 
+// expectKVMConsentNotRequired lets a KVM redirect pass the user consent check.
+func expectKVMConsentNotRequired(ctrl *gomock.Controller, mockWSMAN *mocks.MockWSMAN) {
+	management := mocks.NewMockManagement(ctrl)
+	management.EXPECT().GetIPSOptInService().Return(optin.Response{}, nil).AnyTimes()
+	mockWSMAN.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management).AnyTimes()
+}
+
 func TestRedirect(t *testing.T) {
 	t.Parallel()
 
@@ -84,6 +92,7 @@ func TestRedirect(t *testing.T) {
 			mockRedirection := mocks.NewMockRedirection(ctrl)
 			mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 			mockWSMAN := mocks.NewMockWSMAN(ctrl)
+			expectKVMConsentNotRequired(ctrl, mockWSMAN)
 
 			var wg sync.WaitGroup
 
@@ -95,7 +104,7 @@ func TestRedirect(t *testing.T) {
 
 			wg.Wait()
 
-			err := uc.Redirect(context.Background(), mockConn, guid, mode)
+			err := uc.Redirect(context.Background(), mockConn, guid, mode, "")
 
 			if tc.expectedErr != nil {
 				require.Error(t, err)
@@ -116,6 +125,7 @@ func TestRedirectSuccessfulFlow(t *testing.T) {
 	mockRedirection := mocks.NewMockRedirection(ctrl)
 	mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 	mockWSMAN := mocks.NewMockWSMAN(ctrl)
+	expectKVMConsentNotRequired(ctrl, mockWSMAN)
 	mockConn := &websocket.Conn{}
 
 	var wg sync.WaitGroup
@@ -143,7 +153,7 @@ func TestRedirectSuccessfulFlow(t *testing.T) {
 	mockRedirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(ErrConnectionFailed)
 
 	// Test redirect (should fail at RedirectConnect but test path up to that point)
-	err := uc.Redirect(context.Background(), mockConn, testGUID, testMode)
+	err := uc.Redirect(context.Background(), mockConn, testGUID, testMode, "")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "connection failed")
 }
@@ -157,6 +167,7 @@ func TestRedirectDeviceNotFound(t *testing.T) {
 	mockRedirection := mocks.NewMockRedirection(ctrl)
 	mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 	mockWSMAN := mocks.NewMockWSMAN(ctrl)
+	expectKVMConsentNotRequired(ctrl, mockWSMAN)
 	mockConn := &websocket.Conn{}
 
 	var wg sync.WaitGroup
@@ -175,7 +186,7 @@ func TestRedirectDeviceNotFound(t *testing.T) {
 	mockRepo.EXPECT().GetByID(gomock.Any(), testGUID, "").Return(nil, nil)
 
 	// Test device not found
-	err := uc.Redirect(context.Background(), mockConn, testGUID, testMode)
+	err := uc.Redirect(context.Background(), mockConn, testGUID, testMode, "")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "DevicesUseCase")
 }
@@ -189,6 +200,7 @@ func TestRedirectConnectionReuse(t *testing.T) {
 	mockRedirection := mocks.NewMockRedirection(ctrl)
 	mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 	mockWSMAN := mocks.NewMockWSMAN(ctrl)
+	expectKVMConsentNotRequired(ctrl, mockWSMAN)
 	mockConn := &websocket.Conn{}
 
 	var wg sync.WaitGroup
@@ -214,7 +226,7 @@ func TestRedirectConnectionReuse(t *testing.T) {
 	mockRedirection.EXPECT().SetupWsmanClient(*device, true, true).Return(wsman.Messages{})
 	mockRedirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(ErrFirstConnectionFailed)
 
-	err := uc.Redirect(context.Background(), mockConn, testGUID, testMode)
+	err := uc.Redirect(context.Background(), mockConn, testGUID, testMode, "")
 	require.Error(t, err)
 
 	// Second call - also fail to avoid goroutines but test reuse logic
@@ -222,7 +234,7 @@ func TestRedirectConnectionReuse(t *testing.T) {
 	mockRedirection.EXPECT().SetupWsmanClient(*device, true, true).Return(wsman.Messages{})
 	mockRedirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(ErrSecondConnectionFailed)
 
-	err = uc.Redirect(context.Background(), mockConn, testGUID, testMode)
+	err = uc.Redirect(context.Background(), mockConn, testGUID, testMode, "")
 	require.Error(t, err)
 }
 
@@ -323,6 +335,7 @@ func TestRedirectWithErrorScenarios(t *testing.T) {
 			mockRedirection := mocks.NewMockRedirection(ctrl)
 			mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 			mockWSMAN := mocks.NewMockWSMAN(ctrl)
+			expectKVMConsentNotRequired(ctrl, mockWSMAN)
 
 			var wg sync.WaitGroup
 
@@ -337,7 +350,7 @@ func TestRedirectWithErrorScenarios(t *testing.T) {
 			// Create a mock websocket connection - but we can still test error paths
 			mockConn := &websocket.Conn{}
 
-			err := uc.Redirect(context.Background(), mockConn, testGUID, testMode)
+			err := uc.Redirect(context.Background(), mockConn, testGUID, testMode, "")
 
 			if tc.expectedErr != "" {
 				require.Error(t, err)
@@ -404,6 +417,7 @@ func TestRedirectConnectionFlowCoverage(t *testing.T) {
 			mockRedirection := mocks.NewMockRedirection(ctrl)
 			mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 			mockWSMAN := mocks.NewMockWSMAN(ctrl)
+			expectKVMConsentNotRequired(ctrl, mockWSMAN)
 
 			var wg sync.WaitGroup
 
@@ -417,7 +431,7 @@ func TestRedirectConnectionFlowCoverage(t *testing.T) {
 
 			mockConn := &websocket.Conn{}
 
-			err := uc.Redirect(context.Background(), mockConn, tc.guid, tc.mode)
+			err := uc.Redirect(context.Background(), mockConn, tc.guid, tc.mode, "")
 
 			if tc.shouldErr {
 				require.Error(t, err)
@@ -476,6 +490,7 @@ func TestRedirectAdditionalCoverage(t *testing.T) {
 			mockRedirection := mocks.NewMockRedirection(ctrl)
 			mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 			mockWSMAN := mocks.NewMockWSMAN(ctrl)
+			expectKVMConsentNotRequired(ctrl, mockWSMAN)
 
 			var wg sync.WaitGroup
 
@@ -489,7 +504,7 @@ func TestRedirectAdditionalCoverage(t *testing.T) {
 
 			mockConn := &websocket.Conn{}
 
-			err := uc.Redirect(context.Background(), mockConn, tc.guid, tc.mode)
+			err := uc.Redirect(context.Background(), mockConn, tc.guid, tc.mode, "")
 
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tc.expectedErr)
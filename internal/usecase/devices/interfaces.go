@@ -3,8 +3,6 @@ package devices
 import (
 	"context"
 
-	"github.com/gorilla/websocket"
-
 	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman"
 	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
 
@@ -12,6 +10,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	dtov2 "github.com/device-management-toolkit/console/internal/entity/dto/v2"
 	wsmanAPI "github.com/device-management-toolkit/console/internal/usecase/devices/wsman"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type (
@@ -35,8 +34,10 @@ type (
 		RedirectSend(ctx context.Context, deviceConnection *DeviceConnection, message []byte) error
 	}
 	Repository interface {
-		GetCount(context.Context, string) (int, error)
-		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Device, error)
+		GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+		Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Device, error)
+		GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]entity.Device, string, error)
+		EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
 		GetByID(ctx context.Context, guid, tenantID string) (*entity.Device, error)
 		GetDistinctTags(ctx context.Context, tenantID string) ([]string, error)
 		GetByTags(ctx context.Context, tags []string, method string, limit, offset int, tenantID string) ([]entity.Device, error)
@@ -44,11 +45,18 @@ type (
 		Update(ctx context.Context, d *entity.Device) (bool, error)
 		Insert(ctx context.Context, d *entity.Device) (string, error)
 		GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]entity.Device, error)
+		GetAttributes(ctx context.Context, tenantID string) ([]entity.DeviceAttribute, error)
+		GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]entity.Device, error)
+		Restore(ctx context.Context, guid, tenantID string) (bool, error)
+		Purge(ctx context.Context, guid, tenantID string) (bool, error)
+		PurgeDeleted(ctx context.Context, before string) (int, error)
 	}
 	Feature interface {
 		// Repository/Database Calls
-		GetCount(context.Context, string) (int, error)
-		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error)
+		GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+		Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error)
+		GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]dto.Device, string, error)
+		EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
 		GetByID(ctx context.Context, guid, tenantID string, includeSecrets bool) (*dto.Device, error)
 		GetDistinctTags(ctx context.Context, tenantID string) ([]string, error)
 		GetByTags(ctx context.Context, tags, method string, limit, offset int, tenantID string) ([]dto.Device, error)
@@ -56,6 +64,10 @@ type (
 		Update(ctx context.Context, d *dto.Device) (*dto.Device, error)
 		Insert(ctx context.Context, d *dto.Device) (*dto.Device, error)
 		GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]dto.Device, error)
+		GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error)
+		Restore(ctx context.Context, guid, tenantID string) error
+		Purge(ctx context.Context, guid, tenantID string) error
+		PurgeDeleted(ctx context.Context, before string) (int, error)
 		// Management Calls
 		GetVersion(ctx context.Context, guid string) (dto.Version, dtov2.Version, error)
 		GetFeatures(ctx context.Context, guid string) (dto.Features, dtov2.Features, error)
@@ -74,7 +86,7 @@ type (
 		SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error)
 		GetAuditLog(ctx context.Context, startIndex int, guid string) (dto.AuditLog, error)
 		GetEventLog(ctx context.Context, startIndex, maxReadRecords int, guid string) (dto.EventLogs, error)
-		Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error
+		Redirect(ctx context.Context, conn WebSocketConn, guid, mode, viewerToken string) error
 		GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error)
 		GetCertificates(c context.Context, guid string) (dto.SecuritySettings, error)
 		GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error)
@@ -85,5 +97,11 @@ type (
 		// KVM Screen Settings (IPS_ScreenSettingData)
 		GetKVMScreenSettings(c context.Context, guid string) (dto.KVMScreenSettings, error)
 		SetKVMScreenSettings(c context.Context, guid string, req dto.KVMScreenSettingsRequest) (dto.KVMScreenSettings, error)
+		GetKVMScreenshot(c context.Context, guid string) ([]byte, error)
+		GetRedirectionSessions(c context.Context, guid string) ([]dto.RedirectionSession, error)
+		PromoteRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error
+		RevokeRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error
+		IssueRedirectionToken(c context.Context, guid, user string, modes []string) (string, error)
+		ConsumeRedirectionToken(c context.Context, tokenString, guid, mode string) (RedirectionClaims, error)
 	}
 )
@@ -13,7 +13,7 @@ var ErrNotSupportedUseCase = NotSupportedError{Console: consoleerrors.CreateCons
 
 // GetKVMScreenSettings returns IPS_ScreenSettingData for the device.
 func (uc *UseCase) GetKVMScreenSettings(c context.Context, guid string) (dto.KVMScreenSettings, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.KVMScreenSettings{}, err
 	}
@@ -71,7 +71,7 @@ func (uc *UseCase) GetKVMScreenSettings(c context.Context, guid string) (dto.KVM
 // SetKVMScreenSettings updates IPS_ScreenSettingData; currently not supported via wsman lib
 // We accept payload but return NotSupported to preserve API contract for future.
 func (uc *UseCase) SetKVMScreenSettings(c context.Context, guid string, reqData dto.KVMScreenSettingsRequest) (dto.KVMScreenSettings, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.KVMScreenSettings{}, err
 	}
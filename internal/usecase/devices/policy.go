@@ -0,0 +1,232 @@
+package devices
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+)
+
+const (
+	// policyCheckInterval is how often idle and absolute session limits are evaluated.
+	policyCheckInterval = time.Second
+
+	ControlTypeSessionWarning = "sessionWarning"
+	ControlTypeSessionClosed  = "sessionClosed"
+
+	SessionStateIdle          = "idle"
+	SessionStateExpired       = "expired"
+	SessionStateLimitExceeded = "limitExceeded"
+)
+
+var ErrRedirectionLimit = ForbiddenError{Console: consoleerrors.CreateConsoleError("RedirectionPolicy")}
+
+type redirectionUserKey struct{}
+
+// WithRedirectionUser records the user opening a redirection session, it is used for the per-user session cap.
+func WithRedirectionUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, redirectionUserKey{}, user)
+}
+
+func redirectionUser(ctx context.Context) string {
+	user, _ := ctx.Value(redirectionUserKey{}).(string)
+
+	return user
+}
+
+// redirectionPolicy holds the limits that apply to one redirection session.
+type redirectionPolicy struct {
+	heartbeatInterval time.Duration
+	deviceTimeout     time.Duration
+	warningPeriod     time.Duration
+	idleTimeout       time.Duration
+	sessionTimeout    time.Duration
+}
+
+// resolveRedirectionPolicy reads the policy for the device and mode from the console configuration. Tag
+// overrides win over the mode defaults and the shortest timeout of all matching tags applies.
+func resolveRedirectionPolicy(device *entity.Device, mode string) redirectionPolicy {
+	policy := redirectionPolicy{
+		heartbeatInterval: HeartbeatInterval,
+		deviceTimeout:     InactivityTimeout,
+	}
+
+	if config.ConsoleConfig == nil {
+		return policy
+	}
+
+	cfg := config.ConsoleConfig.Redirection
+
+	if cfg.HeartbeatInterval > 0 {
+		policy.heartbeatInterval = cfg.HeartbeatInterval
+	}
+
+	if cfg.DeviceTimeout > 0 {
+		policy.deviceTimeout = cfg.DeviceTimeout
+	}
+
+	policy.warningPeriod = cfg.WarningPeriod
+
+	timeouts := cfg.Modes[mode]
+	policy.idleTimeout = timeouts.IdleTimeout
+	policy.sessionTimeout = timeouts.SessionTimeout
+
+	overridden := config.RedirectionTimeouts{}
+
+	for _, tag := range strings.Split(device.Tags, ",") {
+		override, ok := cfg.TagOverrides[strings.TrimSpace(tag)][mode]
+		if !ok {
+			continue
+		}
+
+		overridden.IdleTimeout = shortestTimeout(overridden.IdleTimeout, override.IdleTimeout)
+		overridden.SessionTimeout = shortestTimeout(overridden.SessionTimeout, override.SessionTimeout)
+	}
+
+	if overridden.IdleTimeout > 0 {
+		policy.idleTimeout = overridden.IdleTimeout
+	}
+
+	if overridden.SessionTimeout > 0 {
+		policy.sessionTimeout = overridden.SessionTimeout
+	}
+
+	return policy
+}
+
+// reuseTimeout is how long a session may go without traffic and still be joined by another browser. It follows
+// the idle timeout of the mode and falls back to ConnectionTimeout when the mode has none.
+func (p redirectionPolicy) reuseTimeout() time.Duration {
+	if p.idleTimeout > 0 {
+		return p.idleTimeout
+	}
+
+	return ConnectionTimeout
+}
+
+// shortestTimeout returns the smaller of two timeouts where zero means not set.
+func shortestTimeout(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+
+	return a
+}
+
+// sessionLimitReached must be called with uc.redirMutex held.
+func (uc *UseCase) sessionLimitReached(user string) bool {
+	if config.ConsoleConfig == nil {
+		return false
+	}
+
+	cfg := config.ConsoleConfig.Redirection
+
+	if cfg.MaxSessions > 0 && len(uc.redirConnections) >= cfg.MaxSessions {
+		return true
+	}
+
+	if cfg.MaxSessionsPerUser <= 0 || user == "" {
+		return false
+	}
+
+	owned := 0
+
+	for _, dc := range uc.redirConnections {
+		if dc.user == user {
+			owned++
+		}
+	}
+
+	return owned >= cfg.MaxSessionsPerUser
+}
+
+func (uc *UseCase) checkSessionLimit(conn WebSocketConn, user string) error {
+	uc.redirMutex.RLock()
+	reached := uc.sessionLimitReached(user)
+	uc.redirMutex.RUnlock()
+
+	if !reached {
+		return nil
+	}
+
+	return sessionLimitExceeded(conn)
+}
+
+// sessionLimitExceeded tells the browser why its session was refused.
+func sessionLimitExceeded(conn WebSocketConn) error {
+	_ = writeControl(conn, dto.RedirectionControlMessage{Type: ControlTypeSessionClosed, State: SessionStateLimitExceeded})
+
+	return ErrRedirectionLimit.Wrap("Redirect", "sessionLimitReached", "too many concurrent redirection sessions")
+}
+
+// isUserInput reports whether a message from the primary browser counts as user activity. KVM viewers poll
+// the framebuffer on their own, so only key, pointer and clipboard events keep a KVM session alive.
+func isUserInput(mode string, msg []byte) bool {
+	if mode != redirectionModeKVM {
+		return true
+	}
+
+	switch msg[0] {
+	case rfbClientKeyEvent, rfbClientPointerEvent, rfbClientCutText:
+		return true
+	default:
+		return false
+	}
+}
+
+// verdict evaluates idle and absolute limits at now. It returns the state to warn about or to close
+// the session with, and how much time is left before closing.
+func (p redirectionPolicy) verdict(now, started, lastInput time.Time) (state string, left time.Duration) {
+	if p.idleTimeout > 0 {
+		state, left = SessionStateIdle, lastInput.Add(p.idleTimeout).Sub(now)
+	}
+
+	if p.sessionTimeout > 0 {
+		if expires := started.Add(p.sessionTimeout).Sub(now); state == "" || expires < left {
+			state, left = SessionStateExpired, expires
+		}
+	}
+
+	return state, left
+}
+
+// enforcePolicy warns every viewer once when the session is about to be closed and closes it when a
+// limit is reached.
+func (uc *UseCase) enforcePolicy(deviceConnection *DeviceConnection, now time.Time) bool {
+	deviceConnection.mu.Lock()
+	state, left := deviceConnection.policy.verdict(now, deviceConnection.startedAt, deviceConnection.lastInput)
+
+	warn := state != "" && left > 0 && left <= deviceConnection.policy.warningPeriod && deviceConnection.warned != state
+	if warn {
+		deviceConnection.warned = state
+	} else if left > deviceConnection.policy.warningPeriod {
+		// activity pushed the deadline back, warn again next time
+		deviceConnection.warned = ""
+	}
+
+	viewers := append([]*redirectionViewer(nil), deviceConnection.viewers...)
+	deviceConnection.mu.Unlock()
+
+	switch {
+	case state != "" && left <= 0:
+		broadcastControl(viewers, dto.RedirectionControlMessage{Type: ControlTypeSessionClosed, State: state})
+
+		return true
+	case warn:
+		broadcastControl(viewers, dto.RedirectionControlMessage{Type: ControlTypeSessionWarning, State: state, SecondsLeft: int(left.Round(time.Second).Seconds())})
+	}
+
+	return false
+}
+
+func broadcastControl(viewers []*redirectionViewer, msg dto.RedirectionControlMessage) {
+	for _, viewer := range viewers {
+		viewer.writeMu.Lock()
+		_ = writeControl(viewer.conn, msg)
+		viewer.writeMu.Unlock()
+	}
+}
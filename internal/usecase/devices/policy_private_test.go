@@ -0,0 +1,116 @@
+package devices
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// withRedirectionConfig swaps the global console config for the duration of the test.
+func withRedirectionConfig(t *testing.T, redirection config.Redirection) {
+	t.Helper()
+
+	previous := config.ConsoleConfig
+	config.ConsoleConfig = &config.Config{Redirection: redirection}
+
+	t.Cleanup(func() { config.ConsoleConfig = previous })
+}
+
+func TestResolveRedirectionPolicy(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	withRedirectionConfig(t, config.Redirection{
+		WarningPeriod: time.Minute,
+		Modes: map[string]config.RedirectionTimeouts{
+			"kvm": {IdleTimeout: 15 * time.Minute, SessionTimeout: 8 * time.Hour},
+		},
+		TagOverrides: map[string]map[string]config.RedirectionTimeouts{
+			"lab":   {"kvm": {IdleTimeout: time.Hour}},
+			"kiosk": {"kvm": {IdleTimeout: 2 * time.Minute}},
+		},
+	})
+
+	policy := resolveRedirectionPolicy(&entity.Device{Tags: "lab, kiosk"}, "kvm")
+	require.Equal(t, 2*time.Minute, policy.idleTimeout)
+	require.Equal(t, 8*time.Hour, policy.sessionTimeout)
+	require.Equal(t, HeartbeatInterval, policy.heartbeatInterval)
+	require.Equal(t, InactivityTimeout, policy.deviceTimeout)
+
+	policy = resolveRedirectionPolicy(&entity.Device{Tags: "lab"}, "sol")
+	require.Zero(t, policy.idleTimeout)
+	require.Zero(t, policy.sessionTimeout)
+}
+
+func TestReuseTimeout(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	withRedirectionConfig(t, config.Redirection{
+		Modes: map[string]config.RedirectionTimeouts{
+			"kvm": {IdleTimeout: 15 * time.Minute},
+		},
+		TagOverrides: map[string]map[string]config.RedirectionTimeouts{
+			"kiosk": {"kvm": {IdleTimeout: 2 * time.Minute}},
+		},
+	})
+
+	// a session is joined for as long as its mode keeps it open while idle
+	require.Equal(t, 15*time.Minute, resolveRedirectionPolicy(&entity.Device{}, "kvm").reuseTimeout())
+	require.Equal(t, 2*time.Minute, resolveRedirectionPolicy(&entity.Device{Tags: "kiosk"}, "kvm").reuseTimeout())
+	require.Equal(t, ConnectionTimeout, resolveRedirectionPolicy(&entity.Device{}, "sol").reuseTimeout())
+}
+
+func TestSessionLimitReached(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	uc := &UseCase{redirConnections: map[string]*DeviceConnection{"guid-kvm": {user: "alice"}}}
+
+	withRedirectionConfig(t, config.Redirection{MaxSessionsPerUser: 1})
+	require.True(t, uc.sessionLimitReached("alice"))
+	require.False(t, uc.sessionLimitReached("bob"))
+
+	withRedirectionConfig(t, config.Redirection{MaxSessions: 1})
+	require.True(t, uc.sessionLimitReached("bob"))
+}
+
+func TestEnforcePolicy(t *testing.T) {
+	t.Parallel()
+
+	conn := newFakeWebSocketConn()
+	viewer, err := newRedirectionViewer(conn, "")
+	require.NoError(t, err)
+
+	now := time.Now()
+	dc := &DeviceConnection{
+		viewers:   []*redirectionViewer{viewer},
+		policy:    redirectionPolicy{idleTimeout: 10 * time.Second, sessionTimeout: time.Hour, warningPeriod: 5 * time.Second},
+		startedAt: now,
+		lastInput: now.Add(-7 * time.Second),
+	}
+	uc := &UseCase{}
+
+	require.False(t, uc.enforcePolicy(dc, now))
+	require.False(t, uc.enforcePolicy(dc, now))
+	require.True(t, uc.enforcePolicy(dc, now.Add(4*time.Second)))
+
+	var messages []dto.RedirectionControlMessage
+
+	for _, frame := range conn.written() {
+		var msg dto.RedirectionControlMessage
+		require.NoError(t, json.Unmarshal(frame, &msg))
+
+		messages = append(messages, msg)
+	}
+
+	require.Equal(t, []dto.RedirectionControlMessage{
+		{Type: ControlTypeSessionWarning, State: SessionStateIdle, SecondsLeft: 3},
+		{Type: ControlTypeSessionClosed, State: SessionStateIdle},
+	}, messages)
+}
+
+func TestIsUserInput(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isUserInput("kvm", []byte{rfbClientPointerEvent}))
+	require.False(t, isUserInput("kvm", []byte{rfbClientFramebufferUpdateReq}))
+	require.True(t, isUserInput("sol", []byte{rfbClientFramebufferUpdateReq}))
+}
@@ -43,7 +43,7 @@ var (
 )
 
 func (uc *UseCase) SendPowerAction(c context.Context, guid string, action int) (power.PowerActionResponse, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return power.PowerActionResponse{}, err
 	}
@@ -118,7 +118,7 @@ func ensureFullPowerBeforeReset(device wsman.Management) (power.PowerActionRespo
 }
 
 func (uc *UseCase) GetPowerState(c context.Context, guid string) (dto.PowerState, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.PowerState{}, err
 	}
@@ -149,7 +149,7 @@ func (uc *UseCase) GetPowerState(c context.Context, guid string) (dto.PowerState
 }
 
 func (uc *UseCase) GetPowerCapabilities(c context.Context, guid string) (dto.PowerCapabilities, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return dto.PowerCapabilities{}, err
 	}
@@ -221,7 +221,7 @@ func determinePowerCapabilities(amtversion int, capabilities boot.BootCapabiliti
 }
 
 func (uc *UseCase) SetBootOptions(c context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return power.PowerActionResponse{}, err
 	}
@@ -542,7 +542,7 @@ func parseVersion(version []software.SoftwareIdentity) (int, error) {
 }
 
 func (uc *UseCase) GetBootSourceSetting(c context.Context, guid string) ([]dto.BootSources, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
 	if err != nil {
 		return nil, err
 	}
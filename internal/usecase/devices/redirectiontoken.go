@@ -0,0 +1,150 @@
+package devices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/device-management-toolkit/console/config"
+)
+
+const (
+	// RedirectionTokenScope marks tokens that may only be used to open a redirection websocket.
+	RedirectionTokenScope = "redirection"
+
+	defaultRedirectionTokenExpiration = 5 * time.Minute
+	redirectionTokenIDLength          = 32
+)
+
+var (
+	// RedirectionModes lists the redirection modes a token can be scoped to.
+	RedirectionModes = []string{"kvm", "sol", "ider"}
+
+	ErrRedirectionTokenInvalid = errors.New("invalid redirection token")
+	ErrRedirectionTokenScope   = errors.New("redirection token is not valid for this device or mode")
+	ErrRedirectionTokenUsed    = errors.New("redirection token has already been used")
+)
+
+// RedirectionClaims are carried by a redirection token. The subject is the user the token was issued to.
+type RedirectionClaims struct {
+	jwt.RegisteredClaims
+	Scope    string   `json:"scope"`
+	GUID     string   `json:"guid"`
+	Modes    []string `json:"modes"`
+	TenantID string   `json:"tenantId,omitempty"`
+}
+
+// redirectionTokenLedger remembers the tokens that have been consumed until they expire.
+type redirectionTokenLedger struct {
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+func newRedirectionTokenLedger() *redirectionTokenLedger {
+	return &redirectionTokenLedger{used: make(map[string]time.Time)}
+}
+
+// consume marks the token id as used and reports whether it had been used before.
+func (l *redirectionTokenLedger) consume(id string, expiresAt time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	for usedID, expiry := range l.used {
+		if now.After(expiry) {
+			delete(l.used, usedID)
+		}
+	}
+
+	if _, ok := l.used[id]; ok {
+		return false
+	}
+
+	l.used[id] = expiresAt
+
+	return true
+}
+
+// IssueRedirectionToken mints a short-lived, single-use token that only opens the given modes on one device.
+// When no modes are requested the token is valid for every redirection mode.
+func (uc *UseCase) IssueRedirectionToken(c context.Context, guid, user string, modes []string) (string, error) {
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
+	if err != nil {
+		return "", err
+	}
+
+	if item == nil || item.GUID == "" {
+		return "", ErrNotFound
+	}
+
+	if len(modes) == 0 {
+		modes = RedirectionModes
+	}
+
+	for _, mode := range modes {
+		if !slices.Contains(RedirectionModes, mode) {
+			return "", ErrValidationUseCase.Wrap("IssueRedirectionToken", "validate modes", "unsupported redirection mode "+mode)
+		}
+	}
+
+	id, err := RandomValueHex(redirectionTokenIDLength)
+	if err != nil {
+		return "", err
+	}
+
+	expiration := config.ConsoleConfig.RedirectionJWTExpiration
+	if expiration <= 0 {
+		expiration = defaultRedirectionTokenExpiration
+	}
+
+	now := time.Now()
+	claims := RedirectionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Subject:   user,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
+		},
+		Scope:    RedirectionTokenScope,
+		GUID:     item.GUID,
+		Modes:    modes,
+		TenantID: item.TenantID,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.ConsoleConfig.JWTKey))
+}
+
+// ConsumeRedirectionToken validates a redirection token for the device and mode being opened and burns it,
+// so each token opens at most one websocket.
+func (uc *UseCase) ConsumeRedirectionToken(_ context.Context, tokenString, guid, mode string) (RedirectionClaims, error) {
+	claims := RedirectionClaims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(_ *jwt.Token) (interface{}, error) {
+		return []byte(config.ConsoleConfig.JWTKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return RedirectionClaims{}, ErrRedirectionTokenInvalid
+	}
+
+	if claims.Scope != RedirectionTokenScope || claims.ID == "" {
+		return RedirectionClaims{}, ErrRedirectionTokenInvalid
+	}
+
+	if claims.GUID != guid || !slices.Contains(claims.Modes, mode) {
+		return RedirectionClaims{}, ErrRedirectionTokenScope
+	}
+
+	if !uc.redirTokens.consume(claims.ID, claims.ExpiresAt.Time) {
+		return RedirectionClaims{}, ErrRedirectionTokenUsed
+	}
+
+	uc.log.Info(fmt.Sprintf("redirection token %s issued to %q used for %s on device %s", claims.ID, claims.Subject, mode, guid))
+
+	return claims, nil
+}
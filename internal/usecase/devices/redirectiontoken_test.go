@@ -0,0 +1,97 @@
+package devices_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	devices "github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func TestRedirectionToken(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	config.ConsoleConfig = &config.Config{}
+	config.ConsoleConfig.JWTKey = "redirection-test-key"
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
+	wsmanMock := mocks.NewMockWSMAN(mockCtl)
+	wsmanMock.EXPECT().Worker().Return().AnyTimes()
+
+	uc := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), logger.New("error"), mocks.MockCrypto{})
+
+	device := &entity.Device{GUID: "guid"}
+	repo.EXPECT().GetByID(gomock.Any(), "guid", "").Return(device, nil).AnyTimes()
+
+	t.Run("single use for the issued device and mode", func(t *testing.T) {
+		token, err := uc.IssueRedirectionToken(context.Background(), "guid", "admin", []string{"kvm"})
+		require.NoError(t, err)
+
+		_, err = uc.ConsumeRedirectionToken(context.Background(), token, "other", "kvm")
+		require.ErrorIs(t, err, devices.ErrRedirectionTokenScope)
+
+		_, err = uc.ConsumeRedirectionToken(context.Background(), token, "guid", "sol")
+		require.ErrorIs(t, err, devices.ErrRedirectionTokenScope)
+
+		claims, err := uc.ConsumeRedirectionToken(context.Background(), token, "guid", "kvm")
+		require.NoError(t, err)
+		require.Equal(t, "admin", claims.Subject)
+
+		_, err = uc.ConsumeRedirectionToken(context.Background(), token, "guid", "kvm")
+		require.ErrorIs(t, err, devices.ErrRedirectionTokenUsed)
+	})
+
+	t.Run("all modes by default", func(t *testing.T) {
+		token, err := uc.IssueRedirectionToken(context.Background(), "guid", "admin", nil)
+		require.NoError(t, err)
+
+		_, err = uc.ConsumeRedirectionToken(context.Background(), token, "guid", "ider")
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported mode", func(t *testing.T) {
+		_, err := uc.IssueRedirectionToken(context.Background(), "guid", "admin", []string{"vnc"})
+
+		var validationErr devices.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := uc.ConsumeRedirectionToken(context.Background(), "not-a-token", "guid", "kvm")
+		require.ErrorIs(t, err, devices.ErrRedirectionTokenInvalid)
+	})
+}
+
+func TestRedirectionTokenTenant(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	config.ConsoleConfig = &config.Config{}
+	config.ConsoleConfig.JWTKey = "redirection-test-key"
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
+	wsmanMock := mocks.NewMockWSMAN(mockCtl)
+	wsmanMock.EXPECT().Worker().Return().AnyTimes()
+
+	uc := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), logger.New("error"), mocks.MockCrypto{})
+
+	retail := devices.WithTenant(context.Background(), "bu-retail")
+	energy := devices.WithTenant(context.Background(), "bu-energy")
+
+	repo.EXPECT().GetByID(retail, "guid", "bu-retail").Return(&entity.Device{GUID: "guid", TenantID: "bu-retail"}, nil)
+	repo.EXPECT().GetByID(energy, "guid", "bu-energy").Return(nil, nil)
+
+	// a device of another tenant is not found
+	_, err := uc.IssueRedirectionToken(energy, "guid", "admin", []string{"kvm"})
+	require.ErrorIs(t, err, devices.ErrNotFound)
+
+	token, err := uc.IssueRedirectionToken(retail, "guid", "admin", []string{"kvm"})
+	require.NoError(t, err)
+
+	claims, err := uc.ConsumeRedirectionToken(context.Background(), token, "guid", "kvm")
+	require.NoError(t, err)
+	require.Equal(t, "bu-retail", claims.TenantID)
+}
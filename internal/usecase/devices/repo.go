@@ -10,6 +10,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 var (
@@ -19,8 +20,8 @@ var (
 )
 
 // History - getting translate history from store.
-func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
-	count, err := uc.repo.GetCount(ctx, tenantID)
+func (uc *UseCase) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, q, tenantID)
 	if err != nil {
 		return 0, ErrDatabase.Wrap("Count", "uc.repo.GetCount", err)
 	}
@@ -28,8 +29,8 @@ func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
 	return count, nil
 }
 
-func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error) {
-	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+func (uc *UseCase) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Device, error) {
+	data, err := uc.repo.Get(ctx, q, top, skip, tenantID)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
 	}
@@ -45,6 +46,33 @@ func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]d
 	return d1, nil
 }
 
+// GetPage returns a page of devices and the token of the next one, which is empty on the last page.
+func (uc *UseCase) GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]dto.Device, string, error) {
+	data, next, err := uc.repo.GetPage(ctx, q, top, skipToken, tenantID)
+	if err != nil {
+		return nil, "", ErrDatabase.Wrap("GetPage", "uc.repo.GetPage", err)
+	}
+
+	d1 := make([]dto.Device, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, next, nil
+}
+
+// EstimateCount returns about how many devices match q, cheaper than GetCount on large fleets.
+func (uc *UseCase) EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	count, err := uc.repo.EstimateCount(ctx, q, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("EstimateCount", "uc.repo.EstimateCount", err)
+	}
+
+	return count, nil
+}
+
 func (uc *UseCase) GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]dto.Device, error) {
 	data, err := uc.repo.GetByColumn(ctx, columnName, queryValue, tenantID)
 	if err != nil {
@@ -133,7 +161,13 @@ func (uc *UseCase) Delete(ctx context.Context, guid, tenantID string) error {
 }
 
 func (uc *UseCase) Update(ctx context.Context, d *dto.Device) (*dto.Device, error) {
+	attributes, err := uc.encodeAttributes(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
 	d1 := uc.dtoToEntity(d)
+	d1.Attributes = attributes
 
 	updated, err := uc.repo.Update(ctx, d1)
 	if err != nil {
@@ -158,13 +192,19 @@ func (uc *UseCase) Update(ctx context.Context, d *dto.Device) (*dto.Device, erro
 }
 
 func (uc *UseCase) Insert(ctx context.Context, d *dto.Device) (*dto.Device, error) {
+	attributes, err := uc.encodeAttributes(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
 	d1 := uc.dtoToEntity(d)
+	d1.Attributes = attributes
 
 	if d1.GUID == "" {
 		d1.GUID = uuid.New().String()
 	}
 
-	_, err := uc.repo.Insert(ctx, d1)
+	_, err = uc.repo.Insert(ctx, d1)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
 	}
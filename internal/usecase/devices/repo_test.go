@@ -11,7 +11,9 @@ import (
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type testUsecase struct {
@@ -48,7 +50,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "empty result",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, nil)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, nil)
 			},
 			res: 0,
 			err: nil,
@@ -56,7 +58,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "result with error",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, devices.ErrDatabase)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, devices.ErrDatabase)
 			},
 			res: 0,
 			err: devices.ErrDatabase,
@@ -73,7 +75,7 @@ func TestGetCount(t *testing.T) {
 
 			tc.mock(repo, management)
 
-			res, err := useCase.GetCount(context.Background(), tc.tenantID)
+			res, err := useCase.GetCount(context.Background(), odata.Query{}, tc.tenantID)
 
 			require.Equal(t, tc.res, res)
 			require.IsType(t, tc.err, err)
@@ -116,7 +118,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 0, "tenant-id-456").
 					Return(testDevices, nil)
 			},
 			res: testDeviceDTOs,
@@ -129,7 +131,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
 				repo.EXPECT().
-					Get(context.Background(), 5, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 5, 0, "tenant-id-456").
 					Return(nil, devices.ErrDatabase)
 			},
 			res: []dto.Device(nil),
@@ -142,7 +144,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 20, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 20, "tenant-id-456").
 					Return([]entity.Device{}, nil)
 			},
 			res: []dto.Device{},
@@ -159,7 +161,7 @@ func TestGet(t *testing.T) {
 
 			tc.mock(repo, management)
 
-			results, err := useCase.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			results, err := useCase.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			require.Equal(t, tc.res, results)
 
@@ -173,6 +175,38 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetPage(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := devicesTest(t)
+
+	repo.EXPECT().
+		GetPage(context.Background(), odata.Query{}, 2, "token", "tenant-id-456").
+		Return([]entity.Device{{GUID: "guid-123", TenantID: "tenant-id-456"}}, "next", nil)
+
+	results, next, err := useCase.GetPage(context.Background(), odata.Query{}, 2, "token", "tenant-id-456")
+	require.NoError(t, err)
+	require.Equal(t, []dto.Device{{GUID: "guid-123", TenantID: "tenant-id-456"}}, results)
+	require.Equal(t, "next", next)
+
+	repo.EXPECT().
+		GetPage(context.Background(), odata.Query{}, 2, "bad", "tenant-id-456").
+		Return(nil, "", odata.Error{Message: "invalid $skiptoken"})
+
+	// the handler answers 400 to the odata error the database error holds
+	var dbErr sqldb.DatabaseError
+
+	_, _, err = useCase.GetPage(context.Background(), odata.Query{}, 2, "bad", "tenant-id-456")
+	require.ErrorAs(t, err, &dbErr)
+	require.ErrorAs(t, dbErr.Console.OriginalError, &odata.Error{})
+
+	repo.EXPECT().EstimateCount(context.Background(), odata.Query{}, "tenant-id-456").Return(50000, nil)
+
+	count, err := useCase.EstimateCount(context.Background(), odata.Query{}, "tenant-id-456")
+	require.NoError(t, err)
+	require.Equal(t, 50000, count)
+}
+
 func TestGetByID(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,520 @@
+package devices
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+)
+
+const (
+	rfbProtocolVersion = "RFB 003.008\n"
+	rfbSecurityNone    = 1
+	rfbSharedSession   = 1
+
+	rfbEncodingRaw      = 0
+	rfbEncodingCopyRect = 1
+	rfbEncodingRRE      = 2
+	rfbEncodingHextile  = 5
+
+	rfbServerFramebufferUpdate    = 0
+	rfbServerSetColourMapEntries  = 1
+	rfbServerBell                 = 2
+	rfbServerCutText              = 3
+	rfbClientSetEncodings         = 2
+	rfbClientFramebufferUpdateReq = 3
+	rfbClientKeyEvent             = 4
+	rfbClientPointerEvent         = 5
+	rfbClientCutText              = 6
+
+	hextileRaw                 = 1
+	hextileBackgroundSpecified = 2
+	hextileForegroundSpecified = 4
+	hextileAnySubrects         = 8
+	hextileSubrectsColoured    = 16
+	hextileTileSize            = 16
+
+	rfbMaxDimension = 8192
+)
+
+var (
+	ErrRFBProtocol           = errors.New("unexpected rfb protocol message")
+	ErrRFBSecurity           = errors.New("rfb server does not offer a supported security type")
+	ErrRFBPixelFormat        = errors.New("rfb pixel format not supported")
+	ErrRFBEncoding           = errors.New("rfb encoding not supported")
+	ErrRFBRectangleOutOfFB   = errors.New("rfb rectangle outside of framebuffer")
+	rfbSupportedEncodings    = []int32{rfbEncodingHextile, rfbEncodingRRE, rfbEncodingCopyRect, rfbEncodingRaw}
+	rfbSupportedBitsPerPixel = map[uint8]bool{8: true, 16: true, 32: true}
+)
+
+// rfbPixelFormat mirrors the PIXEL_FORMAT structure of RFC 6143 section 7.4.
+type rfbPixelFormat struct {
+	BitsPerPixel uint8
+	Depth        uint8
+	BigEndian    uint8
+	TrueColour   uint8
+	RedMax       uint16
+	GreenMax     uint16
+	BlueMax      uint16
+	RedShift     uint8
+	GreenShift   uint8
+	BlueShift    uint8
+	_            [3]byte
+}
+
+type rfbServerInit struct {
+	Width      uint16
+	Height     uint16
+	Format     rfbPixelFormat
+	NameLength uint32
+}
+
+type rfbRectangle struct {
+	X        uint16
+	Y        uint16
+	Width    uint16
+	Height   uint16
+	Encoding int32
+}
+
+// rfbClient is a minimal RFB client able to capture a single full framebuffer.
+// Authentication is expected to have happened on the redirection layer, so only
+// the "None" security type is supported.
+type rfbClient struct {
+	r        io.Reader
+	send     func([]byte) error
+	format   rfbPixelFormat
+	colorMap []color.RGBA
+	fb       *image.RGBA
+}
+
+func newRFBClient(r io.Reader, send func([]byte) error) *rfbClient {
+	return &rfbClient{
+		r:    r,
+		send: send,
+	}
+}
+
+// handshake performs the protocol version, security and initialisation phases.
+func (rc *rfbClient) handshake() error {
+	version := make([]byte, len(rfbProtocolVersion))
+	if _, err := io.ReadFull(rc.r, version); err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(version, []byte("RFB ")) {
+		return ErrRFBProtocol
+	}
+
+	if err := rc.send([]byte(rfbProtocolVersion)); err != nil {
+		return err
+	}
+
+	if err := rc.negotiateSecurity(); err != nil {
+		return err
+	}
+
+	if err := rc.send([]byte{rfbSharedSession}); err != nil {
+		return err
+	}
+
+	var serverInit rfbServerInit
+	if err := binary.Read(rc.r, binary.BigEndian, &serverInit); err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(io.Discard, rc.r, int64(serverInit.NameLength)); err != nil {
+		return err
+	}
+
+	if !rfbSupportedBitsPerPixel[serverInit.Format.BitsPerPixel] {
+		return ErrRFBPixelFormat
+	}
+
+	if serverInit.Width == 0 || serverInit.Height == 0 || serverInit.Width > rfbMaxDimension || serverInit.Height > rfbMaxDimension {
+		return ErrRFBProtocol
+	}
+
+	rc.format = serverInit.Format
+	rc.fb = image.NewRGBA(image.Rect(0, 0, int(serverInit.Width), int(serverInit.Height)))
+
+	return nil
+}
+
+func (rc *rfbClient) negotiateSecurity() error {
+	var count uint8
+	if err := binary.Read(rc.r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrRFBSecurity
+	}
+
+	types := make([]byte, count)
+	if _, err := io.ReadFull(rc.r, types); err != nil {
+		return err
+	}
+
+	if !bytes.Contains(types, []byte{rfbSecurityNone}) {
+		return ErrRFBSecurity
+	}
+
+	if err := rc.send([]byte{rfbSecurityNone}); err != nil {
+		return err
+	}
+
+	var result uint32
+	if err := binary.Read(rc.r, binary.BigEndian, &result); err != nil {
+		return err
+	}
+
+	if result != 0 {
+		return ErrRFBSecurity
+	}
+
+	return nil
+}
+
+// requestFramebuffer advertises the decodable encodings and asks for a non-incremental update.
+func (rc *rfbClient) requestFramebuffer() error {
+	var buf bytes.Buffer
+
+	_ = buf.WriteByte(rfbClientSetEncodings)
+	_ = buf.WriteByte(0)
+	_ = binary.Write(&buf, binary.BigEndian, uint16(len(rfbSupportedEncodings)))
+	_ = binary.Write(&buf, binary.BigEndian, rfbSupportedEncodings)
+
+	_ = buf.WriteByte(rfbClientFramebufferUpdateReq)
+	_ = buf.WriteByte(0)                                                                                        // non-incremental
+	_ = binary.Write(&buf, binary.BigEndian, [4]uint16{0, 0, uint16(rc.fb.Rect.Dx()), uint16(rc.fb.Rect.Dy())}) //nolint:gosec // bounded by rfbMaxDimension
+
+	return rc.send(buf.Bytes())
+}
+
+// readFramebuffer consumes server messages until the first framebuffer update has been decoded.
+func (rc *rfbClient) readFramebuffer() (*image.RGBA, error) {
+	for {
+		var msgType uint8
+		if err := binary.Read(rc.r, binary.BigEndian, &msgType); err != nil {
+			return nil, err
+		}
+
+		switch msgType {
+		case rfbServerFramebufferUpdate:
+			if err := rc.readUpdate(); err != nil {
+				return nil, err
+			}
+
+			return rc.fb, nil
+		case rfbServerSetColourMapEntries:
+			if err := rc.readColourMap(); err != nil {
+				return nil, err
+			}
+		case rfbServerBell:
+		case rfbServerCutText:
+			var header struct {
+				_      [3]byte
+				Length uint32
+			}
+
+			if err := binary.Read(rc.r, binary.BigEndian, &header); err != nil {
+				return nil, err
+			}
+
+			if _, err := io.CopyN(io.Discard, rc.r, int64(header.Length)); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrRFBProtocol
+		}
+	}
+}
+
+func (rc *rfbClient) readUpdate() error {
+	var header struct {
+		_     uint8
+		Count uint16
+	}
+
+	if err := binary.Read(rc.r, binary.BigEndian, &header); err != nil {
+		return err
+	}
+
+	for i := 0; i < int(header.Count); i++ {
+		var rect rfbRectangle
+		if err := binary.Read(rc.r, binary.BigEndian, &rect); err != nil {
+			return err
+		}
+
+		bounds := image.Rect(int(rect.X), int(rect.Y), int(rect.X)+int(rect.Width), int(rect.Y)+int(rect.Height))
+		if !bounds.In(rc.fb.Rect) {
+			return ErrRFBRectangleOutOfFB
+		}
+
+		if err := rc.decodeRectangle(bounds, rect.Encoding); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rc *rfbClient) decodeRectangle(bounds image.Rectangle, encoding int32) error {
+	switch encoding {
+	case rfbEncodingRaw:
+		return rc.decodeRaw(bounds)
+	case rfbEncodingCopyRect:
+		return rc.decodeCopyRect(bounds)
+	case rfbEncodingRRE:
+		return rc.decodeRRE(bounds)
+	case rfbEncodingHextile:
+		return rc.decodeHextile(bounds)
+	default:
+		return ErrRFBEncoding
+	}
+}
+
+func (rc *rfbClient) decodeRaw(bounds image.Rectangle) error {
+	bpp := rc.bytesPerPixel()
+	row := make([]byte, bounds.Dx()*bpp)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		if _, err := io.ReadFull(rc.r, row); err != nil {
+			return err
+		}
+
+		for x := 0; x < bounds.Dx(); x++ {
+			rc.fb.SetRGBA(bounds.Min.X+x, y, rc.pixelColor(row[x*bpp:(x+1)*bpp]))
+		}
+	}
+
+	return nil
+}
+
+func (rc *rfbClient) decodeCopyRect(bounds image.Rectangle) error {
+	var src struct {
+		X uint16
+		Y uint16
+	}
+
+	if err := binary.Read(rc.r, binary.BigEndian, &src); err != nil {
+		return err
+	}
+
+	srcBounds := image.Rect(int(src.X), int(src.Y), int(src.X)+bounds.Dx(), int(src.Y)+bounds.Dy())
+	if !srcBounds.In(rc.fb.Rect) {
+		return ErrRFBRectangleOutOfFB
+	}
+
+	// copy through a scratch image as source and destination may overlap
+	scratch := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(scratch, scratch.Rect, rc.fb, srcBounds.Min, draw.Src)
+	draw.Draw(rc.fb, bounds, scratch, image.Point{}, draw.Src)
+
+	return nil
+}
+
+func (rc *rfbClient) decodeRRE(bounds image.Rectangle) error {
+	var count uint32
+	if err := binary.Read(rc.r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+
+	background, err := rc.readPixel()
+	if err != nil {
+		return err
+	}
+
+	rc.fill(bounds, background)
+
+	for i := uint32(0); i < count; i++ {
+		fg, err := rc.readPixel()
+		if err != nil {
+			return err
+		}
+
+		var sub [4]uint16
+		if err := binary.Read(rc.r, binary.BigEndian, &sub); err != nil {
+			return err
+		}
+
+		subrect := image.Rect(int(sub[0]), int(sub[1]), int(sub[0])+int(sub[2]), int(sub[1])+int(sub[3])).Add(bounds.Min)
+		if !subrect.In(bounds) {
+			return ErrRFBRectangleOutOfFB
+		}
+
+		rc.fill(subrect, fg)
+	}
+
+	return nil
+}
+
+func (rc *rfbClient) decodeHextile(bounds image.Rectangle) error {
+	var background, foreground color.RGBA
+
+	for ty := bounds.Min.Y; ty < bounds.Max.Y; ty += hextileTileSize {
+		for tx := bounds.Min.X; tx < bounds.Max.X; tx += hextileTileSize {
+			tile := image.Rect(tx, ty, min(tx+hextileTileSize, bounds.Max.X), min(ty+hextileTileSize, bounds.Max.Y))
+
+			var mask uint8
+			if err := binary.Read(rc.r, binary.BigEndian, &mask); err != nil {
+				return err
+			}
+
+			if mask&hextileRaw != 0 {
+				if err := rc.decodeRaw(tile); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			var err error
+
+			if mask&hextileBackgroundSpecified != 0 {
+				if background, err = rc.readPixel(); err != nil {
+					return err
+				}
+			}
+
+			rc.fill(tile, background)
+
+			if mask&hextileForegroundSpecified != 0 {
+				if foreground, err = rc.readPixel(); err != nil {
+					return err
+				}
+			}
+
+			if mask&hextileAnySubrects == 0 {
+				continue
+			}
+
+			if err := rc.decodeHextileSubrects(tile, mask&hextileSubrectsColoured != 0, foreground); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rc *rfbClient) decodeHextileSubrects(tile image.Rectangle, coloured bool, foreground color.RGBA) error {
+	var count uint8
+	if err := binary.Read(rc.r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+
+	for i := 0; i < int(count); i++ {
+		subColor := foreground
+
+		if coloured {
+			var err error
+			if subColor, err = rc.readPixel(); err != nil {
+				return err
+			}
+		}
+
+		var packed [2]uint8
+		if err := binary.Read(rc.r, binary.BigEndian, &packed); err != nil {
+			return err
+		}
+
+		x, y := int(packed[0]>>4), int(packed[0]&0x0f)
+		w, h := int(packed[1]>>4)+1, int(packed[1]&0x0f)+1
+
+		rc.fill(image.Rect(x, y, x+w, y+h).Add(tile.Min).Intersect(tile), subColor)
+	}
+
+	return nil
+}
+
+func (rc *rfbClient) readColourMap() error {
+	var header struct {
+		_     uint8
+		First uint16
+		Count uint16
+	}
+
+	if err := binary.Read(rc.r, binary.BigEndian, &header); err != nil {
+		return err
+	}
+
+	entries := make([][3]uint16, header.Count)
+	if err := binary.Read(rc.r, binary.BigEndian, entries); err != nil {
+		return err
+	}
+
+	if needed := int(header.First) + int(header.Count); needed > len(rc.colorMap) {
+		rc.colorMap = append(rc.colorMap, make([]color.RGBA, needed-len(rc.colorMap))...)
+	}
+
+	for i, entry := range entries {
+		rc.colorMap[int(header.First)+i] = color.RGBA{R: uint8(entry[0] >> 8), G: uint8(entry[1] >> 8), B: uint8(entry[2] >> 8), A: 0xff}
+	}
+
+	return nil
+}
+
+func (rc *rfbClient) bytesPerPixel() int {
+	const bitsPerByte = 8
+
+	return int(rc.format.BitsPerPixel) / bitsPerByte
+}
+
+func (rc *rfbClient) readPixel() (color.RGBA, error) {
+	buf := make([]byte, rc.bytesPerPixel())
+	if _, err := io.ReadFull(rc.r, buf); err != nil {
+		return color.RGBA{}, err
+	}
+
+	return rc.pixelColor(buf), nil
+}
+
+func (rc *rfbClient) pixelColor(b []byte) color.RGBA {
+	var order binary.ByteOrder = binary.LittleEndian
+	if rc.format.BigEndian != 0 {
+		order = binary.BigEndian
+	}
+
+	var value uint32
+
+	switch len(b) {
+	case 1:
+		value = uint32(b[0])
+	case 2:
+		value = uint32(order.Uint16(b))
+	default:
+		value = order.Uint32(b)
+	}
+
+	if rc.format.TrueColour == 0 {
+		if int(value) < len(rc.colorMap) {
+			return rc.colorMap[value]
+		}
+
+		return color.RGBA{A: 0xff}
+	}
+
+	return color.RGBA{
+		R: scaleChannel(value>>rc.format.RedShift, rc.format.RedMax),
+		G: scaleChannel(value>>rc.format.GreenShift, rc.format.GreenMax),
+		B: scaleChannel(value>>rc.format.BlueShift, rc.format.BlueMax),
+		A: 0xff,
+	}
+}
+
+func scaleChannel(value uint32, maxValue uint16) uint8 {
+	if maxValue == 0 {
+		return 0
+	}
+
+	return uint8((value & uint32(maxValue)) * 0xff / uint32(maxValue)) //nolint:gosec // result is bounded to 0-255
+}
+
+func (rc *rfbClient) fill(r image.Rectangle, c color.RGBA) {
+	draw.Draw(rc.fb, r, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
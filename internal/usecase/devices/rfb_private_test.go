@@ -0,0 +1,123 @@
+package devices
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// newTestRFBClient returns a client decoding 8bpp true colour pixels laid out as RGB332.
+func newTestRFBClient(data []byte, width, height int) *rfbClient {
+	rc := newRFBClient(bytes.NewReader(data), func([]byte) error { return nil })
+	rc.format = rfbPixelFormat{
+		BitsPerPixel: 8,
+		Depth:        8,
+		TrueColour:   1,
+		RedMax:       7,
+		GreenMax:     7,
+		BlueMax:      3,
+		RedShift:     5,
+		GreenShift:   2,
+		BlueShift:    0,
+	}
+	rc.fb = image.NewRGBA(image.Rect(0, 0, width, height))
+
+	return rc
+}
+
+var (
+	rgb332Red   = byte(0xe0)
+	rgb332Green = byte(0x1c)
+	rgb332Blue  = byte(0x03)
+	red         = color.RGBA{R: 0xff, A: 0xff}
+	green       = color.RGBA{G: 0xff, A: 0xff}
+	blue        = color.RGBA{B: 0xff, A: 0xff}
+)
+
+func TestRFBDecodeRRE(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	_ = binary.Write(&buf, binary.BigEndian, uint32(1))
+	buf.WriteByte(rgb332Blue)
+	buf.WriteByte(rgb332Red)
+	_ = binary.Write(&buf, binary.BigEndian, []uint16{1, 1, 1, 1})
+
+	rc := newTestRFBClient(buf.Bytes(), 3, 3)
+	require.NoError(t, rc.decodeRectangle(rc.fb.Rect, rfbEncodingRRE))
+	require.Equal(t, blue, rc.fb.RGBAAt(0, 0))
+	require.Equal(t, red, rc.fb.RGBAAt(1, 1))
+	require.Equal(t, blue, rc.fb.RGBAAt(2, 2))
+}
+
+func TestRFBDecodeRRESubrectOutOfBounds(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	_ = binary.Write(&buf, binary.BigEndian, uint32(1))
+	buf.WriteByte(rgb332Blue)
+	buf.WriteByte(rgb332Red)
+	_ = binary.Write(&buf, binary.BigEndian, []uint16{2, 2, 4, 4})
+
+	rc := newTestRFBClient(buf.Bytes(), 3, 3)
+	require.ErrorIs(t, rc.decodeRectangle(rc.fb.Rect, rfbEncodingRRE), ErrRFBRectangleOutOfFB)
+}
+
+func TestRFBDecodeHextile(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	// background green, foreground red, one subrect at (1,0) sized 1x2
+	buf.WriteByte(hextileBackgroundSpecified | hextileForegroundSpecified | hextileAnySubrects)
+	buf.WriteByte(rgb332Green)
+	buf.WriteByte(rgb332Red)
+	buf.WriteByte(1)
+	buf.Write([]byte{0x10, 0x01})
+
+	rc := newTestRFBClient(buf.Bytes(), 2, 2)
+	require.NoError(t, rc.decodeRectangle(rc.fb.Rect, rfbEncodingHextile))
+	require.Equal(t, green, rc.fb.RGBAAt(0, 0))
+	require.Equal(t, red, rc.fb.RGBAAt(1, 0))
+	require.Equal(t, red, rc.fb.RGBAAt(1, 1))
+	require.Equal(t, green, rc.fb.RGBAAt(0, 1))
+}
+
+func TestRFBDecodeCopyRect(t *testing.T) {
+	t.Parallel()
+
+	rc := newTestRFBClient([]byte{0, 0, 0, 0}, 2, 1)
+	rc.fb.SetRGBA(0, 0, blue)
+
+	require.NoError(t, rc.decodeRectangle(image.Rect(1, 0, 2, 1), rfbEncodingCopyRect))
+	require.Equal(t, blue, rc.fb.RGBAAt(1, 0))
+}
+
+func TestRFBUnsupportedEncoding(t *testing.T) {
+	t.Parallel()
+
+	rc := newTestRFBClient(nil, 1, 1)
+	require.ErrorIs(t, rc.decodeRectangle(rc.fb.Rect, 16), ErrRFBEncoding)
+}
+
+func TestCropToDefaultDisplay(t *testing.T) {
+	t.Parallel()
+
+	frame := image.NewRGBA(image.Rect(0, 0, 40, 10))
+	display := dto.KVMScreenDisplay{IsActive: true, IsDefault: true, UpperLeftX: 20, ResolutionX: 20, ResolutionY: 10}
+
+	cropped := cropToDefaultDisplay(frame, dto.KVMScreenSettings{Displays: []dto.KVMScreenDisplay{display}})
+	require.Equal(t, image.Rect(20, 0, 40, 10), cropped.Bounds())
+
+	display.UpperLeftX = 30
+	uncropped := cropToDefaultDisplay(frame, dto.KVMScreenSettings{Displays: []dto.KVMScreenDisplay{display}})
+	require.Equal(t, frame.Rect, uncropped.Bounds())
+}
@@ -0,0 +1,276 @@
+package devices
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/client"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/ips/optin"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+)
+
+const (
+	// ScreenshotTimeout bounds the whole redirection session used to capture a screenshot.
+	ScreenshotTimeout = 30 * time.Second
+
+	redirectionAuthHeaderSize = 9
+	redirectionStartReplySize = 13
+)
+
+var (
+	ErrConsentRequired       = ConsentRequiredError{Console: consoleerrors.CreateConsoleError("KVM Screenshot")}
+	ErrRedirectionSession    = errors.New("redirection session was rejected by the device")
+	ErrRedirectionAuthFailed = errors.New("redirection session authentication failed")
+)
+
+// GetKVMScreenshot opens a KVM redirection session to the device, captures one full framebuffer
+// of the default display and returns it PNG encoded.
+func (uc *UseCase) GetKVMScreenshot(c context.Context, guid string) ([]byte, error) {
+	item, err := uc.repo.GetByID(c, guid, tenantID(c))
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil || item.GUID == "" {
+		return nil, ErrNotFound
+	}
+
+	device := uc.device.SetupWsmanClient(*item, false, true)
+
+	optInResponse, err := device.GetIPSOptInService()
+	if err != nil {
+		return nil, err
+	}
+
+	if !kvmConsentSatisfied(optInResponse.Body.GetAndPutResponse.OptInRequired, optInResponse.Body.GetAndPutResponse.OptInState) {
+		return nil, ErrConsentRequired.Wrap("GetKVMScreenshot", "device.GetIPSOptInService", "user consent is required before capturing the KVM screen")
+	}
+
+	settings, err := uc.GetKVMScreenSettings(c, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, ScreenshotTimeout)
+	defer cancel()
+
+	frame, err := uc.captureFramebuffer(ctx, item)
+	if err != nil {
+		return nil, ErrAMT.Wrap("GetKVMScreenshot", "captureFramebuffer", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, cropToDefaultDisplay(frame, settings)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func kvmConsentSatisfied(required uint32, state int) bool {
+	if optin.OptInRequired(required) == optin.OptInRequiredNone {
+		return true
+	}
+
+	return optin.OptInState(state) == optin.Received || optin.OptInState(state) == optin.InSession
+}
+
+func (uc *UseCase) captureFramebuffer(ctx context.Context, item *entity.Device) (*image.RGBA, error) {
+	password, _ := uc.safeRequirements.Decrypt(item.Password)
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	deviceConnection := &DeviceConnection{
+		wsmanMessages: uc.redirection.SetupWsmanClient(*item, true, true),
+		Device:        *item,
+		Mode:          "kvm",
+		Challenge: client.AuthChallenge{
+			Username: item.Username,
+			Password: password,
+		},
+		ctx:    sessionCtx,
+		cancel: cancel,
+	}
+
+	if err := uc.redirection.RedirectConnect(sessionCtx, deviceConnection); err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	var closeOnce sync.Once
+
+	closeSession := func() {
+		closeOnce.Do(func() {
+			_ = uc.redirection.RedirectClose(context.Background(), deviceConnection)
+		})
+	}
+
+	// closing the session unblocks a pending receive once the deadline expires
+	go func() {
+		<-sessionCtx.Done()
+		closeSession()
+	}()
+
+	defer func() {
+		cancel()
+		closeSession()
+	}()
+
+	stream := bufio.NewReader(&redirectionReader{ctx: sessionCtx, uc: uc, conn: deviceConnection})
+	send := func(data []byte) error {
+		return uc.redirection.RedirectSend(sessionCtx, deviceConnection, data)
+	}
+
+	if err := startKVMRedirectionSession(stream, send, &deviceConnection.Challenge); err != nil {
+		return nil, err
+	}
+
+	rfb := newRFBClient(stream, send)
+	if err := rfb.handshake(); err != nil {
+		return nil, err
+	}
+
+	if err := rfb.requestFramebuffer(); err != nil {
+		return nil, err
+	}
+
+	frame, err := rfb.readFramebuffer()
+	if err != nil {
+		if sessionCtx.Err() != nil {
+			return nil, sessionCtx.Err()
+		}
+
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// startKVMRedirectionSession performs the Intel AMT redirection handshake for the KVM protocol
+// and digest authenticates using the device credentials.
+func startKVMRedirectionSession(stream io.Reader, send func([]byte) error, challenge *client.AuthChallenge) error {
+	startSession := []byte{RedirectionCommandsStartRedirectionSession, 0x00, 0x00, 0x00, 'K', 'V', 'M', 'R'}
+	if err := send(startSession); err != nil {
+		return err
+	}
+
+	reply := make([]byte, RedirectionSessionReply)
+	if _, err := io.ReadFull(stream, reply); err != nil {
+		return err
+	}
+
+	if reply[0] != RedirectionCommandsStartRedirectionSessionReply || reply[1] != StartRedirectionSessionReplyStatusSuccess {
+		return ErrRedirectionSession
+	}
+
+	rest := make([]byte, redirectionStartReplySize-RedirectionSessionReply)
+	if _, err := io.ReadFull(stream, rest); err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(io.Discard, stream, int64(rest[len(rest)-1])); err != nil {
+		return err
+	}
+
+	// the first digest request carries no realm and is answered with the challenge
+	const maxAttempts = 2
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := send(handleDigestAuthentication(challenge)); err != nil {
+			return err
+		}
+
+		authReply, err := readAuthenticateSessionReply(stream)
+		if err != nil {
+			return err
+		}
+
+		if _, authenticated := handleAuthenticateSessionReply(authReply, challenge); authenticated {
+			return nil
+		}
+	}
+
+	return ErrRedirectionAuthFailed
+}
+
+func readAuthenticateSessionReply(stream io.Reader) ([]byte, error) {
+	header := make([]byte, redirectionAuthHeaderSize)
+	if _, err := io.ReadFull(stream, header); err != nil {
+		return nil, err
+	}
+
+	if header[0] != RedirectionCommandsAuthenticateSessionReply {
+		return nil, ErrRedirectionAuthFailed
+	}
+
+	length := binary.LittleEndian.Uint32(header[5:redirectionAuthHeaderSize])
+
+	const maxAuthReplyLength = 4096
+	if length > maxAuthReplyLength {
+		return nil, ErrRedirectionAuthFailed
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(stream, body); err != nil {
+		return nil, err
+	}
+
+	return append(header, body...), nil
+}
+
+// cropToDefaultDisplay narrows a framebuffer spanning several monitors to the default display.
+func cropToDefaultDisplay(frame *image.RGBA, settings dto.KVMScreenSettings) image.Image {
+	for _, display := range settings.Displays {
+		if !display.IsDefault || !display.IsActive || display.ResolutionX == 0 || display.ResolutionY == 0 {
+			continue
+		}
+
+		bounds := image.Rect(display.UpperLeftX, display.UpperLeftY, display.UpperLeftX+display.ResolutionX, display.UpperLeftY+display.ResolutionY)
+		if bounds.Eq(frame.Rect) || !bounds.In(frame.Rect) {
+			return frame
+		}
+
+		return frame.SubImage(bounds)
+	}
+
+	return frame
+}
+
+// redirectionReader adapts the chunked redirection receive calls to an io.Reader.
+type redirectionReader struct {
+	ctx     context.Context
+	uc      *UseCase
+	conn    *DeviceConnection
+	pending []byte
+}
+
+func (r *redirectionReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if err := r.ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		data, err := r.uc.redirection.RedirectListen(r.ctx, r.conn)
+		if err != nil {
+			return 0, err
+		}
+
+		r.pending = data
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
@@ -0,0 +1,195 @@
+package devices_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/ips/kvmredirection"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/ips/optin"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/ips/screensetting"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	devices "github.com/device-management-toolkit/console/internal/usecase/devices"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func initScreenshotTest(t *testing.T) (*devices.UseCase, *mocks.MockWSMAN, *mocks.MockManagement, *mocks.MockRedirection, *mocks.MockDeviceManagementRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
+	wsmanMock := mocks.NewMockWSMAN(mockCtl)
+	wsmanMock.EXPECT().Worker().Return().AnyTimes()
+
+	management := mocks.NewMockManagement(mockCtl)
+	redirection := mocks.NewMockRedirection(mockCtl)
+	log := logger.New("error")
+	u := devices.New(repo, wsmanMock, redirection, log, mocks.MockCrypto{})
+
+	return u, wsmanMock, management, redirection, repo
+}
+
+func optInResponse(required uint32, state int) optin.Response {
+	resp := optin.Response{}
+	resp.Body.GetAndPutResponse.OptInRequired = required
+	resp.Body.GetAndPutResponse.OptInState = state
+
+	return resp
+}
+
+// kvmDeviceStream builds the bytes an AMT device sends for a KVM session
+// delivering a 2x1 raw framebuffer in 32bpp little-endian true colour.
+func kvmDeviceStream() []byte {
+	var buf bytes.Buffer
+
+	// StartRedirectionSessionReply, success, no OEM data
+	buf.Write([]byte{devices.RedirectionCommandsStartRedirectionSessionReply, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+
+	// AuthenticateSessionReply with digest challenge
+	challenge := []byte{5, 'D', 'i', 'g', 'e', 's', 5, 'n', 'o', 'n', 'c', 'e', 4, 'a', 'u', 't', 'h'}
+	buf.Write([]byte{devices.RedirectionCommandsAuthenticateSessionReply, devices.AuthenticationStatusFail, 0, 0, devices.AuthenticationTypeDigest})
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(challenge)))
+	buf.Write(challenge)
+
+	// AuthenticateSessionReply success
+	buf.Write([]byte{devices.RedirectionCommandsAuthenticateSessionReply, devices.AuthenticationStatusSuccess, 0, 0, devices.AuthenticationTypeDigest, 0, 0, 0, 0})
+
+	// RFB handshake
+	buf.WriteString("RFB 004.000\n")
+	buf.Write([]byte{1, 1})       // one security type: None
+	buf.Write([]byte{0, 0, 0, 0}) // security result OK
+	_ = binary.Write(&buf, binary.BigEndian, []uint16{2, 1})
+	buf.Write([]byte{32, 24, 0, 1})
+	_ = binary.Write(&buf, binary.BigEndian, []uint16{255, 255, 255})
+	buf.Write([]byte{16, 8, 0, 0, 0, 0})
+	_ = binary.Write(&buf, binary.BigEndian, uint32(3))
+	buf.WriteString("AMT")
+
+	// FramebufferUpdate with a single raw rectangle
+	buf.Write([]byte{0, 0})
+	_ = binary.Write(&buf, binary.BigEndian, uint16(1))
+	_ = binary.Write(&buf, binary.BigEndian, []uint16{0, 0, 2, 1})
+	_ = binary.Write(&buf, binary.BigEndian, int32(0))
+	buf.Write([]byte{0x00, 0x00, 0xff, 0x00}) // red
+	buf.Write([]byte{0xff, 0x00, 0x00, 0x00}) // blue
+
+	return buf.Bytes()
+}
+
+func expectScreenSettings(management *mocks.MockManagement) {
+	management.EXPECT().GetIPSScreenSettingData().Return(screensetting.Response{}, nil)
+	management.EXPECT().GetIPSKVMRedirectionSettingData().Return(kvmredirection.Response{}, nil)
+}
+
+func TestGetKVMScreenshot(t *testing.T) {
+	t.Parallel()
+
+	device := &entity.Device{GUID: "guid", TenantID: "tenant", Username: "admin"}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, redirection, repo := initScreenshotTest(t)
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil).Times(2)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management).Times(2)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredNone), 0), nil)
+		expectScreenSettings(management)
+
+		stream := bytes.NewReader(kvmDeviceStream())
+		redirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{})
+		redirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(nil)
+		redirection.EXPECT().RedirectSend(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		redirection.EXPECT().RedirectListen(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *devices.DeviceConnection) ([]byte, error) {
+				chunk := make([]byte, 7)
+
+				n, err := stream.Read(chunk)
+				if err != nil {
+					return nil, err
+				}
+
+				return chunk[:n], nil
+			}).AnyTimes()
+		redirection.EXPECT().RedirectClose(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		data, err := useCase.GetKVMScreenshot(context.Background(), device.GUID)
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, 2, img.Bounds().Dx())
+		require.Equal(t, 1, img.Bounds().Dy())
+
+		r, g, b, _ := img.At(0, 0).RGBA()
+		require.Equal(t, []uint32{0xffff, 0, 0}, []uint32{r, g, b})
+
+		r, g, b, _ = img.At(1, 0).RGBA()
+		require.Equal(t, []uint32{0, 0, 0xffff}, []uint32{r, g, b})
+	})
+
+	t.Run("consent required", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, _, repo := initScreenshotTest(t)
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredKVM), int(optin.NotStarted)), nil)
+
+		_, err := useCase.GetKVMScreenshot(context.Background(), device.GUID)
+
+		var consentErr devices.ConsentRequiredError
+		require.ErrorAs(t, err, &consentErr)
+	})
+
+	t.Run("session rejected", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, redirection, repo := initScreenshotTest(t)
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil).Times(2)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management).Times(2)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredAll), int(optin.InSession)), nil)
+		expectScreenSettings(management)
+
+		redirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{})
+		redirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(nil)
+		redirection.EXPECT().RedirectSend(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		redirection.EXPECT().RedirectListen(gomock.Any(), gomock.Any()).
+			Return([]byte{devices.RedirectionCommandsStartRedirectionSessionReply, devices.StartRedirectionSessionReplyStatusBusy, 0, 0}, nil)
+		redirection.EXPECT().RedirectClose(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := useCase.GetKVMScreenshot(context.Background(), device.GUID)
+
+		var amtErr devices.AMTError
+		require.ErrorAs(t, err, &amtErr)
+		require.ErrorIs(t, amtErr.Console.OriginalError, devices.ErrRedirectionSession)
+	})
+
+	t.Run("connect failure", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, wsmanMock, management, redirection, repo := initScreenshotTest(t)
+		repo.EXPECT().GetByID(gomock.Any(), device.GUID, "").Return(device, nil).Times(2)
+		wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management).Times(2)
+		management.EXPECT().GetIPSOptInService().Return(optInResponse(uint32(optin.OptInRequiredNone), 0), nil)
+		expectScreenSettings(management)
+
+		redirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{})
+		redirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(io.ErrUnexpectedEOF)
+
+		_, err := useCase.GetKVMScreenshot(context.Background(), device.GUID)
+
+		var amtErr devices.AMTError
+		require.ErrorAs(t, err, &amtErr)
+		require.ErrorIs(t, amtErr.Console.OriginalError, io.ErrUnexpectedEOF)
+	})
+}
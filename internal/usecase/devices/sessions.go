@@ -0,0 +1,291 @@
+package devices
+
+import (
+	"context"
+	"crypto/subtle"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+)
+
+const (
+	ViewerRolePrimary  = "primary"
+	ViewerRoleObserver = "observer"
+
+	// viewerIDLength is the length of the public identifier handed out for each viewer.
+	viewerIDLength = 16
+	// maxHandshakeTranscript caps the device data kept for replay to late joining observers.
+	maxHandshakeTranscript = 64 * 1024
+
+	rfbFramebufferUpdateRequestLength = 10
+	redirectionModeKVM                = "kvm"
+)
+
+var ErrViewerForbidden = ForbiddenError{Console: consoleerrors.CreateConsoleError("RedirectionSessions")}
+
+// redirectionViewer is one browser websocket attached to a redirection session.
+type redirectionViewer struct {
+	id          string
+	token       string
+	conn        WebSocketConn
+	connectedAt time.Time
+	writeMu     sync.Mutex
+}
+
+func newRedirectionViewer(conn WebSocketConn, token string) (*redirectionViewer, error) {
+	id, err := RandomValueHex(viewerIDLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redirectionViewer{
+		id:          id,
+		token:       token,
+		conn:        conn,
+		connectedAt: time.Now(),
+	}, nil
+}
+
+func (v *redirectionViewer) write(data []byte) error {
+	v.writeMu.Lock()
+	defer v.writeMu.Unlock()
+
+	return v.conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+func (dc *DeviceConnection) isPrimary(viewer *redirectionViewer) bool {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	return dc.primary == viewer
+}
+
+// detachViewer removes the viewer from the session, promoting the earliest observer when the primary leaves.
+// It returns the number of viewers still attached.
+func (dc *DeviceConnection) detachViewer(viewer *redirectionViewer) int {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	for i, v := range dc.viewers {
+		if v == viewer {
+			dc.viewers = append(dc.viewers[:i:i], dc.viewers[i+1:]...)
+
+			break
+		}
+	}
+
+	if dc.primary == viewer {
+		dc.primary = nil
+
+		if len(dc.viewers) > 0 {
+			dc.setPrimary(dc.viewers[0])
+		}
+	}
+
+	return len(dc.viewers)
+}
+
+// setPrimary must be called with dc.mu held.
+func (dc *DeviceConnection) setPrimary(viewer *redirectionViewer) {
+	dc.primary = viewer
+	dc.Conn = viewer.conn
+}
+
+func (dc *DeviceConnection) closeViewers() {
+	dc.mu.RLock()
+	viewers := append([]*redirectionViewer(nil), dc.viewers...)
+	dc.mu.RUnlock()
+
+	for _, viewer := range viewers {
+		_ = viewer.conn.Close()
+	}
+}
+
+// recordDeviceData keeps device data of the session setup for replay and returns the viewers it must be sent to.
+func (dc *DeviceConnection) recordDeviceData(data []byte) []*redirectionViewer {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if !dc.handshakeDone {
+		if len(dc.handshake)+len(data) <= maxHandshakeTranscript {
+			dc.handshake = append(dc.handshake, data...)
+		}
+
+		// only KVM needs the framebuffer state replayed, other modes are live as soon as the session is authenticated
+		if dc.Direct && dc.Mode != redirectionModeKVM {
+			dc.handshakeDone = true
+		}
+	}
+
+	return append([]*redirectionViewer(nil), dc.viewers...)
+}
+
+// recordFrameRequest ends the KVM handshake transcript at the first framebuffer update request of the primary.
+func (dc *DeviceConnection) recordFrameRequest(msg []byte) {
+	if msg[0] != rfbClientFramebufferUpdateReq || len(msg) < rfbFramebufferUpdateRequestLength {
+		return
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if dc.handshakeDone {
+		return
+	}
+
+	dc.frameRequest = append([]byte(nil), msg[:rfbFramebufferUpdateRequestLength]...)
+	dc.handshakeDone = true
+}
+
+// attachObserver joins a browser to a running session in read-only mode. The session setup is replayed first
+// and a full framebuffer is requested so the observer starts from a complete picture.
+func (uc *UseCase) attachObserver(dc *DeviceConnection, conn WebSocketConn, token string) error {
+	if err := dc.ctx.Err(); err != nil {
+		return err
+	}
+
+	viewer, err := newRedirectionViewer(conn, token)
+	if err != nil {
+		return err
+	}
+
+	// hold the viewer back from live data until the transcript has been written
+	viewer.writeMu.Lock()
+
+	dc.mu.Lock()
+	dc.viewers = append(dc.viewers, viewer)
+	transcript := append([]byte(nil), dc.handshake...)
+	frameRequest := append([]byte(nil), dc.frameRequest...)
+	dc.mu.Unlock()
+
+	if len(transcript) > 0 {
+		err = viewer.conn.WriteMessage(websocket.BinaryMessage, transcript)
+	}
+
+	viewer.writeMu.Unlock()
+
+	if err != nil {
+		dc.detachViewer(viewer)
+
+		return err
+	}
+
+	if len(frameRequest) > 0 {
+		frameRequest[1] = 0 // non-incremental
+
+		if err := uc.redirection.RedirectSend(dc.ctx, dc, frameRequest); err != nil {
+			uc.log.Warn("redirection - attachObserver - failed to request framebuffer: " + err.Error())
+		}
+	}
+
+	go uc.listenToViewer(dc, viewer)
+
+	return nil
+}
+
+func (uc *UseCase) redirectionSession(guid, mode string) *DeviceConnection {
+	uc.redirMutex.RLock()
+	defer uc.redirMutex.RUnlock()
+
+	return uc.redirConnections[guid+"-"+mode]
+}
+
+// GetRedirectionSessions lists the active redirection sessions of a device and their viewers. Sessions of
+// devices in other tenants are not listed.
+func (uc *UseCase) GetRedirectionSessions(c context.Context, guid string) ([]dto.RedirectionSession, error) {
+	uc.redirMutex.RLock()
+
+	connections := make([]*DeviceConnection, 0, len(uc.redirConnections))
+
+	for _, dc := range uc.redirConnections {
+		if dc.Device.GUID == guid && dc.Device.TenantID == tenantID(c) {
+			connections = append(connections, dc)
+		}
+	}
+
+	uc.redirMutex.RUnlock()
+
+	sessions := make([]dto.RedirectionSession, 0, len(connections))
+
+	for _, dc := range connections {
+		session := dto.RedirectionSession{GUID: guid, Mode: dc.Mode}
+
+		dc.mu.RLock()
+
+		for _, viewer := range dc.viewers {
+			role := ViewerRoleObserver
+			if viewer == dc.primary {
+				role = ViewerRolePrimary
+			}
+
+			session.Viewers = append(session.Viewers, dto.RedirectionViewer{
+				ID:          viewer.id,
+				Role:        role,
+				ConnectedAt: viewer.connectedAt,
+			})
+		}
+
+		dc.mu.RUnlock()
+
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Mode < sessions[j].Mode })
+
+	return sessions, nil
+}
+
+// PromoteRedirectionViewer hands control of the session to an observer. Only the primary, identified by its
+// viewer token, may do so.
+func (uc *UseCase) PromoteRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error {
+	dc, viewer, err := uc.authorizeViewerChange(tenantID(c), guid, mode, viewerID, viewerToken)
+	if err != nil {
+		return err
+	}
+
+	dc.mu.Lock()
+	dc.setPrimary(viewer)
+	dc.mu.Unlock()
+
+	return nil
+}
+
+// RevokeRedirectionViewer disconnects a viewer from the session. Only the primary, identified by its viewer
+// token, may do so.
+func (uc *UseCase) RevokeRedirectionViewer(c context.Context, guid, mode, viewerID, viewerToken string) error {
+	_, viewer, err := uc.authorizeViewerChange(tenantID(c), guid, mode, viewerID, viewerToken)
+	if err != nil {
+		return err
+	}
+
+	// the viewer's reader detaches it once the socket is closed
+	return viewer.conn.Close()
+}
+
+func (uc *UseCase) authorizeViewerChange(tenant, guid, mode, viewerID, viewerToken string) (*DeviceConnection, *redirectionViewer, error) {
+	dc := uc.redirectionSession(guid, mode)
+	if dc == nil || dc.Device.TenantID != tenant {
+		return nil, nil, ErrNotFound
+	}
+
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+
+	if dc.primary == nil || dc.primary.token == "" ||
+		subtle.ConstantTimeCompare([]byte(dc.primary.token), []byte(viewerToken)) != 1 {
+		return nil, nil, ErrViewerForbidden.Wrap("authorizeViewerChange", "subtle.ConstantTimeCompare", "only the primary viewer may manage the session")
+	}
+
+	for _, viewer := range dc.viewers {
+		if viewer.id == viewerID && viewer != dc.primary {
+			return dc, viewer, nil
+		}
+	}
+
+	return nil, nil, ErrNotFound
+}
@@ -0,0 +1,228 @@
+package devices
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+type fakeWebSocketConn struct {
+	reads  chan []byte
+	mu     sync.Mutex
+	writes [][]byte
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newFakeWebSocketConn() *fakeWebSocketConn {
+	return &fakeWebSocketConn{reads: make(chan []byte, 8), closed: make(chan struct{})}
+}
+
+func (f *fakeWebSocketConn) ReadMessage() (int, []byte, error) {
+	select {
+	case msg := <-f.reads:
+		return 2, msg, nil
+	case <-f.closed:
+		return 0, nil, io.EOF
+	}
+}
+
+func (f *fakeWebSocketConn) WriteMessage(_ int, data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.writes = append(f.writes, append([]byte(nil), data...))
+
+	return nil
+}
+
+func (f *fakeWebSocketConn) Close() error {
+	f.once.Do(func() { close(f.closed) })
+
+	return nil
+}
+
+func (f *fakeWebSocketConn) written() [][]byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([][]byte(nil), f.writes...)
+}
+
+type fakeRedirection struct {
+	mu   sync.Mutex
+	sent [][]byte
+}
+
+func (f *fakeRedirection) SetupWsmanClient(entity.Device, bool, bool) wsman.Messages {
+	return wsman.Messages{}
+}
+
+func (f *fakeRedirection) RedirectConnect(context.Context, *DeviceConnection) error { return nil }
+
+func (f *fakeRedirection) RedirectClose(context.Context, *DeviceConnection) error { return nil }
+
+func (f *fakeRedirection) RedirectListen(ctx context.Context, _ *DeviceConnection) ([]byte, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func (f *fakeRedirection) RedirectSend(_ context.Context, _ *DeviceConnection, message []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sent = append(f.sent, append([]byte(nil), message...))
+
+	return nil
+}
+
+func (f *fakeRedirection) messages() [][]byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([][]byte(nil), f.sent...)
+}
+
+// newSharedSession builds a KVM session whose handshake has completed with the given primary viewer.
+func newSharedSession(t *testing.T, primaryConn WebSocketConn, token string) (*UseCase, *fakeRedirection, *DeviceConnection) {
+	t.Helper()
+
+	redirection := &fakeRedirection{}
+	uc := &UseCase{
+		redirection:      redirection,
+		redirConnections: make(map[string]*DeviceConnection),
+		log:              logger.New("error"),
+	}
+
+	primary, err := newRedirectionViewer(primaryConn, token)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	dc := &DeviceConnection{
+		Conn:    primaryConn,
+		Device:  entity.Device{GUID: "guid"},
+		Mode:    "kvm",
+		Direct:  true,
+		ctx:     ctx,
+		cancel:  cancel,
+		viewers: []*redirectionViewer{primary},
+		primary: primary,
+	}
+
+	dc.recordDeviceData([]byte("RFB 003.008\n"))
+	dc.recordFrameRequest([]byte{rfbClientFramebufferUpdateReq, 1, 0, 0, 0, 0, 0, 2, 0, 1})
+
+	uc.redirConnections["guid-kvm"] = dc
+
+	return uc, redirection, dc
+}
+
+func TestAttachObserver(t *testing.T) {
+	t.Parallel()
+
+	uc, redirection, dc := newSharedSession(t, newFakeWebSocketConn(), "secret")
+	observerConn := newFakeWebSocketConn()
+
+	require.NoError(t, uc.attachObserver(dc, observerConn, ""))
+
+	require.Equal(t, [][]byte{[]byte("RFB 003.008\n")}, observerConn.written())
+	require.Equal(t, [][]byte{{rfbClientFramebufferUpdateReq, 0, 0, 0, 0, 0, 0, 2, 0, 1}}, redirection.messages())
+
+	// live data reaches every viewer once the handshake has been captured
+	require.NoError(t, uc.fanOut(dc, dc.recordDeviceData([]byte{0, 0}), []byte{0, 0}))
+	require.Len(t, observerConn.written(), 2)
+	require.Equal(t, "RFB 003.008\n", string(dc.handshake))
+
+	// observer input is dropped
+	observerConn.reads <- []byte{4, 1, 0, 0, 0, 0, 0, 0x41}
+
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, redirection.messages(), 1)
+
+	sessions, err := uc.GetRedirectionSessions(context.Background(), "guid")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Len(t, sessions[0].Viewers, 2)
+	require.Equal(t, ViewerRolePrimary, sessions[0].Viewers[0].Role)
+	require.Equal(t, ViewerRoleObserver, sessions[0].Viewers[1].Role)
+}
+
+func TestPromoteAndRevokeRedirectionViewer(t *testing.T) {
+	t.Parallel()
+
+	primaryConn := newFakeWebSocketConn()
+	uc, _, dc := newSharedSession(t, primaryConn, "secret")
+	observerConn := newFakeWebSocketConn()
+
+	require.NoError(t, uc.attachObserver(dc, observerConn, ""))
+
+	observerID := dc.viewers[1].id
+
+	var forbidden ForbiddenError
+
+	err := uc.PromoteRedirectionViewer(context.Background(), "guid", "kvm", observerID, "wrong")
+	require.ErrorAs(t, err, &forbidden)
+
+	err = uc.PromoteRedirectionViewer(context.Background(), "guid", "kvm", "unknown", "secret")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, uc.PromoteRedirectionViewer(context.Background(), "guid", "kvm", observerID, "secret"))
+	require.True(t, dc.isPrimary(dc.viewers[1]))
+	require.Equal(t, WebSocketConn(observerConn), dc.Conn)
+
+	// the new primary has no token and therefore cannot manage the session
+	err = uc.RevokeRedirectionViewer(context.Background(), "guid", "kvm", dc.viewers[0].id, "secret")
+	require.ErrorAs(t, err, &forbidden)
+}
+
+func TestDetachPrimaryPromotesObserver(t *testing.T) {
+	t.Parallel()
+
+	_, _, dc := newSharedSession(t, newFakeWebSocketConn(), "secret")
+	observer, err := newRedirectionViewer(newFakeWebSocketConn(), "")
+	require.NoError(t, err)
+
+	dc.viewers = append(dc.viewers, observer)
+
+	require.Equal(t, 1, dc.detachViewer(dc.primary))
+	require.True(t, dc.isPrimary(observer))
+	require.Equal(t, 0, dc.detachViewer(observer))
+	require.Nil(t, dc.primary)
+}
+
+func TestRedirectionSessionsTenant(t *testing.T) {
+	t.Parallel()
+
+	uc, _, dc := newSharedSession(t, newFakeWebSocketConn(), "secret")
+	dc.Device.TenantID = "bu-retail"
+
+	observer, err := newRedirectionViewer(newFakeWebSocketConn(), "")
+	require.NoError(t, err)
+
+	dc.viewers = append(dc.viewers, observer)
+
+	sessions, err := uc.GetRedirectionSessions(WithTenant(context.Background(), "bu-energy"), "guid")
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	err = uc.PromoteRedirectionViewer(WithTenant(context.Background(), "bu-energy"), "guid", "kvm", observer.id, "secret")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	sessions, err = uc.GetRedirectionSessions(WithTenant(context.Background(), "bu-retail"), "guid")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	require.NoError(t, uc.PromoteRedirectionViewer(WithTenant(context.Background(), "bu-retail"), "guid", "kvm", observer.id, "secret"))
+}
@@ -0,0 +1,18 @@
+package devices
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant scopes the device operations run with the context to a tenant. Operations that only take
+// a device GUID look the device up in this tenant, so devices of other tenants are not found.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// tenantID returns the tenant set with WithTenant, the default tenant is empty.
+func tenantID(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+
+	return tenant
+}
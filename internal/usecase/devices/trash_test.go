@@ -0,0 +1,38 @@
+package devices_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/devices"
+)
+
+func TestTrash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	useCase, repo, _ := devicesTest(t)
+
+	repo.EXPECT().GetDeleted(ctx, 10, 0, "t1").Return([]entity.Device{
+		{GUID: "guid1", Tags: "lab", TenantID: "t1", DeletedAt: "2025-08-08T00:00:00Z"},
+	}, nil)
+
+	deleted, err := useCase.GetDeleted(ctx, 10, 0, "t1")
+	require.NoError(t, err)
+	require.Equal(t, []dto.Device{{GUID: "guid1", Tags: []string{"lab"}, TenantID: "t1", DeletedAt: "2025-08-08T00:00:00Z"}}, deleted)
+
+	repo.EXPECT().Restore(ctx, "guid1", "t1").Return(true, nil)
+	require.NoError(t, useCase.Restore(ctx, "guid1", "t1"))
+
+	// a device that is not in the trash cannot be restored or purged
+	repo.EXPECT().Restore(ctx, "guid2", "t1").Return(false, nil)
+	require.ErrorIs(t, useCase.Restore(ctx, "guid2", "t1"), devices.ErrNotFound)
+
+	repo.EXPECT().Purge(ctx, "guid2", "t1").Return(false, nil)
+	require.ErrorIs(t, useCase.Purge(ctx, "guid2", "t1"), devices.ErrNotFound)
+}
@@ -1,6 +1,7 @@
 package devices
 
 import (
+	"encoding/json"
 	"strings"
 	"sync"
 
@@ -43,6 +44,7 @@ type UseCase struct {
 	redirection      Redirection
 	redirConnections map[string]*DeviceConnection
 	redirMutex       sync.RWMutex // Protects redirConnections map
+	redirTokens      *redirectionTokenLedger
 	log              logger.Interface
 	safeRequirements security.Cryptor
 }
@@ -56,6 +58,7 @@ func New(r Repository, d WSMAN, redirection Redirection, log logger.Interface, s
 		device:           d,
 		redirection:      redirection,
 		redirConnections: make(map[string]*DeviceConnection),
+		redirTokens:      newRedirectionTokenLedger(),
 		log:              log,
 		safeRequirements: safeRequirements,
 	}
@@ -87,11 +90,20 @@ func (uc *UseCase) dtoToEntity(d *dto.Device) *entity.Device {
 		LastConnected:    d.LastConnected,
 		LastSeen:         d.LastSeen,
 		LastDisconnected: d.LastDisconnected,
-		// DeviceInfo:       d.DeviceInfo,
-		Username:        d.Username,
-		Password:        d.Password,
-		UseTLS:          d.UseTLS,
-		AllowSelfSigned: d.AllowSelfSigned,
+		Username:         d.Username,
+		Password:         d.Password,
+		UseTLS:           d.UseTLS,
+		AllowSelfSigned:  d.AllowSelfSigned,
+	}
+
+	// the inventory is kept as JSON, groups select devices by it
+	if d.DeviceInfo != nil {
+		info, err := json.Marshal(d.DeviceInfo)
+		if err != nil {
+			uc.log.Error("Error encoding device info")
+		} else {
+			d1.DeviceInfo = string(info)
+		}
 	}
 
 	var err error
@@ -131,16 +143,29 @@ func (uc *UseCase) entityToDTO(d *entity.Device) *dto.Device {
 		LastConnected:    d.LastConnected,
 		LastSeen:         d.LastSeen,
 		LastDisconnected: d.LastDisconnected,
-		// DeviceInfo:       d.DeviceInfo,
-		Username: d.Username,
+		Username:         d.Username,
 		// Password:        d.Password,
 		UseTLS:          d.UseTLS,
 		AllowSelfSigned: d.AllowSelfSigned,
+		DeletedAt:       d.DeletedAt,
+	}
+
+	if d.DeviceInfo != "" {
+		info := &dto.DeviceInfo{}
+		if err := json.Unmarshal([]byte(d.DeviceInfo), info); err == nil {
+			d1.DeviceInfo = info
+		}
 	}
 
 	if d.CertHash != nil {
 		d1.CertHash = *d.CertHash
 	}
 
+	if d.Attributes != "" {
+		if err := json.Unmarshal([]byte(d.Attributes), &d1.Attributes); err != nil {
+			uc.log.Error("Error decoding device attributes")
+		}
+	}
+
 	return d1
 }
@@ -5,24 +5,25 @@ import (
 
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type (
 	Repository interface {
-		GetCount(context.Context, string) (int, error)
-		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Domain, error)
+		GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+		Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Domain, error)
 		GetDomainByDomainSuffix(ctx context.Context, domainSuffix, tenantID string) (*entity.Domain, error)
 		GetByName(ctx context.Context, name, tenantID string) (*entity.Domain, error)
-		Delete(ctx context.Context, name, tenantID string) (bool, error)
+		Delete(ctx context.Context, name, version, tenantID string) (bool, error)
 		Update(ctx context.Context, d *entity.Domain) (bool, error)
 		Insert(ctx context.Context, d *entity.Domain) (string, error)
 	}
 	Feature interface {
-		GetCount(context.Context, string) (int, error)
-		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Domain, error)
+		GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+		Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Domain, error)
 		GetDomainByDomainSuffix(ctx context.Context, domainSuffix, tenantID string) (*dto.Domain, error)
 		GetByName(ctx context.Context, name, tenantID string) (*dto.Domain, error)
-		Delete(ctx context.Context, name, tenantID string) error
+		Delete(ctx context.Context, name, version, tenantID string) error
 		Update(ctx context.Context, d *dto.Domain) (*dto.Domain, error)
 		Insert(ctx context.Context, d *dto.Domain) (*dto.Domain, error)
 	}
@@ -0,0 +1,45 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// recordRevision stores the saved domain as a revision. The certificate holds its private key, so it is kept
+// encrypted along with its password.
+func (uc *UseCase) recordRevision(ctx context.Context, d2 *dto.Domain, d1 *entity.Domain) error {
+	cert, err := uc.safeRequirements.Encrypt(d1.ProvisioningCert)
+	if err != nil {
+		return err
+	}
+
+	secrets := map[string]string{
+		"provisioningCert":         cert,
+		"provisioningCertPassword": d1.ProvisioningCertPassword,
+	}
+
+	if err = uc.revisions.Record(ctx, dto.RevisionKindDomain, d2.ProfileName, d2.TenantID, d2, secrets); err != nil {
+		return ErrDatabase.Wrap("recordRevision", "uc.revisions.Record", err)
+	}
+
+	return nil
+}
+
+// ApplyRevision updates the domain to the content of one of its revisions.
+func (uc *UseCase) ApplyRevision(ctx context.Context, content []byte, tenantID string) error {
+	d := &dto.Domain{}
+	if err := json.Unmarshal(content, d); err != nil {
+		return err
+	}
+
+	d.TenantID = tenantID
+	// a revision is applied over whatever version the config is at now
+	d.Version = ""
+
+	_, err := uc.Update(ctx, d)
+
+	return err
+}
@@ -12,9 +12,11 @@ import (
 
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // UseCase -.
@@ -22,14 +24,16 @@ type UseCase struct {
 	repo             Repository
 	log              logger.Interface
 	safeRequirements security.Cryptor
+	revisions        revisions.Recorder
 }
 
 // New -.
-func New(r Repository, log logger.Interface, safeRequirements security.Cryptor) *UseCase {
+func New(r Repository, log logger.Interface, safeRequirements security.Cryptor, rev revisions.Recorder) *UseCase {
 	return &UseCase{
 		repo:             r,
 		log:              log,
 		safeRequirements: safeRequirements,
+		revisions:        rev,
 	}
 }
 
@@ -42,8 +46,8 @@ var (
 )
 
 // History - getting translate history from store.
-func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
-	count, err := uc.repo.GetCount(ctx, tenantID)
+func (uc *UseCase) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, q, tenantID)
 	if err != nil {
 		return 0, ErrDatabase.Wrap("Get", "uc.repo.GetCount", err)
 	}
@@ -51,8 +55,8 @@ func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
 	return count, nil
 }
 
-func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Domain, error) {
-	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+func (uc *UseCase) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Domain, error) {
+	data, err := uc.repo.Get(ctx, q, top, skip, tenantID)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
 	}
@@ -98,8 +102,8 @@ func (uc *UseCase) GetByName(ctx context.Context, domainName, tenantID string) (
 	return d2, nil
 }
 
-func (uc *UseCase) Delete(ctx context.Context, domainName, tenantID string) error {
-	isSuccessful, err := uc.repo.Delete(ctx, domainName, tenantID)
+func (uc *UseCase) Delete(ctx context.Context, domainName, version, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, domainName, version, tenantID)
 	if err != nil {
 		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
 	}
@@ -114,22 +118,32 @@ func (uc *UseCase) Delete(ctx context.Context, domainName, tenantID string) erro
 func (uc *UseCase) Update(ctx context.Context, d *dto.Domain) (*dto.Domain, error) {
 	d1 := uc.dtoToEntity(d)
 
-	updated, err := uc.repo.Update(ctx, d1)
-	if err != nil {
-		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
-	}
+	var d2 *dto.Domain
 
-	if !updated {
-		return nil, ErrNotFound
-	}
+	// the domain is saved with its revision, a revision that cannot be recorded undoes the update
+	err := uc.revisions.Save(ctx, func(ctx context.Context) error {
+		updated, err := uc.repo.Update(ctx, d1)
+		if err != nil {
+			return ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+		}
+
+		if !updated {
+			return ErrNotFound
+		}
+
+		updateDomain, err := uc.repo.GetByName(ctx, d.ProfileName, d.TenantID)
+		if err != nil {
+			return err
+		}
+
+		d2 = uc.entityToDTO(updateDomain)
 
-	updateDomain, err := uc.repo.GetByName(ctx, d.ProfileName, d.TenantID)
+		return uc.recordRevision(ctx, d2, d1)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	d2 := uc.entityToDTO(updateDomain)
-
 	return d2, nil
 }
 
@@ -143,18 +157,28 @@ func (uc *UseCase) Insert(ctx context.Context, d *dto.Domain) (*dto.Domain, erro
 
 	d1.ExpirationDate = cert.NotAfter.Format(time.RFC3339)
 
-	_, err = uc.repo.Insert(ctx, d1)
-	if err != nil {
-		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
-	}
+	var d2 *dto.Domain
+
+	// the domain is saved with its revision, a revision that cannot be recorded undoes the insert
+	err = uc.revisions.Save(ctx, func(ctx context.Context) error {
+		_, err := uc.repo.Insert(ctx, d1)
+		if err != nil {
+			return ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+		}
 
-	newDomain, err := uc.repo.GetByName(ctx, d.ProfileName, d.TenantID)
+		newDomain, err := uc.repo.GetByName(ctx, d.ProfileName, d.TenantID)
+		if err != nil {
+			return err
+		}
+
+		d2 = uc.entityToDTO(newDomain)
+
+		return uc.recordRevision(ctx, d2, d1)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	d2 := uc.entityToDTO(newDomain)
-
 	return d2, nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/domains"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type test struct {
@@ -38,7 +39,12 @@ func domainsTest(t *testing.T) (*domains.UseCase, *mocks.MockDomainsRepository)
 	repo := mocks.NewMockDomainsRepository(mockCtl)
 	log := logger.New("error")
 	crypto := mocks.MockCrypto{}
-	useCase := domains.New(repo, log, crypto)
+	revisionsMock := mocks.NewMockRevisionsRecorder(mockCtl)
+	revisionsMock.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	revisionsMock.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	useCase := domains.New(repo, log, crypto, revisionsMock)
 
 	return useCase, repo
 }
@@ -50,7 +56,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "empty result",
 			mock: func(repo *mocks.MockDomainsRepository) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, nil)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, nil)
 			},
 			res: 0,
 			err: nil,
@@ -58,7 +64,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "result with error",
 			mock: func(repo *mocks.MockDomainsRepository) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, domains.ErrDatabase)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, domains.ErrDatabase)
 			},
 			res: 0,
 			err: domains.ErrDatabase,
@@ -75,7 +81,7 @@ func TestGetCount(t *testing.T) {
 
 			tc.mock(repo)
 
-			res, err := useCase.GetCount(context.Background(), "")
+			res, err := useCase.GetCount(context.Background(), odata.Query{}, "")
 
 			require.Equal(t, tc.res, res)
 			require.IsType(t, tc.err, err)
@@ -116,7 +122,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDomainsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 0, "tenant-id-456").
 					Return(testDomains, nil)
 			},
 			res: testDomainDTOs,
@@ -129,7 +135,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDomainsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 5, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 5, 0, "tenant-id-456").
 					Return(nil, domains.ErrDatabase)
 			},
 			res: []dto.Domain(nil),
@@ -142,7 +148,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDomainsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 20, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 20, "tenant-id-456").
 					Return([]entity.Domain{}, nil)
 			},
 			res: []dto.Domain{},
@@ -159,7 +165,7 @@ func TestGet(t *testing.T) {
 
 			tc.mock(repo)
 
-			results, err := useCase.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			results, err := useCase.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			require.Equal(t, tc.res, results)
 
@@ -337,7 +343,7 @@ func TestDelete(t *testing.T) {
 			tenantID:   "tenant-id-456",
 			mock: func(repo *mocks.MockDomainsRepository) {
 				repo.EXPECT().
-					Delete(context.Background(), "example-domain", "tenant-id-456").
+					Delete(context.Background(), "example-domain", "", "tenant-id-456").
 					Return(true, nil)
 			},
 			err: nil,
@@ -348,7 +354,7 @@ func TestDelete(t *testing.T) {
 			tenantID:   "tenant-id-456",
 			mock: func(repo *mocks.MockDomainsRepository) {
 				repo.EXPECT().
-					Delete(context.Background(), "nonexistent-domain", "tenant-id-456").
+					Delete(context.Background(), "nonexistent-domain", "", "tenant-id-456").
 					Return(false, nil)
 			},
 			err: domains.ErrNotFound,
@@ -364,7 +370,7 @@ func TestDelete(t *testing.T) {
 
 			tc.mock(repo)
 
-			err := useCase.Delete(context.Background(), tc.domainName, tc.tenantID)
+			err := useCase.Delete(context.Background(), tc.domainName, "", tc.tenantID)
 
 			if tc.err != nil {
 				require.Error(t, err)
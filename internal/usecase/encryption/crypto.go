@@ -0,0 +1,47 @@
+package encryption
+
+import (
+	"sync"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/config"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
+)
+
+// Crypto encrypts and decrypts secrets with a key that can be rotated while the console runs. It is shared by
+// every use case that stores secrets.
+type Crypto struct {
+	mu  sync.RWMutex
+	key string
+}
+
+// NewCrypto -.
+func NewCrypto(key string) *Crypto {
+	return &Crypto{key: key}
+}
+
+func (c *Crypto) current() security.Crypto {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return security.Crypto{EncryptionKey: c.key}
+}
+
+func (c *Crypto) Decrypt(cipherText string) (string, error) {
+	return c.current().Decrypt(cipherText)
+}
+
+func (c *Crypto) Encrypt(plainText string) (string, error) {
+	return c.current().Encrypt(plainText)
+}
+
+func (c *Crypto) EncryptWithKey(plainText, key string) (string, error) {
+	return c.current().EncryptWithKey(plainText, key)
+}
+
+func (c *Crypto) GenerateKey() string {
+	return c.current().GenerateKey()
+}
+
+func (c *Crypto) ReadAndDecryptFile(filePath string) (config.Configuration, error) {
+	return c.current().ReadAndDecryptFile(filePath)
+}
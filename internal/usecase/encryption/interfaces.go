@@ -0,0 +1,30 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		// RotateSecrets replaces every stored secret with reencrypt(secret) and then reads each one back and checks
+		// it with verify(old, new), all in one transaction that is rolled back on the first error. It returns the
+		// number of secrets.
+		RotateSecrets(ctx context.Context, reencrypt func(string) (string, error), verify func(string, string) error) (int, error)
+		// GetSecrets returns every stored secret, those kept by configuration revisions included.
+		GetSecrets(ctx context.Context) ([]string, error)
+	}
+	// SecretStore keeps secrets outside of the database, e.g. in Vault.
+	SecretStore interface {
+		Read(ctx context.Context, path string) (map[string]string, error)
+		Write(ctx context.Context, path string, data map[string]string) error
+		List(ctx context.Context, path string) ([]string, error)
+		Delete(ctx context.Context, path string) error
+	}
+	Feature interface {
+		RotateKey(ctx context.Context, newKey string) (*dto.KeyRotation, error)
+		Run(ctx context.Context)
+		PruneSecrets(ctx context.Context) (int, error)
+	}
+)
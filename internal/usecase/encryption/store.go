@@ -0,0 +1,190 @@
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	wsmanconfig "github.com/device-management-toolkit/go-wsman-messages/v2/pkg/config"
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/pkg/vault"
+)
+
+const (
+	ProviderDatabase = "database"
+	ProviderVault    = "vault"
+
+	// referencePrefix marks a value as a reference into the secret store, encrypted values are base64 and never
+	// contain a colon.
+	referencePrefix = "ref:"
+	secretField     = "value"
+	referenceLength = 16
+
+	// pruneGrace keeps secrets that were just written out of a prune, the row referencing them may not be saved yet.
+	pruneGrace = time.Hour
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown secrets provider")
+	errVaultConfig     = errors.New("the vault secrets provider needs an address and a token")
+	errNoSecretValue   = errors.New("the stored secret has no value")
+)
+
+// NewSecretProvider returns the Cryptor the use cases keep their secrets with. The database provider is c itself.
+func NewSecretProvider(cfg config.Secrets, c *Crypto) (security.Cryptor, error) {
+	switch cfg.Provider {
+	case "", ProviderDatabase:
+		return c, nil
+	case ProviderVault:
+		if cfg.Vault.Address == "" || cfg.Vault.Token == "" {
+			return nil, errVaultConfig
+		}
+
+		opts := []vault.Option{vault.Namespace(cfg.Vault.Namespace)}
+		if cfg.Vault.Mount != "" {
+			opts = append(opts, vault.Mount(cfg.Vault.Mount))
+		}
+
+		if cfg.Vault.Timeout > 0 {
+			opts = append(opts, vault.Timeout(cfg.Vault.Timeout))
+		}
+
+		return NewStoreCrypto(vault.New(cfg.Vault.Address, cfg.Vault.Token, opts...), cfg.Vault.Path, c), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
+
+// StoreCrypto keeps each secret in a SecretStore under a new name, made of the time it was written and random
+// bytes, and returns a reference to it in place of the encrypted value. Values that are no reference are
+// decrypted with the database key, so rows written before the store was configured keep working. A secret that
+// is replaced stays in the store until Prune finds it unreferenced.
+type StoreCrypto struct {
+	store  SecretStore
+	prefix string
+	local  *Crypto
+	now    func() time.Time
+}
+
+// NewStoreCrypto -. Secrets are written under prefix, local handles everything that is not a stored secret.
+func NewStoreCrypto(store SecretStore, prefix string, local *Crypto) *StoreCrypto {
+	return &StoreCrypto{
+		store:  store,
+		prefix: strings.Trim(prefix, "/"),
+		local:  local,
+		now:    time.Now,
+	}
+}
+
+// Encrypt stores plainText and returns the reference to it.
+func (s *StoreCrypto) Encrypt(plainText string) (string, error) {
+	b := make([]byte, referenceLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	name := strconv.FormatInt(s.now().Unix(), 16) + "-" + hex.EncodeToString(b)
+
+	if err := s.store.Write(context.Background(), path.Join(s.prefix, name), map[string]string{secretField: plainText}); err != nil {
+		return "", fmt.Errorf("encryption - Encrypt - s.store.Write: %w", err)
+	}
+
+	return referencePrefix + name, nil
+}
+
+// Decrypt resolves a reference, any other value is decrypted with the database key.
+func (s *StoreCrypto) Decrypt(cipherText string) (string, error) {
+	name, ok := strings.CutPrefix(cipherText, referencePrefix)
+	if !ok {
+		return s.local.Decrypt(cipherText)
+	}
+
+	data, err := s.store.Read(context.Background(), path.Join(s.prefix, name))
+	if err != nil {
+		return "", fmt.Errorf("encryption - Decrypt - s.store.Read: %w", err)
+	}
+
+	value, ok := data[secretField]
+	if !ok {
+		return "", errNoSecretValue
+	}
+
+	return value, nil
+}
+
+// Prune deletes the secrets under the prefix that none of referenced points to anymore, such as replaced
+// passwords and those of purged devices and profiles. Secrets written in the last pruneGrace are kept. It returns
+// the number of secrets deleted.
+func (s *StoreCrypto) Prune(ctx context.Context, referenced []string) (int, error) {
+	names, err := s.store.List(ctx, s.prefix)
+	if err != nil {
+		return 0, fmt.Errorf("encryption - Prune - s.store.List: %w", err)
+	}
+
+	kept := make(map[string]bool, len(referenced))
+
+	for _, value := range referenced {
+		if name, ok := strings.CutPrefix(value, referencePrefix); ok {
+			kept[name] = true
+		}
+	}
+
+	cutoff := s.now().Add(-pruneGrace)
+	pruned := 0
+
+	for _, name := range names {
+		if kept[name] || strings.HasSuffix(name, "/") || writtenAfter(name, cutoff) {
+			continue
+		}
+
+		if err := s.store.Delete(ctx, path.Join(s.prefix, name)); err != nil {
+			return pruned, fmt.Errorf("encryption - Prune - s.store.Delete: %w", err)
+		}
+
+		pruned++
+	}
+
+	return pruned, nil
+}
+
+// writtenAfter reports whether the secret name was written after t. Names without a time were written before
+// names carried it.
+func writtenAfter(name string, t time.Time) bool {
+	written, _, ok := strings.Cut(name, "-")
+	if !ok {
+		return false
+	}
+
+	seconds, err := strconv.ParseInt(written, 16, 64)
+	if err != nil {
+		return false
+	}
+
+	return time.Unix(seconds, 0).After(t)
+}
+
+// EncryptWithKey encrypts with the given key, it is used for exports and never touches the store.
+func (s *StoreCrypto) EncryptWithKey(plainText, key string) (string, error) {
+	return s.local.EncryptWithKey(plainText, key)
+}
+
+func (s *StoreCrypto) GenerateKey() string {
+	return s.local.GenerateKey()
+}
+
+func (s *StoreCrypto) ReadAndDecryptFile(filePath string) (wsmanconfig.Configuration, error) {
+	return s.local.ReadAndDecryptFile(filePath)
+}
+
+// isReference reports whether a stored value is a reference into the secret store.
+func isReference(value string) bool {
+	return strings.HasPrefix(value, referencePrefix)
+}
@@ -0,0 +1,73 @@
+package encryption_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
+	"github.com/device-management-toolkit/console/pkg/vault/vaulttest"
+)
+
+func TestStoreCrypto(t *testing.T) {
+	t.Parallel()
+
+	server := vaulttest.NewServer("secret", "root-token")
+	defer server.Close()
+
+	local := encryption.NewCrypto(oldKey)
+
+	cryptor, err := encryption.NewSecretProvider(config.Secrets{
+		Provider: encryption.ProviderVault,
+		Vault:    config.Vault{Address: server.URL, Token: "root-token", Mount: "secret", Path: "/console/"},
+	}, local)
+	require.NoError(t, err)
+
+	reference, err := cryptor.Encrypt("P@ssw0rd")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(reference, "ref:"))
+	require.NotContains(t, reference, "P@ssw0rd")
+
+	stored, ok := server.Secret("console/" + strings.TrimPrefix(reference, "ref:"))
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"value": "P@ssw0rd"}, stored)
+
+	decrypted, err := cryptor.Decrypt(reference)
+	require.NoError(t, err)
+	require.Equal(t, "P@ssw0rd", decrypted)
+
+	// values written before the store was configured are still encrypted with the key
+	encrypted, err := security.Crypto{EncryptionKey: oldKey}.Encrypt("legacy")
+	require.NoError(t, err)
+
+	decrypted, err = cryptor.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "legacy", decrypted)
+
+	_, err = cryptor.Decrypt("ref:unknown")
+	require.Error(t, err)
+	require.Equal(t, 1, server.Len())
+}
+
+func TestNewSecretProvider(t *testing.T) {
+	t.Parallel()
+
+	local := encryption.NewCrypto(oldKey)
+
+	cryptor, err := encryption.NewSecretProvider(config.Secrets{}, local)
+	require.NoError(t, err)
+	require.Same(t, local, cryptor)
+
+	cryptor, err = encryption.NewSecretProvider(config.Secrets{Provider: encryption.ProviderDatabase}, local)
+	require.NoError(t, err)
+	require.Same(t, local, cryptor)
+
+	_, err = encryption.NewSecretProvider(config.Secrets{Provider: encryption.ProviderVault}, local)
+	require.Error(t, err)
+
+	_, err = encryption.NewSecretProvider(config.Secrets{Provider: "hsm"}, local)
+	require.ErrorIs(t, err, encryption.ErrUnknownProvider)
+}
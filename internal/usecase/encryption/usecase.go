@@ -0,0 +1,193 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+const (
+	// KeyringService and KeyringKey locate the encryption key in the OS keyring when APP_ENCRYPTION_KEY is not set.
+	KeyringService = "device-management-toolkit"
+	KeyringKey     = "default-security-key"
+)
+
+// pruneInterval is how often the secret store is checked for secrets no row references anymore.
+const pruneInterval = time.Hour
+
+// UseCase -.
+type UseCase struct {
+	repo     Repository
+	crypto   *Crypto
+	secrets  security.Cryptor
+	keyStore security.Storager
+	log      logger.Interface
+}
+
+// New -. secrets is the provider the use cases keep their secrets with, keyStore is the keyring the key is kept
+// in when it is not configured.
+func New(r Repository, c *Crypto, secrets security.Cryptor, keyStore security.Storager, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:     r,
+		crypto:   c,
+		secrets:  secrets,
+		keyStore: keyStore,
+		log:      log,
+	}
+}
+
+var (
+	ErrEncryptionUseCase = consoleerrors.CreateConsoleError("EncryptionUseCase")
+	ErrDatabase          = sqldb.DatabaseError{Console: ErrEncryptionUseCase}
+	ErrValidation        = dto.NotValidError{Console: ErrEncryptionUseCase}
+	ErrVerification      = errors.New("a secret does not decrypt to its value with the new key")
+)
+
+// RotateKey encrypts every stored secret again with newKey, or with a generated key when newKey is empty. The
+// secrets are rewritten in one transaction and checked before it commits, so a failed rotation leaves the old
+// key in place. Encryption is held off while the rotation runs. The keyring is updated when the key came from
+// it, otherwise APP_ENCRYPTION_KEY has to be set to the new key before the next start.
+func (uc *UseCase) RotateKey(ctx context.Context, newKey string) (*dto.KeyRotation, error) {
+	result := &dto.KeyRotation{}
+
+	if newKey == "" {
+		newKey = security.Crypto{}.GenerateKey()
+		result.Key = newKey
+	}
+
+	if l := len(newKey); l != 16 && l != 24 && l != 32 {
+		return nil, ErrValidation.Wrap("RotateKey", "len(newKey)", errors.New("the key must be 16, 24 or 32 characters long"))
+	}
+
+	uc.crypto.mu.Lock()
+	defer uc.crypto.mu.Unlock()
+
+	oldKey := uc.crypto.key
+	if newKey == oldKey {
+		return nil, ErrValidation.Wrap("RotateKey", "newKey", errors.New("the new key is the current key"))
+	}
+
+	oldCrypto := security.Crypto{EncryptionKey: oldKey}
+	newCrypto := security.Crypto{EncryptionKey: newKey}
+
+	// references into a secret store are not encrypted with the key
+	reencrypt := func(cipherText string) (string, error) {
+		if isReference(cipherText) {
+			return cipherText, nil
+		}
+
+		plainText, err := oldCrypto.Decrypt(cipherText)
+		if err != nil {
+			return "", fmt.Errorf("decrypt with the current key: %w", err)
+		}
+
+		return newCrypto.Encrypt(plainText)
+	}
+
+	verify := func(oldCipherText, newCipherText string) error {
+		if isReference(oldCipherText) {
+			if newCipherText != oldCipherText {
+				return ErrVerification
+			}
+
+			return nil
+		}
+
+		plainText, err := newCrypto.Decrypt(newCipherText)
+		if err != nil {
+			return ErrVerification
+		}
+
+		if previous, _ := oldCrypto.Decrypt(oldCipherText); previous != plainText {
+			return ErrVerification
+		}
+
+		return nil
+	}
+
+	count, err := uc.repo.RotateSecrets(ctx, reencrypt, verify)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("RotateKey", "uc.repo.RotateSecrets", err)
+	}
+
+	uc.crypto.key = newKey
+	config.ConsoleConfig.EncryptionKey = newKey
+	result.Secrets = count
+
+	if uc.keyStore != nil {
+		stored, err := uc.keyStore.GetKeyValue(KeyringKey)
+		if err == nil && stored == oldKey {
+			if err := uc.keyStore.SetKeyValue(KeyringKey, newKey); err != nil {
+				// the secrets already use the new key, it must not get lost
+				result.Key = newKey
+
+				uc.log.Error("encryption - RotateKey - the keyring could not be updated, store the returned key: " + err.Error())
+
+				return result, nil
+			}
+
+			result.KeyringUpdated = true
+		}
+	}
+
+	uc.log.Info(fmt.Sprintf("encryption key rotated, %d secrets encrypted again", count))
+
+	return result, nil
+}
+
+// Run prunes the secret store right away and then every hour until ctx is done. It returns at once when the
+// secrets are kept in the database.
+func (uc *UseCase) Run(ctx context.Context) {
+	if _, ok := uc.secrets.(*StoreCrypto); !ok {
+		return
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.PruneSecrets(ctx); err != nil {
+			uc.log.Error("encryption - Run - " + err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PruneSecrets deletes the secrets of the secret store that neither a row nor a configuration revision references,
+// those left behind when a secret is replaced or its row is purged. It returns the number of secrets deleted.
+func (uc *UseCase) PruneSecrets(ctx context.Context) (int, error) {
+	store, ok := uc.secrets.(*StoreCrypto)
+	if !ok {
+		return 0, nil
+	}
+
+	referenced, err := uc.repo.GetSecrets(ctx)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("PruneSecrets", "uc.repo.GetSecrets", err)
+	}
+
+	pruned, err := store.Prune(ctx, referenced)
+	if err != nil {
+		return pruned, ErrEncryptionUseCase.Wrap("PruneSecrets", "store.Prune", err)
+	}
+
+	if pruned > 0 {
+		uc.log.Info(fmt.Sprintf("encryption - pruned %d secrets no longer referenced", pruned))
+	}
+
+	return pruned, nil
+}
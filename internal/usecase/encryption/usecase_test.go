@@ -0,0 +1,244 @@
+package encryption_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
+	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/vault"
+	"github.com/device-management-toolkit/console/pkg/vault/vaulttest"
+)
+
+const (
+	oldKey = "0123456789abcdef"
+	newKey = "fedcba9876543210fedcba9876543210"
+)
+
+var errKeyring = errors.New("keyring locked")
+
+// keyStore is a keyring kept in memory.
+type keyStore struct {
+	values map[string]string
+	err    error
+}
+
+func (k *keyStore) GetKeyValue(key string) (string, error) {
+	value, ok := k.values[key]
+	if !ok {
+		return "", errors.New("secret not found in keyring")
+	}
+
+	return value, nil
+}
+
+func (k *keyStore) SetKeyValue(key, value string) error {
+	if k.err != nil {
+		return k.err
+	}
+
+	k.values[key] = value
+
+	return nil
+}
+
+func (k *keyStore) DeleteKeyValue(key string) error {
+	delete(k.values, key)
+
+	return nil
+}
+
+func encryptionTest(t *testing.T, store security.Storager) (*encryption.UseCase, *encryption.Crypto, *mocks.MockEncryptionRepository) {
+	t.Helper()
+
+	config.ConsoleConfig = &config.Config{}
+	config.ConsoleConfig.EncryptionKey = oldKey
+
+	repo := mocks.NewMockEncryptionRepository(gomock.NewController(t))
+	crypto := encryption.NewCrypto(oldKey)
+
+	return encryption.New(repo, crypto, crypto, store, logger.New("error")), crypto, repo
+}
+
+// rotateSecret runs a rotation of one secret the way the repository does.
+func rotateSecret(t *testing.T, repo *mocks.MockEncryptionRepository, secret string) {
+	t.Helper()
+
+	repo.EXPECT().RotateSecrets(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, reencrypt func(string) (string, error), verify func(string, string) error) (int, error) {
+			value, err := reencrypt(secret)
+			if err != nil {
+				return 0, err
+			}
+
+			if err := verify(secret, value); err != nil {
+				return 0, err
+			}
+
+			return 1, nil
+		})
+}
+
+func TestRotateKey(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	secret, err := security.Crypto{EncryptionKey: oldKey}.Encrypt("P@ssw0rd")
+	require.NoError(t, err)
+
+	t.Run("keyring key", func(t *testing.T) {
+		store := &keyStore{values: map[string]string{encryption.KeyringKey: oldKey}}
+		useCase, crypto, repo := encryptionTest(t, store)
+
+		rotateSecret(t, repo, secret)
+
+		result, err := useCase.RotateKey(context.Background(), newKey)
+		require.NoError(t, err)
+		require.Equal(t, &dto.KeyRotation{Secrets: 1, KeyringUpdated: true}, result)
+		require.Equal(t, newKey, store.values[encryption.KeyringKey])
+		require.Equal(t, newKey, config.ConsoleConfig.EncryptionKey)
+
+		// secrets written from now on use the new key
+		encrypted, err := crypto.Encrypt("P@ssw0rd")
+		require.NoError(t, err)
+
+		decrypted, err := security.Crypto{EncryptionKey: newKey}.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "P@ssw0rd", decrypted)
+	})
+
+	t.Run("secret store references are kept", func(t *testing.T) {
+		useCase, _, repo := encryptionTest(t, nil)
+
+		rotateSecret(t, repo, "ref:0123456789abcdef0123456789abcdef")
+
+		result, err := useCase.RotateKey(context.Background(), newKey)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Secrets)
+	})
+
+	t.Run("configured key is generated and returned", func(t *testing.T) {
+		store := &keyStore{values: map[string]string{}}
+		useCase, _, repo := encryptionTest(t, store)
+
+		rotateSecret(t, repo, secret)
+
+		result, err := useCase.RotateKey(context.Background(), "")
+		require.NoError(t, err)
+		require.False(t, result.KeyringUpdated)
+		require.Len(t, result.Key, 32)
+		require.Equal(t, result.Key, config.ConsoleConfig.EncryptionKey)
+		require.Empty(t, store.values)
+	})
+
+	t.Run("keyring update fails", func(t *testing.T) {
+		store := &keyStore{values: map[string]string{encryption.KeyringKey: oldKey}, err: errKeyring}
+		useCase, _, repo := encryptionTest(t, store)
+
+		rotateSecret(t, repo, secret)
+
+		result, err := useCase.RotateKey(context.Background(), newKey)
+		require.NoError(t, err)
+		require.Equal(t, &dto.KeyRotation{Secrets: 1, Key: newKey}, result)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		useCase, _, _ := encryptionTest(t, nil)
+
+		_, err := useCase.RotateKey(context.Background(), "short")
+
+		var validationErr dto.NotValidError
+
+		require.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("same key", func(t *testing.T) {
+		useCase, _, _ := encryptionTest(t, nil)
+
+		_, err := useCase.RotateKey(context.Background(), oldKey)
+
+		var validationErr dto.NotValidError
+
+		require.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("secret of another key keeps the old key", func(t *testing.T) {
+		useCase, crypto, repo := encryptionTest(t, nil)
+
+		foreign, err := security.Crypto{EncryptionKey: "another key 0123"}.Encrypt("P@ssw0rd")
+		require.NoError(t, err)
+
+		rotateSecret(t, repo, foreign)
+
+		_, err = useCase.RotateKey(context.Background(), newKey)
+		require.Error(t, err)
+		require.Equal(t, oldKey, config.ConsoleConfig.EncryptionKey)
+
+		decrypted, err := crypto.Decrypt(secret)
+		require.NoError(t, err)
+		require.Equal(t, "P@ssw0rd", decrypted)
+	})
+}
+
+func TestPruneSecrets(t *testing.T) {
+	t.Parallel()
+
+	server := vaulttest.NewServer("secret", "root-token")
+	defer server.Close()
+
+	local := encryption.NewCrypto(oldKey)
+
+	cryptor, err := encryption.NewSecretProvider(config.Secrets{
+		Provider: encryption.ProviderVault,
+		Vault:    config.Vault{Address: server.URL, Token: "root-token", Mount: "secret", Path: "console"},
+	}, local)
+	require.NoError(t, err)
+
+	// secrets written before names carried the time they were written
+	client := vault.New(server.URL, "root-token")
+	require.NoError(t, client.Write(context.Background(), "console/kept", map[string]string{"value": "current"}))
+	require.NoError(t, client.Write(context.Background(), "console/replaced", map[string]string{"value": "previous"}))
+
+	// its row may not be saved yet
+	fresh, err := cryptor.Encrypt("new")
+	require.NoError(t, err)
+
+	encrypted, err := local.Encrypt("legacy")
+	require.NoError(t, err)
+
+	repo := mocks.NewMockEncryptionRepository(gomock.NewController(t))
+	repo.EXPECT().GetSecrets(gomock.Any()).Return([]string{"ref:kept", encrypted}, nil)
+
+	useCase := encryption.New(repo, local, cryptor, nil, logger.New("error"))
+
+	pruned, err := useCase.PruneSecrets(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+
+	_, ok := server.Secret("console/replaced")
+	require.False(t, ok)
+
+	_, ok = server.Secret("console/kept")
+	require.True(t, ok)
+
+	_, ok = server.Secret("console/" + strings.TrimPrefix(fresh, "ref:"))
+	require.True(t, ok)
+}
+
+func TestPruneSecretsInDatabase(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewMockEncryptionRepository(gomock.NewController(t))
+	local := encryption.NewCrypto(oldKey)
+
+	// secrets kept in encrypted columns leave nothing behind to prune
+	pruned, err := encryption.New(repo, local, local, nil, logger.New("error")).PruneSecrets(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+}
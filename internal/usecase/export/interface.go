@@ -11,4 +11,6 @@ import (
 type Exporter interface {
 	ExportAuditLogsCSV(logs []auditlog.AuditLogRecord) (io.Reader, error) // Converts logs to CSV and returns a reader
 	ExportEventLogsCSV(logs []dto.EventLog) (io.Reader, error)            // Converts logs to CSV and returns a reader
+	ExportActivityCSV(entries []dto.ActivityEntry) (io.Reader, error)     // Converts console activity to CSV and returns a reader
+	ExportDevicesCSV(devices []dto.Device) (io.Reader, error)             // Converts devices and their attributes to CSV and returns a reader
 }
@@ -5,6 +5,10 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
 
@@ -63,3 +67,99 @@ func (e *FileExporter) ExportEventLogsCSV(logs []dto.EventLog) (io.Reader, error
 
 	return buffer, nil
 }
+
+// ExportActivityCSV converts console activity entries to CSV and returns a reader.
+func (e *FileExporter) ExportActivityCSV(entries []dto.ActivityEntry) (io.Reader, error) {
+	buffer := &bytes.Buffer{}
+	writer := csv.NewWriter(buffer)
+
+	records := [][]string{{"Time", "Actor", "API Key", "Source IP", "Action", "Target", "Parameters", "Status", "Result"}}
+	for i := range entries {
+		records = append(records, []string{
+			entries[i].Timestamp.Format(time.RFC3339Nano),
+			entries[i].Actor,
+			entries[i].APIKeyID,
+			entries[i].SourceIP,
+			entries[i].Action,
+			entries[i].Target,
+			entries[i].Parameters,
+			strconv.Itoa(entries[i].Status),
+			entries[i].Result,
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("error writing CSV: %w", err)
+	}
+
+	return buffer, nil
+}
+
+// ExportDevicesCSV converts devices to CSV with a column for each custom attribute any of them has, and returns
+// a reader.
+func (e *FileExporter) ExportDevicesCSV(devices []dto.Device) (io.Reader, error) {
+	buffer := &bytes.Buffer{}
+	writer := csv.NewWriter(buffer)
+
+	seen := make(map[string]bool)
+
+	var attributes []string
+
+	for i := range devices {
+		for name := range devices[i].Attributes {
+			if !seen[name] {
+				seen[name] = true
+
+				attributes = append(attributes, name)
+			}
+		}
+	}
+
+	sort.Strings(attributes)
+
+	header := []string{"GUID", "Hostname", "Friendly Name", "Tags", "DNS Suffix", "Connected", "FW Version", "Current Mode", "IP Address"}
+	records := [][]string{append(header, attributes...)}
+
+	for i := range devices {
+		info := devices[i].DeviceInfo
+		if info == nil {
+			info = &dto.DeviceInfo{}
+		}
+
+		record := []string{
+			devices[i].GUID,
+			devices[i].Hostname,
+			devices[i].FriendlyName,
+			strings.Join(devices[i].Tags, ","),
+			devices[i].DNSSuffix,
+			strconv.FormatBool(devices[i].ConnectionStatus),
+			info.FWVersion,
+			info.CurrentMode,
+			info.IPAddress,
+		}
+
+		for _, name := range attributes {
+			record = append(record, attributeString(devices[i].Attributes[name]))
+		}
+
+		records = append(records, record)
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("error writing CSV: %w", err)
+	}
+
+	return buffer, nil
+}
+
+// attributeString formats the value of a custom attribute, numbers without an exponent.
+func attributeString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -121,3 +121,53 @@ func TestExportEventLogsCSV(t *testing.T) {
 		})
 	}
 }
+
+func TestExportActivityCSV(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	reader, err := export.NewFileExporter().ExportActivityCSV([]dto.ActivityEntry{{
+		Timestamp:  timestamp,
+		Actor:      "jdoe",
+		SourceIP:   "10.0.0.5",
+		Action:     "POST /api/v1/amt/power/action/:guid",
+		Target:     "guid=123",
+		Parameters: `{"body":{"action":10}}`,
+		Status:     200,
+		Result:     "success",
+	}})
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(reader).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Time", "Actor", "API Key", "Source IP", "Action", "Target", "Parameters", "Status", "Result"},
+		{"2025-01-02T03:04:05Z", "jdoe", "", "10.0.0.5", "POST /api/v1/amt/power/action/:guid", "guid=123", `{"body":{"action":10}}`, "200", "success"},
+	}, records)
+}
+
+func TestExportDevicesCSV(t *testing.T) {
+	t.Parallel()
+
+	reader, err := export.NewFileExporter().ExportDevicesCSV([]dto.Device{
+		{
+			GUID:             "guid1",
+			Hostname:         "pos-1",
+			Tags:             []string{"site-x", "lab"},
+			ConnectionStatus: true,
+			DeviceInfo:       &dto.DeviceInfo{FWVersion: "16.1.27", CurrentMode: "ACM", IPAddress: "10.0.0.5"},
+			Attributes:       map[string]interface{}{"rack": float64(12), "owner": "ops"},
+		},
+		{GUID: "guid2", Attributes: map[string]interface{}{"warrantyEnd": "2027-03-31T00:00:00Z"}},
+	})
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(reader).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"GUID", "Hostname", "Friendly Name", "Tags", "DNS Suffix", "Connected", "FW Version", "Current Mode", "IP Address", "owner", "rack", "warrantyEnd"},
+		{"guid1", "pos-1", "", "site-x,lab", "", "true", "16.1.27", "ACM", "10.0.0.5", "ops", "12", ""},
+		{"guid2", "", "", "", "", "false", "", "", "", "", "", "2027-03-31T00:00:00Z"},
+	}, records)
+}
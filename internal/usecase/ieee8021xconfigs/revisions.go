@@ -0,0 +1,34 @@
+package ieee8021xconfigs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// recordRevision stores the saved 802.1X config as a revision, it has no secrets.
+func (uc *UseCase) recordRevision(ctx context.Context, d2 *dto.IEEE8021xConfig, _ *entity.IEEE8021xConfig) error {
+	if err := uc.revisions.Record(ctx, dto.RevisionKindIEEE8021x, d2.ProfileName, d2.TenantID, d2, nil); err != nil {
+		return ErrDatabase.Wrap("recordRevision", "uc.revisions.Record", err)
+	}
+
+	return nil
+}
+
+// ApplyRevision updates the 802.1X config to the content of one of its revisions.
+func (uc *UseCase) ApplyRevision(ctx context.Context, content []byte, tenantID string) error {
+	d := &dto.IEEE8021xConfig{}
+	if err := json.Unmarshal(content, d); err != nil {
+		return err
+	}
+
+	d.TenantID = tenantID
+	// a revision is applied over whatever version the config is at now
+	d.Version = ""
+
+	_, err := uc.Update(ctx, d)
+
+	return err
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/ieee8021xconfigs"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type test struct {
@@ -36,7 +37,12 @@ func ieee8021xconfigsTest(t *testing.T) (*ieee8021xconfigs.UseCase, *mocks.MockI
 
 	repo := mocks.NewMockIEEE8021xConfigsRepository(mockCtl)
 
-	useCase := ieee8021xconfigs.New(repo, log)
+	revisionsMock := mocks.NewMockRevisionsRecorder(mockCtl)
+	revisionsMock.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	revisionsMock.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	useCase := ieee8021xconfigs.New(repo, log, revisionsMock)
 
 	return useCase, repo
 }
@@ -91,7 +97,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "empty result",
 			mock: func(repo *mocks.MockIEEE8021xConfigsRepository) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, nil)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, nil)
 			},
 			res: 0,
 			err: nil,
@@ -99,7 +105,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "result with error",
 			mock: func(repo *mocks.MockIEEE8021xConfigsRepository) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, ieee8021xconfigs.ErrDatabase)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, ieee8021xconfigs.ErrDatabase)
 			},
 			res: 0,
 			err: ieee8021xconfigs.ErrDatabase,
@@ -115,7 +121,7 @@ func TestGetCount(t *testing.T) {
 
 			tc.mock(repo)
 
-			res, err := useCase.GetCount(context.Background(), "")
+			res, err := useCase.GetCount(context.Background(), odata.Query{}, "")
 
 			require.Equal(t, tc.res, res)
 			require.IsType(t, tc.err, err)
@@ -156,7 +162,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockIEEE8021xConfigsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 0, "tenant-id-456").
 					Return(IEEE8021xConfigs, nil)
 			},
 			res: IEEE8021xConfigDTOs,
@@ -169,7 +175,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockIEEE8021xConfigsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 5, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 5, 0, "tenant-id-456").
 					Return(nil, ieee8021xconfigs.ErrDatabase)
 			},
 			res: []dto.IEEE8021xConfig(nil),
@@ -182,7 +188,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockIEEE8021xConfigsRepository) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 20, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 20, "tenant-id-456").
 					Return([]entity.IEEE8021xConfig{}, nil)
 			},
 			res: []dto.IEEE8021xConfig{},
@@ -198,7 +204,7 @@ func TestGet(t *testing.T) {
 
 			tc.mock(repo)
 
-			results, err := useCase.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			results, err := useCase.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			require.Equal(t, tc.res, results)
 
@@ -289,7 +295,7 @@ func TestDelete(t *testing.T) {
 			tenantID:    "tenant-id-456",
 			mock: func(repo *mocks.MockIEEE8021xConfigsRepository) {
 				repo.EXPECT().
-					Delete(context.Background(), "example-ieee8021xconfig", "tenant-id-456").
+					Delete(context.Background(), "example-ieee8021xconfig", "", "tenant-id-456").
 					Return(true, nil)
 			},
 			err: nil,
@@ -300,7 +306,7 @@ func TestDelete(t *testing.T) {
 			tenantID:    "tenant-id-456",
 			mock: func(repo *mocks.MockIEEE8021xConfigsRepository) {
 				repo.EXPECT().
-					Delete(context.Background(), "nonexistent-ieee8021xconfig", "tenant-id-456").
+					Delete(context.Background(), "nonexistent-ieee8021xconfig", "", "tenant-id-456").
 					Return(false, nil)
 			},
 			err: ieee8021xconfigs.ErrNotFound,
@@ -315,7 +321,7 @@ func TestDelete(t *testing.T) {
 
 			tc.mock(repo)
 
-			err := useCase.Delete(context.Background(), tc.profileName, tc.tenantID)
+			err := useCase.Delete(context.Background(), tc.profileName, "", tc.tenantID)
 
 			if tc.err != nil {
 				require.Error(t, err)
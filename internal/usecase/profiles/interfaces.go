@@ -5,25 +5,36 @@ import (
 
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type (
 	Repository interface {
-		GetCount(ctx context.Context, tenantID string) (int, error)
-		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Profile, error)
+		GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+		Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Profile, error)
 		GetByName(ctx context.Context, profileName, tenantID string) (*entity.Profile, error)
-		Delete(ctx context.Context, profileName, tenantID string) (bool, error)
+		Delete(ctx context.Context, profileName, version, tenantID string) (bool, error)
 		Update(ctx context.Context, p *entity.Profile) (bool, error)
 		Insert(ctx context.Context, p *entity.Profile) (string, error)
+		GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]entity.Profile, error)
+		Restore(ctx context.Context, profileName, tenantID string) (bool, error)
+		Purge(ctx context.Context, profileName, tenantID string) (bool, error)
+		PurgeDeleted(ctx context.Context, before string) (int, error)
+		GetByTemplate(ctx context.Context, templateName, tenantID string) ([]string, error)
 	}
 
 	Feature interface {
-		GetCount(ctx context.Context, tenantID string) (int, error)
-		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Profile, error)
+		GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error)
+		Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Profile, error)
 		GetByName(ctx context.Context, profileName, tenantID string) (*dto.Profile, error)
-		Delete(ctx context.Context, profileName, tenantID string) error
+		Delete(ctx context.Context, profileName, version, tenantID string) error
 		Update(ctx context.Context, p *dto.Profile) (*dto.Profile, error)
 		Insert(ctx context.Context, p *dto.Profile) (*dto.Profile, error)
 		Export(ctx context.Context, profileName, domainName, tenantID string) (string, string, error)
+		GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]dto.Profile, error)
+		Restore(ctx context.Context, profileName, tenantID string) error
+		Purge(ctx context.Context, profileName, tenantID string) error
+		PurgeDeleted(ctx context.Context, before string) (int, error)
+		Clone(ctx context.Context, profileName string, overrides []byte, tenantID string) (*dto.Profile, error)
 	}
 )
@@ -0,0 +1,39 @@
+package profiles
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// recordRevision stores the saved profile as a revision, the passwords are kept as they were encrypted for the profile.
+func (uc *UseCase) recordRevision(ctx context.Context, d2 *dto.Profile, d1 *entity.Profile) error {
+	secrets := map[string]string{
+		"amtPassword":  d1.AMTPassword,
+		"mebxPassword": d1.MEBXPassword,
+	}
+
+	if err := uc.revisions.Record(ctx, dto.RevisionKindProfile, d2.ProfileName, d2.TenantID, d2, secrets); err != nil {
+		return ErrDatabase.Wrap("recordRevision", "uc.revisions.Record", err)
+	}
+
+	return nil
+}
+
+// ApplyRevision updates the profile to the content of one of its revisions.
+func (uc *UseCase) ApplyRevision(ctx context.Context, content []byte, tenantID string) error {
+	d := &dto.Profile{}
+	if err := json.Unmarshal(content, d); err != nil {
+		return err
+	}
+
+	d.TenantID = tenantID
+	// a revision is applied over whatever version the config is at now
+	d.Version = ""
+
+	_, err := uc.Update(ctx, d)
+
+	return err
+}
@@ -0,0 +1,353 @@
+package profiles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+var (
+	errTemplateNotFound = errors.New("profile template is not found in the database")
+	errOverride         = errors.New("a template does not hold the setting")
+	errCloneName        = errors.New("the clone needs a profile name of its own")
+	errInvalidDerived   = errors.New("the template would leave derived profiles invalid")
+
+	registerValidations sync.Once
+)
+
+// validate checks a profile against the binding rules of a profile request body. Clones and profiles derived
+// from a template only take their final settings once merged, after the request body was bound.
+func validate(d *dto.Profile) error {
+	if binding.Validator == nil {
+		return nil
+	}
+
+	registerValidations.Do(func() {
+		if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+			_ = v.RegisterValidation("genpasswordwone", dto.ValidateAMTPassOrGenRan)
+			_ = v.RegisterValidation("ciraortls", dto.ValidateCIRAOrTLS)
+			_ = v.RegisterValidation("authProtocolValidator", dto.AuthProtocolValidator)
+		}
+	})
+
+	return binding.Validator.ValidateStruct(d)
+}
+
+// applyTemplate sets the settings a derived profile inherits from its template, the ones it overrides are kept.
+func (uc *UseCase) applyTemplate(ctx context.Context, d *dto.Profile) error {
+	if d.TemplateName == "" {
+		d.TemplateOverrides = nil
+
+		return nil
+	}
+
+	for _, field := range d.TemplateOverrides {
+		if !slices.Contains(dto.ProfileTemplateFields, field) {
+			return ErrNotValid.Wrap("applyTemplate", "dto.ProfileTemplateFields", fmt.Errorf("%w: %s", errOverride, field))
+		}
+	}
+
+	t, err := uc.templates.GetByName(ctx, d.TemplateName, d.TenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("applyTemplate", "uc.templates.GetByName", err)
+	}
+
+	if t == nil {
+		return ErrNotValid.Wrap("applyTemplate", "uc.templates.GetByName", errTemplateNotFound)
+	}
+
+	if err := inheritSettings(d, []byte(t.Settings)); err != nil {
+		return err
+	}
+
+	if err := validate(d); err != nil {
+		return ErrNotValid.Wrap("applyTemplate", "validate", err)
+	}
+
+	return nil
+}
+
+// inheritSettings overlays the template settings the profile does not override onto it.
+func inheritSettings(d *dto.Profile, settings []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(settings, &fields); err != nil {
+		return ErrDatabase.Wrap("inheritSettings", "json.Unmarshal", err)
+	}
+
+	for _, field := range d.TemplateOverrides {
+		delete(fields, field)
+	}
+
+	if err := overlay(d, fields); err != nil {
+		return ErrDatabase.Wrap("inheritSettings", "overlay", err)
+	}
+
+	return nil
+}
+
+// overlay sets the profile fields present in fields, keyed by their JSON names, and leaves the others as they are.
+func overlay(d *dto.Profile, fields map[string]json.RawMessage) error {
+	// a decoded list would otherwise reuse the entries of the current one
+	if _, ok := fields["tags"]; ok {
+		d.Tags = nil
+	}
+
+	if _, ok := fields["wifiConfigs"]; ok {
+		d.WiFiConfigs = nil
+	}
+
+	content, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(content, d); err != nil {
+		return err
+	}
+
+	for i := range d.WiFiConfigs {
+		d.WiFiConfigs[i].ProfileName = d.ProfileName
+		d.WiFiConfigs[i].TenantID = d.TenantID
+	}
+
+	return nil
+}
+
+// editableProfile is the profile as it would be sent to Update: with its passwords in plain text and its wireless
+// configurations.
+func (uc *UseCase) editableProfile(ctx context.Context, data *entity.Profile) (*dto.Profile, error) {
+	if err := uc.DecryptPasswords(data); err != nil {
+		return nil, ErrDatabase.Wrap("editableProfile", "uc.DecryptPasswords", err)
+	}
+
+	wifiConfigs, err := uc.GetWiFiConfigurations(ctx, data.ProfileName, data.TenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("editableProfile", "uc.GetWiFiConfigurations", err)
+	}
+
+	d := uc.entityToDTO(data)
+	d.AMTPassword = data.AMTPassword
+	d.MEBXPassword = data.MEBXPassword
+	d.WiFiConfigs = wifiConfigs
+	d.IEEE8021xProfile = nil
+
+	return d, nil
+}
+
+// Clone creates a profile from an existing one. The overrides are profile fields, with at least a new profileName,
+// that replace those of the copy. A clone of a derived profile is derived from the same template.
+func (uc *UseCase) Clone(ctx context.Context, profileName string, overrides []byte, tenantID string) (*dto.Profile, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(overrides, &fields); err != nil {
+		return nil, ErrNotValid.Wrap("Clone", "json.Unmarshal", err)
+	}
+
+	data, err := uc.repo.GetByName(ctx, profileName, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Clone", "uc.repo.GetByName", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	d, err := uc.editableProfile(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	d.ProfileName = ""
+	d.CreationDate = ""
+	d.Version = ""
+
+	delete(fields, "tenantId")
+
+	if err := overlay(d, fields); err != nil {
+		return nil, ErrNotValid.Wrap("Clone", "overlay", err)
+	}
+
+	if d.ProfileName == "" || d.ProfileName == profileName {
+		return nil, ErrNotValid.Wrap("Clone", "d.ProfileName", errCloneName)
+	}
+
+	d.TenantID = tenantID
+
+	// a derived clone is checked once it took the settings of its template
+	if d.TemplateName == "" {
+		if err := validate(d); err != nil {
+			return nil, ErrNotValid.Wrap("Clone", "validate", err)
+		}
+	}
+
+	return uc.Insert(ctx, d)
+}
+
+// GetByTemplate lists the names of the profiles derived from a template.
+func (uc *UseCase) GetByTemplate(ctx context.Context, templateName, tenantID string) ([]string, error) {
+	names, err := uc.repo.GetByTemplate(ctx, templateName, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByTemplate", "uc.repo.GetByTemplate", err)
+	}
+
+	return names, nil
+}
+
+// ApplyTemplate saves every profile derived from a template again so that they take its current settings. Every
+// profile is checked before any is saved, a template that would leave one of them invalid is applied to none. It
+// returns the names of the profiles saved, on an error those saved before it, which already hold the settings.
+func (uc *UseCase) ApplyTemplate(ctx context.Context, templateName, tenantID string) ([]string, error) {
+	names, err := uc.GetByTemplate(ctx, templateName, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	derived := make([]*dto.Profile, 0, len(names))
+	invalid := make([]string, 0)
+
+	for _, name := range names {
+		data, err := uc.repo.GetByName(ctx, name, tenantID)
+		if err != nil {
+			return nil, ErrDatabase.Wrap("ApplyTemplate", "uc.repo.GetByName", err)
+		}
+
+		if data == nil {
+			continue
+		}
+
+		d, err := uc.editableProfile(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := uc.applyTemplate(ctx, d); err != nil {
+			if !errors.As(err, &dto.NotValidError{}) {
+				return nil, err
+			}
+
+			invalid = append(invalid, name)
+
+			continue
+		}
+
+		derived = append(derived, d)
+	}
+
+	if len(invalid) > 0 {
+		return nil, ErrNotValid.Wrap("ApplyTemplate", "uc.applyTemplate", fmt.Errorf("%w: %s", errInvalidDerived, strings.Join(invalid, ", ")))
+	}
+
+	applied := make([]string, 0, len(derived))
+
+	for _, d := range derived {
+		if _, err := uc.Update(ctx, d); err != nil {
+			return applied, err
+		}
+
+		applied = append(applied, d.ProfileName)
+	}
+
+	return applied, nil
+}
+
+// PreviewTemplate lists the settings the template, once saved, would change in each profile derived from it and
+// the profiles it would leave invalid.
+func (uc *UseCase) PreviewTemplate(ctx context.Context, t *dto.ProfileTemplate) ([]dto.ProfileTemplateImpact, error) {
+	settings, err := json.Marshal(t.ProfileTemplateSettings)
+	if err != nil {
+		return nil, ErrNotValid.Wrap("PreviewTemplate", "json.Marshal", err)
+	}
+
+	names, err := uc.GetByTemplate(ctx, t.TemplateName, t.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	impacts := make([]dto.ProfileTemplateImpact, 0)
+
+	for _, name := range names {
+		data, err := uc.repo.GetByName(ctx, name, t.TenantID)
+		if err != nil {
+			return nil, ErrDatabase.Wrap("PreviewTemplate", "uc.repo.GetByName", err)
+		}
+
+		if data == nil {
+			continue
+		}
+
+		// the passwords are needed to check the profile and are never part of the impact
+		current, err := uc.editableProfile(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+
+		next := *current
+		if err := inheritSettings(&next, settings); err != nil {
+			return nil, err
+		}
+
+		changes, err := changedSettings(current, &next)
+		if err != nil {
+			return nil, ErrDatabase.Wrap("PreviewTemplate", "changedSettings", err)
+		}
+
+		impact := dto.ProfileTemplateImpact{ProfileName: name, Changes: changes, Overrides: current.TemplateOverrides}
+
+		if err := validate(&next); err != nil {
+			impact.Invalid = err.Error()
+		}
+
+		if len(changes) > 0 || impact.Invalid != "" {
+			impacts = append(impacts, impact)
+		}
+	}
+
+	return impacts, nil
+}
+
+// changedSettings returns the template settings that differ between two versions of a profile.
+func changedSettings(from, to *dto.Profile) ([]string, error) {
+	before, err := templateSettings(from)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := templateSettings(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]string, 0)
+
+	for _, field := range dto.ProfileTemplateFields {
+		if !bytes.Equal(before[field], after[field]) {
+			changes = append(changes, field)
+		}
+	}
+
+	return changes, nil
+}
+
+func templateSettings(d *dto.Profile) (map[string]json.RawMessage, error) {
+	content, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
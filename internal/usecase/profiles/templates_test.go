@@ -0,0 +1,275 @@
+package profiles_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/profiles"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// validCrypto decrypts every password to one a profile accepts.
+type validCrypto struct {
+	mocks.MockCrypto
+}
+
+func (validCrypto) Decrypt(_ string) (string, error) {
+	return "P@ssw0rd1", nil
+}
+
+type templatesMocks struct {
+	repo      *mocks.MockProfilesRepository
+	pwc       *mocks.MockProfileWiFiConfigsFeature
+	templates *mocks.MockProfileTemplatesRepository
+}
+
+func templatesTest(t *testing.T) (*profiles.UseCase, templatesMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	m := templatesMocks{
+		repo:      mocks.NewMockProfilesRepository(mockCtl),
+		pwc:       mocks.NewMockProfileWiFiConfigsFeature(mockCtl),
+		templates: mocks.NewMockProfileTemplatesRepository(mockCtl),
+	}
+
+	revisionsMock := mocks.NewMockRevisionsRecorder(mockCtl)
+	revisionsMock.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	revisionsMock.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	useCase := profiles.New(m.repo, mocks.NewMockWiFiConfigsRepository(mockCtl), m.pwc, mocks.NewMockIEEE8021xConfigsFeature(mockCtl),
+		logger.New("error"), mocks.NewMockDomainsRepository(mockCtl), mocks.NewMockCIRAConfigsRepository(mockCtl), validCrypto{}, revisionsMock, m.templates)
+
+	return useCase, m
+}
+
+var siteTemplate = &entity.ProfileTemplate{
+	TemplateName: "site",
+	Settings:     `{"tlsMode":1,"kvmEnabled":true,"tags":["site"]}`,
+	TenantID:     "t1",
+}
+
+func TestInsertFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	var inserted *entity.Profile
+
+	m.templates.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(siteTemplate, nil)
+	m.repo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entity.Profile) (string, error) {
+		inserted = p
+
+		return "", nil
+	})
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").DoAndReturn(func(_ context.Context, _, _ string) (*entity.Profile, error) {
+		return inserted, nil
+	})
+
+	profile := &dto.Profile{ProfileName: "p1", AMTPassword: "P@ssw0rd1", Activation: "ccmactivate", TenantID: "t1", TemplateName: "site", TemplateOverrides: []string{"kvmEnabled"}}
+
+	created, err := useCase.Insert(context.Background(), profile)
+	require.NoError(t, err)
+	require.Equal(t, 1, inserted.TLSMode)
+	require.False(t, inserted.KVMEnabled)
+	require.Equal(t, "site", inserted.Tags)
+	require.Equal(t, "kvmEnabled", inserted.TemplateOverrides)
+	require.Equal(t, []string{"kvmEnabled"}, created.TemplateOverrides)
+}
+
+func TestInsertFromTemplateNotValid(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	m.templates.EXPECT().GetByName(gomock.Any(), "missing", "t1").Return(nil, nil)
+
+	_, err := useCase.Insert(context.Background(), &dto.Profile{ProfileName: "p1", TenantID: "t1", TemplateName: "missing"})
+	require.ErrorAs(t, err, &dto.NotValidError{})
+
+	// the passwords are never part of a template
+	_, err = useCase.Insert(context.Background(), &dto.Profile{ProfileName: "p1", TenantID: "t1", TemplateName: "site", TemplateOverrides: []string{"amtPassword"}})
+	require.ErrorAs(t, err, &dto.NotValidError{})
+
+	// the template settings leave the profile with both a CIRA config and a TLS mode
+	cira := "cira"
+
+	m.templates.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(siteTemplate, nil)
+
+	_, err = useCase.Insert(context.Background(), &dto.Profile{ProfileName: "p1", AMTPassword: "P@ssw0rd1", Activation: "ccmactivate", CIRAConfigName: &cira, TenantID: "t1", TemplateName: "site"})
+	require.ErrorAs(t, err, &dto.NotValidError{})
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	var inserted *entity.Profile
+
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(&entity.Profile{
+		ProfileName: "p1", Activation: "acmactivate", TLSMode: 1, KVMEnabled: true, AMTPassword: "enc", MEBXPassword: "enc", TenantID: "t1",
+	}, nil)
+	m.pwc.EXPECT().GetByProfileName(gomock.Any(), "p1", "t1").Return(nil, nil)
+	m.repo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entity.Profile) (string, error) {
+		inserted = p
+
+		return "", nil
+	})
+	m.repo.EXPECT().GetByName(gomock.Any(), "p2", "t1").DoAndReturn(func(_ context.Context, _, _ string) (*entity.Profile, error) {
+		return inserted, nil
+	})
+
+	clone, err := useCase.Clone(context.Background(), "p1", []byte(`{"profileName":"p2","tlsMode":2,"tenantId":"other"}`), "t1")
+	require.NoError(t, err)
+	require.Equal(t, "p2", clone.ProfileName)
+	require.Equal(t, "t1", inserted.TenantID)
+	require.Equal(t, 2, inserted.TLSMode)
+	require.True(t, inserted.KVMEnabled)
+	require.Equal(t, "acmactivate", inserted.Activation)
+	require.Equal(t, "encrypted", inserted.AMTPassword)
+}
+
+func TestCloneNotValid(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(&entity.Profile{ProfileName: "p1", Activation: "ccmactivate", TenantID: "t1"}, nil)
+	m.pwc.EXPECT().GetByProfileName(gomock.Any(), "p1", "t1").Return(nil, nil)
+
+	// the overrides are not bound as a request body, the clone is checked as a whole before it is saved
+	_, err := useCase.Clone(context.Background(), "p1", []byte(`{"profileName":"x","activation":"bogus"}`), "t1")
+	require.ErrorAs(t, err, &dto.NotValidError{})
+}
+
+func TestCloneNeedsAName(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	_, err := useCase.Clone(context.Background(), "p1", []byte(`[]`), "t1")
+	require.ErrorAs(t, err, &dto.NotValidError{})
+
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(&entity.Profile{ProfileName: "p1", TenantID: "t1"}, nil)
+	m.pwc.EXPECT().GetByProfileName(gomock.Any(), "p1", "t1").Return(nil, nil)
+
+	_, err = useCase.Clone(context.Background(), "p1", []byte(`{"tlsMode":2}`), "t1")
+	require.ErrorAs(t, err, &dto.NotValidError{})
+}
+
+func TestApplyTemplate(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	stored := &entity.Profile{ProfileName: "p1", Activation: "ccmactivate", TLSMode: 2, Tags: "branch", TemplateName: "site", TemplateOverrides: "tags", TenantID: "t1"}
+
+	var updated *entity.Profile
+
+	m.repo.EXPECT().GetByTemplate(gomock.Any(), "site", "t1").Return([]string{"p1"}, nil)
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(stored, nil)
+	m.pwc.EXPECT().GetByProfileName(gomock.Any(), "p1", "t1").Return(nil, nil)
+	m.templates.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(siteTemplate, nil).Times(2)
+	m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entity.Profile) (bool, error) {
+		updated = p
+
+		return true, nil
+	})
+	m.pwc.EXPECT().DeleteByProfileName(gomock.Any(), "p1", "t1").Return(nil)
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(stored, nil)
+
+	applied, err := useCase.ApplyTemplate(context.Background(), "site", "t1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"p1"}, applied)
+	require.Equal(t, 1, updated.TLSMode)
+	require.True(t, updated.KVMEnabled)
+	require.Equal(t, "branch", updated.Tags)
+}
+
+func TestApplyTemplatePartially(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	m.repo.EXPECT().GetByTemplate(gomock.Any(), "site", "t1").Return([]string{"p1", "p2"}, nil)
+
+	for _, name := range []string{"p1", "p2"} {
+		m.repo.EXPECT().GetByName(gomock.Any(), name, "t1").Return(&entity.Profile{ProfileName: name, Activation: "ccmactivate", TLSMode: 2, TemplateName: "site", TenantID: "t1"}, nil)
+		m.pwc.EXPECT().GetByProfileName(gomock.Any(), name, "t1").Return(nil, nil)
+	}
+
+	m.templates.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(siteTemplate, nil).AnyTimes()
+
+	gomock.InOrder(
+		m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(true, nil),
+		m.pwc.EXPECT().DeleteByProfileName(gomock.Any(), "p1", "t1").Return(nil),
+		m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(&entity.Profile{ProfileName: "p1", TenantID: "t1"}, nil),
+		m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(false, errors.New("db down")),
+	)
+
+	// p1 already took the settings of the template when p2 failed
+	applied, err := useCase.ApplyTemplate(context.Background(), "site", "t1")
+	require.Error(t, err)
+	require.Equal(t, []string{"p1"}, applied)
+}
+
+func TestApplyTemplateNotValid(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	cira := "cira"
+
+	m.repo.EXPECT().GetByTemplate(gomock.Any(), "site", "t1").Return([]string{"p1", "p2"}, nil)
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(&entity.Profile{ProfileName: "p1", Activation: "ccmactivate", TemplateName: "site", TenantID: "t1"}, nil)
+	m.repo.EXPECT().GetByName(gomock.Any(), "p2", "t1").Return(&entity.Profile{ProfileName: "p2", Activation: "ccmactivate", CIRAConfigName: &cira, TemplateName: "site", TenantID: "t1"}, nil)
+	m.pwc.EXPECT().GetByProfileName(gomock.Any(), gomock.Any(), "t1").Return(nil, nil).Times(2)
+	m.templates.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(siteTemplate, nil).Times(2)
+
+	// the TLS mode of the template does not go with the CIRA config of p2, so p1 is not saved either
+	applied, err := useCase.ApplyTemplate(context.Background(), "site", "t1")
+	require.ErrorAs(t, err, &dto.NotValidError{})
+	require.ErrorContains(t, err, "p2")
+	require.Empty(t, applied)
+}
+
+func TestPreviewTemplate(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := templatesTest(t)
+
+	cira := "cira"
+
+	m.repo.EXPECT().GetByTemplate(gomock.Any(), "site", "t1").Return([]string{"p1", "p2", "p3"}, nil)
+	m.repo.EXPECT().GetByName(gomock.Any(), "p1", "t1").Return(&entity.Profile{ProfileName: "p1", Activation: "ccmactivate", TLSMode: 1, KVMEnabled: true, TemplateName: "site", TenantID: "t1"}, nil)
+	m.repo.EXPECT().GetByName(gomock.Any(), "p2", "t1").Return(&entity.Profile{ProfileName: "p2", Activation: "ccmactivate", TLSMode: 3, KVMEnabled: true, TemplateName: "site", TemplateOverrides: "tlsMode", TenantID: "t1"}, nil)
+	m.repo.EXPECT().GetByName(gomock.Any(), "p3", "t1").Return(&entity.Profile{ProfileName: "p3", Activation: "ccmactivate", CIRAConfigName: &cira, KVMEnabled: true, TemplateName: "site", TenantID: "t1"}, nil)
+	m.pwc.EXPECT().GetByProfileName(gomock.Any(), gomock.Any(), "t1").Return(nil, nil).Times(3)
+
+	tlsMode, kvmEnabled := 2, true
+	template := &dto.ProfileTemplate{
+		TemplateName:            "site",
+		ProfileTemplateSettings: dto.ProfileTemplateSettings{TLSMode: &tlsMode, KVMEnabled: &kvmEnabled},
+		TenantID:                "t1",
+	}
+
+	impacts, err := useCase.PreviewTemplate(context.Background(), template)
+	require.NoError(t, err)
+	require.Len(t, impacts, 2)
+	require.Equal(t, dto.ProfileTemplateImpact{ProfileName: "p1", Changes: []string{"tlsMode"}}, impacts[0])
+
+	// a TLS mode does not go with the CIRA config of p3
+	require.Equal(t, "p3", impacts[1].ProfileName)
+	require.Equal(t, []string{"tlsMode"}, impacts[1].Changes)
+	require.Contains(t, impacts[1].Invalid, "ciraortls")
+}
@@ -0,0 +1,62 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// GetDeleted lists the profiles in the trash.
+func (uc *UseCase) GetDeleted(ctx context.Context, top, skip int, tenantID string) ([]dto.Profile, error) {
+	data, err := uc.repo.GetDeleted(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetDeleted", "uc.repo.GetDeleted", err)
+	}
+
+	d1 := make([]dto.Profile, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+// Restore takes a profile out of the trash.
+func (uc *UseCase) Restore(ctx context.Context, profileName, tenantID string) error {
+	restored, err := uc.repo.Restore(ctx, profileName, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Restore", "uc.repo.Restore", err)
+	}
+
+	if !restored {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Purge removes a profile in the trash for good.
+func (uc *UseCase) Purge(ctx context.Context, profileName, tenantID string) error {
+	purged, err := uc.repo.Purge(ctx, profileName, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Purge", "uc.repo.Purge", err)
+	}
+
+	if !purged {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeleted removes the profiles of every tenant that were deleted up to before, an RFC 3339 timestamp.
+func (uc *UseCase) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	count, err := uc.repo.PurgeDeleted(ctx, before)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("PurgeDeleted", "uc.repo.PurgeDeleted", err)
+	}
+
+	return count, nil
+}
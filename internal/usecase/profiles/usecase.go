@@ -16,11 +16,14 @@ import (
 	"github.com/device-management-toolkit/console/internal/usecase/ciraconfigs"
 	"github.com/device-management-toolkit/console/internal/usecase/domains"
 	"github.com/device-management-toolkit/console/internal/usecase/ieee8021xconfigs"
+	"github.com/device-management-toolkit/console/internal/usecase/profiletemplates"
 	"github.com/device-management-toolkit/console/internal/usecase/profilewificonfigs"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/internal/usecase/wificonfigs"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // UseCase -.
@@ -33,6 +36,8 @@ type UseCase struct {
 	log               logger.Interface
 	domains           domains.Repository
 	safeRequirements  security.Cryptor
+	revisions         revisions.Recorder
+	templates         profiletemplates.Repository
 }
 
 var (
@@ -43,7 +48,7 @@ var (
 )
 
 // New -.
-func New(r Repository, wifiConfig wificonfigs.Repository, w profilewificonfigs.Feature, i ieee8021xconfigs.Feature, log logger.Interface, d domains.Repository, c ciraconfigs.Repository, safeRequirements security.Cryptor) *UseCase {
+func New(r Repository, wifiConfig wificonfigs.Repository, w profilewificonfigs.Feature, i ieee8021xconfigs.Feature, log logger.Interface, d domains.Repository, c ciraconfigs.Repository, safeRequirements security.Cryptor, rev revisions.Recorder, t profiletemplates.Repository) *UseCase {
 	return &UseCase{
 		repo:              r,
 		wifiConfig:        wifiConfig,
@@ -53,6 +58,8 @@ func New(r Repository, wifiConfig wificonfigs.Repository, w profilewificonfigs.F
 		log:               log,
 		domains:           d,
 		safeRequirements:  safeRequirements,
+		revisions:         rev,
+		templates:         t,
 	}
 }
 
@@ -94,8 +101,8 @@ func (uc *UseCase) getEncryptMethodName(method EncryptMethod) string {
 }
 
 // History - getting translate history from store.
-func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
-	count, err := uc.repo.GetCount(ctx, tenantID)
+func (uc *UseCase) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, q, tenantID)
 	if err != nil {
 		return 0, ErrDatabase.Wrap("Count", "uc.repo.GetCount", err)
 	}
@@ -103,8 +110,8 @@ func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
 	return count, nil
 }
 
-func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Profile, error) {
-	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+func (uc *UseCase) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.Profile, error) {
+	data, err := uc.repo.Get(ctx, q, top, skip, tenantID)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
 	}
@@ -420,14 +427,9 @@ func (uc *UseCase) Export(ctx context.Context, profileName, domainName, tenantID
 	return encryptedYAML, encryptionKey, nil
 }
 
-func (uc *UseCase) Delete(ctx context.Context, profileName, tenantID string) error {
-	// remove all wifi configs associated with the profile
-	err := uc.profileWifiConfig.DeleteByProfileName(ctx, profileName, tenantID)
-	if err != nil {
-		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
-	}
-
-	isSuccessful, err := uc.repo.Delete(ctx, profileName, tenantID)
+func (uc *UseCase) Delete(ctx context.Context, profileName, version, tenantID string) error {
+	// the wifi configs stay linked to the profile in the trash and go when it is purged
+	isSuccessful, err := uc.repo.Delete(ctx, profileName, version, tenantID)
 	if err != nil {
 		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
 	}
@@ -464,13 +466,40 @@ func (uc *UseCase) isWifiProfileExists(ctx context.Context, d *dto.Profile, acti
 }
 
 func (uc *UseCase) Update(ctx context.Context, d *dto.Profile) (*dto.Profile, error) {
+	err := uc.applyTemplate(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
 	d1 := uc.dtoToEntity(d)
 
-	err := uc.isWifiProfileExists(ctx, d, "update")
+	err = uc.isWifiProfileExists(ctx, d, "update")
 	if err != nil {
 		return nil, err
 	}
 
+	var d2 *dto.Profile
+
+	// the profile and its wifi configs are saved with its revision, a revision that cannot be recorded undoes them
+	err = uc.revisions.Save(ctx, func(ctx context.Context) error {
+		var err error
+
+		d2, err = uc.updateProfile(ctx, d, d1)
+		if err != nil {
+			return err
+		}
+
+		return uc.recordRevision(ctx, d2, d1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d2, nil
+}
+
+// updateProfile saves the profile and replaces its wifi configs.
+func (uc *UseCase) updateProfile(ctx context.Context, d *dto.Profile, d1 *entity.Profile) (*dto.Profile, error) {
 	updated, err := uc.repo.Update(ctx, d1)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
@@ -513,6 +542,10 @@ func (uc *UseCase) Update(ctx context.Context, d *dto.Profile) (*dto.Profile, er
 }
 
 func (uc *UseCase) Insert(ctx context.Context, d *dto.Profile) (*dto.Profile, error) {
+	if err := uc.applyTemplate(ctx, d); err != nil {
+		return nil, err
+	}
+
 	d1 := uc.dtoToEntity(d)
 
 	if err := uc.isWifiProfileExists(ctx, d, "insert"); err != nil {
@@ -523,15 +556,32 @@ func (uc *UseCase) Insert(ctx context.Context, d *dto.Profile) (*dto.Profile, er
 		return nil, err
 	}
 
-	if err := uc.insertProfile(ctx, d1); err != nil {
-		return nil, err
-	}
+	var d2 *dto.Profile
 
-	if err := uc.insertProfileWifiConfigs(ctx, d); err != nil {
+	// the profile and its wifi configs are saved with its revision, a revision that cannot be recorded undoes them
+	err := uc.revisions.Save(ctx, func(ctx context.Context) error {
+		if err := uc.insertProfile(ctx, d1); err != nil {
+			return err
+		}
+
+		if err := uc.insertProfileWifiConfigs(ctx, d); err != nil {
+			return err
+		}
+
+		var err error
+
+		d2, err = uc.createdProfile(ctx, d)
+		if err != nil {
+			return err
+		}
+
+		return uc.recordRevision(ctx, d2, d1)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return uc.createdProfile(ctx, d)
+	return d2, nil
 }
 
 func (uc *UseCase) validateIEEE8021xProfile(ctx context.Context, d1 *entity.Profile) error {
@@ -623,6 +673,8 @@ func (uc *UseCase) dtoToEntity(d *dto.Profile) *entity.Profile {
 		IEEE8021xProfileName:       d.IEEE8021xProfileName,
 		Version:                    d.Version,
 		UEFIWiFiSyncEnabled:        d.UEFIWiFiSyncEnabled,
+		TemplateName:               d.TemplateName,
+		TemplateOverrides:          strings.Join(d.TemplateOverrides, ","),
 	}
 
 	d1.AMTPassword, _ = uc.safeRequirements.Encrypt(d.AMTPassword)
@@ -659,6 +711,12 @@ func (uc *UseCase) entityToDTO(d *entity.Profile) *dto.Profile {
 		IEEE8021xProfileName:       d.IEEE8021xProfileName,
 		Version:                    d.Version,
 		UEFIWiFiSyncEnabled:        d.UEFIWiFiSyncEnabled,
+		DeletedAt:                  d.DeletedAt,
+		TemplateName:               d.TemplateName,
+	}
+
+	if d.TemplateOverrides != "" {
+		d1.TemplateOverrides = strings.Split(d.TemplateOverrides, ",")
 	}
 
 	if d.IEEE8021xProfileName != nil && *d.IEEE8021xProfileName != "" {
@@ -15,6 +15,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/profiles"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type test struct {
@@ -43,7 +44,12 @@ func profilesTest(t *testing.T) (*profiles.UseCase, *mocks.MockProfilesRepositor
 	cira := mocks.NewMockCIRAConfigsRepository(mockCtl)
 	security := mocks.MockCrypto{}
 	log := logger.New("error")
-	useCase := profiles.New(repo, wificonfigs, profilewificonfigs, ieeeMock, log, domains, cira, security)
+	revisionsMock := mocks.NewMockRevisionsRecorder(mockCtl)
+	revisionsMock.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	revisionsMock.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	useCase := profiles.New(repo, wificonfigs, profilewificonfigs, ieeeMock, log, domains, cira, security, revisionsMock, mocks.NewMockProfileTemplatesRepository(mockCtl))
 
 	return useCase, repo, wificonfigs, profilewificonfigs
 }
@@ -55,7 +61,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "empty result",
 			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, _ *mocks.MockProfileWiFiConfigsFeature) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, nil)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, nil)
 			},
 			res: 0,
 			err: nil,
@@ -63,7 +69,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "result with error",
 			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, _ *mocks.MockProfileWiFiConfigsFeature) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(0, profiles.ErrDatabase)
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(0, profiles.ErrDatabase)
 			},
 			res: 0,
 			err: profiles.ErrDatabase,
@@ -79,7 +85,7 @@ func TestGetCount(t *testing.T) {
 
 			tc.mock(repo, wifiFeat, pwfFeat)
 
-			res, err := useCase.GetCount(context.Background(), "")
+			res, err := useCase.GetCount(context.Background(), odata.Query{}, "")
 
 			require.Equal(t, tc.res, res)
 			require.IsType(t, tc.err, err)
@@ -134,7 +140,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, profileWifiRepo *mocks.MockProfileWiFiConfigsFeature) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 0, "tenant-id-456").
 					Return(testProfiles, nil)
 				profileWifiRepo.EXPECT().
 					GetByProfileName(context.Background(), "test-profile-1", "tenant-id-456").
@@ -153,7 +159,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, _ *mocks.MockProfileWiFiConfigsFeature) {
 				repo.EXPECT().
-					Get(context.Background(), 5, 0, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 5, 0, "tenant-id-456").
 					Return(nil, profiles.ErrDatabase)
 			},
 			res: []dto.Profile(nil),
@@ -166,7 +172,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, _ *mocks.MockProfileWiFiConfigsFeature) {
 				repo.EXPECT().
-					Get(context.Background(), 10, 20, "tenant-id-456").
+					Get(context.Background(), odata.Query{}, 10, 20, "tenant-id-456").
 					Return([]entity.Profile{}, nil)
 			},
 			res: []dto.Profile{},
@@ -182,7 +188,7 @@ func TestGet(t *testing.T) {
 
 			tc.mock(repo, wifiFeat, pwfFeat)
 
-			results, err := useCase.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			results, err := useCase.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			require.Equal(t, tc.res, results)
 
@@ -275,12 +281,9 @@ func TestDelete(t *testing.T) {
 			name:        "successful deletion",
 			profileName: "example-profile",
 			tenantID:    "tenant-id-456",
-			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, profilewificonfigfeat *mocks.MockProfileWiFiConfigsFeature) {
-				profilewificonfigfeat.EXPECT().
-					DeleteByProfileName(context.Background(), "example-profile", "tenant-id-456").
-					Return(nil)
+			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, _ *mocks.MockProfileWiFiConfigsFeature) {
 				repo.EXPECT().
-					Delete(context.Background(), "example-profile", "tenant-id-456").
+					Delete(context.Background(), "example-profile", "", "tenant-id-456").
 					Return(true, nil)
 			},
 			err: nil,
@@ -289,12 +292,9 @@ func TestDelete(t *testing.T) {
 			name:        "deletion fails - profile not found",
 			profileName: "nonexistent-profile",
 			tenantID:    "tenant-id-456",
-			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, profilewificonfigfeat *mocks.MockProfileWiFiConfigsFeature) {
-				profilewificonfigfeat.EXPECT().
-					DeleteByProfileName(context.Background(), "nonexistent-profile", "tenant-id-456").
-					Return(nil)
+			mock: func(repo *mocks.MockProfilesRepository, _ *mocks.MockWiFiConfigsRepository, _ *mocks.MockProfileWiFiConfigsFeature) {
 				repo.EXPECT().
-					Delete(context.Background(), "nonexistent-profile", "tenant-id-456").
+					Delete(context.Background(), "nonexistent-profile", "", "tenant-id-456").
 					Return(false, nil)
 			},
 			err: profiles.ErrNotFound,
@@ -309,7 +309,7 @@ func TestDelete(t *testing.T) {
 
 			tc.mock(repo, wifiFeat, pwfFeat)
 
-			err := useCase.Delete(context.Background(), tc.profileName, tc.tenantID)
+			err := useCase.Delete(context.Background(), tc.profileName, "", tc.tenantID)
 
 			if tc.err != nil {
 				require.Error(t, err)
@@ -321,6 +321,18 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _, _ := profilesTest(t)
+
+	repo.EXPECT().Restore(context.Background(), "example-profile", "tenant-id-456").Return(true, nil)
+	require.NoError(t, useCase.Restore(context.Background(), "example-profile", "tenant-id-456"))
+
+	repo.EXPECT().Restore(context.Background(), "example-profile", "tenant-id-456").Return(false, nil)
+	require.ErrorIs(t, useCase.Restore(context.Background(), "example-profile", "tenant-id-456"), profiles.ErrNotFound)
+}
+
 func TestUpdate(t *testing.T) {
 	t.Parallel()
 
@@ -557,7 +569,7 @@ func TestHandleIEEE8021xSettings(t *testing.T) {
 
 			tc.mock(ieeeMock)
 
-			useCase := profiles.New(nil, nil, nil, ieeeMock, nil, nil, nil, nil)
+			useCase := profiles.New(nil, nil, nil, ieeeMock, nil, nil, nil, nil, nil, nil)
 
 			err := useCase.HandleIEEE8021xSettings(ctx, tc.data, configuration, tenantID)
 
@@ -621,7 +633,7 @@ func TestGetProfileData(t *testing.T) {
 
 			tc.mock(repoMock)
 
-			useCase := profiles.New(repoMock, nil, nil, nil, nil, nil, nil, nil)
+			useCase := profiles.New(repoMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			data, err := useCase.GetProfileData(ctx, tc.profileName, tenantID)
 
@@ -702,7 +714,7 @@ func TestGetDomainInformation(t *testing.T) {
 
 			tc.mock(domainsMock)
 
-			useCase := profiles.New(nil, nil, nil, nil, nil, domainsMock, nil, cryptoMock)
+			useCase := profiles.New(nil, nil, nil, nil, nil, domainsMock, nil, cryptoMock, nil, nil)
 
 			domain, err := useCase.GetDomainInformation(ctx, tc.activation, tc.domainName, tenantID)
 
@@ -747,7 +759,7 @@ func TestDecryptPasswords(t *testing.T) {
 
 			cryptoMock := &mocks.MockCrypto{}
 
-			useCase := profiles.New(nil, nil, nil, nil, nil, nil, nil, cryptoMock)
+			useCase := profiles.New(nil, nil, nil, nil, nil, nil, nil, cryptoMock, nil, nil)
 
 			err := useCase.DecryptPasswords(tc.data)
 
@@ -819,7 +831,7 @@ func TestBuildWirelessProfiles(t *testing.T) {
 
 			tc.mock(wifiMock)
 
-			useCase := profiles.New(nil, wifiMock, nil, ieeeMock, nil, nil, nil, cryptoMock)
+			useCase := profiles.New(nil, wifiMock, nil, ieeeMock, nil, nil, nil, cryptoMock, nil, nil)
 
 			wifiProfiles, err := useCase.BuildWirelessProfiles(ctx, wifiConfigs, tenantID)
 
@@ -946,7 +958,7 @@ func TestBuildConfigurationObject(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			useCase := profiles.New(nil, nil, nil, nil, nil, nil, nil, nil)
+			useCase := profiles.New(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			result := useCase.BuildConfigurationObject(tc.profile.ProfileName, tc.profile, tc.domain, tc.wifi, tc.cira)
 
@@ -1006,7 +1018,7 @@ func TestGetWiFiConfigurations(t *testing.T) {
 
 			tc.mock(profileWiFiMock)
 
-			useCase := profiles.New(nil, nil, profileWiFiMock, nil, nil, nil, nil, nil)
+			useCase := profiles.New(nil, nil, profileWiFiMock, nil, nil, nil, nil, nil, nil, nil)
 
 			wifiConfigs, err := useCase.GetWiFiConfigurations(ctx, profileName, tenantID)
 
@@ -1054,7 +1066,7 @@ func TestSerializeAndEncryptYAML(t *testing.T) {
 
 			cryptoMock := &mocks.MockCrypto{}
 
-			useCase := profiles.New(nil, nil, nil, nil, nil, nil, nil, cryptoMock)
+			useCase := profiles.New(nil, nil, nil, nil, nil, nil, nil, cryptoMock, nil, nil)
 
 			encryptedData, encryptionKey, err := useCase.SerializeAndEncryptYAML(tc.configuration)
 
@@ -0,0 +1,34 @@
+package profiletemplates
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.ProfileTemplate, error)
+		GetByName(ctx context.Context, templateName, tenantID string) (*entity.ProfileTemplate, error)
+		Delete(ctx context.Context, templateName, tenantID string) (bool, error)
+		Update(ctx context.Context, t *entity.ProfileTemplate) (bool, error)
+		Insert(ctx context.Context, t *entity.ProfileTemplate) error
+	}
+	// Profiles are the profiles derived from the templates, profiles.UseCase implements it.
+	Profiles interface {
+		GetByTemplate(ctx context.Context, templateName, tenantID string) ([]string, error)
+		ApplyTemplate(ctx context.Context, templateName, tenantID string) ([]string, error)
+		PreviewTemplate(ctx context.Context, t *dto.ProfileTemplate) ([]dto.ProfileTemplateImpact, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.ProfileTemplate, error)
+		GetByName(ctx context.Context, templateName, tenantID string) (*dto.ProfileTemplate, error)
+		Delete(ctx context.Context, templateName, tenantID string) error
+		Update(ctx context.Context, t *dto.ProfileTemplate) (*dto.ProfileTemplate, error)
+		Insert(ctx context.Context, t *dto.ProfileTemplate) (*dto.ProfileTemplate, error)
+		Preview(ctx context.Context, t *dto.ProfileTemplate) ([]dto.ProfileTemplateImpact, error)
+	}
+)
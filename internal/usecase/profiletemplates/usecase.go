@@ -0,0 +1,230 @@
+package profiletemplates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// UseCase manages the templates profiles are derived from. A derived profile inherits every setting of its
+// template it does not override, so saving a template updates the profiles derived from it.
+type UseCase struct {
+	repo     Repository
+	profiles Profiles
+	log      logger.Interface
+}
+
+// New -.
+func New(r Repository, profiles Profiles, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:     r,
+		profiles: profiles,
+		log:      log,
+	}
+}
+
+var (
+	ErrProfileTemplatesUseCase = consoleerrors.CreateConsoleError("ProfileTemplatesUseCase")
+	ErrDatabase                = sqldb.DatabaseError{Console: ErrProfileTemplatesUseCase}
+	ErrNotFound                = sqldb.NotFoundError{Console: ErrProfileTemplatesUseCase}
+	ErrValidation              = dto.NotValidError{Console: ErrProfileTemplatesUseCase}
+
+	errInUse          = errors.New("profiles are derived from the template")
+	errInvalidDerived = errors.New("the template would leave derived profiles invalid")
+)
+
+// PartiallyAppliedError reports a template that was saved while only some of the profiles derived from it took
+// its settings. The other profiles keep their previous settings until the template is saved again.
+type PartiallyAppliedError struct {
+	Console consoleerrors.InternalError
+}
+
+func (e PartiallyAppliedError) Error() string {
+	return e.Console.Error()
+}
+
+func (e PartiallyAppliedError) Wrap(call, function string, applied []string, err error) error {
+	_ = e.Console.Wrap(call, function, err)
+	e.Console.Message = fmt.Sprintf("template saved but only applied to the profiles [%s], save it again to apply it to the other profiles derived from it", strings.Join(applied, ", "))
+
+	return e
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.ProfileTemplate, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.ProfileTemplate, len(data))
+
+	for i := range data {
+		d, err := entityToDTO(&data[i])
+		if err != nil {
+			return nil, ErrDatabase.Wrap("Get", "entityToDTO", err)
+		}
+
+		d1[i] = *d
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByName(ctx context.Context, templateName, tenantID string) (*dto.ProfileTemplate, error) {
+	data, err := uc.repo.GetByName(ctx, templateName, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByName", "uc.repo.GetByName", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	d, err := entityToDTO(data)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByName", "entityToDTO", err)
+	}
+
+	return d, nil
+}
+
+// Delete removes a template no profile is derived from anymore.
+func (uc *UseCase) Delete(ctx context.Context, templateName, tenantID string) error {
+	derived, err := uc.profiles.GetByTemplate(ctx, templateName, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.profiles.GetByTemplate", err)
+	}
+
+	if len(derived) > 0 {
+		return ErrValidation.Wrap("Delete", "uc.profiles.GetByTemplate", fmt.Errorf("%w: %s", errInUse, strings.Join(derived, ", ")))
+	}
+
+	isSuccessful, err := uc.repo.Delete(ctx, templateName, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Update saves the template and then every profile derived from it with the settings it inherits. An edit that
+// would leave a derived profile invalid is rejected before anything is saved. The profiles are saved one by one,
+// when one fails the error names those that were saved.
+func (uc *UseCase) Update(ctx context.Context, d *dto.ProfileTemplate) (*dto.ProfileTemplate, error) {
+	t, err := dtoToEntity(d)
+	if err != nil {
+		return nil, ErrValidation.Wrap("Update", "dtoToEntity", err)
+	}
+
+	impacts, err := uc.profiles.PreviewTemplate(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := make([]string, 0)
+
+	for _, impact := range impacts {
+		if impact.Invalid != "" {
+			invalid = append(invalid, impact.ProfileName+" ("+impact.Invalid+")")
+		}
+	}
+
+	if len(invalid) > 0 {
+		return nil, ErrValidation.Wrap("Update", "uc.profiles.PreviewTemplate", fmt.Errorf("%w: %s", errInvalidDerived, strings.Join(invalid, ", ")))
+	}
+
+	updated, err := uc.repo.Update(ctx, t)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	applied, err := uc.profiles.ApplyTemplate(ctx, t.TemplateName, t.TenantID)
+	if err != nil {
+		uc.log.Error(fmt.Sprintf("profile template %s saved but only applied to the profiles [%s]: %s", t.TemplateName, strings.Join(applied, ", "), err))
+
+		return nil, PartiallyAppliedError{Console: ErrProfileTemplatesUseCase}.Wrap("Update", "uc.profiles.ApplyTemplate", applied, err)
+	}
+
+	uc.log.Info(fmt.Sprintf("profile template %s applied to %d profiles", t.TemplateName, len(applied)))
+
+	return uc.GetByName(ctx, t.TemplateName, t.TenantID)
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.ProfileTemplate) (*dto.ProfileTemplate, error) {
+	t, err := dtoToEntity(d)
+	if err != nil {
+		return nil, ErrValidation.Wrap("Insert", "dtoToEntity", err)
+	}
+
+	if err = uc.repo.Insert(ctx, t); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return uc.GetByName(ctx, t.TemplateName, t.TenantID)
+}
+
+// Preview shows the settings an edit of a template would change in each of the profiles derived from it without
+// saving anything. Profiles the edit does not change are left out.
+func (uc *UseCase) Preview(ctx context.Context, d *dto.ProfileTemplate) ([]dto.ProfileTemplateImpact, error) {
+	if _, err := uc.GetByName(ctx, d.TemplateName, d.TenantID); err != nil {
+		return nil, err
+	}
+
+	impacts, err := uc.profiles.PreviewTemplate(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return impacts, nil
+}
+
+func dtoToEntity(d *dto.ProfileTemplate) (*entity.ProfileTemplate, error) {
+	settings, err := json.Marshal(d.ProfileTemplateSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ProfileTemplate{
+		TemplateName: d.TemplateName,
+		Settings:     string(settings),
+		TenantID:     d.TenantID,
+	}, nil
+}
+
+func entityToDTO(t *entity.ProfileTemplate) (*dto.ProfileTemplate, error) {
+	d := &dto.ProfileTemplate{
+		TemplateName: t.TemplateName,
+		TenantID:     t.TenantID,
+	}
+
+	if err := json.Unmarshal([]byte(t.Settings), &d.ProfileTemplateSettings); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,144 @@
+package profiletemplates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/profiletemplates"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func profileTemplatesTest(t *testing.T) (*profiletemplates.UseCase, *mocks.MockProfileTemplatesRepository, *mocks.MockProfileTemplatesProfiles) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockProfileTemplatesRepository(mockCtl)
+	profiles := mocks.NewMockProfileTemplatesProfiles(mockCtl)
+
+	return profiletemplates.New(repo, profiles, logger.New("error")), repo, profiles
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := profileTemplatesTest(t)
+
+	repo.EXPECT().Get(gomock.Any(), 10, 0, "t1").Return([]entity.ProfileTemplate{
+		{TemplateName: "site", Settings: `{"tlsMode":1,"tags":["a"]}`, TenantID: "t1"},
+	}, nil)
+
+	templates, err := useCase.Get(context.Background(), 10, 0, "t1")
+	require.NoError(t, err)
+
+	tlsMode := 1
+	require.Equal(t, []dto.ProfileTemplate{{
+		TemplateName:            "site",
+		ProfileTemplateSettings: dto.ProfileTemplateSettings{TLSMode: &tlsMode, Tags: []string{"a"}},
+		TenantID:                "t1",
+	}}, templates)
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := profileTemplatesTest(t)
+
+	kvmEnabled := false
+	template := &dto.ProfileTemplate{TemplateName: "site", ProfileTemplateSettings: dto.ProfileTemplateSettings{KVMEnabled: &kvmEnabled}, TenantID: "t1"}
+	stored := &entity.ProfileTemplate{TemplateName: "site", Settings: `{"kvmEnabled":false}`, TenantID: "t1"}
+
+	repo.EXPECT().Insert(gomock.Any(), stored).Return(nil)
+	repo.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(stored, nil)
+
+	created, err := useCase.Insert(context.Background(), template)
+	require.NoError(t, err)
+	require.Equal(t, template, created)
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, profiles := profileTemplatesTest(t)
+
+	stored := &entity.ProfileTemplate{TemplateName: "site", Settings: `{}`, TenantID: "t1"}
+
+	profiles.EXPECT().PreviewTemplate(gomock.Any(), gomock.Any()).Return([]dto.ProfileTemplateImpact{{ProfileName: "p1", Changes: []string{"tlsMode"}}}, nil).Times(3)
+	repo.EXPECT().Update(gomock.Any(), stored).Return(true, nil)
+	profiles.EXPECT().ApplyTemplate(gomock.Any(), "site", "t1").Return([]string{"p1", "p2"}, nil)
+	repo.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(stored, nil)
+
+	_, err := useCase.Update(context.Background(), &dto.ProfileTemplate{TemplateName: "site", TenantID: "t1"})
+	require.NoError(t, err)
+
+	repo.EXPECT().Update(gomock.Any(), stored).Return(false, nil)
+
+	_, err = useCase.Update(context.Background(), &dto.ProfileTemplate{TemplateName: "site", TenantID: "t1"})
+	require.ErrorIs(t, err, profiletemplates.ErrNotFound)
+
+	// the error names the profiles that took the template before one failed
+	repo.EXPECT().Update(gomock.Any(), stored).Return(true, nil)
+	profiles.EXPECT().ApplyTemplate(gomock.Any(), "site", "t1").Return([]string{"p1"}, errors.New("db down"))
+
+	_, err = useCase.Update(context.Background(), &dto.ProfileTemplate{TemplateName: "site", TenantID: "t1"})
+
+	var partialErr profiletemplates.PartiallyAppliedError
+
+	require.ErrorAs(t, err, &partialErr)
+	require.Contains(t, partialErr.Console.FriendlyMessage(), "[p1]")
+
+	// an edit that leaves a derived profile invalid saves nothing
+	profiles.EXPECT().PreviewTemplate(gomock.Any(), gomock.Any()).Return([]dto.ProfileTemplateImpact{{ProfileName: "p2", Changes: []string{"tlsMode"}, Invalid: "ciraortls"}}, nil)
+
+	_, err = useCase.Update(context.Background(), &dto.ProfileTemplate{TemplateName: "site", TenantID: "t1"})
+	require.ErrorAs(t, err, &dto.NotValidError{})
+	require.ErrorContains(t, err, "p2")
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, profiles := profileTemplatesTest(t)
+
+	profiles.EXPECT().GetByTemplate(gomock.Any(), "site", "t1").Return([]string{"p1"}, nil)
+
+	err := useCase.Delete(context.Background(), "site", "t1")
+	require.ErrorAs(t, err, &dto.NotValidError{})
+
+	profiles.EXPECT().GetByTemplate(gomock.Any(), "site", "t1").Return(nil, nil)
+	repo.EXPECT().Delete(gomock.Any(), "site", "t1").Return(true, nil)
+
+	require.NoError(t, useCase.Delete(context.Background(), "site", "t1"))
+
+	profiles.EXPECT().GetByTemplate(gomock.Any(), "site", "t1").Return(nil, errors.New("db down"))
+
+	err = useCase.Delete(context.Background(), "site", "t1")
+	require.ErrorAs(t, err, &profiletemplates.ErrDatabase)
+}
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, profiles := profileTemplatesTest(t)
+
+	template := &dto.ProfileTemplate{TemplateName: "site", TenantID: "t1"}
+	impacts := []dto.ProfileTemplateImpact{{ProfileName: "p1", Changes: []string{"tlsMode"}}}
+
+	repo.EXPECT().GetByName(gomock.Any(), "site", "t1").Return(&entity.ProfileTemplate{TemplateName: "site", Settings: `{}`, TenantID: "t1"}, nil)
+	profiles.EXPECT().PreviewTemplate(gomock.Any(), template).Return(impacts, nil)
+
+	result, err := useCase.Preview(context.Background(), template)
+	require.NoError(t, err)
+	require.Equal(t, impacts, result)
+
+	repo.EXPECT().GetByName(gomock.Any(), "missing", "t1").Return(nil, nil)
+
+	_, err = useCase.Preview(context.Background(), &dto.ProfileTemplate{TemplateName: "missing", TenantID: "t1"})
+	require.ErrorIs(t, err, profiletemplates.ErrNotFound)
+}
@@ -0,0 +1,17 @@
+package revisions
+
+import "context"
+
+type authorKey struct{}
+
+// WithAuthor sets the user recorded as the author of the revisions saved with the context.
+func WithAuthor(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, authorKey{}, user)
+}
+
+// author returns the user set with WithAuthor, revisions saved without one have no author.
+func author(ctx context.Context) string {
+	user, _ := ctx.Value(authorKey{}).(string)
+
+	return user
+}
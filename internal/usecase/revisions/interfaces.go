@@ -0,0 +1,35 @@
+package revisions
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, kind, name, tenantID string) (int, error)
+		Get(ctx context.Context, kind, name string, top, skip int, tenantID string) ([]entity.ConfigRevision, error)
+		GetByRevision(ctx context.Context, kind, name string, revision int, tenantID string) (*entity.ConfigRevision, error)
+		Insert(ctx context.Context, rev *entity.ConfigRevision) (int, error)
+		InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+	// Recorder stores a revision each time a configuration is saved. Secrets are passed already encrypted.
+	Recorder interface {
+		Save(ctx context.Context, fn func(ctx context.Context) error) error
+		Record(ctx context.Context, kind, name, tenantID string, content any, secrets map[string]string) error
+	}
+	// Applier saves the content of a revision as the current configuration, which records a new revision.
+	Applier interface {
+		ApplyRevision(ctx context.Context, content []byte, tenantID string) error
+	}
+	Feature interface {
+		Recorder
+		GetCount(ctx context.Context, kind, name, tenantID string) (int, error)
+		Get(ctx context.Context, kind, name string, top, skip int, tenantID string) ([]dto.ConfigRevision, error)
+		GetByRevision(ctx context.Context, kind, name string, revision int, tenantID string) (*dto.ConfigRevision, error)
+		Diff(ctx context.Context, kind, name string, from, to int, tenantID string) (*dto.RevisionDiff, error)
+		Rollback(ctx context.Context, kind, name string, revision int, tenantID string) (*dto.ConfigRevision, error)
+	}
+)
@@ -0,0 +1,385 @@
+package revisions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// UseCase -.
+type UseCase struct {
+	repo             Repository
+	log              logger.Interface
+	safeRequirements security.Cryptor
+	appliers         map[string]Applier
+}
+
+var (
+	ErrRevisionsUseCase = consoleerrors.CreateConsoleError("RevisionsUseCase")
+	ErrDatabase         = sqldb.DatabaseError{Console: ErrRevisionsUseCase}
+	ErrNotFound         = sqldb.NotFoundError{Console: ErrRevisionsUseCase}
+	ErrValidation       = dto.NotValidError{Console: ErrRevisionsUseCase}
+
+	errKind = errors.New("revisions are not kept for this kind of configuration")
+)
+
+// New -.
+func New(r Repository, log logger.Interface, safeRequirements security.Cryptor) *UseCase {
+	return &UseCase{
+		repo:             r,
+		log:              log,
+		safeRequirements: safeRequirements,
+		appliers:         map[string]Applier{},
+	}
+}
+
+// Register adds a kind of configuration whose revisions can be listed and rolled back to.
+func (uc *UseCase) Register(kind string, a Applier) {
+	uc.appliers[kind] = a
+}
+
+func (uc *UseCase) checkKind(kind string) error {
+	if _, ok := uc.appliers[kind]; !ok {
+		return ErrValidation.Wrap("checkKind", "uc.appliers", errKind)
+	}
+
+	return nil
+}
+
+// Save runs fn, which saves a configuration and records its revision with the context it gets, in one
+// transaction. Nothing fn saved is kept when it fails, so a configuration is never left without its revision.
+func (uc *UseCase) Save(ctx context.Context, fn func(ctx context.Context) error) error {
+	var saveErr error
+
+	err := uc.repo.InTx(ctx, func(ctx context.Context) error {
+		saveErr = fn(ctx)
+
+		return saveErr
+	})
+	if saveErr != nil {
+		return saveErr
+	}
+
+	if err != nil {
+		return ErrDatabase.Wrap("Save", "uc.repo.InTx", err)
+	}
+
+	return nil
+}
+
+// Record stores the content of a configuration as its next revision. The secret fields are left out of the
+// content and kept in their encrypted form, empty secrets are not kept.
+func (uc *UseCase) Record(ctx context.Context, kind, name, tenantID string, content any, secrets map[string]string) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	// the version is bumped by every save, it is not part of the configuration
+	delete(fields, "version")
+
+	kept := map[string]string{}
+
+	for field, value := range secrets {
+		delete(fields, field)
+
+		if value != "" {
+			kept[field] = value
+		}
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	rev := &entity.ConfigRevision{
+		Kind:      kind,
+		Name:      name,
+		Author:    author(ctx),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Content:   string(data),
+		Secrets:   kept,
+		TenantID:  tenantID,
+	}
+
+	if _, err = uc.repo.Insert(ctx, rev); err != nil {
+		return ErrDatabase.Wrap("Record", "uc.repo.Insert", err)
+	}
+
+	return nil
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, kind, name, tenantID string) (int, error) {
+	if err := uc.checkKind(kind); err != nil {
+		return 0, err
+	}
+
+	count, err := uc.repo.GetCount(ctx, kind, name, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the revisions of a configuration, newest first.
+func (uc *UseCase) Get(ctx context.Context, kind, name string, top, skip int, tenantID string) ([]dto.ConfigRevision, error) {
+	if err := uc.checkKind(kind); err != nil {
+		return nil, err
+	}
+
+	data, err := uc.repo.Get(ctx, kind, name, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d2 := make([]dto.ConfigRevision, len(data))
+
+	for i := range data {
+		d, err := entityToDTO(&data[i])
+		if err != nil {
+			return nil, err
+		}
+
+		d2[i] = *d
+	}
+
+	return d2, nil
+}
+
+func (uc *UseCase) GetByRevision(ctx context.Context, kind, name string, revision int, tenantID string) (*dto.ConfigRevision, error) {
+	rev, err := uc.getRevision(ctx, kind, name, revision, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entityToDTO(rev)
+}
+
+// Diff compares two revisions field by field. Nested fields are compared by their path, secrets are decrypted
+// to be compared but their values are not returned.
+func (uc *UseCase) Diff(ctx context.Context, kind, name string, from, to int, tenantID string) (*dto.RevisionDiff, error) {
+	fromRev, err := uc.getRevision(ctx, kind, name, from, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	toRev, err := uc.getRevision(ctx, kind, name, to, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromFields, err := flattenContent(fromRev.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	toFields, err := flattenContent(toRev.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]dto.RevisionChange, 0)
+
+	for _, field := range unionKeys(fromFields, toFields) {
+		if !reflect.DeepEqual(fromFields[field], toFields[field]) {
+			changes = append(changes, dto.RevisionChange{Field: field, From: fromFields[field], To: toFields[field]})
+		}
+	}
+
+	for _, field := range unionKeys(fromRev.Secrets, toRev.Secrets) {
+		changed, err := uc.secretChanged(fromRev.Secrets[field], toRev.Secrets[field])
+		if err != nil {
+			return nil, err
+		}
+
+		if changed {
+			changes = append(changes, dto.RevisionChange{Field: field, Secret: true})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return &dto.RevisionDiff{
+		Kind:    kind,
+		Name:    name,
+		From:    from,
+		To:      to,
+		Changes: changes,
+	}, nil
+}
+
+// Rollback saves the content of a revision, secrets included, as the current configuration. The previous
+// revisions are kept and the rollback is recorded as a new revision, which is returned.
+func (uc *UseCase) Rollback(ctx context.Context, kind, name string, revision int, tenantID string) (*dto.ConfigRevision, error) {
+	rev, err := uc.getRevision(ctx, kind, name, revision, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := map[string]interface{}{}
+	if err = json.Unmarshal([]byte(rev.Content), &content); err != nil {
+		return nil, ErrRevisionsUseCase.Wrap("Rollback", "json.Unmarshal", err)
+	}
+
+	for field, value := range rev.Secrets {
+		content[field], err = uc.safeRequirements.Decrypt(value)
+		if err != nil {
+			return nil, ErrRevisionsUseCase.Wrap("Rollback", "uc.safeRequirements.Decrypt", err)
+		}
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, ErrRevisionsUseCase.Wrap("Rollback", "json.Marshal", err)
+	}
+
+	if err = uc.appliers[kind].ApplyRevision(ctx, data, tenantID); err != nil {
+		return nil, err
+	}
+
+	latest, err := uc.repo.Get(ctx, kind, name, 1, 0, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Rollback", "uc.repo.Get", err)
+	}
+
+	if len(latest) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return entityToDTO(&latest[0])
+}
+
+func (uc *UseCase) getRevision(ctx context.Context, kind, name string, revision int, tenantID string) (*entity.ConfigRevision, error) {
+	if err := uc.checkKind(kind); err != nil {
+		return nil, err
+	}
+
+	rev, err := uc.repo.GetByRevision(ctx, kind, name, revision, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("getRevision", "uc.repo.GetByRevision", err)
+	}
+
+	if rev == nil {
+		return nil, ErrNotFound
+	}
+
+	return rev, nil
+}
+
+func (uc *UseCase) secretChanged(from, to string) (bool, error) {
+	if from == "" || to == "" {
+		return from != to, nil
+	}
+
+	fromValue, err := uc.safeRequirements.Decrypt(from)
+	if err != nil {
+		return false, err
+	}
+
+	toValue, err := uc.safeRequirements.Decrypt(to)
+	if err != nil {
+		return false, err
+	}
+
+	return fromValue != toValue, nil
+}
+
+// flattenContent returns the leaf values of a revision's content by their path, such as wifiConfigs.0.priority.
+func flattenContent(content string) (map[string]interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	flatten("", value, fields)
+
+	return fields, nil
+}
+
+func flatten(path string, value interface{}, fields map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			flatten(joinPath(path, key), child, fields)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flatten(joinPath(path, strconv.Itoa(i)), child, fields)
+		}
+	default:
+		fields[path] = v
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+
+	for key := range a {
+		keys = append(keys, key)
+	}
+
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func entityToDTO(rev *entity.ConfigRevision) (*dto.ConfigRevision, error) {
+	content := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(rev.Content), &content); err != nil {
+		return nil, err
+	}
+
+	var secrets []string
+
+	for field := range rev.Secrets {
+		secrets = append(secrets, field)
+	}
+
+	slices.Sort(secrets)
+
+	return &dto.ConfigRevision{
+		Kind:      rev.Kind,
+		Name:      rev.Name,
+		Revision:  rev.Revision,
+		Author:    rev.Author,
+		CreatedAt: rev.CreatedAt,
+		Content:   content,
+		Secrets:   secrets,
+		TenantID:  rev.TenantID,
+	}, nil
+}
@@ -0,0 +1,235 @@
+package revisions_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func revisionsTest(t *testing.T) (*revisions.UseCase, *mocks.MockRevisionsRepository, *mocks.MockRevisionsApplier, *encryption.Crypto) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockRevisionsRepository(mockCtl)
+	applier := mocks.NewMockRevisionsApplier(mockCtl)
+	crypto := encryption.NewCrypto("0123456789abcdef0123456789abcdef")
+
+	useCase := revisions.New(repo, logger.New("error"), crypto)
+	useCase.Register(dto.RevisionKindProfile, applier)
+
+	return useCase, repo, applier, crypto
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _, _ := revisionsTest(t)
+
+	var stored *entity.ConfigRevision
+
+	repo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, rev *entity.ConfigRevision) (int, error) {
+		stored = rev
+
+		return 1, nil
+	})
+
+	ctx := revisions.WithAuthor(context.Background(), "jdoe")
+	profile := &dto.Profile{ProfileName: "p1", AMTPassword: "P@ssw0rd", TLSMode: 2, TenantID: "t1"}
+
+	err := useCase.Record(ctx, dto.RevisionKindProfile, "p1", "t1", profile, map[string]string{"amtPassword": "enc", "mebxPassword": ""})
+	require.NoError(t, err)
+	require.Equal(t, "jdoe", stored.Author)
+	require.Equal(t, map[string]string{"amtPassword": "enc"}, stored.Secrets)
+	require.NotContains(t, stored.Content, "P@ssw0rd")
+	require.Contains(t, stored.Content, `"tlsMode":2`)
+}
+
+func TestSave(t *testing.T) {
+	t.Parallel()
+
+	runTx := func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }
+	errSave := errors.New("save failed")
+
+	tests := []struct {
+		name  string
+		mock  func(repo *mocks.MockRevisionsRepository)
+		save  error
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "success",
+			mock: func(repo *mocks.MockRevisionsRepository) {
+				repo.EXPECT().InTx(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			check: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "save failed",
+			mock: func(repo *mocks.MockRevisionsRepository) {
+				repo.EXPECT().InTx(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			save: errSave,
+			check: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, errSave)
+			},
+		},
+		{
+			name: "commit failed",
+			mock: func(repo *mocks.MockRevisionsRepository) {
+				repo.EXPECT().InTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					if err := fn(ctx); err != nil {
+						return err
+					}
+
+					return errSave
+				})
+			},
+			check: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorAs(t, err, &revisions.ErrDatabase)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo, _, _ := revisionsTest(t)
+			tc.mock(repo)
+
+			err := useCase.Save(context.Background(), func(context.Context) error { return tc.save })
+			tc.check(t, err)
+		})
+	}
+}
+
+func TestGetUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	useCase, _, _, _ := revisionsTest(t)
+
+	_, err := useCase.Get(context.Background(), "devices", "p1", 0, 0, "")
+	require.ErrorAs(t, err, &dto.NotValidError{})
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _, crypto := revisionsTest(t)
+
+	oldPassword, err := crypto.Encrypt("old")
+	require.NoError(t, err)
+
+	newPassword, err := crypto.Encrypt("new")
+	require.NoError(t, err)
+
+	mebxPassword, err := crypto.Encrypt("same")
+	require.NoError(t, err)
+
+	// encrypting again gives another ciphertext of the same password
+	sameMEBXPassword, err := crypto.Encrypt("same")
+	require.NoError(t, err)
+
+	repo.EXPECT().GetByRevision(gomock.Any(), dto.RevisionKindProfile, "p1", 1, "").Return(&entity.ConfigRevision{
+		Content: `{"tlsMode":1,"tags":["a"],"wifiConfigs":[{"profileName":"w1","priority":1}]}`,
+		Secrets: map[string]string{"amtPassword": oldPassword, "mebxPassword": mebxPassword},
+	}, nil)
+	repo.EXPECT().GetByRevision(gomock.Any(), dto.RevisionKindProfile, "p1", 2, "").Return(&entity.ConfigRevision{
+		Content: `{"tlsMode":2,"tags":["a"],"wifiConfigs":[{"profileName":"w1","priority":2}],"kvmEnabled":true}`,
+		Secrets: map[string]string{"amtPassword": newPassword, "mebxPassword": sameMEBXPassword},
+	}, nil)
+
+	diff, err := useCase.Diff(context.Background(), dto.RevisionKindProfile, "p1", 1, 2, "")
+	require.NoError(t, err)
+	require.Equal(t, []dto.RevisionChange{
+		{Field: "amtPassword", Secret: true},
+		{Field: "kvmEnabled", From: nil, To: true},
+		{Field: "tlsMode", From: float64(1), To: float64(2)},
+		{Field: "wifiConfigs.0.priority", From: float64(1), To: float64(2)},
+	}, diff.Changes)
+}
+
+func TestDiffNotFound(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _, _ := revisionsTest(t)
+
+	repo.EXPECT().GetByRevision(gomock.Any(), dto.RevisionKindProfile, "p1", 1, "").Return(nil, nil)
+
+	_, err := useCase.Diff(context.Background(), dto.RevisionKindProfile, "p1", 1, 2, "")
+	require.ErrorIs(t, err, revisions.ErrNotFound)
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, applier, crypto := revisionsTest(t)
+
+	password, err := crypto.Encrypt("P@ssw0rd")
+	require.NoError(t, err)
+
+	repo.EXPECT().GetByRevision(gomock.Any(), dto.RevisionKindProfile, "p1", 1, "t1").Return(&entity.ConfigRevision{
+		Kind:     dto.RevisionKindProfile,
+		Name:     "p1",
+		Revision: 1,
+		Content:  `{"profileName":"p1","tlsMode":1}`,
+		Secrets:  map[string]string{"amtPassword": password},
+		TenantID: "t1",
+	}, nil)
+	applier.EXPECT().ApplyRevision(gomock.Any(), gomock.Any(), "t1").DoAndReturn(func(_ context.Context, content []byte, _ string) error {
+		var profile dto.Profile
+		require.NoError(t, json.Unmarshal(content, &profile))
+		require.Equal(t, "P@ssw0rd", profile.AMTPassword)
+		require.Equal(t, 1, profile.TLSMode)
+
+		return nil
+	})
+	repo.EXPECT().Get(gomock.Any(), dto.RevisionKindProfile, "p1", 1, 0, "t1").Return([]entity.ConfigRevision{
+		{Kind: dto.RevisionKindProfile, Name: "p1", Revision: 3, Content: `{"profileName":"p1","tlsMode":1}`, TenantID: "t1"},
+	}, nil)
+
+	rev, err := useCase.Rollback(context.Background(), dto.RevisionKindProfile, "p1", 1, "t1")
+	require.NoError(t, err)
+	require.Equal(t, 3, rev.Revision)
+	require.InDelta(t, float64(1), rev.Content["tlsMode"], 0)
+}
+
+func TestRollbackUndecryptableSecret(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _, _ := revisionsTest(t)
+
+	// a secret encrypted with a key the console no longer has
+	repo.EXPECT().GetByRevision(gomock.Any(), dto.RevisionKindProfile, "p1", 1, "t1").Return(&entity.ConfigRevision{
+		Kind:     dto.RevisionKindProfile,
+		Name:     "p1",
+		Revision: 1,
+		Content:  `{"profileName":"p1","tlsMode":1}`,
+		Secrets:  map[string]string{"amtPassword": "not-encrypted"},
+		TenantID: "t1",
+	}, nil)
+
+	_, err := useCase.Rollback(context.Background(), dto.RevisionKindProfile, "p1", 1, "t1")
+
+	var consoleErr *consoleerrors.InternalError
+
+	require.ErrorAs(t, err, &consoleErr)
+	require.Equal(t, "uc.safeRequirements.Decrypt", consoleErr.Function)
+}
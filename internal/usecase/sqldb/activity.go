@@ -0,0 +1,248 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+// ActivityRepo -.
+type ActivityRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrActivityDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("ActivityRepo")}
+
+// activityFields and activityOrder page the audit trail newest first, the entries of the same instant by id.
+// Timestamps are stored with milliseconds, in a fixed width.
+var (
+	activityFields = odata.Fields{"timestamp": {Column: "timestamp", Type: odata.Time, NotNull: true, Layout: "2006-01-02T15:04:05.000Z"}}
+	activityOrder  = odata.Query{OrderBy: []odata.Order{{Property: "timestamp", Descending: true}}}
+)
+
+// NewActivityRepo -.
+func NewActivityRepo(database *db.SQL, log logger.Interface) *ActivityRepo {
+	return &ActivityRepo{database, log}
+}
+
+func activityConditions(filter entity.ActivityFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"tenant_id": filter.TenantID}}
+
+	if filter.Actor != "" {
+		conditions = append(conditions, squirrel.Eq{"actor": filter.Actor})
+	}
+
+	if filter.Action != "" {
+		conditions = append(conditions, squirrel.Like{"action": "%" + filter.Action + "%"})
+	}
+
+	if filter.Target != "" {
+		conditions = append(conditions, squirrel.Like{"target": "%" + filter.Target + "%"})
+	}
+
+	if filter.Result != "" {
+		conditions = append(conditions, squirrel.Eq{"result": filter.Result})
+	}
+
+	if filter.From != "" {
+		conditions = append(conditions, squirrel.GtOrEq{"timestamp": filter.From})
+	}
+
+	if filter.To != "" {
+		conditions = append(conditions, squirrel.LtOrEq{"timestamp": filter.To})
+	}
+
+	return conditions
+}
+
+// GetCount -.
+func (r *ActivityRepo) GetCount(_ context.Context, filter entity.ActivityFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("activity_log").
+		Where(activityConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrActivityDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrActivityDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the matching entries, newest first.
+func (r *ActivityRepo) Get(_ context.Context, filter entity.ActivityFilter, top, skip int) ([]entity.ActivityEntry, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("id", "timestamp", "actor", "api_key_id", "source_ip", "action", "target", "parameters", "status", "result", "tenant_id").
+		From("activity_log").
+		Where(activityConditions(filter)).
+		OrderBy("timestamp DESC", "id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrActivityDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrActivityDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrActivityDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	entries := make([]entity.ActivityEntry, 0)
+
+	for rows.Next() {
+		e := entity.ActivityEntry{}
+
+		err = rows.Scan(&e.ID, &e.Timestamp, &e.Actor, &e.APIKeyID, &e.SourceIP, &e.Action, &e.Target, &e.Parameters, &e.Status, &e.Result, &e.TenantID)
+		if err != nil {
+			return nil, ErrActivityDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// GetPage returns up to top matching entries, newest first, starting after the entry skipToken was made from,
+// and the token of the next page, which is empty on the last one. An empty skipToken starts at the newest entry.
+func (r *ActivityRepo) GetPage(ctx context.Context, filter entity.ActivityFilter, top int, skipToken string) ([]entity.ActivityEntry, string, error) {
+	const defaultTop = 100
+
+	if top <= 0 {
+		top = defaultTop
+	}
+
+	keyset, err := activityFields.Keyset(activityOrder, "id")
+	if err != nil {
+		return nil, "", ErrActivityDatabase.Wrap("GetPage", "activityFields.Keyset", err)
+	}
+
+	builder, err := keyset.After(r.Builder.
+		Select("id", "timestamp", "actor", "api_key_id", "source_ip", "action", "target", "parameters", "status", "result", "tenant_id").
+		From("activity_log").
+		Where(activityConditions(filter)), skipToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the entry after the page tells whether there is a next one
+	sqlQuery, args, err := keyset.OrderBy(builder).
+		Limit(uint64(top) + 1).
+		ToSql()
+	if err != nil {
+		return nil, "", ErrActivityDatabase.Wrap("GetPage", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, "", ErrActivityDatabase.Wrap("GetPage", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	entries := make([]entity.ActivityEntry, 0, top)
+
+	for rows.Next() {
+		if len(entries) == top {
+			last := entries[top-1]
+
+			next, err := keyset.Token([]interface{}{last.Timestamp, last.ID})
+			if err != nil {
+				return nil, "", ErrActivityDatabase.Wrap("GetPage", "keyset.Token", err)
+			}
+
+			return entries, next, nil
+		}
+
+		e := entity.ActivityEntry{}
+
+		err = rows.Scan(&e.ID, &e.Timestamp, &e.Actor, &e.APIKeyID, &e.SourceIP, &e.Action, &e.Target, &e.Parameters, &e.Status, &e.Result, &e.TenantID)
+		if err != nil {
+			return nil, "", ErrActivityDatabase.Wrap("GetPage", "rows.Scan: ", err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	if rows.Err() != nil {
+		return nil, "", ErrActivityDatabase.Wrap("GetPage", "rows.Err", rows.Err())
+	}
+
+	return entries, "", nil
+}
+
+// EstimateCount returns about how many entries match without counting them, see db.SQL.EstimateCount.
+func (r *ActivityRepo) EstimateCount(ctx context.Context, filter entity.ActivityFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("id").
+		From("activity_log").
+		Where(activityConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrActivityDatabase.Wrap("EstimateCount", "r.Builder: ", err)
+	}
+
+	count, err := r.SQL.EstimateCount(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, ErrActivityDatabase.Wrap("EstimateCount", "r.SQL.EstimateCount", err)
+	}
+
+	return count, nil
+}
+
+// Insert -.
+func (r *ActivityRepo) Insert(_ context.Context, e *entity.ActivityEntry) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("activity_log").
+		Columns("id", "timestamp", "actor", "api_key_id", "source_ip", "action", "target", "parameters", "status", "result", "tenant_id").
+		Values(e.ID, e.Timestamp, e.Actor, e.APIKeyID, e.SourceIP, e.Action, e.Target, e.Parameters, e.Status, e.Result, e.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrActivityDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	if _, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...); err != nil {
+		return ErrActivityDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestActivityRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE activity_log (
+			id TEXT NOT NULL,
+			timestamp TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			api_key_id TEXT NOT NULL,
+			source_ip TEXT NOT NULL,
+			action TEXT NOT NULL,
+			target TEXT NOT NULL,
+			parameters TEXT NOT NULL,
+			status INTEGER NOT NULL,
+			result TEXT NOT NULL,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (id)
+		);`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewActivityRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+	older := entity.ActivityEntry{ID: "a", Timestamp: "2025-03-01T09:00:00.000Z", Actor: "jdoe", Action: "POST /api/v1/admin/profiles", Target: "profileName=p1", Status: 201, Result: "success"}
+	newer := entity.ActivityEntry{ID: "b", Timestamp: "2025-03-02T09:00:00.000Z", Actor: "jdoe", Action: "POST /api/v1/amt/power/action/:guid", Target: "guid=123", Status: 403, Result: "failure"}
+	other := entity.ActivityEntry{ID: "c", Timestamp: "2025-03-02T09:00:00.000Z", Actor: "admin", Action: "DELETE /api/v1/devices/:guid", Target: "guid=123", Status: 204, Result: "success", TenantID: "other"}
+
+	for _, entry := range []entity.ActivityEntry{older, newer, other} {
+		require.NoError(t, repo.Insert(ctx, &entry))
+	}
+
+	count, err := repo.GetCount(ctx, entity.ActivityFilter{Actor: "jdoe"})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	list, err := repo.Get(ctx, entity.ActivityFilter{}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.ActivityEntry{newer, older}, list)
+
+	list, err = repo.Get(ctx, entity.ActivityFilter{Action: "power", Result: "failure"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.ActivityEntry{newer}, list)
+
+	list, err = repo.Get(ctx, entity.ActivityFilter{To: "2025-03-01T23:59:59.999Z"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.ActivityEntry{older}, list)
+
+	// entries of the same instant are paged by id
+	same := entity.ActivityEntry{ID: "d", Timestamp: newer.Timestamp, Actor: "jdoe", Action: "GET /api/v1/admin/profiles/export/:name", Target: "name=p1", Status: 200, Result: "success"}
+	require.NoError(t, repo.Insert(ctx, &same))
+
+	var skipToken string
+
+	list = nil
+
+	for page := 0; page == 0 || skipToken != ""; page++ {
+		entries, next, err := repo.GetPage(ctx, entity.ActivityFilter{}, 1, skipToken)
+		require.NoError(t, err)
+
+		list = append(list, entries...)
+		skipToken = next
+	}
+
+	require.Equal(t, []entity.ActivityEntry{newer, same, older}, list)
+
+	count, err = repo.EstimateCount(ctx, entity.ActivityFilter{Actor: "jdoe"})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
@@ -0,0 +1,227 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// APIKeyRepo -.
+type APIKeyRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrAPIKeyDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("APIKeyRepo")}
+
+var apiKeyColumns = []string{
+	"id",
+	"name",
+	"owner",
+	"scopes",
+	"key_hash",
+	"COALESCE(expires_at, '')",
+	"COALESCE(last_used_at, '')",
+	"created_at",
+	"tenant_id",
+}
+
+// NewAPIKeyRepo -.
+func NewAPIKeyRepo(database *db.SQL, log logger.Interface) *APIKeyRepo {
+	return &APIKeyRepo{database, log}
+}
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (entity.APIKey, error) {
+	k := entity.APIKey{}
+
+	err := row.Scan(&k.ID, &k.Name, &k.Owner, &k.Scopes, &k.KeyHash, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt, &k.TenantID)
+
+	return k, err
+}
+
+// GetCount -.
+func (r *APIKeyRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("api_keys").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrAPIKeyDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrAPIKeyDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *APIKeyRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.APIKey, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("created_at", "id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrAPIKeyDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrAPIKeyDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrAPIKeyDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	keys := make([]entity.APIKey, 0)
+
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, ErrAPIKeyDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+// GetByID -.
+func (r *APIKeyRepo) GetByID(_ context.Context, id string) (*entity.APIKey, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return nil, ErrAPIKeyDatabase.Wrap("GetByID", "r.Builder: ", err)
+	}
+
+	k, err := scanAPIKey(r.Pool.QueryRowContext(context.Background(), sqlQuery, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ErrAPIKeyDatabase.Wrap("GetByID", "row.Scan: ", err)
+	}
+
+	return &k, nil
+}
+
+// Delete -.
+func (r *APIKeyRepo) Delete(_ context.Context, id, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("api_keys").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrAPIKeyDatabase.Wrap("Delete", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrAPIKeyDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("APIKeyRepo - Delete - r.Pool.Exec: %w", err)
+	}
+
+	return result > 0, nil
+}
+
+// DeleteByOwner deletes every key of owner, of any tenant, and returns how many there were.
+func (r *APIKeyRepo) DeleteByOwner(_ context.Context, owner string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("api_keys").
+		Where("owner = ?", owner).
+		ToSql()
+	if err != nil {
+		return 0, ErrAPIKeyDatabase.Wrap("DeleteByOwner", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return 0, ErrAPIKeyDatabase.Wrap("DeleteByOwner", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrAPIKeyDatabase.Wrap("DeleteByOwner", "res.RowsAffected", err)
+	}
+
+	return int(result), nil
+}
+
+// Insert -.
+func (r *APIKeyRepo) Insert(_ context.Context, k *entity.APIKey) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("api_keys").
+		Columns("id", "name", "owner", "scopes", "key_hash", "expires_at", "last_used_at", "created_at", "tenant_id").
+		Values(k.ID, k.Name, k.Owner, k.Scopes, k.KeyHash, k.ExpiresAt, k.LastUsedAt, k.CreatedAt, k.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrAPIKeyDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	if _, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...); err != nil {
+		return ErrAPIKeyDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// UpdateLastUsed -.
+func (r *APIKeyRepo) UpdateLastUsed(_ context.Context, id, lastUsedAt string) error {
+	sqlQuery, args, err := r.Builder.
+		Update("api_keys").
+		Set("last_used_at", lastUsedAt).
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return ErrAPIKeyDatabase.Wrap("UpdateLastUsed", "r.Builder: ", err)
+	}
+
+	if _, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...); err != nil {
+		return ErrAPIKeyDatabase.Wrap("UpdateLastUsed", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestAPIKeyRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE api_keys (
+			id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			owner TEXT NOT NULL,
+			scopes TEXT NOT NULL,
+			key_hash TEXT NOT NULL,
+			expires_at TEXT,
+			last_used_at TEXT,
+			created_at TEXT NOT NULL,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (id)
+		);`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewAPIKeyRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+	key := entity.APIKey{ID: "abc", Name: "ci", Owner: "jdoe", Scopes: "devices:read", KeyHash: "hash", CreatedAt: "2025-01-01T00:00:00Z"}
+
+	require.NoError(t, repo.Insert(ctx, &key))
+
+	// rows written before last use tracking hold NULL
+	_, err = dbConn.ExecContext(ctx, `INSERT INTO api_keys (id, name, owner, scopes, key_hash, created_at, tenant_id) VALUES ('def', 'old', 'jdoe', 'devices:read', 'hash', '2024-01-01T00:00:00Z', 'other')`)
+	require.NoError(t, err)
+
+	count, err := repo.GetCount(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	require.NoError(t, repo.UpdateLastUsed(ctx, "abc", "2025-02-01T00:00:00Z"))
+
+	key.LastUsedAt = "2025-02-01T00:00:00Z"
+
+	list, err := repo.Get(ctx, 0, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, []entity.APIKey{key}, list)
+
+	found, err := repo.GetByID(ctx, "def")
+	require.NoError(t, err)
+	require.Empty(t, found.ExpiresAt)
+
+	deleted, err := repo.Delete(ctx, "abc", "")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	found, err = repo.GetByID(ctx, "abc")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	// the keys of an owner go whichever tenant they were created in
+	require.NoError(t, repo.Insert(ctx, &entity.APIKey{ID: "ghi", Name: "ci", Owner: "jdoe", Scopes: "devices:read", KeyHash: "hash", CreatedAt: "2025-01-01T00:00:00Z"}))
+	require.NoError(t, repo.Insert(ctx, &entity.APIKey{ID: "jkl", Name: "ci", Owner: "asmith", Scopes: "devices:read", KeyHash: "hash", CreatedAt: "2025-01-01T00:00:00Z"}))
+
+	removed, err := repo.DeleteByOwner(ctx, "jdoe")
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	found, err = repo.GetByID(ctx, "jkl")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+}
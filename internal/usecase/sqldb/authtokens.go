@@ -0,0 +1,195 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// AuthTokenRepo stores refresh tokens and the revocation list of access tokens.
+type AuthTokenRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrAuthTokenDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("AuthTokenRepo")}
+
+// NewAuthTokenRepo -.
+func NewAuthTokenRepo(database *db.SQL, log logger.Interface) *AuthTokenRepo {
+	return &AuthTokenRepo{database, log}
+}
+
+// GetRefreshToken -.
+func (r *AuthTokenRepo) GetRefreshToken(_ context.Context, id string) (*entity.RefreshToken, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("id", "family_id", "subject", "role", "token_hash", "expires_at", "used", "tenant_id").
+		From("refresh_tokens").
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return nil, ErrAuthTokenDatabase.Wrap("GetRefreshToken", "r.Builder: ", err)
+	}
+
+	t := entity.RefreshToken{}
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).
+		Scan(&t.ID, &t.FamilyID, &t.Subject, &t.Role, &t.TokenHash, &t.ExpiresAt, &t.Used, &t.TenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ErrAuthTokenDatabase.Wrap("GetRefreshToken", "row.Scan: ", err)
+	}
+
+	return &t, nil
+}
+
+// InsertRefreshToken -.
+func (r *AuthTokenRepo) InsertRefreshToken(_ context.Context, t *entity.RefreshToken) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("refresh_tokens").
+		Columns("id", "family_id", "subject", "role", "token_hash", "expires_at", "used", "tenant_id").
+		Values(t.ID, t.FamilyID, t.Subject, t.Role, t.TokenHash, t.ExpiresAt, t.Used, t.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrAuthTokenDatabase.Wrap("InsertRefreshToken", "r.Builder: ", err)
+	}
+
+	if _, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...); err != nil {
+		return ErrAuthTokenDatabase.Wrap("InsertRefreshToken", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// MarkRefreshTokenUsed reports false when the token was already used, so that only one of two concurrent
+// refreshes with the same token succeeds.
+func (r *AuthTokenRepo) MarkRefreshTokenUsed(_ context.Context, id string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("refresh_tokens").
+		Set("used", true).
+		Where("id = ?", id).
+		Where("used = ?", false).
+		ToSql()
+	if err != nil {
+		return false, ErrAuthTokenDatabase.Wrap("MarkRefreshTokenUsed", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrAuthTokenDatabase.Wrap("MarkRefreshTokenUsed", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("AuthTokenRepo - MarkRefreshTokenUsed - r.Pool.Exec: %w", err)
+	}
+
+	return result > 0, nil
+}
+
+// DeleteRefreshTokenFamily removes a refresh token along with every token it was rotated from or into.
+func (r *AuthTokenRepo) DeleteRefreshTokenFamily(_ context.Context, familyID string) error {
+	return r.deleteRefreshTokens("DeleteRefreshTokenFamily", squirrel.Eq{"family_id": familyID})
+}
+
+// DeleteRefreshTokens removes every refresh token of subject.
+func (r *AuthTokenRepo) DeleteRefreshTokens(_ context.Context, subject string) error {
+	return r.deleteRefreshTokens("DeleteRefreshTokens", squirrel.Eq{"subject": subject})
+}
+
+func (r *AuthTokenRepo) deleteRefreshTokens(function string, where squirrel.Sqlizer) error {
+	sqlQuery, args, err := r.Builder.
+		Delete("refresh_tokens").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return ErrAuthTokenDatabase.Wrap(function, "r.Builder: ", err)
+	}
+
+	if _, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...); err != nil {
+		return ErrAuthTokenDatabase.Wrap(function, "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// InsertRevocation -.
+func (r *AuthTokenRepo) InsertRevocation(_ context.Context, rv *entity.TokenRevocation) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("token_revocations").
+		Columns("token_id", "subject", "revoked_at", "expires_at").
+		Values(rv.TokenID, rv.Subject, rv.RevokedAt, rv.ExpiresAt).
+		ToSql()
+	if err != nil {
+		return ErrAuthTokenDatabase.Wrap("InsertRevocation", "r.Builder: ", err)
+	}
+
+	if _, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...); err != nil {
+		return ErrAuthTokenDatabase.Wrap("InsertRevocation", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// IsRevoked reports whether a revocation that has not expired at now matches the token tokenID, or every token
+// of subject issued at or before issuedAt. An empty tokenID only matches revocations of the subject.
+func (r *AuthTokenRepo) IsRevoked(_ context.Context, tokenID, subject, issuedAt, now string) (bool, error) {
+	matches := squirrel.Or{
+		squirrel.And{
+			squirrel.Eq{"token_id": ""},
+			squirrel.Eq{"subject": subject},
+			squirrel.GtOrEq{"revoked_at": issuedAt},
+		},
+	}
+
+	if tokenID != "" {
+		matches = append(matches, squirrel.Eq{"token_id": tokenID})
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("token_revocations").
+		Where(squirrel.Gt{"expires_at": now}).
+		Where(matches).
+		ToSql()
+	if err != nil {
+		return false, ErrAuthTokenDatabase.Wrap("IsRevoked", "r.Builder: ", err)
+	}
+
+	var count int
+
+	if err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count); err != nil {
+		return false, ErrAuthTokenDatabase.Wrap("IsRevoked", "r.Pool.QueryRow", err)
+	}
+
+	return count > 0, nil
+}
+
+// DeleteExpired removes the refresh tokens and revocations that expired before now.
+func (r *AuthTokenRepo) DeleteExpired(_ context.Context, now string) error {
+	for _, table := range []string{"refresh_tokens", "token_revocations"} {
+		sqlQuery, args, err := r.Builder.
+			Delete(table).
+			Where("expires_at <= ?", now).
+			ToSql()
+		if err != nil {
+			return ErrAuthTokenDatabase.Wrap("DeleteExpired", "r.Builder: ", err)
+		}
+
+		if _, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...); err != nil {
+			return ErrAuthTokenDatabase.Wrap("DeleteExpired", "r.Pool.Exec", err)
+		}
+	}
+
+	return nil
+}
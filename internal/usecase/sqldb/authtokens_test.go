@@ -0,0 +1,132 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func authTokenRepoTest(t *testing.T) *sqldb.AuthTokenRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE refresh_tokens (
+			id TEXT NOT NULL,
+			family_id TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			role TEXT NOT NULL,
+			token_hash TEXT NOT NULL,
+			expires_at TEXT NOT NULL,
+			used BOOLEAN NOT NULL DEFAULT FALSE,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (id)
+		);
+		CREATE TABLE token_revocations (
+			token_id TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			revoked_at TEXT NOT NULL,
+			expires_at TEXT NOT NULL
+		);`)
+	require.NoError(t, err)
+
+	return sqldb.NewAuthTokenRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+}
+
+func TestAuthTokenRepoRefreshTokens(t *testing.T) {
+	t.Parallel()
+
+	repo := authTokenRepoTest(t)
+	ctx := context.Background()
+
+	first := entity.RefreshToken{ID: "a", FamilyID: "f1", Subject: "jdoe", Role: "viewer", TokenHash: "hash", ExpiresAt: "2030-01-01T00:00:00Z", TenantID: "bu-retail"}
+	second := entity.RefreshToken{ID: "b", FamilyID: "f1", Subject: "jdoe", Role: "viewer", TokenHash: "hash", ExpiresAt: "2030-01-01T00:00:00Z"}
+	other := entity.RefreshToken{ID: "c", FamilyID: "f2", Subject: "jdoe", Role: "viewer", TokenHash: "hash", ExpiresAt: "2020-01-01T00:00:00Z"}
+
+	require.NoError(t, repo.InsertRefreshToken(ctx, &first))
+	require.NoError(t, repo.InsertRefreshToken(ctx, &second))
+	require.NoError(t, repo.InsertRefreshToken(ctx, &other))
+
+	found, err := repo.GetRefreshToken(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, &first, found)
+
+	marked, err := repo.MarkRefreshTokenUsed(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, marked)
+
+	marked, err = repo.MarkRefreshTokenUsed(ctx, "a")
+	require.NoError(t, err)
+	require.False(t, marked)
+
+	found, err = repo.GetRefreshToken(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, found.Used)
+
+	require.NoError(t, repo.DeleteExpired(ctx, "2025-01-01T00:00:00Z"))
+
+	found, err = repo.GetRefreshToken(ctx, "c")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	require.NoError(t, repo.DeleteRefreshTokenFamily(ctx, "f1"))
+
+	found, err = repo.GetRefreshToken(ctx, "b")
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
+
+func TestAuthTokenRepoRevocations(t *testing.T) {
+	t.Parallel()
+
+	repo := authTokenRepoTest(t)
+	ctx := context.Background()
+
+	const now = "2025-01-01T12:00:00Z"
+
+	require.NoError(t, repo.InsertRevocation(ctx, &entity.TokenRevocation{TokenID: "jti", Subject: "jdoe", RevokedAt: now, ExpiresAt: "2025-01-02T00:00:00Z"}))
+	require.NoError(t, repo.InsertRevocation(ctx, &entity.TokenRevocation{Subject: "admin", RevokedAt: now, ExpiresAt: "2025-01-02T00:00:00Z"}))
+
+	tests := []struct {
+		name     string
+		tokenID  string
+		subject  string
+		issuedAt string
+		now      string
+		revoked  bool
+	}{
+		{"revoked token", "jti", "jdoe", "2025-01-01T11:00:00Z", now, true},
+		{"other token of the subject", "other", "jdoe", "2025-01-01T11:00:00Z", now, false},
+		{"revocation has expired", "jti", "jdoe", "2025-01-01T11:00:00Z", "2025-01-02T00:00:00Z", false},
+		{"token issued before the forced logout", "other", "admin", "2025-01-01T11:00:00Z", now, true},
+		{"token issued in the second of the forced logout", "other", "admin", now, now, true},
+		{"token issued after the forced logout", "other", "admin", "2025-01-01T12:00:01Z", now, false},
+		{"token without id is only checked against its subject", "", "jdoe", "2025-01-01T11:00:00Z", now, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			revoked, err := repo.IsRevoked(ctx, tc.tokenID, tc.subject, tc.issuedAt, tc.now)
+			require.NoError(t, err)
+			require.Equal(t, tc.revoked, revoked)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// BackupRepo reads and writes whole tables for backups. Every column of a table is copied, so columns added by
+// later migrations are backed up without changes here.
+type BackupRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrBackupDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("BackupRepo")}
+
+	errBackupRowKey = errors.New("a row of the backup has no value for a key column")
+)
+
+type backupTable struct {
+	name  string
+	keys  []string
+	owner []string
+}
+
+// backupTables lists the tables of a backup in the order they are restored in, tables come after the ones
+// they reference.
+var backupTables = []backupTable{
+	{name: "ieee8021xconfigs", keys: []string{"profile_name", "tenant_id"}},
+	{name: "ciraconfigs", keys: []string{"cira_config_name", "tenant_id"}},
+	{name: "wirelessconfigs", keys: []string{"wireless_profile_name", "tenant_id"}},
+	{name: "profile_templates", keys: []string{"name", "tenant_id"}},
+	{name: "profiles", keys: []string{"profile_name", "tenant_id"}},
+	{
+		name:  "profiles_wirelessconfigs",
+		keys:  []string{"wireless_profile_name", "profile_name", "priority", "tenant_id"},
+		owner: []string{"profile_name", "tenant_id"},
+	},
+	{name: "domains", keys: []string{"name", "tenant_id"}},
+	{name: "device_attributes", keys: []string{"name", "tenant_id"}},
+	{name: "devices", keys: []string{"guid"}},
+	{name: "device_groups", keys: []string{"name", "tenant_id"}},
+	{
+		name:  "device_group_members",
+		keys:  []string{"group_name", "guid", "tenant_id"},
+		owner: []string{"group_name", "tenant_id"},
+	},
+	{
+		name:  "device_group_subgroups",
+		keys:  []string{"group_name", "subgroup_name", "tenant_id"},
+		owner: []string{"group_name", "tenant_id"},
+	},
+}
+
+// NewBackupRepo -.
+func NewBackupRepo(database *db.SQL, log logger.Interface) *BackupRepo {
+	return &BackupRepo{database, log}
+}
+
+// Dump returns every row of the backed up tables ordered by their keys, including the tables without rows.
+func (r *BackupRepo) Dump(ctx context.Context) ([]entity.BackupTable, error) {
+	tables := make([]entity.BackupTable, 0, len(backupTables))
+
+	for _, bt := range backupTables {
+		sqlQuery, args, err := r.Builder.Select("*").From(bt.name).OrderBy(bt.keys...).ToSql()
+		if err != nil {
+			return nil, ErrBackupDatabase.Wrap("Dump", "r.Builder: ", err)
+		}
+
+		rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+		if err != nil {
+			return nil, ErrBackupDatabase.Wrap("Dump", "r.Pool.Query "+bt.name, err)
+		}
+
+		table := entity.BackupTable{
+			Name:    bt.name,
+			Keys:    bt.keys,
+			Secrets: secretColumnsOf(bt.name),
+			Owner:   bt.owner,
+		}
+
+		table.Rows, err = scanRows(rows)
+		if err != nil {
+			return nil, ErrBackupDatabase.Wrap("Dump", "scanRows "+bt.name, err)
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+// Restore writes the rows of tables in one transaction. With replace the backed up tables are emptied first,
+// otherwise rows with the key of a stored row overwrite it and the rows of every owner in tables replace the
+// stored rows of that owner. Columns the database does not have are left out.
+func (r *BackupRepo) Restore(ctx context.Context, tables []entity.BackupTable, replace bool) error {
+	byName := make(map[string]entity.BackupTable, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrBackupDatabase.Wrap("Restore", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	if replace {
+		for i := len(backupTables) - 1; i >= 0; i-- {
+			if err := r.exec(ctx, tx, "Restore", r.Builder.Delete(backupTables[i].name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, bt := range backupTables {
+		table, ok := byName[bt.name]
+		if !ok {
+			continue
+		}
+
+		if !replace && len(bt.owner) > 0 {
+			if err := r.deleteOwned(ctx, tx, bt, table.Rows); err != nil {
+				return err
+			}
+		}
+
+		if err := r.insertRows(ctx, tx, bt, table.Rows); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ErrBackupDatabase.Wrap("Restore", "tx.Commit", err)
+	}
+
+	return nil
+}
+
+// deleteOwned deletes the stored rows of every owner of rows.
+func (r *BackupRepo) deleteOwned(ctx context.Context, tx *sql.Tx, bt backupTable, rows []map[string]interface{}) error {
+	deleted := make(map[string]bool)
+
+	for _, row := range rows {
+		owner := squirrel.Eq{}
+
+		for _, column := range bt.owner {
+			owner[column] = row[column]
+		}
+
+		id := fmt.Sprint(owner)
+		if deleted[id] {
+			continue
+		}
+
+		deleted[id] = true
+
+		if err := r.exec(ctx, tx, "deleteOwned", r.Builder.Delete(bt.name).Where(owner)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *BackupRepo) insertRows(ctx context.Context, tx *sql.Tx, bt backupTable, rows []map[string]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	stored, err := r.columns(ctx, tx, bt.name)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		for _, key := range bt.keys {
+			if row[key] == nil {
+				return ErrBackupDatabase.Wrap("insertRows", bt.name+"."+key, errBackupRowKey)
+			}
+		}
+
+		columns := make([]string, 0, len(row))
+
+		for column := range row {
+			if stored[column] {
+				columns = append(columns, column)
+			}
+		}
+
+		sort.Strings(columns)
+
+		values := make([]interface{}, len(columns))
+		updates := make([]string, 0, len(columns))
+
+		for i, column := range columns {
+			values[i] = row[column]
+
+			if !slices.Contains(bt.keys, column) {
+				updates = append(updates, column+" = excluded."+column)
+			}
+		}
+
+		upsert := "ON CONFLICT (" + strings.Join(bt.keys, ", ") + ") DO NOTHING"
+		if len(updates) > 0 {
+			upsert = "ON CONFLICT (" + strings.Join(bt.keys, ", ") + ") DO UPDATE SET " + strings.Join(updates, ", ")
+		}
+
+		insert := r.Builder.Insert(bt.name).Columns(columns...).Values(values...).Suffix(upsert)
+		if err := r.exec(ctx, tx, "insertRows", insert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// columns returns the columns a table has in the database.
+func (r *BackupRepo) columns(ctx context.Context, tx *sql.Tx, table string) (map[string]bool, error) {
+	sqlQuery, args, err := r.Builder.Select("*").From(table).Where("1 = 0").ToSql()
+	if err != nil {
+		return nil, ErrBackupDatabase.Wrap("columns", "r.Builder: ", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrBackupDatabase.Wrap("columns", "tx.Query "+table, err)
+	}
+
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, ErrBackupDatabase.Wrap("columns", "rows.Columns "+table, err)
+	}
+
+	columns := make(map[string]bool, len(names))
+	for _, name := range names {
+		columns[name] = true
+	}
+
+	return columns, nil
+}
+
+func (r *BackupRepo) exec(ctx context.Context, tx *sql.Tx, op string, b squirrel.Sqlizer) error {
+	sqlQuery, args, err := b.ToSql()
+	if err != nil {
+		return ErrBackupDatabase.Wrap(op, "r.Builder: ", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return ErrBackupDatabase.Wrap(op, "tx.Exec", err)
+	}
+
+	return nil
+}
+
+// scanRows reads every row into a map.
+func scanRows(rows *sql.Rows) ([]map[string]interface{}, error) {
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0)
+
+	for rows.Next() {
+		values, err := db.ScanValues(rows, columns)
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, c := range columns {
+			row[c.Name()] = values[i]
+		}
+
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+func secretColumnsOf(table string) []string {
+	columns := make([]string, 0)
+
+	for _, sc := range secretColumns {
+		if sc.table == table {
+			columns = append(columns, sc.column)
+		}
+	}
+
+	return columns
+}
@@ -0,0 +1,169 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func backupRepoTest(t *testing.T) (*sqldb.BackupRepo, *sql.DB) {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	dbConn.SetMaxOpenConns(1)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		PRAGMA foreign_keys = ON;
+		CREATE TABLE ieee8021xconfigs (profile_name TEXT, wired_interface BOOLEAN NOT NULL, tenant_id TEXT NOT NULL, PRIMARY KEY (profile_name, tenant_id));
+		CREATE TABLE ciraconfigs (cira_config_name TEXT NOT NULL, password TEXT, tenant_id TEXT NOT NULL, PRIMARY KEY (cira_config_name, tenant_id));
+		CREATE TABLE wirelessconfigs (wireless_profile_name TEXT NOT NULL, psk_passphrase TEXT, tenant_id TEXT NOT NULL, PRIMARY KEY (wireless_profile_name, tenant_id));
+		CREATE TABLE profile_templates (name TEXT NOT NULL, settings TEXT NOT NULL, tenant_id TEXT NOT NULL, PRIMARY KEY (name, tenant_id));
+		CREATE TABLE profiles (profile_name TEXT NOT NULL, amt_password TEXT, mebx_password TEXT, kvm_enabled BOOLEAN NOT NULL, tenant_id TEXT NOT NULL,
+			PRIMARY KEY (profile_name, tenant_id));
+		CREATE TABLE profiles_wirelessconfigs (wireless_profile_name TEXT, profile_name TEXT, priority INTEGER, tenant_id TEXT NOT NULL,
+			FOREIGN KEY (wireless_profile_name, tenant_id) REFERENCES wirelessconfigs(wireless_profile_name, tenant_id),
+			FOREIGN KEY (profile_name, tenant_id) REFERENCES profiles(profile_name, tenant_id),
+			PRIMARY KEY (wireless_profile_name, profile_name, priority, tenant_id));
+		CREATE TABLE domains (name TEXT NOT NULL, provisioning_cert_key TEXT, tenant_id TEXT NOT NULL, PRIMARY KEY (name, tenant_id));
+		CREATE TABLE device_attributes (name TEXT NOT NULL, type TEXT NOT NULL, tenant_id TEXT NOT NULL, PRIMARY KEY (name, tenant_id));
+		CREATE TABLE devices (guid TEXT NOT NULL, password TEXT, tenantid TEXT NOT NULL, PRIMARY KEY (guid, tenantid), UNIQUE (guid));
+		CREATE TABLE device_groups (name TEXT NOT NULL, description TEXT NOT NULL, rule TEXT NOT NULL, tenant_id TEXT NOT NULL, PRIMARY KEY (name, tenant_id));
+		CREATE TABLE device_group_members (group_name TEXT NOT NULL, guid TEXT NOT NULL, tenant_id TEXT NOT NULL,
+			FOREIGN KEY (group_name, tenant_id) REFERENCES device_groups(name, tenant_id) ON DELETE CASCADE,
+			PRIMARY KEY (group_name, guid, tenant_id));
+		CREATE TABLE device_group_subgroups (group_name TEXT NOT NULL, subgroup_name TEXT NOT NULL, tenant_id TEXT NOT NULL,
+			FOREIGN KEY (group_name, tenant_id) REFERENCES device_groups(name, tenant_id) ON DELETE CASCADE,
+			FOREIGN KEY (subgroup_name, tenant_id) REFERENCES device_groups(name, tenant_id),
+			PRIMARY KEY (group_name, subgroup_name, tenant_id));
+		INSERT INTO wirelessconfigs VALUES ('w1', 'psk1', ''), ('w2', 'psk2', '');
+		INSERT INTO profiles VALUES ('p1', 'amt1', NULL, true, ''), ('p2', 'amt2', NULL, false, '');
+		INSERT INTO profiles_wirelessconfigs VALUES ('w1', 'p1', 1, ''), ('w1', 'p2', 1, '');
+		INSERT INTO devices VALUES ('d1', 'pw1', '');`)
+	require.NoError(t, err)
+
+	return sqldb.NewBackupRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil)), dbConn
+}
+
+func backupTable(t *testing.T, tables []entity.BackupTable, name string) entity.BackupTable {
+	t.Helper()
+
+	for _, table := range tables {
+		if table.Name == name {
+			return table
+		}
+	}
+
+	t.Fatalf("table %s not found", name)
+
+	return entity.BackupTable{}
+}
+
+func TestBackupRepo_Dump(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := backupRepoTest(t)
+
+	tables, err := repo.Dump(context.Background())
+	require.NoError(t, err)
+	require.Len(t, tables, 12)
+
+	profiles := backupTable(t, tables, "profiles")
+	require.Equal(t, []string{"profile_name", "tenant_id"}, profiles.Keys)
+	require.Equal(t, []string{"amt_password", "mebx_password"}, profiles.Secrets)
+	require.Equal(t, []map[string]interface{}{
+		{"profile_name": "p1", "amt_password": "amt1", "mebx_password": nil, "kvm_enabled": true, "tenant_id": ""},
+		{"profile_name": "p2", "amt_password": "amt2", "mebx_password": nil, "kvm_enabled": false, "tenant_id": ""},
+	}, profiles.Rows)
+
+	links := backupTable(t, tables, "profiles_wirelessconfigs")
+	require.Equal(t, []string{"profile_name", "tenant_id"}, links.Owner)
+	require.Equal(t, int64(1), links.Rows[0]["priority"])
+
+	require.Empty(t, backupTable(t, tables, "domains").Rows)
+}
+
+func TestBackupRepo_RestoreMerge(t *testing.T) {
+	t.Parallel()
+
+	repo, dbConn := backupRepoTest(t)
+
+	err := repo.Restore(context.Background(), []entity.BackupTable{
+		{Name: "profiles", Rows: []map[string]interface{}{
+			{"profile_name": "p1", "amt_password": "amt1-new", "kvm_enabled": false, "tenant_id": "", "dropped_column": "x"},
+			{"profile_name": "p3", "amt_password": "amt3", "kvm_enabled": true, "tenant_id": ""},
+		}},
+		{Name: "profiles_wirelessconfigs", Rows: []map[string]interface{}{
+			{"wireless_profile_name": "w2", "profile_name": "p1", "priority": int64(1), "tenant_id": ""},
+		}},
+	}, false)
+	require.NoError(t, err)
+
+	var password string
+
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), "SELECT amt_password FROM profiles WHERE profile_name = 'p1'").Scan(&password))
+	require.Equal(t, "amt1-new", password)
+
+	var profiles, links, p2Links int
+
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM profiles").Scan(&profiles))
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM profiles_wirelessconfigs WHERE profile_name = 'p1' AND wireless_profile_name = 'w2'").Scan(&links))
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM profiles_wirelessconfigs WHERE profile_name = 'p2'").Scan(&p2Links))
+	require.Equal(t, 3, profiles)
+	// the link of p1 is replaced, the one of p2 is kept
+	require.Equal(t, 1, links)
+	require.Equal(t, 1, p2Links)
+}
+
+func TestBackupRepo_RestoreReplace(t *testing.T) {
+	t.Parallel()
+
+	repo, dbConn := backupRepoTest(t)
+
+	err := repo.Restore(context.Background(), []entity.BackupTable{
+		{Name: "devices", Rows: []map[string]interface{}{{"guid": "d2", "password": "pw2", "tenantid": ""}}},
+	}, true)
+	require.NoError(t, err)
+
+	var devices, profiles int
+
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM devices WHERE guid = 'd2'").Scan(&devices))
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM profiles").Scan(&profiles))
+	require.Equal(t, 1, devices)
+	require.Equal(t, 0, profiles)
+}
+
+func TestBackupRepo_RestoreRollsBack(t *testing.T) {
+	t.Parallel()
+
+	repo, dbConn := backupRepoTest(t)
+
+	// the link references a wireless config that does not exist
+	err := repo.Restore(context.Background(), []entity.BackupTable{
+		{Name: "profiles", Rows: []map[string]interface{}{{"profile_name": "p3", "kvm_enabled": true, "tenant_id": ""}}},
+		{Name: "profiles_wirelessconfigs", Rows: []map[string]interface{}{
+			{"wireless_profile_name": "missing", "profile_name": "p3", "priority": int64(1), "tenant_id": ""},
+		}},
+	}, false)
+	require.Error(t, err)
+
+	var profiles int
+
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM profiles").Scan(&profiles))
+	require.Equal(t, 2, profiles)
+}
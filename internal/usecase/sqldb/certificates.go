@@ -0,0 +1,131 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// CertificateRepo reads the certificates of every tenant for the certificate monitor. Only the fields the
+// monitor needs are filled in.
+type CertificateRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrCertificateDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("CertificateRepo")}
+
+// NewCertificateRepo -.
+func NewCertificateRepo(database *db.SQL, log logger.Interface) *CertificateRepo {
+	return &CertificateRepo{database, log}
+}
+
+// GetDomainExpirations returns the name, expiration date and tenant of the domains with a provisioning
+// certificate.
+func (r *CertificateRepo) GetDomainExpirations(ctx context.Context) ([]entity.Domain, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("name", "expiration_date", "tenant_id").
+		From("domains").
+		Where("expiration_date IS NOT NULL").
+		OrderBy("tenant_id", "name").
+		ToSql()
+	if err != nil {
+		return nil, ErrCertificateDatabase.Wrap("GetDomainExpirations", "r.Builder: ", err)
+	}
+
+	domains := make([]entity.Domain, 0)
+
+	err = r.query(ctx, "GetDomainExpirations", sqlQuery, args, func(rows *sql.Rows) error {
+		d := entity.Domain{}
+		if err := rows.Scan(&d.ProfileName, &d.ExpirationDate, &d.TenantID); err != nil {
+			return err
+		}
+
+		domains = append(domains, d)
+
+		return nil
+	})
+
+	return domains, err
+}
+
+// GetCIRARootCertificates returns the name, MPS root certificate and tenant of the CIRA configs.
+func (r *CertificateRepo) GetCIRARootCertificates(ctx context.Context) ([]entity.CIRAConfig, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("cira_config_name", "mps_root_certificate", "tenant_id").
+		From("ciraconfigs").
+		Where("mps_root_certificate <> ''").
+		OrderBy("tenant_id", "cira_config_name").
+		ToSql()
+	if err != nil {
+		return nil, ErrCertificateDatabase.Wrap("GetCIRARootCertificates", "r.Builder: ", err)
+	}
+
+	configs := make([]entity.CIRAConfig, 0)
+
+	err = r.query(ctx, "GetCIRARootCertificates", sqlQuery, args, func(rows *sql.Rows) error {
+		c := entity.CIRAConfig{}
+		if err := rows.Scan(&c.ConfigName, &c.MPSRootCertificate, &c.TenantID); err != nil {
+			return err
+		}
+
+		configs = append(configs, c)
+
+		return nil
+	})
+
+	return configs, err
+}
+
+// GetTLSDevices returns the GUID, tenant and pinned certificate hash of the devices managed over TLS.
+func (r *CertificateRepo) GetTLSDevices(ctx context.Context) ([]entity.Device, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("guid", "tenantid", "certhash").
+		From("devices").
+		Where("usetls = ? AND deleted_at = ''", true).
+		OrderBy("tenantid", "guid").
+		ToSql()
+	if err != nil {
+		return nil, ErrCertificateDatabase.Wrap("GetTLSDevices", "r.Builder: ", err)
+	}
+
+	devices := make([]entity.Device, 0)
+
+	err = r.query(ctx, "GetTLSDevices", sqlQuery, args, func(rows *sql.Rows) error {
+		d := entity.Device{}
+		if err := rows.Scan(&d.GUID, &d.TenantID, &d.CertHash); err != nil {
+			return err
+		}
+
+		devices = append(devices, d)
+
+		return nil
+	})
+
+	return devices, err
+}
+
+func (r *CertificateRepo) query(ctx context.Context, function, sqlQuery string, args []interface{}, scan func(*sql.Rows) error) error {
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return ErrCertificateDatabase.Wrap(function, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return ErrCertificateDatabase.Wrap(function, "rows.Scan: ", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return ErrCertificateDatabase.Wrap(function, "rows.Err", err)
+	}
+
+	return nil
+}
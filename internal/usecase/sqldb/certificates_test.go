@@ -0,0 +1,58 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestCertificateRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE domains (name TEXT NOT NULL, expiration_date TEXT, tenant_id TEXT NOT NULL);
+		CREATE TABLE ciraconfigs (cira_config_name TEXT NOT NULL, mps_root_certificate TEXT, tenant_id TEXT NOT NULL);
+		CREATE TABLE devices (guid TEXT NOT NULL, tenantid TEXT NOT NULL, usetls BOOLEAN NOT NULL, certhash TEXT, deleted_at TEXT NOT NULL DEFAULT '');
+		INSERT INTO domains VALUES ('b', '2030-01-01T00:00:00Z', ''), ('a', '2029-01-01T00:00:00Z', ''), ('c', NULL, '');
+		INSERT INTO ciraconfigs VALUES ('cira', 'PEM', 'bu-retail'), ('none', NULL, ''), ('empty', '', '');
+		INSERT INTO devices VALUES ('d1', '', TRUE, 'aabb', ''), ('d2', '', TRUE, NULL, ''), ('d3', '', FALSE, NULL, ''),
+			('d4', '', TRUE, 'ccdd', '2025-08-08T00:00:00Z');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewCertificateRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	domains, err := repo.GetDomainExpirations(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []entity.Domain{
+		{ProfileName: "a", ExpirationDate: "2029-01-01T00:00:00Z"},
+		{ProfileName: "b", ExpirationDate: "2030-01-01T00:00:00Z"},
+	}, domains)
+
+	configs, err := repo.GetCIRARootCertificates(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []entity.CIRAConfig{{ConfigName: "cira", MPSRootCertificate: "PEM", TenantID: "bu-retail"}}, configs)
+
+	devices, err := repo.GetTLSDevices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	require.Equal(t, "d1", devices[0].GUID)
+	require.Equal(t, "aabb", *devices[0].CertHash)
+	require.Nil(t, devices[1].CertHash)
+}
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/Masterminds/squirrel"
+
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // CIRARepo -.
@@ -23,25 +26,42 @@ func NewCIRARepo(database *db.SQL, log logger.Interface) *CIRARepo {
 }
 
 var (
-	ErrCIRARepo          = consoleerrors.CreateConsoleError("CIRARepo")
-	ErrCIRARepoDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("CIRARepo")}
-	ErrCIRARepoNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("CIRARepo")}
+	ErrCIRARepo                = consoleerrors.CreateConsoleError("CIRARepo")
+	ErrCIRARepoDatabase        = DatabaseError{Console: consoleerrors.CreateConsoleError("CIRARepo")}
+	ErrCIRARepoNotUnique       = NotUniqueError{Console: consoleerrors.CreateConsoleError("CIRARepo")}
+	ErrCIRARepoVersionConflict = VersionConflictError{Console: consoleerrors.CreateConsoleError("CIRARepo")}
 )
 
+// ciraFields are the CIRA config properties lists can be filtered and ordered by.
+var ciraFields = odata.Fields{
+	"configName":          {Column: "cira_config_name", Type: odata.String},
+	"mpsServerAddress":    {Column: "mps_server_address", Type: odata.String},
+	"mpsPort":             {Column: "mps_port", Type: odata.Number},
+	"username":            {Column: "user_name", Type: odata.String},
+	"commonName":          {Column: "common_name", Type: odata.String},
+	"serverAddressFormat": {Column: "server_address_format", Type: odata.Number},
+	"authMethod":          {Column: "auth_method", Type: odata.Number},
+	"proxyDetails":        {Column: "proxydetails", Type: odata.String},
+}
+
 // GetCount -.
-func (r *CIRARepo) GetCount(_ context.Context, tenantID string) (int, error) {
-	sqlQuery, _, err := r.Builder.
+func (r *CIRARepo) GetCount(_ context.Context, q odata.Query, tenantID string) (int, error) {
+	builder, err := ciraFields.Where(r.Builder.
 		Select("COUNT(*) OVER() AS total_count").
 		From("ciraconfigs").
-		Where("tenant_id = ?", tenantID).
-		ToSql()
+		Where("tenant_id = ?", tenantID), q)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return 0, ErrCIRARepoDatabase.Wrap("GetCount", "r.Builder", err)
 	}
 
 	var count int
 
-	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, tenantID).Scan(&count)
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
@@ -54,7 +74,7 @@ func (r *CIRARepo) GetCount(_ context.Context, tenantID string) (int, error) {
 }
 
 // Get -.
-func (r *CIRARepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.CIRAConfig, error) {
+func (r *CIRARepo) Get(_ context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.CIRAConfig, error) {
 	const defaultTop = 100
 
 	if top == 0 {
@@ -71,7 +91,7 @@ func (r *CIRARepo) Get(_ context.Context, top, skip int, tenantID string) ([]ent
 		limitedSkip = uint64(skip)
 	}
 
-	sqlQuery, _, err := r.Builder.
+	builder, err := ciraFields.Where(r.Builder.
 		Select("cira_config_name",
 			"mps_server_address",
 			"mps_port",
@@ -84,8 +104,17 @@ func (r *CIRARepo) Get(_ context.Context, top, skip int, tenantID string) ([]ent
 			"proxydetails",
 			"tenant_id").
 		From("ciraconfigs").
-		Where("tenant_id = ?", tenantID).
-		OrderBy("cira_config_name").
+		Where("tenant_id = ?", tenantID), q)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = ciraFields.OrderBy(builder, q, "cira_config_name")
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := builder.
 		Limit(limitedTop).
 		Offset(limitedSkip).
 		ToSql()
@@ -93,7 +122,7 @@ func (r *CIRARepo) Get(_ context.Context, top, skip int, tenantID string) ([]ent
 		return nil, ErrCIRARepoDatabase.Wrap("Get", "r.Builder", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, tenantID)
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
 	if err != nil {
 		return nil, ErrCIRARepoDatabase.Wrap("Get", "r.Pool.Query", err)
 	}
@@ -121,7 +150,7 @@ func (r *CIRARepo) Get(_ context.Context, top, skip int, tenantID string) ([]ent
 }
 
 // GetByName -.
-func (r *CIRARepo) GetByName(_ context.Context, configName, tenantID string) (*entity.CIRAConfig, error) {
+func (r *CIRARepo) GetByName(ctx context.Context, configName, tenantID string) (*entity.CIRAConfig, error) {
 	sqlQuery, _, err := r.Builder.
 		Select("cira_config_name",
 			"mps_server_address",
@@ -133,7 +162,8 @@ func (r *CIRARepo) GetByName(_ context.Context, configName, tenantID string) (*e
 			"auth_method",
 			"mps_root_certificate",
 			"proxydetails",
-			"tenant_id").
+			"tenant_id",
+			"version").
 		From("ciraconfigs").
 		Where("cira_config_name = ? and tenant_id = ?", configName, tenantID).
 		ToSql()
@@ -141,7 +171,7 @@ func (r *CIRARepo) GetByName(_ context.Context, configName, tenantID string) (*e
 		return nil, ErrCIRARepoDatabase.Wrap("GetByName", "r.Builder", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, configName, tenantID)
+	rows, err := r.Conn(ctx).QueryContext(ctx, sqlQuery, configName, tenantID)
 	if err != nil {
 		return nil, ErrCIRARepoDatabase.Wrap("GetByName", "r.Pool.Query", err)
 	}
@@ -157,7 +187,7 @@ func (r *CIRARepo) GetByName(_ context.Context, configName, tenantID string) (*e
 	for rows.Next() {
 		p := &entity.CIRAConfig{}
 
-		err = rows.Scan(&p.ConfigName, &p.MPSAddress, &p.MPSPort, &p.Username, &p.Password, &p.CommonName, &p.ServerAddressFormat, &p.AuthMethod, &p.MPSRootCertificate, &p.ProxyDetails, &p.TenantID)
+		err = rows.Scan(&p.ConfigName, &p.MPSAddress, &p.MPSPort, &p.Username, &p.Password, &p.CommonName, &p.ServerAddressFormat, &p.AuthMethod, &p.MPSRootCertificate, &p.ProxyDetails, &p.TenantID, &p.Version)
 		if err != nil {
 			return p, ErrCIRARepoDatabase.Wrap("GetByName", "rows.Scan", err)
 		}
@@ -173,18 +203,37 @@ func (r *CIRARepo) GetByName(_ context.Context, configName, tenantID string) (*e
 }
 
 // Delete -.
-func (r *CIRARepo) Delete(_ context.Context, configName, tenantID string) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *CIRARepo) Delete(ctx context.Context, configName, version, tenantID string) (bool, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrCIRARepoDatabase.Wrap("Delete", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	// profiles in the trash must not keep the config from being deleted
+	if err := detachTrashed(ctx, tx, r.Builder, "cira_config_name", configName, tenantID); err != nil {
+		return false, ErrCIRARepoDatabase.Wrap("Delete", "detachTrashed", err)
+	}
+
+	key := squirrel.Expr("cira_config_name = ? AND tenant_id = ?", configName, tenantID)
+
+	builder := r.Builder.
 		Delete("ciraconfigs").
-		Where("cira_config_name = ? AND tenant_id = ?", configName, tenantID).
-		ToSql()
+		Where(key)
+
+	if version != "" {
+		builder = builder.Where(atVersion(version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrCIRARepoDatabase.Wrap("Delete", "r.Builder", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		return false, ErrCIRARepoDatabase.Wrap("Delete", "r.Pool.Exec", err)
+		return false, ErrCIRARepoDatabase.Wrap("Delete", "tx.Exec", err)
 	}
 
 	rowsAffected, err := res.RowsAffected()
@@ -192,12 +241,22 @@ func (r *CIRARepo) Delete(_ context.Context, configName, tenantID string) (bool,
 		return false, ErrCIRARepoDatabase.Wrap("Delete", "res.RowsAffected", err)
 	}
 
+	if rowsAffected == 0 && version != "" {
+		return false, r.versionConflict("Delete", tx, key, configName)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, ErrCIRARepoDatabase.Wrap("Delete", "tx.Commit", err)
+	}
+
 	return rowsAffected > 0, nil
 }
 
 // Update -.
-func (r *CIRARepo) Update(_ context.Context, p *entity.CIRAConfig) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *CIRARepo) Update(ctx context.Context, p *entity.CIRAConfig) (bool, error) {
+	key := squirrel.Expr("cira_config_name = ? AND tenant_id = ?", p.ConfigName, p.TenantID)
+
+	builder := r.Builder.
 		Update("ciraconfigs").
 		Set("mps_server_address", p.MPSAddress).
 		Set("mps_port", p.MPSPort).
@@ -208,13 +267,19 @@ func (r *CIRARepo) Update(_ context.Context, p *entity.CIRAConfig) (bool, error)
 		Set("auth_method", p.AuthMethod).
 		Set("mps_root_certificate", p.MPSRootCertificate).
 		Set("proxydetails", p.ProxyDetails).
-		Where("cira_config_name = ? AND tenant_id = ?", p.ConfigName, p.TenantID).
-		ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		Where(key)
+
+	if p.Version != "" {
+		builder = builder.Where(atVersion(p.Version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrCIRARepoDatabase.Wrap("Update", "r.Builder", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return false, ErrCIRARepoDatabase.Wrap("Update", "r.Pool.Exec", err)
 	}
@@ -224,18 +289,37 @@ func (r *CIRARepo) Update(_ context.Context, p *entity.CIRAConfig) (bool, error)
 		return false, ErrCIRARepoDatabase.Wrap("Delete", "res.RowsAffected", err)
 	}
 
+	if rowsAffected == 0 && p.Version != "" {
+		return false, r.versionConflict("Update", r.Conn(ctx), key, p.ConfigName)
+	}
+
 	return rowsAffected > 0, nil
 }
 
+// versionConflict is the error of a write conditioned on the version of a configuration that touched nothing: a
+// conflict while the configuration is still there, none when it is gone.
+func (r *CIRARepo) versionConflict(function string, q querier, key squirrel.Sqlizer, name string) error {
+	changed, err := rowExists(q, r.Builder, "ciraconfigs", key)
+	if err != nil {
+		return ErrCIRARepoDatabase.Wrap(function, "rowExists", err)
+	}
+
+	if changed {
+		return ErrCIRARepoVersionConflict.Wrap(name)
+	}
+
+	return nil
+}
+
 // Insert -.
-func (r *CIRARepo) Insert(_ context.Context, p *entity.CIRAConfig) (string, error) {
+func (r *CIRARepo) Insert(ctx context.Context, p *entity.CIRAConfig) (string, error) {
 	insertBuilder := r.Builder.
 		Insert("ciraconfigs").
 		Columns("cira_config_name", "mps_server_address", "mps_port", "user_name", "password", "common_name", "server_address_format", "auth_method", "mps_root_certificate", "proxydetails", "tenant_id").
 		Values(p.ConfigName, p.MPSAddress, p.MPSPort, p.Username, p.Password, p.CommonName, p.ServerAddressFormat, p.AuthMethod, p.MPSRootCertificate, p.ProxyDetails, p.TenantID)
 
 	if !r.IsEmbedded {
-		insertBuilder = insertBuilder.Suffix("RETURNING xmin::text")
+		insertBuilder = insertBuilder.Suffix("RETURNING version::text")
 	}
 
 	sqlQuery, args, err := insertBuilder.ToSql()
@@ -246,9 +330,9 @@ func (r *CIRARepo) Insert(_ context.Context, p *entity.CIRAConfig) (string, erro
 	version := ""
 
 	if r.IsEmbedded {
-		_, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+		_, err = r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	} else {
-		err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&version)
+		err = r.Conn(ctx).QueryRowContext(ctx, sqlQuery, args...).Scan(&version)
 	}
 
 	if err != nil {
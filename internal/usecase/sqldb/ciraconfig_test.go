@@ -14,6 +14,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 var (
@@ -120,7 +121,7 @@ func TestCIRARepo_GetCount(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewCIRARepo(sqlConfig, mockLog)
 
-			count, err := repo.GetCount(context.Background(), tc.tenantID)
+			count, err := repo.GetCount(context.Background(), odata.Query{}, tc.tenantID)
 
 			if err == nil && tc.err != nil {
 				t.Errorf("Expected error of type %T, got nil", tc.err)
@@ -236,7 +237,7 @@ func TestCIRARepo_Get(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewCIRARepo(sqlConfig, mockLog)
 
-			configs, err := repo.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			configs, err := repo.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			var expectedConfig *entity.CIRAConfig
 			if len(tc.expected) > 0 {
@@ -587,7 +588,7 @@ func TestCIRARepo_Delete(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewCIRARepo(sqlConfig, mockLog)
 
-			deleted, err := repo.Delete(context.Background(), tc.configName, tc.tenantID)
+			deleted, err := repo.Delete(context.Background(), tc.configName, "", tc.tenantID)
 
 			assertTestResult(t, nil, nil, tc.err, err)
 
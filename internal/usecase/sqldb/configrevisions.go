@@ -0,0 +1,249 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// ConfigRevisionRepo -.
+type ConfigRevisionRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrConfigRevisionDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("ConfigRevisionRepo")}
+
+// NewConfigRevisionRepo -.
+func NewConfigRevisionRepo(database *db.SQL, log logger.Interface) *ConfigRevisionRepo {
+	return &ConfigRevisionRepo{database, log}
+}
+
+// GetCount -.
+func (r *ConfigRevisionRepo) GetCount(_ context.Context, kind, name, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("config_revisions").
+		Where("kind = ? AND name = ? AND tenant_id = ?", kind, name, tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrConfigRevisionDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrConfigRevisionDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the revisions of a configuration without their secrets, newest first.
+func (r *ConfigRevisionRepo) Get(_ context.Context, kind, name string, top, skip int, tenantID string) ([]entity.ConfigRevision, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("kind", "name", "revision", "author", "created_at", "content", "tenant_id").
+		From("config_revisions").
+		Where("kind = ? AND name = ? AND tenant_id = ?", kind, name, tenantID).
+		OrderBy("revision DESC").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrConfigRevisionDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrConfigRevisionDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrConfigRevisionDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	revisions := make([]entity.ConfigRevision, 0)
+
+	for rows.Next() {
+		rev := entity.ConfigRevision{}
+
+		err = rows.Scan(&rev.Kind, &rev.Name, &rev.Revision, &rev.Author, &rev.CreatedAt, &rev.Content, &rev.TenantID)
+		if err != nil {
+			return nil, ErrConfigRevisionDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, nil
+}
+
+// GetByRevision returns a revision with its encrypted secrets, or nil when it does not exist.
+func (r *ConfigRevisionRepo) GetByRevision(ctx context.Context, kind, name string, revision int, tenantID string) (*entity.ConfigRevision, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("kind", "name", "revision", "author", "created_at", "content", "tenant_id").
+		From("config_revisions").
+		Where("kind = ? AND name = ? AND revision = ? AND tenant_id = ?", kind, name, revision, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrConfigRevisionDatabase.Wrap("GetByRevision", "r.Builder: ", err)
+	}
+
+	rev := &entity.ConfigRevision{}
+
+	err = r.Pool.QueryRowContext(ctx, sqlQuery, args...).
+		Scan(&rev.Kind, &rev.Name, &rev.Revision, &rev.Author, &rev.CreatedAt, &rev.Content, &rev.TenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ErrConfigRevisionDatabase.Wrap("GetByRevision", "r.Pool.QueryRow", err)
+	}
+
+	sqlQuery, args, err = r.Builder.
+		Select("field", "value").
+		From("config_revision_secrets").
+		Where("kind = ? AND name = ? AND revision = ? AND tenant_id = ?", kind, name, revision, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrConfigRevisionDatabase.Wrap("GetByRevision", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrConfigRevisionDatabase.Wrap("GetByRevision", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrConfigRevisionDatabase.Wrap("GetByRevision", "rows.Err", rows.Err())
+	}
+
+	rev.Secrets = map[string]string{}
+
+	for rows.Next() {
+		var field, value string
+
+		if err = rows.Scan(&field, &value); err != nil {
+			return nil, ErrConfigRevisionDatabase.Wrap("GetByRevision", "rows.Scan: ", err)
+		}
+
+		rev.Secrets[field] = value
+	}
+
+	return rev, nil
+}
+
+// revisionAttempts bounds how often Insert numbers a revision again after another save of the configuration
+// took the number first.
+const revisionAttempts = 5
+
+// Insert stores a revision numbered after the latest one of the configuration and returns its number. Two
+// saves of a configuration can read the same latest revision, the second one to write it then fails on the
+// primary key and numbers its revision again. A revision recorded in the transaction of a save is not numbered
+// again, the save fails and is rolled back instead.
+func (r *ConfigRevisionRepo) Insert(ctx context.Context, rev *entity.ConfigRevision) (int, error) {
+	for attempt := 1; ; attempt++ {
+		var (
+			revision int
+			taken    bool
+			err      error
+		)
+
+		txErr := r.InTx(ctx, func(ctx context.Context) error {
+			revision, taken, err = r.insert(ctx, rev)
+
+			return err
+		})
+		if err == nil && txErr != nil {
+			err = ErrConfigRevisionDatabase.Wrap("Insert", "r.InTx", txErr)
+		}
+
+		if !taken || attempt == revisionAttempts || db.HasTx(ctx) {
+			return revision, err
+		}
+	}
+}
+
+// insert stores rev as the revision after the latest one and reports whether that number was taken meanwhile. It
+// runs in the transaction of ctx.
+func (r *ConfigRevisionRepo) insert(ctx context.Context, rev *entity.ConfigRevision) (int, bool, error) {
+	tx := r.Conn(ctx)
+
+	sqlQuery, args, err := r.Builder.
+		Select("COALESCE(MAX(revision), 0)").
+		From("config_revisions").
+		Where("kind = ? AND name = ? AND tenant_id = ?", rev.Kind, rev.Name, rev.TenantID).
+		ToSql()
+	if err != nil {
+		return 0, false, ErrConfigRevisionDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	var latest int
+
+	if err = tx.QueryRowContext(ctx, sqlQuery, args...).Scan(&latest); err != nil {
+		return 0, false, ErrConfigRevisionDatabase.Wrap("Insert", "tx.QueryRow", err)
+	}
+
+	revision := latest + 1
+
+	sqlQuery, args, err = r.Builder.
+		Insert("config_revisions").
+		Columns("kind", "name", "revision", "author", "created_at", "content", "tenant_id").
+		Values(rev.Kind, rev.Name, revision, rev.Author, rev.CreatedAt, rev.Content, rev.TenantID).
+		ToSql()
+	if err != nil {
+		return 0, false, ErrConfigRevisionDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return 0, db.CheckNotUnique(err), ErrConfigRevisionDatabase.Wrap("Insert", "tx.Exec", err)
+	}
+
+	if len(rev.Secrets) > 0 {
+		insert := r.Builder.
+			Insert("config_revision_secrets").
+			Columns("kind", "name", "revision", "field", "value", "tenant_id")
+
+		for field, value := range rev.Secrets {
+			insert = insert.Values(rev.Kind, rev.Name, revision, field, value, rev.TenantID)
+		}
+
+		sqlQuery, args, err = insert.ToSql()
+		if err != nil {
+			return 0, false, ErrConfigRevisionDatabase.Wrap("Insert", "r.Builder: ", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+			return 0, false, ErrConfigRevisionDatabase.Wrap("Insert", "tx.Exec", err)
+		}
+	}
+
+	return revision, false, nil
+}
@@ -0,0 +1,103 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestConfigRevisionRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	// every connection of an in-memory database is a database of its own
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE config_revisions (
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			revision INTEGER NOT NULL,
+			author TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			content TEXT NOT NULL,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (kind, name, revision, tenant_id)
+		);
+		CREATE TABLE config_revision_secrets (
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			revision INTEGER NOT NULL,
+			field TEXT NOT NULL,
+			value TEXT NOT NULL,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (kind, name, revision, field, tenant_id)
+		);`)
+	require.NoError(t, err)
+
+	database := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+	repo := sqldb.NewConfigRevisionRepo(database, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+	first := entity.ConfigRevision{Kind: "profiles", Name: "p1", Author: "jdoe", CreatedAt: "2025-08-09T09:00:00Z", Content: `{"tlsMode":1}`, Secrets: map[string]string{"amtPassword": "enc1"}}
+	second := entity.ConfigRevision{Kind: "profiles", Name: "p1", Author: "admin", CreatedAt: "2025-08-09T10:00:00Z", Content: `{"tlsMode":2}`}
+	other := entity.ConfigRevision{Kind: "profiles", Name: "p1", CreatedAt: "2025-08-09T10:00:00Z", Content: `{}`, TenantID: "other"}
+
+	for i, rev := range []entity.ConfigRevision{first, second} {
+		revision, err := repo.Insert(ctx, &rev)
+		require.NoError(t, err)
+		require.Equal(t, i+1, revision)
+	}
+
+	revision, err := repo.Insert(ctx, &other)
+	require.NoError(t, err)
+	require.Equal(t, 1, revision)
+
+	// a revision recorded by a save that fails is rolled back with it
+	errSave := errors.New("save failed")
+
+	err = database.InTx(ctx, func(ctx context.Context) error {
+		_, err := repo.Insert(ctx, &entity.ConfigRevision{Kind: "profiles", Name: "p1", CreatedAt: "2025-08-09T11:00:00Z", Content: `{}`})
+		require.NoError(t, err)
+
+		return errSave
+	})
+	require.ErrorIs(t, err, errSave)
+
+	count, err := repo.GetCount(ctx, "profiles", "p1", "")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	first.Revision, second.Revision = 1, 2
+	first.Secrets = nil
+
+	list, err := repo.Get(ctx, "profiles", "p1", 0, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, []entity.ConfigRevision{second, first}, list)
+
+	got, err := repo.GetByRevision(ctx, "profiles", "p1", 1, "")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"amtPassword": "enc1"}, got.Secrets)
+	require.Equal(t, `{"tlsMode":1}`, got.Content)
+
+	got, err = repo.GetByRevision(ctx, "profiles", "p1", 3, "")
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
 
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // DeviceRepo -.
@@ -23,25 +28,163 @@ var (
 	ErrDeviceNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("DeviceRepo")}
 )
 
+// deviceFields are the device properties lists can be filtered and ordered by.
+var deviceFields = odata.Fields{
+	"guid":             {Column: "guid", Type: odata.String},
+	"hostname":         {Column: "hostname", Type: odata.String},
+	"tags":             {Column: "tags", Type: odata.List},
+	"mpsInstance":      {Column: "mpsinstance", Type: odata.String},
+	"connectionStatus": {Column: "connectionstatus", Type: odata.Bool},
+	"mpsusername":      {Column: "mpsusername", Type: odata.String},
+	"friendlyName":     {Column: "friendlyname", Type: odata.String},
+	"dnsSuffix":        {Column: "dnssuffix", Type: odata.String},
+	"lastConnected":    {Column: "lastconnected", Type: odata.Time},
+	"lastSeen":         {Column: "lastseen", Type: odata.Time},
+	"lastDisconnected": {Column: "lastdisconnected", Type: odata.Time},
+	"username":         {Column: "username", Type: odata.String},
+	"useTLS":           {Column: "usetls", Type: odata.Bool},
+	"allowSelfSigned":  {Column: "allowselfsigned", Type: odata.Bool},
+	"certHash":         {Column: "certhash", Type: odata.String},
+}
+
+// sqliteTimeLayout is the layout the embedded database stores time.Time values in, which is how the last
+// connected, seen and disconnected timestamps of devices are written.
+const sqliteTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// deviceListColumns are the columns lists read, in the order Get and GetPage scan them.
+var deviceListColumns = []string{
+	"guid",
+	"hostname",
+	"tags",
+	"mpsinstance",
+	"connectionstatus",
+	"mpsusername",
+	"tenantid",
+	"friendlyname",
+	"dnssuffix",
+	"deviceinfo",
+	"username",
+	"password",
+	"usetls",
+	"allowselfsigned",
+	"certhash",
+	"attributes",
+}
+
+// inventoryProperties are the properties of the inventory a device reports, kept as JSON in deviceinfo.
+var inventoryProperties = []string{"fwVersion", "fwBuild", "fwSku", "currentMode", "features", "ipAddress"}
+
+// fields returns deviceFields and the inventory properties, which are read from the JSON the way the database
+// supports it. Devices without an inventory have none of them. When q refers to any other property, the custom
+// attributes of the tenant are added as well.
+func (r *DeviceRepo) fields(ctx context.Context, q odata.Query, tenantID string) (odata.Fields, error) {
+	fields := make(odata.Fields, len(deviceFields)+len(inventoryProperties))
+
+	for name, field := range deviceFields {
+		if field.Type == odata.Time && r.IsEmbedded {
+			field.Layout = sqliteTimeLayout
+		}
+
+		fields[name] = field
+	}
+
+	for _, property := range inventoryProperties {
+		fields[property] = odata.Field{Column: r.jsonColumn("deviceinfo", property), Type: odata.String}
+	}
+
+	custom := false
+
+	for _, property := range q.Properties() {
+		if !fields.Has(property) {
+			custom = true
+		}
+	}
+
+	if !custom {
+		return fields, nil
+	}
+
+	attributes, err := NewDeviceAttributeRepo(r.SQL, r.log).GetAll(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range attributes {
+		if fields.Has(a.Name) {
+			continue
+		}
+
+		field := odata.Field{Column: r.jsonColumn("attributes", a.Name), Type: odata.String}
+
+		switch a.Type {
+		case "number":
+			field.Type = odata.Number
+			if !r.IsEmbedded {
+				field.Column = "(" + field.Column + "::double precision)"
+			}
+		case "date":
+			field.Type = odata.Time
+		}
+
+		fields[a.Name] = field
+	}
+
+	return fields, nil
+}
+
+// jsonColumn reads a property of the JSON object kept in column. Property names are letters, digits and
+// underscores only.
+func (r *DeviceRepo) jsonColumn(column, property string) string {
+	if r.IsEmbedded {
+		return "(CASE WHEN json_valid(" + column + ") THEN json_extract(" + column + ", '$." + property + "') END)"
+	}
+
+	return "(CASE WHEN " + column + " LIKE '{%' THEN " + column + "::jsonb ->> '" + property + "' END)"
+}
+
+// IsDeviceProperty reports whether name is a property devices can be filtered by without custom attributes.
+func IsDeviceProperty(name string) bool {
+	if deviceFields.Has(name) {
+		return true
+	}
+
+	for _, property := range inventoryProperties {
+		if strings.EqualFold(property, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // New -.
 func NewDeviceRepo(database *db.SQL, log logger.Interface) *DeviceRepo {
 	return &DeviceRepo{database, log}
 }
 
 // GetCount -.
-func (r *DeviceRepo) GetCount(_ context.Context, tenantID string) (int, error) {
-	sqlQuery, _, err := r.Builder.
-		Select("COUNT(*) OVER() AS total_count").
+func (r *DeviceRepo) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	fields, err := r.fields(ctx, q, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	builder, err := fields.Where(r.Builder.
+		Select("COUNT(*)").
 		From("devices").
-		Where("tenantid = ?", tenantID).
-		ToSql()
+		Where("tenantid = ? AND deleted_at = ''", tenantID), q)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return 0, ErrDeviceDatabase.Wrap("GetCount", "r.Builder: ", err)
 	}
 
 	var count int
 
-	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, tenantID).Scan(&count)
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
@@ -54,7 +197,7 @@ func (r *DeviceRepo) GetCount(_ context.Context, tenantID string) (int, error) {
 }
 
 // Get -.
-func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.Device, error) {
+func (r *DeviceRepo) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Device, error) {
 	const defaultTop = 100
 
 	if top == 0 {
@@ -71,25 +214,25 @@ func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 		limitedSkip = uint64(skip)
 	}
 
-	sqlQuery, _, err := r.Builder.
-		Select("guid",
-			"hostname",
-			"tags",
-			"mpsinstance",
-			"connectionstatus",
-			"mpsusername",
-			"tenantid",
-			"friendlyname",
-			"dnssuffix",
-			"deviceinfo",
-			"username",
-			"password",
-			"usetls",
-			"allowselfsigned",
-			"certhash").
+	fields, err := r.fields(ctx, q, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err := fields.Where(r.Builder.
+		Select(deviceListColumns...).
 		From("devices").
-		Where("tenantid = ?", tenantID).
-		OrderBy("guid").
+		Where("tenantid = ? AND deleted_at = ''", tenantID), q)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = fields.OrderBy(builder, q, "guid")
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := builder.
 		Limit(limitedTop).
 		Offset(limitedSkip).
 		ToSql()
@@ -97,7 +240,7 @@ func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 		return nil, ErrDeviceDatabase.Wrap("Get", "r.Builder: ", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, tenantID)
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
 	if err != nil {
 		return nil, ErrDeviceDatabase.Wrap("Get", "r.Pool.Query", err)
 	}
@@ -113,7 +256,7 @@ func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 	for rows.Next() {
 		d := entity.Device{}
 
-		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
+		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash, &d.Attributes)
 		if err != nil {
 			return nil, ErrDeviceDatabase.Wrap("Get", "rows.Scan: ", err)
 		}
@@ -124,6 +267,120 @@ func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 	return devices, nil
 }
 
+// GetPage returns up to top devices in the order of q, starting after the device skipToken was made from, and
+// the token of the next page, which is empty on the last one. An empty skipToken starts at the first device.
+// Unlike Get, it seeks to the page instead of skipping the devices before it, so large fleets page as fast
+// at the end as at the start and devices added meanwhile do not shift the pages that follow.
+func (r *DeviceRepo) GetPage(ctx context.Context, q odata.Query, top int, skipToken, tenantID string) ([]entity.Device, string, error) {
+	const defaultTop = 100
+
+	if top <= 0 {
+		top = defaultTop
+	}
+
+	fields, err := r.fields(ctx, q, tenantID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keyset, err := fields.Keyset(q, "guid")
+	if err != nil {
+		return nil, "", err
+	}
+
+	builder, err := fields.Where(r.Builder.
+		Select(slices.Concat(deviceListColumns, keyset.Columns())...).
+		From("devices").
+		Where("tenantid = ? AND deleted_at = ''", tenantID), q)
+	if err != nil {
+		return nil, "", err
+	}
+
+	builder, err = keyset.After(builder, skipToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the device after the page tells whether there is a next one
+	sqlQuery, args, err := keyset.OrderBy(builder).
+		Limit(uint64(top) + 1).
+		ToSql()
+	if err != nil {
+		return nil, "", ErrDeviceDatabase.Wrap("GetPage", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, "", ErrDeviceDatabase.Wrap("GetPage", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	devices := make([]entity.Device, 0, top)
+
+	var lastKey []interface{}
+
+	for rows.Next() {
+		if len(devices) == top {
+			next, err := keyset.Token(lastKey)
+			if err != nil {
+				return nil, "", ErrDeviceDatabase.Wrap("GetPage", "keyset.Token", err)
+			}
+
+			return devices, next, nil
+		}
+
+		d := entity.Device{}
+		key := make([]interface{}, len(keyset.Columns()))
+		dest := []interface{}{&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash, &d.Attributes}
+
+		for i := range key {
+			dest = append(dest, &key[i])
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, "", ErrDeviceDatabase.Wrap("GetPage", "rows.Scan: ", err)
+		}
+
+		devices = append(devices, d)
+		lastKey = key
+	}
+
+	if rows.Err() != nil {
+		return nil, "", ErrDeviceDatabase.Wrap("GetPage", "rows.Err", rows.Err())
+	}
+
+	return devices, "", nil
+}
+
+// EstimateCount returns about how many devices match q without counting them, see db.SQL.EstimateCount.
+func (r *DeviceRepo) EstimateCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	fields, err := r.fields(ctx, q, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	builder, err := fields.Where(r.Builder.
+		Select("guid").
+		From("devices").
+		Where("tenantid = ? AND deleted_at = ''", tenantID), q)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery, args, err := builder.ToSql()
+	if err != nil {
+		return 0, ErrDeviceDatabase.Wrap("EstimateCount", "r.Builder: ", err)
+	}
+
+	count, err := r.SQL.EstimateCount(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, ErrDeviceDatabase.Wrap("EstimateCount", "r.SQL.EstimateCount", err)
+	}
+
+	return count, nil
+}
+
 // GetByID -.
 func (r *DeviceRepo) GetByID(_ context.Context, guid, tenantID string) (*entity.Device, error) {
 	sqlQuery, _, err := r.Builder.
@@ -142,9 +399,10 @@ func (r *DeviceRepo) GetByID(_ context.Context, guid, tenantID string) (*entity.
 			"password",
 			"usetls",
 			"allowselfsigned",
-			"certhash").
+			"certhash",
+			"attributes").
 		From("devices").
-		Where("guid = ? and tenantid = ?").
+		Where("guid = ? and tenantid = ? AND deleted_at = ''").
 		ToSql()
 	if err != nil {
 		return nil, ErrDeviceDatabase.Wrap("Get", "r.Builder: ", err)
@@ -166,7 +424,7 @@ func (r *DeviceRepo) GetByID(_ context.Context, guid, tenantID string) (*entity.
 	for rows.Next() {
 		d := &entity.Device{}
 
-		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
+		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash, &d.Attributes)
 		if err != nil {
 			return d, ErrDeviceDatabase.Wrap("Get", "rows.Scan: ", err)
 		}
@@ -185,7 +443,7 @@ func (r *DeviceRepo) GetDistinctTags(_ context.Context, tenantID string) ([]stri
 	sqlQuery, _, err := r.Builder.
 		Select("DISTINCT tags as tag").
 		From("devices").
-		Where("tenantid = ?", tenantID).
+		Where("tenantid = ? AND deleted_at = ''", tenantID).
 		ToSql()
 	if err != nil {
 		return []string{}, ErrDeviceDatabase.Wrap("GetDistinctTags", "r.Builder: ", err)
@@ -234,7 +492,8 @@ func (r *DeviceRepo) GetByTags(_ context.Context, tags []string, method string,
 			"friendlyname",
 			"dnssuffix",
 			"deviceinfo").
-		From("devices")
+		From("devices").
+		Where("deleted_at = ''")
 
 	var params []interface{}
 
@@ -299,17 +558,19 @@ func (r *DeviceRepo) GetByTags(_ context.Context, tags []string, method string,
 	return devices, nil
 }
 
-// Delete -.
+// Delete moves a device to the trash, where it keeps its credentials and pinned certificate until it is
+// restored or purged.
 func (r *DeviceRepo) Delete(_ context.Context, guid, tenantID string) (bool, error) {
-	sqlQuery, _, err := r.Builder.
-		Delete("devices").
-		Where("guid = ? AND tenantid = ?", guid, tenantID).
+	sqlQuery, args, err := r.Builder.
+		Update("devices").
+		Set("deleted_at", time.Now().UTC().Format(time.RFC3339)).
+		Where("guid = ? AND tenantid = ? AND deleted_at = ''", guid, tenantID).
 		ToSql()
 	if err != nil {
 		return false, ErrDeviceDatabase.Wrap("Delete", "r.Builder", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, guid, tenantID)
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
 	if err != nil {
 		return false, ErrDeviceDatabase.Wrap("Delete", "r.Pool.Exec", err)
 	}
@@ -322,9 +583,131 @@ func (r *DeviceRepo) Delete(_ context.Context, guid, tenantID string) (bool, err
 	return rowsAffected > 0, nil
 }
 
+// GetDeleted lists the devices in the trash, the most recently deleted first.
+func (r *DeviceRepo) GetDeleted(_ context.Context, top, skip int, tenantID string) ([]entity.Device, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("guid", "hostname", "tags", "friendlyname", "dnssuffix", "deviceinfo", "deleted_at").
+		From("devices").
+		Where("tenantid = ? AND deleted_at <> ''", tenantID).
+		OrderBy("deleted_at DESC", "guid").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceDatabase.Wrap("GetDeleted", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrDeviceDatabase.Wrap("GetDeleted", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	devices := make([]entity.Device, 0)
+
+	for rows.Next() {
+		d := entity.Device{TenantID: tenantID}
+
+		if err := rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.DeletedAt); err != nil {
+			return nil, ErrDeviceDatabase.Wrap("GetDeleted", "rows.Scan: ", err)
+		}
+
+		devices = append(devices, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrDeviceDatabase.Wrap("GetDeleted", "rows.Err", err)
+	}
+
+	return devices, nil
+}
+
+// Restore takes a device out of the trash.
+func (r *DeviceRepo) Restore(_ context.Context, guid, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("devices").
+		Set("deleted_at", "").
+		Where("guid = ? AND tenantid = ? AND deleted_at <> ''", guid, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("Restore", "r.Builder", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("Restore", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("Restore", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Purge removes a device in the trash for good, with its credentials and pinned certificate.
+func (r *DeviceRepo) Purge(_ context.Context, guid, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("devices").
+		Where("guid = ? AND tenantid = ? AND deleted_at <> ''", guid, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("Purge", "r.Builder", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("Purge", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("Purge", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// PurgeDeleted removes the devices of every tenant that were deleted up to before, an RFC 3339 timestamp.
+func (r *DeviceRepo) PurgeDeleted(_ context.Context, before string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("devices").
+		Where("deleted_at <> '' AND deleted_at <= ?", before).
+		ToSql()
+	if err != nil {
+		return 0, ErrDeviceDatabase.Wrap("PurgeDeleted", "r.Builder", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return 0, ErrDeviceDatabase.Wrap("PurgeDeleted", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrDeviceDatabase.Wrap("PurgeDeleted", "res.RowsAffected", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
 // Update -.
 func (r *DeviceRepo) Update(_ context.Context, d *entity.Device) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+	builder := r.Builder.
 		Update("devices").
 		Set("guid", d.GUID).
 		Set("hostname", d.Hostname).
@@ -341,8 +724,14 @@ func (r *DeviceRepo) Update(_ context.Context, d *entity.Device) (bool, error) {
 		Set("useTLS", d.UseTLS).
 		Set("allowSelfSigned", d.AllowSelfSigned).
 		Set("certhash", d.CertHash).
-		Where("guid = ? AND tenantid = ?", d.GUID, d.TenantID).
-		ToSql()
+		Where("guid = ? AND tenantid = ? AND deleted_at = ''", d.GUID, d.TenantID)
+
+	// devices written without attributes keep the ones they have
+	if d.Attributes != "" {
+		builder = builder.Set("attributes", d.Attributes)
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrDeviceDatabase.Wrap("Update", "r.Builder", err)
 	}
@@ -364,8 +753,8 @@ func (r *DeviceRepo) Update(_ context.Context, d *entity.Device) (bool, error) {
 func (r *DeviceRepo) Insert(_ context.Context, d *entity.Device) (string, error) {
 	insertBuilder := r.Builder.
 		Insert("devices").
-		Columns("guid", "hostname", "tags", "mpsinstance", "connectionstatus", "mpsusername", "tenantid", "friendlyname", "dnssuffix", "deviceinfo", "username", "password", "usetls", "allowselfsigned", "certhash").
-		Values(d.GUID, d.Hostname, d.Tags, d.MPSInstance, d.ConnectionStatus, d.MPSUsername, d.TenantID, d.FriendlyName, d.DNSSuffix, d.DeviceInfo, d.Username, d.Password, d.UseTLS, d.AllowSelfSigned, d.CertHash)
+		Columns("guid", "hostname", "tags", "mpsinstance", "connectionstatus", "mpsusername", "tenantid", "friendlyname", "dnssuffix", "deviceinfo", "username", "password", "usetls", "allowselfsigned", "certhash", "attributes").
+		Values(d.GUID, d.Hostname, d.Tags, d.MPSInstance, d.ConnectionStatus, d.MPSUsername, d.TenantID, d.FriendlyName, d.DNSSuffix, d.DeviceInfo, d.Username, d.Password, d.UseTLS, d.AllowSelfSigned, d.CertHash, d.Attributes)
 
 	if !r.IsEmbedded {
 		insertBuilder = insertBuilder.Suffix("RETURNING xmin::text")
@@ -386,6 +775,13 @@ func (r *DeviceRepo) Insert(_ context.Context, d *entity.Device) (string, error)
 
 	if err != nil {
 		if db.CheckNotUnique(err) {
+			// a device in the trash keeps its GUID until it is purged
+			trashed, existsErr := rowExists(r.Pool, r.Builder, "devices",
+				squirrel.Expr("guid = ? AND tenantid = ? AND deleted_at <> ''", d.GUID, d.TenantID))
+			if existsErr == nil && trashed {
+				return "", ErrDeviceNotUnique.Wrap("device " + d.GUID + " is in the trash, restore or purge it first")
+			}
+
 			return "", ErrDeviceNotUnique
 		}
 
@@ -412,9 +808,10 @@ func (r *DeviceRepo) GetByColumn(_ context.Context, columnName, queryValue, tena
 			"password",
 			"usetls",
 			"allowselfsigned",
-			"certhash").
+			"certhash",
+			"attributes").
 		From("devices").
-		Where(columnName+" = ? AND tenantid = ?", queryValue, tenantID).
+		Where(columnName+" = ? AND tenantid = ? AND deleted_at = ''", queryValue, tenantID).
 		ToSql()
 	if err != nil {
 		return nil, ErrDeviceDatabase.Wrap("Get", "r.Builder: ", err)
@@ -436,7 +833,7 @@ func (r *DeviceRepo) GetByColumn(_ context.Context, columnName, queryValue, tena
 	for rows.Next() {
 		d := entity.Device{}
 
-		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
+		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash, &d.Attributes)
 		if err != nil {
 			return nil, ErrDeviceDatabase.Wrap("Get", "rows.Scan: ", err)
 		}
@@ -446,3 +843,8 @@ func (r *DeviceRepo) GetByColumn(_ context.Context, columnName, queryValue, tena
 
 	return devices, nil
 }
+
+// GetAttributes returns the custom attributes defined for the devices of a tenant.
+func (r *DeviceRepo) GetAttributes(ctx context.Context, tenantID string) ([]entity.DeviceAttribute, error) {
+	return NewDeviceAttributeRepo(r.SQL, r.log).GetAll(ctx, tenantID)
+}
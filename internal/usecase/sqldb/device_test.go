@@ -6,6 +6,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 var (
@@ -45,7 +47,17 @@ func setupDeviceTable(t *testing.T) *sql.DB {
 			password TEXT NOT NULL DEFAULT '',
 			usetls BOOLEAN NOT NULL DEFAULT FALSE,
 			allowselfsigned BOOLEAN NOT NULL DEFAULT FALSE,
-			certhash TEXT NOT NULL DEFAULT ''
+			certhash TEXT NOT NULL DEFAULT '',
+			attributes TEXT NOT NULL DEFAULT '',
+			deleted_at TEXT NOT NULL DEFAULT ''
+		);
+		CREATE TABLE device_attributes (
+			name TEXT NOT NULL,
+			type TEXT NOT NULL,
+			enum_values TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '',
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (name, tenant_id)
 		);
 	`)
 	require.NoError(t, err)
@@ -118,7 +130,7 @@ func TestDeviceRepo_GetCount(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewDeviceRepo(sqlConfig, mockLog)
 
-			count, err := repo.GetCount(context.Background(), tc.tenantID)
+			count, err := repo.GetCount(context.Background(), odata.Query{}, tc.tenantID)
 
 			if err == nil && tc.err != nil {
 				t.Errorf("Expected error of type %T, got nil", tc.err)
@@ -247,7 +259,7 @@ func TestDeviceRepo_Get(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewDeviceRepo(sqlConfig, mockLog)
 
-			devices, err := repo.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			devices, err := repo.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			checkDeviceError(t, err, tc.err)
 
@@ -256,6 +268,181 @@ func TestDeviceRepo_Get(t *testing.T) {
 	}
 }
 
+func TestDeviceRepo_GetFiltered(t *testing.T) {
+	t.Parallel()
+
+	dbConn := setupDeviceTable(t)
+	defer dbConn.Close()
+
+	_, err := dbConn.ExecContext(context.Background(), `ALTER TABLE devices ADD COLUMN lastseen TEXT`)
+	require.NoError(t, err)
+
+	// timestamps are written as time.Time, the way the driver stores them
+	_, err = dbConn.ExecContext(context.Background(), `
+		INSERT INTO devices (guid, hostname, tags, tenantid, lastseen) VALUES
+			('guid1', 'lab-1', 'lab,eu', 'tenant1', ?),
+			('guid2', 'lab-2', 'lab', 'tenant1', ?),
+			('guid3', 'lab-3', 'eu', 'tenant1', ?),
+			('guid4', 'pos-1', 'lab', 'tenant1', ?),
+			('guid5', 'lab-5', 'lab', 'tenant2', ?);`,
+		time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 12, 31, 23, 59, 59, 500000000, time.UTC),
+		time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+
+	q, err := odata.Parse("hostname startswith 'lab' and lastSeen lt 2026-01-01 and (tags eq 'eu' or tags ne 'x')", "hostname desc")
+	require.NoError(t, err)
+
+	devices, err := repo.Get(context.Background(), q, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	require.Equal(t, "guid2", devices[0].GUID)
+	require.Equal(t, "guid1", devices[1].GUID)
+
+	count, err := repo.GetCount(context.Background(), q, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	for filter, expected := range map[string]int{
+		"lastSeen ge 2026-01-01":                                      1,
+		"lastSeen eq 2026-01-01T10:00:00Z":                            1,
+		"lastSeen gt 2025-12-31T23:59:59Z and lastSeen lt 2026-01-01": 1,
+		"lastSeen le 2025-12-31T23:59:59Z":                            2,
+	} {
+		q, err = odata.Parse(filter, "")
+		require.NoError(t, err)
+
+		count, err = repo.GetCount(context.Background(), q, "tenant1")
+		require.NoError(t, err)
+		require.Equal(t, expected, count, filter)
+	}
+
+	q, err = odata.Parse("password eq 'password1'", "")
+	require.NoError(t, err)
+
+	_, err = repo.Get(context.Background(), q, 10, 0, "tenant1")
+	require.ErrorAs(t, err, &odata.Error{})
+}
+
+func TestDeviceRepo_GetPage(t *testing.T) {
+	t.Parallel()
+
+	dbConn := setupDeviceTable(t)
+	defer dbConn.Close()
+
+	_, err := dbConn.ExecContext(context.Background(), `
+		ALTER TABLE devices ADD COLUMN lastseen TEXT;
+		INSERT INTO devices (guid, hostname, tenantid, lastseen) VALUES
+			('guid1', 'lab-1', 'tenant1', '2025-06-01T00:00:00Z'),
+			('guid2', 'lab-2', 'tenant1', NULL),
+			('guid3', 'lab-3', 'tenant1', '2025-06-01T00:00:00Z'),
+			('guid4', 'lab-4', 'tenant1', '2026-02-01T00:00:00Z'),
+			('guid5', 'lab-5', 'tenant1', NULL),
+			('guid6', 'lab-6', 'tenant2', '2026-02-01T00:00:00Z');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+
+	q, err := odata.Parse("", "lastSeen desc")
+	require.NoError(t, err)
+
+	var (
+		guids     []string
+		skipToken string
+	)
+
+	for page := 0; page == 0 || skipToken != ""; page++ {
+		devices, next, err := repo.GetPage(context.Background(), q, 2, skipToken, "tenant1")
+		require.NoError(t, err)
+
+		for i := range devices {
+			guids = append(guids, devices[i].GUID)
+		}
+
+		// a device added meanwhile before the current page does not shift the next one
+		if page == 0 {
+			_, err = dbConn.ExecContext(context.Background(), `INSERT INTO devices (guid, hostname, tenantid, lastseen) VALUES ('guid0', 'lab-0', 'tenant1', '2027-01-01T00:00:00Z')`)
+			require.NoError(t, err)
+		}
+
+		skipToken = next
+	}
+
+	// nulls come last, equal values are ordered by guid
+	require.Equal(t, []string{"guid4", "guid1", "guid3", "guid2", "guid5"}, guids)
+
+	count, err := repo.EstimateCount(context.Background(), q, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 6, count)
+
+	_, _, err = repo.GetPage(context.Background(), odata.Query{}, 2, "not a token", "tenant1")
+	require.ErrorAs(t, err, &odata.Error{})
+}
+
+func TestDeviceRepo_GetByInventory(t *testing.T) {
+	t.Parallel()
+
+	dbConn := setupDeviceTable(t)
+	defer dbConn.Close()
+
+	_, err := dbConn.ExecContext(context.Background(), `
+		INSERT INTO devices (guid, tags, tenantid, deviceinfo) VALUES
+			('guid1', 'site-x', 'tenant1', '{"fwVersion":"16.1.27","currentMode":"ACM"}'),
+			('guid2', 'site-x', 'tenant1', '{"fwVersion":"15.0.45","currentMode":"ACM"}'),
+			('guid3', 'site-x', 'tenant1', '{"fwVersion":"16.1.27","currentMode":"CCM"}'),
+			('guid4', 'site-x', 'tenant1', ''),
+			('guid5', 'site-y', 'tenant1', '{"fwVersion":"16.0.15","currentMode":"ACM"}');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+
+	q, err := odata.Parse("currentMode eq 'ACM' and fwVersion startswith '16.' and tags eq 'site-x' or guid in ('guid4')", "fwVersion")
+	require.NoError(t, err)
+
+	devices, err := repo.Get(context.Background(), q, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	require.Equal(t, "guid4", devices[0].GUID)
+	require.Equal(t, "guid1", devices[1].GUID)
+}
+
+func TestDeviceRepo_GetByAttributes(t *testing.T) {
+	t.Parallel()
+
+	dbConn := setupDeviceTable(t)
+	defer dbConn.Close()
+
+	_, err := dbConn.ExecContext(context.Background(), `
+		INSERT INTO device_attributes (name, type, tenant_id) VALUES
+			('owner', 'string', 'tenant1'), ('rack', 'number', 'tenant1'), ('warrantyEnd', 'date', 'tenant1');
+		INSERT INTO devices (guid, tenantid, attributes) VALUES
+			('guid1', 'tenant1', '{"owner":"ops","rack":12,"warrantyEnd":"2026-03-31T00:00:00Z"}'),
+			('guid2', 'tenant1', '{"owner":"ops","rack":3,"warrantyEnd":"2028-03-31T00:00:00Z"}'),
+			('guid3', 'tenant1', '{"owner":"lab","rack":20}'),
+			('guid4', 'tenant1', '');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+
+	q, err := odata.Parse("owner eq 'ops' and rack ge 10 or warrantyEnd gt 2027-01-01", "rack desc")
+	require.NoError(t, err)
+
+	devices, err := repo.Get(context.Background(), q, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	require.Equal(t, "guid1", devices[0].GUID)
+	require.JSONEq(t, `{"owner":"ops","rack":12,"warrantyEnd":"2026-03-31T00:00:00Z"}`, devices[0].Attributes)
+	require.Equal(t, "guid2", devices[1].GUID)
+
+	// the attributes of another tenant are unknown
+	_, err = repo.GetCount(context.Background(), q, "tenant2")
+	require.ErrorAs(t, err, &odata.Error{})
+}
+
 func TestDeviceRepo_GetByID(t *testing.T) {
 	t.Parallel()
 
@@ -559,7 +746,8 @@ func TestDeviceRepo_GetByTags(t *testing.T) {
                     tenantid TEXT NOT NULL,
                     friendlyname TEXT NOT NULL DEFAULT '',
                     dnssuffix TEXT NOT NULL DEFAULT '',
-                    deviceinfo TEXT NOT NULL DEFAULT ''
+                    deviceinfo TEXT NOT NULL DEFAULT '',
+                    deleted_at TEXT NOT NULL DEFAULT ''
                 );
             `)
 			require.NoError(t, err)
@@ -655,7 +843,8 @@ func TestDeviceRepo_Delete(t *testing.T) {
 					username TEXT NOT NULL DEFAULT '',
 					password TEXT NOT NULL DEFAULT '',
 					usetls BOOLEAN NOT NULL DEFAULT FALSE,
-					allowselfsigned BOOLEAN NOT NULL DEFAULT FALSE
+					allowselfsigned BOOLEAN NOT NULL DEFAULT FALSE,
+					deleted_at TEXT NOT NULL DEFAULT ''
 				);
 			`)
 			require.NoError(t, err)
@@ -804,7 +993,9 @@ func TestDeviceRepo_Update(t *testing.T) {
 					password TEXT NOT NULL DEFAULT '',
 					usetls BOOLEAN NOT NULL DEFAULT FALSE,
 					allowselfsigned BOOLEAN NOT NULL DEFAULT FALSE,
-					certhash TEXT NOT NULL DEFAULT ''
+					certhash TEXT NOT NULL DEFAULT '',
+					attributes TEXT NOT NULL DEFAULT '',
+					deleted_at TEXT NOT NULL DEFAULT ''
 				);
 			`)
 			require.NoError(t, err)
@@ -1059,7 +1250,9 @@ func TestDeviceRepo_GetByColumn(t *testing.T) {
                     password TEXT NOT NULL DEFAULT '',
                     usetls BOOLEAN NOT NULL DEFAULT FALSE,
                     allowselfsigned BOOLEAN NOT NULL DEFAULT FALSE,
-					certhash TEXT NOT NULL DEFAULT ''
+					certhash TEXT NOT NULL DEFAULT '',
+					attributes TEXT NOT NULL DEFAULT '',
+					deleted_at TEXT NOT NULL DEFAULT ''
                 );
             `)
 			require.NoError(t, err)
@@ -1088,3 +1281,81 @@ func TestDeviceRepo_GetByColumn(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceRepo_Trash(t *testing.T) {
+	t.Parallel()
+
+	dbConn := setupDeviceTable(t)
+	defer dbConn.Close()
+
+	_, err := dbConn.ExecContext(context.Background(), `
+		INSERT INTO devices (guid, hostname, tenantid, password, certhash, deleted_at) VALUES
+			('guid1', 'host1', 'tenant1', 'encrypted', 'aabb', ''),
+			('guid2', 'host2', 'tenant1', 'encrypted', '', ''),
+			('guid3', 'host3', 'tenant2', '', '', '2020-01-01T00:00:00Z');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+	ctx := context.Background()
+
+	deleted, err := repo.Delete(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	// a device in the trash is gone from every other read and cannot be deleted twice
+	found, err := repo.GetByID(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	count, err := repo.GetCount(ctx, odata.Query{}, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	deleted, err = repo.Delete(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	// the GUID stays taken until the device is purged
+	_, err = repo.Insert(ctx, &entity.Device{GUID: "guid1", TenantID: "tenant1", CertHash: StringPtr("")})
+
+	var notUniqueErr sqldb.NotUniqueError
+
+	require.ErrorAs(t, err, &notUniqueErr)
+	require.Contains(t, notUniqueErr.Console.FriendlyMessage(), "in the trash")
+
+	trash, err := repo.GetDeleted(ctx, 0, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, "guid1", trash[0].GUID)
+	require.NotEmpty(t, trash[0].DeletedAt)
+
+	restored, err := repo.Restore(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, restored)
+
+	found, err = repo.GetByID(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, "encrypted", found.Password)
+	require.Equal(t, "aabb", *found.CertHash)
+
+	// only devices in the trash can be purged
+	purged, err := repo.Purge(ctx, "guid2", "tenant1")
+	require.NoError(t, err)
+	require.False(t, purged)
+
+	_, err = repo.Delete(ctx, "guid2", "tenant1")
+	require.NoError(t, err)
+
+	purged, err = repo.Purge(ctx, "guid2", "tenant1")
+	require.NoError(t, err)
+	require.True(t, purged)
+
+	count, err = repo.PurgeDeleted(ctx, "2021-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	var remaining int
+
+	require.NoError(t, dbConn.QueryRowContext(ctx, "SELECT COUNT(*) FROM devices").Scan(&remaining))
+	require.Equal(t, 1, remaining)
+}
@@ -0,0 +1,287 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// DeviceAttributeRepo keeps the custom attributes defined for the devices of a tenant.
+type DeviceAttributeRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrDeviceAttributeDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("DeviceAttributeRepo")}
+	ErrDeviceAttributeNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("DeviceAttributeRepo")}
+)
+
+// NewDeviceAttributeRepo -.
+func NewDeviceAttributeRepo(database *db.SQL, log logger.Interface) *DeviceAttributeRepo {
+	return &DeviceAttributeRepo{database, log}
+}
+
+// GetCount -.
+func (r *DeviceAttributeRepo) GetCount(ctx context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("device_attributes").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrDeviceAttributeDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrDeviceAttributeDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *DeviceAttributeRepo) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.DeviceAttribute, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	return r.list(ctx, r.selectAttributes(tenantID).Limit(limitedTop).Offset(limitedSkip))
+}
+
+// GetAll returns every attribute defined for the devices of a tenant.
+func (r *DeviceAttributeRepo) GetAll(ctx context.Context, tenantID string) ([]entity.DeviceAttribute, error) {
+	return r.list(ctx, r.selectAttributes(tenantID))
+}
+
+// GetByName -.
+func (r *DeviceAttributeRepo) GetByName(ctx context.Context, name, tenantID string) (*entity.DeviceAttribute, error) {
+	attributes, err := r.list(ctx, r.selectAttributes(tenantID).Where("name = ?", name))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	return &attributes[0], nil
+}
+
+// Delete removes an attribute and its values from the devices of the tenant.
+func (r *DeviceAttributeRepo) Delete(ctx context.Context, name, tenantID string) (bool, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Delete", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	sqlQuery, args, err := r.Builder.
+		Delete("device_attributes").
+		Where("name = ? AND tenant_id = ?", name, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Delete", "r.Builder: ", err)
+	}
+
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Delete", "tx.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("DeviceAttributeRepo - Delete - tx.Exec: %w", err)
+	}
+
+	if result == 0 {
+		return false, nil
+	}
+
+	// names are letters, digits and underscores, which is safe to put in the JSON path
+	values := "(attributes::jsonb - '" + name + "')::text"
+	if r.IsEmbedded {
+		values = "json_remove(attributes, '$." + name + "')"
+	}
+
+	sqlQuery, args, err = r.Builder.
+		Update("devices").
+		Set("attributes", squirrel.Expr(values)).
+		Where("tenantid = ? AND attributes LIKE '{%'", tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Delete", "r.Builder: ", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Delete", "tx.Exec devices", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Delete", "tx.Commit", err)
+	}
+
+	return true, nil
+}
+
+// Update -.
+func (r *DeviceAttributeRepo) Update(ctx context.Context, a *entity.DeviceAttribute) (bool, error) {
+	enumValues, err := json.Marshal(a.EnumValues)
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Update", "json.Marshal", err)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Update("device_attributes").
+		Set("type", a.Type).
+		Set("enum_values", string(enumValues)).
+		Set("description", a.Description).
+		Where("name = ? AND tenant_id = ?", a.Name, a.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Update", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Update", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrDeviceAttributeDatabase.Wrap("Update", "res.RowsAffected", err)
+	}
+
+	return result > 0, nil
+}
+
+// CountValues counts the devices of the tenant, trashed ones included, that have a value for the attribute. When
+// values are given only the devices with one of them are counted.
+func (r *DeviceAttributeRepo) CountValues(ctx context.Context, name, tenantID string, values []string) (int, error) {
+	value := NewDeviceRepo(r.SQL, r.log).jsonColumn("attributes", name)
+
+	builder := r.Builder.
+		Select("COUNT(*)").
+		From("devices").
+		Where("tenantid = ?", tenantID)
+
+	if len(values) > 0 {
+		builder = builder.Where(squirrel.Eq{value: values})
+	} else {
+		builder = builder.Where(value + " IS NOT NULL")
+	}
+
+	sqlQuery, args, err := builder.ToSql()
+	if err != nil {
+		return 0, ErrDeviceAttributeDatabase.Wrap("CountValues", "r.Builder: ", err)
+	}
+
+	var count int
+
+	if err := r.Pool.QueryRowContext(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		return 0, ErrDeviceAttributeDatabase.Wrap("CountValues", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Insert -.
+func (r *DeviceAttributeRepo) Insert(ctx context.Context, a *entity.DeviceAttribute) error {
+	enumValues, err := json.Marshal(a.EnumValues)
+	if err != nil {
+		return ErrDeviceAttributeDatabase.Wrap("Insert", "json.Marshal", err)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Insert("device_attributes").
+		Columns("name", "type", "enum_values", "description", "tenant_id").
+		Values(a.Name, a.Type, string(enumValues), a.Description, a.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrDeviceAttributeDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	if _, err := r.Pool.ExecContext(ctx, sqlQuery, args...); err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrDeviceAttributeNotUnique.Wrap(err.Error())
+		}
+
+		return ErrDeviceAttributeDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *DeviceAttributeRepo) selectAttributes(tenantID string) squirrel.SelectBuilder {
+	return r.Builder.
+		Select("name", "type", "enum_values", "description", "tenant_id").
+		From("device_attributes").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name")
+}
+
+func (r *DeviceAttributeRepo) list(ctx context.Context, builder squirrel.SelectBuilder) ([]entity.DeviceAttribute, error) {
+	sqlQuery, args, err := builder.ToSql()
+	if err != nil {
+		return nil, ErrDeviceAttributeDatabase.Wrap("list", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrDeviceAttributeDatabase.Wrap("list", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	attributes := make([]entity.DeviceAttribute, 0)
+
+	for rows.Next() {
+		var (
+			a          entity.DeviceAttribute
+			enumValues string
+		)
+
+		if err := rows.Scan(&a.Name, &a.Type, &enumValues, &a.Description, &a.TenantID); err != nil {
+			return nil, ErrDeviceAttributeDatabase.Wrap("list", "rows.Scan: ", err)
+		}
+
+		if enumValues != "" {
+			if err := json.Unmarshal([]byte(enumValues), &a.EnumValues); err != nil {
+				return nil, ErrDeviceAttributeDatabase.Wrap("list", "json.Unmarshal", err)
+			}
+		}
+
+		attributes = append(attributes, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrDeviceAttributeDatabase.Wrap("list", "rows.Err", err)
+	}
+
+	return attributes, nil
+}
@@ -0,0 +1,116 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestDeviceAttributeRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE device_attributes (name TEXT NOT NULL, type TEXT NOT NULL, enum_values TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '', tenant_id TEXT NOT NULL, PRIMARY KEY (name, tenant_id));
+		CREATE TABLE devices (guid TEXT NOT NULL, tenantid TEXT NOT NULL, attributes TEXT NOT NULL DEFAULT '');
+		INSERT INTO devices (guid, tenantid, attributes) VALUES
+			('guid1', 'tenant1', '{"location":"datacenter","owner":"ops"}'),
+			('guid2', 'tenant1', ''),
+			('guid3', 'tenant2', '{"location":"branch"}');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceAttributeRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+	location := entity.DeviceAttribute{Name: "location", Type: "enum", EnumValues: []string{"datacenter", "branch"}, TenantID: "tenant1"}
+	owner := entity.DeviceAttribute{Name: "owner", Type: "string", Description: "Team owning the device", TenantID: "tenant1"}
+
+	require.NoError(t, repo.Insert(ctx, &location))
+	require.NoError(t, repo.Insert(ctx, &owner))
+
+	var notUnique sqldb.NotUniqueError
+	require.ErrorAs(t, repo.Insert(ctx, &owner), &notUnique)
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	list, err := repo.Get(ctx, 0, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceAttribute{location, owner}, list)
+
+	count, err = repo.CountValues(ctx, "location", "tenant1", nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	count, err = repo.CountValues(ctx, "location", "tenant1", []string{"branch", "home"})
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	count, err = repo.CountValues(ctx, "location", "tenant1", []string{"datacenter"})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	location.EnumValues = append(location.EnumValues, "home")
+
+	updated, err := repo.Update(ctx, &location)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	found, err := repo.GetByName(ctx, "location", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &location, found)
+
+	deleted, err := repo.Delete(ctx, "location", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	// the values go with the attribute, in its tenant only
+	rows, err := dbConn.QueryContext(ctx, `SELECT attributes FROM devices ORDER BY guid`)
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	var values []string
+
+	for rows.Next() {
+		var v string
+		require.NoError(t, rows.Scan(&v))
+
+		values = append(values, v)
+	}
+
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{`{"owner":"ops"}`, "", `{"location":"branch"}`}, values)
+
+	all, err := repo.GetAll(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceAttribute{owner}, all)
+
+	found, err = repo.GetByName(ctx, "location", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	deleted, err = repo.Delete(ctx, "location", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
@@ -0,0 +1,437 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// DeviceGroupRepo keeps device groups with their static members and subgroups.
+type DeviceGroupRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrDeviceGroupDatabase            = DatabaseError{Console: consoleerrors.CreateConsoleError("DeviceGroupRepo")}
+	ErrDeviceGroupNotUnique           = NotUniqueError{Console: consoleerrors.CreateConsoleError("DeviceGroupRepo")}
+	ErrDeviceGroupForeignKeyViolation = ForeignKeyViolationError{Console: consoleerrors.CreateConsoleError("DeviceGroupRepo")}
+)
+
+// NewDeviceGroupRepo -.
+func NewDeviceGroupRepo(database *db.SQL, log logger.Interface) *DeviceGroupRepo {
+	return &DeviceGroupRepo{database, log}
+}
+
+// GetCount -.
+func (r *DeviceGroupRepo) GetCount(ctx context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("device_groups").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrDeviceGroupDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrDeviceGroupDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *DeviceGroupRepo) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.DeviceGroup, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("name", "description", "rule", "tenant_id").
+		From("device_groups").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	groups := make([]entity.DeviceGroup, 0)
+
+	for rows.Next() {
+		g := entity.DeviceGroup{}
+
+		if err := rows.Scan(&g.Name, &g.Description, &g.Rule, &g.TenantID); err != nil {
+			return nil, ErrDeviceGroupDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		groups = append(groups, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("Get", "rows.Err", err)
+	}
+
+	if err := r.loadMembers(ctx, groups, tenantID); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetByName -.
+func (r *DeviceGroupRepo) GetByName(ctx context.Context, name, tenantID string) (*entity.DeviceGroup, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("name", "description", "rule", "tenant_id").
+		From("device_groups").
+		Where("name = ? AND tenant_id = ?", name, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("GetByName", "r.Builder: ", err)
+	}
+
+	g := entity.DeviceGroup{}
+
+	err = r.Pool.QueryRowContext(ctx, sqlQuery, args...).Scan(&g.Name, &g.Description, &g.Rule, &g.TenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ErrDeviceGroupDatabase.Wrap("GetByName", "row.Scan: ", err)
+	}
+
+	groups := []entity.DeviceGroup{g}
+	if err := r.loadMembers(ctx, groups, tenantID); err != nil {
+		return nil, err
+	}
+
+	return &groups[0], nil
+}
+
+// GetParents returns the names of the groups that have the group as a subgroup.
+func (r *DeviceGroupRepo) GetParents(ctx context.Context, name, tenantID string) ([]string, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("group_name").
+		From("device_group_subgroups").
+		Where("subgroup_name = ? AND tenant_id = ?", name, tenantID).
+		OrderBy("group_name").
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("GetParents", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("GetParents", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	parents := make([]string, 0)
+
+	for rows.Next() {
+		var parent string
+		if err := rows.Scan(&parent); err != nil {
+			return nil, ErrDeviceGroupDatabase.Wrap("GetParents", "rows.Scan: ", err)
+		}
+
+		parents = append(parents, parent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("GetParents", "rows.Err", err)
+	}
+
+	return parents, nil
+}
+
+// Delete removes a group with its static members and the list of its subgroups.
+func (r *DeviceGroupRepo) Delete(ctx context.Context, name, tenantID string) (bool, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrDeviceGroupDatabase.Wrap("Delete", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	if err := r.deleteMembers(ctx, tx, name, tenantID); err != nil {
+		return false, err
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Delete("device_groups").
+		Where("name = ? AND tenant_id = ?", name, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceGroupDatabase.Wrap("Delete", "r.Builder: ", err)
+	}
+
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		if db.CheckForeignKeyViolation(err) {
+			return false, ErrDeviceGroupForeignKeyViolation.Wrap(err.Error())
+		}
+
+		return false, ErrDeviceGroupDatabase.Wrap("Delete", "tx.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("DeviceGroupRepo - Delete - tx.Exec: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, ErrDeviceGroupDatabase.Wrap("Delete", "tx.Commit", err)
+	}
+
+	return result > 0, nil
+}
+
+// Update changes a group and replaces its static members and subgroups.
+func (r *DeviceGroupRepo) Update(ctx context.Context, g *entity.DeviceGroup) (bool, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrDeviceGroupDatabase.Wrap("Update", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	sqlQuery, args, err := r.Builder.
+		Update("device_groups").
+		Set("description", g.Description).
+		Set("rule", g.Rule).
+		Where("name = ? AND tenant_id = ?", g.Name, g.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceGroupDatabase.Wrap("Update", "r.Builder: ", err)
+	}
+
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, ErrDeviceGroupDatabase.Wrap("Update", "tx.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("DeviceGroupRepo - Update - tx.Exec: %w", err)
+	}
+
+	if result == 0 {
+		return false, nil
+	}
+
+	if err := r.deleteMembers(ctx, tx, g.Name, g.TenantID); err != nil {
+		return false, err
+	}
+
+	if err := r.insertMembers(ctx, tx, g); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, ErrDeviceGroupDatabase.Wrap("Update", "tx.Commit", err)
+	}
+
+	return true, nil
+}
+
+// Insert -.
+func (r *DeviceGroupRepo) Insert(ctx context.Context, g *entity.DeviceGroup) error {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return ErrDeviceGroupDatabase.Wrap("Insert", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	sqlQuery, args, err := r.Builder.
+		Insert("device_groups").
+		Columns("name", "description", "rule", "tenant_id").
+		Values(g.Name, g.Description, g.Rule, g.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrDeviceGroupDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrDeviceGroupNotUnique.Wrap(err.Error())
+		}
+
+		return ErrDeviceGroupDatabase.Wrap("Insert", "tx.Exec", err)
+	}
+
+	if err := r.insertMembers(ctx, tx, g); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ErrDeviceGroupDatabase.Wrap("Insert", "tx.Commit", err)
+	}
+
+	return nil
+}
+
+func (r *DeviceGroupRepo) deleteMembers(ctx context.Context, tx *sql.Tx, name, tenantID string) error {
+	for _, table := range []string{"device_group_members", "device_group_subgroups"} {
+		sqlQuery, args, err := r.Builder.
+			Delete(table).
+			Where("group_name = ? AND tenant_id = ?", name, tenantID).
+			ToSql()
+		if err != nil {
+			return ErrDeviceGroupDatabase.Wrap("deleteMembers", "r.Builder: ", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+			return ErrDeviceGroupDatabase.Wrap("deleteMembers", "tx.Exec "+table, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *DeviceGroupRepo) insertMembers(ctx context.Context, tx *sql.Tx, g *entity.DeviceGroup) error {
+	for _, guid := range g.Devices {
+		sqlQuery, args, err := r.Builder.
+			Insert("device_group_members").
+			Columns("group_name", "guid", "tenant_id").
+			Values(g.Name, guid, g.TenantID).
+			ToSql()
+		if err != nil {
+			return ErrDeviceGroupDatabase.Wrap("insertMembers", "r.Builder: ", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+			if db.CheckNotUnique(err) {
+				return ErrDeviceGroupNotUnique.Wrap(err.Error())
+			}
+
+			return ErrDeviceGroupDatabase.Wrap("insertMembers", "tx.Exec", err)
+		}
+	}
+
+	for _, subgroup := range g.Subgroups {
+		sqlQuery, args, err := r.Builder.
+			Insert("device_group_subgroups").
+			Columns("group_name", "subgroup_name", "tenant_id").
+			Values(g.Name, subgroup, g.TenantID).
+			ToSql()
+		if err != nil {
+			return ErrDeviceGroupDatabase.Wrap("insertMembers", "r.Builder: ", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+			if db.CheckNotUnique(err) {
+				return ErrDeviceGroupNotUnique.Wrap(err.Error())
+			}
+
+			if db.CheckForeignKeyViolation(err) {
+				return ErrDeviceGroupForeignKeyViolation.Wrap(err.Error())
+			}
+
+			return ErrDeviceGroupDatabase.Wrap("insertMembers", "tx.Exec", err)
+		}
+	}
+
+	return nil
+}
+
+// loadMembers reads the static members and subgroups of groups.
+func (r *DeviceGroupRepo) loadMembers(ctx context.Context, groups []entity.DeviceGroup, tenantID string) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(groups))
+	for i := range groups {
+		names[i] = groups[i].Name
+	}
+
+	devices, err := r.memberNames(ctx, "device_group_members", "guid", names, tenantID)
+	if err != nil {
+		return err
+	}
+
+	subgroups, err := r.memberNames(ctx, "device_group_subgroups", "subgroup_name", names, tenantID)
+	if err != nil {
+		return err
+	}
+
+	for i := range groups {
+		groups[i].Devices = append(make([]string, 0), devices[groups[i].Name]...)
+		groups[i].Subgroups = append(make([]string, 0), subgroups[groups[i].Name]...)
+	}
+
+	return nil
+}
+
+// memberNames reads column of the rows of table that belong to the named groups, by group.
+func (r *DeviceGroupRepo) memberNames(ctx context.Context, table, column string, names []string, tenantID string) (map[string][]string, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("group_name", column).
+		From(table).
+		Where(squirrel.Eq{"group_name": names, "tenant_id": tenantID}).
+		OrderBy("group_name", column).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("memberNames", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("memberNames", "r.Pool.Query "+table, err)
+	}
+
+	defer rows.Close()
+
+	members := make(map[string][]string, len(names))
+
+	for rows.Next() {
+		var group, member string
+		if err := rows.Scan(&group, &member); err != nil {
+			return nil, ErrDeviceGroupDatabase.Wrap("memberNames", "rows.Scan: ", err)
+		}
+
+		members[group] = append(members[group], member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrDeviceGroupDatabase.Wrap("memberNames", "rows.Err", err)
+	}
+
+	return members, nil
+}
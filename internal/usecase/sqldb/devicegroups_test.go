@@ -0,0 +1,101 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestDeviceGroupRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE device_groups (name TEXT NOT NULL, description TEXT NOT NULL, rule TEXT NOT NULL, tenant_id TEXT NOT NULL, PRIMARY KEY (name, tenant_id));
+		CREATE TABLE device_group_members (group_name TEXT NOT NULL, guid TEXT NOT NULL, tenant_id TEXT NOT NULL, PRIMARY KEY (group_name, guid, tenant_id));
+		CREATE TABLE device_group_subgroups (group_name TEXT NOT NULL, subgroup_name TEXT NOT NULL, tenant_id TEXT NOT NULL,
+			PRIMARY KEY (group_name, subgroup_name, tenant_id));`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceGroupRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+	kiosks := entity.DeviceGroup{Name: "kiosks", Devices: []string{"guid2", "guid1"}, Subgroups: []string{}, TenantID: "tenant1"}
+	acm := entity.DeviceGroup{
+		Name:        "acm-amt16",
+		Description: "ACM devices on AMT 16",
+		Rule:        "currentMode eq 'ACM' and fwVersion startswith '16.'",
+		Devices:     []string{},
+		Subgroups:   []string{"kiosks"},
+		TenantID:    "tenant1",
+	}
+
+	require.NoError(t, repo.Insert(ctx, &kiosks))
+	require.NoError(t, repo.Insert(ctx, &acm))
+
+	var notUnique sqldb.NotUniqueError
+	require.ErrorAs(t, repo.Insert(ctx, &kiosks), &notUnique)
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	kiosks.Devices = []string{"guid1", "guid2"}
+
+	list, err := repo.Get(ctx, 0, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceGroup{acm, kiosks}, list)
+
+	list, err = repo.Get(ctx, 0, 0, "tenant2")
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	parents, err := repo.GetParents(ctx, "kiosks", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"acm-amt16"}, parents)
+
+	kiosks.Devices = []string{"guid3"}
+	kiosks.Description = "lobby kiosks"
+
+	updated, err := repo.Update(ctx, &kiosks)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	found, err := repo.GetByName(ctx, "kiosks", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &kiosks, found)
+
+	deleted, err := repo.Delete(ctx, "acm-amt16", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	parents, err = repo.GetParents(ctx, "kiosks", "tenant1")
+	require.NoError(t, err)
+	require.Empty(t, parents)
+
+	found, err = repo.GetByName(ctx, "acm-amt16", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	updated, err = repo.Update(ctx, &acm)
+	require.NoError(t, err)
+	require.False(t, updated)
+}
@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/Masterminds/squirrel"
+
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // DomainRepo -.
@@ -19,29 +22,42 @@ type DomainRepo struct {
 }
 
 var (
-	ErrDomainDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("DomainRepo")}
-	ErrDomainNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("DomainRepo")}
+	ErrDomainDatabase        = DatabaseError{Console: consoleerrors.CreateConsoleError("DomainRepo")}
+	ErrDomainNotUnique       = NotUniqueError{Console: consoleerrors.CreateConsoleError("DomainRepo")}
+	ErrDomainVersionConflict = VersionConflictError{Console: consoleerrors.CreateConsoleError("DomainRepo")}
 )
 
+// domainFields are the domain properties lists can be filtered and ordered by.
+var domainFields = odata.Fields{
+	"profileName":                   {Column: "name", Type: odata.String},
+	"domainSuffix":                  {Column: "domain_suffix", Type: odata.String},
+	"provisioningCertStorageFormat": {Column: "provisioning_cert_storage_format", Type: odata.String},
+	"expirationDate":                {Column: "expiration_date", Type: odata.Time},
+}
+
 // New -.
 func NewDomainRepo(database *db.SQL, log logger.Interface) *DomainRepo {
 	return &DomainRepo{database, log}
 }
 
 // GetCount -.
-func (r *DomainRepo) GetCount(_ context.Context, tenantID string) (int, error) {
-	sqlQuery, _, err := r.Builder.
+func (r *DomainRepo) GetCount(_ context.Context, q odata.Query, tenantID string) (int, error) {
+	builder, err := domainFields.Where(r.Builder.
 		Select("COUNT(*) OVER() AS total_count").
 		From("domains").
-		Where("tenant_id = ?", tenantID).
-		ToSql()
+		Where("tenant_id = ?", tenantID), q)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return 0, ErrDomainDatabase.Wrap("GetCount", "r.Builder: ", err)
 	}
 
 	var count int
 
-	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, tenantID).Scan(&count)
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
@@ -54,7 +70,7 @@ func (r *DomainRepo) GetCount(_ context.Context, tenantID string) (int, error) {
 }
 
 // Get -.
-func (r *DomainRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.Domain, error) {
+func (r *DomainRepo) Get(_ context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Domain, error) {
 	const defaultTop = 100
 
 	if top == 0 {
@@ -71,7 +87,7 @@ func (r *DomainRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 		limitedSkip = uint64(skip)
 	}
 
-	sqlQuery, _, err := r.Builder.
+	builder, err := domainFields.Where(r.Builder.
 		Select("name",
 			"domain_suffix",
 			"provisioning_cert",
@@ -80,8 +96,17 @@ func (r *DomainRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 			"expiration_date",
 			"tenant_id").
 		From("domains").
-		Where("tenant_id = ?", tenantID).
-		OrderBy("name").
+		Where("tenant_id = ?", tenantID), q)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = domainFields.OrderBy(builder, q, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := builder.
 		Limit(limitedTop).
 		Offset(limitedSkip).
 		ToSql()
@@ -89,7 +114,7 @@ func (r *DomainRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 		return nil, ErrDomainDatabase.Wrap("Get", "r.Builder: ", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, tenantID)
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
 	if err != nil {
 		return nil, ErrDomainDatabase.Wrap("Get", "r.Pool.Query", err)
 	}
@@ -151,7 +176,7 @@ func (r *DomainRepo) GetDomainByDomainSuffix(_ context.Context, domainSuffix, te
 }
 
 // GetByName -.
-func (r *DomainRepo) GetByName(_ context.Context, domainName, tenantID string) (*entity.Domain, error) {
+func (r *DomainRepo) GetByName(ctx context.Context, domainName, tenantID string) (*entity.Domain, error) {
 	sqlQuery, args, err := r.Builder.
 		Select(
 			"name",
@@ -161,6 +186,7 @@ func (r *DomainRepo) GetByName(_ context.Context, domainName, tenantID string) (
 			"provisioning_cert_key",
 			"expiration_date",
 			"tenant_id",
+			"version",
 		).
 		From("domains").
 		Where("LOWER(name) = LOWER(?) AND tenant_id = ?", domainName, tenantID).
@@ -169,11 +195,11 @@ func (r *DomainRepo) GetByName(_ context.Context, domainName, tenantID string) (
 		return nil, ErrDomainDatabase.Wrap("GetByName", "r.Builder: ", err)
 	}
 
-	row := r.Pool.QueryRowContext(context.Background(), sqlQuery, args...)
+	row := r.Conn(ctx).QueryRowContext(ctx, sqlQuery, args...)
 
 	d := entity.Domain{}
 
-	err = row.Scan(&d.ProfileName, &d.DomainSuffix, &d.ProvisioningCert, &d.ProvisioningCertStorageFormat, &d.ProvisioningCertPassword, &d.ExpirationDate, &d.TenantID)
+	err = row.Scan(&d.ProfileName, &d.DomainSuffix, &d.ProvisioningCert, &d.ProvisioningCertStorageFormat, &d.ProvisioningCertPassword, &d.ExpirationDate, &d.TenantID, &d.Version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -186,11 +212,18 @@ func (r *DomainRepo) GetByName(_ context.Context, domainName, tenantID string) (
 }
 
 // Delete -.
-func (r *DomainRepo) Delete(_ context.Context, domainName, tenantID string) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *DomainRepo) Delete(_ context.Context, domainName, version, tenantID string) (bool, error) {
+	key := squirrel.Expr("LOWER(name) = LOWER(?) AND tenant_id = ?", domainName, tenantID)
+
+	builder := r.Builder.
 		Delete("domains").
-		Where("LOWER(name) = LOWER(?) AND tenant_id = ?", domainName, tenantID).
-		ToSql()
+		Where(key)
+
+	if version != "" {
+		builder = builder.Where(atVersion(version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrDomainDatabase.Wrap("Delete", "r.Builder: ", err)
 	}
@@ -205,12 +238,18 @@ func (r *DomainRepo) Delete(_ context.Context, domainName, tenantID string) (boo
 		return false, fmt.Errorf("DomainRepo - Delete - r.Pool.Exec: %w", err)
 	}
 
+	if result == 0 && version != "" {
+		return false, r.versionConflict("Delete", r.Pool, key, domainName)
+	}
+
 	return result > 0, nil
 }
 
 // Update -.
-func (r *DomainRepo) Update(_ context.Context, d *entity.Domain) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *DomainRepo) Update(ctx context.Context, d *entity.Domain) (bool, error) {
+	key := squirrel.Expr("name = ? AND tenant_id = ?", d.ProfileName, d.TenantID)
+
+	builder := r.Builder.
 		Update("domains").
 		Set("name", d.ProfileName).
 		Set("domain_suffix", d.DomainSuffix).
@@ -218,13 +257,19 @@ func (r *DomainRepo) Update(_ context.Context, d *entity.Domain) (bool, error) {
 		Set("provisioning_cert_storage_format", d.ProvisioningCertStorageFormat).
 		Set("provisioning_cert_key", d.ProvisioningCertPassword).
 		Set("expiration_date", d.ExpirationDate).
-		Where("name = ? AND tenant_id = ?", d.ProfileName, d.TenantID).
-		ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		Where(key)
+
+	if d.Version != "" {
+		builder = builder.Where(atVersion(d.Version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrDomainDatabase.Wrap("Update", "r.Builder: ", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		if db.CheckNotUnique(err) {
 			return false, ErrProfileNotUnique.Wrap(err.Error())
@@ -238,18 +283,37 @@ func (r *DomainRepo) Update(_ context.Context, d *entity.Domain) (bool, error) {
 		return false, fmt.Errorf("DomainRepo - Update - r.Pool.Exec: %w", err)
 	}
 
+	if result == 0 && d.Version != "" {
+		return false, r.versionConflict("Update", r.Conn(ctx), key, d.ProfileName)
+	}
+
 	return result > 0, nil
 }
 
+// versionConflict is the error of a write conditioned on the version of a domain that touched nothing: a
+// conflict while the domain is still there, none when it is gone.
+func (r *DomainRepo) versionConflict(function string, q querier, key squirrel.Sqlizer, name string) error {
+	changed, err := rowExists(q, r.Builder, "domains", key)
+	if err != nil {
+		return ErrDomainDatabase.Wrap(function, "rowExists", err)
+	}
+
+	if changed {
+		return ErrDomainVersionConflict.Wrap(name)
+	}
+
+	return nil
+}
+
 // Insert -.
-func (r *DomainRepo) Insert(_ context.Context, d *entity.Domain) (string, error) {
+func (r *DomainRepo) Insert(ctx context.Context, d *entity.Domain) (string, error) {
 	insertBuilder := r.Builder.
 		Insert("domains").
 		Columns("name", "domain_suffix", "provisioning_cert", "provisioning_cert_storage_format", "provisioning_cert_key", "expiration_date", "tenant_id").
 		Values(d.ProfileName, d.DomainSuffix, d.ProvisioningCert, d.ProvisioningCertStorageFormat, d.ProvisioningCertPassword, d.ExpirationDate, d.TenantID)
 
 	if !r.IsEmbedded {
-		insertBuilder = insertBuilder.Suffix("RETURNING xmin::text")
+		insertBuilder = insertBuilder.Suffix("RETURNING version::text")
 	}
 
 	sqlQuery, args, err := insertBuilder.ToSql()
@@ -260,9 +324,9 @@ func (r *DomainRepo) Insert(_ context.Context, d *entity.Domain) (string, error)
 	version := ""
 
 	if r.IsEmbedded {
-		_, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+		_, err = r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	} else {
-		err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&version)
+		err = r.Conn(ctx).QueryRowContext(ctx, sqlQuery, args...).Scan(&version)
 	}
 
 	if err != nil {
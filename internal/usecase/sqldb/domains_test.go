@@ -15,6 +15,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 func setupDomainTable(t *testing.T) *sql.DB {
@@ -89,7 +90,7 @@ func TestDomainRepo_GetCount(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewDomainRepo(sqlConfig, mockLog)
 
-			count, err := repo.GetCount(context.Background(), tc.tenantID)
+			count, err := repo.GetCount(context.Background(), odata.Query{}, tc.tenantID)
 
 			if err == nil && tc.err != nil {
 				t.Errorf("Expected error of type %T, got nil", tc.err)
@@ -175,7 +176,7 @@ func TestDomainRepo_Get(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewDomainRepo(sqlConfig, mockLog)
 
-			domains, err := repo.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			domains, err := repo.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			if tc.err != nil {
 				assert.Error(t, err)
@@ -332,6 +333,7 @@ func TestDomainRepo_GetByName(t *testing.T) {
 				ProvisioningCertPassword:      "password",
 				ExpirationDate:                "2024-12-31",
 				TenantID:                      "tenant1",
+				Version:                       "1",
 			},
 			expectError: false,
 		},
@@ -450,7 +452,7 @@ func TestDomainRepo_Delete(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewDomainRepo(sqlConfig, mockLog)
 
-			deleted, err := repo.Delete(context.Background(), tc.domainName, tc.tenantID)
+			deleted, err := repo.Delete(context.Background(), tc.domainName, "", tc.tenantID)
 
 			if err == nil && tc.err != nil {
 				t.Errorf("Expected error of type %T, got nil", tc.err)
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/Masterminds/squirrel"
+
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // IEEE8021xRepo -.
@@ -18,10 +21,19 @@ type IEEE8021xRepo struct {
 }
 
 var (
-	ErrIEEE8021xDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("IEEE8021xRepo")}
-	ErrIEEE8021xNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("IEEE8021xRepo")}
+	ErrIEEE8021xDatabase        = DatabaseError{Console: consoleerrors.CreateConsoleError("IEEE8021xRepo")}
+	ErrIEEE8021xNotUnique       = NotUniqueError{Console: consoleerrors.CreateConsoleError("IEEE8021xRepo")}
+	ErrIEEE8021xVersionConflict = VersionConflictError{Console: consoleerrors.CreateConsoleError("IEEE8021xRepo")}
 )
 
+// ieee8021xFields are the 802.1X config properties lists can be filtered and ordered by.
+var ieee8021xFields = odata.Fields{
+	"profileName":            {Column: "profile_name", Type: odata.String},
+	"authenticationProtocol": {Column: "auth_protocol", Type: odata.Number},
+	"pxeTimeout":             {Column: "pxe_timeout", Type: odata.Number},
+	"wiredInterface":         {Column: "wired_interface", Type: odata.Bool},
+}
+
 // New -.
 func NewIEEE8021xRepo(database *db.SQL, log logger.Interface) *IEEE8021xRepo {
 	return &IEEE8021xRepo{database, log}
@@ -53,19 +65,23 @@ func (r *IEEE8021xRepo) CheckProfileExists(_ context.Context, profileName, tenan
 }
 
 // GetCount -.
-func (r *IEEE8021xRepo) GetCount(_ context.Context, tenantID string) (int, error) {
-	sqlQuery, _, err := r.Builder.
+func (r *IEEE8021xRepo) GetCount(_ context.Context, q odata.Query, tenantID string) (int, error) {
+	builder, err := ieee8021xFields.Where(r.Builder.
 		Select("COUNT(*) OVER() AS total_count").
 		From("ieee8021xconfigs").
-		Where("tenant_id = ?", tenantID).
-		ToSql()
+		Where("tenant_id = ?", tenantID), q)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return 0, ErrIEEE8021xDatabase.Wrap("GetCount", "r.Builder: ", err)
 	}
 
 	var count int
 
-	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, tenantID).Scan(&count)
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
@@ -78,7 +94,7 @@ func (r *IEEE8021xRepo) GetCount(_ context.Context, tenantID string) (int, error
 }
 
 // Get -.
-func (r *IEEE8021xRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.IEEE8021xConfig, error) {
+func (r *IEEE8021xRepo) Get(_ context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.IEEE8021xConfig, error) {
 	const defaultTop = 100
 
 	if top == 0 {
@@ -95,7 +111,7 @@ func (r *IEEE8021xRepo) Get(_ context.Context, top, skip int, tenantID string) (
 		limitedSkip = uint64(skip)
 	}
 
-	sqlQuery, _, err := r.Builder.
+	builder, err := ieee8021xFields.Where(r.Builder.
 		Select("profile_name",
 			"auth_Protocol",
 			"pxe_timeout",
@@ -103,7 +119,17 @@ func (r *IEEE8021xRepo) Get(_ context.Context, top, skip int, tenantID string) (
 			"tenant_id",
 		).
 		From("ieee8021xconfigs").
-		Where("tenant_id = ?", tenantID).
+		Where("tenant_id = ?", tenantID), q)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = ieee8021xFields.OrderBy(builder, q, "profile_name")
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := builder.
 		Limit(limitedTop).
 		Offset(limitedSkip).
 		ToSql()
@@ -111,7 +137,7 @@ func (r *IEEE8021xRepo) Get(_ context.Context, top, skip int, tenantID string) (
 		return nil, ErrIEEE8021xDatabase.Wrap("Get", "r.Builder: ", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, tenantID)
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
 	if err != nil {
 		return nil, ErrIEEE8021xDatabase.Wrap("Get", "r.Pool.Query", err)
 	}
@@ -139,13 +165,14 @@ func (r *IEEE8021xRepo) Get(_ context.Context, top, skip int, tenantID string) (
 }
 
 // GetByName -.
-func (r *IEEE8021xRepo) GetByName(_ context.Context, profileName, tenantID string) (*entity.IEEE8021xConfig, error) {
+func (r *IEEE8021xRepo) GetByName(ctx context.Context, profileName, tenantID string) (*entity.IEEE8021xConfig, error) {
 	sqlQuery, _, err := r.Builder.
 		Select("profile_name",
 			"auth_Protocol",
 			"pxe_timeout",
 			"wired_interface",
 			"tenant_id",
+			"version",
 		).
 		From("ieee8021xconfigs").
 		Where("profile_name = ? and tenant_id = ?", profileName, tenantID).
@@ -154,7 +181,7 @@ func (r *IEEE8021xRepo) GetByName(_ context.Context, profileName, tenantID strin
 		return nil, ErrIEEE8021xDatabase.Wrap("Get", "r.Builder: ", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, profileName, tenantID)
+	rows, err := r.Conn(ctx).QueryContext(ctx, sqlQuery, profileName, tenantID)
 	if err != nil {
 		return nil, ErrIEEE8021xDatabase.Wrap("Get", "r.Pool.Query", err)
 	}
@@ -170,7 +197,7 @@ func (r *IEEE8021xRepo) GetByName(_ context.Context, profileName, tenantID strin
 	for rows.Next() {
 		p := &entity.IEEE8021xConfig{}
 
-		err = rows.Scan(&p.ProfileName, &p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface, &p.TenantID)
+		err = rows.Scan(&p.ProfileName, &p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface, &p.TenantID, &p.Version)
 		if err != nil {
 			return p, ErrIEEE8021xDatabase.Wrap("Get", "rows.Scan: ", err)
 		}
@@ -186,18 +213,37 @@ func (r *IEEE8021xRepo) GetByName(_ context.Context, profileName, tenantID strin
 }
 
 // Delete -.
-func (r *IEEE8021xRepo) Delete(_ context.Context, profileName, tenantID string) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *IEEE8021xRepo) Delete(ctx context.Context, profileName, version, tenantID string) (bool, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrIEEE8021xDatabase.Wrap("Delete", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	// profiles in the trash must not keep the config from being deleted
+	if err := detachTrashed(ctx, tx, r.Builder, "ieee8021x_profile_name", profileName, tenantID); err != nil {
+		return false, ErrIEEE8021xDatabase.Wrap("Delete", "detachTrashed", err)
+	}
+
+	key := squirrel.Expr("profile_name = ? AND tenant_id = ?", profileName, tenantID)
+
+	builder := r.Builder.
 		Delete("ieee8021xconfigs").
-		Where("profile_name = ? AND tenant_id = ?", profileName, tenantID).
-		ToSql()
+		Where(key)
+
+	if version != "" {
+		builder = builder.Where(atVersion(version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrIEEE8021xDatabase.Wrap("Delete", "r.Builder: ", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
-		return false, ErrIEEE8021xDatabase.Wrap("Delete", "r.Pool.Exec", err)
+		return false, ErrIEEE8021xDatabase.Wrap("Delete", "tx.Exec", err)
 	}
 
 	rowsAffected, err := res.RowsAffected()
@@ -205,23 +251,39 @@ func (r *IEEE8021xRepo) Delete(_ context.Context, profileName, tenantID string)
 		return false, ErrIEEE8021xDatabase.Wrap("Delete", "res.RowsAffected", err)
 	}
 
+	if rowsAffected == 0 && version != "" {
+		return false, r.versionConflict("Delete", tx, key, profileName)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, ErrIEEE8021xDatabase.Wrap("Delete", "tx.Commit", err)
+	}
+
 	return rowsAffected > 0, nil
 }
 
 // Update -.
-func (r *IEEE8021xRepo) Update(_ context.Context, p *entity.IEEE8021xConfig) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *IEEE8021xRepo) Update(ctx context.Context, p *entity.IEEE8021xConfig) (bool, error) {
+	key := squirrel.Expr("profile_name = ? AND tenant_id = ?", p.ProfileName, p.TenantID)
+
+	builder := r.Builder.
 		Update("ieee8021xconfigs").
 		Set("auth_protocol", p.AuthenticationProtocol).
 		Set("pxe_timeout", p.PXETimeout).
 		Set("wired_interface", p.WiredInterface).
-		Where("profile_name = ? AND tenant_id = ?", p.ProfileName, p.TenantID).
-		ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		Where(key)
+
+	if p.Version != "" {
+		builder = builder.Where(atVersion(p.Version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrIEEE8021xDatabase.Wrap("Update", "r.Builder: ", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return false, ErrIEEE8021xDatabase.Wrap("Update", "r.Pool.Exec", err)
 	}
@@ -231,18 +293,37 @@ func (r *IEEE8021xRepo) Update(_ context.Context, p *entity.IEEE8021xConfig) (bo
 		return false, ErrIEEE8021xDatabase.Wrap("Update", "res.RowsAffected", err)
 	}
 
+	if rowsAffected == 0 && p.Version != "" {
+		return false, r.versionConflict("Update", r.Conn(ctx), key, p.ProfileName)
+	}
+
 	return rowsAffected > 0, nil
 }
 
+// versionConflict is the error of a write conditioned on the version of a configuration that touched nothing: a
+// conflict while the configuration is still there, none when it is gone.
+func (r *IEEE8021xRepo) versionConflict(function string, q querier, key squirrel.Sqlizer, name string) error {
+	changed, err := rowExists(q, r.Builder, "ieee8021xconfigs", key)
+	if err != nil {
+		return ErrIEEE8021xDatabase.Wrap(function, "rowExists", err)
+	}
+
+	if changed {
+		return ErrIEEE8021xVersionConflict.Wrap(name)
+	}
+
+	return nil
+}
+
 // Insert -.
-func (r *IEEE8021xRepo) Insert(_ context.Context, p *entity.IEEE8021xConfig) (string, error) {
+func (r *IEEE8021xRepo) Insert(ctx context.Context, p *entity.IEEE8021xConfig) (string, error) {
 	insertBuilder := r.Builder.
 		Insert("ieee8021xconfigs").
 		Columns("profile_name", "auth_protocol", "pxe_timeout", "wired_interface", "tenant_id").
 		Values(p.ProfileName, p.AuthenticationProtocol, p.PXETimeout, p.WiredInterface, p.TenantID)
 
 	if !r.IsEmbedded {
-		insertBuilder = insertBuilder.Suffix("RETURNING xmin::text")
+		insertBuilder = insertBuilder.Suffix("RETURNING version::text")
 	}
 
 	sqlQuery, args, err := insertBuilder.ToSql()
@@ -253,9 +334,9 @@ func (r *IEEE8021xRepo) Insert(_ context.Context, p *entity.IEEE8021xConfig) (st
 	version := ""
 
 	if r.IsEmbedded {
-		_, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+		_, err = r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	} else {
-		err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&version)
+		err = r.Conn(ctx).QueryRowContext(ctx, sqlQuery, args...).Scan(&version)
 	}
 
 	if err != nil {
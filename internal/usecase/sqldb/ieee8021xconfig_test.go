@@ -15,6 +15,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 func setupIEEE8021xTable(t *testing.T) *sql.DB {
@@ -171,7 +172,7 @@ func TestIEEE8021xRepo_GetCount(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewIEEE8021xRepo(sqlConfig, mockLog)
 
-			count, err := repo.GetCount(context.Background(), tc.tenantID)
+			count, err := repo.GetCount(context.Background(), odata.Query{}, tc.tenantID)
 
 			if err == nil && tc.err != nil {
 				t.Errorf("Expected error of type %T, got nil", tc.err)
@@ -335,7 +336,7 @@ func TestIEEE8021xRepo_Get(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewIEEE8021xRepo(sqlConfig, mockLog)
 
-			configs, err := repo.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			configs, err := repo.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			GetIEEEHelper(t, tc, configs, err)
 		})
@@ -494,7 +495,7 @@ func TestIEEE8021xRepo_Delete(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewIEEE8021xRepo(sqlConfig, mockLog)
 
-			deleted, err := repo.Delete(context.Background(), tc.profileName, tc.tenantID)
+			deleted, err := repo.Delete(context.Background(), tc.profileName, "", tc.tenantID)
 
 			if (err != nil) != tc.expectError {
 				t.Errorf("Expected error status %v, got %v", tc.expectError, err != nil)
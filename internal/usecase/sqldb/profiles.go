@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
 
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // ProfileRepo -.
@@ -18,29 +22,58 @@ type ProfileRepo struct {
 }
 
 var (
-	ErrProfileDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("ProfileRepo")}
-	ErrProfileNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("ProfileRepo")}
+	ErrProfileDatabase        = DatabaseError{Console: consoleerrors.CreateConsoleError("ProfileRepo")}
+	ErrProfileNotUnique       = NotUniqueError{Console: consoleerrors.CreateConsoleError("ProfileRepo")}
+	ErrProfileVersionConflict = VersionConflictError{Console: consoleerrors.CreateConsoleError("ProfileRepo")}
 )
 
+// profileFields are the profile properties lists can be filtered and ordered by.
+var profileFields = odata.Fields{
+	"profileName":                {Column: "p.profile_name", Type: odata.String},
+	"activation":                 {Column: "p.activation", Type: odata.String},
+	"generateRandomPassword":     {Column: "p.generate_random_password", Type: odata.Bool},
+	"ciraConfigName":             {Column: "p.cira_config_name", Type: odata.String},
+	"generateRandomMEBxPassword": {Column: "p.generate_random_mebx_password", Type: odata.Bool},
+	"tags":                       {Column: "p.tags", Type: odata.List},
+	"dhcpEnabled":                {Column: "p.dhcp_enabled", Type: odata.Bool},
+	"tlsMode":                    {Column: "p.tls_mode", Type: odata.Number},
+	"userConsent":                {Column: "p.user_consent", Type: odata.String},
+	"iderEnabled":                {Column: "p.ider_enabled", Type: odata.Bool},
+	"kvmEnabled":                 {Column: "p.kvm_enabled", Type: odata.Bool},
+	"solEnabled":                 {Column: "p.sol_enabled", Type: odata.Bool},
+	"tlsSigningAuthority":        {Column: "p.tls_signing_authority", Type: odata.String},
+	"ipSyncEnabled":              {Column: "p.ip_sync_enabled", Type: odata.Bool},
+	"localWifiSyncEnabled":       {Column: "p.local_wifi_sync_enabled", Type: odata.Bool},
+	"ieee8021xProfileName":       {Column: "p.ieee8021x_profile_name", Type: odata.String},
+	"uefiWifiSyncEnabled":        {Column: "p.uefi_wifi_sync_enabled", Type: odata.Bool},
+	"templateName":               {Column: "p.template_name", Type: odata.String},
+	"authenticationProtocol":     {Column: "e.auth_protocol", Type: odata.Number},
+}
+
 // New -.
 func NewProfileRepo(database *db.SQL, log logger.Interface) *ProfileRepo {
 	return &ProfileRepo{database, log}
 }
 
 // GetCount -.
-func (r *ProfileRepo) GetCount(_ context.Context, tenantID string) (int, error) {
-	sqlQuery, _, err := r.Builder.
+func (r *ProfileRepo) GetCount(_ context.Context, q odata.Query, tenantID string) (int, error) {
+	builder, err := profileFields.Where(r.Builder.
 		Select("COUNT(*) OVER() AS total_count").
-		From("profiles").
-		Where("tenant_id = ?", tenantID).
-		ToSql()
+		From("profiles p").
+		LeftJoin("ieee8021xconfigs e ON p.ieee8021x_profile_name = e.profile_name AND p.tenant_id = e.tenant_id").
+		Where("p.tenant_id = ? AND p.deleted_at = ''", tenantID), q)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return 0, ErrProfileDatabase.Wrap("GetCount", "r.Builder", err)
 	}
 
 	var count int
 
-	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, tenantID).Scan(&count)
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
@@ -55,7 +88,7 @@ func (r *ProfileRepo) GetCount(_ context.Context, tenantID string) (int, error)
 // Get -.
 //
 //nolint:funlen // 2 lines ain't enough
-func (r *ProfileRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.Profile, error) {
+func (r *ProfileRepo) Get(_ context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.Profile, error) {
 	const defaultTop = 100
 
 	if top == 0 {
@@ -72,7 +105,7 @@ func (r *ProfileRepo) Get(_ context.Context, top, skip int, tenantID string) ([]
 		limitedSkip = uint64(skip)
 	}
 
-	sqlQuery, _, err := r.Builder.
+	builder, err := profileFields.Where(r.Builder.
 		Select(
 			"p.profile_name",
 			"p.activation",
@@ -92,6 +125,8 @@ func (r *ProfileRepo) Get(_ context.Context, top, skip int, tenantID string) ([]
 			"p.local_wifi_sync_enabled",
 			"p.ieee8021x_profile_name",
 			"p.uefi_wifi_sync_enabled",
+			"p.template_name",
+			"p.template_overrides",
 			"e.auth_protocol",
 			"e.pxe_timeout",
 			"e.wired_interface",
@@ -99,7 +134,7 @@ func (r *ProfileRepo) Get(_ context.Context, top, skip int, tenantID string) ([]
 		From("profiles p").
 		LeftJoin("profiles_wirelessconfigs pw ON pw.profile_name = p.profile_name AND pw.tenant_id = p.tenant_id").
 		LeftJoin("ieee8021xconfigs e ON p.ieee8021x_profile_name = e.profile_name AND p.tenant_id = e.tenant_id").
-		Where("p.tenant_id = ?", tenantID).
+		Where("p.tenant_id = ? AND p.deleted_at = ''", tenantID).
 		GroupBy(
 			"p.profile_name",
 			"p.activation",
@@ -119,18 +154,29 @@ func (r *ProfileRepo) Get(_ context.Context, top, skip int, tenantID string) ([]
 			"p.local_wifi_sync_enabled",
 			"p.ieee8021x_profile_name",
 			"p.uefi_wifi_sync_enabled",
+			"p.template_name",
+			"p.template_overrides",
 			"e.auth_protocol",
 			"e.pxe_timeout",
 			"e.wired_interface",
-		).
-		OrderBy("p.profile_name").
+		), q)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = profileFields.OrderBy(builder, q, "p.profile_name")
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := builder.
 		Limit(limitedTop).Offset(limitedSkip).
 		ToSql()
 	if err != nil {
 		return nil, ErrProfileDatabase.Wrap("Get", "r.Builder", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, tenantID)
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
 	if err != nil {
 		return nil, ErrProfileDatabase.Wrap("Get", "r.Pool.Query", err)
 	}
@@ -149,7 +195,8 @@ func (r *ProfileRepo) Get(_ context.Context, top, skip int, tenantID string) ([]
 		err = rows.Scan(&p.ProfileName, &p.Activation, &p.GenerateRandomPassword, &p.CIRAConfigName,
 			&p.GenerateRandomMEBxPassword, &p.Tags, &p.DHCPEnabled, &p.TenantID, &p.TLSMode,
 			&p.UserConsent, &p.IDEREnabled, &p.KVMEnabled, &p.SOLEnabled, &p.TLSSigningAuthority,
-			&p.IPSyncEnabled, &p.LocalWiFiSyncEnabled, &p.IEEE8021xProfileName, &p.UEFIWiFiSyncEnabled, &p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface)
+			&p.IPSyncEnabled, &p.LocalWiFiSyncEnabled, &p.IEEE8021xProfileName, &p.UEFIWiFiSyncEnabled, &p.TemplateName, &p.TemplateOverrides,
+			&p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface)
 		if err != nil {
 			return nil, ErrProfileDatabase.Wrap("Get", "rows.Scan", err)
 		}
@@ -162,8 +209,8 @@ func (r *ProfileRepo) Get(_ context.Context, top, skip int, tenantID string) ([]
 
 // GetByName -.
 
-func (r *ProfileRepo) GetByName(_ context.Context, profileName, tenantID string) (*entity.Profile, error) {
-	sqlQuery, _, err := r.Builder.
+func (r *ProfileRepo) GetByName(ctx context.Context, profileName, tenantID string) (*entity.Profile, error) {
+	sqlQuery, args, err := r.Builder.
 		Select(
 			"p.profile_name",
 			"p.activation",
@@ -185,19 +232,22 @@ func (r *ProfileRepo) GetByName(_ context.Context, profileName, tenantID string)
 			"p.local_wifi_sync_enabled",
 			"p.ieee8021x_profile_name",
 			"p.uefi_wifi_sync_enabled",
+			"p.template_name",
+			"p.template_overrides",
+			"p.version",
 			"e.auth_protocol",
 			"e.pxe_timeout",
 			"e.wired_interface",
 		).
 		From("profiles p").
 		LeftJoin("ieee8021xconfigs e ON p.ieee8021x_profile_name = e.profile_name AND p.tenant_id = e.tenant_id").
-		Where("p.profile_name = ? and p.tenant_id = ?", profileName, tenantID).
+		Where("p.profile_name = ? and p.tenant_id = ? AND p.deleted_at = ''", profileName, tenantID).
 		ToSql()
 	if err != nil {
 		return nil, ErrProfileDatabase.Wrap("GetByName", "r.Builder", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, profileName, tenantID)
+	rows, err := r.Conn(ctx).QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, ErrProfileDatabase.Wrap("GetByName", "r.Pool.Query", err)
 	}
@@ -217,7 +267,8 @@ func (r *ProfileRepo) GetByName(_ context.Context, profileName, tenantID string)
 			&p.CIRAConfigName,
 			&p.GenerateRandomMEBxPassword, &p.Tags, &p.DHCPEnabled, &p.TenantID, &p.TLSMode,
 			&p.UserConsent, &p.IDEREnabled, &p.KVMEnabled, &p.SOLEnabled, &p.TLSSigningAuthority,
-			&p.IPSyncEnabled, &p.LocalWiFiSyncEnabled, &p.IEEE8021xProfileName, &p.UEFIWiFiSyncEnabled, &p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface)
+			&p.IPSyncEnabled, &p.LocalWiFiSyncEnabled, &p.IEEE8021xProfileName, &p.UEFIWiFiSyncEnabled, &p.TemplateName, &p.TemplateOverrides,
+			&p.Version, &p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface)
 		if err != nil {
 			return p, ErrProfileDatabase.Wrap("GetByName", "rows.Scan", err)
 		}
@@ -232,13 +283,59 @@ func (r *ProfileRepo) GetByName(_ context.Context, profileName, tenantID string)
 	return profiles[0], nil
 }
 
-// Delete -.
-
-func (r *ProfileRepo) Delete(_ context.Context, profileName, tenantID string) (bool, error) {
+// GetByTemplate returns the names of the profiles derived from a template.
+func (r *ProfileRepo) GetByTemplate(_ context.Context, templateName, tenantID string) ([]string, error) {
 	sqlQuery, args, err := r.Builder.
-		Delete("profiles").
-		Where("profile_name = ? AND tenant_id = ?", profileName, tenantID).
+		Select("profile_name").
+		From("profiles").
+		Where("template_name = ? AND tenant_id = ? AND deleted_at = ''", templateName, tenantID).
+		OrderBy("profile_name").
 		ToSql()
+	if err != nil {
+		return nil, ErrProfileDatabase.Wrap("GetByTemplate", "r.Builder", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrProfileDatabase.Wrap("GetByTemplate", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrProfileDatabase.Wrap("GetByTemplate", "rows.Err", rows.Err())
+	}
+
+	names := make([]string, 0)
+
+	for rows.Next() {
+		var name string
+
+		if err = rows.Scan(&name); err != nil {
+			return nil, ErrProfileDatabase.Wrap("GetByTemplate", "rows.Scan", err)
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// Delete moves a profile to the trash. Its wireless configurations stay linked so that a restore brings them back.
+// A version other than blank only moves it while it is still at that version.
+func (r *ProfileRepo) Delete(_ context.Context, profileName, version, tenantID string) (bool, error) {
+	key := squirrel.Expr("profile_name = ? AND tenant_id = ? AND deleted_at = ''", profileName, tenantID)
+
+	builder := r.Builder.
+		Update("profiles").
+		Set("deleted_at", time.Now().UTC().Format(time.RFC3339)).
+		Where(key)
+
+	if version != "" {
+		builder = builder.Where(atVersion(version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrProfileDatabase.Wrap("Delete", "r.Builder", err)
 	}
@@ -253,13 +350,226 @@ func (r *ProfileRepo) Delete(_ context.Context, profileName, tenantID string) (b
 		return false, ErrProfileDatabase.Wrap("Delete", "res.RowsAffected", err)
 	}
 
+	if rowsAffected == 0 && version != "" {
+		return false, r.versionConflict("Delete", r.Pool, key, profileName)
+	}
+
 	return rowsAffected > 0, nil
 }
 
-// Update -.
+// GetDeleted lists the profiles in the trash, the most recently deleted first.
+func (r *ProfileRepo) GetDeleted(_ context.Context, top, skip int, tenantID string) ([]entity.Profile, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
 
-func (r *ProfileRepo) Update(_ context.Context, p *entity.Profile) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("profile_name", "activation", "cira_config_name", "tags", "tls_mode", "deleted_at").
+		From("profiles").
+		Where("tenant_id = ? AND deleted_at <> ''", tenantID).
+		OrderBy("deleted_at DESC", "profile_name").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrProfileDatabase.Wrap("GetDeleted", "r.Builder", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrProfileDatabase.Wrap("GetDeleted", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	profiles := make([]entity.Profile, 0)
+
+	for rows.Next() {
+		p := entity.Profile{TenantID: tenantID}
+
+		if err := rows.Scan(&p.ProfileName, &p.Activation, &p.CIRAConfigName, &p.Tags, &p.TLSMode, &p.DeletedAt); err != nil {
+			return nil, ErrProfileDatabase.Wrap("GetDeleted", "rows.Scan", err)
+		}
+
+		profiles = append(profiles, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrProfileDatabase.Wrap("GetDeleted", "rows.Err", err)
+	}
+
+	return profiles, nil
+}
+
+// Restore takes a profile out of the trash.
+func (r *ProfileRepo) Restore(_ context.Context, profileName, tenantID string) (bool, error) {
 	sqlQuery, args, err := r.Builder.
+		Update("profiles").
+		Set("deleted_at", "").
+		Where("profile_name = ? AND tenant_id = ? AND deleted_at <> ''", profileName, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Restore", "r.Builder", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Restore", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Restore", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Purge removes a profile in the trash for good, with its passwords and wireless configuration links.
+func (r *ProfileRepo) Purge(ctx context.Context, profileName, tenantID string) (bool, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Purge", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	sqlQuery, args, err := r.Builder.
+		Delete("profiles_wirelessconfigs").
+		Where(squirrel.Expr("profile_name = ? AND tenant_id = ? AND EXISTS (SELECT 1 FROM profiles p "+
+			"WHERE p.profile_name = profiles_wirelessconfigs.profile_name AND p.tenant_id = profiles_wirelessconfigs.tenant_id "+
+			"AND p.deleted_at <> '')", profileName, tenantID)).
+		ToSql()
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Purge", "r.Builder", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return false, ErrProfileDatabase.Wrap("Purge", "tx.Exec wirelessconfigs", err)
+	}
+
+	sqlQuery, args, err = r.Builder.
+		Delete("profiles").
+		Where("profile_name = ? AND tenant_id = ? AND deleted_at <> ''", profileName, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Purge", "r.Builder", err)
+	}
+
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Purge", "tx.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrProfileDatabase.Wrap("Purge", "res.RowsAffected", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, ErrProfileDatabase.Wrap("Purge", "tx.Commit", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// PurgeDeleted removes the profiles of every tenant that were deleted up to before, an RFC 3339 timestamp.
+func (r *ProfileRepo) PurgeDeleted(ctx context.Context, before string) (int, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, ErrProfileDatabase.Wrap("PurgeDeleted", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	sqlQuery, args, err := r.Builder.
+		Delete("profiles_wirelessconfigs").
+		Where(squirrel.Expr("EXISTS (SELECT 1 FROM profiles p "+
+			"WHERE p.profile_name = profiles_wirelessconfigs.profile_name AND p.tenant_id = profiles_wirelessconfigs.tenant_id "+
+			"AND p.deleted_at <> '' AND p.deleted_at <= ?)", before)).
+		ToSql()
+	if err != nil {
+		return 0, ErrProfileDatabase.Wrap("PurgeDeleted", "r.Builder", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return 0, ErrProfileDatabase.Wrap("PurgeDeleted", "tx.Exec wirelessconfigs", err)
+	}
+
+	sqlQuery, args, err = r.Builder.
+		Delete("profiles").
+		Where("deleted_at <> '' AND deleted_at <= ?", before).
+		ToSql()
+	if err != nil {
+		return 0, ErrProfileDatabase.Wrap("PurgeDeleted", "r.Builder", err)
+	}
+
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, ErrProfileDatabase.Wrap("PurgeDeleted", "tx.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrProfileDatabase.Wrap("PurgeDeleted", "res.RowsAffected", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, ErrProfileDatabase.Wrap("PurgeDeleted", "tx.Commit", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// detachTrashed unlinks a configuration from the profiles in the trash, which would otherwise keep it from
+// being deleted. A profile restored later comes back without it.
+func detachTrashed(ctx context.Context, tx *sql.Tx, builder squirrel.StatementBuilderType, column, name, tenantID string) error {
+	sqlQuery, args, err := builder.
+		Update("profiles").
+		Set(column, nil).
+		Where(column+" = ? AND tenant_id = ? AND deleted_at <> ''", name, tenantID).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
+
+	return err
+}
+
+// detachTrashedWireless unlinks a wireless configuration from the profiles in the trash, the same way as
+// detachTrashed.
+func detachTrashedWireless(ctx context.Context, tx *sql.Tx, builder squirrel.StatementBuilderType, name, tenantID string) error {
+	sqlQuery, args, err := builder.
+		Delete("profiles_wirelessconfigs").
+		Where(squirrel.Expr("wireless_profile_name = ? AND tenant_id = ? AND EXISTS (SELECT 1 FROM profiles p "+
+			"WHERE p.profile_name = profiles_wirelessconfigs.profile_name AND p.tenant_id = profiles_wirelessconfigs.tenant_id "+
+			"AND p.deleted_at <> '')", name, tenantID)).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlQuery, args...)
+
+	return err
+}
+
+// Update -.
+
+func (r *ProfileRepo) Update(ctx context.Context, p *entity.Profile) (bool, error) {
+	key := squirrel.Expr("profile_name = ? AND tenant_id = ? AND deleted_at = ''", p.ProfileName, p.TenantID)
+
+	builder := r.Builder.
 		Update("profiles").
 		Set("activation", p.Activation).
 		Set("amt_password", p.AMTPassword).
@@ -279,13 +589,21 @@ func (r *ProfileRepo) Update(_ context.Context, p *entity.Profile) (bool, error)
 		Set("ip_sync_enabled", p.IPSyncEnabled).
 		Set("local_wifi_sync_enabled", p.LocalWiFiSyncEnabled).
 		Set("uefi_wifi_sync_enabled", p.UEFIWiFiSyncEnabled).
-		Where("profile_name = ? AND tenant_id = ?", p.ProfileName, p.TenantID).
-		ToSql()
+		Set("template_name", p.TemplateName).
+		Set("template_overrides", p.TemplateOverrides).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(key)
+
+	if p.Version != "" {
+		builder = builder.Where(atVersion(p.Version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrProfileDatabase.Wrap("Update", "r.Builder", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return false, ErrProfileDatabase.Wrap("Update", "r.Pool.Exec", err)
 	}
@@ -295,11 +613,30 @@ func (r *ProfileRepo) Update(_ context.Context, p *entity.Profile) (bool, error)
 		return false, ErrProfileDatabase.Wrap("Update", "res.RowsAffected", err)
 	}
 
+	if rowsAffected == 0 && p.Version != "" {
+		return false, r.versionConflict("Update", r.Conn(ctx), key, p.ProfileName)
+	}
+
 	return rowsAffected > 0, nil
 }
 
+// versionConflict is the error of a write conditioned on the version of a profile that touched nothing: a
+// conflict while the profile is still there, none when it is gone.
+func (r *ProfileRepo) versionConflict(function string, q querier, key squirrel.Sqlizer, profileName string) error {
+	changed, err := rowExists(q, r.Builder, "profiles", key)
+	if err != nil {
+		return ErrProfileDatabase.Wrap(function, "rowExists", err)
+	}
+
+	if changed {
+		return ErrProfileVersionConflict.Wrap(profileName)
+	}
+
+	return nil
+}
+
 // Insert -.
-func (r *ProfileRepo) Insert(_ context.Context, p *entity.Profile) (string, error) {
+func (r *ProfileRepo) Insert(ctx context.Context, p *entity.Profile) (string, error) {
 	ciraConfigName := p.CIRAConfigName
 
 	ieee8021xProfileName := p.IEEE8021xProfileName
@@ -318,11 +655,11 @@ func (r *ProfileRepo) Insert(_ context.Context, p *entity.Profile) (string, erro
 
 	insertBuilder := r.Builder.
 		Insert("profiles").
-		Columns("profile_name", "activation", "amt_password", "generate_random_password", "cira_config_name", "mebx_password", "generate_random_mebx_password", "tags", "dhcp_enabled", "tls_mode", "user_consent", "ider_enabled", "kvm_enabled", "sol_enabled", "tls_signing_authority", "ieee8021x_profile_name", "ip_sync_enabled", "local_wifi_sync_enabled", "tenant_id", "uefi_wifi_sync_enabled").
-		Values(p.ProfileName, p.Activation, p.AMTPassword, p.GenerateRandomPassword, ciraConfigName, p.MEBXPassword, p.GenerateRandomMEBxPassword, p.Tags, p.DHCPEnabled, p.TLSMode, p.UserConsent, p.IDEREnabled, p.KVMEnabled, p.SOLEnabled, p.TLSSigningAuthority, ieee8021xProfileName, p.IPSyncEnabled, p.LocalWiFiSyncEnabled, p.TenantID, p.UEFIWiFiSyncEnabled)
+		Columns("profile_name", "activation", "amt_password", "generate_random_password", "cira_config_name", "mebx_password", "generate_random_mebx_password", "tags", "dhcp_enabled", "tls_mode", "user_consent", "ider_enabled", "kvm_enabled", "sol_enabled", "tls_signing_authority", "ieee8021x_profile_name", "ip_sync_enabled", "local_wifi_sync_enabled", "tenant_id", "uefi_wifi_sync_enabled", "template_name", "template_overrides").
+		Values(p.ProfileName, p.Activation, p.AMTPassword, p.GenerateRandomPassword, ciraConfigName, p.MEBXPassword, p.GenerateRandomMEBxPassword, p.Tags, p.DHCPEnabled, p.TLSMode, p.UserConsent, p.IDEREnabled, p.KVMEnabled, p.SOLEnabled, p.TLSSigningAuthority, ieee8021xProfileName, p.IPSyncEnabled, p.LocalWiFiSyncEnabled, p.TenantID, p.UEFIWiFiSyncEnabled, p.TemplateName, p.TemplateOverrides)
 
 	if !r.IsEmbedded {
-		insertBuilder = insertBuilder.Suffix("RETURNING xmin::text")
+		insertBuilder = insertBuilder.Suffix("RETURNING version::text")
 	}
 
 	sqlQuery, args, err := insertBuilder.ToSql()
@@ -333,13 +670,20 @@ func (r *ProfileRepo) Insert(_ context.Context, p *entity.Profile) (string, erro
 	version := ""
 
 	if r.IsEmbedded {
-		_, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+		_, err = r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	} else {
-		err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&version)
+		err = r.Conn(ctx).QueryRowContext(ctx, sqlQuery, args...).Scan(&version)
 	}
 
 	if err != nil {
 		if db.CheckNotUnique(err) {
+			// a profile in the trash keeps its name until it is purged
+			trashed, existsErr := rowExists(r.Conn(ctx), r.Builder, "profiles",
+				squirrel.Expr("profile_name = ? AND tenant_id = ? AND deleted_at <> ''", p.ProfileName, p.TenantID))
+			if existsErr == nil && trashed {
+				return "", ErrProfileNotUnique.Wrap("profile " + p.ProfileName + " is in the trash, restore or purge it first")
+			}
+
 			return "", ErrProfileNotUnique.Wrap(err.Error())
 		}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 const schema = `
@@ -52,6 +53,7 @@ CREATE TABLE IF NOT EXISTS ciraconfigs(
   mps_root_certificate TEXT,
   proxydetails TEXT,
   tenant_id TEXT NOT NULL,
+  version INTEGER NOT NULL DEFAULT 1,
   PRIMARY KEY (cira_config_name, tenant_id)
 );
 
@@ -61,6 +63,7 @@ CREATE TABLE IF NOT EXISTS ieee8021xconfigs(
   pxe_timeout INTEGER,
   wired_interface BOOLEAN NOT NULL,
   tenant_id TEXT NOT NULL,
+  version INTEGER NOT NULL DEFAULT 1,
   PRIMARY KEY (profile_name, tenant_id)
 );
 
@@ -76,6 +79,7 @@ CREATE TABLE IF NOT EXISTS wirelessconfigs(
   created_by TEXT,
   tenant_id TEXT NOT NULL,
   ieee8021x_profile_name TEXT,
+  version INTEGER NOT NULL DEFAULT 1,
   FOREIGN KEY (ieee8021x_profile_name, tenant_id) REFERENCES ieee8021xconfigs(profile_name, tenant_id),
   PRIMARY KEY (wireless_profile_name, tenant_id)
 );
@@ -103,6 +107,10 @@ CREATE TABLE IF NOT EXISTS profiles(
   local_wifi_sync_enabled BOOLEAN NOT NULL, 
   ieee8021x_profile_name TEXT,
   uefi_wifi_sync_enabled BOOLEAN NOT NULL,
+  deleted_at TEXT NOT NULL DEFAULT '',
+  template_name TEXT NOT NULL DEFAULT '',
+  template_overrides TEXT NOT NULL DEFAULT '',
+  version INTEGER NOT NULL DEFAULT 1,
   FOREIGN KEY (ieee8021x_profile_name, tenant_id) REFERENCES ieee8021xconfigs(profile_name, tenant_id),
   FOREIGN KEY (cira_config_name, tenant_id) REFERENCES ciraconfigs(cira_config_name, tenant_id),
   PRIMARY KEY (profile_name, tenant_id)
@@ -130,6 +138,7 @@ CREATE TABLE IF NOT EXISTS domains(
   creation_date TEXT, -- TIMESTAMP as TEXT
   created_by TEXT,
   tenant_id TEXT NOT NULL,
+  version INTEGER NOT NULL DEFAULT 1,
   CONSTRAINT domainsuffix UNIQUE (domain_suffix, tenant_id),
   PRIMARY KEY (name, tenant_id)
 );
@@ -214,7 +223,7 @@ func TestProfileRepo_GetCount(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewProfileRepo(sqlConfig, mockLog)
 
-			count, err := repo.GetCount(context.Background(), tc.tenantID)
+			count, err := repo.GetCount(context.Background(), odata.Query{}, tc.tenantID)
 
 			if err == nil && tc.err != nil {
 				t.Errorf("Expected error of type %T, got nil", tc.err)
@@ -391,7 +400,7 @@ func TestProfileRepo_Get(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewProfileRepo(sqlConfig, mockLog)
 
-			profiles, err := repo.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			profiles, err := repo.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			GetProfileHelper(t, tc, profiles, err)
 		})
@@ -592,7 +601,7 @@ func TestProfileRepo_Delete(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewProfileRepo(sqlConfig, mockLog)
 
-			deleted, err := repo.Delete(context.Background(), tc.profileName, tc.tenantID)
+			deleted, err := repo.Delete(context.Background(), tc.profileName, "", tc.tenantID)
 
 			if (err != nil) != tc.expectError {
 				t.Errorf("Expected error status %v, got %v", tc.expectError, err != nil)
@@ -672,7 +681,7 @@ func TestProfileRepo_Update(t *testing.T) {
 				KVMEnabled:                 false,
 				SOLEnabled:                 false,
 				IEEE8021xProfileName:       StringPtr("new-ieee"),
-				Version:                    "v2",
+				Version:                    "1",
 				AuthenticationProtocol:     IntPtr(2),
 				ServerName:                 "new-server",
 				Domain:                     "new-domain",
@@ -902,3 +911,238 @@ func TestProfileRepo_Insert(t *testing.T) {
 		})
 	}
 }
+
+func TestProfileRepo_TrashedReferences(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON;"+schema)
+	require.NoError(t, err)
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		INSERT INTO ciraconfigs (cira_config_name, tenant_id) VALUES ('cira1', 'tenant1');
+		INSERT INTO ieee8021xconfigs (profile_name, wired_interface, tenant_id) VALUES ('ieee1', true, 'tenant1');
+		INSERT INTO wirelessconfigs (wireless_profile_name, tenant_id) VALUES ('wifi1', 'tenant1');
+		INSERT INTO profiles (profile_name, activation, amt_password, mebx_password, generate_random_password,
+			generate_random_mebx_password, tags, dhcp_enabled, tenant_id, tls_mode, user_consent, ider_enabled, kvm_enabled,
+			sol_enabled, tls_signing_authority, ip_sync_enabled, local_wifi_sync_enabled, uefi_wifi_sync_enabled,
+			cira_config_name, ieee8021x_profile_name, deleted_at) VALUES
+			('profile1', 'acmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false, 'cira1', 'ieee1', '2020-01-01T00:00:00Z');
+		INSERT INTO profiles_wirelessconfigs (wireless_profile_name, profile_name, priority, tenant_id) VALUES
+			('wifi1', 'profile1', 1, 'tenant1');`)
+	require.NoError(t, err)
+
+	database := CreateSQLConfig(dbConn, false)
+	ctx := context.Background()
+	repo := sqldb.NewProfileRepo(database, mocks.NewMockLogger(nil))
+
+	// the name stays taken until the profile is purged
+	_, err = repo.Insert(ctx, &entity.Profile{ProfileName: "profile1", TenantID: "tenant1"})
+
+	var notUniqueErr sqldb.NotUniqueError
+
+	require.ErrorAs(t, err, &notUniqueErr)
+	require.Contains(t, notUniqueErr.Console.FriendlyMessage(), "in the trash")
+
+	// the configs the trashed profile refers to can still be deleted
+	deleted, err := sqldb.NewCIRARepo(database, mocks.NewMockLogger(nil)).Delete(ctx, "cira1", "", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = sqldb.NewWirelessRepo(database, mocks.NewMockLogger(nil)).Delete(ctx, "wifi1", "", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = sqldb.NewIEEE8021xRepo(database, mocks.NewMockLogger(nil)).Delete(ctx, "ieee1", "", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	restored, err := repo.Restore(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, restored)
+
+	found, err := repo.GetByName(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, found.CIRAConfigName)
+	require.Nil(t, found.IEEE8021xProfileName)
+}
+
+func TestProfileRepo_Versions(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), schema)
+	require.NoError(t, err)
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		INSERT INTO profiles (profile_name, activation, amt_password, mebx_password, generate_random_password,
+			generate_random_mebx_password, tags, dhcp_enabled, tenant_id, tls_mode, user_consent, ider_enabled, kvm_enabled,
+			sol_enabled, tls_signing_authority, ip_sync_enabled, local_wifi_sync_enabled, uefi_wifi_sync_enabled) VALUES
+			('profile1', 'acmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false);`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewProfileRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+	ctx := context.Background()
+
+	found, err := repo.GetByName(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, "1", found.Version)
+
+	updated, err := repo.Update(ctx, found)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// the version read before the first update is stale now
+	updated, err = repo.Update(ctx, found)
+	require.ErrorAs(t, err, &sqldb.VersionConflictError{})
+	require.False(t, updated)
+
+	deleted, err := repo.Delete(ctx, "profile1", "1", "tenant1")
+	require.ErrorAs(t, err, &sqldb.VersionConflictError{})
+	require.False(t, deleted)
+
+	found, err = repo.GetByName(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, "2", found.Version)
+
+	deleted, err = repo.Delete(ctx, "profile1", "2", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	// a profile that is gone is not found rather than changed
+	updated, err = repo.Update(ctx, found)
+	require.NoError(t, err)
+	require.False(t, updated)
+}
+
+func TestProfileRepo_Trash(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), schema)
+	require.NoError(t, err)
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		INSERT INTO wirelessconfigs (wireless_profile_name, tenant_id) VALUES ('wifi1', 'tenant1');
+		INSERT INTO profiles (profile_name, activation, amt_password, mebx_password, generate_random_password,
+			generate_random_mebx_password, tags, dhcp_enabled, tenant_id, tls_mode, user_consent, ider_enabled, kvm_enabled,
+			sol_enabled, tls_signing_authority, ip_sync_enabled, local_wifi_sync_enabled, uefi_wifi_sync_enabled, deleted_at) VALUES
+			('profile1', 'acmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false, ''),
+			('profile2', 'ccmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false, '2020-01-01T00:00:00Z');
+		INSERT INTO profiles_wirelessconfigs (wireless_profile_name, profile_name, priority, tenant_id) VALUES
+			('wifi1', 'profile1', 1, 'tenant1'), ('wifi1', 'profile2', 1, 'tenant1');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewProfileRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+	ctx := context.Background()
+
+	deleted, err := repo.Delete(ctx, "profile1", "", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	found, err := repo.GetByName(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	trash, err := repo.GetDeleted(ctx, 0, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, trash, 2)
+	require.Equal(t, "profile1", trash[0].ProfileName)
+	require.Equal(t, "profile2", trash[1].ProfileName)
+
+	// restoring brings the wireless configuration links back with the profile
+	restored, err := repo.Restore(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, restored)
+
+	found, err = repo.GetByName(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, "encrypted", found.AMTPassword)
+
+	count, err := repo.PurgeDeleted(ctx, "2021-01-01T00:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	var links int
+
+	require.NoError(t, dbConn.QueryRowContext(ctx, "SELECT COUNT(*) FROM profiles_wirelessconfigs").Scan(&links))
+	require.Equal(t, 1, links)
+
+	purged, err := repo.Purge(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.False(t, purged)
+
+	_, err = repo.Delete(ctx, "profile1", "", "tenant1")
+	require.NoError(t, err)
+
+	purged, err = repo.Purge(ctx, "profile1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, purged)
+
+	require.NoError(t, dbConn.QueryRowContext(ctx, "SELECT COUNT(*) FROM profiles_wirelessconfigs").Scan(&links))
+	require.Equal(t, 0, links)
+}
+
+func TestProfileRepo_GetByTemplate(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), schema)
+	require.NoError(t, err)
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		INSERT INTO profiles (profile_name, activation, amt_password, mebx_password, generate_random_password,
+			generate_random_mebx_password, tags, dhcp_enabled, tenant_id, tls_mode, user_consent, ider_enabled, kvm_enabled,
+			sol_enabled, tls_signing_authority, ip_sync_enabled, local_wifi_sync_enabled, uefi_wifi_sync_enabled, deleted_at,
+			template_name, template_overrides) VALUES
+			('profile2', 'acmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false, '', 'site', 'tags'),
+			('profile1', 'acmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false, '', 'site', ''),
+			('profile3', 'acmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false, '2020-01-01T00:00:00Z', 'site', ''),
+			('profile4', 'acmactivate', 'encrypted', 'encrypted', false, false, '', true, 'tenant1', 0, 'All', true, true, true, '',
+				true, true, false, '', '', '');`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewProfileRepo(CreateSQLConfig(dbConn, false), mocks.NewMockLogger(nil))
+	ctx := context.Background()
+
+	// profiles in the trash are not updated with the template
+	names, err := repo.GetByTemplate(ctx, "site", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"profile1", "profile2"}, names)
+
+	found, err := repo.GetByName(ctx, "profile2", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, "site", found.TemplateName)
+	require.Equal(t, "tags", found.TemplateOverrides)
+}
@@ -0,0 +1,195 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// ProfileTemplateRepo keeps the templates profiles of a tenant are derived from.
+type ProfileTemplateRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrProfileTemplateDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("ProfileTemplateRepo")}
+	ErrProfileTemplateNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("ProfileTemplateRepo")}
+)
+
+// NewProfileTemplateRepo -.
+func NewProfileTemplateRepo(database *db.SQL, log logger.Interface) *ProfileTemplateRepo {
+	return &ProfileTemplateRepo{database, log}
+}
+
+// GetCount -.
+func (r *ProfileTemplateRepo) GetCount(ctx context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("profile_templates").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrProfileTemplateDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrProfileTemplateDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *ProfileTemplateRepo) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.ProfileTemplate, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	return r.list(ctx, r.selectTemplates(tenantID).Limit(limitedTop).Offset(limitedSkip))
+}
+
+// GetByName -.
+func (r *ProfileTemplateRepo) GetByName(ctx context.Context, templateName, tenantID string) (*entity.ProfileTemplate, error) {
+	templates, err := r.list(ctx, r.selectTemplates(tenantID).Where("name = ?", templateName))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	return &templates[0], nil
+}
+
+// Delete -.
+func (r *ProfileTemplateRepo) Delete(ctx context.Context, templateName, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("profile_templates").
+		Where("name = ? AND tenant_id = ?", templateName, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrProfileTemplateDatabase.Wrap("Delete", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, ErrProfileTemplateDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrProfileTemplateDatabase.Wrap("Delete", "res.RowsAffected", err)
+	}
+
+	return result > 0, nil
+}
+
+// Update -.
+func (r *ProfileTemplateRepo) Update(ctx context.Context, t *entity.ProfileTemplate) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("profile_templates").
+		Set("settings", t.Settings).
+		Where("name = ? AND tenant_id = ?", t.TemplateName, t.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrProfileTemplateDatabase.Wrap("Update", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return false, ErrProfileTemplateDatabase.Wrap("Update", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrProfileTemplateDatabase.Wrap("Update", "res.RowsAffected", err)
+	}
+
+	return result > 0, nil
+}
+
+// Insert -.
+func (r *ProfileTemplateRepo) Insert(ctx context.Context, t *entity.ProfileTemplate) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("profile_templates").
+		Columns("name", "settings", "tenant_id").
+		Values(t.TemplateName, t.Settings, t.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrProfileTemplateDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	if _, err := r.Pool.ExecContext(ctx, sqlQuery, args...); err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrProfileTemplateNotUnique.Wrap(err.Error())
+		}
+
+		return ErrProfileTemplateDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileTemplateRepo) selectTemplates(tenantID string) squirrel.SelectBuilder {
+	return r.Builder.
+		Select("name", "settings", "tenant_id").
+		From("profile_templates").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name")
+}
+
+func (r *ProfileTemplateRepo) list(ctx context.Context, builder squirrel.SelectBuilder) ([]entity.ProfileTemplate, error) {
+	sqlQuery, args, err := builder.ToSql()
+	if err != nil {
+		return nil, ErrProfileTemplateDatabase.Wrap("list", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrProfileTemplateDatabase.Wrap("list", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	templates := make([]entity.ProfileTemplate, 0)
+
+	for rows.Next() {
+		var t entity.ProfileTemplate
+
+		if err := rows.Scan(&t.TemplateName, &t.Settings, &t.TenantID); err != nil {
+			return nil, ErrProfileTemplateDatabase.Wrap("list", "rows.Scan: ", err)
+		}
+
+		templates = append(templates, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrProfileTemplateDatabase.Wrap("list", "rows.Err", err)
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,80 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestProfileTemplateRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	// every connection of an in-memory database is a database of its own
+	dbConn.SetMaxOpenConns(1)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE profile_templates (
+			name TEXT NOT NULL,
+			settings TEXT NOT NULL,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (name, tenant_id)
+		);`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewProfileTemplateRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+	site := entity.ProfileTemplate{TemplateName: "site", Settings: `{"tlsMode":1}`}
+	branch := entity.ProfileTemplate{TemplateName: "branch", Settings: `{"kvmEnabled":true}`}
+	other := entity.ProfileTemplate{TemplateName: "site", Settings: `{}`, TenantID: "other"}
+
+	for _, template := range []entity.ProfileTemplate{site, branch, other} {
+		require.NoError(t, repo.Insert(ctx, &template))
+	}
+
+	err = repo.Insert(ctx, &site)
+	require.ErrorAs(t, err, &sqldb.NotUniqueError{})
+
+	count, err := repo.GetCount(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	list, err := repo.Get(ctx, 0, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, []entity.ProfileTemplate{branch, site}, list)
+
+	site.Settings = `{"tlsMode":2}`
+
+	updated, err := repo.Update(ctx, &site)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	got, err := repo.GetByName(ctx, "site", "")
+	require.NoError(t, err)
+	require.Equal(t, &site, got)
+
+	deleted, err := repo.Delete(ctx, "branch", "")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	got, err = repo.GetByName(ctx, "branch", "")
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
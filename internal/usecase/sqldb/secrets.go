@@ -0,0 +1,200 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// SecretRepo rewrites the encrypted columns of every table when the encryption key is rotated.
+type SecretRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrSecretDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("SecretRepo")}
+
+	errSecretsChanged = errors.New("secrets changed while they were rotated")
+)
+
+// secretColumn is a column holding secrets encrypted with the console encryption key.
+type secretColumn struct {
+	table  string
+	keys   []string
+	column string
+}
+
+// secretColumns lists every encrypted column, a new one must be added here or it is lost on key rotation.
+var secretColumns = []secretColumn{
+	{table: "devices", keys: []string{"guid"}, column: "password"},
+	{table: "ciraconfigs", keys: []string{"cira_config_name", "tenant_id"}, column: "password"},
+	{table: "profiles", keys: []string{"profile_name", "tenant_id"}, column: "amt_password"},
+	{table: "profiles", keys: []string{"profile_name", "tenant_id"}, column: "mebx_password"},
+	{table: "wirelessconfigs", keys: []string{"wireless_profile_name", "tenant_id"}, column: "psk_passphrase"},
+	// holds the encrypted provisioning certificate password
+	{table: "domains", keys: []string{"name", "tenant_id"}, column: "provisioning_cert_key"},
+	{table: "config_revision_secrets", keys: []string{"kind", "name", "revision", "field", "tenant_id"}, column: "value"},
+}
+
+type storedSecret struct {
+	keys  []any
+	value string
+}
+
+// NewSecretRepo -.
+func NewSecretRepo(database *db.SQL, log logger.Interface) *SecretRepo {
+	return &SecretRepo{database, log}
+}
+
+// RotateSecrets -.
+func (r *SecretRepo) RotateSecrets(ctx context.Context, reencrypt func(string) (string, error), verify func(string, string) error) (int, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, ErrSecretDatabase.Wrap("RotateSecrets", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	count := 0
+
+	for _, sc := range secretColumns {
+		secrets, err := r.readSecrets(ctx, tx, sc)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, secret := range secrets {
+			value, err := reencrypt(secret.value)
+			if err != nil {
+				return 0, ErrSecretDatabase.Wrap("RotateSecrets", "reencrypt "+sc.table+"."+sc.column, err)
+			}
+
+			update := r.Builder.Update(sc.table).Set(sc.column, value)
+			for i, key := range sc.keys {
+				update = update.Where(squirrel.Eq{key: secret.keys[i]})
+			}
+
+			sqlQuery, args, err := update.ToSql()
+			if err != nil {
+				return 0, ErrSecretDatabase.Wrap("RotateSecrets", "r.Builder: ", err)
+			}
+
+			if _, err = tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+				return 0, ErrSecretDatabase.Wrap("RotateSecrets", "tx.Exec", err)
+			}
+		}
+
+		written, err := r.readSecrets(ctx, tx, sc)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(written) != len(secrets) {
+			return 0, ErrSecretDatabase.Wrap("RotateSecrets", "verify "+sc.table+"."+sc.column, errSecretsChanged)
+		}
+
+		for i := range written {
+			if !slices.Equal(written[i].keys, secrets[i].keys) {
+				return 0, ErrSecretDatabase.Wrap("RotateSecrets", "verify "+sc.table+"."+sc.column, errSecretsChanged)
+			}
+
+			if err := verify(secrets[i].value, written[i].value); err != nil {
+				return 0, ErrSecretDatabase.Wrap("RotateSecrets", fmt.Sprintf("verify %s.%s %v", sc.table, sc.column, written[i].keys), err)
+			}
+		}
+
+		count += len(secrets)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, ErrSecretDatabase.Wrap("RotateSecrets", "tx.Commit", err)
+	}
+
+	return count, nil
+}
+
+// GetSecrets returns the value of every encrypted column, the references into a secret store among them.
+func (r *SecretRepo) GetSecrets(ctx context.Context) ([]string, error) {
+	// one transaction reads all columns at the same point in time
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, ErrSecretDatabase.Wrap("GetSecrets", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // only reads
+
+	values := make([]string, 0)
+
+	for _, sc := range secretColumns {
+		secrets, err := r.readSecrets(ctx, tx, sc)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, secret := range secrets {
+			values = append(values, secret.value)
+		}
+	}
+
+	return values, nil
+}
+
+// readSecrets returns the non-empty secrets of a column ordered by their keys.
+func (r *SecretRepo) readSecrets(ctx context.Context, tx *sql.Tx, sc secretColumn) ([]storedSecret, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(append(slices.Clone(sc.keys), sc.column)...).
+		From(sc.table).
+		Where(squirrel.NotEq{sc.column: nil}).
+		Where(squirrel.NotEq{sc.column: ""}).
+		OrderBy(sc.keys...).
+		ToSql()
+	if err != nil {
+		return nil, ErrSecretDatabase.Wrap("readSecrets", "r.Builder: ", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, ErrSecretDatabase.Wrap("readSecrets", "tx.Query", err)
+	}
+
+	defer rows.Close()
+
+	secrets := make([]storedSecret, 0)
+
+	for rows.Next() {
+		keys := make([]string, len(sc.keys))
+		dest := make([]any, 0, len(sc.keys)+1)
+
+		for i := range keys {
+			dest = append(dest, &keys[i])
+		}
+
+		var value string
+
+		if err := rows.Scan(append(dest, &value)...); err != nil {
+			return nil, ErrSecretDatabase.Wrap("readSecrets", "rows.Scan: ", err)
+		}
+
+		secret := storedSecret{value: value, keys: make([]any, len(keys))}
+		for i, key := range keys {
+			secret.keys[i] = key
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ErrSecretDatabase.Wrap("readSecrets", "rows.Err", err)
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,157 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func secretRepoTest(t *testing.T) (*sqldb.SecretRepo, *sql.DB) {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	// every connection of an in-memory database is a database of its own
+	dbConn.SetMaxOpenConns(1)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE devices (guid TEXT NOT NULL, password TEXT, tenantid TEXT NOT NULL DEFAULT '');
+		CREATE TABLE ciraconfigs (cira_config_name TEXT NOT NULL, password TEXT, tenant_id TEXT NOT NULL DEFAULT '');
+		CREATE TABLE profiles (profile_name TEXT NOT NULL, amt_password TEXT, mebx_password TEXT, tenant_id TEXT NOT NULL DEFAULT '');
+		CREATE TABLE wirelessconfigs (wireless_profile_name TEXT NOT NULL, psk_passphrase TEXT, tenant_id TEXT NOT NULL DEFAULT '');
+		CREATE TABLE domains (name TEXT NOT NULL, provisioning_cert_key TEXT, tenant_id TEXT NOT NULL DEFAULT '');
+		CREATE TABLE config_revision_secrets (kind TEXT NOT NULL, name TEXT NOT NULL, revision INTEGER NOT NULL, field TEXT NOT NULL, value TEXT NOT NULL, tenant_id TEXT NOT NULL DEFAULT '');
+		INSERT INTO devices (guid, password) VALUES ('d1', 'old:a'), ('d2', NULL), ('d3', '');
+		INSERT INTO ciraconfigs (cira_config_name, password, tenant_id) VALUES ('c1', 'old:b', 'bu-retail');
+		INSERT INTO profiles (profile_name, amt_password, mebx_password) VALUES ('p1', 'old:c', 'old:d'), ('p2', 'old:e', NULL);
+		INSERT INTO wirelessconfigs (wireless_profile_name, psk_passphrase) VALUES ('w1', 'old:f');
+		INSERT INTO domains (name, provisioning_cert_key) VALUES ('dm1', 'old:g');
+		INSERT INTO config_revision_secrets (kind, name, revision, field, value) VALUES ('profiles', 'p1', 1, 'amtPassword', 'old:h');`)
+	require.NoError(t, err)
+
+	return sqldb.NewSecretRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil)), dbConn
+}
+
+func reencryptTest(value string) (string, error) {
+	plain, ok := strings.CutPrefix(value, "old:")
+	if !ok {
+		return "", errors.New("not encrypted with the old key")
+	}
+
+	return "new:" + plain, nil
+}
+
+func readSecretTest(t *testing.T, dbConn *sql.DB, query string) sql.NullString {
+	t.Helper()
+
+	var value sql.NullString
+
+	require.NoError(t, dbConn.QueryRowContext(context.Background(), query).Scan(&value))
+
+	return value
+}
+
+func TestSecretRepoRotateSecrets(t *testing.T) {
+	t.Parallel()
+
+	repo, dbConn := secretRepoTest(t)
+
+	verified := 0
+
+	count, err := repo.RotateSecrets(context.Background(), reencryptTest, func(oldValue, newValue string) error {
+		verified++
+
+		require.Equal(t, strings.TrimPrefix(oldValue, "old:"), strings.TrimPrefix(newValue, "new:"))
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 8, count)
+	require.Equal(t, 8, verified)
+
+	require.Equal(t, "new:a", readSecretTest(t, dbConn, "SELECT password FROM devices WHERE guid = 'd1'").String)
+	require.False(t, readSecretTest(t, dbConn, "SELECT password FROM devices WHERE guid = 'd2'").Valid)
+	require.Empty(t, readSecretTest(t, dbConn, "SELECT password FROM devices WHERE guid = 'd3'").String)
+	require.Equal(t, "new:b", readSecretTest(t, dbConn, "SELECT password FROM ciraconfigs").String)
+	require.Equal(t, "new:d", readSecretTest(t, dbConn, "SELECT mebx_password FROM profiles WHERE profile_name = 'p1'").String)
+	require.Equal(t, "new:e", readSecretTest(t, dbConn, "SELECT amt_password FROM profiles WHERE profile_name = 'p2'").String)
+	require.Equal(t, "new:f", readSecretTest(t, dbConn, "SELECT psk_passphrase FROM wirelessconfigs").String)
+	require.Equal(t, "new:g", readSecretTest(t, dbConn, "SELECT provisioning_cert_key FROM domains").String)
+	require.Equal(t, "new:h", readSecretTest(t, dbConn, "SELECT value FROM config_revision_secrets").String)
+}
+
+func TestSecretRepoRotateSecretsRollsBack(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reencrypt func(string) (string, error)
+		verify    func(string, string) error
+	}{
+		{
+			name: "reencrypt fails",
+			reencrypt: func(value string) (string, error) {
+				if value == "old:f" {
+					return "", errors.New("decrypt failed")
+				}
+
+				return reencryptTest(value)
+			},
+			verify: func(_, _ string) error { return nil },
+		},
+		{
+			name:      "verify fails",
+			reencrypt: reencryptTest,
+			verify: func(oldValue, _ string) error {
+				if oldValue == "old:g" {
+					return errors.New("mismatch")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo, dbConn := secretRepoTest(t)
+
+			count, err := repo.RotateSecrets(context.Background(), tc.reencrypt, tc.verify)
+
+			var dbErr sqldb.DatabaseError
+
+			require.ErrorAs(t, err, &dbErr)
+			require.Zero(t, count)
+			require.Equal(t, "old:a", readSecretTest(t, dbConn, "SELECT password FROM devices WHERE guid = 'd1'").String)
+			require.Equal(t, "old:c", readSecretTest(t, dbConn, "SELECT amt_password FROM profiles WHERE profile_name = 'p1'").String)
+		})
+	}
+}
+
+func TestSecretRepoGetSecrets(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := secretRepoTest(t)
+
+	secrets, err := repo.GetSecrets(context.Background())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"old:a", "old:b", "old:c", "old:d", "old:e", "old:f", "old:g", "old:h"}, secrets)
+}
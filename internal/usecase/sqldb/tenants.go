@@ -0,0 +1,201 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// TenantRepo -. Tenants are not scoped to a tenant themselves, they are managed from the default tenant.
+type TenantRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrTenantDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("TenantRepo")}
+	ErrTenantNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("TenantRepo")}
+)
+
+// NewTenantRepo -.
+func NewTenantRepo(database *db.SQL, log logger.Interface) *TenantRepo {
+	return &TenantRepo{database, log}
+}
+
+// GetCount -.
+func (r *TenantRepo) GetCount(_ context.Context) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("tenants").
+		ToSql()
+	if err != nil {
+		return 0, ErrTenantDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrTenantDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *TenantRepo) Get(_ context.Context, top, skip int) ([]entity.Tenant, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("id", "name", "description").
+		From("tenants").
+		OrderBy("id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrTenantDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrTenantDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrTenantDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	tenants := make([]entity.Tenant, 0)
+
+	for rows.Next() {
+		t := entity.Tenant{}
+
+		err = rows.Scan(&t.ID, &t.Name, &t.Description)
+		if err != nil {
+			return nil, ErrTenantDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		tenants = append(tenants, t)
+	}
+
+	return tenants, nil
+}
+
+// GetByID -.
+func (r *TenantRepo) GetByID(_ context.Context, id string) (*entity.Tenant, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("id", "name", "description").
+		From("tenants").
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return nil, ErrTenantDatabase.Wrap("GetByID", "r.Builder: ", err)
+	}
+
+	t := entity.Tenant{}
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&t.ID, &t.Name, &t.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ErrTenantDatabase.Wrap("GetByID", "row.Scan: ", err)
+	}
+
+	return &t, nil
+}
+
+// Delete -.
+func (r *TenantRepo) Delete(_ context.Context, id string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("tenants").
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return false, ErrTenantDatabase.Wrap("Delete", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrTenantDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("TenantRepo - Delete - r.Pool.Exec: %w", err)
+	}
+
+	return result > 0, nil
+}
+
+// Update -.
+func (r *TenantRepo) Update(_ context.Context, t *entity.Tenant) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("tenants").
+		Set("name", t.Name).
+		Set("description", t.Description).
+		Where("id = ?", t.ID).
+		ToSql()
+	if err != nil {
+		return false, ErrTenantDatabase.Wrap("Update", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrTenantDatabase.Wrap("Update", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("TenantRepo - Update - r.Pool.Exec: %w", err)
+	}
+
+	return result > 0, nil
+}
+
+// Insert -.
+func (r *TenantRepo) Insert(_ context.Context, t *entity.Tenant) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("tenants").
+		Columns("id", "name", "description").
+		Values(t.ID, t.Name, t.Description).
+		ToSql()
+	if err != nil {
+		return ErrTenantDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	_, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrTenantNotUnique.Wrap(err.Error())
+		}
+
+		return ErrTenantDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
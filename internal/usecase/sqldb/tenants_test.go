@@ -0,0 +1,75 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+func TestTenantRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE tenants (
+			id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			description TEXT NOT NULL,
+			PRIMARY KEY (id)
+		);`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewTenantRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+	retail := entity.Tenant{ID: "bu-retail", Name: "Retail"}
+	energy := entity.Tenant{ID: "bu-energy", Name: "Energy", Description: "power plants"}
+
+	require.NoError(t, repo.Insert(ctx, &retail))
+	require.NoError(t, repo.Insert(ctx, &energy))
+
+	var notUnique sqldb.NotUniqueError
+	require.ErrorAs(t, repo.Insert(ctx, &retail), &notUnique)
+
+	count, err := repo.GetCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	list, err := repo.Get(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Tenant{energy, retail}, list)
+
+	retail.Description = "stores"
+
+	updated, err := repo.Update(ctx, &retail)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	found, err := repo.GetByID(ctx, "bu-retail")
+	require.NoError(t, err)
+	require.Equal(t, &retail, found)
+
+	deleted, err := repo.Delete(ctx, "bu-retail")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	found, err = repo.GetByID(ctx, "bu-retail")
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
@@ -0,0 +1,203 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/db"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// UserRepo -.
+type UserRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrUserDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("UserRepo")}
+	ErrUserNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("UserRepo")}
+)
+
+// NewUserRepo -.
+func NewUserRepo(database *db.SQL, log logger.Interface) *UserRepo {
+	return &UserRepo{database, log}
+}
+
+// GetCount -.
+func (r *UserRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("users").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrUserDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrUserDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *UserRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.User, error) {
+	const defaultTop = 100
+
+	limitedTop := uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	limitedSkip := uint64(0)
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("username", "password_hash", "role", "tenant_id").
+		From("users").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("username").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrUserDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, ErrUserDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrUserDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	users := make([]entity.User, 0)
+
+	for rows.Next() {
+		u := entity.User{}
+
+		err = rows.Scan(&u.Username, &u.PasswordHash, &u.Role, &u.TenantID)
+		if err != nil {
+			return nil, ErrUserDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		users = append(users, u)
+	}
+
+	return users, nil
+}
+
+// GetByUsername looks a user up across tenants, usernames are unique.
+func (r *UserRepo) GetByUsername(_ context.Context, username string) (*entity.User, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("username", "password_hash", "role", "tenant_id").
+		From("users").
+		Where("username = ?", username).
+		ToSql()
+	if err != nil {
+		return nil, ErrUserDatabase.Wrap("GetByUsername", "r.Builder: ", err)
+	}
+
+	u := entity.User{}
+
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&u.Username, &u.PasswordHash, &u.Role, &u.TenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, ErrUserDatabase.Wrap("GetByUsername", "row.Scan: ", err)
+	}
+
+	return &u, nil
+}
+
+// Delete -.
+func (r *UserRepo) Delete(_ context.Context, username, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("users").
+		Where("username = ? AND tenant_id = ?", username, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Delete", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("UserRepo - Delete - r.Pool.Exec: %w", err)
+	}
+
+	return result > 0, nil
+}
+
+// Update -.
+func (r *UserRepo) Update(_ context.Context, u *entity.User) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("users").
+		Set("password_hash", u.PasswordHash).
+		Set("role", u.Role).
+		Where("username = ? AND tenant_id = ?", u.Username, u.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Update", "r.Builder: ", err)
+	}
+
+	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Update", "r.Pool.Exec", err)
+	}
+
+	result, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("UserRepo - Update - r.Pool.Exec: %w", err)
+	}
+
+	return result > 0, nil
+}
+
+// Insert -.
+func (r *UserRepo) Insert(_ context.Context, u *entity.User) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("users").
+		Columns("username", "password_hash", "role", "tenant_id").
+		Values(u.Username, u.PasswordHash, u.Role, u.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrUserDatabase.Wrap("Insert", "r.Builder: ", err)
+	}
+
+	_, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrUserNotUnique.Wrap(err.Error())
+		}
+
+		return ErrUserDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/db"
+)
+
+// setupUserRepo creates an in-memory sqlite DB with the users schema used in tests.
+func setupUserRepo(t *testing.T) *sqldb.UserRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.ExecContext(context.Background(), `
+		CREATE TABLE users (
+			username TEXT NOT NULL,
+			password_hash TEXT NOT NULL,
+			role TEXT NOT NULL,
+			tenant_id TEXT NOT NULL,
+			PRIMARY KEY (username)
+		);`)
+	require.NoError(t, err)
+
+	sqlConfig := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	return sqldb.NewUserRepo(sqlConfig, mocks.NewMockLogger(nil))
+}
+
+func TestUserRepo(t *testing.T) {
+	t.Parallel()
+
+	repo := setupUserRepo(t)
+	ctx := context.Background()
+
+	jdoe := entity.User{Username: "jdoe", PasswordHash: "hash", Role: "viewer", TenantID: ""}
+	require.NoError(t, repo.Insert(ctx, &jdoe))
+	require.NoError(t, repo.Insert(ctx, &entity.User{Username: "other", PasswordHash: "hash", Role: "admin", TenantID: "tenant1"}))
+
+	err := repo.Insert(ctx, &jdoe)
+
+	var notUniqueErr sqldb.NotUniqueError
+	require.ErrorAs(t, err, &notUniqueErr)
+
+	count, err := repo.GetCount(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	list, err := repo.Get(ctx, 0, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, []entity.User{jdoe}, list)
+
+	jdoe.Role = "operator"
+	updated, err := repo.Update(ctx, &jdoe)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	found, err := repo.GetByUsername(ctx, "jdoe")
+	require.NoError(t, err)
+	require.Equal(t, &jdoe, found)
+
+	deleted, err := repo.Delete(ctx, "other", "")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "jdoe", "")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	found, err = repo.GetByUsername(ctx, "jdoe")
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
@@ -0,0 +1,61 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+)
+
+// VersionConflictError is returned when a conditional update or delete finds the row at another version than
+// the one the client read it at.
+type VersionConflictError struct {
+	Console consoleerrors.InternalError
+}
+
+func (e VersionConflictError) Error() string {
+	return e.Console.Error()
+}
+
+func (e VersionConflictError) Wrap(details string) error {
+	e.Console.Message = "version conflict: " + details
+
+	return e
+}
+
+// atVersion is the condition that a row is still at version. Versions start at 1, so one that is not a number
+// matches no row.
+func atVersion(version string) squirrel.Eq {
+	n, _ := strconv.ParseInt(version, 10, 64)
+
+	return squirrel.Eq{"version": n}
+}
+
+// querier runs queries on the pool or inside a transaction.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// rowExists tells whether table holds a row that fits where. It tells a row that was changed since the client
+// read it apart from one that is gone, once a write conditioned on its version touched nothing.
+func rowExists(q querier, builder squirrel.StatementBuilderType, table string, where squirrel.Sqlizer) (bool, error) {
+	sqlQuery, args, err := builder.
+		Select("COUNT(*)").
+		From(table).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+
+	if err = q.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
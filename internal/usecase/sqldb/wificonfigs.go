@@ -6,10 +6,13 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Masterminds/squirrel"
+
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // WirelessRepo -.
@@ -21,9 +24,20 @@ type WirelessRepo struct {
 var (
 	ErrWiFiDatabase                = DatabaseError{Console: consoleerrors.CreateConsoleError("WirelessRepo")}
 	ErrWiFiNotUnique               = NotUniqueError{Console: consoleerrors.CreateConsoleError("WirelessRepo")}
+	ErrWiFiVersionConflict         = VersionConflictError{Console: consoleerrors.CreateConsoleError("WirelessRepo")}
 	ErrWiFiIEEEForeignKeyViolation = ForeignKeyViolationError{Console: consoleerrors.CreateConsoleError("WirelessRepo")}
 )
 
+// wirelessFields are the wireless config properties lists can be filtered and ordered by.
+var wirelessFields = odata.Fields{
+	"profileName":          {Column: "wireless_profile_name", Type: odata.String},
+	"authenticationMethod": {Column: "authentication_method", Type: odata.Number},
+	"encryptionMethod":     {Column: "encryption_method", Type: odata.Number},
+	"ssid":                 {Column: "ssid", Type: odata.String},
+	"pskValue":             {Column: "psk_value", Type: odata.Number},
+	"ieee8021xProfileName": {Column: "ieee8021x_profile_name", Type: odata.String},
+}
+
 // New -.
 func NewWirelessRepo(database *db.SQL, log logger.Interface) *WirelessRepo {
 	return &WirelessRepo{database, log}
@@ -55,19 +69,23 @@ func (r *WirelessRepo) CheckProfileExists(_ context.Context, profileName, tenant
 }
 
 // GetCount -.
-func (r *WirelessRepo) GetCount(_ context.Context, tenantID string) (int, error) {
-	sqlQuery, _, err := r.Builder.
+func (r *WirelessRepo) GetCount(_ context.Context, q odata.Query, tenantID string) (int, error) {
+	builder, err := wirelessFields.Where(r.Builder.
 		Select("COUNT(*) OVER() AS total_count").
 		From("wirelessconfigs").
-		Where("tenant_id = ?", tenantID).
-		ToSql()
+		Where("tenant_id = ?", tenantID), q)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return 0, ErrWiFiDatabase.Wrap("GetCount", "r.Builder", err)
 	}
 
 	var count int
 
-	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, tenantID).Scan(&count)
+	err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&count)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, nil
@@ -80,7 +98,7 @@ func (r *WirelessRepo) GetCount(_ context.Context, tenantID string) (int, error)
 }
 
 // Get -.
-func (r *WirelessRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.WirelessConfig, error) {
+func (r *WirelessRepo) Get(_ context.Context, q odata.Query, top, skip int, tenantID string) ([]entity.WirelessConfig, error) {
 	const defaultTop = 100
 
 	if top == 0 {
@@ -97,7 +115,7 @@ func (r *WirelessRepo) Get(_ context.Context, top, skip int, tenantID string) ([
 		limitedSkip = uint64(skip)
 	}
 
-	sqlQuery, _, err := r.Builder.
+	builder, err := wirelessFields.Where(r.Builder.
 		Select(
 			"wireless_profile_name",
 			"authentication_method",
@@ -114,8 +132,17 @@ func (r *WirelessRepo) Get(_ context.Context, top, skip int, tenantID string) ([
 		).
 		From("wirelessconfigs w").
 		LeftJoin("ieee8021xconfigs e ON e.profile_name = w.ieee8021x_profile_name AND e.tenant_id = w.tenant_id AND e.wired_interface = false").
-		Where("w.tenant_id = ?", tenantID).
-		OrderBy("wireless_profile_name").
+		Where("w.tenant_id = ?", tenantID), q)
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = wirelessFields.OrderBy(builder, q, "wireless_profile_name")
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := builder.
 		Limit(limitedTop).
 		Offset(limitedSkip).
 		ToSql()
@@ -123,7 +150,7 @@ func (r *WirelessRepo) Get(_ context.Context, top, skip int, tenantID string) ([
 		return nil, ErrWiFiDatabase.Wrap("Get", "r.Builder", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, tenantID)
+	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, args...)
 	if err != nil {
 		return nil, ErrWiFiDatabase.Wrap("Get", "r.Pool.Query", err)
 	}
@@ -152,7 +179,7 @@ func (r *WirelessRepo) Get(_ context.Context, top, skip int, tenantID string) ([
 }
 
 // GetByName -.
-func (r *WirelessRepo) GetByName(_ context.Context, profileName, tenantID string) (*entity.WirelessConfig, error) {
+func (r *WirelessRepo) GetByName(ctx context.Context, profileName, tenantID string) (*entity.WirelessConfig, error) {
 	sqlQuery, _, err := r.Builder.
 		Select(
 			"wireless_profile_name",
@@ -167,6 +194,7 @@ func (r *WirelessRepo) GetByName(_ context.Context, profileName, tenantID string
 			"auth_protocol",
 			"pxe_timeout",
 			"wired_interface",
+			"w.version",
 		).
 		From("wirelessconfigs w").
 		LeftJoin("ieee8021xconfigs e ON e.profile_name = w.ieee8021x_profile_name AND e.tenant_id = w.tenant_id AND e.wired_interface = false").
@@ -176,7 +204,7 @@ func (r *WirelessRepo) GetByName(_ context.Context, profileName, tenantID string
 		return nil, ErrWiFiDatabase.Wrap("GetByName", "r.Builder", err)
 	}
 
-	rows, err := r.Pool.QueryContext(context.Background(), sqlQuery, profileName, tenantID)
+	rows, err := r.Conn(ctx).QueryContext(ctx, sqlQuery, profileName, tenantID)
 	if err != nil {
 		return nil, ErrWiFiDatabase.Wrap("GetByName", "r.Pool.Query", err)
 	}
@@ -193,7 +221,7 @@ func (r *WirelessRepo) GetByName(_ context.Context, profileName, tenantID string
 		p := &entity.WirelessConfig{}
 
 		err = rows.Scan(&p.ProfileName, &p.AuthenticationMethod, &p.EncryptionMethod, &p.SSID, &p.PSKValue, &p.PSKPassphrase, &p.LinkPolicy, &p.TenantID, &p.IEEE8021xProfileName,
-			&p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface)
+			&p.AuthenticationProtocol, &p.PXETimeout, &p.WiredInterface, &p.Version)
 		if err != nil {
 			return p, ErrWiFiDatabase.Wrap("GetByName", "rows.Scan", err)
 		}
@@ -209,23 +237,42 @@ func (r *WirelessRepo) GetByName(_ context.Context, profileName, tenantID string
 }
 
 // Delete -.
-func (r *WirelessRepo) Delete(_ context.Context, profileName, tenantID string) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *WirelessRepo) Delete(ctx context.Context, profileName, version, tenantID string) (bool, error) {
+	tx, err := r.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return false, ErrWiFiDatabase.Wrap("Delete", "r.Pool.BeginTx", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	// profiles in the trash must not keep the config from being deleted
+	if err := detachTrashedWireless(ctx, tx, r.Builder, profileName, tenantID); err != nil {
+		return false, ErrWiFiDatabase.Wrap("Delete", "detachTrashedWireless", err)
+	}
+
+	key := squirrel.Expr("wireless_profile_name = ? AND tenant_id = ?", profileName, tenantID)
+
+	builder := r.Builder.
 		Delete("wirelessconfigs").
-		Where("wireless_profile_name = ? AND tenant_id = ?", profileName, tenantID).
-		ToSql()
+		Where(key)
+
+	if version != "" {
+		builder = builder.Where(atVersion(version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrWiFiDatabase.Wrap("Delete", "r.Builder", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := tx.ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		// Check for PostgreSQL and SQLite foreign key violation errors
 		if db.CheckForeignKeyViolation(err) {
 			return false, ErrProfileWiFiConfigsForeignKeyViolation.Wrap(err.Error())
 		}
 
-		return false, ErrWiFiDatabase.Wrap("Delete", "r.Pool.Exec", err)
+		return false, ErrWiFiDatabase.Wrap("Delete", "tx.Exec", err)
 	}
 
 	result, err := res.RowsAffected()
@@ -233,12 +280,22 @@ func (r *WirelessRepo) Delete(_ context.Context, profileName, tenantID string) (
 		return false, ErrDomainDatabase.Wrap("Delete", "res.RowsAffected", err)
 	}
 
+	if result == 0 && version != "" {
+		return false, r.versionConflict("Delete", tx, key, profileName)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, ErrWiFiDatabase.Wrap("Delete", "tx.Commit", err)
+	}
+
 	return result > 0, nil
 }
 
 // Update -.
-func (r *WirelessRepo) Update(_ context.Context, p *entity.WirelessConfig) (bool, error) {
-	sqlQuery, args, err := r.Builder.
+func (r *WirelessRepo) Update(ctx context.Context, p *entity.WirelessConfig) (bool, error) {
+	key := squirrel.Expr("wireless_profile_name = ? AND tenant_id = ?", p.ProfileName, p.TenantID)
+
+	builder := r.Builder.
 		Update("wirelessconfigs").
 		Set("authentication_method", p.AuthenticationMethod).
 		Set("encryption_method", p.EncryptionMethod).
@@ -247,13 +304,19 @@ func (r *WirelessRepo) Update(_ context.Context, p *entity.WirelessConfig) (bool
 		Set("psk_passphrase", p.PSKPassphrase).
 		Set("link_policy", p.LinkPolicy).
 		Set("ieee8021x_profile_name", p.IEEE8021xProfileName).
-		Where("wireless_profile_name = ? AND tenant_id = ?", p.ProfileName, p.TenantID).
-		ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		Where(key)
+
+	if p.Version != "" {
+		builder = builder.Where(atVersion(p.Version))
+	}
+
+	sqlQuery, args, err := builder.ToSql()
 	if err != nil {
 		return false, ErrWiFiDatabase.Wrap("Update", "r.Builder", err)
 	}
 
-	res, err := r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+	res, err := r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return false, ErrWiFiDatabase.Wrap("Update", "r.Pool.Exec", err)
 	}
@@ -263,11 +326,30 @@ func (r *WirelessRepo) Update(_ context.Context, p *entity.WirelessConfig) (bool
 		return false, ErrDomainDatabase.Wrap("Update", "res.RowsAffected", err)
 	}
 
+	if result == 0 && p.Version != "" {
+		return false, r.versionConflict("Update", r.Conn(ctx), key, p.ProfileName)
+	}
+
 	return result > 0, nil
 }
 
+// versionConflict is the error of a write conditioned on the version of a wireless configuration that touched nothing: a
+// conflict while the wireless configuration is still there, none when it is gone.
+func (r *WirelessRepo) versionConflict(function string, q querier, key squirrel.Sqlizer, name string) error {
+	changed, err := rowExists(q, r.Builder, "wirelessconfigs", key)
+	if err != nil {
+		return ErrWiFiDatabase.Wrap(function, "rowExists", err)
+	}
+
+	if changed {
+		return ErrWiFiVersionConflict.Wrap(name)
+	}
+
+	return nil
+}
+
 // Insert -.
-func (r *WirelessRepo) Insert(_ context.Context, p *entity.WirelessConfig) (string, error) {
+func (r *WirelessRepo) Insert(ctx context.Context, p *entity.WirelessConfig) (string, error) {
 	date := time.Now().Format("2006-01-02 15:04:05")
 
 	ieeeProfileName := p.IEEE8021xProfileName
@@ -284,7 +366,7 @@ func (r *WirelessRepo) Insert(_ context.Context, p *entity.WirelessConfig) (stri
 		Values(p.ProfileName, p.AuthenticationMethod, p.EncryptionMethod, p.SSID, p.PSKValue, p.PSKPassphrase, p.LinkPolicy, date, p.TenantID, ieeeProfileName)
 
 	if !r.IsEmbedded {
-		insertBuilder = insertBuilder.Suffix("RETURNING xmin::text")
+		insertBuilder = insertBuilder.Suffix("RETURNING version::text")
 	}
 
 	sqlQuery, args, err := insertBuilder.ToSql()
@@ -295,9 +377,9 @@ func (r *WirelessRepo) Insert(_ context.Context, p *entity.WirelessConfig) (stri
 	version := ""
 
 	if r.IsEmbedded {
-		_, err = r.Pool.ExecContext(context.Background(), sqlQuery, args...)
+		_, err = r.Conn(ctx).ExecContext(ctx, sqlQuery, args...)
 	} else {
-		err = r.Pool.QueryRowContext(context.Background(), sqlQuery, args...).Scan(&version)
+		err = r.Conn(ctx).QueryRowContext(ctx, sqlQuery, args...).Scan(&version)
 	}
 
 	if err != nil {
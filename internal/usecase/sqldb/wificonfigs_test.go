@@ -16,6 +16,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type WirelessRepo struct {
@@ -89,7 +90,7 @@ func TestWirelessRepo_GetCount(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewWirelessRepo(sqlConfig, mockLog)
 
-			count, err := repo.GetCount(context.Background(), tc.tenantID)
+			count, err := repo.GetCount(context.Background(), odata.Query{}, tc.tenantID)
 
 			if err == nil && tc.err != nil {
 				t.Errorf("Expected error of type %T, got nil", tc.err)
@@ -214,7 +215,7 @@ func TestWirelessRepo_Get(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewWirelessRepo(sqlConfig, mockLog)
 
-			wireless, err := repo.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			wireless, err := repo.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			GetWirelessConfigHelper(t, tc, wireless, err)
 		})
@@ -456,7 +457,7 @@ func TestWirelessRepo_Delete(t *testing.T) {
 			mockLog := mocks.NewMockLogger(nil)
 			repo := sqldb.NewWirelessRepo(sqlConfig, mockLog)
 
-			deleted, err := repo.Delete(context.Background(), tc.profileName, tc.tenantID)
+			deleted, err := repo.Delete(context.Background(), tc.profileName, "", tc.tenantID)
 
 			if (err != nil) != tc.expectError {
 				t.Errorf("Expected error status %v, got %v", tc.expectError, err != nil)
@@ -493,6 +494,7 @@ func TestWirelessRepo_Update(t *testing.T) {
 						link_policy TEXT,
 						ieee8021x_profile_name TEXT,
 						tenant_id TEXT NOT NULL,
+						version INTEGER NOT NULL DEFAULT 1,
 						PRIMARY KEY (wireless_profile_name, tenant_id)
 					);
 				`)
@@ -530,6 +532,7 @@ func TestWirelessRepo_Update(t *testing.T) {
 						link_policy TEXT,
 						ieee8021x_profile_name TEXT,
 						tenant_id TEXT NOT NULL,
+						version INTEGER NOT NULL DEFAULT 1,
 						PRIMARY KEY (wireless_profile_name, tenant_id)
 					);
 				`)
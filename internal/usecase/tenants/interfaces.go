@@ -0,0 +1,27 @@
+package tenants
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context) (int, error)
+		Get(ctx context.Context, top, skip int) ([]entity.Tenant, error)
+		GetByID(ctx context.Context, id string) (*entity.Tenant, error)
+		Delete(ctx context.Context, id string) (bool, error)
+		Update(ctx context.Context, t *entity.Tenant) (bool, error)
+		Insert(ctx context.Context, t *entity.Tenant) error
+	}
+	Feature interface {
+		GetCount(ctx context.Context) (int, error)
+		Get(ctx context.Context, top, skip int) ([]dto.Tenant, error)
+		GetByID(ctx context.Context, id string) (*dto.Tenant, error)
+		Delete(ctx context.Context, id string) error
+		Update(ctx context.Context, t *dto.Tenant) (*dto.Tenant, error)
+		Insert(ctx context.Context, t *dto.Tenant) (*dto.Tenant, error)
+	}
+)
@@ -0,0 +1,128 @@
+package tenants
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// DefaultTenant is the tenant of callers that carry no tenant, such as the configured admin. Only callers of
+// the default tenant may manage tenants.
+const DefaultTenant = ""
+
+// UseCase -.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+}
+
+// New -.
+func New(r Repository, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  log,
+	}
+}
+
+var (
+	ErrTenantsUseCase = consoleerrors.CreateConsoleError("TenantsUseCase")
+	ErrDatabase       = sqldb.DatabaseError{Console: ErrTenantsUseCase}
+	ErrNotFound       = sqldb.NotFoundError{Console: ErrTenantsUseCase}
+)
+
+func (uc *UseCase) GetCount(ctx context.Context) (int, error) {
+	count, err := uc.repo.GetCount(ctx)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int) ([]dto.Tenant, error) {
+	data, err := uc.repo.Get(ctx, top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.Tenant, len(data))
+
+	for i := range data {
+		d1[i] = *entityToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByID(ctx context.Context, id string) (*dto.Tenant, error) {
+	data, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByID", "uc.repo.GetByID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return entityToDTO(data), nil
+}
+
+// Delete removes the tenant registration. Devices, configuration and users of the tenant are kept, a tenant
+// registered again under the same id gets them back.
+func (uc *UseCase) Delete(ctx context.Context, id string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, id)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (uc *UseCase) Update(ctx context.Context, d *dto.Tenant) (*dto.Tenant, error) {
+	t := dtoToEntity(d)
+
+	updated, err := uc.repo.Update(ctx, t)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return entityToDTO(t), nil
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.Tenant) (*dto.Tenant, error) {
+	t := dtoToEntity(d)
+
+	if err := uc.repo.Insert(ctx, t); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return entityToDTO(t), nil
+}
+
+func dtoToEntity(d *dto.Tenant) *entity.Tenant {
+	return &entity.Tenant{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+	}
+}
+
+func entityToDTO(t *entity.Tenant) *dto.Tenant {
+	return &dto.Tenant{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+	}
+}
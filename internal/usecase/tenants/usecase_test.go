@@ -0,0 +1,73 @@
+package tenants_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func tenantsTest(t *testing.T) (*tenants.UseCase, *mocks.MockTenantsRepository) {
+	t.Helper()
+
+	repo := mocks.NewMockTenantsRepository(gomock.NewController(t))
+
+	return tenants.New(repo, logger.New("error")), repo
+}
+
+func TestGetByID(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := tenantsTest(t)
+
+	repo.EXPECT().GetByID(context.Background(), "bu-retail").Return(&entity.Tenant{ID: "bu-retail", Name: "Retail"}, nil)
+	repo.EXPECT().GetByID(context.Background(), "unknown").Return(nil, nil)
+
+	tenant, err := useCase.GetByID(context.Background(), "bu-retail")
+	require.NoError(t, err)
+	require.Equal(t, &dto.Tenant{ID: "bu-retail", Name: "Retail"}, tenant)
+
+	_, err = useCase.GetByID(context.Background(), "unknown")
+	require.ErrorIs(t, err, tenants.ErrNotFound)
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := tenantsTest(t)
+
+	repo.EXPECT().Insert(context.Background(), &entity.Tenant{ID: "bu-retail", Name: "Retail"}).Return(nil)
+	repo.EXPECT().Insert(context.Background(), &entity.Tenant{ID: "bu-energy", Name: "Energy"}).Return(errors.New("test error"))
+
+	tenant, err := useCase.Insert(context.Background(), &dto.Tenant{ID: "bu-retail", Name: "Retail"})
+	require.NoError(t, err)
+	require.Equal(t, &dto.Tenant{ID: "bu-retail", Name: "Retail"}, tenant)
+
+	_, err = useCase.Insert(context.Background(), &dto.Tenant{ID: "bu-energy", Name: "Energy"})
+	var dbErr sqldb.DatabaseError
+	require.ErrorAs(t, err, &dbErr)
+}
+
+func TestUpdateAndDelete(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := tenantsTest(t)
+
+	repo.EXPECT().Update(context.Background(), &entity.Tenant{ID: "unknown", Name: "Unknown"}).Return(false, nil)
+	repo.EXPECT().Delete(context.Background(), "unknown").Return(false, nil)
+
+	_, err := useCase.Update(context.Background(), &dto.Tenant{ID: "unknown", Name: "Unknown"})
+	require.ErrorIs(t, err, tenants.ErrNotFound)
+
+	err = useCase.Delete(context.Background(), "unknown")
+	require.ErrorIs(t, err, tenants.ErrNotFound)
+}
@@ -0,0 +1,16 @@
+package trash
+
+import (
+	"context"
+)
+
+type (
+	// Purger removes what was deleted up to a point in time, devices.Feature and profiles.Feature implement it.
+	Purger interface {
+		PurgeDeleted(ctx context.Context, before string) (int, error)
+	}
+	Feature interface {
+		Run(ctx context.Context)
+		Purge(ctx context.Context) error
+	}
+)
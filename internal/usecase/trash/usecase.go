@@ -0,0 +1,86 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// purgeInterval is how often the trash is checked for items past their retention.
+const purgeInterval = time.Hour
+
+// UseCase purges the devices and profiles that stayed in the trash longer than the configured retention.
+type UseCase struct {
+	devices  Purger
+	profiles Purger
+	log      logger.Interface
+	now      func() time.Time
+}
+
+// New -.
+func New(devices, profiles Purger, log logger.Interface) *UseCase {
+	return &UseCase{
+		devices:  devices,
+		profiles: profiles,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+var (
+	ErrTrashUseCase = consoleerrors.CreateConsoleError("TrashUseCase")
+	ErrDatabase     = sqldb.DatabaseError{Console: ErrTrashUseCase}
+)
+
+// Run purges the trash right away and then every hour until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	if config.ConsoleConfig.Trash.Retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.Purge(ctx); err != nil {
+			uc.log.Error("trash - Run - " + err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge removes the devices and profiles deleted longer ago than the retention.
+func (uc *UseCase) Purge(ctx context.Context) error {
+	retention := config.ConsoleConfig.Trash.Retention
+	if retention <= 0 {
+		return nil
+	}
+
+	before := uc.now().Add(-retention).UTC().Format(time.RFC3339)
+
+	devices, err := uc.devices.PurgeDeleted(ctx, before)
+	if err != nil {
+		return ErrDatabase.Wrap("Purge", "uc.devices.PurgeDeleted", err)
+	}
+
+	profiles, err := uc.profiles.PurgeDeleted(ctx, before)
+	if err != nil {
+		return ErrDatabase.Wrap("Purge", "uc.profiles.PurgeDeleted", err)
+	}
+
+	if devices > 0 || profiles > 0 {
+		uc.log.Info(fmt.Sprintf("trash - purged %d devices and %d profiles deleted before %s", devices, profiles, before))
+	}
+
+	return nil
+}
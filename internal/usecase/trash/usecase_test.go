@@ -0,0 +1,73 @@
+package trash_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/internal/usecase/trash"
+)
+
+var errDatabase = errors.New("database is locked")
+
+func trashTest(t *testing.T, retention time.Duration) (*trash.UseCase, *mocks.MockTrashPurger, *mocks.MockTrashPurger, *mocks.MockLogger) {
+	t.Helper()
+
+	config.ConsoleConfig = &config.Config{}
+	config.ConsoleConfig.Trash = config.Trash{Retention: retention}
+
+	ctrl := gomock.NewController(t)
+	devices := mocks.NewMockTrashPurger(ctrl)
+	profiles := mocks.NewMockTrashPurger(ctrl)
+	log := mocks.NewMockLogger(ctrl)
+
+	return trash.New(devices, profiles, log), devices, profiles, log
+}
+
+func TestPurge(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	useCase, devices, profiles, log := trashTest(t, 24*time.Hour)
+
+	var cutoff string
+
+	devices.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before string) (int, error) {
+		cutoff = before
+
+		return 2, nil
+	})
+	profiles.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before string) (int, error) {
+		require.Equal(t, cutoff, before)
+
+		return 0, nil
+	})
+	log.EXPECT().Info(gomock.Any())
+
+	require.NoError(t, useCase.Purge(context.Background()))
+
+	before, err := time.Parse(time.RFC3339, cutoff)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+}
+
+func TestPurgeDatabaseError(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	useCase, devices, _, _ := trashTest(t, 24*time.Hour)
+
+	devices.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).Return(0, errDatabase)
+
+	var dbErr sqldb.DatabaseError
+
+	require.ErrorAs(t, useCase.Purge(context.Background()), &dbErr)
+}
+
+func TestPurgeWithoutRetention(t *testing.T) { //nolint:paralleltest // modifies the global console config
+	useCase, _, _, _ := trashTest(t, 0)
+
+	// nothing is purged while the retention is 0
+	require.NoError(t, useCase.Purge(context.Background()))
+}
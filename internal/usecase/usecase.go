@@ -4,16 +4,30 @@ import (
 	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
 
 	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/activity"
 	"github.com/device-management-toolkit/console/internal/usecase/amtexplorer"
+	"github.com/device-management-toolkit/console/internal/usecase/apikeys"
+	"github.com/device-management-toolkit/console/internal/usecase/auth"
+	"github.com/device-management-toolkit/console/internal/usecase/backup"
+	"github.com/device-management-toolkit/console/internal/usecase/certmonitor"
 	"github.com/device-management-toolkit/console/internal/usecase/ciraconfigs"
+	"github.com/device-management-toolkit/console/internal/usecase/deviceattributes"
+	"github.com/device-management-toolkit/console/internal/usecase/devicegroups"
 	"github.com/device-management-toolkit/console/internal/usecase/devices"
 	"github.com/device-management-toolkit/console/internal/usecase/devices/wsman"
 	"github.com/device-management-toolkit/console/internal/usecase/domains"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
 	"github.com/device-management-toolkit/console/internal/usecase/export"
 	"github.com/device-management-toolkit/console/internal/usecase/ieee8021xconfigs"
 	"github.com/device-management-toolkit/console/internal/usecase/profiles"
+	"github.com/device-management-toolkit/console/internal/usecase/profiletemplates"
 	"github.com/device-management-toolkit/console/internal/usecase/profilewificonfigs"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/internal/usecase/tenants"
+	"github.com/device-management-toolkit/console/internal/usecase/trash"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
 	"github.com/device-management-toolkit/console/internal/usecase/wificonfigs"
 	"github.com/device-management-toolkit/console/pkg/db"
 	"github.com/device-management-toolkit/console/pkg/logger"
@@ -30,36 +44,80 @@ type Usecases struct {
 	CIRAConfigs        ciraconfigs.Feature
 	WirelessProfiles   wificonfigs.Feature
 	Exporter           export.Exporter
+	Users              users.Feature
+	APIKeys            apikeys.Feature
+	Activity           activity.Feature
+	Tenants            tenants.Feature
+	Auth               auth.Feature
+	Encryption         encryption.Feature
+	CertMonitor        certmonitor.Feature
+	Backup             backup.Feature
+	DeviceGroups       devicegroups.Feature
+	DeviceAttributes   deviceattributes.Feature
+	Trash              trash.Feature
+	Revisions          revisions.Feature
+	ProfileTemplates   profiletemplates.Feature
 }
 
 // New -.
 func NewUseCases(database *db.SQL, log logger.Interface) *Usecases {
 	pwc := profilewificonfigs.New(sqldb.NewProfileWiFiConfigsRepo(database, log), log)
-	ieee := ieee8021xconfigs.New(sqldb.NewIEEE8021xRepo(database, log), log)
 	wifiConfigRepo := sqldb.NewWirelessRepo(database, log)
 	key := config.ConsoleConfig.EncryptionKey
-	safeRequirements := security.Crypto{
-		EncryptionKey: key,
+	// shared by every use case so that a rotated key takes effect everywhere
+	crypto := encryption.NewCrypto(key)
+
+	safeRequirements, err := encryption.NewSecretProvider(config.ConsoleConfig.Secrets, crypto)
+	if err != nil {
+		log.Fatal("usecase - NewUseCases - encryption.NewSecretProvider: " + err.Error())
 	}
+
+	revisions1 := revisions.New(sqldb.NewConfigRevisionRepo(database, log), log, safeRequirements)
+	ieee := ieee8021xconfigs.New(sqldb.NewIEEE8021xRepo(database, log), log, revisions1)
+
 	wsman1 := wsman.NewGoWSMANMessages(log, safeRequirements)
 	wsman2 := amtexplorer.NewGoWSMANMessages(log, safeRequirements)
 	domainRepo := sqldb.NewDomainRepo(database, log)
 	deviceRepo := sqldb.NewDeviceRepo(database, log)
 	ciraRepo := sqldb.NewCIRARepo(database, log)
 	profileRepo := sqldb.NewProfileRepo(database, log)
+	templateRepo := sqldb.NewProfileTemplateRepo(database, log)
+
+	domains1 := domains.New(domainRepo, log, safeRequirements, revisions1)
+	wificonfig := wificonfigs.New(wifiConfigRepo, ieee, log, safeRequirements, revisions1)
+	cira := ciraconfigs.New(ciraRepo, log, safeRequirements, revisions1)
+
+	devices1 := devices.New(deviceRepo, wsman1, devices.NewRedirector(safeRequirements), log, safeRequirements)
+	profiles1 := profiles.New(profileRepo, wifiConfigRepo, pwc, ieee, log, domainRepo, ciraRepo, safeRequirements, revisions1, templateRepo)
 
-	domains1 := domains.New(domainRepo, log, safeRequirements)
-	wificonfig := wificonfigs.New(wifiConfigRepo, ieee, log, safeRequirements)
+	revisions1.Register(dto.RevisionKindProfile, profiles1)
+	revisions1.Register(dto.RevisionKindWireless, wificonfig)
+	revisions1.Register(dto.RevisionKindCIRA, cira)
+	revisions1.Register(dto.RevisionKindIEEE8021x, ieee)
+	revisions1.Register(dto.RevisionKindDomain, domains1)
 
 	return &Usecases{
 		Domains:            domains1,
-		Devices:            devices.New(deviceRepo, wsman1, devices.NewRedirector(safeRequirements), log, safeRequirements),
+		Devices:            devices1,
 		AMTExplorer:        amtexplorer.New(deviceRepo, wsman2, log, safeRequirements),
-		Profiles:           profiles.New(profileRepo, wifiConfigRepo, pwc, ieee, log, domainRepo, ciraRepo, safeRequirements),
+		Profiles:           profiles1,
 		IEEE8021xProfiles:  ieee,
-		CIRAConfigs:        ciraconfigs.New(ciraRepo, log, safeRequirements),
+		CIRAConfigs:        cira,
 		WirelessProfiles:   wificonfig,
 		ProfileWiFiConfigs: pwc,
 		Exporter:           export.NewFileExporter(),
+		Users:              users.New(sqldb.NewUserRepo(database, log), log),
+		APIKeys:            apikeys.New(sqldb.NewAPIKeyRepo(database, log), log),
+		Activity:           activity.New(sqldb.NewActivityRepo(database, log), log),
+		Tenants:            tenants.New(sqldb.NewTenantRepo(database, log), log),
+		Auth:               auth.New(sqldb.NewAuthTokenRepo(database, log), log),
+		Encryption:         encryption.New(sqldb.NewSecretRepo(database, log), crypto, safeRequirements, security.NewKeyRingStorage(encryption.KeyringService), log),
+		CertMonitor:        certmonitor.New(sqldb.NewCertificateRepo(database, log), devices1, log),
+		Backup:             backup.New(sqldb.NewBackupRepo(database, log), log, safeRequirements),
+		DeviceGroups:       devicegroups.New(sqldb.NewDeviceGroupRepo(database, log), devices1, log),
+		DeviceAttributes:   deviceattributes.New(sqldb.NewDeviceAttributeRepo(database, log), log),
+		Trash:              trash.New(devices1, profiles1, log),
+		Revisions:          revisions1,
+		ProfileTemplates:   profiletemplates.New(templateRepo, profiles1, log),
 	}
 }
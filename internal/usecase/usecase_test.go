@@ -8,17 +8,18 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
-	"github.com/device-management-toolkit/go-wsman-messages/v2/pkg/security"
-
 	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/ciraconfigs"
 	"github.com/device-management-toolkit/console/internal/usecase/devices"
 	"github.com/device-management-toolkit/console/internal/usecase/devices/wsman"
 	"github.com/device-management-toolkit/console/internal/usecase/domains"
+	"github.com/device-management-toolkit/console/internal/usecase/encryption"
 	"github.com/device-management-toolkit/console/internal/usecase/ieee8021xconfigs"
 	"github.com/device-management-toolkit/console/internal/usecase/profiles"
 	"github.com/device-management-toolkit/console/internal/usecase/profilewificonfigs"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/internal/usecase/wificonfigs"
 	"github.com/device-management-toolkit/console/pkg/db"
@@ -45,9 +46,31 @@ func setupConfig() {
 func TestUsecases(t *testing.T) {
 	t.Parallel()
 
-	safeRequirements := security.Crypto{
-		EncryptionKey: "test",
-	}
+	safeRequirements := encryption.NewCrypto("test")
+
+	// every config use case records revisions, which can be rolled back through the use case again
+	revs := revisions.New(sqldb.NewConfigRevisionRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), safeRequirements)
+	ieee := ieee8021xconfigs.New(sqldb.NewIEEE8021xRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), revs)
+	domains1 := domains.New(sqldb.NewDomainRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), safeRequirements, revs)
+	wireless := wificonfigs.New(sqldb.NewWirelessRepo(&db.SQL{}, mocks.NewMockLogger(nil)), ieee, mocks.NewMockLogger(nil), safeRequirements, revs)
+	cira := ciraconfigs.New(sqldb.NewCIRARepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), safeRequirements, revs)
+	profiles1 := profiles.New(
+		sqldb.NewProfileRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
+		sqldb.NewWirelessRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
+		profilewificonfigs.New(sqldb.NewProfileWiFiConfigsRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil)),
+		ieee, mocks.NewMockLogger(nil),
+		sqldb.NewDomainRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
+		sqldb.NewCIRARepo(&db.SQL{}, mocks.NewMockLogger(nil)),
+		safeRequirements,
+		revs,
+		sqldb.NewProfileTemplateRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
+	)
+
+	revs.Register(dto.RevisionKindProfile, profiles1)
+	revs.Register(dto.RevisionKindWireless, wireless)
+	revs.Register(dto.RevisionKindCIRA, cira)
+	revs.Register(dto.RevisionKindIEEE8021x, ieee)
+	revs.Register(dto.RevisionKindDomain, domains1)
 
 	tests := []usecaseTest{
 		{
@@ -61,21 +84,14 @@ func TestUsecases(t *testing.T) {
 				return NewUseCases(mockDB, mockLogger)
 			},
 			expectedResult: &Usecases{
-				Domains: domains.New(sqldb.NewDomainRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), safeRequirements),
-				Devices: devices.New(sqldb.NewDeviceRepo(&db.SQL{}, mocks.NewMockLogger(nil)), wsman.NewGoWSMANMessages(mocks.NewMockLogger(nil), safeRequirements), devices.NewRedirector(safeRequirements), mocks.NewMockLogger(nil), safeRequirements),
-				Profiles: profiles.New(
-					sqldb.NewProfileRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
-					sqldb.NewWirelessRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
-					profilewificonfigs.New(sqldb.NewProfileWiFiConfigsRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil)),
-					ieee8021xconfigs.New(sqldb.NewIEEE8021xRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil),
-					sqldb.NewDomainRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
-					sqldb.NewCIRARepo(&db.SQL{}, mocks.NewMockLogger(nil)),
-					safeRequirements,
-				),
-				IEEE8021xProfiles:  ieee8021xconfigs.New(sqldb.NewIEEE8021xRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil)),
-				CIRAConfigs:        ciraconfigs.New(sqldb.NewCIRARepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), safeRequirements),
-				WirelessProfiles:   wificonfigs.New(sqldb.NewWirelessRepo(&db.SQL{}, mocks.NewMockLogger(nil)), ieee8021xconfigs.New(sqldb.NewIEEE8021xRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), safeRequirements),
+				Domains:            domains1,
+				Devices:            devices.New(sqldb.NewDeviceRepo(&db.SQL{}, mocks.NewMockLogger(nil)), wsman.NewGoWSMANMessages(mocks.NewMockLogger(nil), safeRequirements), devices.NewRedirector(safeRequirements), mocks.NewMockLogger(nil), safeRequirements),
+				Profiles:           profiles1,
+				IEEE8021xProfiles:  ieee,
+				CIRAConfigs:        cira,
+				WirelessProfiles:   wireless,
 				ProfileWiFiConfigs: profilewificonfigs.New(sqldb.NewProfileWiFiConfigsRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil)),
+				Revisions:          revs,
 			},
 		},
 	}
@@ -96,6 +112,7 @@ func TestUsecases(t *testing.T) {
 			assert.NotNil(t, uc.IEEE8021xProfiles)
 			assert.NotNil(t, uc.CIRAConfigs)
 			assert.NotNil(t, uc.WirelessProfiles)
+			assert.NotNil(t, uc.Revisions)
 
 			assert.Equal(t, tc.expectedResult.Domains, uc.Domains)
 			assert.Equal(t, tc.expectedResult.Devices, uc.Devices)
@@ -104,6 +121,7 @@ func TestUsecases(t *testing.T) {
 			assert.Equal(t, tc.expectedResult.IEEE8021xProfiles, uc.IEEE8021xProfiles)
 			assert.Equal(t, tc.expectedResult.CIRAConfigs, uc.CIRAConfigs)
 			assert.Equal(t, tc.expectedResult.WirelessProfiles, uc.WirelessProfiles)
+			assert.Equal(t, tc.expectedResult.Revisions, uc.Revisions)
 		})
 	}
 }
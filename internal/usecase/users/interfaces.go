@@ -0,0 +1,28 @@
+package users
+
+import (
+	"context"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.User, error)
+		GetByUsername(ctx context.Context, username string) (*entity.User, error)
+		Delete(ctx context.Context, username, tenantID string) (bool, error)
+		Update(ctx context.Context, u *entity.User) (bool, error)
+		Insert(ctx context.Context, u *entity.User) error
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.User, error)
+		GetByUsername(ctx context.Context, username, tenantID string) (*dto.User, error)
+		Delete(ctx context.Context, username, tenantID string) error
+		Update(ctx context.Context, u *dto.User) (*dto.User, error)
+		Insert(ctx context.Context, u *dto.User) (*dto.User, error)
+		Authenticate(ctx context.Context, username, password string) (*dto.User, error)
+	}
+)
@@ -0,0 +1,92 @@
+package users
+
+import (
+	"strings"
+
+	"github.com/device-management-toolkit/console/config"
+)
+
+// MapClaims resolves the console role of an identity provider user from its ID token claims. Mappings are tried
+// in order and the first one naming a known role decides. ok is false when no mapping matches.
+func MapClaims(mappings []config.RoleMapping, claims map[string]any) (role string, ok bool) {
+	for _, mapping := range mappings {
+		if _, known := rolePermissions[mapping.Role]; !known {
+			continue
+		}
+
+		if mapping.EmailDomain != "" {
+			if emailDomainMatches(claims, mapping.EmailDomain) {
+				return mapping.Role, true
+			}
+
+			continue
+		}
+
+		if mapping.Claim != "" && claimContains(lookupClaim(claims, mapping.Claim), mapping.Value) {
+			return mapping.Role, true
+		}
+	}
+
+	return "", false
+}
+
+// ClaimTenant resolves the tenant of an identity provider user from the claim at path, which may be a dotted path
+// into nested claims. Without a path every user belongs to the default tenant. ok is false when the claim is
+// missing or not a string.
+func ClaimTenant(path string, claims map[string]any) (tenant string, ok bool) {
+	if path == "" {
+		return "", true
+	}
+
+	tenant, _ = lookupClaim(claims, path).(string)
+
+	return tenant, tenant != ""
+}
+
+// lookupClaim follows a dotted path into nested claims.
+func lookupClaim(claims map[string]any, path string) any {
+	var value any = claims
+
+	for _, key := range strings.Split(path, ".") {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = nested[key]
+	}
+
+	return value
+}
+
+func claimContains(claim any, value string) bool {
+	switch v := claim.(type) {
+	case string:
+		return v == value
+	case []any:
+		for _, entry := range v {
+			if s, ok := entry.(string); ok && s == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// emailDomainMatches only trusts email addresses the identity provider reports as verified, an address without
+// the email_verified claim is not trusted either.
+func emailDomainMatches(claims map[string]any, domain string) bool {
+	if verified, _ := claims["email_verified"].(bool); !verified {
+		return false
+	}
+
+	email, _ := claims["email"].(string)
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	return strings.EqualFold(email[at+1:], strings.TrimPrefix(domain, "@"))
+}
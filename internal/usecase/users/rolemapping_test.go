@@ -0,0 +1,76 @@
+package users_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/config"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+)
+
+func TestMapClaims(t *testing.T) {
+	t.Parallel()
+
+	mappings := []config.RoleMapping{
+		{Claim: "groups", Value: "console-admins", Role: users.RoleAdmin},
+		{Claim: "realm_access.roles", Value: "console-auditor", Role: users.RoleAuditor},
+		{Claim: "groups", Value: "misconfigured", Role: "superuser"},
+		{EmailDomain: "@Example.com", Role: users.RoleViewer},
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+		role   string
+		ok     bool
+	}{
+		{"group", map[string]any{"groups": []any{"staff", "console-admins"}, "email": "a@example.com"}, users.RoleAdmin, true},
+		{"nested claim", map[string]any{"realm_access": map[string]any{"roles": []any{"console-auditor"}}}, users.RoleAuditor, true},
+		{"email domain", map[string]any{"email": "jdoe@example.COM", "email_verified": true}, users.RoleViewer, true},
+		{"unverified email", map[string]any{"email": "jdoe@example.com", "email_verified": false}, "", false},
+		{"email without verification", map[string]any{"email": "jdoe@example.com"}, "", false},
+		{"other domain", map[string]any{"email": "jdoe@example.com.evil", "email_verified": true}, "", false},
+		{"unknown role is skipped", map[string]any{"groups": []any{"misconfigured"}}, "", false},
+		{"no claims", map[string]any{}, "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			role, ok := users.MapClaims(mappings, tc.claims)
+			require.Equal(t, tc.role, role)
+			require.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestClaimTenant(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		path   string
+		claims map[string]any
+		tenant string
+		ok     bool
+	}{
+		{"no tenant claim configured", "", map[string]any{"tenant": "bu-retail"}, "", true},
+		{"claim", "tenant", map[string]any{"tenant": "bu-retail"}, "bu-retail", true},
+		{"nested claim", "org.tenant", map[string]any{"org": map[string]any{"tenant": "bu-energy"}}, "bu-energy", true},
+		{"missing claim", "tenant", map[string]any{"groups": []any{"staff"}}, "", false},
+		{"empty claim", "tenant", map[string]any{"tenant": ""}, "", false},
+		{"list claim", "tenant", map[string]any{"tenant": []any{"bu-retail", "bu-energy"}}, "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tenant, ok := users.ClaimTenant(tc.path, tc.claims)
+			require.Equal(t, tc.tenant, tenant)
+			require.Equal(t, tc.ok, ok)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package users
+
+import "slices"
+
+const (
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleViewer   = "viewer"
+	RoleAuditor  = "auditor"
+
+	// PermissionDevicesRead allows reading device state, settings and logs.
+	PermissionDevicesRead = "devices:read"
+	// PermissionDevicesWrite allows actions that change a device, such as power actions or settings.
+	PermissionDevicesWrite = "devices:write"
+	// PermissionPowerWrite allows power actions and changing boot options.
+	PermissionPowerWrite = "power:write"
+	// PermissionDevicesRedirect allows KVM, SOL and IDER sessions and screenshots.
+	PermissionDevicesRedirect = "devices:redirect"
+	// PermissionAdminRead allows reading provisioning configuration under /admin.
+	PermissionAdminRead = "admin:read"
+	// PermissionAdminWrite allows changing provisioning configuration under /admin.
+	PermissionAdminWrite = "admin:write"
+	// PermissionUsersManage allows managing console users.
+	PermissionUsersManage = "users:manage"
+	// PermissionAuditRead allows querying and exporting the console activity audit trail.
+	PermissionAuditRead = "audit:read"
+	// PermissionTenantsManage allows managing tenants, it only takes effect for callers of the default tenant.
+	PermissionTenantsManage = "tenants:manage"
+	// PermissionEncryptionManage allows rotating the key that encrypts stored secrets.
+	PermissionEncryptionManage = "encryption:manage"
+	// PermissionBackupManage allows backing up and restoring the devices and configuration of every tenant.
+	PermissionBackupManage = "backup:manage"
+)
+
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionDevicesRead, PermissionDevicesWrite, PermissionPowerWrite, PermissionDevicesRedirect,
+		PermissionAdminRead, PermissionAdminWrite, PermissionUsersManage, PermissionAuditRead, PermissionTenantsManage,
+		PermissionEncryptionManage, PermissionBackupManage,
+	},
+	RoleOperator: {PermissionDevicesRead, PermissionDevicesWrite, PermissionPowerWrite, PermissionDevicesRedirect, PermissionAdminRead},
+	RoleViewer:   {PermissionDevicesRead},
+	RoleAuditor:  {PermissionDevicesRead, PermissionAdminRead, PermissionAuditRead},
+}
+
+// IsPermission reports whether permission is one the console knows about.
+func IsPermission(permission string) bool {
+	return slices.Contains(rolePermissions[RoleAdmin], permission)
+}
+
+// Permissions returns the permissions granted to role. Unknown roles are granted nothing.
+func Permissions(role string) []string {
+	return slices.Clone(rolePermissions[role])
+}
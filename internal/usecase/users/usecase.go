@@ -0,0 +1,182 @@
+package users
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
+	"github.com/device-management-toolkit/console/pkg/consoleerrors"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+// UseCase -.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+}
+
+// New -.
+func New(r Repository, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  log,
+	}
+}
+
+var (
+	ErrUsersUseCase       = consoleerrors.CreateConsoleError("UsersUseCase")
+	ErrDatabase           = sqldb.DatabaseError{Console: ErrUsersUseCase}
+	ErrNotFound           = sqldb.NotFoundError{Console: ErrUsersUseCase}
+	ErrValidation         = dto.NotValidError{Console: ErrUsersUseCase}
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// dummyHash is compared against when the user does not exist so that unknown and known users take
+// the same time to reject.
+var dummyHash = []byte("$2a$10$pdjdAxOffhB.zP6omY9UAeYkmBxJfRIHC6kvCbPFzUA9mj1TKOHEK")
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.User, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.User, len(data))
+
+	for i := range data {
+		d1[i] = *entityToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByUsername(ctx context.Context, username, tenantID string) (*dto.User, error) {
+	data, err := uc.repo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByUsername", "uc.repo.GetByUsername", err)
+	}
+
+	if data == nil || data.TenantID != tenantID {
+		return nil, ErrNotFound
+	}
+
+	return entityToDTO(data), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, username, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, username, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Update changes the role of a user and, when a password is given, its password.
+func (uc *UseCase) Update(ctx context.Context, d *dto.User) (*dto.User, error) {
+	existing, err := uc.repo.GetByUsername(ctx, d.Username)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.GetByUsername", err)
+	}
+
+	if existing == nil || existing.TenantID != d.TenantID {
+		return nil, ErrNotFound
+	}
+
+	existing.Role = d.Role
+
+	if d.Password != "" {
+		if existing.PasswordHash, err = hashPassword(d.Password); err != nil {
+			return nil, ErrValidation.Wrap("Update", "hashPassword", err)
+		}
+	}
+
+	updated, err := uc.repo.Update(ctx, existing)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return entityToDTO(existing), nil
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.User) (*dto.User, error) {
+	if d.Password == "" {
+		return nil, ErrValidation.Wrap("Insert", "password", errors.New("password is required"))
+	}
+
+	hash, err := hashPassword(d.Password)
+	if err != nil {
+		return nil, ErrValidation.Wrap("Insert", "hashPassword", err)
+	}
+
+	u := &entity.User{
+		Username:     d.Username,
+		PasswordHash: hash,
+		Role:         d.Role,
+		TenantID:     d.TenantID,
+	}
+
+	if err := uc.repo.Insert(ctx, u); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return entityToDTO(u), nil
+}
+
+// Authenticate checks the password of a local user and returns the user on success.
+func (uc *UseCase) Authenticate(ctx context.Context, username, password string) (*dto.User, error) {
+	u, err := uc.repo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Authenticate", "uc.repo.GetByUsername", err)
+	}
+
+	if u == nil {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+
+		return nil, ErrInvalidCredentials
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return entityToDTO(u), nil
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func entityToDTO(u *entity.User) *dto.User {
+	return &dto.User{
+		Username: u.Username,
+		Role:     u.Role,
+		TenantID: u.TenantID,
+	}
+}
@@ -0,0 +1,104 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+	"github.com/device-management-toolkit/console/internal/mocks"
+	"github.com/device-management-toolkit/console/internal/usecase/users"
+	"github.com/device-management-toolkit/console/pkg/logger"
+)
+
+func usersTest(t *testing.T) (*users.UseCase, *mocks.MockUsersRepository) {
+	t.Helper()
+
+	repo := mocks.NewMockUsersRepository(gomock.NewController(t))
+
+	return users.New(repo, logger.New("error")), repo
+}
+
+func TestInsertAndAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := usersTest(t)
+
+	var stored entity.User
+
+	repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.User) error {
+		stored = *u
+
+		return nil
+	})
+
+	created, err := useCase.Insert(context.Background(), &dto.User{Username: "jdoe", Password: "viewer-pass", Role: users.RoleViewer})
+	require.NoError(t, err)
+	require.Equal(t, &dto.User{Username: "jdoe", Role: users.RoleViewer}, created)
+	require.NotEqual(t, "viewer-pass", stored.PasswordHash)
+
+	repo.EXPECT().GetByUsername(context.Background(), "jdoe").Return(&stored, nil).Times(2)
+	repo.EXPECT().GetByUsername(context.Background(), "nobody").Return(nil, nil)
+
+	user, err := useCase.Authenticate(context.Background(), "jdoe", "viewer-pass")
+	require.NoError(t, err)
+	require.Equal(t, users.RoleViewer, user.Role)
+
+	_, err = useCase.Authenticate(context.Background(), "jdoe", "wrong")
+	require.ErrorIs(t, err, users.ErrInvalidCredentials)
+
+	_, err = useCase.Authenticate(context.Background(), "nobody", "viewer-pass")
+	require.ErrorIs(t, err, users.ErrInvalidCredentials)
+}
+
+func TestInsertRequiresPassword(t *testing.T) {
+	t.Parallel()
+
+	useCase, _ := usersTest(t)
+
+	_, err := useCase.Insert(context.Background(), &dto.User{Username: "jdoe", Role: users.RoleViewer})
+
+	var validationErr dto.NotValidError
+	require.ErrorAs(t, err, &validationErr)
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps the password when none is given", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, repo := usersTest(t)
+
+		repo.EXPECT().GetByUsername(context.Background(), "jdoe").Return(&entity.User{Username: "jdoe", PasswordHash: "hash", Role: users.RoleViewer}, nil)
+		repo.EXPECT().Update(context.Background(), &entity.User{Username: "jdoe", PasswordHash: "hash", Role: users.RoleOperator}).Return(true, nil)
+
+		updated, err := useCase.Update(context.Background(), &dto.User{Username: "jdoe", Role: users.RoleOperator})
+		require.NoError(t, err)
+		require.Equal(t, users.RoleOperator, updated.Role)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, repo := usersTest(t)
+
+		repo.EXPECT().GetByUsername(context.Background(), "jdoe").Return(&entity.User{Username: "jdoe", TenantID: "tenant1"}, nil)
+
+		_, err := useCase.Update(context.Background(), &dto.User{Username: "jdoe", Role: users.RoleAdmin})
+		require.ErrorIs(t, err, users.ErrNotFound)
+	})
+}
+
+func TestPermissions(t *testing.T) {
+	t.Parallel()
+
+	require.Contains(t, users.Permissions(users.RoleViewer), users.PermissionDevicesRead)
+	require.NotContains(t, users.Permissions(users.RoleViewer), users.PermissionDevicesWrite)
+	require.NotContains(t, users.Permissions(users.RoleOperator), users.PermissionUsersManage)
+	require.Contains(t, users.Permissions(users.RoleAdmin), users.PermissionUsersManage)
+	require.Empty(t, users.Permissions("unknown"))
+}
@@ -0,0 +1,36 @@
+package wificonfigs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/device-management-toolkit/console/internal/entity"
+	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
+)
+
+// recordRevision stores the saved wireless config as a revision, the passphrase is kept as it was encrypted for the config.
+func (uc *UseCase) recordRevision(ctx context.Context, d2 *dto.WirelessConfig, d1 *entity.WirelessConfig) error {
+	secrets := map[string]string{"pskPassphrase": d1.PSKPassphrase}
+
+	if err := uc.revisions.Record(ctx, dto.RevisionKindWireless, d2.ProfileName, d2.TenantID, d2, secrets); err != nil {
+		return ErrDatabase.Wrap("recordRevision", "uc.revisions.Record", err)
+	}
+
+	return nil
+}
+
+// ApplyRevision updates the wireless config to the content of one of its revisions.
+func (uc *UseCase) ApplyRevision(ctx context.Context, content []byte, tenantID string) error {
+	d := &dto.WirelessConfig{}
+	if err := json.Unmarshal(content, d); err != nil {
+		return err
+	}
+
+	d.TenantID = tenantID
+	// a revision is applied over whatever version the config is at now
+	d.Version = ""
+
+	_, err := uc.Update(ctx, d)
+
+	return err
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/device-management-toolkit/console/internal/entity"
 	"github.com/device-management-toolkit/console/internal/entity/dto/v1"
 	"github.com/device-management-toolkit/console/internal/usecase/ieee8021xconfigs"
+	"github.com/device-management-toolkit/console/internal/usecase/revisions"
 	"github.com/device-management-toolkit/console/internal/usecase/sqldb"
 	"github.com/device-management-toolkit/console/pkg/consoleerrors"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 // UseCase -.
@@ -22,15 +24,17 @@ type UseCase struct {
 	ieee             ieee8021xconfigs.Feature
 	log              logger.Interface
 	safeRequirements security.Cryptor
+	revisions        revisions.Recorder
 }
 
 // New -.
-func New(r Repository, ieee ieee8021xconfigs.Feature, log logger.Interface, safeRequirements security.Cryptor) *UseCase {
+func New(r Repository, ieee ieee8021xconfigs.Feature, log logger.Interface, safeRequirements security.Cryptor, rev revisions.Recorder) *UseCase {
 	return &UseCase{
 		repo:             r,
 		ieee:             ieee,
 		log:              log,
 		safeRequirements: safeRequirements,
+		revisions:        rev,
 	}
 }
 
@@ -51,8 +55,8 @@ func (uc *UseCase) CheckProfileExists(ctx context.Context, profileName, tenantID
 	return data, nil
 }
 
-func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
-	count, err := uc.repo.GetCount(ctx, tenantID)
+func (uc *UseCase) GetCount(ctx context.Context, q odata.Query, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, q, tenantID)
 	if err != nil {
 		return 0, ErrDatabase.Wrap("Count", "uc.repo.GetCount", err)
 	}
@@ -60,8 +64,8 @@ func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
 	return count, nil
 }
 
-func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.WirelessConfig, error) {
-	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+func (uc *UseCase) Get(ctx context.Context, q odata.Query, top, skip int, tenantID string) ([]dto.WirelessConfig, error) {
+	data, err := uc.repo.Get(ctx, q, top, skip, tenantID)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
 	}
@@ -92,8 +96,8 @@ func (uc *UseCase) GetByName(ctx context.Context, profileName, tenantID string)
 	return d1, nil
 }
 
-func (uc *UseCase) Delete(ctx context.Context, profileName, tenantID string) error {
-	isSuccessful, err := uc.repo.Delete(ctx, profileName, tenantID)
+func (uc *UseCase) Delete(ctx context.Context, profileName, version, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, profileName, version, tenantID)
 	if err != nil {
 		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
 	}
@@ -116,22 +120,32 @@ func (uc *UseCase) Update(ctx context.Context, d *dto.WirelessConfig) (*dto.Wire
 		}
 	}
 
-	updated, err := uc.repo.Update(ctx, d1)
-	if err != nil {
-		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
-	}
+	var d2 *dto.WirelessConfig
 
-	if !updated {
-		return nil, ErrNotFound
-	}
+	// the wireless config is saved with its revision, a revision that cannot be recorded undoes the update
+	err := uc.revisions.Save(ctx, func(ctx context.Context) error {
+		updated, err := uc.repo.Update(ctx, d1)
+		if err != nil {
+			return ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+		}
+
+		if !updated {
+			return ErrNotFound
+		}
+
+		updatedConfig, err := uc.repo.GetByName(ctx, d1.ProfileName, d.TenantID)
+		if err != nil {
+			return err
+		}
+
+		d2 = uc.entityToDTO(updatedConfig)
 
-	updatedConfig, err := uc.repo.GetByName(ctx, d1.ProfileName, d.TenantID)
+		return uc.recordRevision(ctx, d2, d1)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	d2 := uc.entityToDTO(updatedConfig)
-
 	return d2, nil
 }
 
@@ -146,18 +160,28 @@ func (uc *UseCase) Insert(ctx context.Context, d *dto.WirelessConfig) (*dto.Wire
 		}
 	}
 
-	_, err := uc.repo.Insert(ctx, d1)
-	if err != nil {
-		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
-	}
+	var d2 *dto.WirelessConfig
+
+	// the wireless config is saved with its revision, a revision that cannot be recorded undoes the insert
+	err := uc.revisions.Save(ctx, func(ctx context.Context) error {
+		_, err := uc.repo.Insert(ctx, d1)
+		if err != nil {
+			return ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+		}
 
-	insertedConfig, err := uc.repo.GetByName(ctx, d.ProfileName, d.TenantID)
+		insertedConfig, err := uc.repo.GetByName(ctx, d.ProfileName, d.TenantID)
+		if err != nil {
+			return err
+		}
+
+		d2 = uc.entityToDTO(insertedConfig)
+
+		return uc.recordRevision(ctx, d2, d1)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	d2 := uc.entityToDTO(insertedConfig)
-
 	return d2, nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/device-management-toolkit/console/internal/mocks"
 	"github.com/device-management-toolkit/console/internal/usecase/wificonfigs"
 	"github.com/device-management-toolkit/console/pkg/logger"
+	"github.com/device-management-toolkit/console/pkg/odata"
 )
 
 type test struct {
@@ -31,11 +32,11 @@ func (m MockIEEE8021x) CheckProfileExists(_ context.Context, _, _ string) (bool,
 	return false, nil
 }
 
-func (m MockIEEE8021x) GetCount(_ context.Context, _ string) (int, error) {
+func (m MockIEEE8021x) GetCount(_ context.Context, _ odata.Query, _ string) (int, error) {
 	return 0, nil
 }
 
-func (m MockIEEE8021x) Get(_ context.Context, _, _ int, _ string) ([]dto.IEEE8021xConfig, error) {
+func (m MockIEEE8021x) Get(_ context.Context, _ odata.Query, _, _ int, _ string) ([]dto.IEEE8021xConfig, error) {
 	return []dto.IEEE8021xConfig{}, nil
 }
 
@@ -43,7 +44,7 @@ func (m MockIEEE8021x) GetByName(_ context.Context, _, _ string) (*dto.IEEE8021x
 	return &dto.IEEE8021xConfig{}, nil
 }
 
-func (m MockIEEE8021x) Delete(_ context.Context, _, _ string) error {
+func (m MockIEEE8021x) Delete(_ context.Context, _, _, _ string) error {
 	return nil
 }
 
@@ -65,7 +66,12 @@ func wificonfigsTest(t *testing.T) (*wificonfigs.UseCase, *mocks.MockWiFiConfigs
 	log := logger.New("error")
 	ieeeMock := MockIEEE8021x{}
 	cryptoMock := mocks.MockCrypto{}
-	useCase := wificonfigs.New(repo, ieeeMock, log, cryptoMock)
+	revisionsMock := mocks.NewMockRevisionsRecorder(mockCtl)
+	revisionsMock.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	revisionsMock.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	useCase := wificonfigs.New(repo, ieeeMock, log, cryptoMock, revisionsMock)
 
 	return useCase, repo
 }
@@ -121,7 +127,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "empty result",
 			mock: func(repo *mocks.MockWiFiConfigsRepository, args ...interface{}) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(args[0], args[1])
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(args[0], args[1])
 			},
 			res: 0,
 			err: nil,
@@ -129,7 +135,7 @@ func TestGetCount(t *testing.T) {
 		{
 			name: "result with error",
 			mock: func(repo *mocks.MockWiFiConfigsRepository, args ...interface{}) {
-				repo.EXPECT().GetCount(context.Background(), "").Return(args[0], args[1])
+				repo.EXPECT().GetCount(context.Background(), odata.Query{}, "").Return(args[0], args[1])
 			},
 			res: 0,
 			err: wificonfigs.ErrDatabase,
@@ -144,7 +150,7 @@ func TestGetCount(t *testing.T) {
 			useCase, repo := wificonfigsTest(t)
 			tc.mock(repo, tc.res, tc.err)
 
-			res, err := useCase.GetCount(context.Background(), "")
+			res, err := useCase.GetCount(context.Background(), odata.Query{}, "")
 
 			require.Equal(t, tc.res, res)
 			require.IsType(t, tc.err, err)
@@ -198,7 +204,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockWiFiConfigsRepository, args ...interface{}) {
 				repo.EXPECT().
-					Get(context.Background(), args[0], args[1], args[2]).
+					Get(context.Background(), odata.Query{}, args[0], args[1], args[2]).
 					Return(testWifiConfigsEntity, nil)
 			},
 			res: testWifiConfigDTOs,
@@ -211,7 +217,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockWiFiConfigsRepository, args ...interface{}) {
 				repo.EXPECT().
-					Get(context.Background(), args[0], args[1], args[2]).
+					Get(context.Background(), odata.Query{}, args[0], args[1], args[2]).
 					Return(nil, wificonfigs.ErrDatabase)
 			},
 			res: []dto.WirelessConfig(nil),
@@ -224,7 +230,7 @@ func TestGet(t *testing.T) {
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockWiFiConfigsRepository, args ...interface{}) {
 				repo.EXPECT().
-					Get(context.Background(), args[0], args[1], args[2]).
+					Get(context.Background(), odata.Query{}, args[0], args[1], args[2]).
 					Return([]entity.WirelessConfig{}, nil)
 			},
 			res: []dto.WirelessConfig{},
@@ -240,7 +246,7 @@ func TestGet(t *testing.T) {
 
 			tc.mock(repo, tc.top, tc.skip, tc.tenantID)
 
-			results, err := useCase.Get(context.Background(), tc.top, tc.skip, tc.tenantID)
+			results, err := useCase.Get(context.Background(), odata.Query{}, tc.top, tc.skip, tc.tenantID)
 
 			require.Equal(t, tc.res, results)
 
@@ -332,7 +338,7 @@ func TestDelete(t *testing.T) {
 			tenantID:    "tenant-id-456",
 			mock: func(repo *mocks.MockWiFiConfigsRepository, args ...interface{}) {
 				repo.EXPECT().
-					Delete(context.Background(), args[0], args[1]).
+					Delete(context.Background(), args[0], "", args[1]).
 					Return(true, nil)
 			},
 			err: nil,
@@ -343,7 +349,7 @@ func TestDelete(t *testing.T) {
 			tenantID:    "tenant-id-456",
 			mock: func(repo *mocks.MockWiFiConfigsRepository, args ...interface{}) {
 				repo.EXPECT().
-					Delete(context.Background(), args[0], args[1]).
+					Delete(context.Background(), args[0], "", args[1]).
 					Return(false, nil)
 			},
 			err: wificonfigs.ErrNotFound,
@@ -358,7 +364,7 @@ func TestDelete(t *testing.T) {
 
 			tc.mock(repo, tc.profileName, tc.tenantID)
 
-			err := useCase.Delete(context.Background(), tc.profileName, tc.tenantID)
+			err := useCase.Delete(context.Background(), tc.profileName, "", tc.tenantID)
 
 			if tc.err != nil {
 				require.Error(t, err)
@@ -0,0 +1,351 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// copyBatchSize is the number of rows inserted by one statement.
+const copyBatchSize = 100
+
+var (
+	ErrTargetNotEmpty = errors.New("the target database already holds data")
+	ErrCopyMismatch   = errors.New("the copied table does not match the source")
+	errTableCycle     = errors.New("the foreign keys of the tables form a cycle")
+)
+
+// querier is a database or a transaction on it.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// CopiedTable reports a table copied by CopySQLite.
+type CopiedTable struct {
+	Name     string
+	Rows     int
+	Checksum string
+}
+
+// CopySQLite copies every table of an SQLite database into target, which must have the same tables without
+// any rows, e.g. a database both were migrated on. The tables are copied in one transaction, each after the
+// tables its foreign keys reference, and compared by row count and a checksum of their rows before it commits,
+// so a copy that does not match leaves target empty.
+func CopySQLite(ctx context.Context, source *sql.DB, target *SQL) ([]CopiedTable, error) {
+	tables, err := sqliteTables(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		var count int
+		if err := target.Pool.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&count); err != nil {
+			return nil, fmt.Errorf("count %s: %w", table, err)
+		}
+
+		if count > 0 {
+			return nil, fmt.Errorf("%w: %s has %d rows", ErrTargetNotEmpty, table, count)
+		}
+	}
+
+	tx, err := target.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	for _, table := range tables {
+		if err := copyTable(ctx, source, tx, target, table); err != nil {
+			return nil, fmt.Errorf("copy %s: %w", table, err)
+		}
+	}
+
+	copied := make([]CopiedTable, 0, len(tables))
+
+	for _, table := range tables {
+		want, err := checksum(ctx, source, table)
+		if err != nil {
+			return nil, fmt.Errorf("checksum %s: %w", table, err)
+		}
+
+		// read within the transaction, a copy that does not match is rolled back
+		got, err := checksum(ctx, tx, table)
+		if err != nil {
+			return nil, fmt.Errorf("checksum %s: %w", table, err)
+		}
+
+		if got != want {
+			return nil, fmt.Errorf("%w: %s has %d rows with checksum %s, expected %d rows with checksum %s",
+				ErrCopyMismatch, table, got.Rows, got.Checksum, want.Rows, want.Checksum)
+		}
+
+		copied = append(copied, got)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return copied, nil
+}
+
+// sqliteTables returns the tables of an SQLite database other than the migration bookkeeping, every table
+// after the tables it references.
+func sqliteTables(ctx context.Context, source *sql.DB) ([]string, error) {
+	rows, err := source.QueryContext(ctx,
+		"SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_migrations' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	references := make(map[string][]string, len(names))
+
+	for _, name := range names {
+		if references[name], err = sqliteReferences(ctx, source, name); err != nil {
+			return nil, err
+		}
+	}
+
+	ordered := make([]string, 0, len(names))
+	done := make(map[string]bool, len(names))
+
+	for len(ordered) < len(names) {
+		progress := false
+
+		for _, name := range names {
+			if done[name] {
+				continue
+			}
+
+			ready := true
+
+			for _, ref := range references[name] {
+				if ref != name && !done[ref] && slices.Contains(names, ref) {
+					ready = false
+				}
+			}
+
+			if ready {
+				ordered = append(ordered, name)
+				done[name] = true
+				progress = true
+			}
+		}
+
+		if !progress {
+			return nil, errTableCycle
+		}
+	}
+
+	return ordered, nil
+}
+
+// sqliteReferences returns the tables the foreign keys of table reference.
+func sqliteReferences(ctx context.Context, source *sql.DB, table string) ([]string, error) {
+	rows, err := source.QueryContext(ctx, "SELECT DISTINCT \"table\" FROM pragma_foreign_key_list(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	references := make([]string, 0)
+
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+
+		references = append(references, ref)
+	}
+
+	return references, rows.Err()
+}
+
+func copyTable(ctx context.Context, source *sql.DB, tx *sql.Tx, target *SQL, table string) error {
+	rows, err := source.QueryContext(ctx, "SELECT * FROM "+table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name()
+	}
+
+	insert := target.Builder.Insert(table).Columns(names...)
+	batch := 0
+
+	flush := func() error {
+		if batch == 0 {
+			return nil
+		}
+
+		sqlQuery, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+			return err
+		}
+
+		insert = target.Builder.Insert(table).Columns(names...)
+		batch = 0
+
+		return nil
+	}
+
+	for rows.Next() {
+		values, err := ScanValues(rows, columns)
+		if err != nil {
+			return err
+		}
+
+		insert = insert.Values(values...)
+		batch++
+
+		if batch == copyBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// ScanValues scans a row, turning text read as bytes into strings and integers of boolean columns into
+// booleans, as SQLite stores them, so rows read from either database look the same.
+func ScanValues(rows *sql.Rows, columns []*sql.ColumnType) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	for i, c := range columns {
+		switch v := values[i].(type) {
+		case []byte:
+			values[i] = string(v)
+		case int64:
+			if isBoolean(c) {
+				values[i] = v != 0
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func isBoolean(c *sql.ColumnType) bool {
+	t := strings.ToUpper(c.DatabaseTypeName())
+
+	return t == "BOOLEAN" || t == "BOOL"
+}
+
+// checksum hashes every row of a table independently of the order the rows are read in, the order of the
+// columns and the database the table is kept in.
+func checksum(ctx context.Context, database querier, table string) (CopiedTable, error) {
+	rows, err := database.QueryContext(ctx, "SELECT * FROM "+table)
+	if err != nil {
+		return CopiedTable{}, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return CopiedTable{}, err
+	}
+
+	order := make([]int, len(columns))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortFunc(order, func(a, b int) int { return strings.Compare(columns[a].Name(), columns[b].Name()) })
+
+	hashes := make([]string, 0)
+
+	for rows.Next() {
+		values, err := ScanValues(rows, columns)
+		if err != nil {
+			return CopiedTable{}, err
+		}
+
+		h := sha256.New()
+
+		for _, i := range order {
+			fmt.Fprintf(h, "%s=%s;", columns[i].Name(), canonical(values[i]))
+		}
+
+		hashes = append(hashes, hex.EncodeToString(h.Sum(nil)))
+	}
+
+	if err := rows.Err(); err != nil {
+		return CopiedTable{}, err
+	}
+
+	slices.Sort(hashes)
+
+	sum := sha256.Sum256([]byte(strings.Join(hashes, "")))
+
+	return CopiedTable{Name: table, Rows: len(hashes), Checksum: hex.EncodeToString(sum[:])}, nil
+}
+
+func canonical(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+)
+
+// copySchema has a table that sorts before the table it references.
+const copySchema = `
+	PRAGMA foreign_keys = ON;
+	CREATE TABLE schema_migrations (version INTEGER, dirty BOOLEAN);
+	CREATE TABLE parents (name TEXT NOT NULL PRIMARY KEY, enabled BOOLEAN NOT NULL, weight REAL);
+	CREATE TABLE children (name TEXT NOT NULL PRIMARY KEY, parent TEXT REFERENCES parents(name), priority INTEGER);
+	CREATE TABLE log (message TEXT);`
+
+func copyTestDB(t *testing.T, statements ...string) *sql.DB {
+	t.Helper()
+
+	database, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	database.SetMaxOpenConns(1)
+
+	t.Cleanup(func() { database.Close() })
+
+	for _, statement := range statements {
+		_, err = database.ExecContext(context.Background(), statement)
+		require.NoError(t, err)
+	}
+
+	return database
+}
+
+func TestCopySQLite(t *testing.T) {
+	t.Parallel()
+
+	source := copyTestDB(t, copySchema, `
+		INSERT INTO schema_migrations VALUES (20250805000000, false);
+		INSERT INTO parents VALUES ('p1', true, 1.5), ('p2', false, NULL);
+		INSERT INTO children VALUES ('c1', 'p1', 1), ('c2', 'p2', 2), ('c3', NULL, NULL);
+		INSERT INTO log VALUES ('same'), ('same'), ('it''s quoted');`)
+
+	targetDB := copyTestDB(t, copySchema)
+	target := &SQL{Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question), Pool: targetDB}
+
+	copied, err := CopySQLite(context.Background(), source, target)
+	require.NoError(t, err)
+	require.Len(t, copied, 3)
+	require.Equal(t, "parents", copied[1].Name)
+	require.Equal(t, "children", copied[2].Name)
+	require.Equal(t, 3, copied[0].Rows)
+	require.Equal(t, 2, copied[1].Rows)
+
+	var enabled bool
+
+	require.NoError(t, targetDB.QueryRowContext(context.Background(), "SELECT enabled FROM parents WHERE name = 'p1'").Scan(&enabled))
+	require.True(t, enabled)
+
+	var migrations int
+
+	require.NoError(t, targetDB.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM schema_migrations").Scan(&migrations))
+	require.Equal(t, 0, migrations)
+
+	_, err = CopySQLite(context.Background(), source, target)
+	require.ErrorIs(t, err, ErrTargetNotEmpty)
+}
+
+func TestCopySQLiteMismatch(t *testing.T) {
+	t.Parallel()
+
+	source := copyTestDB(t, copySchema, `
+		INSERT INTO parents VALUES ('p1', true, 1.5);
+		INSERT INTO children VALUES ('c1', 'p1', 1);`)
+
+	// the target changes what is written to it
+	targetDB := copyTestDB(t, copySchema, `
+		CREATE TRIGGER reweigh AFTER INSERT ON parents BEGIN UPDATE parents SET weight = 0 WHERE name = NEW.name; END;`)
+	target := &SQL{Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question), Pool: targetDB}
+
+	_, err := CopySQLite(context.Background(), source, target)
+	require.ErrorIs(t, err, ErrCopyMismatch)
+
+	// nothing of the copy was committed
+	for _, table := range []string{"parents", "children"} {
+		var count int
+
+		require.NoError(t, targetDB.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM "+table).Scan(&count))
+		require.Zero(t, count, table)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	a := copyTestDB(t, copySchema, `INSERT INTO parents VALUES ('p1', true, 1.5), ('p2', false, NULL);`)
+	b := copyTestDB(t, copySchema, `INSERT INTO parents (weight, enabled, name) VALUES (NULL, false, 'p2'), (1.5, true, 'p1');`)
+	c := copyTestDB(t, copySchema, `INSERT INTO parents VALUES ('p1', true, 1.5), ('p2', true, NULL);`)
+
+	sumA, err := checksum(context.Background(), a, "parents")
+	require.NoError(t, err)
+
+	sumB, err := checksum(context.Background(), b, "parents")
+	require.NoError(t, err)
+
+	sumC, err := checksum(context.Background(), c, "parents")
+	require.NoError(t, err)
+
+	require.Equal(t, sumA, sumB)
+	require.NotEqual(t, sumA.Checksum, sumC.Checksum)
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -36,6 +37,14 @@ type SQL struct {
 	enableForeignKeys bool
 }
 
+var errNoPlan = errors.New("the database returned no query plan")
+
+// IsPostgres reports whether url points to a Postgres database, which it does with either URL scheme Postgres
+// accepts.
+func IsPostgres(url string) bool {
+	return strings.HasPrefix(url, "postgres://") || strings.HasPrefix(url, "postgresql://")
+}
+
 // OpenFunc is a type for functions that open a database connection.
 type OpenFunc func(driverName, dataSourceName string) (*sql.DB, error)
 
@@ -56,7 +65,7 @@ func New(url string, dbOpen OpenFunc, opts ...Option) (*SQL, error) {
 
 	var err error
 
-	if strings.HasPrefix(url, "postgres://") {
+	if IsPostgres(url) {
 		err = setupHostedDB(db, url, dbOpen)
 		if err != nil {
 			return nil, err
@@ -129,6 +138,39 @@ func (p *SQL) Close() {
 	}
 }
 
+// EstimateCount returns about how many rows query, a SELECT, returns. Postgres takes it from the plan of the
+// query, which stays fast however many rows match, while the embedded database counts them.
+func (p *SQL) EstimateCount(ctx context.Context, query string, args ...interface{}) (int, error) {
+	var count int
+
+	if p.IsEmbedded {
+		err := p.Pool.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+query+") AS estimated", args...).Scan(&count)
+
+		return count, err
+	}
+
+	var plan []byte
+	if err := p.Pool.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&plan); err != nil {
+		return 0, err
+	}
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return 0, err
+	}
+
+	if len(explained) == 0 {
+		return 0, errNoPlan
+	}
+
+	return int(explained[0].Plan.Rows), nil
+}
+
 func CheckNotUnique(err error) bool {
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
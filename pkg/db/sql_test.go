@@ -60,6 +60,15 @@ func TestNew_Embedded(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestIsPostgres(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsPostgres("postgres://localhost:5432/testdb"))
+	assert.True(t, IsPostgres("postgresql://localhost:5432/testdb"))
+	assert.False(t, IsPostgres("sqlite://localhost:5432/testdb"))
+	assert.False(t, IsPostgres(""))
+}
+
 var ErrTest = errors.New("test error")
 
 func TestCheckNotUnique(t *testing.T) {
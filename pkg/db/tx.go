@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor runs statements on the pool or inside a transaction.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// InTx runs fn in a transaction, which commits when fn succeeds and rolls back when it fails. The statements
+// repositories run with the context fn gets, see Conn, are part of the transaction. fn joins the transaction of
+// ctx when there is one.
+func (p *SQL) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if HasTx(ctx) {
+		return fn(ctx)
+	}
+
+	tx, err := p.Pool.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint:errcheck // fails after a commit, which is intended
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Conn returns the transaction ctx runs in, or the pool outside of one.
+func (p *SQL) Conn(ctx context.Context) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return p.Pool
+}
+
+// HasTx reports whether ctx runs in a transaction started by SQL.InTx.
+func HasTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*sql.Tx)
+
+	return ok
+}
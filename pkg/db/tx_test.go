@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInTx(t *testing.T) {
+	t.Parallel()
+
+	pool, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	// every connection of an in-memory database is a database of its own
+	pool.SetMaxOpenConns(1)
+
+	defer pool.Close()
+
+	ctx := context.Background()
+
+	_, err = pool.ExecContext(ctx, "CREATE TABLE items (name TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	database := &SQL{Pool: pool, IsEmbedded: true}
+	require.Equal(t, Executor(pool), database.Conn(ctx))
+	require.False(t, HasTx(ctx))
+
+	insert := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			require.True(t, HasTx(ctx))
+
+			_, err := database.Conn(ctx).ExecContext(ctx, "INSERT INTO items (name) VALUES (?)", name)
+
+			return err
+		}
+	}
+
+	require.NoError(t, database.InTx(ctx, insert("committed")))
+
+	errSave := errors.New("save failed")
+
+	err = database.InTx(ctx, func(ctx context.Context) error {
+		// a nested transaction joins the one of ctx and is rolled back with it
+		require.NoError(t, database.InTx(ctx, insert("rolled back")))
+
+		return errSave
+	})
+	require.ErrorIs(t, err, errSave)
+
+	var names []string
+
+	rows, err := pool.QueryContext(ctx, "SELECT name FROM items")
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+
+		require.NoError(t, rows.Scan(&name))
+
+		names = append(names, name)
+	}
+
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"committed"}, names)
+}
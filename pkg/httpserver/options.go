@@ -35,3 +35,10 @@ func ShutdownTimeout(timeout time.Duration) Option {
 		s.shutdownTimeout = timeout
 	}
 }
+
+// TLS serves HTTPS instead of HTTP.
+func TLS(cfg TLSConfig) Option {
+	return func(s *Server) {
+		s.tls = &cfg
+	}
+}
@@ -3,6 +3,7 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"time"
 )
@@ -19,6 +20,7 @@ type Server struct {
 	server          *http.Server
 	notify          chan error
 	shutdownTimeout time.Duration
+	tls             *TLSConfig
 }
 
 // New -.
@@ -47,8 +49,32 @@ func New(handler http.Handler, opts ...Option) *Server {
 }
 
 func (s *Server) start() {
+	if s.tls == nil {
+		go func() {
+			s.notify <- s.server.ListenAndServe()
+
+			close(s.notify)
+		}()
+
+		return
+	}
+
+	reloader, err := newTLSReloader(*s.tls)
+	if err != nil {
+		s.notify <- err
+
+		close(s.notify)
+
+		return
+	}
+
+	s.server.TLSConfig = &tls.Config{
+		MinVersion:         reloader.base.MinVersion,
+		GetConfigForClient: reloader.GetConfigForClient,
+	}
+
 	go func() {
-		s.notify <- s.server.ListenAndServe()
+		s.notify <- s.server.ListenAndServeTLS("", "")
 
 		close(s.notify)
 	}()
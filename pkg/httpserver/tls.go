@@ -0,0 +1,258 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const _defaultReloadInterval = 30 * time.Second
+
+var (
+	errTLSConfig    = errors.New("invalid tls configuration")
+	errNoClientCert = errors.New("no certificate found in the client CA file")
+)
+
+// TLSConfig configures HTTPS. The certificate, key and client CA files are checked for changes at most once per
+// ReloadInterval, on the next handshake, and loaded again when they changed. A file that fails to load keeps the
+// previous configuration in use.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// MinVersion is "1.2" or "1.3", "1.0" and "1.1" are only accepted with AllowInsecureCiphers.
+	MinVersion string
+	// AllowInsecureCiphers adds the RSA key exchange suites older AMT firmware and clients need.
+	AllowInsecureCiphers bool
+	// ClientCAFile enables client certificate authentication with the CAs in the file.
+	ClientCAFile string
+	// ClientAuth is "require", the default with a ClientCAFile, or "optional" to verify certificates when given.
+	ClientAuth     string
+	ReloadInterval time.Duration
+	// ReloadError is called with why changed files could not be loaded, once until they fail differently or
+	// load again. It is called during a handshake and must not block.
+	ReloadError func(err error)
+}
+
+// secureCipherSuites are used for TLS 1.2, TLS 1.3 suites are not configurable.
+var secureCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+// insecureCipherSuites mirrors the suites the wsman client adds when insecure ciphers are allowed.
+var insecureCipherSuites = []uint16{
+	tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+}
+
+// tlsReloader hands out the current tls.Config and builds a new one when one of its files changed.
+type tlsReloader struct {
+	cfg  TLSConfig
+	base *tls.Config
+
+	mu        sync.Mutex
+	current   *tls.Config
+	modTimes  []time.Time
+	lastCheck time.Time
+	// lastErr is why the files could not be loaded again on the last check, it was reported already
+	lastErr error
+	now     func() time.Time
+}
+
+func newTLSReloader(cfg TLSConfig) (*tlsReloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("%w: a certificate and a key file are required", errTLSConfig)
+	}
+
+	base, err := baseTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = _defaultReloadInterval
+	}
+
+	r := &tlsReloader{cfg: cfg, base: base, now: time.Now}
+
+	modTimes, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.current, err = r.load(); err != nil {
+		return nil, err
+	}
+
+	r.modTimes = modTimes
+	r.lastCheck = r.now()
+
+	return r, nil
+}
+
+func baseTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	c := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: secureCipherSuites,
+	}
+
+	switch cfg.MinVersion {
+	case "", "1.2":
+	case "1.3":
+		c.MinVersion = tls.VersionTLS13
+	case "1.0", "1.1":
+		if !cfg.AllowInsecureCiphers {
+			return nil, fmt.Errorf("%w: TLS %s needs insecure ciphers to be allowed", errTLSConfig, cfg.MinVersion)
+		}
+
+		c.MinVersion = tls.VersionTLS10
+		if cfg.MinVersion == "1.1" {
+			c.MinVersion = tls.VersionTLS11
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown TLS version %q", errTLSConfig, cfg.MinVersion)
+	}
+
+	if cfg.AllowInsecureCiphers {
+		c.CipherSuites = make([]uint16, 0, len(tls.CipherSuites())+len(insecureCipherSuites))
+		for _, suite := range tls.CipherSuites() {
+			c.CipherSuites = append(c.CipherSuites, suite.ID)
+		}
+
+		c.CipherSuites = append(c.CipherSuites, insecureCipherSuites...)
+	}
+
+	switch {
+	case cfg.ClientCAFile == "":
+	case cfg.ClientAuth == "" || cfg.ClientAuth == "require":
+		c.ClientAuth = tls.RequireAndVerifyClientCert
+	case cfg.ClientAuth == "optional":
+		c.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return nil, fmt.Errorf("%w: unknown client auth %q", errTLSConfig, cfg.ClientAuth)
+	}
+
+	return c, nil
+}
+
+// GetConfigForClient implements tls.Config.GetConfigForClient.
+func (r *tlsReloader) GetConfigForClient(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastCheck) < r.cfg.ReloadInterval {
+		return r.current, nil
+	}
+
+	r.lastCheck = now
+
+	modTimes, err := r.stat()
+	if err != nil {
+		r.fail(err)
+
+		return r.current, nil
+	}
+
+	if timesEqual(modTimes, r.modTimes) {
+		return r.current, nil
+	}
+
+	next, err := r.load()
+	if err != nil {
+		// a certificate and key written one after the other do not match in between, the next check retries
+		r.fail(err)
+
+		return r.current, nil
+	}
+
+	r.current = next
+	r.modTimes = modTimes
+	r.lastErr = nil
+
+	return r.current, nil
+}
+
+// fail keeps the current configuration and reports err unless the last check failed the same way. It must be
+// called with r.mu held.
+func (r *tlsReloader) fail(err error) {
+	if r.cfg.ReloadError != nil && (r.lastErr == nil || r.lastErr.Error() != err.Error()) {
+		r.cfg.ReloadError(err)
+	}
+
+	r.lastErr = err
+}
+
+func (r *tlsReloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+
+	return files
+}
+
+func (r *tlsReloader) stat() ([]time.Time, error) {
+	files := r.files()
+	modTimes := make([]time.Time, len(files))
+
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("httpserver - tls - os.Stat: %w", err)
+		}
+
+		modTimes[i] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+func (r *tlsReloader) load() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("httpserver - tls - tls.LoadX509KeyPair: %w", err)
+	}
+
+	c := r.base.Clone()
+	c.Certificates = []tls.Certificate{cert}
+
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("httpserver - tls - os.ReadFile: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("httpserver - tls - %s: %w", r.cfg.ClientCAFile, errNoClientCert)
+		}
+
+		c.ClientCAs = pool
+	}
+
+	return c, nil
+}
+
+func timesEqual(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,235 @@
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCert writes a self-signed certificate for commonName and its key to dir.
+func writeCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func commonName(t *testing.T, c *tls.Config) string {
+	t.Helper()
+
+	leaf, err := x509.ParseCertificate(c.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func TestBaseTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		cfg        TLSConfig
+		minVersion uint16
+		clientAuth tls.ClientAuthType
+		insecure   bool
+		err        bool
+	}{
+		{name: "defaults", minVersion: tls.VersionTLS12},
+		{name: "tls 1.3", cfg: TLSConfig{MinVersion: "1.3"}, minVersion: tls.VersionTLS13},
+		{name: "tls 1.0 without insecure ciphers", cfg: TLSConfig{MinVersion: "1.0"}, err: true},
+		{name: "tls 1.1 with insecure ciphers", cfg: TLSConfig{MinVersion: "1.1", AllowInsecureCiphers: true}, minVersion: tls.VersionTLS11, insecure: true},
+		{name: "unknown version", cfg: TLSConfig{MinVersion: "2"}, err: true},
+		{name: "client certificates", cfg: TLSConfig{ClientCAFile: "ca.pem"}, minVersion: tls.VersionTLS12, clientAuth: tls.RequireAndVerifyClientCert},
+		{name: "optional client certificates", cfg: TLSConfig{ClientCAFile: "ca.pem", ClientAuth: "optional"}, minVersion: tls.VersionTLS12, clientAuth: tls.VerifyClientCertIfGiven},
+		{name: "client auth without CA", cfg: TLSConfig{ClientAuth: "optional"}, minVersion: tls.VersionTLS12},
+		{name: "unknown client auth", cfg: TLSConfig{ClientCAFile: "ca.pem", ClientAuth: "maybe"}, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := baseTLSConfig(tc.cfg)
+			if tc.err {
+				require.ErrorIs(t, err, errTLSConfig)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.minVersion, c.MinVersion)
+			assert.Equal(t, tc.clientAuth, c.ClientAuth)
+			assert.Equal(t, tc.insecure, len(c.CipherSuites) > len(secureCipherSuites))
+			assert.Contains(t, c.CipherSuites, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256)
+		})
+	}
+}
+
+func TestTLSReloader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "first")
+
+	var reported []error
+
+	r, err := newTLSReloader(TLSConfig{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		ClientCAFile:   certFile,
+		ReloadInterval: time.Minute,
+		ReloadError:    func(err error) { reported = append(reported, err) },
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	c, err := r.GetConfigForClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", commonName(t, c))
+	assert.NotNil(t, c.ClientCAs)
+
+	writeCert(t, dir, "second")
+
+	later := now.Add(2 * time.Second)
+	for _, file := range []string{certFile, keyFile} {
+		require.NoError(t, os.Chtimes(file, later, later))
+	}
+
+	// not checked again before the interval passed
+	c, _ = r.GetConfigForClient(nil)
+	assert.Equal(t, "first", commonName(t, c))
+
+	now = now.Add(time.Minute)
+
+	c, _ = r.GetConfigForClient(nil)
+	assert.Equal(t, "second", commonName(t, c))
+
+	// a broken key keeps the certificate in use
+	require.NoError(t, os.WriteFile(keyFile, []byte("broken"), 0o600))
+
+	latest := later.Add(2 * time.Second)
+	require.NoError(t, os.Chtimes(keyFile, latest, latest))
+
+	now = now.Add(time.Minute)
+
+	c, _ = r.GetConfigForClient(nil)
+	assert.Equal(t, "second", commonName(t, c))
+	require.Len(t, reported, 1)
+	require.ErrorContains(t, reported[0], "tls.LoadX509KeyPair")
+
+	// the same failure on the next check is not reported again
+	now = now.Add(time.Minute)
+
+	_, _ = r.GetConfigForClient(nil)
+	require.Len(t, reported, 1)
+
+	// a missing file fails differently
+	require.NoError(t, os.Remove(keyFile))
+
+	now = now.Add(time.Minute)
+
+	c, _ = r.GetConfigForClient(nil)
+	assert.Equal(t, "second", commonName(t, c))
+	require.Len(t, reported, 2)
+	require.ErrorIs(t, reported[1], os.ErrNotExist)
+}
+
+func TestNewTLSReloaderErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "console")
+
+	_, err := newTLSReloader(TLSConfig{CertFile: certFile})
+	require.ErrorIs(t, err, errTLSConfig)
+
+	_, err = newTLSReloader(TLSConfig{CertFile: certFile, KeyFile: filepath.Join(dir, "missing.pem")})
+	require.Error(t, err)
+
+	_, err = newTLSReloader(TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile})
+	require.ErrorIs(t, err, errNoClientCert)
+}
+
+func TestNewTLSNotifiesConfigError(t *testing.T) {
+	t.Parallel()
+
+	s := New(http.NewServeMux(), Port("localhost", "0"), TLS(TLSConfig{}))
+
+	err := <-s.Notify()
+	require.ErrorIs(t, err, errTLSConfig)
+}
+
+func TestServeTLS(t *testing.T) { //nolint:paralleltest // httpserver can't be bind to multiple ports at the same time for tests
+	certFile, keyFile := writeCert(t, t.TempDir(), "localhost")
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	s := New(handler, Port("localhost", "9443"), TLS(TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.3"}))
+	defer s.server.Close()
+
+	pemData, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(pemData))
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS13}}}
+
+	var resp *http.Response
+
+	require.Eventually(t, func() bool {
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://localhost:9443/", http.NoBody)
+
+		resp, err = client.Do(req)
+
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+}
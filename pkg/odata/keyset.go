@@ -0,0 +1,193 @@
+package odata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Keyset pages through a list without OFFSET. A page starts right after the sort key of the last row of the
+// previous one, which the database seeks to instead of reading and dropping every row before it, and rows
+// inserted or removed meanwhile neither shift nor repeat the rows of the pages that follow.
+//
+// The sort key is the $orderby properties followed by a column unique to every row, which keeps the order
+// stable. Nulls come last whatever the direction, the same way in every database.
+type Keyset struct {
+	columns    []string
+	descending []bool
+	nullable   []bool
+	orderBy    string
+}
+
+// skipToken is what a $skiptoken holds: the $orderby it was made for and the sort key of the last row read.
+type skipToken struct {
+	OrderBy string        `json:"o"`
+	Values  []interface{} `json:"v"`
+}
+
+// Keyset returns the keyset of the $orderby of q followed by key, a column that is never null and unique to
+// every row.
+func (f Fields) Keyset(q Query, key string) (Keyset, error) {
+	k := Keyset{}
+	orderBy := make([]string, 0, len(q.OrderBy))
+
+	for _, o := range q.OrderBy {
+		field, err := f.lookup(o.Property)
+		if err != nil {
+			return Keyset{}, err
+		}
+
+		direction := "asc"
+		if o.Descending {
+			direction = "desc"
+		}
+
+		k.columns = append(k.columns, field.Column)
+		k.descending = append(k.descending, o.Descending)
+		k.nullable = append(k.nullable, !field.NotNull)
+		orderBy = append(orderBy, strings.ToLower(o.Property)+" "+direction)
+	}
+
+	k.columns = append(k.columns, key)
+	k.descending = append(k.descending, false)
+	k.nullable = append(k.nullable, false)
+	k.orderBy = strings.Join(orderBy, ",")
+
+	return k, nil
+}
+
+// Columns returns the columns of the sort key. A page selects them after its own columns so that Token can
+// be made from its last row.
+func (k Keyset) Columns() []string {
+	return k.columns
+}
+
+// OrderBy adds the order of the sort key to b.
+func (k Keyset) OrderBy(b squirrel.SelectBuilder) squirrel.SelectBuilder {
+	orderBy := make([]string, 0, len(k.columns))
+
+	for i, column := range k.columns {
+		if k.nullable[i] {
+			orderBy = append(orderBy, "("+column+" IS NULL)")
+		}
+
+		if k.descending[i] {
+			column += " DESC"
+		}
+
+		orderBy = append(orderBy, column)
+	}
+
+	return b.OrderBy(orderBy...)
+}
+
+// After adds to b the condition that keeps the rows following the one token was made from. An empty token
+// keeps every row.
+func (k Keyset) After(b squirrel.SelectBuilder, token string) (squirrel.SelectBuilder, error) {
+	if token == "" {
+		return b, nil
+	}
+
+	values, err := k.decode(token)
+	if err != nil {
+		return b, err
+	}
+
+	// (a, b) follows (x, y) when a is beyond x, or a equals x and b is beyond y
+	after := make(squirrel.Or, 0, len(k.columns))
+
+	for i := range k.columns {
+		beyond, ok := k.beyond(i, values[i])
+		if !ok {
+			continue
+		}
+
+		same := make(squirrel.And, 0, i+1)
+		for j := 0; j < i; j++ {
+			same = append(same, squirrel.Eq{k.columns[j]: values[j]})
+		}
+
+		after = append(after, append(same, beyond))
+	}
+
+	return b.Where(after), nil
+}
+
+// Token makes the $skiptoken of the page that follows the row whose sort key is values, in the order of
+// Columns.
+func (k Keyset) Token(values []interface{}) (string, error) {
+	t := skipToken{OrderBy: k.orderBy, Values: make([]interface{}, len(values))}
+
+	for i, v := range values {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+
+		t.Values[i] = v
+	}
+
+	content, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+func (k Keyset) decode(token string) ([]interface{}, error) {
+	content, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errorf("invalid $skiptoken")
+	}
+
+	var t skipToken
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&t); err != nil {
+		return nil, errorf("invalid $skiptoken")
+	}
+
+	if t.OrderBy != k.orderBy || len(t.Values) != len(k.columns) || t.Values[len(t.Values)-1] == nil {
+		return nil, errorf("$skiptoken does not belong to this $orderby")
+	}
+
+	for i, v := range t.Values {
+		n, ok := v.(json.Number)
+		if !ok {
+			continue
+		}
+
+		if t.Values[i], err = n.Int64(); err != nil {
+			if t.Values[i], err = n.Float64(); err != nil {
+				return nil, errorf("invalid $skiptoken")
+			}
+		}
+	}
+
+	return t.Values, nil
+}
+
+// beyond is the condition on the column at i of the rows that come after value. There are none after a null.
+func (k Keyset) beyond(i int, value interface{}) (squirrel.Sqlizer, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	column := k.columns[i]
+
+	var cond squirrel.Sqlizer = squirrel.Gt{column: value}
+	if k.descending[i] {
+		cond = squirrel.Lt{column: value}
+	}
+
+	if !k.nullable[i] {
+		return cond, true
+	}
+
+	return squirrel.Or{cond, squirrel.Eq{column: nil}}, true
+}
@@ -0,0 +1,121 @@
+package odata_test
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+func TestKeyset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		orderBy string
+		values  []interface{}
+		sql     string
+		args    []interface{}
+	}{
+		{
+			name:   "key only",
+			values: []interface{}{"g1"},
+			sql:    "SELECT * FROM devices WHERE ((guid > ?)) ORDER BY guid",
+			args:   []interface{}{"g1"},
+		},
+		{
+			name:    "descending property",
+			orderBy: "lastSeen desc",
+			values:  []interface{}{"2026-01-01T00:00:00Z", "g1"},
+			sql: "SELECT * FROM devices WHERE (((lastseen < ? OR lastseen IS NULL)) OR (lastseen = ? AND guid > ?)) " +
+				"ORDER BY (lastseen IS NULL), lastseen DESC, guid",
+			args: []interface{}{"2026-01-01T00:00:00Z", "2026-01-01T00:00:00Z", "g1"},
+		},
+		{
+			name:    "not null property",
+			orderBy: "createdAt desc",
+			values:  []interface{}{"2026-01-01T00:00:00Z", "g1"},
+			sql:     "SELECT * FROM devices WHERE ((created_at < ?) OR (created_at = ? AND guid > ?)) ORDER BY created_at DESC, guid",
+			args:    []interface{}{"2026-01-01T00:00:00Z", "2026-01-01T00:00:00Z", "g1"},
+		},
+		{
+			name:    "null and numbers",
+			orderBy: "hostname,mpsPort",
+			values:  []interface{}{nil, int64(4433), "g1"},
+			sql: "SELECT * FROM devices WHERE ((hostname IS NULL AND (mps_port > ? OR mps_port IS NULL)) OR (hostname IS NULL AND mps_port = ? AND guid > ?)) " +
+				"ORDER BY (hostname IS NULL), hostname, (mps_port IS NULL), mps_port, guid",
+			args: []interface{}{int64(4433), int64(4433), "g1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := odata.Parse("", tc.orderBy)
+			require.NoError(t, err)
+
+			keyset, err := deviceFields.Keyset(query, "guid")
+			require.NoError(t, err)
+
+			token, err := keyset.Token(tc.values)
+			require.NoError(t, err)
+
+			b, err := keyset.After(squirrel.Select("*").From("devices"), token)
+			require.NoError(t, err)
+
+			sql, args, err := keyset.OrderBy(b).ToSql()
+			require.NoError(t, err)
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestKeysetFirstPage(t *testing.T) {
+	t.Parallel()
+
+	keyset, err := deviceFields.Keyset(odata.Query{}, "guid")
+	require.NoError(t, err)
+	require.Equal(t, []string{"guid"}, keyset.Columns())
+
+	b, err := keyset.After(squirrel.Select("*").From("devices"), "")
+	require.NoError(t, err)
+
+	sql, _, err := b.ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM devices", sql)
+}
+
+func TestKeysetErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := deviceFields.Keyset(odata.Query{OrderBy: []odata.Order{{Property: "password"}}}, "guid")
+	require.ErrorAs(t, err, &odata.Error{})
+
+	byHostname, err := deviceFields.Keyset(odata.Query{OrderBy: []odata.Order{{Property: "hostname"}}}, "guid")
+	require.NoError(t, err)
+
+	byKey, err := deviceFields.Keyset(odata.Query{}, "guid")
+	require.NoError(t, err)
+
+	token, err := byHostname.Token([]interface{}{[]byte("host"), "g1"})
+	require.NoError(t, err)
+
+	b := squirrel.Select("*").From("devices")
+
+	// a token only fits the $orderby it was made for
+	_, err = byKey.After(b, token)
+	require.ErrorAs(t, err, &odata.Error{})
+
+	_, err = byKey.After(b, "not a token")
+	require.ErrorAs(t, err, &odata.Error{})
+
+	nullKey, err := byKey.Token([]interface{}{nil})
+	require.NoError(t, err)
+
+	_, err = byKey.After(b, nullKey)
+	require.ErrorAs(t, err, &odata.Error{})
+}
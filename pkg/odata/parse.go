@@ -0,0 +1,450 @@
+package odata
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxDepth bounds the nesting of parentheses and not.
+const maxDepth = 32
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenString
+	tokenLiteral
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(s string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(s); {
+		r := rune(s[i])
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenOpen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenClose, ")", i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", i})
+			i++
+		case r == '\'':
+			text, end, err := readString(s, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{tokenString, text, i})
+			i = end
+		case unicode.IsDigit(r) || r == '-':
+			end := i + 1
+			for end < len(s) && isLiteralChar(rune(s[end])) {
+				end++
+			}
+
+			tokens = append(tokens, token{tokenLiteral, s[i:end], i})
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(s) && isWordChar(rune(s[end])) {
+				end++
+			}
+
+			tokens = append(tokens, token{tokenWord, s[i:end], i})
+			i = end
+		default:
+			return nil, errorf("unexpected %q at position %d", r, i)
+		}
+	}
+
+	return append(tokens, token{tokenEnd, "", len(s)}), nil
+}
+
+// readString reads the quoted string starting at s[start], where two quotes stand for one.
+func readString(s string, start int) (text string, end int, err error) {
+	var b strings.Builder
+
+	for i := start + 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			b.WriteByte(s[i])
+
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+
+			continue
+		}
+
+		return b.String(), i + 1, nil
+	}
+
+	return "", 0, errorf("unterminated string at position %d", start)
+}
+
+func isLiteralChar(r rune) bool {
+	return unicode.IsDigit(r) || unicode.IsLetter(r) || strings.ContainsRune(":.+-", r)
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_'
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+
+	return t
+}
+
+// keyword reports whether the next token is the word w, which is matched case-insensitively.
+func (p *parser) keyword(w string) bool {
+	t := p.peek()
+
+	return t.kind == tokenWord && strings.EqualFold(t.text, w)
+}
+
+func (p *parser) expect(kind tokenKind, what string) error {
+	if t := p.next(); t.kind != kind {
+		return unexpected(t, what)
+	}
+
+	return nil
+}
+
+func unexpected(t token, expected string) error {
+	if t.kind == tokenEnd {
+		return errorf("expected %s at the end of the filter", expected)
+	}
+
+	return errorf("expected %s at position %d, found %q", expected, t.pos, t.text)
+}
+
+func parseFilter(filter string) (Node, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, unexpected(t, "and or or")
+	}
+
+	return n, nil
+}
+
+func (p *parser) or() (Node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("or") {
+		p.next()
+
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) and() (Node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("and") {
+		p.next()
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) unary() (Node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+
+	if p.depth > maxDepth {
+		return nil, errorf("the filter is nested too deeply")
+	}
+
+	if p.keyword("not") {
+		p.next()
+
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not{Operand: operand}, nil
+	}
+
+	if p.peek().kind == tokenOpen {
+		p.next()
+
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenClose, "')'"); err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	}
+
+	return p.comparison()
+}
+
+func (p *parser) comparison() (Node, error) {
+	t := p.next()
+	if t.kind != tokenWord || isKeyword(t.text) {
+		return nil, unexpected(t, "a property")
+	}
+
+	// contains(property,'value') and the like
+	if op, ok := stringFunction(t.text); ok && p.peek().kind == tokenOpen {
+		p.next()
+
+		property := p.next()
+		if property.kind != tokenWord || isKeyword(property.text) {
+			return nil, unexpected(property, "a property")
+		}
+
+		if err := p.expect(tokenComma, "','"); err != nil {
+			return nil, err
+		}
+
+		value, err := p.literal()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenClose, "')'"); err != nil {
+			return nil, err
+		}
+
+		return Comparison{Property: property.text, Operator: op, Value: value}, nil
+	}
+
+	opToken := p.next()
+
+	op, ok := operator(opToken)
+	if !ok {
+		return nil, unexpected(opToken, "an operator")
+	}
+
+	if op == In {
+		values, err := p.literals()
+		if err != nil {
+			return nil, err
+		}
+
+		return Comparison{Property: t.text, Operator: op, Value: values}, nil
+	}
+
+	value, err := p.literal()
+	if err != nil {
+		return nil, err
+	}
+
+	return Comparison{Property: t.text, Operator: op, Value: value}, nil
+}
+
+func (p *parser) literal() (interface{}, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenString:
+		return t.text, nil
+	case tokenLiteral:
+		return parseLiteral(t)
+	case tokenWord:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	case tokenEnd, tokenOpen, tokenClose, tokenComma:
+	}
+
+	return nil, unexpected(t, "a value")
+}
+
+// literals reads the parenthesized, comma separated list of values of in.
+func (p *parser) literals() ([]interface{}, error) {
+	if err := p.expect(tokenOpen, "'('"); err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0)
+
+	if p.peek().kind == tokenClose {
+		p.next()
+
+		return values, nil
+	}
+
+	for {
+		value, err := p.literal()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		if t := p.next(); t.kind != tokenComma {
+			if t.kind != tokenClose {
+				return nil, unexpected(t, "',' or ')'")
+			}
+
+			return values, nil
+		}
+	}
+}
+
+func parseLiteral(t token) (interface{}, error) {
+	if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+		return i, nil
+	}
+
+	if f, err := strconv.ParseFloat(t.text, 64); err == nil {
+		return f, nil
+	}
+
+	if d, err := time.Parse(time.DateOnly, t.text); err == nil {
+		return d, nil
+	}
+
+	if d, err := time.Parse(time.RFC3339Nano, t.text); err == nil {
+		return d, nil
+	}
+
+	return nil, errorf("invalid value %q at position %d", t.text, t.pos)
+}
+
+func operator(t token) (Operator, bool) {
+	if t.kind != tokenWord {
+		return "", false
+	}
+
+	op := Operator(strings.ToLower(t.text))
+
+	switch op {
+	case Equal, NotEqual, Greater, GreaterOrEqual, Less, LessOrEqual, Contains, StartsWith, EndsWith, In:
+		return op, true
+	}
+
+	return "", false
+}
+
+func stringFunction(name string) (Operator, bool) {
+	op := Operator(strings.ToLower(name))
+
+	switch op {
+	case Contains, StartsWith, EndsWith:
+		return op, true
+	case Equal, NotEqual, Greater, GreaterOrEqual, Less, LessOrEqual, In:
+	}
+
+	return "", false
+}
+
+// isKeyword reports whether w is reserved and cannot name a property.
+func isKeyword(w string) bool {
+	switch strings.ToLower(w) {
+	case "and", "or", "not", "true", "false", "null", "eq", "ne", "gt", "ge", "lt", "le", "in":
+		return true
+	}
+
+	return false
+}
+
+func parseOrderBy(orderBy string) ([]Order, error) {
+	orders := make([]Order, 0)
+
+	for _, item := range strings.Split(orderBy, ",") {
+		fields := strings.Fields(item)
+		if len(fields) == 0 || len(fields) > 2 || isKeyword(fields[0]) {
+			return nil, errorf("invalid $orderby item %q", strings.TrimSpace(item))
+		}
+
+		for _, r := range fields[0] {
+			if !isWordChar(r) {
+				return nil, errorf("invalid $orderby property %q", fields[0])
+			}
+		}
+
+		order := Order{Property: fields[0]}
+
+		if len(fields) == 2 {
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+			case "desc":
+				order.Descending = true
+			default:
+				return nil, errorf("invalid $orderby direction %q", fields[1])
+			}
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
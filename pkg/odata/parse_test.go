@@ -0,0 +1,127 @@
+package odata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy string
+		query   odata.Query
+	}{
+		{
+			name:  "empty",
+			query: odata.Query{},
+		},
+		{
+			name:   "infix string function and date",
+			filter: "hostname startswith 'lab' and lastSeen lt 2026-01-01",
+			query: odata.Query{Filter: odata.And{
+				Left:  odata.Comparison{Property: "hostname", Operator: odata.StartsWith, Value: "lab"},
+				Right: odata.Comparison{Property: "lastSeen", Operator: odata.Less, Value: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+		},
+		{
+			name:   "function call, or binds looser than and",
+			filter: "contains(friendlyName,'it''s') or useTLS eq true and mpsPort ge -1.5",
+			query: odata.Query{Filter: odata.Or{
+				Left: odata.Comparison{Property: "friendlyName", Operator: odata.Contains, Value: "it's"},
+				Right: odata.And{
+					Left:  odata.Comparison{Property: "useTLS", Operator: odata.Equal, Value: true},
+					Right: odata.Comparison{Property: "mpsPort", Operator: odata.GreaterOrEqual, Value: -1.5},
+				},
+			}},
+		},
+		{
+			name:    "not, parentheses, null and order",
+			filter:  "NOT (certHash EQ null or tlsMode ne 2)",
+			orderBy: "hostname desc, guid asc,tags",
+			query: odata.Query{
+				Filter: odata.Not{Operand: odata.Or{
+					Left:  odata.Comparison{Property: "certHash", Operator: odata.Equal, Value: nil},
+					Right: odata.Comparison{Property: "tlsMode", Operator: odata.NotEqual, Value: int64(2)},
+				}},
+				OrderBy: []odata.Order{{Property: "hostname", Descending: true}, {Property: "guid"}, {Property: "tags"}},
+			},
+		},
+		{
+			name:   "date-time",
+			filter: "expirationDate gt 2026-01-01T08:00:00.5Z",
+			query: odata.Query{Filter: odata.Comparison{
+				Property: "expirationDate", Operator: odata.Greater, Value: time.Date(2026, 1, 1, 8, 0, 0, 500000000, time.UTC),
+			}},
+		},
+		{
+			name:   "in",
+			filter: "guid in ('a', 'b') or tlsMode in ()",
+			query: odata.Query{Filter: odata.Or{
+				Left:  odata.Comparison{Property: "guid", Operator: odata.In, Value: []interface{}{"a", "b"}},
+				Right: odata.Comparison{Property: "tlsMode", Operator: odata.In, Value: []interface{}{}},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := odata.Parse(tc.filter, tc.orderBy)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.query, query)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy string
+	}{
+		{name: "missing value", filter: "hostname eq"},
+		{name: "unknown operator", filter: "hostname like 'a'"},
+		{name: "unterminated string", filter: "hostname eq 'a"},
+		{name: "unbalanced parentheses", filter: "(hostname eq 'a'"},
+		{name: "trailing tokens", filter: "hostname eq 'a' 'b'"},
+		{name: "keyword as property", filter: "and eq 'a'"},
+		{name: "invalid literal", filter: "lastSeen lt 2026-13-45"},
+		{name: "unexpected character", filter: "hostname eq 'a'; DROP TABLE devices"},
+		{name: "nested too deeply", filter: "not not not not not not not not not not not not not not not not not not not not not not not not not not not not not not not not hostname eq 'a'"},
+		{name: "in without a list", filter: "guid in 'a'"},
+		{name: "unterminated in", filter: "guid in ('a' 'b')"},
+		{name: "invalid direction", orderBy: "hostname up"},
+		{name: "invalid property", orderBy: "hostname; DROP TABLE devices"},
+		{name: "empty item", orderBy: "hostname,"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := odata.Parse(tc.filter, tc.orderBy)
+			require.ErrorAs(t, err, &odata.Error{})
+		})
+	}
+}
+
+func TestProperties(t *testing.T) {
+	t.Parallel()
+
+	q, err := odata.Parse("not (owner eq 'ops' or rack gt 3) and hostname ne null", "warrantyEnd desc")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"owner", "rack", "hostname", "warrantyEnd"}, q.Properties())
+	require.Empty(t, odata.Query{}.Properties())
+}
@@ -0,0 +1,137 @@
+// Package odata parses the subset of the OData $filter and $orderby query options the console supports and
+// translates them into squirrel conditions.
+//
+// Filters compare a property with a literal using eq, ne, gt, ge, lt and le, match strings with contains,
+// startswith and endswith, either as functions, contains(hostname,'lab'), or infix, hostname contains 'lab',
+// test a property for one of several literals with in, guid in ('a','b'), and combine those with and, or, not
+// and parentheses. Literals are single-quoted strings, in which a quote is
+// doubled, numbers, true, false, null, dates such as 2026-01-01 and RFC 3339 date-times.
+package odata
+
+import "fmt"
+
+// Operator compares a property with a literal.
+type Operator string
+
+const (
+	Equal          Operator = "eq"
+	NotEqual       Operator = "ne"
+	Greater        Operator = "gt"
+	GreaterOrEqual Operator = "ge"
+	Less           Operator = "lt"
+	LessOrEqual    Operator = "le"
+	Contains       Operator = "contains"
+	StartsWith     Operator = "startswith"
+	EndsWith       Operator = "endswith"
+	In             Operator = "in"
+)
+
+// Node is a node of a parsed $filter: a Comparison, And, Or or Not.
+type Node interface {
+	node()
+}
+
+// Comparison compares Property with Value, which is a string, int64, float64, bool, time.Time or nil for null,
+// or a []interface{} of those for In.
+type Comparison struct {
+	Property string
+	Operator Operator
+	Value    interface{}
+}
+
+// And matches when both sides match.
+type And struct {
+	Left, Right Node
+}
+
+// Or matches when either side matches.
+type Or struct {
+	Left, Right Node
+}
+
+// Not matches when its operand does not.
+type Not struct {
+	Operand Node
+}
+
+func (Comparison) node() {}
+func (And) node()        {}
+func (Or) node()         {}
+func (Not) node()        {}
+
+// Order is a property of $orderby.
+type Order struct {
+	Property   string
+	Descending bool
+}
+
+// Query is a parsed $filter and $orderby. The zero value matches everything in the default order.
+type Query struct {
+	Filter  Node
+	OrderBy []Order
+}
+
+// Properties returns the properties q filters and orders by, in the order they appear.
+func (q Query) Properties() []string {
+	var properties []string
+
+	var walk func(n Node)
+
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case Comparison:
+			properties = append(properties, n.Property)
+		case And:
+			walk(n.Left)
+			walk(n.Right)
+		case Or:
+			walk(n.Left)
+			walk(n.Right)
+		case Not:
+			walk(n.Operand)
+		}
+	}
+
+	walk(q.Filter)
+
+	for _, o := range q.OrderBy {
+		properties = append(properties, o.Property)
+	}
+
+	return properties
+}
+
+// Error reports a $filter or $orderby that cannot be parsed or does not fit the properties of a list.
+type Error struct {
+	Message string
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+func errorf(format string, args ...interface{}) error {
+	return Error{Message: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses the $filter and $orderby query options, either of which may be empty.
+func Parse(filter, orderBy string) (Query, error) {
+	var (
+		q   Query
+		err error
+	)
+
+	if filter != "" {
+		if q.Filter, err = parseFilter(filter); err != nil {
+			return Query{}, err
+		}
+	}
+
+	if orderBy != "" {
+		if q.OrderBy, err = parseOrderBy(orderBy); err != nil {
+			return Query{}, err
+		}
+	}
+
+	return q, nil
+}
@@ -0,0 +1,313 @@
+package odata
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Type is the type of the column behind a property. It decides the operators and literals a filter can use.
+type Type int
+
+const (
+	// String columns support every operator with string literals.
+	String Type = iota
+	// Number columns support eq, ne, gt, ge, lt and le with numbers.
+	Number
+	// Bool columns support eq and ne with true and false.
+	Bool
+	// Time columns hold timestamps in UTC in the layout of their field and support eq, ne, gt, ge, lt and le with
+	// dates and date-times.
+	Time
+	// List columns hold comma separated values such as tags. eq and ne test for one of the values and
+	// contains matches part of any of them.
+	List
+)
+
+// Field maps a property to a column. NotNull columns never hold null, which spares a Keyset ordering nulls.
+// Layout is the time layout the timestamps of a Time column are stored in, RFC 3339 when blank. Literals are
+// formatted in it, so that they compare with the stored timestamps as text the way the times themselves do.
+type Field struct {
+	Column  string
+	Type    Type
+	NotNull bool
+	Layout  string
+}
+
+// format formats t the way the timestamps of the column of f are stored.
+func (f Field) format(t time.Time) string {
+	if f.Layout == "" {
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return t.UTC().Format(f.Layout)
+}
+
+// Fields are the properties of a list that can be filtered and ordered by. Only their columns ever make it
+// into the SQL, every literal is passed as an argument.
+type Fields map[string]Field
+
+// lookup finds the field of a property, ignoring case.
+func (f Fields) lookup(property string) (Field, error) {
+	if field, ok := f[property]; ok {
+		return field, nil
+	}
+
+	for name, field := range f {
+		if strings.EqualFold(name, property) {
+			return field, nil
+		}
+	}
+
+	return Field{}, errorf("unknown property %q", property)
+}
+
+// Has reports whether property is one of the fields, ignoring case.
+func (f Fields) Has(property string) bool {
+	_, err := f.lookup(property)
+
+	return err == nil
+}
+
+// Where adds the $filter of q to b.
+func (f Fields) Where(b squirrel.SelectBuilder, q Query) (squirrel.SelectBuilder, error) {
+	if q.Filter == nil {
+		return b, nil
+	}
+
+	cond, err := f.condition(q.Filter)
+	if err != nil {
+		return b, err
+	}
+
+	return b.Where(cond), nil
+}
+
+// OrderBy adds the $orderby of q to b, followed by the given columns, which keep the order stable.
+func (f Fields) OrderBy(b squirrel.SelectBuilder, q Query, columns ...string) (squirrel.SelectBuilder, error) {
+	orderBy := make([]string, 0, len(q.OrderBy)+len(columns))
+
+	for _, o := range q.OrderBy {
+		field, err := f.lookup(o.Property)
+		if err != nil {
+			return b, err
+		}
+
+		if o.Descending {
+			orderBy = append(orderBy, field.Column+" DESC")
+		} else {
+			orderBy = append(orderBy, field.Column)
+		}
+	}
+
+	return b.OrderBy(append(orderBy, columns...)...), nil
+}
+
+func (f Fields) condition(n Node) (squirrel.Sqlizer, error) {
+	switch n := n.(type) {
+	case And:
+		left, right, err := f.conditions(n.Left, n.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		return squirrel.And{left, right}, nil
+	case Or:
+		left, right, err := f.conditions(n.Left, n.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		return squirrel.Or{left, right}, nil
+	case Not:
+		operand, err := f.condition(n.Operand)
+		if err != nil {
+			return nil, err
+		}
+
+		return not{operand}, nil
+	case Comparison:
+		return f.comparison(n)
+	}
+
+	return nil, errorf("unsupported filter")
+}
+
+func (f Fields) conditions(left, right Node) (l, r squirrel.Sqlizer, err error) {
+	if l, err = f.condition(left); err != nil {
+		return nil, nil, err
+	}
+
+	if r, err = f.condition(right); err != nil {
+		return nil, nil, err
+	}
+
+	return l, r, nil
+}
+
+//nolint:cyclop // one case per column type
+func (f Fields) comparison(c Comparison) (squirrel.Sqlizer, error) {
+	field, err := f.lookup(c.Property)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Operator == In {
+		return f.in(field, c)
+	}
+
+	if c.Value == nil {
+		switch c.Operator {
+		case Equal:
+			return squirrel.Eq{field.Column: nil}, nil
+		case NotEqual:
+			return squirrel.NotEq{field.Column: nil}, nil
+		case Greater, GreaterOrEqual, Less, LessOrEqual, Contains, StartsWith, EndsWith, In:
+		}
+
+		return nil, errorf("%s cannot be compared with null using %s", c.Property, c.Operator)
+	}
+
+	var value interface{}
+
+	switch field.Type {
+	case String:
+		if s, ok := c.Value.(string); ok {
+			return stringComparison(field.Column, c.Operator, s), nil
+		}
+	case List:
+		if s, ok := c.Value.(string); ok && (c.Operator == Equal || c.Operator == NotEqual || c.Operator == Contains) {
+			return listComparison(field.Column, c.Operator, s), nil
+		}
+	case Number:
+		switch c.Value.(type) {
+		case int64, float64:
+			value = c.Value
+		}
+	case Bool:
+		if _, ok := c.Value.(bool); ok && (c.Operator == Equal || c.Operator == NotEqual) {
+			value = c.Value
+		}
+	case Time:
+		if t, ok := c.Value.(time.Time); ok {
+			value = field.format(t)
+		}
+	}
+
+	if value != nil {
+		if cond, ok := compare(field.Column, c.Operator, value); ok {
+			return cond, nil
+		}
+	}
+
+	return nil, errorf("%s cannot be compared with %v using %s", c.Property, c.Value, c.Operator)
+}
+
+// in matches any of the values of c, which matches nothing when there are none.
+func (f Fields) in(field Field, c Comparison) (squirrel.Sqlizer, error) {
+	values, ok := c.Value.([]interface{})
+	if !ok {
+		return nil, errorf("%s in needs a list of values", c.Property)
+	}
+
+	if field.Type == String {
+		strs := make([]string, 0, len(values))
+
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+
+		if len(strs) == len(values) {
+			return squirrel.Eq{field.Column: strs}, nil
+		}
+	}
+
+	anyOf := make(squirrel.Or, 0, len(values))
+
+	for _, v := range values {
+		cond, err := f.comparison(Comparison{Property: c.Property, Operator: Equal, Value: v})
+		if err != nil {
+			return nil, err
+		}
+
+		anyOf = append(anyOf, cond)
+	}
+
+	return anyOf, nil
+}
+
+// compare applies eq, ne, gt, ge, lt and le.
+func compare(column string, op Operator, value interface{}) (squirrel.Sqlizer, bool) {
+	switch op {
+	case Equal:
+		return squirrel.Eq{column: value}, true
+	case NotEqual:
+		return squirrel.NotEq{column: value}, true
+	case Greater:
+		return squirrel.Gt{column: value}, true
+	case GreaterOrEqual:
+		return squirrel.GtOrEq{column: value}, true
+	case Less:
+		return squirrel.Lt{column: value}, true
+	case LessOrEqual:
+		return squirrel.LtOrEq{column: value}, true
+	case Contains, StartsWith, EndsWith, In:
+	}
+
+	return nil, false
+}
+
+func stringComparison(column string, op Operator, value string) squirrel.Sqlizer {
+	switch op {
+	case Contains:
+		return like(column, "%"+escapeLike(value)+"%")
+	case StartsWith:
+		return like(column, escapeLike(value)+"%")
+	case EndsWith:
+		return like(column, "%"+escapeLike(value))
+	case Equal, NotEqual, Greater, GreaterOrEqual, Less, LessOrEqual, In:
+	}
+
+	cond, _ := compare(column, op, value)
+
+	return cond
+}
+
+func listComparison(column string, op Operator, value string) squirrel.Sqlizer {
+	if op == Contains {
+		return like(column, "%"+escapeLike(value)+"%")
+	}
+
+	hasValue := like("(',' || "+column+" || ',')", "%,"+escapeLike(value)+",%")
+	if op == NotEqual {
+		return squirrel.Or{squirrel.Eq{column: nil}, not{hasValue}}
+	}
+
+	return hasValue
+}
+
+func like(column, pattern string) squirrel.Sqlizer {
+	return squirrel.Expr(column+` LIKE ? ESCAPE '\'`, pattern)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+type not struct {
+	operand squirrel.Sqlizer
+}
+
+func (n not) ToSql() (sql string, args []interface{}, err error) {
+	sql, args, err = n.operand.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "NOT (" + sql + ")", args, nil
+}
@@ -0,0 +1,195 @@
+package odata_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"github.com/device-management-toolkit/console/pkg/odata"
+)
+
+var deviceFields = odata.Fields{
+	"hostname":  {Column: "hostname", Type: odata.String},
+	"tags":      {Column: "tags", Type: odata.List},
+	"useTLS":    {Column: "usetls", Type: odata.Bool},
+	"mpsPort":   {Column: "mps_port", Type: odata.Number},
+	"lastSeen":  {Column: "lastseen", Type: odata.Time},
+	"certHash":  {Column: "certhash", Type: odata.String},
+	"createdAt": {Column: "created_at", Type: odata.Time, NotNull: true},
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy string
+		sql     string
+		args    []interface{}
+	}{
+		{
+			name: "no filter",
+			sql:  "SELECT * FROM devices ORDER BY guid",
+		},
+		{
+			name:    "string and time",
+			filter:  "hostname startswith 'lab_1%' and lastseen lt 2026-01-01",
+			orderBy: "lastSeen desc",
+			sql:     `SELECT * FROM devices WHERE (hostname LIKE ? ESCAPE '\' AND lastseen < ?) ORDER BY lastseen DESC, guid`,
+			args:    []interface{}{`lab\_1\%%`, "2026-01-01T00:00:00Z"},
+		},
+		{
+			name:   "list, bool, number and null",
+			filter: "not (tags eq 'a' or tags ne 'b') and useTLS eq false and mpsPort gt 4433 and certHash ne null",
+			sql: `SELECT * FROM devices WHERE (((NOT (((',' || tags || ',') LIKE ? ESCAPE '\' OR (tags IS NULL OR NOT ((',' || tags || ',') LIKE ? ESCAPE '\')))) ` +
+				`AND usetls = ?) AND mps_port > ?) AND certhash IS NOT NULL) ORDER BY guid`,
+			args: []interface{}{"%,a,%", "%,b,%", false, int64(4433)},
+		},
+		{
+			name:   "contains and endswith",
+			filter: "contains(hostname,'x') or hostname endswith 'y' or tags contains 'z'",
+			sql:    `SELECT * FROM devices WHERE ((hostname LIKE ? ESCAPE '\' OR hostname LIKE ? ESCAPE '\') OR tags LIKE ? ESCAPE '\') ORDER BY guid`,
+			args:   []interface{}{"%x%", "%y", "%z%"},
+		},
+		{
+			name:   "in",
+			filter: "hostname in ('a','b') and tags in ('c') and mpsPort in ()",
+			sql:    `SELECT * FROM devices WHERE ((hostname IN (?,?) AND ((',' || tags || ',') LIKE ? ESCAPE '\')) AND (1=0)) ORDER BY guid`,
+			args:   []interface{}{"a", "b", "%,c,%"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := odata.Parse(tc.filter, tc.orderBy)
+			require.NoError(t, err)
+
+			b := squirrel.Select("*").From("devices")
+
+			b, err = deviceFields.Where(b, query)
+			require.NoError(t, err)
+
+			b, err = deviceFields.OrderBy(b, query, "guid")
+			require.NoError(t, err)
+
+			sql, args, err := b.ToSql()
+			require.NoError(t, err)
+			require.Equal(t, tc.sql, sql)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestFieldsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy string
+	}{
+		{name: "unknown property", filter: "password eq 'secret'"},
+		{name: "unknown order property", orderBy: "password"},
+		{name: "string compared with a number", filter: "hostname eq 5"},
+		{name: "number compared with a string", filter: "mpsPort eq '5'"},
+		{name: "bool ordered", filter: "useTLS gt true"},
+		{name: "time compared with a string", filter: "lastSeen lt 'yesterday'"},
+		{name: "list ordered", filter: "tags gt 'a'"},
+		{name: "null ordered", filter: "lastSeen lt null"},
+		{name: "number matched", filter: "contains(mpsPort,'4')"},
+		{name: "in with a value of another type", filter: "mpsPort in (1, 'a')"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := odata.Parse(tc.filter, tc.orderBy)
+			require.NoError(t, err)
+
+			b := squirrel.Select("*").From("devices")
+
+			if _, err = deviceFields.Where(b, query); err == nil {
+				_, err = deviceFields.OrderBy(b, query)
+			}
+
+			require.ErrorAs(t, err, &odata.Error{})
+		})
+	}
+}
+
+func TestFieldsTimeLayout(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.ExecContext(context.Background(), `CREATE TABLE entries (id TEXT NOT NULL, written TEXT, logged TEXT)`)
+	require.NoError(t, err)
+
+	// written is stored the way the driver stores time.Time, logged with milliseconds in a fixed width
+	for id, at := range map[string]time.Time{
+		"a": time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+		"b": time.Date(2026, 1, 1, 10, 0, 0, 250000000, time.UTC),
+		"c": time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+	} {
+		_, err = dbConn.ExecContext(context.Background(), `INSERT INTO entries VALUES (?, ?, ?)`, id, at, at.Format("2006-01-02T15:04:05.000Z"))
+		require.NoError(t, err)
+	}
+
+	fields := odata.Fields{
+		"written": {Column: "written", Type: odata.Time, Layout: "2006-01-02 15:04:05.999999999 -0700 MST"},
+		"logged":  {Column: "logged", Type: odata.Time, Layout: "2006-01-02T15:04:05.000Z"},
+	}
+
+	tests := []struct {
+		filter string
+		ids    []string
+	}{
+		{filter: "written ge 2026-01-01", ids: []string{"a", "b"}},
+		{filter: "written eq 2026-01-01T10:00:00Z", ids: []string{"a"}},
+		{filter: "written gt 2026-01-01T10:00:00Z", ids: []string{"b"}},
+		{filter: "written lt 2026-01-01T10:00:00.1Z", ids: []string{"a", "c"}},
+		{filter: "logged ge 2026-01-01T10:00:00Z", ids: []string{"a", "b"}},
+		{filter: "logged le 2026-01-01T10:00:00Z", ids: []string{"a", "c"}},
+		{filter: "logged lt 2026-01-01", ids: []string{"c"}},
+	}
+
+	for _, tc := range tests {
+		query, err := odata.Parse(tc.filter, "")
+		require.NoError(t, err)
+
+		b, err := fields.Where(squirrel.Select("id").From("entries").OrderBy("id"), query)
+		require.NoError(t, err)
+
+		sqlQuery, args, err := b.ToSql()
+		require.NoError(t, err)
+
+		rows, err := dbConn.QueryContext(context.Background(), sqlQuery, args...)
+		require.NoError(t, err)
+
+		ids := []string{}
+
+		for rows.Next() {
+			var id string
+
+			require.NoError(t, rows.Scan(&id))
+
+			ids = append(ids, id)
+		}
+
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+		require.Equal(t, tc.ids, ids, tc.filter)
+	}
+}
@@ -0,0 +1,183 @@
+// Package vault implements a client of the Vault KV version 2 secrets engine HTTP API.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	_defaultMount   = "secret"
+	_defaultTimeout = 10 * time.Second
+
+	tokenHeader     = "X-Vault-Token"
+	namespaceHeader = "X-Vault-Namespace"
+)
+
+var (
+	ErrNotFound = errors.New("secret not found in vault")
+	errRequest  = errors.New("vault request failed")
+)
+
+// Client reads and writes secrets of one KV version 2 mount.
+type Client struct {
+	address    string
+	token      string
+	mount      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// New -.
+func New(address, token string, opts ...Option) *Client {
+	c := &Client{
+		address:    strings.TrimRight(address, "/"),
+		token:      token,
+		mount:      _defaultMount,
+		httpClient: &http.Client{Timeout: _defaultTimeout},
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+type secretRequest struct {
+	Data map[string]string `json:"data"`
+}
+
+type secretResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+type listResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// Read returns the latest version of the secret at path.
+func (c *Client) Read(ctx context.Context, path string) (map[string]string, error) {
+	body, err := c.do(ctx, http.MethodGet, c.url("data", path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res secretResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("vault - Read - json.Unmarshal: %w", err)
+	}
+
+	// a deleted version is returned without data
+	if res.Data.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	return res.Data.Data, nil
+}
+
+// Write stores data as a new version of the secret at path.
+func (c *Client) Write(ctx context.Context, path string, data map[string]string) error {
+	payload, err := json.Marshal(secretRequest{Data: data})
+	if err != nil {
+		return fmt.Errorf("vault - Write - json.Marshal: %w", err)
+	}
+
+	_, err = c.do(ctx, http.MethodPost, c.url("data", path), payload)
+
+	return err
+}
+
+// Delete removes the secret at path along with all of its versions.
+func (c *Client) Delete(ctx context.Context, path string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.url("metadata", path), nil)
+
+	return err
+}
+
+// List returns the names of the secrets directly under path, folders end in a slash. A path nothing was written
+// under has no names.
+func (c *Client) List(ctx context.Context, path string) ([]string, error) {
+	body, err := c.do(ctx, http.MethodGet, c.url("metadata", path)+"?list=true", nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var res listResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("vault - List - json.Unmarshal: %w", err)
+	}
+
+	return res.Data.Keys, nil
+}
+
+func (c *Client) url(kind, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+
+	return c.address + "/v1/" + c.mount + "/" + kind + "/" + strings.Join(segments, "/")
+}
+
+func (c *Client) do(ctx context.Context, method, target string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("vault - %s - http.NewRequest: %w", method, err)
+	}
+
+	req.Header.Set(tokenHeader, c.token)
+
+	if c.namespace != "" {
+		req.Header.Set(namespaceHeader, c.namespace)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault - %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("vault - %s - io.ReadAll: %w", method, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode >= http.StatusBadRequest:
+		var res errorResponse
+
+		_ = json.Unmarshal(body, &res)
+
+		return nil, fmt.Errorf("%w: %s %s: %d %s", errRequest, method, req.URL.Path, resp.StatusCode, strings.Join(res.Errors, ", "))
+	}
+
+	return body, nil
+}
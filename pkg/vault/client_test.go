@@ -0,0 +1,91 @@
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/device-management-toolkit/console/pkg/vault/vaulttest"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	server := vaulttest.NewServer("kv", "root-token")
+	defer server.Close()
+
+	c := New(server.URL+"/", "root-token", Mount("/kv/"))
+	ctx := context.Background()
+
+	require.NoError(t, c.Write(ctx, "console/a", map[string]string{"value": "first"}))
+	require.NoError(t, c.Write(ctx, "console/a", map[string]string{"value": "second"}))
+
+	data, err := c.Read(ctx, "console/a")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"value": "second"}, data)
+
+	require.NoError(t, c.Delete(ctx, "console/a"))
+
+	_, err = c.Read(ctx, "console/a")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	assert.Equal(t, []string{
+		"POST /v1/kv/data/console/a",
+		"POST /v1/kv/data/console/a",
+		"GET /v1/kv/data/console/a",
+		"DELETE /v1/kv/metadata/console/a",
+		"GET /v1/kv/data/console/a",
+	}, server.Requests())
+}
+
+func TestClientList(t *testing.T) {
+	t.Parallel()
+
+	server := vaulttest.NewServer("kv", "root-token")
+	defer server.Close()
+
+	c := New(server.URL, "root-token", Mount("kv"))
+	ctx := context.Background()
+
+	names, err := c.List(ctx, "console")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	require.NoError(t, c.Write(ctx, "console/b", map[string]string{"value": "b"}))
+	require.NoError(t, c.Write(ctx, "console/a", map[string]string{"value": "a"}))
+	require.NoError(t, c.Write(ctx, "console/nested/c", map[string]string{"value": "c"}))
+	require.NoError(t, c.Write(ctx, "other/d", map[string]string{"value": "d"}))
+
+	names, err = c.List(ctx, "console")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "nested/"}, names)
+	assert.Contains(t, server.Requests(), "GET /v1/kv/metadata/console")
+}
+
+func TestClientPermissionDenied(t *testing.T) {
+	t.Parallel()
+
+	server := vaulttest.NewServer("secret", "root-token")
+	defer server.Close()
+
+	c := New(server.URL, "wrong-token")
+
+	err := c.Write(context.Background(), "console/a", map[string]string{"value": "first"})
+	require.ErrorIs(t, err, errRequest)
+	assert.Contains(t, err.Error(), "permission denied")
+	assert.Zero(t, server.Len())
+}
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	c := New("http://vault:8200", "token", Mount("kv/"), Namespace("it"), Timeout(time.Second))
+
+	assert.Equal(t, "kv", c.mount)
+	assert.Equal(t, "it", c.namespace)
+	assert.Equal(t, time.Second, c.httpClient.Timeout)
+	assert.Equal(t, "http://vault:8200/v1/kv/data/console/a%20b", c.url("data", "/console/a b"))
+}
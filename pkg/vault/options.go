@@ -0,0 +1,30 @@
+package vault
+
+import (
+	"strings"
+	"time"
+)
+
+// Option -.
+type Option func(*Client)
+
+// Mount sets the path the KV version 2 engine is mounted at, "secret" by default.
+func Mount(mount string) Option {
+	return func(c *Client) {
+		c.mount = strings.Trim(mount, "/")
+	}
+}
+
+// Namespace sets the namespace of Vault Enterprise the mount belongs to.
+func Namespace(namespace string) Option {
+	return func(c *Client) {
+		c.namespace = namespace
+	}
+}
+
+// Timeout -.
+func Timeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
@@ -0,0 +1,179 @@
+// Package vaulttest provides a stand-in for the Vault KV version 2 HTTP API, it keeps the secrets in memory.
+package vaulttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Server serves one KV version 2 mount and accepts a single token.
+type Server struct {
+	*httptest.Server
+
+	mount string
+	token string
+
+	mu       sync.Mutex
+	secrets  map[string][]map[string]string
+	requests []string
+}
+
+// NewServer starts a server for the mount, it must be closed when done.
+func NewServer(mount, token string) *Server {
+	s := &Server{
+		mount:   strings.Trim(mount, "/"),
+		token:   token,
+		secrets: make(map[string][]map[string]string),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// Secret returns the latest version of the secret at path.
+func (s *Server) Secret(path string) (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions, ok := s.secrets[path]
+	if !ok {
+		return nil, false
+	}
+
+	return versions[len(versions)-1], true
+}
+
+// Len returns the number of secrets stored.
+func (s *Server) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.secrets)
+}
+
+// Requests returns the requests served so far as "METHOD path", like an audit device would record them.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if r.Header.Get("X-Vault-Token") != s.token {
+		writeJSON(w, http.StatusForbidden, map[string][]string{"errors": {"permission denied"}})
+
+		return
+	}
+
+	rest, ok := strings.CutPrefix(r.URL.Path, "/v1/"+s.mount+"/")
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errors": {}})
+
+		return
+	}
+
+	kind, path, _ := strings.Cut(rest, "/")
+
+	switch {
+	case kind == "data" && r.Method == http.MethodGet:
+		s.read(w, path)
+	case kind == "data" && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		s.write(w, r, path)
+	case kind == "metadata" && r.Method == http.MethodGet && r.URL.Query().Get("list") == "true":
+		s.list(w, path)
+	case kind == "metadata" && r.Method == http.MethodDelete:
+		delete(s.secrets, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, map[string][]string{"errors": {"unsupported operation"}})
+	}
+}
+
+func (s *Server) read(w http.ResponseWriter, path string) {
+	versions, ok := s.secrets[path]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errors": {}})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{
+			"data":     versions[len(versions)-1],
+			"metadata": map[string]int{"version": len(versions)},
+		},
+	})
+}
+
+// list answers with the secrets and folders directly under path like Vault does, or 404 when there are none.
+func (s *Server) list(w http.ResponseWriter, path string) {
+	prefix := strings.Trim(path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	keys := make([]string, 0)
+
+	for stored := range s.secrets {
+		rest, ok := strings.CutPrefix(stored, prefix)
+		if !ok {
+			continue
+		}
+
+		if folder, _, nested := strings.Cut(rest, "/"); nested {
+			rest = folder + "/"
+		}
+
+		if !slices.Contains(keys, rest) {
+			keys = append(keys, rest)
+		}
+	}
+
+	if len(keys) == 0 {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errors": {}})
+
+		return
+	}
+
+	slices.Sort(keys)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string][]string{"keys": keys},
+	})
+}
+
+func (s *Server) write(w http.ResponseWriter, r *http.Request, path string) {
+	var req struct {
+		Data map[string]string `json:"data"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Data == nil {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"errors": {"no data provided"}})
+
+		return
+	}
+
+	s.secrets[path] = append(s.secrets[path], req.Data)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]int{"version": len(s.secrets[path])},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}